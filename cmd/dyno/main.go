@@ -5,8 +5,18 @@ import (
 	"os"
 
 	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/app/commands/clone"
+	"github.com/Mad-Pixels/go-dyno/internal/app/commands/cost"
 	"github.com/Mad-Pixels/go-dyno/internal/app/commands/generate"
+	"github.com/Mad-Pixels/go-dyno/internal/app/commands/heatmap"
+	"github.com/Mad-Pixels/go-dyno/internal/app/commands/importer"
+	"github.com/Mad-Pixels/go-dyno/internal/app/commands/migrate"
+	"github.com/Mad-Pixels/go-dyno/internal/app/commands/scaffold"
+	"github.com/Mad-Pixels/go-dyno/internal/app/commands/selftest"
+	"github.com/Mad-Pixels/go-dyno/internal/app/commands/stats"
 	"github.com/Mad-Pixels/go-dyno/internal/app/commands/validate"
+	"github.com/Mad-Pixels/go-dyno/internal/app/commands/wizard"
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
 	"github.com/Mad-Pixels/go-dyno/internal/logger"
 
 	"github.com/rs/zerolog"
@@ -15,6 +25,13 @@ import (
 
 func init() {
 	logger.Init()
+
+	// -v is used for verbosity.
+	cli.VersionFlag = &cli.BoolFlag{
+		Name:               "version",
+		Usage:              "print the version",
+		DisableDefaultText: true,
+	}
 }
 
 func main() {
@@ -22,10 +39,25 @@ func main() {
 		Name:    godyno.Name,
 		Usage:   godyno.Usage,
 		Version: godyno.Version,
+		Before:  setVerbosity,
 
+		UseShortOptionHandling: true,
+		Flags: []cli.Flag{
+			flags.GlobalQuiet.Object,
+			flags.GlobalVerbose.Object,
+		},
 		Commands: []*cli.Command{
+			wizard.Command(),
+			importer.Command(),
 			generate.Command(),
 			validate.Command(),
+			selftest.Command(),
+			scaffold.Command(),
+			stats.Command(),
+			cost.Command(),
+			heatmap.Command(),
+			clone.Command(),
+			migrate.Command(),
 		},
 	}
 
@@ -35,5 +67,19 @@ func main() {
 		} else {
 			logger.Log.Error().Msg(err.Error())
 		}
+		os.Exit(1)
+	}
+}
+
+// setVerbosity applies -q/-v/-vv over the GODYNO_LOG_LEVEL default.
+func setVerbosity(ctx *cli.Context) error {
+	switch verbose := ctx.Count(flags.GlobalVerbose.GetName()); {
+	case ctx.Bool(flags.GlobalQuiet.GetName()):
+		logger.SetLevel(zerolog.ErrorLevel)
+	case verbose == 1:
+		logger.SetLevel(zerolog.DebugLevel)
+	case verbose > 1:
+		logger.SetLevel(zerolog.TraceLevel)
 	}
+	return nil
 }
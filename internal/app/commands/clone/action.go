@@ -0,0 +1,100 @@
+package clone
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
+	"github.com/Mad-Pixels/go-dyno/internal/awsclient"
+	"github.com/Mad-Pixels/go-dyno/internal/generator"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/anonymize"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/clone"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/urfave/cli/v2"
+)
+
+func action(ctx *cli.Context) error {
+	var (
+		outputRaw  = ctx.String(flags.LocalOutputFormat.GetName())
+		schemaPath = ctx.String(flags.LocalSchema.GetName())
+		source     = ctx.String(flags.LocalTable.GetName())
+		target     = ctx.String(flags.LocalTargetTable.GetName())
+		anonymized = ctx.Bool(flags.LocalAnonymize.GetName())
+		salt       = ctx.String(flags.LocalSalt.GetName())
+		rate       = ctx.Int(flags.LocalRate.GetName())
+		opts       = awsclient.Options{
+			Endpoint: ctx.String(flags.LocalEndpoint.GetName()),
+			Region:   ctx.String(flags.LocalRegion.GetName()),
+		}
+	)
+	format, err := output.Parse(outputRaw)
+	if err != nil {
+		return err
+	}
+	g, err := generator.NewGenerator(schemaPath)
+	if err != nil {
+		return err
+	}
+	if source == "" {
+		source = g.TableName()
+	}
+
+	cloneOpts := clone.Options{Source: source, Target: target, Rate: rate}
+	if anonymized {
+		cloneOpts.Anonymizer = anonymize.New(g.Schema(), salt)
+		fields := cloneOpts.Anonymizer.Fields()
+		if len(fields) == 0 {
+			return logger.NewFailure("schema declares no attributes to anonymize", nil).
+				With("schema", schemaPath).
+				With("hint", `set "anonymize": "hash" | "faker" | "null" on PII attributes`)
+		}
+		if salt == "" {
+			logger.Log.Warn().Msg("Anonymizing without --" + flags.LocalSalt.GetName() + ": hashed values of low-entropy attributes can be guessed")
+		}
+		logger.Log.Info().
+			Strs("attributes", fields).
+			Msg("Anonymizing attributes")
+	}
+	logger.Log.Debug().
+		Str("source", source).
+		Str("target", target).
+		Int("rate", rate).
+		Str("endpoint", opts.Endpoint).
+		Msg("Starting table clone")
+
+	client, err := awsclient.DynamoDB(ctx.Context, opts)
+	if err != nil {
+		return err
+	}
+	// ItemCount is refreshed about every 6 hours, good enough for a progress bar.
+	var total int
+	if described, err := client.DescribeTable(ctx.Context, &dynamodb.DescribeTableInput{TableName: aws.String(source)}); err == nil {
+		total = int(aws.ToInt64(described.Table.ItemCount))
+	}
+	var (
+		progress = logger.NewProgress("clone", total)
+		reported int
+	)
+	cloneOpts.Progress = func(written int) {
+		progress.Add(written-reported, target)
+		reported = written
+	}
+	res, err := clone.Run(ctx.Context, client, cloneOpts)
+	if err != nil {
+		return err
+	}
+	progress.Done()
+
+	if format.IsJSON() {
+		return output.Print(res)
+	}
+	logger.Log.Info().
+		Str("source", source).
+		Str("target", target).
+		Int("written", res.Written).
+		Int("anonymized", res.Anonymized).
+		Int("retries", res.Retries).
+		Msg("Table cloned")
+	return nil
+}
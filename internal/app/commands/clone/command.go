@@ -0,0 +1,66 @@
+// Package clone provides the 'clone' CLI command: copy a live table, optionally anonymizing PII.
+package clone
+
+import (
+	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	name  = "clone"
+	usage = "copy items of a live table into another table, anonymizing PII attributes"
+)
+
+type tmplUsage struct {
+	Command   string
+	EnvPrefix string
+
+	FlagSchemaPath  string
+	FlagTable       string
+	FlagTargetTable string
+	FlagAnonymize   string
+	FlagSalt        string
+	FlagRate        string
+	FlagEndpoint    string
+}
+
+// Command entrypoint.
+func Command() *cli.Command {
+	usageText := tmpl.MustParseTemplateToString(
+		usageTemplate,
+		tmplUsage{
+			Command:   name,
+			EnvPrefix: godyno.EnvPrefix,
+
+			FlagSchemaPath:  flags.LocalSchema.GetName(),
+			FlagTable:       flags.LocalTable.GetName(),
+			FlagTargetTable: flags.LocalTargetTable.GetName(),
+			FlagAnonymize:   flags.LocalAnonymize.GetName(),
+			FlagSalt:        flags.LocalSalt.GetName(),
+			FlagRate:        flags.LocalRate.GetName(),
+			FlagEndpoint:    flags.LocalEndpoint.GetName(),
+		},
+	)
+
+	return &cli.Command{
+		Name:      name,
+		Usage:     usage,
+		UsageText: usageText,
+		Action:    action,
+
+		Flags: []cli.Flag{
+			flags.LocalSchema.Object,
+			flags.LocalTable.Object,
+			flags.LocalTargetTable.Object,
+			flags.LocalAnonymize.Object,
+			flags.LocalSalt.Object,
+			flags.LocalRate.Object,
+			flags.LocalEndpoint.Object,
+			flags.LocalRegion.Object,
+			flags.LocalOutputFormat.Object,
+		},
+	}
+}
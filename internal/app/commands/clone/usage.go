@@ -0,0 +1,25 @@
+package clone
+
+const usageTemplate = `
+🧬 {{.Command}} copies items of a live table into another table, e.g. production data into staging.
+
+With --{{.FlagAnonymize}} PII attributes are rewritten by the strategy declared in the schema:
+
+   {"name": "user_id", "type": "S", "anonymize": "hash"}    keyed hash, equal values stay equal
+   {"name": "email",   "type": "S", "anonymize": "faker"}   realistic fake picked by attribute name
+   {"name": "phone",   "type": "S", "anonymize": "null"}    attribute removed
+
+Replacements are derived from HMAC-SHA256 of the value keyed by --{{.FlagSalt}}: keys, indexes and
+references between tables cloned with the same salt stay consistent, composite index keys
+("user_id#type") are rebuilt from anonymized parts. Keep the salt secret, without it hashed
+emails or phones can be guessed by brute force.
+
+Writes are paced to --{{.FlagRate}} items per second and throttled batches are retried with backoff.
+The target table must exist with the same key schema.
+
+EXAMPLES:
+   $ godyno {{.Command}} -s ./users.json --{{.FlagTargetTable}} users-staging --{{.FlagAnonymize}} --{{.FlagSalt}} "$SALT"
+   $ godyno {{.Command}} -s ./users.json --{{.FlagTable}} users-prod --{{.FlagTargetTable}} users-dev --{{.FlagRate}} 500
+   $ godyno {{.Command}} -s ./users.json --{{.FlagTargetTable}} users --{{.FlagEndpoint}} http://localhost:4566
+   $ {{.EnvPrefix}}_SALT=... godyno {{.Command}} -s ./users.json --{{.FlagTargetTable}} users-staging --{{.FlagAnonymize}}
+`
@@ -0,0 +1,66 @@
+package cost
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
+	"github.com/Mad-Pixels/go-dyno/internal/generator"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/cost"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/urfave/cli/v2"
+)
+
+func action(ctx *cli.Context) error {
+	var (
+		outputRaw    = ctx.String(flags.LocalOutputFormat.GetName())
+		schemaPath   = ctx.String(flags.LocalSchema.GetName())
+		workloadPath = ctx.String(flags.LocalWorkload.GetName())
+	)
+	format, err := output.Parse(outputRaw)
+	if err != nil {
+		return err
+	}
+	g, err := generator.NewGenerator(schemaPath)
+	if err != nil {
+		return err
+	}
+	workload, err := cost.LoadWorkload(workloadPath)
+	if err != nil {
+		return err
+	}
+	estimate, err := cost.Calculate(g.Schema(), workload)
+	if err != nil {
+		return err
+	}
+
+	if format.IsJSON() {
+		return output.Print(estimate)
+	}
+	for _, p := range estimate.Patterns {
+		logger.Log.Info().
+			Str("pattern", p.Name).
+			Str("operation", p.Operation).
+			Str("target", p.Target).
+			Float64("rcu", p.RCU).
+			Float64("wcu", p.WCU).
+			Msg("Access pattern")
+	}
+	for _, c := range estimate.Capacity {
+		logger.Log.Info().
+			Str("target", c.Target).
+			Float64("rcu", c.RCU).
+			Float64("wcu", c.WCU).
+			Float64("provisionedUSD", c.Provisioned).
+			Float64("onDemandUSD", c.OnDemand).
+			Msg("Capacity")
+	}
+	for _, w := range estimate.Warnings {
+		logger.Log.Warn().Msg(w)
+	}
+	logger.Log.Info().
+		Str("table", estimate.Table).
+		Float64("provisionedUSD", estimate.Provisioned).
+		Float64("onDemandUSD", estimate.OnDemand).
+		Msg("Monthly cost estimated")
+	return nil
+}
@@ -0,0 +1,52 @@
+// Package cost provides the 'cost' CLI command: capacity and price estimate of a workload.
+package cost
+
+import (
+	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	name  = "cost"
+	usage = "estimate RCU/WCU and monthly price of a workload on a schema"
+)
+
+type tmplUsage struct {
+	Command   string
+	EnvPrefix string
+
+	FlagSchemaPath string
+	FlagWorkload   string
+	FlagOutput     string
+}
+
+// Command entrypoint.
+func Command() *cli.Command {
+	usageText := tmpl.MustParseTemplateToString(
+		usageTemplate,
+		tmplUsage{
+			Command:   name,
+			EnvPrefix: godyno.EnvPrefix,
+
+			FlagSchemaPath: flags.LocalSchema.GetName(),
+			FlagWorkload:   flags.LocalWorkload.GetName(),
+			FlagOutput:     flags.LocalOutputFormat.GetName(),
+		},
+	)
+
+	return &cli.Command{
+		Name:      name,
+		Usage:     usage,
+		UsageText: usageText,
+		Action:    action,
+
+		Flags: []cli.Flag{
+			flags.LocalSchema.Object,
+			flags.LocalWorkload.Object,
+			flags.LocalOutputFormat.Object,
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package cost
+
+const usageTemplate = `
+💰 {{.Command}} estimates the capacity and monthly price of a workload on a schema.
+
+The workload lists access patterns with their request rates:
+
+   {
+     "item_size": 2048,
+     "patterns": [
+       {"name": "get post",          "operation": "get",   "rate": 200},
+       {"name": "posts by category", "operation": "query", "hash_key": "category", "items": 20, "rate": 50},
+       {"name": "publish post",      "operation": "put",   "rate": 5}
+     ]
+   }
+
+Operations: get, query, scan, put, update, delete. Queries are planned on the table or
+the index matching "hash_key"/"range_key" (or forced with "index"); reads of KEYS_ONLY and
+INCLUDE indexes are sized by their projection, and every write is copied to each index.
+
+The estimate includes:
+  • 📖 RCU/WCU per access pattern, per table and per GSI (LSIs share table capacity)
+  • 🧾 Monthly price in provisioned mode and in on-demand mode
+  • ⚠️  Queries without a matching index and projections needing extra reads
+
+Prices default to us-east-1 Standard table class, override them with "pricing":
+{"on_demand_read_per_million", "on_demand_write_per_million", "provisioned_rcu_hour", "provisioned_wcu_hour"}.
+
+EXAMPLES:
+   $ godyno {{.Command}} -s ./posts.json --{{.FlagWorkload}} ./workload.json
+   $ godyno {{.Command}} -s ./posts.json -w ./workload.json --{{.FlagOutput}} json
+   $ {{.EnvPrefix}}_SCHEMA=./posts.json {{.EnvPrefix}}_WORKLOAD=./workload.json godyno {{.Command}}
+`
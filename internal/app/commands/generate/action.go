@@ -3,24 +3,56 @@ package generate
 import (
 	"path"
 
+	godyno "github.com/Mad-Pixels/go-dyno"
 	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
 	"github.com/Mad-Pixels/go-dyno/internal/generator"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/lock"
 	"github.com/Mad-Pixels/go-dyno/internal/generator/mode"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/module"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/verify"
 	"github.com/Mad-Pixels/go-dyno/internal/logger"
 	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/fs"
 	"github.com/Mad-Pixels/go-dyno/internal/utils/writer"
 
 	"github.com/urfave/cli/v2"
 )
 
+type result struct {
+	Schema   string   `json:"schema"`
+	Table    string   `json:"table"`
+	Package  string   `json:"package"`
+	Mode     string   `json:"mode"`
+	Files    []string `json:"files"`
+	Warnings []string `json:"warnings"`
+	Skipped  bool     `json:"skipped"`
+}
+
 func action(ctx *cli.Context) error {
 	var (
-		schemaPath       = ctx.String(flags.LocalSchema.GetName())
-		outputPath       = ctx.String(flags.LocalOutputDir.GetName())
-		modeRaw          = ctx.String(flags.LocalGenerateMode.GetName())
-		withStreamEvents = ctx.Bool(flags.LocalWithStreamEvents.GetName())
+		outputRaw         = ctx.String(flags.LocalOutputFormat.GetName())
+		schemaPath        = ctx.String(flags.LocalSchema.GetName())
+		outputPath        = ctx.String(flags.LocalOutputDir.GetName())
+		modeRaw           = ctx.String(flags.LocalGenerateMode.GetName())
+		workers           = ctx.Int(flags.LocalWorkers.GetName())
+		force             = ctx.Bool(flags.LocalForce.GetName())
+		verifyBuild       = ctx.Bool(flags.LocalVerifyBuild.GetName())
+		initModule        = ctx.String(flags.LocalInitModule.GetName())
+		withStreamEvents  = ctx.Bool(flags.LocalWithStreamEvents.GetName())
+		withLogging       = ctx.Bool(flags.LocalWithLogging.GetName())
+		withChaos         = ctx.Bool(flags.LocalWithChaos.GetName())
+		withBreaker       = ctx.Bool(flags.LocalWithCircuitBreaker.GetName())
+		withPropertyTests = ctx.Bool(flags.LocalWithPropertyTests.GetName())
+		withFuzzTests     = ctx.Bool(flags.LocalWithFuzzTests.GetName())
+		withDoc           = ctx.Bool(flags.LocalWithDoc.GetName())
+		noScan            = ctx.Bool(flags.LocalNoScan.GetName())
 	)
 
+	format, err := output.Parse(outputRaw)
+	if err != nil {
+		return err
+	}
 	m, err := mode.ParseMode(modeRaw)
 	if err != nil {
 		return err
@@ -30,15 +62,86 @@ func action(ctx *cli.Context) error {
 		Str("schema", schemaPath).
 		Str("output", outputPath).
 		Str("mode", m.String()).
+		Int("workers", workers).
+		Bool("force", force).
+		Bool("verifyBuild", verifyBuild).
+		Str("initModule", initModule).
 		Bool("withStreamEvents", withStreamEvents).
+		Bool("withLogging", withLogging).
+		Bool("withChaos", withChaos).
+		Bool("withCircuitBreaker", withBreaker).
+		Bool("withPropertyTests", withPropertyTests).
+		Bool("withFuzzTests", withFuzzTests).
+		Bool("withDoc", withDoc).
+		Bool("noScan", noScan).
 		Msg("Starting code generation")
 
-	g, err := generator.NewGenerator(schemaPath)
+	if initModule != "" {
+		if outputPath == "" {
+			return logger.NewFailure("module scaffolding requires an output directory", nil).
+				With("flag", flags.LocalOutputDir.GetName())
+		}
+		if err := module.CheckPath(initModule); err != nil {
+			return err
+		}
+	}
+
+	if fs.IsDir(schemaPath) {
+		return generateDir(ctx, schemaPath, outputPath, m, format, workers)
+	}
+	if format.IsJSON() && outputPath == "" {
+		return logger.NewFailure("json output requires an output directory, stdout is used for the result", nil).
+			With("flag", flags.LocalOutputDir.GetName())
+	}
+
+	var l *lock.Lock
+	if outputPath != "" {
+		if l, err = lock.Load(outputPath, godyno.Version); err != nil {
+			return err
+		}
+	}
+	res, err := render(ctx, schemaPath, outputPath, m, l)
 	if err != nil {
 		return err
 	}
+	if l != nil {
+		if err := l.Save(); err != nil {
+			return err
+		}
+	}
+	if initModule != "" {
+		files, err := module.Scaffold(outputPath, initModule)
+		if err != nil {
+			return err
+		}
+		res.Files = append(res.Files, files...)
+	}
+	if format.IsJSON() {
+		return output.Print(res)
+	}
+	return nil
+}
+
+// render generates code for a single schema and writes it to outputPath (stdout if empty).
+// If l is set, the schema is skipped when it's up to date with the lock (unless --force).
+func render(ctx *cli.Context, schemaPath, outputPath string, m mode.Mode, l *lock.Lock) (result, error) {
+	var (
+		force             = ctx.Bool(flags.LocalForce.GetName())
+		withLogging       = ctx.Bool(flags.LocalWithLogging.GetName())
+		withChaos         = ctx.Bool(flags.LocalWithChaos.GetName())
+		withBreaker       = ctx.Bool(flags.LocalWithCircuitBreaker.GetName())
+		withPropertyTests = ctx.Bool(flags.LocalWithPropertyTests.GetName())
+		withFuzzTests     = ctx.Bool(flags.LocalWithFuzzTests.GetName())
+		withDoc           = ctx.Bool(flags.LocalWithDoc.GetName())
+		noScan            = ctx.Bool(flags.LocalNoScan.GetName())
+	)
+
+	g, err := generator.NewGenerator(schemaPath)
+	if err != nil {
+		return result{}, err
+	}
 	if err := g.Validate(); err != nil {
-		return err
+		return result{}, err
 	}
 
 	builder := g.NewRenderBuilder().
@@ -75,6 +178,110 @@ func action(ctx *cli.Context) error {
 			Str("flag", flags.LocalWithStreamEvents.GetName()).
 			Msg("Stream events option overridden vai CLI flag")
 	}
+	if ctx.IsSet(flags.LocalWithLogging.GetName()) {
+		builder.WithLogging(withLogging)
+		logger.Log.Debug().
+			Str("flag", flags.LocalWithLogging.GetName()).
+			Msg("Logging option overridden via CLI flag")
+	}
+	if ctx.IsSet(flags.LocalWithChaos.GetName()) {
+		builder.WithChaos(withChaos)
+		logger.Log.Debug().
+			Str("flag", flags.LocalWithChaos.GetName()).
+			Msg("Chaos option overridden via CLI flag")
+	}
+	if ctx.IsSet(flags.LocalWithCircuitBreaker.GetName()) {
+		builder.WithCircuitBreaker(withBreaker)
+		logger.Log.Debug().
+			Str("flag", flags.LocalWithCircuitBreaker.GetName()).
+			Msg("Circuit breaker option overridden via CLI flag")
+	}
+	if ctx.IsSet(flags.LocalWithPropertyTests.GetName()) {
+		builder.WithPropertyTests(withPropertyTests)
+		logger.Log.Debug().
+			Str("flag", flags.LocalWithPropertyTests.GetName()).
+			Msg("Property tests option overridden via CLI flag")
+	}
+	if ctx.IsSet(flags.LocalWithFuzzTests.GetName()) {
+		builder.WithFuzzTests(withFuzzTests)
+		logger.Log.Debug().
+			Str("flag", flags.LocalWithFuzzTests.GetName()).
+			Msg("Fuzz tests option overridden via CLI flag")
+	}
+	if ctx.IsSet(flags.LocalWithDoc.GetName()) {
+		builder.WithDoc(withDoc)
+		logger.Log.Debug().
+			Str("flag", flags.LocalWithDoc.GetName()).
+			Msg("Doc option overridden via CLI flag")
+	}
+	if ctx.IsSet(flags.LocalNoScan.GetName()) {
+		builder.WithNoScan(noScan)
+		logger.Log.Debug().
+			Str("flag", flags.LocalNoScan.GetName()).
+			Msg("No scan option overridden via CLI flag")
+	}
+	if builder.HasDoc() && builder.GetFilename() == builder.GetDocFilename() {
+		return result{}, logger.NewFailure("generated filename collides with the package doc file", nil).
+			With("filename", builder.GetFilename()).
+			With("flag", flags.LocalFilename.GetName())
+	}
+
+	res := result{
+		Schema:   schemaPath,
+		Table:    g.TableName(),
+		Package:  builder.GetPackageName(),
+		Mode:     builder.GetMode().String(),
+		Files:    []string{},
+		Warnings: []string{},
+	}
+
+	fingerprint := builder.Fingerprint()
+	if l != nil && !force && l.UpToDate(schemaPath, fingerprint) {
+		res.Files = l.Files(schemaPath)
+		res.Skipped = true
+		logger.Log.Info().
+			Str("schema", schemaPath).
+			Str("table", g.TableName()).
+			Msg("Schema unchanged, generation skipped")
+		return res, nil
+	}
+
+	var code, tests, doc []byte
+	rendered, err := builder.Build()
+	if err != nil {
+		return result{}, withSchema(err, schemaPath)
+	}
+	code = []byte(rendered)
+	if builder.HasTests() && outputPath != "" {
+		rendered, err := builder.BuildTests()
+		if err != nil {
+			return result{}, withSchema(err, schemaPath)
+		}
+		tests = []byte(rendered)
+	}
+	if builder.HasDoc() && outputPath != "" {
+		rendered, err := builder.BuildDoc()
+		if err != nil {
+			return result{}, withSchema(err, schemaPath)
+		}
+		doc = []byte(rendered)
+	}
+	if ctx.Bool(flags.LocalVerifyBuild.GetName()) {
+		files := map[string][]byte{builder.GetFilename(): code}
+		if tests != nil {
+			files[builder.GetTestFilename()] = tests
+		}
+		if doc != nil {
+			files[builder.GetDocFilename()] = doc
+		}
+		if err := verify.Build(ctx.Context, builder.GetPackageName(), files); err != nil {
+			return result{}, withSchema(err, schemaPath)
+		}
+		logger.Log.Debug().
+			Str("schema", schemaPath).
+			Str("package", builder.GetPackageName()).
+			Msg("Generated code build verified")
+	}
 
 	var w writer.Writer
 	switch outputPath {
@@ -89,13 +296,14 @@ func action(ctx *cli.Context) error {
 			builder.GetFilename(),
 		)
 		w = writer.NewFileWriter(outputFilePath)
+		res.Files = append(res.Files, outputFilePath)
 		logger.Log.Debug().
 			Str("path", outputFilePath).
 			Msg("Using file writer")
 	}
 
-	if err := w.Write([]byte(builder.Build())); err != nil {
-		return logger.NewFailure("failed to write generated content", err).
+	if err := w.Write(code); err != nil {
+		return result{}, logger.NewFailure("failed to write generated content", err).
 			With("writer", w.Type()).
 			With("schema", schemaPath)
 	}
@@ -106,5 +314,69 @@ func action(ctx *cli.Context) error {
 		Str("filename", builder.GetFilename()).
 		Str("writer", w.Type()).
 		Msg("Code generated successfully")
-	return nil
+
+	if builder.HasDoc() {
+		if outputPath == "" {
+			logger.Log.Warn().
+				Str("schema", schemaPath).
+				Msg("Doc file generation requires --output-dir, skipped")
+			res.Warnings = append(res.Warnings, "doc file generation requires --output-dir, skipped")
+		} else {
+			docFilePath := path.Join(
+				outputPath,
+				builder.GetPackageName(),
+				builder.GetDocFilename(),
+			)
+			dw := writer.NewFileWriter(docFilePath)
+			if err := dw.Write(doc); err != nil {
+				return result{}, logger.NewFailure("failed to write generated doc", err).
+					With("writer", dw.Type()).
+					With("schema", schemaPath)
+			}
+			res.Files = append(res.Files, docFilePath)
+			logger.Log.Info().
+				Str("schema", schemaPath).
+				Str("filename", builder.GetDocFilename()).
+				Str("writer", dw.Type()).
+				Msg("Doc generated successfully")
+		}
+	}
+	if builder.HasTests() {
+		if outputPath == "" {
+			logger.Log.Warn().
+				Str("schema", schemaPath).
+				Msg("Test file generation requires --output-dir, skipped")
+			res.Warnings = append(res.Warnings, "test file generation requires --output-dir, skipped")
+			return res, nil
+		}
+		testFilePath := path.Join(
+			outputPath,
+			builder.GetPackageName(),
+			builder.GetTestFilename(),
+		)
+		tw := writer.NewFileWriter(testFilePath)
+		if err := tw.Write(tests); err != nil {
+			return result{}, logger.NewFailure("failed to write generated tests", err).
+				With("writer", tw.Type()).
+				With("schema", schemaPath)
+		}
+		res.Files = append(res.Files, testFilePath)
+		logger.Log.Info().
+			Str("schema", schemaPath).
+			Str("filename", builder.GetTestFilename()).
+			Str("writer", tw.Type()).
+			Msg("Tests generated successfully")
+	}
+	if l != nil {
+		l.Set(schemaPath, fingerprint, res.Files)
+	}
+	return res, nil
+}
+
+// withSchema adds the schema path to err if it's a *logger.Failure.
+func withSchema(err error, schemaPath string) error {
+	if failure, ok := err.(*logger.Failure); ok {
+		return failure.With("schema", schemaPath)
+	}
+	return err
 }
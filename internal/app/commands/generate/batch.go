@@ -0,0 +1,189 @@
+package generate
+
+import (
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
+	"github.com/Mad-Pixels/go-dyno/internal/generator"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/lock"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/mode"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/module"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/rs/zerolog"
+	"github.com/urfave/cli/v2"
+)
+
+type batchResult struct {
+	Results []result     `json:"results"`
+	Errors  []batchError `json:"errors"`
+	Module  []string     `json:"module"`
+}
+
+type batchError struct {
+	Schema string `json:"schema"`
+	Error  string `json:"error"`
+}
+
+// generateDir renders every *.json schema from dir concurrently with a pool of workers.
+// Errors are collected per schema, so one broken schema doesn't stop the others.
+func generateDir(ctx *cli.Context, dir, outputPath string, m mode.Mode, format output.Format, workers int) error {
+	if outputPath == "" {
+		return logger.NewFailure("schema directory requires an output directory", nil).
+			With("flag", flags.LocalOutputDir.GetName())
+	}
+	if ctx.IsSet(flags.LocalPackageName.GetName()) || ctx.IsSet(flags.LocalFilename.GetName()) {
+		return logger.NewFailure("package and filename overrides are not supported for a schema directory", nil).
+			With("schema", dir)
+	}
+
+	schemas, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return logger.NewFailure("failed to list schemas", err).
+			With("path", dir)
+	}
+	if len(schemas) == 0 {
+		return logger.NewFailure("no JSON schemas found", nil).
+			With("path", dir)
+	}
+	sort.Strings(schemas)
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, len(schemas))
+
+	l, err := lock.Load(outputPath, godyno.Version)
+	if err != nil {
+		return err
+	}
+
+	var (
+		results  = make([]result, len(schemas))
+		errs     = packageCollisions(schemas, outputPath)
+		jobs     = make(chan int)
+		progress = logger.NewProgress("generate", len(schemas))
+		wg       sync.WaitGroup
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = render(ctx, schemas[i], outputPath, m, l)
+				progress.Step(filepath.Base(schemas[i]))
+			}
+		}()
+	}
+	for i := range schemas {
+		if errs[i] != nil {
+			progress.Step(filepath.Base(schemas[i]))
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	progress.Done()
+
+	if err := l.Save(); err != nil {
+		return err
+	}
+
+	batch := batchResult{
+		Results: []result{},
+		Errors:  []batchError{},
+		Module:  []string{},
+	}
+	if initModule := ctx.String(flags.LocalInitModule.GetName()); initModule != "" {
+		files, err := module.Scaffold(outputPath, initModule)
+		if err != nil {
+			return err
+		}
+		batch.Module = files
+	}
+	for i, schemaPath := range schemas {
+		if errs[i] == nil {
+			batch.Results = append(batch.Results, results[i])
+			continue
+		}
+		batch.Errors = append(batch.Errors, batchError{Schema: schemaPath, Error: errs[i].Error()})
+		if failure, ok := errs[i].(*logger.Failure); ok {
+			failure.With("schema", schemaPath).Log(zerolog.ErrorLevel)
+			continue
+		}
+		logger.Log.Error().
+			Str("schema", schemaPath).
+			Err(errs[i]).
+			Msg("Code generation failed")
+	}
+	var skipped int
+	for _, res := range batch.Results {
+		if res.Skipped {
+			skipped++
+		}
+	}
+	if len(batch.Errors) == 0 && skipped == len(batch.Results) {
+		logger.Log.Info().
+			Int("schemas", len(schemas)).
+			Msg("Nothing changed, all schemas are up to date")
+	} else {
+		logger.Log.Info().
+			Int("generated", len(batch.Results)-skipped).
+			Int("skipped", skipped).
+			Int("failed", len(batch.Errors)).
+			Msg("Code generation finished")
+	}
+	if format.IsJSON() {
+		if err := output.Print(batch); err != nil {
+			return err
+		}
+	}
+	if len(batch.Errors) > 0 {
+		return logger.NewFailure("code generation failed for some schemas", nil).
+			With("failed", len(batch.Errors)).
+			With("total", len(schemas))
+	}
+	return nil
+}
+
+// packageCollisions returns an error for every schema whose package directory in outputPath
+// is shared with another schema: their files would overwrite each other or declare the same types.
+// Schemas which fail to load are left to render, which reports the error.
+func packageCollisions(schemas []string, outputPath string) []error {
+	var (
+		errs     = make([]error, len(schemas))
+		packages = make(map[string][]int, len(schemas))
+	)
+	for i, schemaPath := range schemas {
+		g, err := generator.NewGenerator(schemaPath)
+		if err != nil {
+			continue
+		}
+		dir := filepath.Join(outputPath, g.PackageName())
+		packages[dir] = append(packages[dir], i)
+	}
+	for dir, indexes := range packages {
+		if len(indexes) < 2 {
+			continue
+		}
+		for _, i := range indexes {
+			var others []string
+			for _, j := range indexes {
+				if j != i {
+					others = append(others, schemas[j])
+				}
+			}
+			errs[i] = logger.NewFailure("schemas resolve to the same output package", nil).
+				With("schema", schemas[i]).
+				With("path", dir).
+				With("conflicts", strings.Join(others, ", "))
+		}
+	}
+	return errs
+}
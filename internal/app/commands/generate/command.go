@@ -47,7 +47,19 @@ func Command() *cli.Command {
 			flags.LocalFilename.Object,
 			flags.LocalPackageName.Object,
 			flags.LocalGenerateMode.Object,
+			flags.LocalOutputFormat.Object,
+			flags.LocalWorkers.Object,
+			flags.LocalForce.Object,
+			flags.LocalVerifyBuild.Object,
+			flags.LocalInitModule.Object,
 			flags.LocalWithStreamEvents.Object,
+			flags.LocalWithLogging.Object,
+			flags.LocalWithChaos.Object,
+			flags.LocalWithCircuitBreaker.Object,
+			flags.LocalWithPropertyTests.Object,
+			flags.LocalWithFuzzTests.Object,
+			flags.LocalWithDoc.Object,
+			flags.LocalNoScan.Object,
 		},
 	}
 }
@@ -24,18 +24,63 @@ EXAMPLES:
    # Override package and filename
    $ godyno {{.Command}} -s ./schema.json -o ./models --package users --filename user.go
    
+   # Generate every schema from a directory with 8 parallel workers
+   $ godyno {{.Command}} -s ./schemas --output-dir ./generated --workers 8
+
+   # Regenerate even if schemas are unchanged since the last run (see <output-dir>/.godyno.lock)
+   $ godyno {{.Command}} -s ./schemas --output-dir ./generated --force
+
+   # Type-check generated code against the pinned AWS SDK before writing it
+   $ godyno {{.Command}} -s ./schema.json --output-dir ./generated --verify-build
+
+   # Standalone versioned module (go.mod, doc.go, one sub-package per schema)
+   $ godyno {{.Command}} -s ./schemas --output-dir ./tables --init-module github.com/org/tables
+
+   # Machine-readable result (files written, warnings)
+   $ godyno {{.Command}} -s ./schema.json --output-dir ./generated --output json
+
+   # Quiet (errors only) or verbose (-v debug, -vv trace) logging
+   $ godyno -q {{.Command}} -s ./schema.json -o ./generated
+   $ godyno -vv {{.Command}} -s ./schema.json -o ./generated
+
    # Using environment variables
    $ {{.EnvPrefix}}_SCHEMA=./schema.json {{.EnvPrefix}}_OUTPUT_DIR=./gen godyno {{.Command}}
 
    # With DynamoDB stream events methods
    $ godyno {{.Command}} -s ./schema.json --output-dir ./generated --with-stream-events
 
+   # With slog based logging decorator
+   $ godyno {{.Command}} -s ./schema.json --output-dir ./generated --with-logging
+
+   # With fault-injection decorator for resilience tests, in the companion test file
+   $ godyno {{.Command}} -s ./schema.json --output-dir ./generated --with-chaos
+
+   # With circuit breaker decorator failing fast or failing over while DynamoDB is unhealthy
+   $ godyno {{.Command}} -s ./schema.json --output-dir ./generated --with-circuit-breaker
+
+   # With property-based marshal round-trip tests (written next to the generated file)
+   $ godyno {{.Command}} -s ./schema.json --output-dir ./generated --with-property-tests
+
+   # With fuzz targets for composite keys and item unmarshaling
+   $ godyno {{.Command}} -s ./schema.json --output-dir ./generated --with-fuzz-tests
+
+   # With package-level doc.go describing keys, indexes and access patterns
+   $ godyno {{.Command}} -s ./schema.json --output-dir ./generated --with-doc
+
+   # Without ScanBuilder and Scan, for a "no table scans" policy
+   $ godyno {{.Command}} -s ./schema.json --output-dir ./generated --no-scan
+
 GENERATED FEATURES:
    ✨ Type-safe structs with dynamodbav tags
    ✨ Table/column/index constants (no magic strings!)
    ✨ Fluent query builders with condition expressions
    ✨ Batch operations and atomic updates
    ✨ DynamoDB Streams event handlers
+   ✨ Structured logging decorator (slog)
+   ✨ Client helpers for regional, LocalStack and dynamodb-local endpoints
+   ✨ Schema hash/version constants and AssertSchemaCompatible runtime check
+   ✨ Generator version guard: init fails on mixed go-dyno versions or an outdated AWS SDK
+   ✨ Godoc examples with real table, index and key names
    ✨ Comprehensive error handling and validation
    ✨ AWS SDK v2 compatible (latest and greatest!)
    ✨ Production-ready with zero dependencies
@@ -0,0 +1,175 @@
+package heatmap
+
+import (
+	"os"
+	"time"
+
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
+	"github.com/Mad-Pixels/go-dyno/internal/awsclient"
+	"github.com/Mad-Pixels/go-dyno/internal/generator"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/heatmap"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/urfave/cli/v2"
+)
+
+// minPeriod is the smallest report period of Contributor Insights.
+const minPeriod = time.Minute
+
+func action(ctx *cli.Context) error {
+	var (
+		outputRaw  = ctx.String(flags.LocalOutputFormat.GetName())
+		schemaPath = ctx.String(flags.LocalSchema.GetName())
+		table      = ctx.String(flags.LocalTable.GetName())
+		indexName  = ctx.String(flags.LocalIndex.GetName())
+		enable     = ctx.Bool(flags.LocalEnable.GetName())
+		throttled  = ctx.Bool(flags.LocalThrottled.GetName())
+		window     = ctx.Duration(flags.LocalWindow.GetName())
+		periods    = ctx.Int(flags.LocalPeriods.GetName())
+		top        = ctx.Int(flags.LocalTop.GetName())
+		opts       = awsclient.Options{
+			Endpoint: ctx.String(flags.LocalEndpoint.GetName()),
+			Region:   ctx.String(flags.LocalRegion.GetName()),
+		}
+	)
+	format, err := output.Parse(outputRaw)
+	if err != nil {
+		return err
+	}
+	if window <= 0 || periods < 1 || top < 1 {
+		return logger.NewFailure("window, periods and top must be positive", nil).
+			With("window", window.String()).
+			With("periods", periods).
+			With("top", top)
+	}
+	g, err := generator.NewGenerator(schemaPath)
+	if err != nil {
+		return err
+	}
+	if table == "" {
+		table = g.TableName()
+	}
+	target, err := heatmap.TargetOf(g.Schema(), indexName)
+	if err != nil {
+		return err
+	}
+
+	client, err := awsclient.DynamoDB(ctx.Context, opts)
+	if err != nil {
+		return err
+	}
+	input := &dynamodb.DescribeContributorInsightsInput{TableName: aws.String(table)}
+	if indexName != "" {
+		input.IndexName = aws.String(indexName)
+	}
+	described, err := client.DescribeContributorInsights(ctx.Context, input)
+	if err != nil {
+		return logger.NewFailure("failed to describe contributor insights", err).
+			With("table", table).
+			With("index", indexName)
+	}
+	switch described.ContributorInsightsStatus {
+	case types.ContributorInsightsStatusEnabled:
+	case types.ContributorInsightsStatusEnabling:
+		logger.Log.Warn().
+			Str("table", table).
+			Msg("Contributor Insights is being enabled, reports may be empty for a few minutes")
+	default:
+		if !enable {
+			return logger.NewFailure("contributor insights is not enabled", nil).
+				With("table", table).
+				With("index", indexName).
+				With("status", described.ContributorInsightsStatus).
+				With("hint", "rerun with --"+flags.LocalEnable.GetName())
+		}
+		update := &dynamodb.UpdateContributorInsightsInput{
+			TableName:                 aws.String(table),
+			ContributorInsightsAction: types.ContributorInsightsActionEnable,
+		}
+		if indexName != "" {
+			update.IndexName = aws.String(indexName)
+		}
+		if _, err := client.UpdateContributorInsights(ctx.Context, update); err != nil {
+			return logger.NewFailure("failed to enable contributor insights", err).
+				With("table", table).
+				With("index", indexName)
+		}
+		logger.Log.Info().
+			Str("table", table).
+			Str("index", indexName).
+			Msg("Contributor Insights enabled, rerun in a few minutes to read the heatmap")
+		return nil
+	}
+
+	// Periods are whole minutes, the window is stretched to fit them.
+	period := max((window / time.Duration(periods)).Round(time.Minute), minPeriod)
+	var (
+		end   = time.Now().UTC().Truncate(period)
+		start = end.Add(-period * time.Duration(periods))
+	)
+	insights, err := awsclient.Insights(ctx.Context, opts)
+	if err != nil {
+		return err
+	}
+
+	var maps []*heatmap.Heatmap
+	for _, rule := range described.ContributorInsightsRuleList {
+		kind, ok := heatmap.RuleKind(rule)
+		if !ok {
+			continue
+		}
+		if isThrottled := kind == heatmap.PartitionKeysThrottled || kind == heatmap.KeysThrottled; isThrottled != throttled {
+			continue
+		}
+		report, err := insights.RuleReport(ctx.Context, rule, start, end, period, top)
+		if err != nil {
+			return err
+		}
+		contributors := make([]heatmap.Contributor, 0, len(report.Contributors))
+		for _, c := range report.Contributors {
+			points := make([]heatmap.Point, 0, len(c.Datapoints))
+			for _, dp := range c.Datapoints {
+				points = append(points, heatmap.Point{Time: dp.Timestamp, Value: dp.ApproximateValue})
+			}
+			contributors = append(contributors, heatmap.Contributor{
+				Keys:       c.Keys,
+				Total:      c.ApproximateAggregateValue,
+				Datapoints: heatmap.Cells(start, period, periods, points),
+			})
+		}
+		maps = append(maps, heatmap.Build(rule, target, contributors))
+	}
+	if len(maps) == 0 {
+		logger.Log.Warn().
+			Str("table", table).
+			Bool("throttled", throttled).
+			Msg("No Contributor Insights rules found")
+	}
+
+	if format.IsJSON() {
+		return output.Print(maps)
+	}
+	for _, h := range maps {
+		if err := heatmap.Render(os.Stdout, h); err != nil {
+			return err
+		}
+		if h.Skew >= float64(len(h.Rows))/2 && len(h.Rows) > 1 {
+			logger.Log.Warn().
+				Str("rule", h.Rule).
+				Float64("skew", h.Skew).
+				Msg("Hot key: one key takes most of the traffic")
+		}
+	}
+	logger.Log.Info().
+		Str("table", table).
+		Str("target", target.Name).
+		Time("start", start).
+		Dur("period", period).
+		Int("rules", len(maps)).
+		Msg("Heatmap rendered")
+	return nil
+}
@@ -0,0 +1,72 @@
+// Package heatmap provides the 'heatmap' CLI command: hot keys of a live table from Contributor Insights.
+package heatmap
+
+import (
+	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	name  = "heatmap"
+	usage = "render hot partition keys of a live table from CloudWatch Contributor Insights"
+)
+
+type tmplUsage struct {
+	Command   string
+	EnvPrefix string
+
+	FlagSchemaPath string
+	FlagTable      string
+	FlagIndex      string
+	FlagEnable     string
+	FlagThrottled  string
+	FlagWindow     string
+	FlagPeriods    string
+	FlagEndpoint   string
+	FlagOutput     string
+}
+
+// Command entrypoint.
+func Command() *cli.Command {
+	usageText := tmpl.MustParseTemplateToString(
+		usageTemplate,
+		tmplUsage{
+			Command:   name,
+			EnvPrefix: godyno.EnvPrefix,
+
+			FlagSchemaPath: flags.LocalSchema.GetName(),
+			FlagTable:      flags.LocalTable.GetName(),
+			FlagIndex:      flags.LocalIndex.GetName(),
+			FlagEnable:     flags.LocalEnable.GetName(),
+			FlagThrottled:  flags.LocalThrottled.GetName(),
+			FlagWindow:     flags.LocalWindow.GetName(),
+			FlagPeriods:    flags.LocalPeriods.GetName(),
+			FlagEndpoint:   flags.LocalEndpoint.GetName(),
+			FlagOutput:     flags.LocalOutputFormat.GetName(),
+		},
+	)
+
+	return &cli.Command{
+		Name:      name,
+		Usage:     usage,
+		UsageText: usageText,
+		Action:    action,
+
+		Flags: []cli.Flag{
+			flags.LocalSchema.Object,
+			flags.LocalTable.Object,
+			flags.LocalIndex.Object,
+			flags.LocalEnable.Object,
+			flags.LocalThrottled.Object,
+			flags.LocalWindow.Object,
+			flags.LocalPeriods.Object,
+			flags.LocalTop.Object,
+			flags.LocalEndpoint.Object,
+			flags.LocalRegion.Object,
+			flags.LocalOutputFormat.Object,
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package heatmap
+
+const usageTemplate = `
+🔥 {{.Command}} renders the hottest keys of a live table from CloudWatch Contributor Insights.
+
+Every row is one key, split back into schema attributes (composite keys like "user_id#type"
+included), every column one period of the window, shaded relative to the hottest cell:
+
+   DynamoDBContributorInsights-PKC-orders-... (table, 12 periods, skew 4.1x)
+     user_id=alice  ████▓▓▒▒░░▒▓   41.3%  18230
+     user_id=bob    ▒▒░░  ░  ░░▒   12.0%  5291
+
+Skew compares the hottest key with an even distribution over the reported keys: values close
+to 1 mean sharding helpers spread the load, high values point at a hot partition.
+
+Contributor Insights must be enabled on the table or index (--{{.FlagEnable}} enables it;
+reports fill within minutes and CloudWatch bills the rules per matched event).
+
+EXAMPLES:
+   $ godyno {{.Command}} -s ./orders.json
+   $ godyno {{.Command}} -s ./orders.json --{{.FlagIndex}} gsi_by_status --{{.FlagWindow}} 24h --{{.FlagPeriods}} 24
+   $ godyno {{.Command}} -s ./orders.json --{{.FlagThrottled}} --{{.FlagOutput}} json
+   $ godyno {{.Command}} -s ./orders.json --{{.FlagTable}} orders-staging --{{.FlagEnable}}
+   $ {{.EnvPrefix}}_SCHEMA=./orders.json godyno {{.Command}}
+`
@@ -0,0 +1,133 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/tfstate"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/fs"
+
+	"github.com/urfave/cli/v2"
+)
+
+type result struct {
+	State    string   `json:"state"`
+	Resource string   `json:"resource"`
+	Table    string   `json:"table"`
+	Path     string   `json:"path"`
+	Indexes  int      `json:"indexes"`
+	Warnings []string `json:"warnings"`
+}
+
+func tfstateAction(ctx *cli.Context) error {
+	var (
+		outputRaw  = ctx.String(flags.LocalOutputFormat.GetName())
+		resource   = ctx.String(flags.LocalResource.GetName())
+		force      = ctx.Bool(flags.LocalForce.GetName())
+		statePath  = ctx.Args().Get(0)
+		schemaPath = ctx.Args().Get(1)
+	)
+	format, err := output.Parse(outputRaw)
+	if err != nil {
+		return err
+	}
+	if statePath == "" {
+		return logger.NewFailure("Terraform state path is required", nil).
+			With("hint", "use '-' to read 'terraform show -json' from stdin")
+	}
+	logger.Log.Debug().
+		Str("state", statePath).
+		Str("resource", resource).
+		Bool("force", force).
+		Msg("Starting Terraform import")
+
+	var data []byte
+	if statePath == "-" {
+		data, err = io.ReadAll(ctx.App.Reader)
+		if err != nil {
+			return logger.NewFailure("failed to read Terraform state from stdin", err)
+		}
+	} else if data, err = fs.ReadFile(statePath); err != nil {
+		return err
+	}
+
+	tables, err := tfstate.Tables(data)
+	if err != nil {
+		return err
+	}
+	table, err := tfstate.Find(tables, resource)
+	if err != nil {
+		return err
+	}
+	draft, err := table.Schema()
+	if err != nil {
+		return err
+	}
+
+	if schemaPath == "" {
+		schemaPath = table.Name() + ".json"
+	} else {
+		schemaPath = fs.AddFileExt(schemaPath, ".json")
+	}
+	if fs.IsFileOrError(schemaPath) == nil && !force {
+		return logger.NewFailure("schema file already exists", nil).
+			With("path", schemaPath).
+			With("hint", "use --"+flags.LocalForce.GetName()+" to overwrite")
+	}
+
+	encoded, err := json.MarshalIndent(draft, "", "  ")
+	if err != nil {
+		return logger.NewFailure("failed to encode schema", err)
+	}
+	s, err := schema.FromJSON(encoded)
+	if err != nil {
+		return err
+	}
+	res := result{
+		State:    statePath,
+		Resource: table.Address,
+		Table:    table.Name(),
+		Path:     schemaPath,
+		Indexes:  len(draft.SecondaryIndexes),
+		Warnings: []string{},
+	}
+	for _, name := range draft.Assumed {
+		msg := fmt.Sprintf("type of projected attribute '%s' is unknown, assumed S", name)
+		res.Warnings = append(res.Warnings, msg)
+		logger.Log.Warn().
+			Str("attribute", name).
+			Msg(msg)
+	}
+	list := s.Diagnose()
+	for _, w := range list.Warnings() {
+		res.Warnings = append(res.Warnings, w.Message)
+		logger.Log.Warn().
+			Str("code", string(w.Code)).
+			Str("path", w.Path).
+			Str("suggestion", w.Suggestion).
+			Msg(w.Message)
+	}
+	if err := list.Err(); err != nil {
+		return err
+	}
+	if err := fs.WriteToFile(schemaPath, append(encoded, '\n')); err != nil {
+		return err
+	}
+
+	if format.IsJSON() {
+		return output.Print(res)
+	}
+	logger.Log.Info().
+		Str("resource", table.Address).
+		Str("path", schemaPath).
+		Str("table", table.Name()).
+		Int("indexes", res.Indexes).
+		Str("next", fmt.Sprintf("add non-key attributes, then godyno generate -s %s -o ./%s", schemaPath, s.PackageName())).
+		Msg("Schema imported")
+	return nil
+}
@@ -0,0 +1,60 @@
+// Package importer provides the 'import' CLI command: build schemas from existing infrastructure definitions.
+package importer
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	name  = "import"
+	usage = "create a JSON schema from an existing infrastructure definition"
+
+	tfstateName  = "tfstate"
+	tfstateUsage = "create a JSON schema from an aws_dynamodb_table of a Terraform state or plan"
+)
+
+type tmplUsage struct {
+	Command    string
+	Subcommand string
+
+	FlagResource string
+	FlagForce    string
+}
+
+// Command entrypoint.
+func Command() *cli.Command {
+	usageText := tmpl.MustParseTemplateToString(
+		usageTemplate,
+		tmplUsage{
+			Command:    name,
+			Subcommand: tfstateName,
+
+			FlagResource: flags.LocalResource.GetName(),
+			FlagForce:    flags.LocalForce.GetName(),
+		},
+	)
+
+	return &cli.Command{
+		Name:  name,
+		Usage: usage,
+
+		Subcommands: []*cli.Command{
+			{
+				Name:      tfstateName,
+				Usage:     tfstateUsage,
+				UsageText: usageText,
+				ArgsUsage: "<state.json> [schema.json]",
+				Action:    tfstateAction,
+
+				Flags: []cli.Flag{
+					flags.LocalResource.Object,
+					flags.LocalForce.Object,
+					flags.LocalOutputFormat.Object,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package importer
+
+const usageTemplate = `
+📥 {{.Command}} {{.Subcommand}} reads an aws_dynamodb_table resource from Terraform and writes a go-dyno schema,
+so the table definition Terraform already owns doesn't have to be retyped.
+
+Inputs:
+   terraform.tfstate                                 state file
+   terraform show -json > state.json                 state in JSON
+   terraform show -json plan.tfplan > plan.json      plan: planned values, prior state as fallback
+
+Mapped: table name, hash and range keys, key attribute types, GSIs and LSIs with
+their keys and projections, GSI capacity of provisioned tables, table class,
+warm throughput and replica regions.
+Terraform declares only key attributes: add the other attributes to the written schema.
+
+The schema is written to ./<table_name>.json unless a path is given.
+Existing files are kept unless --{{.FlagForce}} is set.
+
+EXAMPLES:
+   $ godyno {{.Command}} {{.Subcommand}} terraform.tfstate
+   $ terraform show -json | godyno {{.Command}} {{.Subcommand}} - ./schemas/posts.json
+   $ godyno {{.Command}} {{.Subcommand}} plan.json --{{.FlagResource}} module.blog.aws_dynamodb_table.posts
+`
@@ -0,0 +1,246 @@
+package migrate
+
+import (
+	"context"
+	"time"
+
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
+	"github.com/Mad-Pixels/go-dyno/internal/awsclient"
+	"github.com/Mad-Pixels/go-dyno/internal/generator"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/billing"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// maxDatapoints is the CloudWatch limit of datapoints returned by one request.
+	maxDatapoints = 1440
+
+	// periodStep is the granularity of statistic periods (CloudWatch keeps 5 minute data for 63 days).
+	periodStep = 5 * time.Minute
+)
+
+type result struct {
+	Plan    *billing.Plan `json:"plan"`
+	Applied bool          `json:"applied"`
+}
+
+func billingAction(ctx *cli.Context) error {
+	var (
+		outputRaw  = ctx.String(flags.LocalOutputFormat.GetName())
+		schemaPath = ctx.String(flags.LocalSchema.GetName())
+		toRaw      = ctx.String(flags.LocalTo.GetName())
+		table      = ctx.String(flags.LocalTable.GetName())
+		lookback   = ctx.Duration(flags.LocalLookback.GetName())
+		headroom   = ctx.Int(flags.LocalHeadroom.GetName())
+		apply      = ctx.Bool(flags.LocalApply.GetName())
+		force      = ctx.Bool(flags.LocalForce.GetName())
+		opts       = awsclient.Options{
+			Endpoint: ctx.String(flags.LocalEndpoint.GetName()),
+			Region:   ctx.String(flags.LocalRegion.GetName()),
+		}
+	)
+	format, err := output.Parse(outputRaw)
+	if err != nil {
+		return err
+	}
+	to, err := billing.ParseMode(toRaw)
+	if err != nil {
+		return err
+	}
+	if lookback < periodStep || headroom < 0 {
+		return logger.NewFailure("lookback must be at least 5m and headroom not negative", nil).
+			With("lookback", lookback.String()).
+			With("headroom", headroom)
+	}
+	g, err := generator.NewGenerator(schemaPath)
+	if err != nil {
+		return err
+	}
+	if table == "" {
+		table = g.TableName()
+	}
+	logger.Log.Debug().
+		Str("table", table).
+		Str("to", string(to)).
+		Dur("lookback", lookback).
+		Int("headroom", headroom).
+		Bool("apply", apply).
+		Msg("Starting billing mode migration")
+
+	client, err := awsclient.DynamoDB(ctx.Context, opts)
+	if err != nil {
+		return err
+	}
+	described, err := client.DescribeTable(ctx.Context, &dynamodb.DescribeTableInput{TableName: aws.String(table)})
+	if err != nil {
+		return logger.NewFailure("failed to describe table", err).
+			With("table", table)
+	}
+	live := liveTable(described.Table)
+
+	usage := map[string]billing.Usage{}
+	if to == billing.Provisioned {
+		insights, err := awsclient.Insights(ctx.Context, opts)
+		if err != nil {
+			return err
+		}
+		period := max((lookback/maxDatapoints + periodStep - 1).Truncate(periodStep), periodStep)
+		end := time.Now().UTC().Truncate(period)
+		start := end.Add(-lookback)
+
+		if usage[""], err = consumed(ctx.Context, insights, map[string]string{"TableName": table}, start, end, period); err != nil {
+			return err
+		}
+		for _, idx := range live.Indexes {
+			dims := map[string]string{"TableName": table, "GlobalSecondaryIndexName": idx.Name}
+			if usage[idx.Name], err = consumed(ctx.Context, insights, dims, start, end, period); err != nil {
+				return err
+			}
+		}
+	}
+
+	plan, err := billing.Build(live, usage, billing.Options{
+		To:       to,
+		Headroom: float64(headroom) / 100,
+		Declared: g.Schema().GlobalSecondaryIndexes(),
+		Now:      time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, w := range plan.Warnings {
+		logger.Log.Warn().
+			Str("table", table).
+			Msg(w)
+	}
+	if err := plan.Err(); err != nil {
+		if !force {
+			return err
+		}
+		for _, b := range plan.Blockers {
+			logger.Log.Warn().
+				Str("table", table).
+				Msg("Ignored with --" + flags.LocalForce.GetName() + ": " + b)
+		}
+	}
+
+	res := result{Plan: plan}
+	if apply {
+		if _, err := client.UpdateTable(ctx.Context, updateTableInput(plan.Request)); err != nil {
+			return logger.NewFailure("failed to update table", err).
+				With("table", table).
+				With("to", to)
+		}
+		res.Applied = true
+	}
+
+	if format.IsJSON() {
+		return output.Print(res)
+	}
+	for _, c := range plan.Capacity {
+		target := "table"
+		if c.Index != "" {
+			target = c.Index
+		}
+		logger.Log.Info().
+			Str("target", target).
+			Int64("rcu", c.Read).
+			Int64("wcu", c.Write).
+			Str("source", c.Source).
+			Float64("peakRead", c.Usage.PeakRead).
+			Float64("peakWrite", c.Usage.PeakWrite).
+			Msg("Capacity")
+	}
+	if !apply {
+		if err := output.Print(plan.Request); err != nil {
+			return err
+		}
+	}
+	logger.Log.Info().
+		Str("table", table).
+		Str("from", string(plan.From)).
+		Str("to", string(plan.To)).
+		Bool("applied", res.Applied).
+		Msg("Billing mode migration planned")
+	return nil
+}
+
+// liveTable maps a table description to the billing view of the table.
+func liveTable(desc *types.TableDescription) billing.Table {
+	t := billing.Table{
+		Name:   aws.ToString(desc.TableName),
+		Status: string(desc.TableStatus),
+		Mode:   billing.Provisioned,
+	}
+	if s := desc.BillingModeSummary; s != nil {
+		if s.BillingMode == types.BillingModePayPerRequest {
+			t.Mode = billing.OnDemand
+		}
+		if s.LastUpdateToPayPerRequestDateTime != nil {
+			t.LastSwitchToOnDemand = *s.LastUpdateToPayPerRequestDateTime
+		}
+	}
+	for _, gsi := range desc.GlobalSecondaryIndexes {
+		t.Indexes = append(t.Indexes, billing.Index{
+			Name:   aws.ToString(gsi.IndexName),
+			Status: string(gsi.IndexStatus),
+		})
+	}
+	return t
+}
+
+// consumed reads the consumed read and write capacity of a table or GSI.
+func consumed(ctx context.Context, insights *awsclient.InsightsClient, dims map[string]string, start, end time.Time, period time.Duration) (billing.Usage, error) {
+	sums := func(metric string) ([]float64, error) {
+		points, err := insights.MetricStatistics(ctx, metric, dims, "Sum", start, end, period)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]float64, len(points))
+		for i, p := range points {
+			values[i] = p.Sum
+		}
+		return values, nil
+	}
+	reads, err := sums("ConsumedReadCapacityUnits")
+	if err != nil {
+		return billing.Usage{}, err
+	}
+	writes, err := sums("ConsumedWriteCapacityUnits")
+	if err != nil {
+		return billing.Usage{}, err
+	}
+	return billing.Summarize(reads, writes, period), nil
+}
+
+// updateTableInput converts the planned request into an UpdateTable input.
+func updateTableInput(req billing.UpdateTable) *dynamodb.UpdateTableInput {
+	input := &dynamodb.UpdateTableInput{
+		TableName:   aws.String(req.TableName),
+		BillingMode: types.BillingMode(req.BillingMode),
+	}
+	if t := req.ProvisionedThroughput; t != nil {
+		input.ProvisionedThroughput = &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(t.ReadCapacityUnits),
+			WriteCapacityUnits: aws.Int64(t.WriteCapacityUnits),
+		}
+	}
+	for _, u := range req.GlobalSecondaryIndexUpdates {
+		input.GlobalSecondaryIndexUpdates = append(input.GlobalSecondaryIndexUpdates, types.GlobalSecondaryIndexUpdate{
+			Update: &types.UpdateGlobalSecondaryIndexAction{
+				IndexName: aws.String(u.Update.IndexName),
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(u.Update.ProvisionedThroughput.ReadCapacityUnits),
+					WriteCapacityUnits: aws.Int64(u.Update.ProvisionedThroughput.WriteCapacityUnits),
+				},
+			},
+		})
+	}
+	return input
+}
@@ -0,0 +1,79 @@
+// Package migrate provides the 'migrate' CLI command: guided changes of live tables.
+package migrate
+
+import (
+	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	name  = "migrate"
+	usage = "change settings of a live table with pre-checks"
+
+	billingName  = "billing"
+	billingUsage = "switch a live table between on-demand and provisioned capacity"
+)
+
+type tmplUsage struct {
+	Command    string
+	Subcommand string
+	EnvPrefix  string
+
+	FlagTo       string
+	FlagLookback string
+	FlagHeadroom string
+	FlagApply    string
+	FlagForce    string
+	FlagTable    string
+	FlagOutput   string
+}
+
+// Command entrypoint.
+func Command() *cli.Command {
+	usageText := tmpl.MustParseTemplateToString(
+		usageTemplate,
+		tmplUsage{
+			Command:    name,
+			Subcommand: billingName,
+			EnvPrefix:  godyno.EnvPrefix,
+
+			FlagTo:       flags.LocalTo.GetName(),
+			FlagLookback: flags.LocalLookback.GetName(),
+			FlagHeadroom: flags.LocalHeadroom.GetName(),
+			FlagApply:    flags.LocalApply.GetName(),
+			FlagForce:    flags.LocalForce.GetName(),
+			FlagTable:    flags.LocalTable.GetName(),
+			FlagOutput:   flags.LocalOutputFormat.GetName(),
+		},
+	)
+
+	return &cli.Command{
+		Name:  name,
+		Usage: usage,
+
+		Subcommands: []*cli.Command{
+			{
+				Name:      billingName,
+				Usage:     billingUsage,
+				UsageText: usageText,
+				Action:    billingAction,
+
+				Flags: []cli.Flag{
+					flags.LocalSchema.Object,
+					flags.LocalTo.Object,
+					flags.LocalTable.Object,
+					flags.LocalLookback.Object,
+					flags.LocalHeadroom.Object,
+					flags.LocalApply.Object,
+					flags.LocalForce.Object,
+					flags.LocalEndpoint.Object,
+					flags.LocalRegion.Object,
+					flags.LocalOutputFormat.Object,
+				},
+			},
+		},
+	}
+}
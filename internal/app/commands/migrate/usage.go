@@ -0,0 +1,24 @@
+package migrate
+
+const usageTemplate = `
+💱 {{.Command}} {{.Subcommand}} switches a live table between on-demand and provisioned capacity.
+
+Switching to provisioned sets the capacity of the table and every GSI in the same UpdateTable
+request. It is sized from the peak consumed capacity of the --{{.FlagLookback}} window (CloudWatch
+ConsumedReadCapacityUnits/ConsumedWriteCapacityUnits) plus --{{.FlagHeadroom}} percent;
+read_capacity and write_capacity declared for a GSI in the schema take precedence.
+
+Pre-checks:
+   blocking   table or index not ACTIVE, no consumed capacity to size from
+   warning    spiky workload (peak 4x the average), GSI write capacity below the table's,
+              declared GSI capacity below the observed peak, switch to on-demand within 24h
+
+The request is printed for 'aws dynamodb update-table --cli-input-json' and sent only
+with --{{.FlagApply}}. Blocking pre-checks are ignored with --{{.FlagForce}}.
+
+EXAMPLES:
+   $ godyno {{.Command}} {{.Subcommand}} -s ./orders.json --{{.FlagTo}} provisioned
+   $ godyno {{.Command}} {{.Subcommand}} -s ./orders.json --{{.FlagTo}} provisioned --{{.FlagLookback}} 720h --{{.FlagHeadroom}} 50 --{{.FlagApply}}
+   $ godyno {{.Command}} {{.Subcommand}} -s ./orders.json --{{.FlagTo}} on-demand --{{.FlagTable}} orders-staging --{{.FlagOutput}} json
+   $ {{.EnvPrefix}}_SCHEMA=./orders.json godyno {{.Command}} {{.Subcommand}} --{{.FlagTo}} on-demand
+`
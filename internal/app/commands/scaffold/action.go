@@ -0,0 +1,142 @@
+package scaffold
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
+	"github.com/Mad-Pixels/go-dyno/internal/generator"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/example"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/mode"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/module"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/fs"
+
+	"github.com/urfave/cli/v2"
+)
+
+type result struct {
+	Schema  string   `json:"schema"`
+	Table   string   `json:"table"`
+	Package string   `json:"package"`
+	Type    string   `json:"type"`
+	Module  string   `json:"module"`
+	Files   []string `json:"files"`
+}
+
+// file is a rendered project file, path is relative to the output directory.
+type file struct {
+	path string
+	data []byte
+}
+
+func action(ctx *cli.Context) error {
+	var (
+		outputRaw  = ctx.String(flags.LocalOutputFormat.GetName())
+		schemaPath = ctx.String(flags.LocalSchema.GetName())
+		kindRaw    = ctx.String(flags.LocalExampleType.GetName())
+		outputPath = ctx.String(flags.LocalOutputDir.GetName())
+		modulePath = ctx.String(flags.LocalInitModule.GetName())
+		force      = ctx.Bool(flags.LocalForce.GetName())
+	)
+	format, err := output.Parse(outputRaw)
+	if err != nil {
+		return err
+	}
+	kind, err := example.ParseKind(kindRaw)
+	if err != nil {
+		return err
+	}
+
+	g, err := generator.NewGenerator(schemaPath)
+	if err != nil {
+		return err
+	}
+	if err := g.Validate(); err != nil {
+		return err
+	}
+
+	// The example uses the query builder and the stream handler, both need ALL mode with stream events.
+	builder := g.NewRenderBuilder().
+		WithMode(mode.ALL).
+		WithStreamEvents(true)
+	pkg := builder.GetPackageName()
+	if outputPath == "" {
+		outputPath = pkg + "-example"
+	}
+	if modulePath == "" {
+		modulePath = example.DefaultModulePath(pkg)
+	}
+	if err := module.CheckPath(modulePath); err != nil {
+		return err
+	}
+	logger.Log.Debug().
+		Str("schema", schemaPath).
+		Str("type", kind.String()).
+		Str("output", outputPath).
+		Str("module", modulePath).
+		Bool("force", force).
+		Msg("Starting example scaffolding")
+
+	importPath := path.Join(modulePath, pkg)
+	code, err := builder.Build()
+	if err != nil {
+		return err
+	}
+	main, err := builder.BuildExample(kind, importPath)
+	if err != nil {
+		return err
+	}
+	readme, err := builder.BuildExampleReadme(kind, importPath)
+	if err != nil {
+		return err
+	}
+	files := []file{
+		{module.GoModFile, module.GoMod(modulePath)},
+		{filepath.Join(pkg, builder.GetFilename()), []byte(code)},
+		{example.MainFile, []byte(main)},
+		{example.ReadmeFile, []byte(readme)},
+	}
+	if !force {
+		for _, f := range files {
+			p := filepath.Join(outputPath, f.path)
+			if _, err := os.Stat(p); err == nil {
+				return logger.NewFailure("example file already exists", nil).
+					With("path", p).
+					With("flag", flags.LocalForce.GetName())
+			}
+		}
+	}
+
+	res := result{
+		Schema:  schemaPath,
+		Table:   g.TableName(),
+		Package: pkg,
+		Type:    kind.String(),
+		Module:  modulePath,
+		Files:   []string{},
+	}
+	for _, f := range files {
+		p := filepath.Join(outputPath, f.path)
+		if err := fs.IsDirOrCreate(filepath.Dir(p)); err != nil {
+			return err
+		}
+		if err := fs.WriteToFile(p, f.data); err != nil {
+			return err
+		}
+		res.Files = append(res.Files, p)
+	}
+
+	if format.IsJSON() {
+		return output.Print(res)
+	}
+	logger.Log.Info().
+		Str("path", outputPath).
+		Str("type", kind.String()).
+		Str("module", modulePath).
+		Str("next", "cd "+outputPath+" && go mod tidy").
+		Msg("Example application scaffolded")
+	return nil
+}
@@ -0,0 +1,62 @@
+// Package scaffold provides the 'example' CLI command: a runnable example application wired to generated code.
+package scaffold
+
+import (
+	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/example"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	name  = "example"
+	usage = "scaffold an example application for a schema"
+)
+
+type tmplUsage struct {
+	Command   string
+	EnvPrefix string
+	Kinds     []string
+
+	FlagSchemaPath string
+	FlagType       string
+	FlagOutputDir  string
+	FlagModule     string
+	FlagForce      string
+}
+
+// Command entrypoint.
+func Command() *cli.Command {
+	usageText := tmpl.MustParseTemplateToString(
+		usageTemplate,
+		tmplUsage{
+			Command:   name,
+			EnvPrefix: godyno.EnvPrefix,
+			Kinds:     example.GetAvailableKinds(),
+
+			FlagSchemaPath: flags.LocalSchema.GetName(),
+			FlagType:       flags.LocalExampleType.GetName(),
+			FlagOutputDir:  flags.LocalOutputDir.GetName(),
+			FlagModule:     flags.LocalInitModule.GetName(),
+			FlagForce:      flags.LocalForce.GetName(),
+		},
+	)
+
+	return &cli.Command{
+		Name:      name,
+		Usage:     usage,
+		UsageText: usageText,
+		Action:    action,
+
+		Flags: []cli.Flag{
+			flags.LocalSchema.Object,
+			flags.LocalExampleType.Object,
+			flags.LocalOutputDir.Object,
+			flags.LocalInitModule.Object,
+			flags.LocalForce.Object,
+			flags.LocalOutputFormat.Object,
+		},
+	}
+}
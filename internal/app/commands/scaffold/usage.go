@@ -0,0 +1,32 @@
+package scaffold
+
+const usageTemplate = `
+🏗️ {{.Command}} scaffolds a small runnable project wired to the code generated from a schema.
+
+The project is a standalone Go module and a living starting point:
+  • ⚙️ DynamoDB client setup (AWS config, DYNAMODB_ENDPOINT for local DynamoDB)
+  • ✍️ One write: an example item via ItemInput and PutItem
+  • 🔍 One query: items of a partition via the query builder
+  • 🌊 One stream handler: CreateTriggerHandler logging INSERT/MODIFY/REMOVE
+
+Types ({{Join .Kinds ", "}}):
+   lambda  stream Lambda function, "go run . seed" writes and queries locally
+   http    net/http server: POST /items, GET /items/{hashKey}, POST /stream
+   cli     command-line tool: put, query <hashKey>, stream < event.json
+   admin   operator tool: get, put, query, delete with a flag per attribute
+
+EXAMPLES:
+   $ godyno {{.Command}} --{{.FlagSchemaPath}} ./orders.json
+   $ godyno {{.Command}} -s ./orders.json --{{.FlagType}} lambda -o ./orders-lambda
+   $ godyno {{.Command}} -s ./orders.json -t http --{{.FlagModule}} github.com/org/orders-api
+   $ godyno {{.Command}} -s ./orders.json -t admin -o ./orders-admin
+   $ {{.EnvPrefix}}_TYPE=http godyno {{.Command}} -s ./orders.json
+
+LAYOUT (default --{{.FlagOutputDir}} ./<package>-example):
+   go.mod              module (default example.com/<package>-example), pinned AWS SDK
+   main.go             example application
+   README.md           how to run it
+   <package>/<file>.go generated code (ALL mode with stream events)
+
+Existing files are kept unless --{{.FlagForce}} is set. Run "go mod tidy" in the project afterwards.
+`
@@ -0,0 +1,64 @@
+package selftest
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/snapshot"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/urfave/cli/v2"
+)
+
+func action(ctx *cli.Context) error {
+	var (
+		fixturesDir = ctx.String(flags.LocalFixturesDir.GetName())
+		goldenDir   = ctx.String(flags.LocalGoldenDir.GetName())
+		update      = ctx.Bool(flags.LocalUpdate.GetName())
+		outputRaw   = ctx.String(flags.LocalOutputFormat.GetName())
+	)
+	format, err := output.Parse(outputRaw)
+	if err != nil {
+		return err
+	}
+	logger.Log.Debug().
+		Str("fixtures", fixturesDir).
+		Str("golden", goldenDir).
+		Bool("update", update).
+		Msg("Starting snapshot tests")
+
+	results, err := snapshot.Run(fixturesDir, goldenDir, update)
+	if err != nil {
+		return err
+	}
+	if format.IsJSON() {
+		if err := output.Print(results); err != nil {
+			return err
+		}
+	}
+	if update {
+		logger.Log.Info().
+			Str("golden", goldenDir).
+			Int("files", len(results)).
+			Msg("Golden files updated")
+		return nil
+	}
+
+	failed := snapshot.Failed(results)
+	for _, r := range failed {
+		logger.Log.Warn().
+			Str("status", string(r.Status)).
+			Str("path", r.Path).
+			Int("line", r.Line).
+			Msg("Generated code differs from golden file")
+	}
+	if len(failed) > 0 {
+		return logger.NewFailure("snapshot tests failed", nil).
+			With("failed", len(failed)).
+			With("total", len(results))
+	}
+
+	logger.Log.Info().
+		Int("files", len(results)).
+		Msg("Snapshot tests completed successfully")
+	return nil
+}
@@ -0,0 +1,53 @@
+// Package selftest provides a CLI command for golden-file snapshot tests of generated code.
+package selftest
+
+import (
+	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	name  = "selftest"
+	usage = "compare generated code with golden files"
+)
+
+type tmplUsage struct {
+	Command   string
+	EnvPrefix string
+
+	FlagFixtures string
+	FlagGolden   string
+	FlagUpdate   string
+}
+
+// Command entrypoint.
+func Command() *cli.Command {
+	usageText := tmpl.MustParseTemplateToString(
+		usageTemplate,
+		tmplUsage{
+			Command:   name,
+			EnvPrefix: godyno.EnvPrefix,
+
+			FlagFixtures: flags.LocalFixturesDir.GetName(),
+			FlagGolden:   flags.LocalGoldenDir.GetName(),
+			FlagUpdate:   flags.LocalUpdate.GetName(),
+		},
+	)
+
+	return &cli.Command{
+		Name:      name,
+		Usage:     usage,
+		UsageText: usageText,
+		Action:    action,
+
+		Flags: []cli.Flag{
+			flags.LocalFixturesDir.Object,
+			flags.LocalGoldenDir.Object,
+			flags.LocalUpdate.Object,
+			flags.LocalOutputFormat.Object,
+		},
+	}
+}
@@ -0,0 +1,27 @@
+package selftest
+
+const usageTemplate = `
+🧪 {{.Command}} renders all embedded templates against a corpus of schemas and compares the output with golden files.
+
+Every valid schema from the fixtures directory is rendered with several generation variants
+(default, min mode and all optional features). Any difference from the goldens is reported,
+so template refactors can't silently change the generated code.
+
+After an intended change of the generated code, refresh goldens with --{{.FlagUpdate}}
+and review the diff before committing.
+
+EXAMPLES:
+   # Compare with goldens (run from the repository root)
+   $ godyno {{.Command}}
+
+   # Refresh goldens after an intended template change
+   $ godyno {{.Command}} --{{.FlagUpdate}}
+
+   # Custom fixtures and golden directories
+   $ godyno {{.Command}} --{{.FlagFixtures}} ./schemas --{{.FlagGolden}} ./testdata/golden
+   $ {{.EnvPrefix}}_GOLDEN=./testdata/golden godyno {{.Command}}
+
+GOLDEN LAYOUT:
+   <golden>/<schema>/<variant>.go.golden
+   <golden>/<schema>/<variant>_test.go.golden
+`
@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
+	"github.com/Mad-Pixels/go-dyno/internal/awsclient"
+	"github.com/Mad-Pixels/go-dyno/internal/generator"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/stats"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/urfave/cli/v2"
+)
+
+func action(ctx *cli.Context) error {
+	var (
+		outputRaw  = ctx.String(flags.LocalOutputFormat.GetName())
+		schemaPath = ctx.String(flags.LocalSchemaOptional.GetName())
+		table      = ctx.String(flags.LocalTable.GetName())
+		sample     = ctx.Int(flags.LocalSample.GetName())
+		opts       = awsclient.Options{
+			Endpoint: ctx.String(flags.LocalEndpoint.GetName()),
+			Region:   ctx.String(flags.LocalRegion.GetName()),
+		}
+	)
+	format, err := output.Parse(outputRaw)
+	if err != nil {
+		return err
+	}
+
+	var g *generator.Generator
+	if schemaPath != "" {
+		if g, err = generator.NewGenerator(schemaPath); err != nil {
+			return err
+		}
+		if table == "" {
+			table = g.TableName()
+		}
+	}
+	if table == "" {
+		return logger.NewFailure("table name is required", nil).
+			With("flags", []string{flags.LocalTable.GetName(), flags.LocalSchemaOptional.GetName()})
+	}
+	logger.Log.Debug().
+		Str("table", table).
+		Str("schema", schemaPath).
+		Int("sample", sample).
+		Str("endpoint", opts.Endpoint).
+		Msg("Starting table sampling")
+
+	client, err := awsclient.DynamoDB(ctx.Context, opts)
+	if err != nil {
+		return err
+	}
+	var (
+		progress = logger.NewProgress("sample", sample)
+		reported int
+	)
+	report, err := stats.Collect(ctx.Context, client, table, sample, func(sampled int) {
+		progress.Add(sampled-reported, table)
+		reported = sampled
+	})
+	if err != nil {
+		return err
+	}
+	progress.Done()
+	if g != nil {
+		stats.Suggest(report, g.Schema())
+	}
+
+	if format.IsJSON() {
+		return output.Print(report)
+	}
+	for _, st := range report.Attributes {
+		event := logger.Log.Info().
+			Str("attribute", st.Name).
+			Str("fill", percent(st.FillRate)).
+			Float64("avgSize", round(st.AvgSize)).
+			Interface("types", st.Types).
+			Int("distinct", st.Distinct)
+		if st.MaxSetSize > 0 {
+			event = event.Float64("avgSetSize", round(st.AvgSetSize)).Int("maxSetSize", st.MaxSetSize)
+		}
+		if len(st.TopValues) > 0 {
+			event = event.Interface("top", st.TopValues)
+		}
+		event.Msg("Attribute")
+	}
+	for _, s := range report.Suggestions {
+		logger.Log.Warn().
+			Str("scope", s.Scope).
+			Msg(s.Message)
+	}
+	logger.Log.Info().
+		Str("table", report.Table).
+		Int("sampled", report.Sampled).
+		Float64("avgItemSize", round(report.AvgItemSize)).
+		Int("attributes", len(report.Attributes)).
+		Int("suggestions", len(report.Suggestions)).
+		Msg("Table statistics collected")
+	return nil
+}
+
+// percent formats a ratio as a percentage.
+func percent(ratio float64) string {
+	return strconv.FormatFloat(ratio*100, 'f', 1, 64) + "%"
+}
+
+// round rounds to two decimals for log output.
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
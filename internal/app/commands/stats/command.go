@@ -0,0 +1,59 @@
+// Package stats provides the 'stats' CLI command: attribute statistics of a live table sample.
+package stats
+
+import (
+	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	name  = "stats"
+	usage = "sample a live table and report attribute statistics"
+)
+
+type tmplUsage struct {
+	Command   string
+	EnvPrefix string
+
+	FlagTable      string
+	FlagSchemaPath string
+	FlagSample     string
+	FlagEndpoint   string
+	FlagOutput     string
+}
+
+// Command entrypoint.
+func Command() *cli.Command {
+	usageText := tmpl.MustParseTemplateToString(
+		usageTemplate,
+		tmplUsage{
+			Command:   name,
+			EnvPrefix: godyno.EnvPrefix,
+
+			FlagTable:      flags.LocalTable.GetName(),
+			FlagSchemaPath: flags.LocalSchemaOptional.GetName(),
+			FlagSample:     flags.LocalSample.GetName(),
+			FlagEndpoint:   flags.LocalEndpoint.GetName(),
+			FlagOutput:     flags.LocalOutputFormat.GetName(),
+		},
+	)
+
+	return &cli.Command{
+		Name:      name,
+		Usage:     usage,
+		UsageText: usageText,
+		Action:    action,
+
+		Flags: []cli.Flag{
+			flags.LocalTable.Object,
+			flags.LocalSchemaOptional.Object,
+			flags.LocalSample.Object,
+			flags.LocalEndpoint.Object,
+			flags.LocalRegion.Object,
+			flags.LocalOutputFormat.Object,
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package stats
+
+const usageTemplate = `
+📈 {{.Command}} samples items of a live table and reports how attributes are actually used.
+
+For every attribute found in the sample:
+  • 📊 Fill rate: share of items having the attribute
+  • 📏 Average size in bytes (attribute name included, as DynamoDB bills it)
+  • 🧩 DynamoDB types, set cardinalities (average / max members)
+  • 🏆 Distinct values and the most frequent ones
+
+With --{{.FlagSchemaPath}} the sample is compared with the schema and adjustments are suggested:
+declared but unused attributes, undeclared attributes, mixed types, low-cardinality or
+sparse GSI hash keys, and large attributes copied into ALL-projected GSIs.
+
+EXAMPLES:
+   $ godyno {{.Command}} --{{.FlagTable}} orders --{{.FlagSample}} 10000
+   $ godyno {{.Command}} -s ./orders.json
+   $ godyno {{.Command}} -s ./orders.json --{{.FlagEndpoint}} http://localhost:4566 --{{.FlagOutput}} json
+   $ {{.EnvPrefix}}_TABLE=orders godyno {{.Command}}
+
+The table is read with Scan: a sample of N items consumes about N * item size / 4KB / 2 RCU.
+`
@@ -2,32 +2,132 @@ package validate
 
 import (
 	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/app/output"
 	"github.com/Mad-Pixels/go-dyno/internal/generator"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/drift"
 	"github.com/Mad-Pixels/go-dyno/internal/logger"
 
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	// exitCodeInvalid is returned when the schema has error diagnostics.
+	exitCodeInvalid = 1
+
+	// exitCodeDrift is returned when the live table differs from the schema.
+	exitCodeDrift = 2
+)
+
+type result struct {
+	Schema      string       `json:"schema"`
+	Table       string       `json:"table"`
+	Package     string       `json:"package"`
+	Valid       bool         `json:"valid"`
+	Diagnostics diag.List    `json:"diagnostics"`
+	Drift       []drift.Item `json:"drift"`
+}
+
 func action(ctx *cli.Context) (err error) {
 	var (
-		schemaPath = ctx.String(flags.LocalSchema.GetName())
+		schemaPath   = ctx.String(flags.LocalSchema.GetName())
+		againstTable = ctx.Bool(flags.LocalAgainstTable.GetName())
+		outputRaw    = ctx.String(flags.LocalOutputFormat.GetName())
+		opts         = drift.Options{
+			Endpoint:      ctx.String(flags.LocalEndpoint.GetName()),
+			Region:        ctx.String(flags.LocalRegion.GetName()),
+			TTLAttribute:  ctx.String(flags.LocalTTLAttribute.GetName()),
+			StreamEnabled: ctx.Bool(flags.LocalWithStreamEvents.GetName()),
+		}
 	)
+	format, err := output.Parse(outputRaw)
+	if err != nil {
+		return err
+	}
 	logger.Log.Debug().
 		Str("schema", schemaPath).
+		Bool("againstTable", againstTable).
+		Str("endpoint", opts.Endpoint).
+		Str("output", string(format)).
 		Msg("Starting schema validation")
 
 	g, err := generator.NewGenerator(schemaPath)
 	if err != nil {
 		return err
 	}
-	if err := g.Validate(); err != nil {
-		return err
+	diagnostics := g.Diagnose()
+
+	res := result{
+		Schema:      schemaPath,
+		Table:       g.TableName(),
+		Package:     g.PackageName(),
+		Valid:       len(diagnostics.Errors()) == 0,
+		Diagnostics: append(diag.List{}, diagnostics...),
+		Drift:       []drift.Item{},
+	}
+	if !res.Valid {
+		if format.IsJSON() {
+			if err := output.Print(res); err != nil {
+				return err
+			}
+		} else {
+			logDiagnostics(res.Diagnostics)
+		}
+		return cli.Exit("", exitCodeInvalid)
+	}
+	if againstTable {
+		if attr := g.Schema().TTLAttribute(); attr != nil && opts.TTLAttribute == "" {
+			opts.TTLAttribute = attr.Name
+		}
+		table, err := drift.Fetch(ctx.Context, g.TableName(), opts)
+		if err != nil {
+			return err
+		}
+		res.Drift = append(res.Drift, drift.Compare(g.Schema(), table, opts)...)
+	}
+
+	if format.IsJSON() {
+		if err := output.Print(res); err != nil {
+			return err
+		}
+	} else {
+		logDiagnostics(res.Diagnostics)
+		for _, item := range res.Drift {
+			logger.Log.Warn().
+				Str("kind", string(item.Kind)).
+				Str("scope", item.Scope).
+				Str("field", item.Field).
+				Str("expected", item.Expected).
+				Str("actual", item.Actual).
+				Msg("Table drift detected")
+		}
+	}
+	if len(res.Drift) > 0 {
+		return cli.Exit("", exitCodeDrift)
 	}
 
 	logger.Log.Info().
 		Str("schema", schemaPath).
 		Str("table", g.TableName()).
 		Str("package", g.PackageName()).
+		Bool("againstTable", againstTable).
 		Msg("Schema validation completed successfully")
 	return nil
 }
+
+// logDiagnostics prints each diagnostic as a log line: errors at error level, warnings at warn level.
+func logDiagnostics(list diag.List) {
+	for _, d := range list {
+		event := logger.Log.Warn()
+		if d.Severity == diag.SeverityError {
+			event = logger.Log.Error()
+		}
+		event = event.
+			Str("code", string(d.Code)).
+			Str("path", d.Path)
+		if d.Suggestion != "" {
+			event = event.Str("suggestion", d.Suggestion)
+		}
+		event.Msg(d.Message)
+	}
+}
@@ -18,7 +18,10 @@ type tmplUsage struct {
 	Command   string
 	EnvPrefix string
 
-	FlagSchemaPath string
+	FlagSchemaPath   string
+	FlagAgainstTable string
+	FlagEndpoint     string
+	FlagOutput       string
 }
 
 // Command entrypoint.
@@ -29,7 +32,10 @@ func Command() *cli.Command {
 			Command:   name,
 			EnvPrefix: godyno.EnvPrefix,
 
-			FlagSchemaPath: flags.LocalSchema.GetName(),
+			FlagSchemaPath:   flags.LocalSchema.GetName(),
+			FlagAgainstTable: flags.LocalAgainstTable.GetName(),
+			FlagEndpoint:     flags.LocalEndpoint.GetName(),
+			FlagOutput:       flags.LocalOutputFormat.GetName(),
 		},
 	)
 
@@ -41,6 +47,12 @@ func Command() *cli.Command {
 
 		Flags: []cli.Flag{
 			flags.LocalSchema.Object,
+			flags.LocalAgainstTable.Object,
+			flags.LocalEndpoint.Object,
+			flags.LocalRegion.Object,
+			flags.LocalTTLAttribute.Object,
+			flags.LocalWithStreamEvents.Object,
+			flags.LocalOutputFormat.Object,
 		},
 	}
 }
@@ -19,6 +19,12 @@ EXAMPLES:
    $ godyno {{.Command}} --{{.FlagSchemaPath}} ./configs/user-posts.json
    $ godyno {{.Command}} -s ./schemas/orders.json
 
+   # Compare schema with the live table (LocalStack)
+   $ godyno {{.Command}} -s ./schema.json --{{.FlagAgainstTable}} --{{.FlagEndpoint}} http://localhost:4566
+
+   # Machine-readable diagnostics and drift report
+   $ godyno {{.Command}} -s ./schema.json --{{.FlagAgainstTable}} --{{.FlagOutput}} json
+
 VALIDATION CHECKS:
    ✅ JSON syntax and structure
    ✅ Required fields presence (table_name, hash_key, attributes)
@@ -26,4 +32,26 @@ VALIDATION CHECKS:
    ✅ Index key references to existing attributes
    ✅ Composite key format and attribute resolution
    ✅ Go naming conventions and reserved keyword conflicts
+
+DIAGNOSTICS:
+   Every problem is reported with a stable code, a severity, a JSON pointer
+   into the schema and a fix suggestion:
+
+   GD309 error /secondary_indexes/1/range_key
+         GSI range key 'created_at' not declared in attributes
+         💡 add 'created_at' to attributes or fix the key name
+
+   Codes: GD0xx table, GD1xx attributes, GD2xx primary key, GD3xx indexes.
+   Warnings (e.g. GD106 unused key attribute) don't fail validation.
+
+LIVE TABLE CHECKS (--{{.FlagAgainstTable}}):
+   🔑 Table key schema and key attribute types
+   📊 Secondary indexes: existence, type, keys, projection
+   ⏳ TTL attribute and status (with --ttl-attribute or a schema TTL attribute)
+   🌊 DynamoDB streams (with --with-stream-events)
+
+EXIT CODES:
+   0  schema is valid, no drift
+   1  invalid schema (error diagnostics) or command failure
+   2  live table differs from the schema
 `
@@ -0,0 +1,347 @@
+package wizard
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/fs"
+
+	"github.com/urfave/cli/v2"
+)
+
+// defaultSchemaPath is used when no path argument is given.
+const defaultSchemaPath = "schema.json"
+
+var (
+	// tableNameRe follows DynamoDB table naming rules.
+	tableNameRe = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,255}$`)
+
+	keyTypes       = []string{"S", "N", "B"}
+	attributeTypes = []string{"S", "N", "B", "BOOL", "SS", "NS", "BS", "L", "M"}
+	indexTypes     = []string{index.GSI.String(), index.LSI.String()}
+	projections    = []string{"ALL", "KEYS_ONLY", "INCLUDE"}
+)
+
+// draft is the schema being built, encoded in the schema file format.
+type draft struct {
+	TableName        string                `json:"table_name"`
+	SchemaVersion    int                   `json:"schema_version"`
+	HashKey          string                `json:"hash_key"`
+	RangeKey         string                `json:"range_key,omitempty"`
+	Attributes       []attribute.Attribute `json:"attributes"`
+	CommonAttributes []attribute.Attribute `json:"common_attributes"`
+	SecondaryIndexes []index.Index         `json:"secondary_indexes"`
+}
+
+func action(ctx *cli.Context) error {
+	var (
+		path  = ctx.Args().First()
+		force = ctx.Bool(flags.LocalForce.GetName())
+	)
+	if path == "" {
+		path = defaultSchemaPath
+	}
+	path = fs.AddFileExt(path, ".json")
+	if fs.IsFileOrError(path) == nil && !force {
+		return logger.NewFailure("schema file already exists", nil).
+			With("path", path).
+			With("hint", "use --"+flags.LocalForce.GetName()+" to overwrite")
+	}
+	logger.Log.Debug().
+		Str("path", path).
+		Bool("force", force).
+		Msg("Starting schema wizard")
+
+	p := newPrompter(ctx.App.Reader, ctx.App.Writer)
+	d, err := run(p)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return logger.NewFailure("failed to encode schema", err)
+	}
+	s, err := schema.FromJSON(data)
+	if err != nil {
+		return err
+	}
+	list := s.Diagnose()
+	for _, w := range list.Warnings() {
+		logger.Log.Warn().
+			Str("code", string(w.Code)).
+			Str("path", w.Path).
+			Str("suggestion", w.Suggestion).
+			Msg(w.Message)
+	}
+	if err := list.Err(); err != nil {
+		return err
+	}
+	if err := fs.WriteToFile(path, append(data, '\n')); err != nil {
+		return err
+	}
+
+	logger.Log.Info().
+		Str("path", path).
+		Str("table", d.TableName).
+		Int("attributes", len(d.Attributes)+len(d.CommonAttributes)).
+		Int("indexes", len(d.SecondaryIndexes)).
+		Str("next", fmt.Sprintf("godyno generate -s %s -o ./%s", path, s.PackageName())).
+		Msg("Schema written")
+	return nil
+}
+
+// run walks through all wizard steps.
+func run(p *prompter) (*draft, error) {
+	d := &draft{SchemaVersion: 1}
+
+	p.section("📝 Table")
+	name, err := p.ask("Table name", "", func(s string) error {
+		if !tableNameRe.MatchString(s) {
+			return fmt.Errorf("use 3-255 characters: letters, digits, '_', '-', '.'")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.TableName = name
+
+	p.section("🔑 Primary key")
+	if d.HashKey, err = askKey(p, d, "Hash (partition) key", false, nil); err != nil {
+		return nil, err
+	}
+	if d.RangeKey, err = askKey(p, d, "Range (sort) key, empty for none", true, func(s string) error {
+		if s == d.HashKey {
+			return fmt.Errorf("range key must differ from hash key '%s'", d.HashKey)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	p.section("🗃️ Attributes (non-key fields, empty name to finish)")
+	for {
+		attr, err := askAttribute(p, d)
+		if err != nil {
+			return nil, err
+		}
+		if attr == nil {
+			break
+		}
+		d.CommonAttributes = append(d.CommonAttributes, *attr)
+	}
+
+	p.section("📊 Secondary indexes")
+	for {
+		more, err := p.confirm("Add a secondary index?", false)
+		if err != nil {
+			return nil, err
+		}
+		if !more {
+			break
+		}
+		if err := askIndex(p, d); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// askKey asks for a key attribute name and, for a new attribute, its scalar type.
+// An empty answer is accepted only for an optional key.
+func askKey(p *prompter, d *draft, question string, optional bool, check func(string) error) (string, error) {
+	name, err := p.ask(question, "", func(s string) error {
+		if s == "" {
+			if optional {
+				return nil
+			}
+			return fmt.Errorf("key name cannot be empty")
+		}
+		for _, a := range d.CommonAttributes {
+			if a.Name == s && !isKeyType(a.Type) {
+				return fmt.Errorf("attribute '%s' has type %s, keys must be one of: %s", s, a.Type, strings.Join(keyTypes, ", "))
+			}
+		}
+		if check != nil {
+			return check(s)
+		}
+		return nil
+	})
+	if err != nil || name == "" {
+		return name, err
+	}
+	return name, useAsKey(p, d, name)
+}
+
+// useAsKey makes sure the attribute is declared in attributes: a common attribute is moved there,
+// an unknown one is declared after asking its type.
+func useAsKey(p *prompter, d *draft, name string) error {
+	for _, a := range d.Attributes {
+		if a.Name == name {
+			return nil
+		}
+	}
+	for i, a := range d.CommonAttributes {
+		if a.Name == name {
+			d.CommonAttributes = append(d.CommonAttributes[:i], d.CommonAttributes[i+1:]...)
+			d.Attributes = append(d.Attributes, a)
+			return nil
+		}
+	}
+
+	attrType, err := p.choose(fmt.Sprintf("  Type of '%s'", name), "S", keyTypes...)
+	if err != nil {
+		return err
+	}
+	d.Attributes = append(d.Attributes, attribute.Attribute{Name: name, Type: attrType})
+	return nil
+}
+
+// askAttribute asks for a common attribute, nil means no more attributes.
+func askAttribute(p *prompter, d *draft) (*attribute.Attribute, error) {
+	name, err := p.ask("Attribute name", "", func(s string) error {
+		if s != "" && isDeclared(d, s) {
+			return fmt.Errorf("attribute '%s' is already declared", s)
+		}
+		return nil
+	})
+	if err != nil || name == "" {
+		return nil, err
+	}
+
+	for {
+		attrType, err := p.choose(fmt.Sprintf("  Type of '%s'", name), "S", attributeTypes...)
+		if err != nil {
+			return nil, err
+		}
+		attr := attribute.Attribute{Name: name, Type: attrType}
+		if err := reportErrors(p, attr.Diagnose(diag.Pointer("common_attributes", len(d.CommonAttributes)))); err != nil {
+			continue
+		}
+		return &attr, nil
+	}
+}
+
+// askIndex asks for a secondary index and appends it to the draft once it's valid.
+func askIndex(p *prompter, d *draft) error {
+	var (
+		position = len(d.SecondaryIndexes)
+		attrs    = append([]attribute.Attribute{}, d.Attributes...)
+		common   = append([]attribute.Attribute{}, d.CommonAttributes...)
+	)
+
+	name, err := p.ask("  Index name", "", func(s string) error {
+		if s == "" {
+			return fmt.Errorf("index name cannot be empty")
+		}
+		for _, idx := range d.SecondaryIndexes {
+			if idx.Name == s {
+				return fmt.Errorf("index '%s' already exists", s)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	indexType := index.GSI.String()
+	if d.RangeKey != "" {
+		if indexType, err = p.choose("  Index type", index.GSI.String(), indexTypes...); err != nil {
+			return err
+		}
+	}
+	idx := index.Index{Name: name, Type: index.Type(indexType)}
+
+	if idx.IsGSI() {
+		if idx.HashKey, err = askKey(p, d, "  Index hash key", false, nil); err != nil {
+			return err
+		}
+		if idx.RangeKey, err = askKey(p, d, "  Index range key, empty for none", true, nil); err != nil {
+			return err
+		}
+	} else {
+		if idx.RangeKey, err = askKey(p, d, "  Index range key", false, func(s string) error {
+			switch s {
+			case d.RangeKey:
+				return fmt.Errorf("LSI range key must differ from table range key '%s'", d.RangeKey)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if idx.ProjectionType, err = p.choose("  Projection", "ALL", projections...); err != nil {
+		return err
+	}
+	if idx.ProjectionType == "INCLUDE" {
+		raw, err := p.ask("  Projected attributes (comma-separated)", "", func(s string) error {
+			for _, nk := range splitList(s) {
+				if !isDeclared(d, nk) {
+					return fmt.Errorf("attribute '%s' is not declared", nk)
+				}
+			}
+			if len(splitList(s)) == 0 {
+				return fmt.Errorf("list at least one attribute")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		idx.NonKeyAttributes = splitList(raw)
+	}
+
+	if err := reportErrors(p, idx.Diagnose(diag.Pointer("secondary_indexes", position), d.RangeKey)); err != nil {
+		// Keys declared for the rejected index must not stay in the schema.
+		d.Attributes, d.CommonAttributes = attrs, common
+		fmt.Fprintln(p.out, "  index skipped, try again")
+		return nil
+	}
+	d.SecondaryIndexes = append(d.SecondaryIndexes, idx)
+	return nil
+}
+
+// reportErrors prints error diagnostics and returns the first one.
+func reportErrors(p *prompter, list diag.List) error {
+	for _, e := range list.Errors() {
+		fmt.Fprintf(p.out, "  ✗ %s (%s)\n", e.Message, e.Code)
+		if e.Suggestion != "" {
+			fmt.Fprintf(p.out, "    💡 %s\n", e.Suggestion)
+		}
+	}
+	return list.Err()
+}
+
+func isDeclared(d *draft, name string) bool {
+	for _, a := range append(append([]attribute.Attribute{}, d.Attributes...), d.CommonAttributes...) {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isKeyType(t string) bool {
+	return matchOption(t, keyTypes) != ""
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
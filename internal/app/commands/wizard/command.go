@@ -0,0 +1,46 @@
+// Package wizard provides the 'init' CLI command: an interactive wizard writing a starter JSON schema.
+package wizard
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/app/flags"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
+
+	cli "github.com/urfave/cli/v2"
+)
+
+var (
+	name  = "init"
+	usage = "create a JSON schema interactively"
+)
+
+type tmplUsage struct {
+	Command     string
+	DefaultPath string
+
+	FlagForce string
+}
+
+// Command entrypoint.
+func Command() *cli.Command {
+	usageText := tmpl.MustParseTemplateToString(
+		usageTemplate,
+		tmplUsage{
+			Command:     name,
+			DefaultPath: defaultSchemaPath,
+
+			FlagForce: flags.LocalForce.GetName(),
+		},
+	)
+
+	return &cli.Command{
+		Name:      name,
+		Usage:     usage,
+		UsageText: usageText,
+		ArgsUsage: "[path]",
+		Action:    action,
+
+		Flags: []cli.Flag{
+			flags.LocalForce.Object,
+		},
+	}
+}
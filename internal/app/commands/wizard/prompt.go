@@ -0,0 +1,108 @@
+package wizard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+)
+
+// prompter asks questions on out and reads answers line by line from in.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// ask repeats the question until check accepts the answer.
+// An empty answer is replaced by def, check may be nil.
+func (p *prompter) ask(question, def string, check func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", question)
+		}
+
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = def
+		}
+		if check == nil {
+			return answer, nil
+		}
+		if err := check(answer); err != nil {
+			fmt.Fprintf(p.out, "  ✗ %s\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// choose asks for one of options (case-insensitive) and returns it in the options' case.
+func (p *prompter) choose(question, def string, options ...string) (string, error) {
+	answer, err := p.ask(
+		fmt.Sprintf("%s (%s)", question, strings.Join(options, "/")),
+		def,
+		func(s string) error {
+			if matchOption(s, options) == "" {
+				return fmt.Errorf("choose one of: %s", strings.Join(options, ", "))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	return matchOption(answer, options), nil
+}
+
+// confirm asks a yes/no question.
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	defAnswer := "n"
+	if def {
+		defAnswer = "y"
+	}
+	answer, err := p.choose(question, defAnswer, "y", "n")
+	if err != nil {
+		return false, err
+	}
+	return answer == "y", nil
+}
+
+// section prints a step header.
+func (p *prompter) section(title string) {
+	fmt.Fprintf(p.out, "\n%s\n", title)
+}
+
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		if errors.Is(err, io.EOF) {
+			return "", logger.NewFailure("input closed before the wizard finished", nil)
+		}
+		return "", logger.NewFailure("failed to read input", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func matchOption(answer string, options []string) string {
+	for _, o := range options {
+		if strings.EqualFold(answer, o) {
+			return o
+		}
+	}
+	return ""
+}
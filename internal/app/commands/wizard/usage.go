@@ -0,0 +1,33 @@
+package wizard
+
+const usageTemplate = `
+🧙 {{.Command}} asks a few questions and writes a starter DynamoDB JSON schema.
+
+The wizard walks through:
+  • 📝 Table name
+  • 🔑 Primary key: hash key and optional range key with their types
+  • 🗃️ Attributes: non-key fields and their DynamoDB types
+  • 📊 Secondary indexes (GSI/LSI): keys and projection
+
+Every answer is checked right away, an invalid one is asked again.
+Key attributes of indexes are declared on the fly, so the written schema
+passes 'godyno validate' and is ready for 'godyno generate'. 🚀
+
+EXAMPLES:
+   # Write ./{{.DefaultPath}}
+   $ godyno {{.Command}}
+
+   # Custom path, overwrite an existing file
+   $ godyno {{.Command}} ./schemas/orders.json --{{.FlagForce}}
+
+SESSION:
+   📝 Table
+   Table name: orders
+
+   🔑 Primary key
+   Hash (partition) key: user_id
+     Type of 'user_id' (S/N/B) [S]:
+   Range (sort) key, empty for none: created_at
+     Type of 'created_at' (S/N/B) [S]: N
+   ...
+`
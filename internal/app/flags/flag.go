@@ -5,6 +5,8 @@
 //
 // local.go contains local flags used internally by commands with automatic
 // environment variable support (GODYNO_ prefix).
+//
+// global.go contains application-wide flags (e.g. verbosity) set before the command name.
 package flags
 
 import "github.com/urfave/cli/v2"
@@ -0,0 +1,40 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	godyno "github.com/Mad-Pixels/go-dyno"
+
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// GlobalQuiet defines the -q/--quiet flag: log only errors.
+	GlobalQuiet = Flag{
+		Object: &cli.BoolFlag{
+			Name:  "quiet",
+			Usage: "Log only errors",
+			Aliases: []string{
+				"q",
+			},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("quiet")),
+			},
+			Required: false,
+		},
+	}
+
+	// GlobalVerbose defines the -v/--verbose flag: -v enables debug logs, -vv enables trace logs.
+	GlobalVerbose = Flag{
+		Object: &cli.BoolFlag{
+			Name:  "verbose",
+			Usage: "Increase log verbosity (-v debug, -vv trace)",
+			Aliases: []string{
+				"v",
+			},
+			Count:    new(int),
+			Required: false,
+		},
+	}
+)
@@ -3,8 +3,10 @@ package flags
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/example"
 	"github.com/Mad-Pixels/go-dyno/internal/generator/mode"
 
 	"github.com/urfave/cli/v2"
@@ -16,7 +18,7 @@ var (
 	LocalSchema = Flag{
 		Object: &cli.StringFlag{
 			Name:  "schema",
-			Usage: "Set path to 'JSON' schame for generate goLang objects.",
+			Usage: "Set path to 'JSON' schame for generate goLang objects (or a directory of schemas).",
 			Aliases: []string{
 				"s",
 			},
@@ -100,4 +102,534 @@ var (
 			Required: false,
 		},
 	}
+
+	// LocalWithLogging defines the --with-logging for the structured logging client decorator.
+	// By default, the logging decorator is not included.
+	LocalWithLogging = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "with-logging",
+			Usage:   "Add slog based logging decorator for DynamoDB operations",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("with-logging")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalWithChaos defines the --with-chaos flag: generate or not the fault-injection client decorator in the test file.
+	// By default, it is not included.
+	LocalWithChaos = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "with-chaos",
+			Usage:   "Add fault-injection client decorator for resilience tests to the generated test file",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("with-chaos")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalWithCircuitBreaker defines the --with-circuit-breaker flag: generate or not the circuit breaker client decorator.
+	// By default, it is not included.
+	LocalWithCircuitBreaker = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "with-circuit-breaker",
+			Usage:   "Add circuit breaker client decorator with optional failover client",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("with-circuit-breaker")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalWithPropertyTests defines the --with-property-tests flag: generate or not property-based round-trip tests.
+	// By default, it is not included.
+	LocalWithPropertyTests = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "with-property-tests",
+			Usage:   "Add property-based marshal round-trip tests (<filename>_test.go)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("with-property-tests")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalWithFuzzTests defines the --with-fuzz-tests flag: generate or not fuzz targets.
+	// By default, it is not included.
+	LocalWithFuzzTests = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "with-fuzz-tests",
+			Usage:   "Add fuzz targets for composite keys and item unmarshaling (<filename>_test.go)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("with-fuzz-tests")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalWithDoc defines the --with-doc flag: generate the package-level doc.go with access patterns.
+	LocalWithDoc = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "with-doc",
+			Usage:   "Add package-level doc.go summarizing table keys, indexes and access patterns",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("with-doc")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalNoScan defines the --no-scan flag: omit ScanBuilder and every scan operation,
+	// so the generated package can't read the table with a scan.
+	LocalNoScan = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "no-scan",
+			Usage:   "Omit ScanBuilder and Scan from the client interface (no table scans)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("no-scan")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalFixturesDir defines the --fixtures flag: directory with JSON schemas used by selftest.
+	LocalFixturesDir = Flag{
+		Object: &cli.StringFlag{
+			Name:    "fixtures",
+			Usage:   "Set directory with JSON schemas for snapshot tests.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("fixtures")),
+			},
+			Value:    "tests/fixtures",
+			Required: false,
+		},
+	}
+
+	// LocalGoldenDir defines the --golden flag: directory with golden files used by selftest.
+	LocalGoldenDir = Flag{
+		Object: &cli.StringFlag{
+			Name:    "golden",
+			Usage:   "Set directory with golden files for snapshot tests.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("golden")),
+			},
+			Value:    "tests/golden",
+			Required: false,
+		},
+	}
+
+	// LocalUpdate defines the --update flag: overwrite golden files with the current output.
+	LocalUpdate = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "update",
+			Usage:   "Overwrite golden files with the current generated output",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("update")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalAgainstTable defines the --against-table flag: compare the schema with the live DynamoDB table.
+	LocalAgainstTable = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "against-table",
+			Usage:   "Compare schema with the live DynamoDB table and report drift",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("against-table")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalEndpoint defines the --endpoint flag for a custom DynamoDB endpoint (LocalStack, dynamodb-local).
+	LocalEndpoint = Flag{
+		Object: &cli.StringFlag{
+			Name:    "endpoint",
+			Usage:   "Set custom DynamoDB endpoint URL. (AWS default if not set)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("endpoint")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalRegion defines the --region flag for the AWS region of the live table.
+	LocalRegion = Flag{
+		Object: &cli.StringFlag{
+			Name:    "region",
+			Usage:   "Set AWS region. (AWS default configuration if not set)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("region")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalTTLAttribute defines the --ttl-attribute flag: expected TTL attribute of the live table.
+	LocalTTLAttribute = Flag{
+		Object: &cli.StringFlag{
+			Name:    "ttl-attribute",
+			Usage:   "Set expected TTL attribute of the live table. (defaults to the schema's TTL attribute, TTL is not checked if neither is set)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("ttl-attribute")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalOutputFormat defines the --output flag for the result format of commands.
+	LocalOutputFormat = Flag{
+		Object: &cli.StringFlag{
+			Name:    "output",
+			Usage:   "Set result format: 'text' or 'json'.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("output")),
+			},
+			Value:    "text",
+			Required: false,
+		},
+	}
+
+	// LocalWorkers defines the --workers flag: number of parallel workers for a schema directory.
+	LocalWorkers = Flag{
+		Object: &cli.IntFlag{
+			Name:    "workers",
+			Usage:   "Set number of parallel workers when --schema is a directory. (number of CPUs if not set)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("workers")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalForce defines the --force flag: regenerate schemas even if they're unchanged.
+	LocalForce = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "force",
+			Usage:   "Regenerate code even if the schema and options are unchanged since the last run (init, import: overwrite an existing schema file; migrate: ignore blocking pre-checks)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("force")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalVerifyBuild defines the --verify-build flag: type-check generated code before writing it.
+	LocalVerifyBuild = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "verify-build",
+			Usage:   "Type-check generated code against the pinned AWS SDK before writing files (requires the go command)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("verify-build")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalInitModule defines the --init-module flag: scaffold the output directory as a standalone Go module.
+	LocalInitModule = Flag{
+		Object: &cli.StringFlag{
+			Name:    "init-module",
+			Usage:   "Scaffold output directory as a Go module with this path (go.mod, doc.go, per-schema sub-packages)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("init-module")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalExampleType defines the --type flag: kind of example application to scaffold.
+	LocalExampleType = Flag{
+		Object: &cli.StringFlag{
+			Name:  "type",
+			Usage: fmt.Sprintf("Set example application type (%s). (default: %s)", strings.Join(example.GetAvailableKinds(), ", "), example.GetDefault()),
+			Aliases: []string{
+				"t",
+			},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("type")),
+			},
+			Required: false,
+			Value:    example.GetDefault().String(),
+		},
+	}
+
+	// LocalSchemaOptional defines the --schema flag for commands where the schema is optional.
+	LocalSchemaOptional = Flag{
+		Object: &cli.StringFlag{
+			Name:  "schema",
+			Usage: "Set path to 'JSON' schema to compare with the table data. (optional)",
+			Aliases: []string{
+				"s",
+			},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("schema")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalTable defines the --table flag: name of the live table (schema table name if not set).
+	LocalTable = Flag{
+		Object: &cli.StringFlag{
+			Name:    "table",
+			Usage:   "Set live DynamoDB table name. (table name from --schema if not set)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("table")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalSample defines the --sample flag: number of items read from the live table.
+	LocalSample = Flag{
+		Object: &cli.IntFlag{
+			Name:    "sample",
+			Usage:   "Set number of items to sample from the table.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("sample")),
+			},
+			Value:    10000,
+			Required: false,
+		},
+	}
+
+	// LocalWorkload defines the --workload flag: JSON workload description for capacity estimates.
+	LocalWorkload = Flag{
+		Object: &cli.StringFlag{
+			Name:  "workload",
+			Usage: "Set path to 'JSON' workload description (access patterns with request rates).",
+			Aliases: []string{
+				"w",
+			},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("workload")),
+			},
+			Required: true,
+		},
+	}
+
+	// LocalIndex defines the --index flag: secondary index of the live table (table if not set).
+	LocalIndex = Flag{
+		Object: &cli.StringFlag{
+			Name:    "index",
+			Usage:   "Set secondary index name to report on. (base table if not set)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("index")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalEnable defines the --enable flag: enable Contributor Insights if it's disabled.
+	LocalEnable = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "enable",
+			Usage:   "Enable CloudWatch Contributor Insights on the table (or index) if it's disabled",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("enable")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalThrottled defines the --throttled flag: report throttled keys instead of accessed keys.
+	LocalThrottled = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "throttled",
+			Usage:   "Report the most throttled keys instead of the most accessed keys",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("throttled")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalWindow defines the --window flag: time window of a report.
+	LocalWindow = Flag{
+		Object: &cli.DurationFlag{
+			Name:    "window",
+			Usage:   "Set report time window, ending now.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("window")),
+			},
+			Value:    time.Hour,
+			Required: false,
+		},
+	}
+
+	// LocalPeriods defines the --periods flag: number of heatmap columns.
+	LocalPeriods = Flag{
+		Object: &cli.IntFlag{
+			Name:    "periods",
+			Usage:   "Set number of periods (heatmap columns) the window is split into.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("periods")),
+			},
+			Value:    12,
+			Required: false,
+		},
+	}
+
+	// LocalTop defines the --top flag: number of reported keys.
+	LocalTop = Flag{
+		Object: &cli.IntFlag{
+			Name:    "top",
+			Usage:   "Set number of hottest keys to report.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("top")),
+			},
+			Value:    10,
+			Required: false,
+		},
+	}
+
+	// LocalTargetTable defines the --target-table flag: table items are copied into.
+	LocalTargetTable = Flag{
+		Object: &cli.StringFlag{
+			Name:    "target-table",
+			Usage:   "Set target DynamoDB table name items are copied into.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("target-table")),
+			},
+			Required: true,
+		},
+	}
+
+	// LocalAnonymize defines the --anonymize flag: apply anonymization strategies declared in the schema.
+	LocalAnonymize = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "anonymize",
+			Usage:   "Anonymize PII attributes by the 'anonymize' strategies declared in the schema",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("anonymize")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalSalt defines the --salt flag: secret key of anonymization hashes.
+	LocalSalt = Flag{
+		Object: &cli.StringFlag{
+			Name:    "salt",
+			Usage:   "Set secret key of anonymization hashes (keep it to produce the same values across runs)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("salt")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalRate defines the --rate flag: write rate limit.
+	LocalRate = Flag{
+		Object: &cli.IntFlag{
+			Name:    "rate",
+			Usage:   "Set maximum number of items written per second. (0 for unlimited)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("rate")),
+			},
+			Value:    100,
+			Required: false,
+		},
+	}
+
+	// LocalResource defines the --resource flag: address of the Terraform resource to import.
+	LocalResource = Flag{
+		Object: &cli.StringFlag{
+			Name:    "resource",
+			Usage:   "Set address of the aws_dynamodb_table resource, e.g. 'module.blog.aws_dynamodb_table.posts'. (required if the state has several tables)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("resource")),
+			},
+			Required: false,
+		},
+	}
+
+	// LocalTo defines the --to flag: target billing mode of a table.
+	LocalTo = Flag{
+		Object: &cli.StringFlag{
+			Name:    "to",
+			Usage:   "Set target billing mode: 'on-demand' or 'provisioned'.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("to")),
+			},
+			Required: true,
+		},
+	}
+
+	// LocalLookback defines the --lookback flag: window of consumed capacity statistics.
+	LocalLookback = Flag{
+		Object: &cli.DurationFlag{
+			Name:    "lookback",
+			Usage:   "Set window of consumed capacity statistics used to size provisioned capacity, ending now.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("lookback")),
+			},
+			Value:    14 * 24 * time.Hour,
+			Required: false,
+		},
+	}
+
+	// LocalHeadroom defines the --headroom flag: capacity added on top of the observed peak.
+	LocalHeadroom = Flag{
+		Object: &cli.IntFlag{
+			Name:    "headroom",
+			Usage:   "Set capacity added on top of the observed peak consumption, in percent.",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("headroom")),
+			},
+			Value:    20,
+			Required: false,
+		},
+	}
+
+	// LocalApply defines the --apply flag: send the planned request instead of printing it only.
+	LocalApply = Flag{
+		Object: &cli.BoolFlag{
+			Name:    "apply",
+			Usage:   "Send the planned UpdateTable request. (the request is only printed if not set)",
+			Aliases: []string{},
+			EnvVars: []string{
+				fmt.Sprintf("%s_%s", godyno.EnvPrefix, strings.ToUpper("apply")),
+			},
+			Required: false,
+		},
+	}
 )
@@ -0,0 +1,69 @@
+// Package output provides result formats for CLI commands.
+//
+// In text mode commands report results with log lines. In JSON mode a single
+// JSON document is written to stdout, so other tools can consume results
+// (files written, warnings, drift items) without parsing logs.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
+
+	"github.com/rs/zerolog"
+)
+
+// Format is a command result format.
+type Format string
+
+const (
+	// Text reports results with log lines (default).
+	Text Format = "text"
+
+	// JSON writes results as a JSON document to stdout.
+	JSON Format = "json"
+)
+
+var validFormats = map[string]bool{
+	string(Text): true,
+	string(JSON): true,
+}
+
+// Parse converts a raw --output value into Format and configures logging for it.
+// In JSON mode stdout belongs to the result, so only warnings and errors are logged (to stderr).
+//
+// Example:
+//
+//	format, err := output.Parse(ctx.String(flags.LocalOutputFormat.GetName()))
+func Parse(raw string) (Format, error) {
+	if !validFormats[raw] {
+		return "", logger.NewFailure("invalid output format", nil).
+			With("output", raw).
+			With("available", conv.AvailableKeys(validFormats))
+	}
+	format := Format(raw)
+	if format == JSON && logger.Log.GetLevel() < zerolog.WarnLevel {
+		logger.Log = logger.Log.Level(zerolog.WarnLevel)
+	}
+	return format, nil
+}
+
+// IsJSON returns true for the JSON format.
+func (f Format) IsJSON() bool {
+	return f == JSON
+}
+
+// Print writes v to stdout as an indented JSON document.
+func Print(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return logger.NewFailure("failed to encode result", err)
+	}
+	if _, err := fmt.Fprintln(os.Stdout, string(data)); err != nil {
+		return logger.NewFailure("failed to write result", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package awsclient creates AWS clients for commands working with live tables.
+package awsclient
+
+import (
+	"context"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// Options configures the connection to AWS or a local emulator.
+type Options struct {
+	// Endpoint overrides the DynamoDB endpoint (LocalStack, dynamodb-local).
+	Endpoint string
+
+	// Region overrides the AWS region of the default configuration chain.
+	Region string
+}
+
+// Config loads the AWS configuration for opts.
+func Config(ctx context.Context, opts Options) (aws.Config, error) {
+	var loadOpts []func(*config.LoadOptions) error
+	if opts.Region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(opts.Region))
+	}
+	if opts.Endpoint != "" {
+		// Local emulators accept any credentials, the default chain may have none.
+		loadOpts = append(loadOpts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider("local", "local", ""),
+		))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return aws.Config{}, logger.NewFailure("failed to load AWS config", err)
+	}
+	return cfg, nil
+}
+
+// DynamoDB creates a DynamoDB client for opts.
+//
+// Example:
+//
+//	client, err := awsclient.DynamoDB(ctx, awsclient.Options{Endpoint: "http://localhost:4566"})
+func DynamoDB(ctx context.Context, opts Options) (*dynamodb.Client, error) {
+	cfg, err := Config(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+		if opts.Endpoint != "" {
+			o.BaseEndpoint = aws.String(opts.Endpoint)
+		}
+	}), nil
+}
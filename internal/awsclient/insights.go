@@ -0,0 +1,178 @@
+package awsclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// cloudWatchVersion is the API version of the CloudWatch Query protocol.
+const cloudWatchVersion = "2010-08-01"
+
+// InsightRuleReport is the result of CloudWatch GetInsightRuleReport.
+type InsightRuleReport struct {
+	KeyLabels    []string             `xml:"KeyLabels>member"`
+	Contributors []InsightContributor `xml:"Contributors>member"`
+}
+
+// InsightContributor is one key of an insight rule report.
+type InsightContributor struct {
+	Keys                      []string           `xml:"Keys>member"`
+	ApproximateAggregateValue float64            `xml:"ApproximateAggregateValue"`
+	Datapoints                []InsightDatapoint `xml:"Datapoints>member"`
+}
+
+// InsightDatapoint is the value of a contributor in one period.
+type InsightDatapoint struct {
+	Timestamp        time.Time `xml:"Timestamp"`
+	ApproximateValue float64   `xml:"ApproximateValue"`
+}
+
+// InsightsClient reads CloudWatch Contributor Insights rule reports and metric statistics.
+//
+// It calls the CloudWatch Query API directly with SigV4 signing of the core SDK,
+// go-dyno needs too few CloudWatch calls to depend on its SDK client.
+type InsightsClient struct {
+	cfg      aws.Config
+	endpoint string
+	signer   *v4.Signer
+}
+
+// Insights creates an InsightsClient for opts. With opts.Endpoint the emulator endpoint is
+// used for CloudWatch too (LocalStack serves all services on one port).
+func Insights(ctx context.Context, opts Options) (*InsightsClient, error) {
+	cfg, err := Config(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://monitoring.%s.amazonaws.com", cfg.Region)
+	}
+	return &InsightsClient{cfg: cfg, endpoint: endpoint, signer: v4.NewSigner()}, nil
+}
+
+// RuleReport returns the top contributors of rule between start and end, aggregated per period.
+func (c *InsightsClient) RuleReport(ctx context.Context, rule string, start, end time.Time, period time.Duration, top int) (*InsightRuleReport, error) {
+	form := url.Values{
+		"Action":              {"GetInsightRuleReport"},
+		"Version":             {cloudWatchVersion},
+		"RuleName":            {rule},
+		"StartTime":           {start.UTC().Format(time.RFC3339)},
+		"EndTime":             {end.UTC().Format(time.RFC3339)},
+		"Period":              {strconv.Itoa(int(period.Seconds()))},
+		"MaxContributorCount": {strconv.Itoa(top)},
+		"OrderBy":             {"Sum"},
+	}
+	var out struct {
+		Result InsightRuleReport `xml:"GetInsightRuleReportResult"`
+	}
+	if err := c.call(ctx, form, &out); err != nil {
+		return nil, logger.NewFailure("failed to read contributor insights report", err).
+			With("rule", rule)
+	}
+	return &out.Result, nil
+}
+
+// MetricDatapoint is the value of a metric statistic in one period.
+type MetricDatapoint struct {
+	Timestamp time.Time `xml:"Timestamp"`
+	Sum       float64   `xml:"Sum"`
+	Maximum   float64   `xml:"Maximum"`
+	Average   float64   `xml:"Average"`
+}
+
+// MetricStatistics returns the statistic ("Sum", "Maximum" or "Average") of an AWS/DynamoDB
+// metric between start and end per period, oldest first. Periods without data are omitted.
+func (c *InsightsClient) MetricStatistics(ctx context.Context, metric string, dimensions map[string]string, statistic string, start, end time.Time, period time.Duration) ([]MetricDatapoint, error) {
+	form := url.Values{
+		"Action":              {"GetMetricStatistics"},
+		"Version":             {cloudWatchVersion},
+		"Namespace":           {"AWS/DynamoDB"},
+		"MetricName":          {metric},
+		"StartTime":           {start.UTC().Format(time.RFC3339)},
+		"EndTime":             {end.UTC().Format(time.RFC3339)},
+		"Period":              {strconv.Itoa(int(period.Seconds()))},
+		"Statistics.member.1": {statistic},
+	}
+	names := make([]string, 0, len(dimensions))
+	for name := range dimensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		form.Set(fmt.Sprintf("Dimensions.member.%d.Name", i+1), name)
+		form.Set(fmt.Sprintf("Dimensions.member.%d.Value", i+1), dimensions[name])
+	}
+	var out struct {
+		Datapoints []MetricDatapoint `xml:"GetMetricStatisticsResult>Datapoints>member"`
+	}
+	if err := c.call(ctx, form, &out); err != nil {
+		return nil, logger.NewFailure("failed to read metric statistics", err).
+			With("metric", metric).
+			With("dimensions", dimensions)
+	}
+	sort.Slice(out.Datapoints, func(i, j int) bool {
+		return out.Datapoints[i].Timestamp.Before(out.Datapoints[j].Timestamp)
+	})
+	return out.Datapoints, nil
+}
+
+// call sends a signed Query API request and decodes the XML response into out.
+func (c *InsightsClient) call(ctx context.Context, form url.Values, out any) error {
+	body := form.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	creds, err := c.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256([]byte(body))
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "monitoring", c.cfg.Region, time.Now()); err != nil {
+		return err
+	}
+
+	var client aws.HTTPClient = http.DefaultClient
+	if c.cfg.HTTPClient != nil {
+		client = c.cfg.HTTPClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Code    string `xml:"Error>Code"`
+			Message string `xml:"Error>Message"`
+		}
+		if xml.Unmarshal(data, &apiErr) == nil && apiErr.Code != "" {
+			return fmt.Errorf("%s: %s", apiErr.Code, apiErr.Message)
+		}
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return xml.Unmarshal(data, out)
+}
@@ -0,0 +1,194 @@
+// Package anonymize replaces PII attribute values by the strategies declared in a schema.
+//
+// Attributes opt in with "anonymize":
+//
+//	{"name": "email", "type": "S", "anonymize": "faker"}
+//	{"name": "user_id", "type": "S", "anonymize": "hash"}
+//	{"name": "phone", "type": "S", "anonymize": "null"}
+//
+// Every replacement is derived from a keyed hash (HMAC-SHA256) of the original value, so
+// it is deterministic: equal values stay equal across items and tables, keeping keys,
+// indexes and references between tables consistent. Without the salt the originals can't be
+// recovered, with an empty salt low-entropy values (emails, phones) can be guessed by brute force.
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
+)
+
+// hashLength is the number of hex characters of a hashed string.
+const hashLength = 32
+
+var (
+	firstNames = []string{"Alex", "Sam", "Jordan", "Taylor", "Morgan", "Casey", "Riley", "Jamie", "Avery", "Quinn", "Robin", "Drew"}
+	lastNames  = []string{"Smith", "Johnson", "Brown", "Garcia", "Miller", "Davis", "Wilson", "Moore", "Clark", "Lewis", "Young", "Hall"}
+	streets    = []string{"Main St", "Oak Ave", "Pine Rd", "Maple Dr", "Cedar Ln", "Elm St", "Lake Blvd", "Hill Rd"}
+	cities     = []string{"Springfield", "Riverside", "Fairview", "Franklin", "Greenville", "Madison", "Clinton", "Salem"}
+)
+
+// Anonymizer applies the anonymization strategies of a schema.
+type Anonymizer struct {
+	salt       []byte
+	attributes map[string]attribute.Attribute
+	composites map[string][]string
+	format     index.CompositeFormat
+}
+
+// New creates an Anonymizer for the attributes of s declaring a strategy.
+func New(s *schema.Schema, salt string) *Anonymizer {
+	a := &Anonymizer{
+		salt:       []byte(salt),
+		attributes: make(map[string]attribute.Attribute),
+		composites: make(map[string][]string),
+		format:     s.CompositeKeys(),
+	}
+	for _, attr := range s.AllAttributes() {
+		if attr.Anonymize != "" {
+			a.attributes[attr.Name] = attr
+		}
+	}
+	for _, idx := range s.SecondaryIndexes() {
+		for _, key := range []string{idx.HashKey, idx.RangeKey} {
+			if !strings.Contains(key, "#") {
+				continue
+			}
+			parts := strings.Split(key, "#")
+			for _, part := range parts {
+				if _, ok := a.attributes[part]; ok {
+					a.composites[key] = parts
+					break
+				}
+			}
+		}
+	}
+	return a
+}
+
+// Fields returns the sorted names of anonymized attributes.
+func (a *Anonymizer) Fields() []string {
+	fields := make([]string, 0, len(a.attributes))
+	for name := range a.attributes {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Strategy returns the strategy of an attribute, false if the attribute is kept as is.
+func (a *Anonymizer) Strategy(name string) (string, bool) {
+	attr, ok := a.attributes[name]
+	return attr.Anonymize, ok
+}
+
+// String anonymizes a string value of the attribute.
+func (a *Anonymizer) String(name, value string) string {
+	if strategy, _ := a.Strategy(name); strategy == attribute.AnonymizeFaker {
+		return a.fake(name, value)
+	}
+	sum := a.sum(value)
+	return hex.EncodeToString(sum[:])[:hashLength]
+}
+
+// Number anonymizes a number value of the attribute, returned in DynamoDB number format.
+// The result is a non-negative integer below 2^48, exact in float64 and every Go number subtype
+// wider than 32 bits; for faker it is below 10^6 to look like a count or an amount.
+func (a *Anonymizer) Number(name, value string) string {
+	sum := a.sum(value)
+	n := binary.BigEndian.Uint64(sum[:8]) >> 16
+	if strategy, _ := a.Strategy(name); strategy == attribute.AnonymizeFaker {
+		n %= 1_000_000
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// Bytes anonymizes a binary value of the attribute.
+func (a *Anonymizer) Bytes(name string, value []byte) []byte {
+	sum := a.sum(string(value))
+	return sum[:]
+}
+
+// Composite re-derives a composite index key value ("user_id#type" = "alice#post")
+// from the anonymized parts, joined by the separator of the key declared in the schema. It returns false if key has no anonymized part.
+func (a *Anonymizer) Composite(key, value string) (string, bool) {
+	parts, ok := a.composites[key]
+	if !ok {
+		return value, false
+	}
+	values := a.format.Split(key, value, len(parts))
+	if len(values) != len(parts) {
+		// Not built from the parts, hash the whole value to avoid leaking it.
+		sum := a.sum(value)
+		return hex.EncodeToString(sum[:])[:hashLength], true
+	}
+	for i, part := range parts {
+		attr, ok := a.attributes[part]
+		if !ok {
+			continue
+		}
+		if attr.Type == "N" || attr.Type == "NS" {
+			values[i] = a.Number(part, values[i])
+		} else {
+			values[i] = a.String(part, values[i])
+		}
+	}
+	return a.format.Join(key, values), true
+}
+
+// IsComposite returns true if name is a composite index key with anonymized parts.
+func (a *Anonymizer) IsComposite(name string) bool {
+	_, ok := a.composites[name]
+	return ok
+}
+
+// fake returns a realistic value for the attribute, picked by its name.
+func (a *Anonymizer) fake(name, value string) string {
+	var (
+		sum   = a.sum(value)
+		n     = binary.BigEndian.Uint64(sum[:8])
+		lower = strings.ToLower(name)
+		first = firstNames[n%uint64(len(firstNames))]
+		last  = lastNames[(n>>8)%uint64(len(lastNames))]
+		id    = hex.EncodeToString(sum[8:12])
+	)
+	switch {
+	case strings.Contains(lower, "email") || strings.Contains(lower, "mail"):
+		return fmt.Sprintf("%s.%s.%s@example.com", strings.ToLower(first), strings.ToLower(last), id)
+	case strings.Contains(lower, "phone") || strings.Contains(lower, "mobile"):
+		return fmt.Sprintf("+1555%07d", n%10_000_000)
+	case strings.Contains(lower, "first"):
+		return first
+	case strings.Contains(lower, "last") || strings.Contains(lower, "surname"):
+		return last
+	case strings.Contains(lower, "name"):
+		return first + " " + last
+	case strings.Contains(lower, "address") || strings.Contains(lower, "street"):
+		return fmt.Sprintf("%d %s", n%9000+100, streets[(n>>16)%uint64(len(streets))])
+	case strings.Contains(lower, "city"):
+		return cities[(n>>16)%uint64(len(cities))]
+	case strings.Contains(lower, "ip"):
+		return fmt.Sprintf("10.%d.%d.%d", sum[0], sum[1], sum[2])
+	default:
+		return name + "-" + id
+	}
+}
+
+// sum returns the keyed hash of a value.
+func (a *Anonymizer) sum(value string) [sha256.Size]byte {
+	mac := hmac.New(sha256.New, a.salt)
+	mac.Write([]byte(value))
+
+	var sum [sha256.Size]byte
+	copy(sum[:], mac.Sum(nil))
+	return sum
+}
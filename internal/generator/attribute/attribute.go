@@ -14,6 +14,13 @@
 package attribute
 
 import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
 	"github.com/Mad-Pixels/go-dyno/internal/logger"
 	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
 )
@@ -36,6 +43,40 @@ var (
 		"M":    true,
 		"NULL": true,
 	}
+
+	// guardTypes lists DynamoDB types supported by each change guard.
+	guardTypes = map[string]map[string]bool{
+		GuardImmutable:           validTypes,
+		GuardMonotonicIncreasing: {"S": true, "N": true, "B": true},
+	}
+
+	// anonymizeTypes lists DynamoDB types supported by each anonymization strategy.
+	anonymizeTypes = map[string]map[string]bool{
+		AnonymizeHash:  {"S": true, "N": true, "B": true, "SS": true, "NS": true, "BS": true},
+		AnonymizeFaker: {"S": true, "N": true, "SS": true, "NS": true},
+		AnonymizeNull:  validTypes,
+	}
+)
+
+// Anonymization strategies of PII attributes, applied by 'godyno clone --anonymize'.
+const (
+	// AnonymizeHash replaces the value by its keyed hash; equal values stay equal.
+	AnonymizeHash = "hash"
+
+	// AnonymizeFaker replaces the value by a realistic fake derived from its hash.
+	AnonymizeFaker = "faker"
+
+	// AnonymizeNull removes the attribute.
+	AnonymizeNull = "null"
+)
+
+// Change guards of attributes, enforced by condition expressions of generated update paths.
+const (
+	// GuardImmutable rejects changes of the attribute once it is set.
+	GuardImmutable = "immutable"
+
+	// GuardMonotonicIncreasing rejects updates decreasing the attribute.
+	GuardMonotonicIncreasing = "monotonic_increasing"
 )
 
 // Attribute defines a DynamoDB attribute with a name, DynamoDB type, and optional Go subtype.
@@ -48,10 +89,120 @@ type Attribute struct {
 
 	// Subtype defines the specific Go type to generate. Optional.
 	Subtype attributeSubtype `json:"subtype,omitempty"`
+
+	// CustomGoName overrides the Go identifier derived from Name. Optional.
+	CustomGoName string `json:"go_name,omitempty"`
+
+	// Anonymize is the anonymization strategy of a PII attribute: "hash", "faker" or "null". Optional.
+	Anonymize string `json:"anonymize,omitempty"`
+
+	// Sensitive marks the attribute as sensitive data, encrypted by generated client-side encryption hooks. Optional.
+	Sensitive bool `json:"sensitive,omitempty"`
+
+	// EncryptionContext is added to the encryption context of the attribute, implies Sensitive. Optional.
+	EncryptionContext map[string]string `json:"encryption_context,omitempty"`
+
+	// Computed is the expression deriving the attribute from other attributes on write, e.g. "lower(name)". Optional.
+	Computed string `json:"computed,omitempty"`
+
+	// Guard is the change guard of the attribute: "immutable" or "monotonic_increasing". Optional.
+	Guard string `json:"guard,omitempty"`
+
+	// Version marks the integer attribute used for optimistic locking. Optional.
+	Version bool `json:"version,omitempty"`
+
+	// Auto is the audit timestamp set by generated writes: "created_at" or "updated_at". Optional.
+	Auto string `json:"auto,omitempty"`
+
+	// AutoFormat is the format of the audit timestamp: "unix", "unix_milli", "rfc3339" or "rfc3339_nano".
+	// Defaults to "unix" for "N" and "rfc3339" for "S" attributes. Optional.
+	AutoFormat string `json:"auto_format,omitempty"`
+
+	// Checksum marks the string attribute holding the item checksum written by generated puts. Optional.
+	Checksum bool `json:"checksum,omitempty"`
+
+	// TTL marks the integer attribute holding the expiry of the item in Unix seconds. Optional.
+	TTL bool `json:"ttl,omitempty"`
+
+	// FieldVersions marks the map attribute holding the last modification time of each attribute. Optional.
+	FieldVersions bool `json:"field_versions,omitempty"`
+
+	// Element is the element type of a typed list, declared as "L<S>", "L<N>" or "L<M:Name>".
+	Element string `json:"-"`
+
+	// Fields are the attributes of "L<M:Name>" elements, generated as struct Name. Optional.
+	Fields []Attribute `json:"fields,omitempty"`
+
+	// Required set to false generates a pointer field, nil if the item has no such attribute. Optional.
+	Required *bool `json:"required,omitempty"`
+
+	// CustomGoType overrides the Go type of the struct field: "time.Time" or a type implementing
+	// the attributevalue marshalers, qualified by its import path ("github.com/acme/money.Amount"). Optional.
+	CustomGoType string `json:"go_type,omitempty"`
+
+	// Enum lists the allowed values of a string attribute, generated as a typed string with constants. Optional.
+	Enum []string `json:"enum,omitempty"`
+
+	// MinLength is the minimum length of the value checked by the generated Validate:
+	// characters of strings, bytes of binaries, elements of sets, lists and maps. Optional.
+	MinLength *int `json:"min_length,omitempty"`
+
+	// MaxLength is the maximum length of the value checked by the generated Validate, see MinLength. Optional.
+	MaxLength *int `json:"max_length,omitempty"`
+
+	// Min is the minimum of a number checked by the generated Validate. Optional.
+	Min *float64 `json:"min,omitempty"`
+
+	// Max is the maximum of a number checked by the generated Validate. Optional.
+	Max *float64 `json:"max,omitempty"`
+
+	// Pattern is the regular expression strings must match, checked by the generated Validate. Optional.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Normalized normalizes values of a string key attribute before they are written or looked up:
+	// Unicode NFC, trimmed spaces and case folding, or the steps listed in Normalize. Optional.
+	Normalized bool `json:"normalized,omitempty"`
+
+	// Normalize lists the normalization steps of a normalized attribute: "nfc", "trim", "fold". Optional.
+	Normalize []string `json:"normalize,omitempty"`
+
+	// Default is the value ItemInput fills in when the attribute is unset: the zero value,
+	// nil for optional attributes. A JSON string, number or boolean matching the type. Optional.
+	Default json.RawMessage `json:"default,omitempty"`
+
+	// Payload is the protobuf message stored compressed in a binary attribute, qualified by its Go import path:
+	// "proto:github.com/acme/events/eventspb.OrderPlaced". Generates typed Get and Set accessors. Optional.
+	Payload string `json:"payload,omitempty"`
+}
+
+// IsComputed returns true if the attribute is derived from other attributes.
+func (a Attribute) IsComputed() bool {
+	return a.Computed != ""
+}
+
+// ComputedExpr returns the parsed expression of a computed attribute.
+func (a Attribute) ComputedExpr() (Expr, error) {
+	return ParseExpr(a.Computed)
+}
+
+// IsSensitive returns true if the attribute is marked sensitive or declares an encryption context.
+func (a Attribute) IsSensitive() bool {
+	return a.Sensitive || len(a.EncryptionContext) > 0
+}
+
+// GoName returns the Go identifier used for the struct field and column constant.
+func (a Attribute) GoName() string {
+	if a.CustomGoName != "" {
+		return a.CustomGoName
+	}
+	return conv.ToUpperCamelCase(conv.ToSafeName(a.Name))
 }
 
 // GoType return the Go type for this attribute.
 func (a Attribute) GoType() string {
+	if a.IsEnum() && a.Type == "S" {
+		return a.EnumType()
+	}
 	if !a.Subtype.IsDefault() {
 		return a.Subtype.GoType()
 	}
@@ -72,6 +223,9 @@ func (a Attribute) GoType() string {
 	case "BOOL":
 		return "bool"
 	case "L":
+		if a.IsTypedList() {
+			return "[]" + a.ElementGoType()
+		}
 		return "[]any"
 	case "M":
 		return "map[string]any"
@@ -82,6 +236,22 @@ func (a Attribute) GoType() string {
 	}
 }
 
+// NumberGoType returns the Go type holding the numbers of an "N" or "NS" attribute:
+// the element type for sets, int64 for Unix seconds of go_type "time.Time", empty for other attributes.
+func (a Attribute) NumberGoType() string {
+	switch {
+	case a.Type != "N" && a.Type != "NS":
+		return ""
+	case a.CustomGoType == TimeGoType:
+		return "int64"
+	case a.HasCustomGoType():
+		return ""
+	case !a.Subtype.IsDefault():
+		return a.Subtype.GoType()
+	}
+	return "int"
+}
+
 // ZeroValue returns the zero value expression for this attribute.
 func (a Attribute) ZeroValue() string {
 	if !a.Subtype.IsDefault() {
@@ -93,27 +263,141 @@ func (a Attribute) ZeroValue() string {
 		return `""`
 	case "N":
 		return "0"
-	case "B", "BOOL":
+	case "BOOL":
 		return "false"
-	case "SS", "NS", "BS", "L", "M", "NULL":
+	case "B", "SS", "NS", "BS", "L", "M", "NULL":
 		return "nil"
 	default:
 		return "nil"
 	}
 }
 
+// ExampleValue returns a sample value expression for this attribute, used in generated doc examples.
+func (a Attribute) ExampleValue() string {
+	if a.IsEnum() {
+		return a.EnumConst(a.Enum[0])
+	}
+	switch goType := a.GoType(); goType {
+	case "string":
+		return strconv.Quote(a.Name + "-1")
+	case "bool":
+		return "true"
+	case "float32", "float64":
+		return "1.5"
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "1"
+	case "[]byte":
+		return fmt.Sprintf("[]byte(%q)", a.Name)
+	case "[]string":
+		return fmt.Sprintf("[]string{%q}", a.Name+"-1")
+	case "[][]byte":
+		return fmt.Sprintf("[][]byte{[]byte(%q)}", a.Name)
+	case "[]bool":
+		return "[]bool{true}"
+	default:
+		if name := a.ElementStruct(); name != "" {
+			return fmt.Sprintf("[]%s{{}}", name)
+		}
+		if a.Element == "M" {
+			return a.ZeroValue()
+		}
+		if strings.HasPrefix(goType, "[]") {
+			return goType + "{1}"
+		}
+		return a.ZeroValue()
+	}
+}
+
 // Validate checks if the attribute configuration is valid.
 func (a Attribute) Validate() error {
+	return a.Diagnose("").Err()
+}
+
+// Diagnose returns all problems of the attribute configuration.
+// path is the JSON pointer of the attribute in the schema (e.g. "/attributes/0").
+func (a Attribute) Diagnose(path string) diag.List {
+	var list diag.List
 	if a.Name == "" {
-		return logger.NewFailure("attribute name cannot be empty", nil)
+		list = append(list, diag.Errorf(diag.CodeAttributeNameEmpty, path+"/name", "attribute name cannot be empty").
+			Suggest("set a non-empty name"))
 	}
 	if !validTypes[a.Type] {
-		return logger.NewFailure("invalid attribute type", nil).
-			With("name", a.Name).
-			With("type", a.Type).
-			With("available", conv.AvailableKeys(validTypes))
+		list = append(list, diag.Errorf(diag.CodeAttributeTypeInvalid, path+"/type", "invalid attribute type '%s' of '%s'", a.Type, a.Name).
+			Suggest("use one of: %s", strings.Join(conv.AvailableKeys(validTypes), ", ")))
+		return list
+	}
+	if a.CustomGoName != "" && (!token.IsIdentifier(a.CustomGoName) || !token.IsExported(a.CustomGoName)) {
+		list = append(list, diag.Errorf(diag.CodeAttributeGoNameInvalid, path+"/go_name", "go_name '%s' of '%s' must be an exported Go identifier", a.CustomGoName, a.Name).
+			Suggest("start go_name with an upper-case letter and use only letters, digits and '_'"))
+	}
+	if types, ok := anonymizeTypes[a.Anonymize]; a.Anonymize != "" && !ok {
+		list = append(list, diag.Errorf(diag.CodeAttributeAnonymizeInvalid, path+"/anonymize", "invalid anonymize strategy '%s' of '%s'", a.Anonymize, a.Name).
+			Suggest("use one of: %s, %s, %s", AnonymizeFaker, AnonymizeHash, AnonymizeNull))
+	} else if ok && !types[a.Type] {
+		list = append(list, diag.Errorf(diag.CodeAttributeAnonymizeInvalid, path+"/anonymize", "anonymize strategy '%s' doesn't support DynamoDB type '%s' of '%s'", a.Anonymize, a.Type, a.Name).
+			Suggest("use one of: %s", strings.Join(conv.AvailableKeys(anonymizeTypeStrategies(a.Type)), ", ")))
+	}
+	if a.IsComputed() {
+		if _, err := a.ComputedExpr(); err != nil {
+			list = append(list, diag.Errorf(diag.CodeAttributeComputedInvalid, path+"/computed", "invalid computed expression of '%s': %v", a.Name, err).
+				Suggest("use attribute names, string literals and functions: concat, lower, trim, upper"))
+		} else if a.GoType() != "string" {
+			list = append(list, diag.Errorf(diag.CodeAttributeComputedInvalid, path+"/computed", "computed attribute '%s' must be of type 'S', got '%s'", a.Name, a.Type).
+				Suggest("set type of '%s' to 'S'", a.Name))
+		}
+	}
+	if types, ok := guardTypes[a.Guard]; a.Guard != "" && !ok {
+		list = append(list, diag.Errorf(diag.CodeAttributeGuardInvalid, path+"/guard", "invalid guard '%s' of '%s'", a.Guard, a.Name).
+			Suggest("use one of: %s, %s", GuardImmutable, GuardMonotonicIncreasing))
+	} else if ok && !types[a.Type] {
+		list = append(list, diag.Errorf(diag.CodeAttributeGuardInvalid, path+"/guard", "guard '%s' doesn't support DynamoDB type '%s' of '%s'", a.Guard, a.Type, a.Name).
+			Suggest("use '%s' or remove the guard", GuardImmutable))
+	}
+	if a.IsAuto() || a.AutoFormat != "" {
+		list = append(list, a.diagnoseAuto(path)...)
+	}
+	if a.Version && !IsIntegerAttr(a) {
+		list = append(list, diag.Errorf(diag.CodeAttributeVersionInvalid, path+"/version", "version attribute '%s' must be an integer of type 'N'", a.Name).
+			Suggest("set type of '%s' to 'N' with an integer subtype", a.Name))
+	}
+	if a.Checksum && (a.Type != "S" || a.Subtype != SubtypeDefault) {
+		list = append(list, diag.Errorf(diag.CodeAttributeChecksumInvalid, path+"/checksum", "checksum attribute '%s' must be of type 'S' without subtype", a.Name).
+			Suggest("set type of '%s' to 'S'", a.Name))
+	}
+	if a.TTL && !IsIntegerAttr(a) {
+		list = append(list, diag.Errorf(diag.CodeAttributeTTLInvalid, path+"/ttl", "TTL attribute '%s' must be an integer of type 'N'", a.Name).
+			Suggest("set type of '%s' to 'N', DynamoDB expects Unix seconds", a.Name))
+	}
+	list = append(list, a.diagnoseList(path)...)
+	list = append(list, a.diagnoseOptional(path)...)
+	list = append(list, a.diagnoseGoType(path)...)
+	list = append(list, a.diagnoseEnum(path)...)
+	list = append(list, a.diagnoseRules(path)...)
+	list = append(list, a.diagnoseNormalize(path)...)
+	list = append(list, a.diagnoseDefault(path)...)
+	list = append(list, a.diagnosePayload(path)...)
+	if a.FieldVersions && a.Type != "M" {
+		list = append(list, diag.Errorf(diag.CodeAttributeFieldVersionsInvalid, path+"/field_versions", "field versions attribute '%s' must be of type 'M'", a.Name).
+			Suggest("set type of '%s' to 'M'", a.Name))
+	}
+	if err := a.Subtype.Validate(a.Type); err != nil {
+		list = append(list, diag.Errorf(diag.CodeAttributeSubtypeIncompatible, path+"/subtype", "incompatible subtype '%s' for DynamoDB type '%s' of '%s'", a.Subtype, a.Type, a.Name).
+			Suggest("remove subtype or use a subtype matching type '%s'", a.Type))
 	}
 
-	logger.Log.Debug().Any("attr", a).Msg("Attribute is valid")
-	return a.Subtype.Validate(a.Type)
+	if len(list) == 0 {
+		logger.Log.Debug().Any("attr", a).Msg("Attribute is valid")
+	}
+	return list
+}
+
+// anonymizeTypeStrategies returns the anonymization strategies supporting DynamoDB type t.
+func anonymizeTypeStrategies(t string) map[string]bool {
+	strategies := make(map[string]bool)
+	for strategy, types := range anonymizeTypes {
+		if types[t] {
+			strategies[strategy] = true
+		}
+	}
+	return strategies
 }
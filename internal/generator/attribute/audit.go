@@ -0,0 +1,97 @@
+package attribute
+
+import (
+	"fmt"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// Audit timestamps of attributes, set by generated write paths.
+const (
+	// AutoCreatedAt sets the attribute when the item is created and never changes it.
+	AutoCreatedAt = "created_at"
+
+	// AutoUpdatedAt sets the attribute on every put and update.
+	AutoUpdatedAt = "updated_at"
+)
+
+// Formats of audit timestamps.
+const (
+	// AutoFormatUnix is the Unix time in seconds, the default of "N" attributes.
+	AutoFormatUnix = "unix"
+
+	// AutoFormatUnixMilli is the Unix time in milliseconds.
+	AutoFormatUnixMilli = "unix_milli"
+
+	// AutoFormatRFC3339 is the UTC time in RFC 3339, the default of "S" attributes.
+	AutoFormatRFC3339 = "rfc3339"
+
+	// AutoFormatRFC3339Nano is the UTC time in RFC 3339 with nanoseconds.
+	AutoFormatRFC3339Nano = "rfc3339_nano"
+)
+
+// autoFormatTypes maps timestamp formats to the DynamoDB type they are stored as.
+var autoFormatTypes = map[string]string{
+	AutoFormatUnix:        "N",
+	AutoFormatUnixMilli:   "N",
+	AutoFormatRFC3339:     "S",
+	AutoFormatRFC3339Nano: "S",
+}
+
+// IsAuto returns true if the attribute is an audit timestamp.
+func (a Attribute) IsAuto() bool {
+	return a.Auto != ""
+}
+
+// AutoTimestampFormat returns the format of the audit timestamp, the default of the type if unset.
+func (a Attribute) AutoTimestampFormat() string {
+	if a.AutoFormat != "" {
+		return a.AutoFormat
+	}
+	if a.Type == "N" {
+		return AutoFormatUnix
+	}
+	return AutoFormatRFC3339
+}
+
+// AutoValue returns the Go expression of the audit timestamp of the time.Time expression now.
+//
+// Example:
+//
+//	Attribute{Type: "N", Subtype: SubtypeInt64, Auto: "updated_at"}.AutoValue("now") → "int64(now.Unix())"
+//	Attribute{Type: "S", Auto: "created_at"}.AutoValue("now")                       → "now.UTC().Format(time.RFC3339)"
+func (a Attribute) AutoValue(now string) string {
+	switch a.AutoTimestampFormat() {
+	case AutoFormatUnixMilli:
+		return fmt.Sprintf("%s(%s.UnixMilli())", a.GoType(), now)
+	case AutoFormatRFC3339:
+		return now + ".UTC().Format(time.RFC3339)"
+	case AutoFormatRFC3339Nano:
+		return now + ".UTC().Format(time.RFC3339Nano)"
+	default:
+		return fmt.Sprintf("%s(%s.Unix())", a.GoType(), now)
+	}
+}
+
+// diagnoseAuto returns problems of the audit timestamp settings at path.
+func (a Attribute) diagnoseAuto(path string) diag.List {
+	var list diag.List
+	if a.Auto != AutoCreatedAt && a.Auto != AutoUpdatedAt {
+		return append(list, diag.Errorf(diag.CodeAttributeAutoInvalid, path+"/auto", "invalid auto timestamp '%s' of '%s'", a.Auto, a.Name).
+			Suggest("use '%s' or '%s'", AutoCreatedAt, AutoUpdatedAt))
+	}
+	if (a.Type != "N" || !IsIntegerAttr(a)) && a.Type != "S" {
+		return append(list, diag.Errorf(diag.CodeAttributeAutoInvalid, path+"/type", "auto timestamp '%s' must be of type 'S' or an integer 'N', got '%s'", a.Name, a.Type).
+			Suggest("set type of '%s' to 'N' for Unix time or 'S' for RFC 3339", a.Name))
+	}
+	typ, ok := autoFormatTypes[a.AutoFormat]
+	switch {
+	case a.AutoFormat != "" && !ok:
+		list = append(list, diag.Errorf(diag.CodeAttributeAutoInvalid, path+"/auto_format", "invalid auto_format '%s' of '%s'", a.AutoFormat, a.Name).
+			Suggest("use one of: %s, %s, %s, %s", AutoFormatUnix, AutoFormatUnixMilli, AutoFormatRFC3339, AutoFormatRFC3339Nano))
+	case ok && typ != a.Type:
+		list = append(list, diag.Errorf(diag.CodeAttributeAutoInvalid, path+"/auto_format", "auto_format '%s' of '%s' needs type '%s', got '%s'", a.AutoFormat, a.Name, typ, a.Type).
+			Suggest("set type of '%s' to '%s' or change auto_format", a.Name, typ))
+	}
+	return list
+}
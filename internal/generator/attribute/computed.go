@@ -0,0 +1,191 @@
+package attribute
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// computedFuncs lists functions of computed attribute expressions with their minimum
+// and maximum number of arguments (-1 for unlimited) and the Go function applied.
+var computedFuncs = map[string]struct {
+	min, max int
+	goFunc   string
+}{
+	"lower":  {1, 1, "strings.ToLower"},
+	"upper":  {1, 1, "strings.ToUpper"},
+	"trim":   {1, 1, "strings.TrimSpace"},
+	"concat": {2, -1, ""},
+}
+
+// Expr is a parsed computed attribute expression: a function call, an attribute reference or a string literal.
+//
+// Example:
+//
+//	lower(name)
+//	concat(last_name, ", ", lower(first_name))
+type Expr struct {
+	// Func is the function name of a call, empty otherwise.
+	Func string
+
+	// Args are the arguments of a call.
+	Args []Expr
+
+	// Ref is the name of a referenced attribute.
+	Ref string
+
+	// Literal is the value of a string literal.
+	Literal string
+
+	// IsLiteral is true if the expression is a string literal.
+	IsLiteral bool
+}
+
+// ParseExpr parses a computed attribute expression.
+func ParseExpr(s string) (Expr, error) {
+	p := &exprParser{src: s}
+	expr, err := p.parse()
+	if err != nil {
+		return Expr{}, err
+	}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return Expr{}, fmt.Errorf("unexpected '%s' at %d", p.src[p.pos:], p.pos)
+	}
+	return expr, nil
+}
+
+// Refs returns the names of attributes referenced by the expression, without duplicates.
+func (e Expr) Refs() []string {
+	var (
+		refs []string
+		seen = make(map[string]bool)
+		walk func(Expr)
+	)
+	walk = func(e Expr) {
+		if e.Ref != "" && !seen[e.Ref] {
+			seen[e.Ref] = true
+			refs = append(refs, e.Ref)
+		}
+		for _, arg := range e.Args {
+			walk(arg)
+		}
+	}
+	walk(e)
+	return refs
+}
+
+// GoExpr renders the expression as Go code of type string.
+// value returns the Go expression of type string reading the referenced attribute.
+func (e Expr) GoExpr(value func(ref string) string) string {
+	switch {
+	case e.IsLiteral:
+		return strconv.Quote(e.Literal)
+	case e.Ref != "":
+		return value(e.Ref)
+	case e.Func == "concat":
+		args := make([]string, 0, len(e.Args))
+		for _, arg := range e.Args {
+			args = append(args, arg.GoExpr(value))
+		}
+		return strings.Join(args, " + ")
+	default:
+		return computedFuncs[e.Func].goFunc + "(" + e.Args[0].GoExpr(value) + ")"
+	}
+}
+
+// exprParser is a recursive descent parser of computed attribute expressions.
+type exprParser struct {
+	src string
+	pos int
+}
+
+func (p *exprParser) parse() (Expr, error) {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return Expr{}, fmt.Errorf("unexpected end of expression")
+	}
+	if p.src[p.pos] == '"' {
+		return p.parseLiteral()
+	}
+
+	name := p.parseName()
+	if name == "" {
+		return Expr{}, fmt.Errorf("unexpected '%c' at %d", p.src[p.pos], p.pos)
+	}
+	if p.skipSpace(); p.pos >= len(p.src) || p.src[p.pos] != '(' {
+		return Expr{Ref: name}, nil
+	}
+	p.pos++
+
+	fn, ok := computedFuncs[name]
+	if !ok {
+		return Expr{}, fmt.Errorf("unknown function '%s'", name)
+	}
+	expr := Expr{Func: name}
+	for {
+		if p.skipSpace(); p.pos < len(p.src) && p.src[p.pos] == ')' && len(expr.Args) == 0 {
+			p.pos++
+			break
+		}
+		arg, err := p.parse()
+		if err != nil {
+			return Expr{}, err
+		}
+		expr.Args = append(expr.Args, arg)
+
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return Expr{}, fmt.Errorf("missing ')' of '%s'", name)
+		}
+		if p.src[p.pos] == ')' {
+			p.pos++
+			break
+		}
+		if p.src[p.pos] != ',' {
+			return Expr{}, fmt.Errorf("unexpected '%c' at %d", p.src[p.pos], p.pos)
+		}
+		p.pos++
+	}
+	if len(expr.Args) < fn.min || (fn.max >= 0 && len(expr.Args) > fn.max) {
+		return Expr{}, fmt.Errorf("function '%s' got %d arguments", name, len(expr.Args))
+	}
+	return expr, nil
+}
+
+func (p *exprParser) parseLiteral() (Expr, error) {
+	end := p.pos + 1
+	for end < len(p.src) && p.src[end] != '"' {
+		if p.src[end] == '\\' {
+			end++
+		}
+		end++
+	}
+	if end >= len(p.src) {
+		return Expr{}, fmt.Errorf("unterminated string at %d", p.pos)
+	}
+	value, err := strconv.Unquote(p.src[p.pos : end+1])
+	if err != nil {
+		return Expr{}, fmt.Errorf("invalid string at %d: %v", p.pos, err)
+	}
+	p.pos = end + 1
+	return Expr{Literal: value, IsLiteral: true}, nil
+}
+
+func (p *exprParser) parseName() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		r := rune(p.src[p.pos])
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' && r != '.' {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
@@ -0,0 +1,125 @@
+package attribute
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// HasDefault returns true if the attribute declares the value ItemInput fills in when it is unset.
+func (a Attribute) HasDefault() bool {
+	return len(a.Default) > 0
+}
+
+// DefaultValue returns the Go expression of the default value: an enum constant,
+// a quoted string, the number as declared or a boolean.
+//
+// Examples:
+//
+//	{"type": "S", "default": "new"}                                            → `"new"`
+//	{"name": "status", "type": "S", "enum": ["new", "done"], "default": "new"} → "StatusNew"
+//	{"type": "N", "subtype": "float64", "default": 0.5}                        → "0.5"
+func (a Attribute) DefaultValue() string {
+	value, err := a.defaultJSON()
+	if err != nil {
+		return a.ZeroValue()
+	}
+	switch v := value.(type) {
+	case string:
+		if a.IsEnum() {
+			return a.EnumConst(v)
+		}
+		return strconv.Quote(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return a.ZeroValue()
+}
+
+// defaultJSON decodes the default value keeping numbers as declared.
+func (a Attribute) defaultJSON() (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(a.Default))
+	dec.UseNumber()
+	var value any
+	err := dec.Decode(&value)
+	return value, err
+}
+
+// diagnoseDefault returns problems of the default value of the attribute at path.
+func (a Attribute) diagnoseDefault(path string) diag.List {
+	var list diag.List
+	if !a.HasDefault() {
+		return list
+	}
+	path += "/default"
+	if a.HasCustomGoType() || a.IsComputed() || a.IsAuto() || a.Version || a.Checksum || a.TTL || a.FieldVersions {
+		return append(list, diag.Errorf(diag.CodeAttributeDefaultInvalid, path, "attribute '%s' is written by generated code or has a go_type and can't declare a default", a.Name).
+			Suggest("remove default from '%s'", a.Name))
+	}
+
+	value, err := a.defaultJSON()
+	if err != nil {
+		return append(list, diag.Errorf(diag.CodeAttributeDefaultInvalid, path, "invalid default of '%s': %v", a.Name, err))
+	}
+	switch a.Type {
+	case "S":
+		s, ok := value.(string)
+		if !ok {
+			return append(list, diag.Errorf(diag.CodeAttributeDefaultInvalid, path, "default of '%s' must be a string, got %s", a.Name, a.Default).
+				Suggest("quote the default of '%s'", a.Name))
+		}
+		if a.IsEnum() && !slices.Contains(a.Enum, s) {
+			list = append(list, diag.Errorf(diag.CodeAttributeDefaultInvalid, path, "default '%s' of '%s' is not one of its enum values", s, a.Name).
+				Suggest("use one of %s", strings.Join(a.Enum, ", ")))
+		}
+	case "N":
+		n, ok := value.(json.Number)
+		if !ok {
+			return append(list, diag.Errorf(diag.CodeAttributeDefaultInvalid, path, "default of '%s' must be a number, got %s", a.Name, a.Default))
+		}
+		if !numberDefaultFits(n.String(), a.NumberGoType()) {
+			list = append(list, diag.Errorf(diag.CodeAttributeDefaultInvalid, path, "default %s of '%s' doesn't fit Go type %s", n, a.Name, a.NumberGoType()).
+				Suggest("use a default in the range of %s or change the subtype", a.NumberGoType()))
+		}
+	case "BOOL":
+		if _, ok := value.(bool); !ok {
+			return append(list, diag.Errorf(diag.CodeAttributeDefaultInvalid, path, "default of '%s' must be a boolean, got %s", a.Name, a.Default))
+		}
+		if !a.IsOptional() {
+			list = append(list, diag.Errorf(diag.CodeAttributeDefaultInvalid, path, "default of required boolean '%s' can't tell false from unset", a.Name).
+				Suggest("set required of '%s' to false", a.Name))
+		}
+	default:
+		list = append(list, diag.Errorf(diag.CodeAttributeDefaultInvalid, path, "default of '%s' doesn't support DynamoDB type '%s'", a.Name, a.Type).
+			Suggest("declare defaults of S, N and BOOL attributes"))
+	}
+	return list
+}
+
+// numberDefaultFits reports whether the number literal is a valid value of the Go number type.
+func numberDefaultFits(n, goType string) bool {
+	switch goType {
+	case "float32":
+		_, err := strconv.ParseFloat(n, 32)
+		return err == nil
+	case "float64":
+		_, err := strconv.ParseFloat(n, 64)
+		return err == nil
+	}
+	bits := 64
+	if size, ok := strings.CutPrefix(strings.TrimPrefix(goType, "u"), "int"); ok && size != "" {
+		bits, _ = strconv.Atoi(size)
+	}
+	if strings.HasPrefix(goType, "uint") {
+		_, err := strconv.ParseUint(n, 10, bits)
+		return err == nil
+	}
+	_, err := strconv.ParseInt(n, 10, bits)
+	return err == nil
+}
@@ -0,0 +1,66 @@
+package attribute
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
+)
+
+// IsEnum returns true if the attribute declares its allowed values with "enum".
+func (a Attribute) IsEnum() bool {
+	return len(a.Enum) > 0
+}
+
+// EnumType returns the Go string type generated for the values of an enum attribute.
+func (a Attribute) EnumType() string {
+	return a.GoName()
+}
+
+// EnumConst returns the Go constant of an enum value.
+//
+// Examples:
+//
+//	{"name": "status", "enum": ["draft"]}     → EnumConst("draft")     → "StatusDraft"
+//	{"name": "status", "enum": ["in-review"]} → EnumConst("in-review") → "StatusInReview"
+func (a Attribute) EnumConst(value string) string {
+	return a.EnumType() + conv.ToUpperCamelCase(value)
+}
+
+// diagnoseEnum returns problems of the allowed values of the attribute at path.
+func (a Attribute) diagnoseEnum(path string) diag.List {
+	var list diag.List
+	if a.Enum == nil {
+		return list
+	}
+	if a.Type != "S" {
+		list = append(list, diag.Errorf(diag.CodeAttributeEnumInvalid, path+"/enum", "enum attribute '%s' must be of type 'S', got '%s'", a.Name, a.Type).
+			Suggest("set type of '%s' to 'S'", a.Name))
+	}
+	if len(a.Enum) == 0 {
+		list = append(list, diag.Errorf(diag.CodeAttributeEnumInvalid, path+"/enum", "enum of '%s' has no values", a.Name).
+			Suggest("list the allowed values or remove enum"))
+	}
+	consts := map[string]string{}
+	for i, value := range a.Enum {
+		valuePath := path + diag.Pointer("enum", i)
+		if value == "" {
+			list = append(list, diag.Errorf(diag.CodeAttributeEnumInvalid, valuePath, "enum of '%s' has an empty value", a.Name).
+				Suggest("remove the empty value, unset attributes are empty"))
+			continue
+		}
+		name := a.EnumConst(value)
+		if other, ok := consts[name]; ok {
+			list = append(list, diag.Errorf(diag.CodeAttributeEnumInvalid, valuePath, "enum values '%s' and '%s' of '%s' have the same constant '%s'", other, value, a.Name, name).
+				Suggest("remove the duplicate value"))
+		}
+		consts[name] = value
+	}
+	if a.Subtype != SubtypeDefault || a.HasCustomGoType() {
+		list = append(list, diag.Errorf(diag.CodeAttributeEnumConflict, path+"/enum", "enum attribute '%s' can't declare subtype or go_type", a.Name).
+			Suggest("remove subtype and go_type from '%s'", a.Name))
+	}
+	if a.IsComputed() || a.IsAuto() || a.Checksum {
+		list = append(list, diag.Errorf(diag.CodeAttributeEnumConflict, path+"/enum", "attribute '%s' is written by generated code and can't declare enum", a.Name).
+			Suggest("remove enum from '%s'", a.Name))
+	}
+	return list
+}
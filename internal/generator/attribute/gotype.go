@@ -0,0 +1,95 @@
+package attribute
+
+import (
+	"go/token"
+	"path"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// TimeGoType is the go_type of timestamps: Unix seconds in "N" and RFC 3339 strings in "S" attributes.
+const TimeGoType = "time.Time"
+
+// HasCustomGoType returns true if the attribute declares a go_type.
+func (a Attribute) HasCustomGoType() bool {
+	return a.CustomGoType != ""
+}
+
+// BaseFieldType returns the Go type of the struct field without the pointer of optional attributes:
+// the go_type qualified by its package name, or the base type of the DynamoDB type.
+//
+// Examples:
+//
+//	{"type": "N", "go_type": "time.Time"}                    → "time.Time"
+//	{"type": "M", "go_type": "github.com/acme/money.Amount"} → "money.Amount"
+//	{"type": "S"}                                            → "string"
+func (a Attribute) BaseFieldType() string {
+	if !a.HasCustomGoType() {
+		return ToGolangBaseType(a)
+	}
+	return path.Base(a.CustomGoType)
+}
+
+// FieldExampleValue returns a sample value expression of the struct field type, used in generated doc examples.
+func (a Attribute) FieldExampleValue() string {
+	switch {
+	case a.CustomGoType == TimeGoType:
+		return "time.Now()"
+	case a.HasCustomGoType():
+		return a.BaseFieldType() + "{}"
+	}
+	return a.ExampleValue()
+}
+
+// GoTypeImport returns the import path of the package of the go_type, empty without go_type.
+//
+// Examples:
+//
+//	{"go_type": "time.Time"}                    → "time"
+//	{"go_type": "github.com/acme/money.Amount"} → "github.com/acme/money"
+func (a Attribute) GoTypeImport() string {
+	pkg, _ := splitGoType(a.CustomGoType)
+	return pkg
+}
+
+// splitGoType splits a go_type into the import path and the type name:
+// the last path element is the package name, followed by the type after a dot.
+func splitGoType(goType string) (string, string) {
+	dir, base := path.Split(goType)
+	pkg, name, ok := strings.Cut(base, ".")
+	if !ok {
+		return "", ""
+	}
+	return dir + pkg, name
+}
+
+// diagnoseGoType returns problems of the go_type of the attribute at path.
+func (a Attribute) diagnoseGoType(path string) diag.List {
+	var list diag.List
+	if !a.HasCustomGoType() {
+		return list
+	}
+	pkg, name := splitGoType(a.CustomGoType)
+	pkgName := pkg[strings.LastIndex(pkg, "/")+1:]
+	if !token.IsIdentifier(pkgName) || !token.IsIdentifier(name) || !token.IsExported(name) {
+		list = append(list, diag.Errorf(diag.CodeAttributeGoTypeInvalid, path+"/go_type", "invalid go_type '%s' of '%s'", a.CustomGoType, a.Name).
+			Suggest("use time.Time or an exported type qualified by its import path, e.g. github.com/acme/money.Amount"))
+	} else if a.CustomGoType == TimeGoType && a.Type != "N" && a.Type != "S" {
+		list = append(list, diag.Errorf(diag.CodeAttributeGoTypeInvalid, path+"/go_type", "go_type '%s' of '%s' must be of type 'N' or 'S', got '%s'", TimeGoType, a.Name, a.Type).
+			Suggest("set type of '%s' to 'N' for Unix seconds or 'S' for RFC 3339", a.Name))
+	}
+	if a.Subtype != SubtypeDefault {
+		list = append(list, diag.Errorf(diag.CodeAttributeGoTypeConflict, path+"/go_type", "attribute '%s' can't declare both subtype and go_type", a.Name).
+			Suggest("remove subtype from '%s'", a.Name))
+	}
+	if a.IsTypedList() {
+		list = append(list, diag.Errorf(diag.CodeAttributeGoTypeConflict, path+"/go_type", "typed list '%s' can't declare go_type", a.Name).
+			Suggest("declare '%s' as 'L' or remove go_type", a.Name))
+	}
+	if a.IsComputed() || a.Version || a.IsAuto() || a.Checksum || a.TTL || a.FieldVersions {
+		list = append(list, diag.Errorf(diag.CodeAttributeGoTypeConflict, path+"/go_type", "attribute '%s' is written by generated code and can't declare go_type", a.Name).
+			Suggest("remove go_type from '%s'", a.Name))
+	}
+	return list
+}
@@ -0,0 +1,132 @@
+package attribute
+
+import (
+	"encoding/json"
+	"go/token"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// listElementTypes lists the element types of typed lists ("L<S>") with their Go types.
+// Maps of named struct elements ("L<M:Address>") are declared with "fields".
+var listElementTypes = map[string]string{
+	"S":    "string",
+	"N":    "int",
+	"B":    "[]byte",
+	"BOOL": "bool",
+	"M":    "map[string]any",
+}
+
+// UnmarshalJSON decodes an attribute, splitting typed lists ("L<S>", "L<M:Address>")
+// into the "L" type and the element type.
+func (a *Attribute) UnmarshalJSON(data []byte) error {
+	type plain Attribute
+	if err := json.Unmarshal(data, (*plain)(a)); err != nil {
+		return err
+	}
+	if elem, ok := strings.CutPrefix(a.Type, "L<"); ok && strings.HasSuffix(elem, ">") {
+		a.Type, a.Element = "L", strings.TrimSuffix(elem, ">")
+	}
+	return nil
+}
+
+// MarshalJSON encodes an attribute with the type as declared in the schema.
+func (a Attribute) MarshalJSON() ([]byte, error) {
+	type plain Attribute
+	p := plain(a)
+	p.Type = a.TypeDecl()
+	return json.Marshal(p)
+}
+
+// TypeDecl returns the type as declared in the schema, with the element type of typed lists.
+func (a Attribute) TypeDecl() string {
+	if a.Type == "L" && a.Element != "" {
+		return "L<" + a.Element + ">"
+	}
+	return a.Type
+}
+
+// IsTypedList returns true if the attribute is a list with a declared element type.
+func (a Attribute) IsTypedList() bool {
+	return a.Type == "L" && a.Element != ""
+}
+
+// ElementStruct returns the Go struct name of "L<M:Name>" elements, empty for other attributes.
+func (a Attribute) ElementStruct() string {
+	if !a.IsTypedList() {
+		return ""
+	}
+	name, _ := strings.CutPrefix(a.Element, "M:")
+	if name == a.Element {
+		return ""
+	}
+	return name
+}
+
+// ElementGoType returns the Go type of the elements of a typed list.
+func (a Attribute) ElementGoType() string {
+	if name := a.ElementStruct(); name != "" {
+		return name
+	}
+	if goType, ok := listElementTypes[a.Element]; ok {
+		return goType
+	}
+	return "any"
+}
+
+// diagnoseList returns problems of the element type and fields of a typed list at path.
+func (a Attribute) diagnoseList(path string) diag.List {
+	var list diag.List
+	if len(a.Fields) > 0 && a.ElementStruct() == "" {
+		list = append(list, diag.Errorf(diag.CodeAttributeListElementInvalid, path+"/fields", "fields of '%s' need a struct element type", a.Name).
+			Suggest("set type of '%s' to 'L<M:Name>' or remove fields", a.Name))
+	}
+	if !a.IsTypedList() {
+		return list
+	}
+
+	name := a.ElementStruct()
+	if _, ok := listElementTypes[a.Element]; !ok && name == "" {
+		return append(list, diag.Errorf(diag.CodeAttributeListElementInvalid, path+"/type", "invalid list element type '%s' of '%s'", a.Element, a.Name).
+			Suggest("use L<S>, L<N>, L<B>, L<BOOL>, L<M> or L<M:Name> with fields"))
+	}
+	if name == "" {
+		return list
+	}
+	if !token.IsIdentifier(name) || !token.IsExported(name) {
+		list = append(list, diag.Errorf(diag.CodeAttributeListElementInvalid, path+"/type", "list element struct '%s' of '%s' must be an exported Go identifier", name, a.Name).
+			Suggest("start the struct name with an upper-case letter"))
+	}
+	if len(a.Fields) == 0 {
+		list = append(list, diag.Errorf(diag.CodeAttributeListElementInvalid, path+"/fields", "list element struct '%s' of '%s' has no fields", name, a.Name).
+			Suggest("declare the attributes of the elements in fields"))
+	}
+	seen := map[string]bool{}
+	for i, field := range a.Fields {
+		fieldPath := path + diag.Pointer("fields", i)
+		if field.ElementStruct() != "" || len(field.Fields) > 0 {
+			list = append(list, diag.Errorf(diag.CodeAttributeListElementInvalid, fieldPath+"/type", "field '%s' of '%s' can't be a list of structs", field.Name, name).
+				Suggest("use L<M> for nested lists of maps"))
+			continue
+		}
+		if seen[field.GoName()] {
+			list = append(list, diag.Errorf(diag.CodeAttributeGoNameCollision, fieldPath+"/name", "field '%s' of '%s' collides with another field as Go name '%s'", field.Name, name, field.GoName()).
+				Suggest("rename the field or set go_name"))
+		}
+		seen[field.GoName()] = true
+		list = append(list, field.Diagnose(fieldPath)...)
+	}
+	return list
+}
+
+// ElementExampleValue returns a sample element expression of a typed list, used in generated doc examples.
+func (a Attribute) ElementExampleValue() string {
+	switch {
+	case a.ElementStruct() != "":
+		return a.ElementStruct() + "{}"
+	case a.Element == "M":
+		return "map[string]any{}"
+	}
+	return Attribute{Name: a.Name, Type: a.Element}.ExampleValue()
+}
@@ -0,0 +1,73 @@
+package attribute
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// Normalization steps of normalized attributes, applied in this order.
+const (
+	NormalizeNFC  = "nfc"
+	NormalizeTrim = "trim"
+	NormalizeFold = "fold"
+)
+
+// normalizeSteps are the normalization steps in the order they are applied.
+var normalizeSteps = []string{NormalizeNFC, NormalizeTrim, NormalizeFold}
+
+// IsNormalized returns true if values of the attribute are normalized by generated key and query builders.
+func (a Attribute) IsNormalized() bool {
+	return a.Normalized || len(a.Normalize) > 0
+}
+
+// NormalizeSteps returns the normalization steps of the attribute in the order they are applied:
+// all of them when only "normalized" is declared.
+//
+// Examples:
+//
+//	{"normalized": true}            → ["nfc", "trim", "fold"]
+//	{"normalize": ["fold", "trim"]} → ["trim", "fold"]
+func (a Attribute) NormalizeSteps() []string {
+	if !a.IsNormalized() {
+		return nil
+	}
+	if len(a.Normalize) == 0 {
+		return normalizeSteps
+	}
+	var steps []string
+	for _, step := range normalizeSteps {
+		if slices.Contains(a.Normalize, step) {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// diagnoseNormalize returns problems of the normalization of the attribute at path.
+func (a Attribute) diagnoseNormalize(path string) diag.List {
+	var list diag.List
+	if !a.IsNormalized() {
+		return list
+	}
+	if a.Type != "S" {
+		list = append(list, diag.Errorf(diag.CodeAttributeNormalizeInvalid, path+"/normalized", "normalized attribute '%s' must be of type 'S', got '%s'", a.Name, a.Type).
+			Suggest("remove normalized from '%s'", a.Name))
+	}
+	for i, step := range a.Normalize {
+		if !slices.Contains(normalizeSteps, step) {
+			list = append(list, diag.Errorf(diag.CodeAttributeNormalizeInvalid, path+diag.Pointer("normalize", i), "unknown normalization step '%s' of '%s'", step, a.Name).
+				Suggest("use %s", strings.Join(normalizeSteps, ", ")))
+		}
+	}
+	if a.IsEnum() || a.HasCustomGoType() {
+		list = append(list, diag.Errorf(diag.CodeAttributeNormalizeInvalid, path+"/normalized", "normalized attribute '%s' can't declare enum or go_type", a.Name).
+			Suggest("remove normalized from '%s'", a.Name))
+	}
+	if a.IsComputed() || a.IsAuto() || a.Checksum {
+		list = append(list, diag.Errorf(diag.CodeAttributeNormalizeInvalid, path+"/normalized", "attribute '%s' is written by generated code and can't be normalized", a.Name).
+			Suggest("remove normalized from '%s'", a.Name))
+	}
+	return list
+}
@@ -0,0 +1,46 @@
+package attribute
+
+import "github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+
+// optionalTypes are the DynamoDB types of attributes which can be optional.
+// Other types generate slice or map fields, which absent attributes already leave nil.
+var optionalTypes = map[string]bool{
+	"S":    true,
+	"N":    true,
+	"BOOL": true,
+}
+
+// IsOptional returns true if the attribute is declared with "required": false.
+func (a Attribute) IsOptional() bool {
+	return a.Required != nil && !*a.Required
+}
+
+// FieldType returns the Go type of the struct field: a pointer to the base type for optional attributes.
+//
+// Examples:
+//
+//	{"type": "S"}                    → "string"
+//	{"type": "N", "required": false} → "*int"
+func (a Attribute) FieldType() string {
+	if a.IsOptional() {
+		return "*" + a.BaseFieldType()
+	}
+	return a.BaseFieldType()
+}
+
+// diagnoseOptional returns problems of an optional attribute at path.
+func (a Attribute) diagnoseOptional(path string) diag.List {
+	var list diag.List
+	if !a.IsOptional() {
+		return list
+	}
+	if !optionalTypes[a.Type] {
+		list = append(list, diag.Errorf(diag.CodeAttributeOptionalInvalid, path+"/required", "optional attribute '%s' must be of type 'S', 'N' or 'BOOL', got '%s'", a.Name, a.Type).
+			Suggest("remove required, absent attributes of type '%s' are nil", a.Type))
+	}
+	if a.IsComputed() || a.Version || a.IsAuto() || a.Checksum || a.TTL || a.FieldVersions {
+		list = append(list, diag.Errorf(diag.CodeAttributeOptionalConflict, path+"/required", "attribute '%s' is written by generated code and can't be optional", a.Name).
+			Suggest("remove required from '%s'", a.Name))
+	}
+	return list
+}
@@ -0,0 +1,64 @@
+package attribute
+
+import (
+	"go/token"
+	"path"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// PayloadProtoPrefix prefixes the message type of protobuf payload attributes.
+const PayloadProtoPrefix = "proto:"
+
+// IsPayload returns true if the attribute declares a payload message.
+func (a Attribute) IsPayload() bool {
+	return a.Payload != ""
+}
+
+// PayloadImport returns the import path of the package of the payload message, empty without payload.
+//
+// Examples:
+//
+//	{"payload": "proto:github.com/acme/events/eventspb.OrderPlaced"} → "github.com/acme/events/eventspb"
+func (a Attribute) PayloadImport() string {
+	pkg, _ := splitGoType(strings.TrimPrefix(a.Payload, PayloadProtoPrefix))
+	return pkg
+}
+
+// PayloadType returns the Go type of the payload message qualified by its package name.
+//
+// Examples:
+//
+//	{"payload": "proto:github.com/acme/events/eventspb.OrderPlaced"} → "eventspb.OrderPlaced"
+func (a Attribute) PayloadType() string {
+	return path.Base(strings.TrimPrefix(a.Payload, PayloadProtoPrefix))
+}
+
+// diagnosePayload returns problems of the payload message of the attribute at path.
+func (a Attribute) diagnosePayload(path string) diag.List {
+	var list diag.List
+	if !a.IsPayload() {
+		return list
+	}
+	message, ok := strings.CutPrefix(a.Payload, PayloadProtoPrefix)
+	pkg, name := splitGoType(message)
+	pkgName := pkg[strings.LastIndex(pkg, "/")+1:]
+	if !ok || !token.IsIdentifier(pkgName) || !token.IsIdentifier(name) || !token.IsExported(name) {
+		list = append(list, diag.Errorf(diag.CodeAttributePayloadInvalid, path+"/payload", "invalid payload '%s' of '%s'", a.Payload, a.Name).
+			Suggest("qualify the generated message type by its Go import path, e.g. proto:github.com/acme/events/eventspb.OrderPlaced"))
+	}
+	if a.Type != "B" {
+		list = append(list, diag.Errorf(diag.CodeAttributePayloadInvalid, path+"/type", "payload attribute '%s' must be of type 'B', got '%s'", a.Name, a.Type).
+			Suggest("set type of '%s' to 'B', payloads are stored as compressed bytes", a.Name))
+	}
+	if a.HasCustomGoType() {
+		list = append(list, diag.Errorf(diag.CodeAttributePayloadConflict, path+"/go_type", "payload attribute '%s' can't declare go_type", a.Name).
+			Suggest("remove go_type, the payload accessors convert the bytes"))
+	}
+	if a.IsComputed() || a.Version || a.IsAuto() || a.Checksum || a.TTL || a.FieldVersions {
+		list = append(list, diag.Errorf(diag.CodeAttributePayloadConflict, path+"/payload", "attribute '%s' is written by generated code and can't hold a payload", a.Name).
+			Suggest("remove payload from '%s'", a.Name))
+	}
+	return list
+}
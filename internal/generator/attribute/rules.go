@@ -0,0 +1,71 @@
+package attribute
+
+import (
+	"regexp"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// lengthRuleTypes are the DynamoDB types supporting min_length and max_length:
+// characters of strings, bytes of binaries and elements of sets, lists and maps.
+var lengthRuleTypes = map[string]bool{
+	"S":  true,
+	"B":  true,
+	"SS": true,
+	"NS": true,
+	"BS": true,
+	"L":  true,
+	"M":  true,
+}
+
+// HasRules returns true if the attribute declares validation rules checked by the generated Validate.
+func (a Attribute) HasRules() bool {
+	return a.HasLengthRule() || a.Min != nil || a.Max != nil || a.Pattern != ""
+}
+
+// HasLengthRule returns true if the attribute declares min_length or max_length.
+func (a Attribute) HasLengthRule() bool {
+	return a.MinLength != nil || a.MaxLength != nil
+}
+
+// diagnoseRules returns problems of the validation rules of the attribute at path.
+func (a Attribute) diagnoseRules(path string) diag.List {
+	var list diag.List
+	if !a.HasRules() {
+		return list
+	}
+	if a.HasLengthRule() && !lengthRuleTypes[a.Type] {
+		list = append(list, diag.Errorf(diag.CodeAttributeRuleInvalid, path+"/min_length", "length rules of '%s' don't support DynamoDB type '%s'", a.Name, a.Type).
+			Suggest("use min and max for numbers"))
+	}
+	if (a.MinLength != nil && *a.MinLength < 0) || (a.MaxLength != nil && *a.MaxLength < 0) {
+		list = append(list, diag.Errorf(diag.CodeAttributeRuleInvalid, path+"/min_length", "length rules of '%s' can't be negative", a.Name).
+			Suggest("use a min_length and max_length of 0 or more"))
+	}
+	if a.MinLength != nil && a.MaxLength != nil && *a.MinLength > *a.MaxLength {
+		list = append(list, diag.Errorf(diag.CodeAttributeRuleInvalid, path+"/max_length", "max_length %d of '%s' is less than min_length %d", *a.MaxLength, a.Name, *a.MinLength).
+			Suggest("swap min_length and max_length"))
+	}
+	if (a.Min != nil || a.Max != nil) && a.Type != "N" {
+		list = append(list, diag.Errorf(diag.CodeAttributeRuleInvalid, path+"/min", "range rules of '%s' need type 'N', got '%s'", a.Name, a.Type).
+			Suggest("use min_length and max_length for type '%s'", a.Type))
+	}
+	if a.Min != nil && a.Max != nil && *a.Min > *a.Max {
+		list = append(list, diag.Errorf(diag.CodeAttributeRuleInvalid, path+"/max", "max %v of '%s' is less than min %v", *a.Max, a.Name, *a.Min).
+			Suggest("swap min and max"))
+	}
+	if a.Pattern != "" {
+		if a.Type != "S" {
+			list = append(list, diag.Errorf(diag.CodeAttributeRuleInvalid, path+"/pattern", "pattern of '%s' needs type 'S', got '%s'", a.Name, a.Type).
+				Suggest("remove pattern from '%s'", a.Name))
+		} else if _, err := regexp.Compile(a.Pattern); err != nil {
+			list = append(list, diag.Errorf(diag.CodeAttributeRuleInvalid, path+"/pattern", "invalid pattern of '%s': %v", a.Name, err).
+				Suggest("use Go regexp (RE2) syntax"))
+		}
+	}
+	if a.HasCustomGoType() {
+		list = append(list, diag.Errorf(diag.CodeAttributeRuleInvalid, path+"/go_type", "attribute '%s' with go_type can't declare validation rules", a.Name).
+			Suggest("validate '%s' in a BeforePut hook", a.Name))
+	}
+	return list
+}
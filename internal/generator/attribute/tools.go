@@ -2,6 +2,7 @@ package attribute
 
 import (
 	"fmt"
+	"sort"
 )
 
 // GetUsedNumericSetTypes returns a list of distinct Go slice types used for DynamoDB "NS" (Number Set) attributes.
@@ -11,7 +12,7 @@ import (
 // it defaults to `[]int`.
 //
 // This function is useful for code generation, particularly when producing type-switch logic
-// for marshaling numeric sets.
+// for marshaling numeric sets. The result is sorted to keep generated output deterministic.
 //
 // Examples:
 //
@@ -35,6 +36,7 @@ func GetUsedNumericSetTypes(attributes []Attribute) []string {
 	for t := range typesSet {
 		types = append(types, t)
 	}
+	sort.Strings(types)
 	return types
 }
 
@@ -42,24 +44,33 @@ func GetUsedNumericSetTypes(attributes []Attribute) []string {
 //
 // For set types (NS, SS, BS), it appends the corresponding set directive (e.g., `numberset`)
 // to ensure proper encoding when using the AWS SDK for Go v2. For all other types,
-// it generates a standard tag with just the attribute name.
+// it generates a standard tag with just the attribute name. Optional attributes are
+// tagged `omitempty`, so nil fields are left out of the item. "N" attributes of go_type
+// "time.Time" are tagged `unixtime`; other go_types encode themselves.
 //
 // Examples:
 //
 //	ToDynamoDBStructTag(Attribute{Name: "ids", Type: "NS"}) → `dynamodbav:"ids,numberset"`
 //	ToDynamoDBStructTag(Attribute{Name: "tags", Type: "SS"}) → `dynamodbav:"tags,stringset"`
 //	ToDynamoDBStructTag(Attribute{Name: "data", Type: "S"})  → `dynamodbav:"data"`
+//	ToDynamoDBStructTag(Attribute{Name: "at", Type: "N", CustomGoType: "time.Time"}) → `dynamodbav:"at,unixtime"`
 func ToDynamoDBStructTag(attr Attribute) string {
-	switch attr.Type {
-	case "NS":
-		return fmt.Sprintf(`dynamodbav:"%s,numberset"`, attr.Name)
-	case "SS":
-		return fmt.Sprintf(`dynamodbav:"%s,stringset"`, attr.Name)
-	case "BS":
-		return fmt.Sprintf(`dynamodbav:"%s,binaryset"`, attr.Name)
-	default:
-		return fmt.Sprintf(`dynamodbav:"%s"`, attr.Name)
+	tag := attr.Name
+	switch {
+	case attr.CustomGoType == TimeGoType && attr.Type == "N":
+		tag += ",unixtime"
+	case attr.CustomGoType != "":
+	case attr.Type == "NS":
+		tag += ",numberset"
+	case attr.Type == "SS":
+		tag += ",stringset"
+	case attr.Type == "BS":
+		tag += ",binaryset"
+	}
+	if attr.IsOptional() {
+		tag += ",omitempty"
 	}
+	return fmt.Sprintf(`dynamodbav:"%s"`, tag)
 }
 
 // IsIntegerAttr returns true if the given attribute is considered an integer type.
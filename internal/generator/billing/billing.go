@@ -0,0 +1,308 @@
+// Package billing plans switches of a live table between on-demand and provisioned capacity.
+//
+// A plan is built from the live table description and the capacity the table and its GSIs
+// consumed recently (CloudWatch ConsumedRead/WriteCapacityUnits):
+//
+//	on-demand -> provisioned: capacity of the table and every GSI is sized from the peak
+//	                          consumption plus headroom, or taken from the schema
+//	provisioned -> on-demand: capacity settings are dropped
+//
+// Pre-checks block plans that DynamoDB would reject or that are likely to throttle
+// (inactive table or indexes, no consumption data to size capacity from) and warn about
+// risky ones (spiky workloads, GSIs provisioned below the table, recent switches).
+// The result is a single UpdateTable request, printable for 'aws dynamodb update-table'.
+package billing
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+)
+
+const (
+	// spikeRatio is the peak to average consumption ratio from which a workload is considered spiky.
+	spikeRatio = 4
+
+	// switchInterval is the interval in which DynamoDB limits billing mode switches.
+	switchInterval = 24 * time.Hour
+
+	// activeStatus is the status of tables and indexes accepting updates.
+	activeStatus = "ACTIVE"
+)
+
+// Mode is a billing mode.
+type Mode string
+
+const (
+	// OnDemand bills requests (PAY_PER_REQUEST).
+	OnDemand Mode = "on-demand"
+	// Provisioned bills provisioned read and write capacity (PROVISIONED).
+	Provisioned Mode = "provisioned"
+)
+
+// ParseMode returns the billing mode of a CLI value or a DynamoDB BillingMode.
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToUpper(s) {
+	case "ON-DEMAND", "ONDEMAND", "PAY_PER_REQUEST":
+		return OnDemand, nil
+	case "PROVISIONED":
+		return Provisioned, nil
+	}
+	return "", logger.NewFailure("invalid billing mode", nil).
+		With("mode", s).
+		With("hint", "use 'on-demand' or 'provisioned'")
+}
+
+// BillingMode returns the DynamoDB BillingMode of the mode.
+func (m Mode) BillingMode() string {
+	if m == OnDemand {
+		return "PAY_PER_REQUEST"
+	}
+	return "PROVISIONED"
+}
+
+// Table is the live table description relevant for a switch.
+type Table struct {
+	Name   string
+	Status string
+	Mode   Mode
+
+	// LastSwitchToOnDemand is the time of the last switch to on-demand, zero if unknown.
+	LastSwitchToOnDemand time.Time
+
+	// Indexes are the GSIs of the table.
+	Indexes []Index
+}
+
+// Index is a live GSI description.
+type Index struct {
+	Name   string
+	Status string
+}
+
+// Usage is the capacity consumed by a table or GSI over the lookback window, in units per second.
+type Usage struct {
+	PeakRead  float64 `json:"peak_read"`
+	PeakWrite float64 `json:"peak_write"`
+	AvgRead   float64 `json:"avg_read"`
+	AvgWrite  float64 `json:"avg_write"`
+
+	// Datapoints is the number of periods with data, zero if nothing was consumed or published.
+	Datapoints int `json:"datapoints"`
+}
+
+// Options configures a plan.
+type Options struct {
+	// To is the target billing mode.
+	To Mode
+
+	// Headroom is the share added on top of the peak consumption (0.2 for 20%).
+	Headroom float64
+
+	// Declared is the capacity declared for GSIs in the schema, used instead of the sized one.
+	Declared []index.Index
+
+	// Now is the planning time.
+	Now time.Time
+}
+
+// Capacity is the planned capacity of the table (empty Index) or a GSI.
+type Capacity struct {
+	Index  string `json:"index,omitempty"`
+	Read   int64  `json:"read"`
+	Write  int64  `json:"write"`
+	Source string `json:"source"`
+	Usage  Usage  `json:"usage"`
+}
+
+// Plan is a billing mode switch with its pre-check results.
+type Plan struct {
+	Table    string      `json:"table"`
+	From     Mode        `json:"from"`
+	To       Mode        `json:"to"`
+	Capacity []Capacity  `json:"capacity,omitempty"`
+	Warnings []string    `json:"warnings"`
+	Blockers []string    `json:"blockers"`
+	Request  UpdateTable `json:"request"`
+}
+
+// Err returns the blockers of the plan as an error, nil if there are none.
+func (p *Plan) Err() error {
+	if len(p.Blockers) == 0 {
+		return nil
+	}
+	return logger.NewFailure("billing mode switch blocked by pre-checks", nil).
+		With("table", p.Table).
+		With("blockers", p.Blockers)
+}
+
+// UpdateTable is an UpdateTable request in the format of 'aws dynamodb update-table --cli-input-json'.
+type UpdateTable struct {
+	TableName                   string        `json:"TableName"`
+	BillingMode                 string        `json:"BillingMode"`
+	ProvisionedThroughput       *Throughput   `json:"ProvisionedThroughput,omitempty"`
+	GlobalSecondaryIndexUpdates []IndexUpdate `json:"GlobalSecondaryIndexUpdates,omitempty"`
+}
+
+// Throughput is the provisioned capacity of a table or GSI.
+type Throughput struct {
+	ReadCapacityUnits  int64 `json:"ReadCapacityUnits"`
+	WriteCapacityUnits int64 `json:"WriteCapacityUnits"`
+}
+
+// IndexUpdate is a GSI update of an UpdateTable request.
+type IndexUpdate struct {
+	Update struct {
+		IndexName             string     `json:"IndexName"`
+		ProvisionedThroughput Throughput `json:"ProvisionedThroughput"`
+	} `json:"Update"`
+}
+
+// Build plans the switch of table to opts.To. usage holds the consumption of the table
+// (key "") and its GSIs (keyed by index name).
+func Build(table Table, usage map[string]Usage, opts Options) (*Plan, error) {
+	if opts.To != OnDemand && opts.To != Provisioned {
+		return nil, logger.NewFailure("invalid target billing mode", nil).
+			With("mode", opts.To)
+	}
+	if table.Mode == opts.To {
+		return nil, logger.NewFailure("table already uses the billing mode", nil).
+			With("table", table.Name).
+			With("mode", opts.To)
+	}
+	if opts.Headroom < 0 {
+		return nil, logger.NewFailure("headroom must not be negative", nil).
+			With("headroom", opts.Headroom)
+	}
+
+	p := &Plan{
+		Table:    table.Name,
+		From:     table.Mode,
+		To:       opts.To,
+		Warnings: []string{},
+		Blockers: []string{},
+		Request: UpdateTable{
+			TableName:   table.Name,
+			BillingMode: opts.To.BillingMode(),
+		},
+	}
+	if table.Status != activeStatus {
+		p.Blockers = append(p.Blockers, fmt.Sprintf("table status is %s, billing mode can only be switched on ACTIVE tables", table.Status))
+	}
+	for _, idx := range table.Indexes {
+		if idx.Status != activeStatus {
+			p.Blockers = append(p.Blockers, fmt.Sprintf("index %s status is %s, wait until it is ACTIVE", idx.Name, idx.Status))
+		}
+	}
+
+	if opts.To == OnDemand {
+		if !table.LastSwitchToOnDemand.IsZero() && opts.Now.Sub(table.LastSwitchToOnDemand) < switchInterval {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("table switched to on-demand at %s, DynamoDB limits switches within %s", table.LastSwitchToOnDemand.UTC().Format(time.RFC3339), switchInterval))
+		}
+		return p, nil
+	}
+
+	tableCap := p.size("", "table", usage[""], opts.Headroom, true)
+	p.Capacity = append(p.Capacity, tableCap)
+	p.Request.ProvisionedThroughput = &Throughput{ReadCapacityUnits: tableCap.Read, WriteCapacityUnits: tableCap.Write}
+
+	for _, idx := range table.Indexes {
+		i := slices.IndexFunc(opts.Declared, func(d index.Index) bool { return d.Name == idx.Name })
+		declared := i >= 0 && opts.Declared[i].ReadCapacity != nil && opts.Declared[i].WriteCapacity != nil
+		c := p.size(idx.Name, "index "+idx.Name, usage[idx.Name], opts.Headroom, !declared)
+		if i >= 0 {
+			c = p.declared(c, opts.Declared[i])
+		}
+		if c.Write < tableCap.Write {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("index %s write capacity %d is below the table's %d, a throttled GSI throttles writes to the table (fine for sparse indexes)", idx.Name, c.Write, tableCap.Write))
+		}
+		p.Capacity = append(p.Capacity, c)
+
+		var update IndexUpdate
+		update.Update.IndexName = idx.Name
+		update.Update.ProvisionedThroughput = Throughput{ReadCapacityUnits: c.Read, WriteCapacityUnits: c.Write}
+		p.Request.GlobalSecondaryIndexUpdates = append(p.Request.GlobalSecondaryIndexUpdates, update)
+	}
+	for _, d := range opts.Declared {
+		if d.IsGSI() && !slices.ContainsFunc(table.Indexes, func(idx Index) bool { return idx.Name == d.Name }) {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("index %s is declared in the schema but missing on the live table", d.Name))
+		}
+	}
+	return p, nil
+}
+
+// size returns the capacity of the table or a GSI sized from its peak consumption plus headroom.
+// Without consumption data the plan is blocked unless requireUsage is false.
+func (p *Plan) size(name, scope string, u Usage, headroom float64, requireUsage bool) Capacity {
+	if u.Datapoints == 0 && requireUsage {
+		p.Blockers = append(p.Blockers, fmt.Sprintf("%s has no consumed capacity in the lookback window, capacity can't be sized", scope))
+	}
+	for _, m := range []struct {
+		kind      string
+		peak, avg float64
+	}{{"read", u.PeakRead, u.AvgRead}, {"write", u.PeakWrite, u.AvgWrite}} {
+		if m.avg > 0 && m.peak/m.avg >= spikeRatio {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("%s %s peak %.1f/s is %.0fx the average %.1f/s, on-demand or auto scaling may be cheaper than capacity sized for the peak", scope, m.kind, m.peak, m.peak/m.avg, m.avg))
+		}
+	}
+	return Capacity{
+		Index:  name,
+		Read:   units(u.PeakRead, headroom),
+		Write:  units(u.PeakWrite, headroom),
+		Source: "usage",
+		Usage:  u,
+	}
+}
+
+// declared replaces the sized capacity of a GSI by the capacity declared in the schema.
+func (p *Plan) declared(c Capacity, d index.Index) Capacity {
+	if d.ReadCapacity == nil && d.WriteCapacity == nil {
+		return c
+	}
+	sized := c
+	if d.ReadCapacity != nil {
+		c.Read = int64(*d.ReadCapacity)
+	}
+	if d.WriteCapacity != nil {
+		c.Write = int64(*d.WriteCapacity)
+	}
+	c.Source = "schema"
+	if float64(c.Read) < c.Usage.PeakRead || float64(c.Write) < c.Usage.PeakWrite {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("index %s capacity declared in the schema (%d/%d) is below the observed peak (%.1f/%.1f), sized %d/%d", d.Name, c.Read, c.Write, c.Usage.PeakRead, c.Usage.PeakWrite, sized.Read, sized.Write))
+	}
+	return c
+}
+
+// units returns the capacity units covering peak plus headroom, at least 1.
+func units(peak, headroom float64) int64 {
+	return max(int64(math.Ceil(peak*(1+headroom))), 1)
+}
+
+// Summarize returns the usage of a series of consumed capacity sums per period.
+func Summarize(reads, writes []float64, period time.Duration) Usage {
+	var u Usage
+	seconds := period.Seconds()
+	if seconds <= 0 {
+		return u
+	}
+	stat := func(sums []float64) (peak, avg float64) {
+		var total float64
+		for _, s := range sums {
+			peak = max(peak, s/seconds)
+			total += s / seconds
+		}
+		if len(sums) > 0 {
+			avg = total / float64(len(sums))
+		}
+		return peak, avg
+	}
+	u.PeakRead, u.AvgRead = stat(reads)
+	u.PeakWrite, u.AvgWrite = stat(writes)
+	u.Datapoints = max(len(reads), len(writes))
+	return u
+}
@@ -1,7 +1,15 @@
 package generator
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	godyno "github.com/Mad-Pixels/go-dyno"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/example"
 	"github.com/Mad-Pixels/go-dyno/internal/generator/mode"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/module"
 	"github.com/Mad-Pixels/go-dyno/internal/logger"
 	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
 	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
@@ -11,11 +19,18 @@ import (
 // RenderBuilder provides a customizing code generation.
 // Allows overriding schema defaults (package name, filename) via CLI flags.
 type RenderBuilder struct {
-	generator       *Generator
-	mode            *mode.Mode
-	packageName     *string
-	filename        *string
-	useStreamEvents *bool
+	generator        *Generator
+	mode             *mode.Mode
+	packageName      *string
+	filename         *string
+	useStreamEvents  *bool
+	useLogging       *bool
+	useChaos         *bool
+	useBreaker       *bool
+	usePropertyTests *bool
+	useFuzzTests     *bool
+	useDoc           *bool
+	noScan           *bool
 }
 
 // WithPackageName overrides the package name with safe conversion.
@@ -50,15 +65,115 @@ func (rb *RenderBuilder) WithStreamEvents(value bool) *RenderBuilder {
 	return rb
 }
 
+// WithLogging overrides the 'useLogging' flag.
+func (rb *RenderBuilder) WithLogging(value bool) *RenderBuilder {
+	rb.useLogging = &value
+	return rb
+}
+
+// WithChaos overrides the 'useChaos' flag.
+func (rb *RenderBuilder) WithChaos(value bool) *RenderBuilder {
+	rb.useChaos = &value
+	return rb
+}
+
+// WithCircuitBreaker overrides the 'useBreaker' flag.
+func (rb *RenderBuilder) WithCircuitBreaker(value bool) *RenderBuilder {
+	rb.useBreaker = &value
+	return rb
+}
+
+// WithPropertyTests overrides the 'usePropertyTests' flag.
+func (rb *RenderBuilder) WithPropertyTests(value bool) *RenderBuilder {
+	rb.usePropertyTests = &value
+	return rb
+}
+
+// WithFuzzTests overrides the 'useFuzzTests' flag.
+func (rb *RenderBuilder) WithFuzzTests(value bool) *RenderBuilder {
+	rb.useFuzzTests = &value
+	return rb
+}
+
+// WithDoc overrides the 'useDoc' flag.
+func (rb *RenderBuilder) WithDoc(value bool) *RenderBuilder {
+	rb.useDoc = &value
+	return rb
+}
+
+// WithNoScan overrides the 'noScan' flag.
+func (rb *RenderBuilder) WithNoScan(value bool) *RenderBuilder {
+	rb.noScan = &value
+	return rb
+}
+
 // Build renders the final Go code using configured overrides.
-func (rb *RenderBuilder) Build() string {
+func (rb *RenderBuilder) Build() (string, error) {
 	var (
 		tmplMap = rb.buildTemplateMap()
 	)
-	logger.Log.Debug().
+	logger.Log.Trace().
 		Any("data", tmplMap).
 		Msg("Template map prepared")
-	return tmpl.MustParseTemplateFormattedToString(v2.CodeTemplate, tmplMap)
+	return tmpl.ParseTemplateFormattedToString(v2.CodeTemplate, tmplMap)
+}
+
+// BuildTests renders the companion test file using configured overrides.
+func (rb *RenderBuilder) BuildTests() (string, error) {
+	return tmpl.ParseTemplateFormattedToString(v2.TestTemplate, rb.buildTemplateMap())
+}
+
+// HasTests returns true if any option requires the companion test file.
+func (rb *RenderBuilder) HasTests() bool {
+	return rb.GetPropertyTestsOpt() || rb.GetFuzzTestsOpt() || rb.GetChaosOpt()
+}
+
+// BuildDoc renders the package-level doc file using configured overrides.
+func (rb *RenderBuilder) BuildDoc() (string, error) {
+	return tmpl.ParseTemplateFormattedToString(v2.DocTemplate, rb.buildTemplateMap())
+}
+
+// HasDoc returns true if the package-level doc file is requested.
+func (rb *RenderBuilder) HasDoc() bool {
+	return rb.GetDocOpt()
+}
+
+// BuildExample renders main.go of an example application of kind,
+// which imports the generated package from importPath.
+func (rb *RenderBuilder) BuildExample(kind example.Kind, importPath string) (string, error) {
+	return tmpl.ParseTemplateFormattedToString(v2.ExampleTemplates[kind.String()], rb.buildExampleMap(kind, importPath))
+}
+
+// BuildExampleReadme renders README.md of an example application of kind.
+func (rb *RenderBuilder) BuildExampleReadme(kind example.Kind, importPath string) (string, error) {
+	return tmpl.ParseTemplateToString(v2.ExampleReadmeTemplate, rb.buildExampleMap(kind, importPath))
+}
+
+// Fingerprint returns a digest of everything affecting the rendered output:
+// schema content, resolved options and templates. Extend it when a new option is added.
+func (rb *RenderBuilder) Fingerprint() string {
+	h := sha256.New()
+	for _, part := range []string{
+		rb.generator.schema.Hash(),
+		rb.GetPackageName(),
+		rb.GetFilename(),
+		rb.GetMode().String(),
+		strconv.FormatBool(rb.GetStreamEventsOpt()),
+		strconv.FormatBool(rb.GetLoggingOpt()),
+		strconv.FormatBool(rb.GetChaosOpt()),
+		strconv.FormatBool(rb.GetCircuitBreakerOpt()),
+		strconv.FormatBool(rb.GetPropertyTestsOpt()),
+		strconv.FormatBool(rb.GetFuzzTestsOpt()),
+		strconv.FormatBool(rb.GetDocOpt()),
+		strconv.FormatBool(rb.GetNoScanOpt()),
+		v2.CodeTemplate,
+		v2.TestTemplate,
+		v2.DocTemplate,
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 // GetPackageName returns the final package name (override or schema default).
@@ -77,6 +192,16 @@ func (rb *RenderBuilder) GetFilename() string {
 	return rb.generator.schema.Filename()
 }
 
+// GetTestFilename returns the companion test filename (e.g. "users_test.go").
+func (rb *RenderBuilder) GetTestFilename() string {
+	return strings.TrimSuffix(rb.GetFilename(), ".go") + "_test.go"
+}
+
+// GetDocFilename returns the package-level doc filename.
+func (rb *RenderBuilder) GetDocFilename() string {
+	return "doc.go"
+}
+
 // GetStreamEventsOpt return the final option: generate or not DynamoDB event stream methods.
 func (rb *RenderBuilder) GetStreamEventsOpt() bool {
 	if rb.useStreamEvents != nil {
@@ -85,6 +210,62 @@ func (rb *RenderBuilder) GetStreamEventsOpt() bool {
 	return false
 }
 
+// GetLoggingOpt return the final option: generate or not the logging client decorator.
+func (rb *RenderBuilder) GetLoggingOpt() bool {
+	if rb.useLogging != nil {
+		return *rb.useLogging
+	}
+	return false
+}
+
+// GetChaosOpt return the final option: generate or not the fault-injection client decorator in the test file.
+func (rb *RenderBuilder) GetChaosOpt() bool {
+	if rb.useChaos != nil {
+		return *rb.useChaos
+	}
+	return false
+}
+
+// GetCircuitBreakerOpt return the final option: generate or not the circuit breaker client decorator.
+func (rb *RenderBuilder) GetCircuitBreakerOpt() bool {
+	if rb.useBreaker != nil {
+		return *rb.useBreaker
+	}
+	return false
+}
+
+// GetPropertyTestsOpt return the final option: generate or not property-based round-trip tests.
+func (rb *RenderBuilder) GetPropertyTestsOpt() bool {
+	if rb.usePropertyTests != nil {
+		return *rb.usePropertyTests
+	}
+	return false
+}
+
+// GetFuzzTestsOpt return the final option: generate or not fuzz targets.
+func (rb *RenderBuilder) GetFuzzTestsOpt() bool {
+	if rb.useFuzzTests != nil {
+		return *rb.useFuzzTests
+	}
+	return false
+}
+
+// GetDocOpt return the final option: generate or not the package-level doc file.
+func (rb *RenderBuilder) GetDocOpt() bool {
+	if rb.useDoc != nil {
+		return *rb.useDoc
+	}
+	return false
+}
+
+// GetNoScanOpt return the final option: omit or not ScanBuilder and scan operations.
+func (rb *RenderBuilder) GetNoScanOpt() bool {
+	if rb.noScan != nil {
+		return *rb.noScan
+	}
+	return false
+}
+
 // GetMode returns the current generation mode (or default if not set).
 func (rb *RenderBuilder) GetMode() mode.Mode {
 	if rb.mode != nil {
@@ -98,16 +279,56 @@ func (rb *RenderBuilder) buildTemplateMap() v2.TemplateMap {
 	schema := rb.generator.schema
 
 	return v2.TemplateMap{
-		PackageName:      rb.getPackageName(),
-		Mode:             rb.GetMode(),
-		UseStreamEvents:  rb.GetStreamEventsOpt(),
-		TableName:        schema.TableName(),
-		HashKey:          schema.HashKey(),
-		RangeKey:         schema.RangeKey(),
-		Attributes:       schema.Attributes(),
-		CommonAttributes: schema.CommonAttributes(),
-		AllAttributes:    schema.AllAttributes(),
-		SecondaryIndexes: schema.SecondaryIndexes(),
+		PackageName:        rb.getPackageName(),
+		Mode:               rb.GetMode(),
+		UseStreamEvents:    rb.GetStreamEventsOpt(),
+		UseLogging:         rb.GetLoggingOpt(),
+		UseChaos:           rb.GetChaosOpt(),
+		UseBreaker:         rb.GetCircuitBreakerOpt(),
+		UsePropertyTests:   rb.GetPropertyTestsOpt(),
+		UseFuzzTests:       rb.GetFuzzTestsOpt(),
+		UseDoc:             rb.GetDocOpt(),
+		NoScan:             rb.GetNoScanOpt(),
+		TableName:          schema.TableName(),
+		SchemaHash:         schema.Hash(),
+		SchemaVersion:      schema.Version(),
+		GeneratorVersion:   godyno.Version,
+		MinDynamoDBVersion: module.DynamoDBVersion,
+		HashKey:            schema.HashKey(),
+		RangeKey:           schema.RangeKey(),
+		Attributes:         schema.Attributes(),
+		CommonAttributes:   schema.CommonAttributes(),
+		AllAttributes:      schema.AllAttributes(),
+		SecondaryIndexes:   schema.SecondaryIndexes(),
+		CompositeKeys:      schema.CompositeKeys(),
+
+		KMSKeyID:          schema.Encryption().KMSKeyID,
+		EncryptionContext: schema.Encryption().Context,
+		TableClass:        schema.TableClass(),
+		WarmThroughput:    schema.WarmThroughput(),
+		Replicas:          schema.ReplicaRegions(),
+		Locks:             schema.HasLocks(),
+		LockKeyPrefix:     schema.Locks().KeyPrefix,
+		LockSortKey:       schema.Locks().SortKey,
+		Quotas:            schema.HasQuotas(),
+		QuotaKeyPrefix:    schema.Quotas().KeyPrefix,
+		QuotaSortKey:      schema.Quotas().SortKey,
+		QueueIndex:        schema.QueueIndex(),
+		SessionIndex:      schema.SessionUserIndex(),
+		EventLog:          schema.HasEventLog(),
+		SnapshotKeyPrefix: schema.EventLog().SnapshotPrefix,
+		SnapshotEvery:     schema.EventLog().SnapshotEvery,
+		PruneTimeUnit:     schema.PruneTimeUnit(),
+		Queries:           schema.Queries(),
+	}
+}
+
+// buildExampleMap creates example template data on top of the template map.
+func (rb *RenderBuilder) buildExampleMap(kind example.Kind, importPath string) v2.ExampleMap {
+	return v2.ExampleMap{
+		TemplateMap: rb.buildTemplateMap(),
+		Kind:        kind.String(),
+		ImportPath:  importPath,
 	}
 }
 
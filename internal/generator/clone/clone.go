@@ -0,0 +1,228 @@
+// Package clone copies items of a live table into another table, optionally anonymizing PII.
+//
+// Items are read with Scan and written with BatchWriteItem (25 items per request), with
+// unprocessed items retried using exponential backoff. Writes are paced to a rate in items
+// per second so copying production data doesn't starve the source or target tables.
+package clone
+
+import (
+	"context"
+	"time"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/anonymize"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// batchSize is the BatchWriteItem limit of items per request.
+	batchSize = 25
+
+	// maxRetries bounds retries of unprocessed items of one batch.
+	maxRetries = 8
+
+	// baseBackoff is the first retry delay of unprocessed items, doubled on every retry.
+	baseBackoff = 50 * time.Millisecond
+)
+
+// Client is the subset of the DynamoDB client used by Run.
+type Client interface {
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+}
+
+// Options configures a copy.
+type Options struct {
+	// Source and Target are the table names.
+	Source string
+	Target string
+
+	// Anonymizer rewrites PII attributes of every item, nil copies items as is.
+	Anonymizer *anonymize.Anonymizer
+
+	// Rate is the maximum number of items written per second, 0 for unlimited.
+	Rate int
+
+	// Progress is called after every scanned page with the number of items written so far; it may be nil.
+	Progress func(written int)
+}
+
+// Result is the summary of a copy.
+type Result struct {
+	Written    int `json:"written"`
+	Anonymized int `json:"anonymized"` // attribute values replaced or removed
+	Retries    int `json:"retries"`
+}
+
+// Run copies all items of opts.Source into opts.Target.
+func Run(ctx context.Context, client Client, opts Options) (*Result, error) {
+	if opts.Source == opts.Target {
+		return nil, logger.NewFailure("source and target tables must differ", nil).
+			With("table", opts.Source)
+	}
+	var (
+		res     = &Result{}
+		started = time.Now()
+		input   = &dynamodb.ScanInput{TableName: aws.String(opts.Source)}
+		batch   = make([]types.WriteRequest, 0, batchSize)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := write(ctx, client, opts.Target, batch, res); err != nil {
+			return err
+		}
+		res.Written += len(batch)
+		batch = batch[:0]
+		return pace(ctx, started, res.Written, opts.Rate)
+	}
+
+	for {
+		out, err := client.Scan(ctx, input)
+		if err != nil {
+			return nil, logger.NewFailure("failed to scan source table", err).
+				With("table", opts.Source)
+		}
+		for _, item := range out.Items {
+			if opts.Anonymizer != nil {
+				res.Anonymized += Anonymize(opts.Anonymizer, item)
+			}
+			batch = append(batch, types.WriteRequest{PutRequest: &types.PutRequest{Item: item}})
+			if len(batch) == batchSize {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if opts.Progress != nil {
+			opts.Progress(res.Written)
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// write sends one batch, retrying unprocessed items with exponential backoff.
+func write(ctx context.Context, client Client, table string, batch []types.WriteRequest, res *Result) error {
+	requests := map[string][]types.WriteRequest{table: batch}
+	for attempt := 0; ; attempt++ {
+		out, err := client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{RequestItems: requests})
+		if err != nil {
+			return logger.NewFailure("failed to write target table", err).
+				With("table", table)
+		}
+		if len(out.UnprocessedItems[table]) == 0 {
+			return nil
+		}
+		if attempt == maxRetries {
+			return logger.NewFailure("target table keeps throttling writes", nil).
+				With("table", table).
+				With("unprocessed", len(out.UnprocessedItems[table]))
+		}
+		res.Retries++
+		requests = out.UnprocessedItems
+		if err := sleep(ctx, baseBackoff<<attempt); err != nil {
+			return err
+		}
+	}
+}
+
+// pace sleeps until written items fit into rate items per second since started.
+func pace(ctx context.Context, started time.Time, written, rate int) error {
+	if rate <= 0 {
+		return nil
+	}
+	due := started.Add(time.Duration(written) * time.Second / time.Duration(rate))
+	return sleep(ctx, time.Until(due))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Anonymize rewrites the PII attributes of item in place and returns the number of
+// replaced or removed values.
+func Anonymize(a *anonymize.Anonymizer, item map[string]types.AttributeValue) int {
+	var changed int
+	for name, value := range item {
+		if a.IsComposite(name) {
+			if s, ok := value.(*types.AttributeValueMemberS); ok {
+				anonymized, _ := a.Composite(name, s.Value)
+				item[name] = &types.AttributeValueMemberS{Value: anonymized}
+				changed++
+			}
+			continue
+		}
+		strategy, ok := a.Strategy(name)
+		if !ok {
+			continue
+		}
+		changed++
+		if strategy == attribute.AnonymizeNull {
+			delete(item, name)
+			continue
+		}
+		item[name] = anonymizeValue(a, name, value)
+	}
+	return changed
+}
+
+// anonymizeValue replaces a value by the strategy of the attribute. Types the strategy
+// doesn't support (rejected by schema validation) are replaced by NULL.
+func anonymizeValue(a *anonymize.Anonymizer, name string, value types.AttributeValue) types.AttributeValue {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return &types.AttributeValueMemberS{Value: a.String(name, v.Value)}
+	case *types.AttributeValueMemberN:
+		return &types.AttributeValueMemberN{Value: a.Number(name, v.Value)}
+	case *types.AttributeValueMemberB:
+		return &types.AttributeValueMemberB{Value: a.Bytes(name, v.Value)}
+	case *types.AttributeValueMemberSS:
+		return &types.AttributeValueMemberSS{Value: distinct(v.Value, func(s string) string { return a.String(name, s) })}
+	case *types.AttributeValueMemberNS:
+		return &types.AttributeValueMemberNS{Value: distinct(v.Value, func(n string) string { return a.Number(name, n) })}
+	case *types.AttributeValueMemberBS:
+		out := make([][]byte, 0, len(v.Value))
+		for _, b := range v.Value {
+			out = append(out, a.Bytes(name, b))
+		}
+		return &types.AttributeValueMemberBS{Value: out}
+	}
+	return &types.AttributeValueMemberNULL{Value: true}
+}
+
+// distinct maps set members, dropping duplicates DynamoDB rejects in sets (fakes may collide).
+func distinct(values []string, fn func(string) string) []string {
+	var (
+		out  = make([]string, 0, len(values))
+		seen = make(map[string]bool, len(values))
+	)
+	for _, v := range values {
+		if mapped := fn(v); !seen[mapped] {
+			seen[mapped] = true
+			out = append(out, mapped)
+		}
+	}
+	return out
+}
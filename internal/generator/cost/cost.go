@@ -0,0 +1,372 @@
+// Package cost estimates DynamoDB capacity and monthly price of a workload on a schema.
+//
+// A workload lists access patterns with their request rates:
+//
+//	{
+//	  "item_size": 2048,
+//	  "patterns": [
+//	    {"name": "get post", "operation": "get", "rate": 200},
+//	    {"name": "posts by category", "operation": "query", "hash_key": "category", "items": 20, "rate": 50},
+//	    {"name": "publish post", "operation": "put", "rate": 5}
+//	  ]
+//	}
+//
+// Queries are planned on the schema like the generated query builder does: the table
+// when the keys match the primary key, otherwise the index with matching keys.
+// Reads of KEYS_ONLY and INCLUDE indexes are sized by the projected share of attributes,
+// and every write is multiplied into each secondary index.
+package cost
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+)
+
+const (
+	// DefaultItemSize is the item size assumed when the workload doesn't set one (bytes).
+	DefaultItemSize = 1024
+
+	// readUnitSize is the item size covered by one read capacity unit.
+	readUnitSize = 4096
+
+	// writeUnitSize is the item size covered by one write capacity unit.
+	writeUnitSize = 1024
+
+	// secondsPerMonth is the number of seconds in an average month (730 hours).
+	secondsPerMonth = 730 * 3600
+
+	// hoursPerMonth is the number of hours in an average month.
+	hoursPerMonth = 730
+)
+
+// Operation is a DynamoDB operation of an access pattern.
+type Operation string
+
+const (
+	// Get reads one item by primary key.
+	Get Operation = "get"
+	// Query reads items of a partition of the table or an index.
+	Query Operation = "query"
+	// Scan reads items of the whole table.
+	Scan Operation = "scan"
+	// Put writes a whole item.
+	Put Operation = "put"
+	// Update modifies an item.
+	Update Operation = "update"
+	// Delete removes an item.
+	Delete Operation = "delete"
+)
+
+var validOperations = map[Operation]bool{Get: true, Query: true, Scan: true, Put: true, Update: true, Delete: true}
+
+// IsWrite returns true for operations consuming write capacity.
+func (o Operation) IsWrite() bool {
+	return o == Put || o == Update || o == Delete
+}
+
+// Pricing defines prices in USD. Zero fields use the us-east-1 Standard table class prices.
+type Pricing struct {
+	OnDemandReadPerMillion  float64 `json:"on_demand_read_per_million,omitempty"`
+	OnDemandWritePerMillion float64 `json:"on_demand_write_per_million,omitempty"`
+	ProvisionedRCUHour      float64 `json:"provisioned_rcu_hour,omitempty"`
+	ProvisionedWCUHour      float64 `json:"provisioned_wcu_hour,omitempty"`
+}
+
+// DefaultPricing are us-east-1 Standard table class prices.
+var DefaultPricing = Pricing{
+	OnDemandReadPerMillion:  0.125,
+	OnDemandWritePerMillion: 0.625,
+	ProvisionedRCUHour:      0.00013,
+	ProvisionedWCUHour:      0.00065,
+}
+
+// Workload is the description of the expected traffic.
+type Workload struct {
+	// ItemSize is the average item size in bytes (DefaultItemSize if not set).
+	ItemSize int `json:"item_size,omitempty"`
+
+	// Pricing overrides DefaultPricing, e.g. for another region.
+	Pricing Pricing `json:"pricing,omitempty"`
+
+	// Patterns are the access patterns.
+	Patterns []Pattern `json:"patterns"`
+}
+
+// Pattern is one access pattern.
+type Pattern struct {
+	Name      string    `json:"name"`
+	Operation Operation `json:"operation"`
+
+	// Rate is the number of requests per second.
+	Rate float64 `json:"rate"`
+
+	// HashKey and RangeKey are the key attributes of a query (HashKey defaults to the table hash key).
+	HashKey  string `json:"hash_key,omitempty"`
+	RangeKey string `json:"range_key,omitempty"`
+
+	// Index forces the index of a query instead of planning it.
+	Index string `json:"index,omitempty"`
+
+	// Items is the number of items read per query or scan request (1 if not set).
+	Items int `json:"items,omitempty"`
+
+	// ItemSize overrides the workload item size for this pattern.
+	ItemSize int `json:"item_size,omitempty"`
+
+	// Consistent marks strongly consistent reads (double read cost).
+	Consistent bool `json:"consistent,omitempty"`
+}
+
+// LoadWorkload reads a workload description from a JSON file.
+func LoadWorkload(path string) (*Workload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, logger.NewFailure("failed to read workload", err).
+			With("path", path)
+	}
+	var w Workload
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, logger.NewFailure("failed to parse workload", err).
+			With("path", path)
+	}
+	return &w, nil
+}
+
+// Estimate is the capacity and price estimate of a workload.
+type Estimate struct {
+	Table       string             `json:"table"`
+	Patterns    []PatternEstimate  `json:"patterns"`
+	Capacity    []CapacityEstimate `json:"capacity"`
+	Provisioned float64            `json:"provisioned_monthly_usd"`
+	OnDemand    float64            `json:"on_demand_monthly_usd"`
+	Warnings    []string           `json:"warnings"`
+}
+
+// PatternEstimate is the capacity consumed by one access pattern.
+type PatternEstimate struct {
+	Name       string  `json:"name"`
+	Operation  string  `json:"operation"`
+	Target     string  `json:"target"` // "table" or the index name
+	ReadUnits  float64 `json:"read_units_per_request,omitempty"`
+	WriteUnits float64 `json:"write_units_per_request,omitempty"`
+	RCU        float64 `json:"rcu"`
+	WCU        float64 `json:"wcu"`
+}
+
+// CapacityEstimate is the capacity required by the table or one GSI.
+// LSIs share the capacity of the table.
+type CapacityEstimate struct {
+	Target      string  `json:"target"`
+	RCU         float64 `json:"rcu"`
+	WCU         float64 `json:"wcu"`
+	Provisioned float64 `json:"provisioned_monthly_usd"`
+	OnDemand    float64 `json:"on_demand_monthly_usd"`
+}
+
+// tableTarget is the capacity target of the base table.
+const tableTarget = "table"
+
+// Calculate estimates the capacity and price of the workload on the schema.
+func Calculate(s *schema.Schema, w *Workload) (*Estimate, error) {
+	if len(w.Patterns) == 0 {
+		return nil, logger.NewFailure("workload has no access patterns", nil)
+	}
+	pricing := w.Pricing.withDefaults()
+	itemSize := w.ItemSize
+	if itemSize <= 0 {
+		itemSize = DefaultItemSize
+	}
+
+	est := &Estimate{
+		Table:    s.TableName(),
+		Patterns: make([]PatternEstimate, 0, len(w.Patterns)),
+		Warnings: []string{},
+	}
+	capacity := map[string]*CapacityEstimate{tableTarget: {Target: tableTarget}}
+	for _, idx := range s.GlobalSecondaryIndexes() {
+		capacity[idx.Name] = &CapacityEstimate{Target: idx.Name}
+	}
+
+	for i, p := range w.Patterns {
+		if !validOperations[p.Operation] {
+			return nil, logger.NewFailure("invalid operation of access pattern", nil).
+				With("pattern", i).
+				With("operation", p.Operation)
+		}
+		if p.Rate < 0 {
+			return nil, logger.NewFailure("negative rate of access pattern", nil).
+				With("pattern", i)
+		}
+		size := p.ItemSize
+		if size <= 0 {
+			size = itemSize
+		}
+		pe := PatternEstimate{Name: p.Name, Operation: string(p.Operation), Target: tableTarget}
+
+		if p.Operation.IsWrite() {
+			pe.WriteUnits = writeUnits(size)
+			pe.WCU = pe.WriteUnits * p.Rate
+			capacity[tableTarget].WCU += pe.WCU
+			for _, idx := range s.SecondaryIndexes() {
+				units := writeUnits(projectedSize(s, idx, size)) * p.Rate
+				if p.Operation == Update {
+					// An update of an index key deletes the old index entry and writes the new one.
+					units *= 2
+				}
+				if idx.IsLSI() {
+					capacity[tableTarget].WCU += units
+				} else {
+					capacity[idx.Name].WCU += units
+				}
+				pe.WCU += units
+			}
+		} else {
+			readSize := size
+			if p.Operation == Query {
+				idx, warning := plan(s, p)
+				if warning != "" {
+					est.Warnings = append(est.Warnings, fmt.Sprintf("%s: %s", p.Name, warning))
+				}
+				if idx != nil {
+					pe.Target = idx.Name
+					readSize = projectedSize(s, *idx, size)
+					if idx.IsGSI() && !strings.EqualFold(idx.ProjectionType, "ALL") {
+						est.Warnings = append(est.Warnings, fmt.Sprintf("%s: index %s projects %s, reading other attributes needs extra table reads", p.Name, idx.Name, idx.ProjectionType))
+					}
+				}
+			}
+			pe.ReadUnits = readUnits(p.Operation, readSize, max(p.Items, 1), p.Consistent)
+			pe.RCU = pe.ReadUnits * p.Rate
+			target := pe.Target
+			if idx := s.GetIndexByName(target); idx != nil && idx.IsLSI() {
+				target = tableTarget
+			}
+			capacity[target].RCU += pe.RCU
+		}
+		est.Patterns = append(est.Patterns, pe)
+	}
+
+	targets := make([]string, 0, len(capacity))
+	for target := range capacity {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		// The table first, then indexes by name.
+		if (targets[i] == tableTarget) != (targets[j] == tableTarget) {
+			return targets[i] == tableTarget
+		}
+		return targets[i] < targets[j]
+	})
+	for _, target := range targets {
+		c := capacity[target]
+		c.RCU = round(c.RCU)
+		c.WCU = round(c.WCU)
+		c.Provisioned = round((math.Ceil(c.RCU)*pricing.ProvisionedRCUHour + math.Ceil(c.WCU)*pricing.ProvisionedWCUHour) * hoursPerMonth)
+		c.OnDemand = round((c.RCU*pricing.OnDemandReadPerMillion + c.WCU*pricing.OnDemandWritePerMillion) * secondsPerMonth / 1e6)
+		est.Provisioned += c.Provisioned
+		est.OnDemand += c.OnDemand
+		est.Capacity = append(est.Capacity, *c)
+	}
+	est.Provisioned = round(est.Provisioned)
+	est.OnDemand = round(est.OnDemand)
+	return est, nil
+}
+
+// plan selects the index serving a query pattern, nil for the table.
+func plan(s *schema.Schema, p Pattern) (*index.Index, string) {
+	if p.Index != "" {
+		if idx := s.GetIndexByName(p.Index); idx != nil {
+			return idx, ""
+		}
+		return nil, fmt.Sprintf("unknown index %s, assuming the table", p.Index)
+	}
+	hashKey := p.HashKey
+	if hashKey == "" {
+		hashKey = s.HashKey()
+	}
+	if hashKey == s.HashKey() && (p.RangeKey == "" || p.RangeKey == s.RangeKey()) {
+		return nil, ""
+	}
+	for _, idx := range s.SecondaryIndexes() {
+		if idx.GetEffectiveHashKey(s.HashKey()) == hashKey && idx.RangeKey == p.RangeKey {
+			return &idx, ""
+		}
+	}
+	if idx := s.GetOptimalIndexForQuery(hashKey); idx != nil {
+		return idx, fmt.Sprintf("no index has range key %s, the range condition becomes a filter", p.RangeKey)
+	}
+	return nil, fmt.Sprintf("no index has hash key %s, the query needs a Scan", hashKey)
+}
+
+// projectedSize estimates the size of an index entry by the share of projected attributes.
+func projectedSize(s *schema.Schema, idx index.Index, itemSize int) int {
+	if strings.EqualFold(idx.ProjectionType, "ALL") {
+		return itemSize
+	}
+	total := len(s.AllAttributes())
+	if total == 0 {
+		return itemSize
+	}
+	projected := map[string]bool{s.HashKey(): true, idx.GetEffectiveHashKey(s.HashKey()): true}
+	if s.RangeKey() != "" {
+		projected[s.RangeKey()] = true
+	}
+	if idx.RangeKey != "" {
+		projected[idx.RangeKey] = true
+	}
+	if strings.EqualFold(idx.ProjectionType, "INCLUDE") {
+		for _, attr := range idx.NonKeyAttributes {
+			projected[attr] = true
+		}
+	}
+	return max(1, itemSize*min(len(projected), total)/total)
+}
+
+// readUnits returns the read capacity units of one request.
+// Get is billed per item, Query and Scan by the total size of the items read.
+func readUnits(op Operation, size, items int, consistent bool) float64 {
+	var units float64
+	if op == Get {
+		units = math.Ceil(float64(size) / readUnitSize)
+	} else {
+		units = math.Ceil(float64(size*items) / readUnitSize)
+	}
+	if !consistent {
+		units /= 2
+	}
+	return units
+}
+
+// writeUnits returns the write capacity units of one write of an item of size bytes.
+func writeUnits(size int) float64 {
+	return math.Ceil(float64(size) / writeUnitSize)
+}
+
+// withDefaults fills zero prices from DefaultPricing.
+func (p Pricing) withDefaults() Pricing {
+	if p.OnDemandReadPerMillion == 0 {
+		p.OnDemandReadPerMillion = DefaultPricing.OnDemandReadPerMillion
+	}
+	if p.OnDemandWritePerMillion == 0 {
+		p.OnDemandWritePerMillion = DefaultPricing.OnDemandWritePerMillion
+	}
+	if p.ProvisionedRCUHour == 0 {
+		p.ProvisionedRCUHour = DefaultPricing.ProvisionedRCUHour
+	}
+	if p.ProvisionedWCUHour == 0 {
+		p.ProvisionedWCUHour = DefaultPricing.ProvisionedWCUHour
+	}
+	return p
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,238 @@
+// Package diag defines typed schema diagnostics.
+//
+// Every problem found in a schema is reported as a Diagnostic with a stable code,
+// a severity, a JSON pointer into the schema file and an optional fix suggestion:
+//
+//	{
+//	  "code": "GD309",
+//	  "severity": "error",
+//	  "path": "/secondary_indexes/1/range_key",
+//	  "message": "GSI range key 'created_at' not declared in attributes",
+//	  "suggestion": "add 'created_at' to attributes or fix the key name"
+//	}
+//
+// Codes are grouped by schema section: GD0xx table, GD1xx attributes, GD2xx primary key, GD3xx indexes.
+package diag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+)
+
+// Severity is the impact of a diagnostic.
+type Severity string
+
+const (
+	// SeverityError makes the schema invalid, code can't be generated.
+	SeverityError Severity = "error"
+
+	// SeverityWarning points to a likely mistake, the schema stays valid.
+	SeverityWarning Severity = "warning"
+)
+
+// Code is a stable diagnostic identifier.
+type Code string
+
+//revive:disable:exported
+const (
+	// Table.
+	CodeSchemaVersionNegative   Code = "GD001"
+	CodeTableNameEmpty          Code = "GD002"
+	CodeTableClassInvalid       Code = "GD003"
+	CodeWarmThroughputInvalid   Code = "GD004"
+	CodeReplicaRegionInvalid    Code = "GD005"
+	CodeReplicaDuplicate        Code = "GD006"
+	CodeHelperKeyInvalid        Code = "GD007"
+	CodeHelperAttributeReserved Code = "GD008"
+	CodeQueueIndexInvalid       Code = "GD009"
+	CodeQuotaTTLMissing         Code = "GD010"
+	CodeSessionStoreInvalid     Code = "GD011"
+	CodeEventLogInvalid         Code = "GD012"
+	CodePruningInvalid          Code = "GD013"
+
+	// Attributes.
+	CodeAttributeNameEmpty             Code = "GD101"
+	CodeAttributeTypeInvalid           Code = "GD102"
+	CodeAttributeSubtypeIncompatible   Code = "GD103"
+	CodeAttributeGoNameInvalid         Code = "GD104"
+	CodeAttributeGoNameCollision       Code = "GD105"
+	CodeAttributeUnused                Code = "GD106"
+	CodeAttributeAnonymizeInvalid      Code = "GD107"
+	CodeAttributeAnonymizeKey          Code = "GD108"
+	CodeAttributeSensitiveKey          Code = "GD109"
+	CodeEncryptionContextReserved      Code = "GD110"
+	CodeAttributeComputedInvalid       Code = "GD111"
+	CodeAttributeComputedInput         Code = "GD112"
+	CodeAttributeGuardInvalid          Code = "GD113"
+	CodeAttributeGuardKey              Code = "GD114"
+	CodeAttributeVersionInvalid        Code = "GD115"
+	CodeAttributeVersionConflict       Code = "GD116"
+	CodeAttributeAutoInvalid           Code = "GD117"
+	CodeAttributeAutoConflict          Code = "GD118"
+	CodeAttributeChecksumInvalid       Code = "GD119"
+	CodeAttributeChecksumConflict      Code = "GD120"
+	CodeAttributeTTLInvalid            Code = "GD121"
+	CodeAttributeTTLConflict           Code = "GD122"
+	CodeAttributeFieldVersionsInvalid  Code = "GD123"
+	CodeAttributeFieldVersionsConflict Code = "GD124"
+	CodeAttributeListElementInvalid    Code = "GD125"
+	CodeAttributeOptionalInvalid       Code = "GD126"
+	CodeAttributeOptionalConflict      Code = "GD127"
+	CodeAttributeGoTypeInvalid         Code = "GD128"
+	CodeAttributeGoTypeConflict        Code = "GD129"
+	CodeAttributeEnumInvalid           Code = "GD130"
+	CodeAttributeEnumConflict          Code = "GD131"
+	CodeAttributeRuleInvalid           Code = "GD132"
+	CodeAttributeNormalizeInvalid      Code = "GD133"
+	CodeAttributeDefaultInvalid        Code = "GD134"
+	CodeAttributePayloadInvalid        Code = "GD135"
+	CodeAttributePayloadConflict       Code = "GD136"
+
+	// Primary key.
+	CodeHashKeyUndefined  Code = "GD201"
+	CodeRangeKeyUndefined Code = "GD202"
+
+	// Secondary indexes.
+	CodeIndexTypeInvalid            Code = "GD301"
+	CodeIndexProjectionInvalid      Code = "GD302"
+	CodeIndexNonKeyMissing          Code = "GD303"
+	CodeIndexNonKeyUnexpected       Code = "GD304"
+	CodeIndexRangeKeyMissing        Code = "GD305"
+	CodeIndexRangeKeyDuplicate      Code = "GD306"
+	CodeIndexCapacityUnexpected     Code = "GD307"
+	CodeIndexHashKeyMissing         Code = "GD308"
+	CodeIndexKeyUndefined           Code = "GD309"
+	CodeIndexNonKeyUndefined        Code = "GD310"
+	CodeIndexCompositePartUndefined Code = "GD311"
+	CodeIndexLSILimit               Code = "GD312"
+	CodeIndexNameDuplicate          Code = "GD313"
+	CodeIndexGoNameCollision        Code = "GD314"
+	CodeIndexCompositeFormatInvalid Code = "GD315"
+
+	// Named queries.
+	CodeQueryGoNameCollision    Code = "GD401"
+	CodeQueryIndexUndefined     Code = "GD402"
+	CodeQueryOperatorInvalid    Code = "GD403"
+	CodeQueryAttributeUndefined Code = "GD404"
+	CodeQueryValueInvalid       Code = "GD405"
+	CodeQueryUnsatisfiable      Code = "GD406"
+)
+
+// Diagnostic is a single problem found in a schema.
+type Diagnostic struct {
+	// Code identifies the kind of problem.
+	Code Code `json:"code"`
+
+	// Severity of the problem.
+	Severity Severity `json:"severity"`
+
+	// Path is a JSON pointer (RFC 6901) to the offending schema value, e.g. "/attributes/2/type".
+	Path string `json:"path"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+
+	// Suggestion describes a possible fix. Optional.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Errorf creates an error diagnostic.
+//
+// Example:
+//
+//	d := diag.Errorf(diag.CodeHashKeyUndefined, "/hash_key", "hash key '%s' not declared in attributes", key)
+func Errorf(code Code, path, format string, args ...any) Diagnostic {
+	return Diagnostic{
+		Code:     code,
+		Severity: SeverityError,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// Warningf creates a warning diagnostic.
+func Warningf(code Code, path, format string, args ...any) Diagnostic {
+	d := Errorf(code, path, format, args...)
+	d.Severity = SeverityWarning
+	return d
+}
+
+// Suggest sets the fix suggestion of the diagnostic.
+func (d Diagnostic) Suggest(format string, args ...any) Diagnostic {
+	d.Suggestion = fmt.Sprintf(format, args...)
+	return d
+}
+
+// String returns the diagnostic as a single line, e.g. "GD201 /hash_key: hash key 'id' not declared in attributes".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s %s: %s", d.Code, d.Path, d.Message)
+}
+
+// Failure converts the diagnostic into an error carrying code, path and suggestion as fields.
+func (d Diagnostic) Failure() *logger.Failure {
+	f := logger.NewFailure(d.Message, nil).
+		With("code", d.Code).
+		With("path", d.Path)
+	if d.Suggestion != "" {
+		f.With("suggestion", d.Suggestion)
+	}
+	return f
+}
+
+// List is a set of diagnostics in the order they were found.
+type List []Diagnostic
+
+// Errors returns diagnostics with SeverityError.
+func (l List) Errors() List {
+	return l.filter(SeverityError)
+}
+
+// Warnings returns diagnostics with SeverityWarning.
+func (l List) Warnings() List {
+	return l.filter(SeverityWarning)
+}
+
+// Err returns the first error diagnostic as *logger.Failure, or nil if there are no errors.
+func (l List) Err() error {
+	for _, d := range l {
+		if d.Severity == SeverityError {
+			return d.Failure()
+		}
+	}
+	return nil
+}
+
+func (l List) filter(severity Severity) List {
+	out := List{}
+	for _, d := range l {
+		if d.Severity == severity {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Pointer builds a JSON pointer from reference tokens, escaping "~" and "/".
+//
+// Example:
+//
+//	diag.Pointer("secondary_indexes", 1, "range_key") → "/secondary_indexes/1/range_key"
+func Pointer(tokens ...any) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		switch v := t.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(v))
+		default:
+			s := fmt.Sprint(v)
+			s = strings.ReplaceAll(s, "~", "~0")
+			s = strings.ReplaceAll(s, "/", "~1")
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
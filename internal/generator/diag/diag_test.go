@@ -0,0 +1,78 @@
+package diag
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointer_Tokens(t *testing.T) {
+	assert.Equal(t, "/secondary_indexes/1/range_key", Pointer("secondary_indexes", 1, "range_key"))
+	assert.Equal(t, "/hash_key", Pointer("hash_key"))
+	assert.Equal(t, "", Pointer())
+}
+
+func TestPointer_Escaping(t *testing.T) {
+	assert.Equal(t, "/attributes/a~1b/c~0d", Pointer("attributes", "a/b", "c~d"))
+	assert.Equal(t, "/~01", Pointer("~1"), "Tilde should be escaped before slash")
+}
+
+func TestDiagnostic_JSON(t *testing.T) {
+	d := Errorf(CodeIndexKeyUndefined, Pointer("secondary_indexes", 1, "range_key"), "GSI range key '%s' not declared in attributes", "created_at").
+		Suggest("add '%s' to attributes or fix the key name", "created_at")
+
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"code": "GD309",
+		"severity": "error",
+		"path": "/secondary_indexes/1/range_key",
+		"message": "GSI range key 'created_at' not declared in attributes",
+		"suggestion": "add 'created_at' to attributes or fix the key name"
+	}`, string(data))
+}
+
+func TestDiagnostic_JSONWithoutSuggestion(t *testing.T) {
+	data, err := json.Marshal(Warningf(CodeAttributeUnused, "/attributes/2", "attribute '%s' is unused", "status"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"code": "GD106", "severity": "warning", "path": "/attributes/2", "message": "attribute 'status' is unused"}`, string(data))
+}
+
+func TestDiagnostic_String(t *testing.T) {
+	d := Errorf(CodeHashKeyUndefined, "/hash_key", "hash key '%s' not declared in attributes", "id")
+	assert.Equal(t, "GD201 /hash_key: hash key 'id' not declared in attributes", d.String())
+}
+
+func TestDiagnostic_Failure(t *testing.T) {
+	f := Errorf(CodeHashKeyUndefined, "/hash_key", "hash key missing").Suggest("declare it").Failure()
+	assert.Equal(t, "hash key missing", f.Error())
+	assert.Equal(t, map[string]any{"code": CodeHashKeyUndefined, "path": "/hash_key", "suggestion": "declare it"}, f.Fields)
+
+	f = Errorf(CodeHashKeyUndefined, "/hash_key", "hash key missing").Failure()
+	assert.NotContains(t, f.Fields, "suggestion")
+}
+
+func TestList_Severities(t *testing.T) {
+	l := List{
+		Warningf(CodeAttributeUnused, "/attributes/0", "unused"),
+		Errorf(CodeRangeKeyUndefined, "/range_key", "range key missing"),
+		Errorf(CodeHashKeyUndefined, "/hash_key", "hash key missing"),
+	}
+	assert.Equal(t, List{l[1], l[2]}, l.Errors())
+	assert.Equal(t, List{l[0]}, l.Warnings())
+
+	var f *logger.Failure
+	require.True(t, errors.As(l.Err(), &f))
+	assert.Equal(t, "range key missing", f.Message, "Err should return the first error")
+}
+
+func TestList_NoErrors(t *testing.T) {
+	l := List{Warningf(CodeAttributeUnused, "/attributes/0", "unused")}
+	assert.NoError(t, l.Err())
+	assert.Equal(t, List{}, l.Errors())
+}
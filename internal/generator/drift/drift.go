@@ -0,0 +1,250 @@
+// Package drift compares a schema definition with the live DynamoDB table.
+//
+// It reports differences in:
+//   - Primary key schema and key attribute types
+//   - Secondary indexes (existence, type, keys, projection)
+//   - TTL and stream settings (when expected by the caller)
+//
+// The result is a flat list of drift items suitable for both human-readable
+// logs and machine-readable (JSON) output.
+package drift
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/awsclient"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Kind classifies a drift item.
+type Kind string
+
+const (
+	// KindMissing means the schema defines something the table doesn't have.
+	KindMissing Kind = "missing"
+
+	// KindUnexpected means the table has something the schema doesn't define.
+	KindUnexpected Kind = "unexpected"
+
+	// KindMismatch means both define it, but with different values.
+	KindMismatch Kind = "mismatch"
+)
+
+// Item is a single difference between the schema and the live table.
+type Item struct {
+	Kind     Kind   `json:"kind"`
+	Scope    string `json:"scope"`
+	Field    string `json:"field"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// Options configures connection and expectations not described by the schema.
+type Options struct {
+	// Endpoint overrides the DynamoDB endpoint (LocalStack, dynamodb-local).
+	Endpoint string
+
+	// Region overrides the AWS region from the default configuration chain.
+	Region string
+
+	// TTLAttribute is the expected TTL attribute; TTL isn't checked if empty.
+	TTLAttribute string
+
+	// StreamEnabled requires DynamoDB streams to be enabled on the table.
+	StreamEnabled bool
+}
+
+// Table is the live table state used for comparison.
+type Table struct {
+	Description *types.TableDescription
+	TTL         *types.TimeToLiveDescription
+}
+
+// Fetch loads the live table description and TTL settings.
+//
+// Example:
+//
+//	table, err := drift.Fetch(ctx, "users", drift.Options{Endpoint: "http://localhost:4566"})
+func Fetch(ctx context.Context, tableName string, opts Options) (*Table, error) {
+	client, err := awsclient.DynamoDB(ctx, awsclient.Options{Endpoint: opts.Endpoint, Region: opts.Region})
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return nil, logger.NewFailure("failed to describe table", err).
+			With("table", tableName)
+	}
+	ttl, err := client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return nil, logger.NewFailure("failed to describe table TTL", err).
+			With("table", tableName)
+	}
+	return &Table{
+		Description: desc.Table,
+		TTL:         ttl.TimeToLiveDescription,
+	}, nil
+}
+
+// Compare returns all differences between a validated schema and the live table.
+// An empty result means no drift.
+func Compare(s *schema.Schema, table *Table, opts Options) []Item {
+	var (
+		items []Item
+		desc  = table.Description
+	)
+	if desc == nil {
+		return []Item{{Kind: KindMissing, Scope: "table", Field: "description", Expected: s.TableName()}}
+	}
+
+	items = append(items, compareKeys("table", desc.KeySchema, s.HashKey(), s.RangeKey())...)
+	items = append(items, compareAttributeTypes(s, desc.AttributeDefinitions)...)
+	items = append(items, compareIndexes(s, desc)...)
+
+	if opts.TTLAttribute != "" {
+		var status, attr string
+		if table.TTL != nil {
+			status = string(table.TTL.TimeToLiveStatus)
+			attr = aws.ToString(table.TTL.AttributeName)
+		}
+		if status != string(types.TimeToLiveStatusEnabled) {
+			items = append(items, Item{Kind: KindMismatch, Scope: "ttl", Field: "status", Expected: string(types.TimeToLiveStatusEnabled), Actual: status})
+		}
+		if attr != opts.TTLAttribute {
+			items = append(items, Item{Kind: KindMismatch, Scope: "ttl", Field: "attribute", Expected: opts.TTLAttribute, Actual: attr})
+		}
+	}
+	if opts.StreamEnabled {
+		if desc.StreamSpecification == nil || !aws.ToBool(desc.StreamSpecification.StreamEnabled) {
+			items = append(items, Item{Kind: KindMismatch, Scope: "stream", Field: "enabled", Expected: "true", Actual: "false"})
+		}
+	}
+	return items
+}
+
+func compareKeys(scope string, keys []types.KeySchemaElement, hashKey, rangeKey string) []Item {
+	var (
+		items               []Item
+		liveHash, liveRange string
+	)
+	for _, k := range keys {
+		switch k.KeyType {
+		case types.KeyTypeHash:
+			liveHash = aws.ToString(k.AttributeName)
+		case types.KeyTypeRange:
+			liveRange = aws.ToString(k.AttributeName)
+		}
+	}
+	if liveHash != hashKey {
+		items = append(items, Item{Kind: KindMismatch, Scope: scope, Field: "hash_key", Expected: hashKey, Actual: liveHash})
+	}
+	if liveRange != rangeKey {
+		items = append(items, Item{Kind: KindMismatch, Scope: scope, Field: "range_key", Expected: rangeKey, Actual: liveRange})
+	}
+	return items
+}
+
+func compareAttributeTypes(s *schema.Schema, defs []types.AttributeDefinition) []Item {
+	var (
+		items    []Item
+		expected = make(map[string]string)
+	)
+	for _, attr := range s.AllAttributes() {
+		expected[attr.Name] = attr.Type
+	}
+	for _, def := range defs {
+		name := aws.ToString(def.AttributeName)
+		want, ok := expected[name]
+		if !ok {
+			continue
+		}
+		if want != string(def.AttributeType) {
+			items = append(items, Item{Kind: KindMismatch, Scope: "attribute " + name, Field: "type", Expected: want, Actual: string(def.AttributeType)})
+		}
+	}
+	return items
+}
+
+type liveIndex struct {
+	kind       index.Type
+	keys       []types.KeySchemaElement
+	projection *types.Projection
+}
+
+func compareIndexes(s *schema.Schema, desc *types.TableDescription) []Item {
+	var (
+		items []Item
+		live  = make(map[string]liveIndex)
+	)
+	for _, gsi := range desc.GlobalSecondaryIndexes {
+		live[aws.ToString(gsi.IndexName)] = liveIndex{kind: index.GSI, keys: gsi.KeySchema, projection: gsi.Projection}
+	}
+	for _, lsi := range desc.LocalSecondaryIndexes {
+		live[aws.ToString(lsi.IndexName)] = liveIndex{kind: index.LSI, keys: lsi.KeySchema, projection: lsi.Projection}
+	}
+
+	defined := make(map[string]bool)
+	for _, idx := range s.SecondaryIndexes() {
+		defined[idx.Name] = true
+		scope := "index " + idx.Name
+
+		li, ok := live[idx.Name]
+		if !ok {
+			items = append(items, Item{Kind: KindMissing, Scope: scope, Field: "index", Expected: idx.Name})
+			continue
+		}
+		if want := strings.ToUpper(idx.Type.String()); want != li.kind.String() {
+			items = append(items, Item{Kind: KindMismatch, Scope: scope, Field: "type", Expected: want, Actual: li.kind.String()})
+		}
+		items = append(items, compareKeys(scope, li.keys, idx.GetEffectiveHashKey(s.HashKey()), idx.RangeKey)...)
+		items = append(items, compareProjection(scope, idx, li.projection)...)
+	}
+
+	var extra []string
+	for name := range live {
+		if !defined[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		items = append(items, Item{Kind: KindUnexpected, Scope: "index " + name, Field: "index", Actual: name})
+	}
+	return items
+}
+
+func compareProjection(scope string, idx index.Index, projection *types.Projection) []Item {
+	var (
+		items      []Item
+		liveType   string
+		liveNonKey []string
+	)
+	if projection != nil {
+		liveType = string(projection.ProjectionType)
+		liveNonKey = append(liveNonKey, projection.NonKeyAttributes...)
+	}
+	if want := strings.ToUpper(idx.ProjectionType); want != liveType {
+		items = append(items, Item{Kind: KindMismatch, Scope: scope, Field: "projection_type", Expected: want, Actual: liveType})
+	}
+
+	wantNonKey := append([]string(nil), idx.NonKeyAttributes...)
+	sort.Strings(wantNonKey)
+	sort.Strings(liveNonKey)
+	if strings.Join(wantNonKey, ",") != strings.Join(liveNonKey, ",") {
+		items = append(items, Item{Kind: KindMismatch, Scope: scope, Field: "non_key_attributes", Expected: strings.Join(wantNonKey, ","), Actual: strings.Join(liveNonKey, ",")})
+	}
+	return items
+}
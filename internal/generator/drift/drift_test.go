@@ -0,0 +1,153 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+	"table_name": "events",
+	"hash_key": "id",
+	"range_key": "ts",
+	"attributes": [
+		{ "name": "id", "type": "S" },
+		{ "name": "ts", "type": "N" },
+		{ "name": "status", "type": "S" }
+	],
+	"common_attributes": [
+		{ "name": "title", "type": "S" }
+	],
+	"secondary_indexes": [
+		{ "name": "by_status", "type": "GSI", "hash_key": "status", "range_key": "ts", "projection_type": "INCLUDE", "non_key_attributes": ["title"] }
+	]
+}`
+
+func keySchema(hashKey, rangeKey string) []types.KeySchemaElement {
+	keys := []types.KeySchemaElement{{AttributeName: aws.String(hashKey), KeyType: types.KeyTypeHash}}
+	if rangeKey != "" {
+		keys = append(keys, types.KeySchemaElement{AttributeName: aws.String(rangeKey), KeyType: types.KeyTypeRange})
+	}
+	return keys
+}
+
+// liveTable returns the table matching testSchema.
+func liveTable() *Table {
+	return &Table{
+		Description: &types.TableDescription{
+			KeySchema: keySchema("id", "ts"),
+			AttributeDefinitions: []types.AttributeDefinition{
+				{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+				{AttributeName: aws.String("ts"), AttributeType: types.ScalarAttributeTypeN},
+				{AttributeName: aws.String("status"), AttributeType: types.ScalarAttributeTypeS},
+			},
+			GlobalSecondaryIndexes: []types.GlobalSecondaryIndexDescription{{
+				IndexName:  aws.String("by_status"),
+				KeySchema:  keySchema("status", "ts"),
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeInclude, NonKeyAttributes: []string{"title"}},
+			}},
+			StreamSpecification: &types.StreamSpecification{StreamEnabled: aws.Bool(true)},
+		},
+		TTL: &types.TimeToLiveDescription{
+			AttributeName:    aws.String("expires_at"),
+			TimeToLiveStatus: types.TimeToLiveStatusEnabled,
+		},
+	}
+}
+
+func TestCompare_Drift(t *testing.T) {
+	s, err := schema.FromJSON([]byte(testSchema))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		opts   Options
+		modify func(table *Table)
+		want   []Item
+	}{
+		{
+			name: "matching table",
+			opts: Options{TTLAttribute: "expires_at", StreamEnabled: true},
+		},
+		{
+			name:   "missing description",
+			modify: func(table *Table) { table.Description = nil },
+			want:   []Item{{Kind: KindMissing, Scope: "table", Field: "description", Expected: "events"}},
+		},
+		{
+			name:   "different range key",
+			modify: func(table *Table) { table.Description.KeySchema = keySchema("id", "created") },
+			want:   []Item{{Kind: KindMismatch, Scope: "table", Field: "range_key", Expected: "ts", Actual: "created"}},
+		},
+		{
+			name: "different key attribute type",
+			modify: func(table *Table) {
+				table.Description.AttributeDefinitions[1].AttributeType = types.ScalarAttributeTypeS
+			},
+			want: []Item{{Kind: KindMismatch, Scope: "attribute ts", Field: "type", Expected: "N", Actual: "S"}},
+		},
+		{
+			name:   "missing index",
+			modify: func(table *Table) { table.Description.GlobalSecondaryIndexes = nil },
+			want:   []Item{{Kind: KindMissing, Scope: "index by_status", Field: "index", Expected: "by_status"}},
+		},
+		{
+			name: "unexpected indexes sorted by name",
+			modify: func(table *Table) {
+				table.Description.LocalSecondaryIndexes = []types.LocalSecondaryIndexDescription{
+					{IndexName: aws.String("lsi_b"), KeySchema: keySchema("id", "title")},
+					{IndexName: aws.String("lsi_a"), KeySchema: keySchema("id", "title")},
+				}
+			},
+			want: []Item{
+				{Kind: KindUnexpected, Scope: "index lsi_a", Field: "index", Actual: "lsi_a"},
+				{Kind: KindUnexpected, Scope: "index lsi_b", Field: "index", Actual: "lsi_b"},
+			},
+		},
+		{
+			name: "different index projection",
+			modify: func(table *Table) {
+				table.Description.GlobalSecondaryIndexes[0].Projection = &types.Projection{ProjectionType: types.ProjectionTypeAll}
+			},
+			want: []Item{
+				{Kind: KindMismatch, Scope: "index by_status", Field: "projection_type", Expected: "INCLUDE", Actual: "ALL"},
+				{Kind: KindMismatch, Scope: "index by_status", Field: "non_key_attributes", Expected: "title"},
+			},
+		},
+		{
+			name: "disabled TTL and stream",
+			opts: Options{TTLAttribute: "expires_at", StreamEnabled: true},
+			modify: func(table *Table) {
+				table.TTL = &types.TimeToLiveDescription{TimeToLiveStatus: types.TimeToLiveStatusDisabled}
+				table.Description.StreamSpecification = nil
+			},
+			want: []Item{
+				{Kind: KindMismatch, Scope: "ttl", Field: "status", Expected: "ENABLED", Actual: "DISABLED"},
+				{Kind: KindMismatch, Scope: "ttl", Field: "attribute", Expected: "expires_at"},
+				{Kind: KindMismatch, Scope: "stream", Field: "enabled", Expected: "true", Actual: "false"},
+			},
+		},
+		{
+			name: "TTL and stream not expected",
+			modify: func(table *Table) {
+				table.TTL = nil
+				table.Description.StreamSpecification = nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			table := liveTable()
+			if tc.modify != nil {
+				tc.modify(table)
+			}
+			assert.Equal(t, tc.want, Compare(s, table, tc.opts))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package example defines the kinds of example applications scaffolded around generated code.
+//
+// An example project is a standalone Go module wired to the generated package:
+//
+//	orders-example/
+//	├── go.mod            pinned AWS SDK requirements
+//	├── main.go           client setup, one write, one query, one stream handler
+//	├── README.md         how to run it
+//	└── orders/orders.go  generated code (ALL mode with stream events)
+package example
+
+import (
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
+)
+
+// Kind is the type of example application.
+type Kind string
+
+const (
+	// Lambda is a DynamoDB stream Lambda function with a local "seed" command.
+	Lambda Kind = "lambda"
+
+	// HTTP is a net/http server with item and stream endpoints.
+	HTTP Kind = "http"
+
+	// CLI is a command-line tool with put, query and stream commands.
+	CLI Kind = "cli"
+
+	// Admin is an operator tool with get, put, query and delete commands and a flag per attribute.
+	Admin Kind = "admin"
+)
+
+// MainFile is the example application entrypoint.
+const MainFile = "main.go"
+
+// ReadmeFile describes how to run the example application.
+const ReadmeFile = "README.md"
+
+var validKinds = map[Kind]bool{
+	Lambda: true,
+	HTTP:   true,
+	CLI:    true,
+	Admin:  true,
+}
+
+// String returns the string representation of the Kind.
+func (k Kind) String() string {
+	return string(k)
+}
+
+// IsValid checks if the kind is a supported example kind.
+func (k Kind) IsValid() bool {
+	return validKinds[k]
+}
+
+// ParseKind parses a string into a Kind with case-insensitive matching.
+func ParseKind(s string) (Kind, error) {
+	kind := Kind(strings.ToLower(strings.TrimSpace(s)))
+	if !kind.IsValid() {
+		return "", logger.NewFailure("invalid example type", nil).
+			With("type", s).
+			With("available", GetAvailableKinds())
+	}
+	return kind, nil
+}
+
+// GetDefault returns the default example kind.
+func GetDefault() Kind {
+	return CLI
+}
+
+// GetAvailableKinds returns a slice of all valid kinds sorted alphabetically.
+func GetAvailableKinds() []string {
+	stringKinds := make(map[string]bool, len(validKinds))
+	for kind := range validKinds {
+		stringKinds[string(kind)] = true
+	}
+	return conv.AvailableKeys(stringKinds)
+}
+
+// DefaultModulePath returns the module path used when none is given, e.g. "example.com/orders-example".
+func DefaultModulePath(packageName string) string {
+	return "example.com/" + packageName + "-example"
+}
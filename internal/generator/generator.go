@@ -13,6 +13,7 @@
 package generator
 
 import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
 	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
 )
 
@@ -58,6 +59,11 @@ func (g *Generator) TableName() string {
 	return ""
 }
 
+// Schema returns the parsed schema definition.
+func (g *Generator) Schema() *schema.Schema {
+	return g.schema
+}
+
 // NewRenderBuilder creates a new builder instance.
 func (g *Generator) NewRenderBuilder() *RenderBuilder {
 	return &RenderBuilder{
@@ -72,3 +78,8 @@ func (g *Generator) Validate() error {
 	}
 	return g.schema.Validate()
 }
+
+// Diagnose returns every schema problem (errors and warnings) with codes, paths and suggestions.
+func (g *Generator) Diagnose() diag.List {
+	return g.schema.Diagnose()
+}
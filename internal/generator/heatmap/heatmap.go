@@ -0,0 +1,239 @@
+// Package heatmap renders CloudWatch Contributor Insights reports of a table as key heatmaps.
+//
+// DynamoDB Contributor Insights publishes rules with the most accessed (and most throttled)
+// keys of a table or index. A report row is one key; its values are split back into schema
+// attributes, composite keys ("user_id#type") included, so the distribution of sharded keys
+// written by generated helpers can be verified:
+//
+//	user_id=alice  type=post   ████▓▓▒▒░░  41.3%
+//	user_id=bob    type=post   ▒▒░░  ░     12.0%
+package heatmap
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+)
+
+// shades are heatmap cell characters from cold to hot.
+var shades = []rune{' ', '░', '▒', '▓', '█'}
+
+// Kind is the Contributor Insights rule kind.
+type Kind string
+
+const (
+	// PartitionKeys ranks the most accessed partition keys (rule suffix PKC).
+	PartitionKeys Kind = "PKC"
+	// PartitionKeysThrottled ranks the most throttled partition keys (rule suffix PKT).
+	PartitionKeysThrottled Kind = "PKT"
+	// Keys ranks the most accessed partition and sort keys (rule suffix SKC).
+	Keys Kind = "SKC"
+	// KeysThrottled ranks the most throttled partition and sort keys (rule suffix SKT).
+	KeysThrottled Kind = "SKT"
+)
+
+// RuleKind returns the kind of a Contributor Insights rule name,
+// e.g. "DynamoDBContributorInsights-PKC-orders-1600000000000".
+func RuleKind(rule string) (Kind, bool) {
+	for _, kind := range []Kind{PartitionKeys, PartitionKeysThrottled, Keys, KeysThrottled} {
+		if strings.Contains(rule, "-"+string(kind)+"-") {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// Contributor is one key of a rule report.
+type Contributor struct {
+	// Keys are the key values: the partition key, then the sort key for SKC/SKT rules.
+	Keys []string
+
+	// Total is the aggregated value over the report window.
+	Total float64
+
+	// Datapoints are the values per period, oldest first.
+	Datapoints []float64
+}
+
+// Point is a timestamped value of a contributor.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Cells places sparse points into periods consecutive cells starting at start.
+// Points outside the window are dropped, points of the same period are summed.
+func Cells(start time.Time, period time.Duration, periods int, points []Point) []float64 {
+	cells := make([]float64, periods)
+	if period <= 0 {
+		return cells
+	}
+	for _, p := range points {
+		i := int(p.Time.Sub(start) / period)
+		if p.Time.Before(start) || i >= periods {
+			continue
+		}
+		cells[i] += p.Value
+	}
+	return cells
+}
+
+// Target is the table or index a rule reports on.
+type Target struct {
+	Name     string `json:"name"` // "table" or the index name
+	HashKey  string `json:"hash_key"`
+	RangeKey string `json:"range_key,omitempty"`
+
+	// Composite splits composite key values into their parts.
+	Composite index.CompositeFormat `json:"-"`
+}
+
+// TargetOf returns the keys of the table (index "") or of an index of the schema.
+func TargetOf(s *schema.Schema, indexName string) (Target, error) {
+	if indexName == "" {
+		return Target{Name: "table", HashKey: s.HashKey(), RangeKey: s.RangeKey(), Composite: s.CompositeKeys()}, nil
+	}
+	idx := s.GetIndexByName(indexName)
+	if idx == nil {
+		return Target{}, logger.NewFailure("index not found in schema", nil).
+			With("index", indexName)
+	}
+	return Target{Name: idx.Name, HashKey: idx.GetEffectiveHashKey(s.HashKey()), RangeKey: idx.RangeKey, Composite: s.CompositeKeys()}, nil
+}
+
+// Heatmap is the rendered report of one rule.
+type Heatmap struct {
+	Rule    string `json:"rule"`
+	Kind    Kind   `json:"kind"`
+	Target  Target `json:"target"`
+	Periods int    `json:"periods"`
+	Rows    []Row  `json:"rows"`
+
+	// Skew is the share of the hottest key divided by the share of an even distribution
+	// over the reported keys: 1 is even, the number of keys means one key takes everything.
+	Skew float64 `json:"skew"`
+}
+
+// Row is one key of the heatmap.
+type Row struct {
+	Key        string            `json:"key"`
+	Attributes map[string]string `json:"attributes"`
+	Total      float64           `json:"total"`
+	Share      float64           `json:"share"`
+	Cells      []float64         `json:"cells"`
+}
+
+// Build maps contributors of a rule to schema attributes of target.
+func Build(rule string, target Target, contributors []Contributor) *Heatmap {
+	kind, _ := RuleKind(rule)
+	h := &Heatmap{Rule: rule, Kind: kind, Target: target, Rows: make([]Row, 0, len(contributors))}
+
+	var sum float64
+	for _, c := range contributors {
+		sum += c.Total
+		h.Periods = max(h.Periods, len(c.Datapoints))
+	}
+	keyAttrs := []string{target.HashKey}
+	if kind == Keys || kind == KeysThrottled {
+		keyAttrs = append(keyAttrs, target.RangeKey)
+	}
+	for _, c := range contributors {
+		row := Row{
+			Key:        strings.Join(c.Keys, " | "),
+			Attributes: make(map[string]string),
+			Total:      c.Total,
+			Cells:      c.Datapoints,
+		}
+		if sum > 0 {
+			row.Share = c.Total / sum
+		}
+		for i, value := range c.Keys {
+			if i < len(keyAttrs) && keyAttrs[i] != "" {
+				splitKey(row.Attributes, target.Composite, keyAttrs[i], value)
+			}
+		}
+		h.Rows = append(h.Rows, row)
+		h.Skew = max(h.Skew, row.Share*float64(len(contributors)))
+	}
+	return h
+}
+
+// splitKey maps a key value to attributes, splitting composite keys ("a#b") by their parts.
+func splitKey(attrs map[string]string, format index.CompositeFormat, key, value string) {
+	names := strings.Split(key, "#")
+	values := format.Split(key, value, len(names))
+	if len(values) != len(names) {
+		attrs[key] = value
+		return
+	}
+	for i, name := range names {
+		attrs[name] = values[i]
+	}
+}
+
+// Render writes the heatmap as text: one line per key, one cell per period,
+// shaded relative to the hottest cell of the map.
+func Render(w io.Writer, h *Heatmap) error {
+	var peak float64
+	for _, row := range h.Rows {
+		for _, v := range row.Cells {
+			peak = math.Max(peak, v)
+		}
+	}
+	labels := make([]string, len(h.Rows))
+	width := 0
+	for i, row := range h.Rows {
+		labels[i] = label(h.Target, row)
+		width = max(width, len([]rune(labels[i])))
+	}
+
+	if _, err := fmt.Fprintf(w, "%s (%s, %d periods, skew %.1fx)\n", h.Rule, h.Target.Name, h.Periods, h.Skew); err != nil {
+		return err
+	}
+	for i, row := range h.Rows {
+		var cells strings.Builder
+		for p := 0; p < h.Periods; p++ {
+			var v float64
+			if p < len(row.Cells) {
+				v = row.Cells[p]
+			}
+			cells.WriteRune(shade(v, peak))
+		}
+		pad := strings.Repeat(" ", width-len([]rune(labels[i])))
+		if _, err := fmt.Fprintf(w, "  %s%s  %s  %5.1f%%  %.0f\n", labels[i], pad, cells.String(), row.Share*100, row.Total); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// label formats the key attributes of a row in key order.
+func label(target Target, row Row) string {
+	var parts []string
+	for _, key := range []string{target.HashKey, target.RangeKey} {
+		for _, name := range strings.Split(key, "#") {
+			if value, ok := row.Attributes[name]; ok && name != "" {
+				parts = append(parts, name+"="+value)
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return row.Key
+	}
+	return strings.Join(parts, "  ")
+}
+
+// shade returns the cell character of v relative to peak.
+func shade(v, peak float64) rune {
+	if v <= 0 || peak <= 0 {
+		return shades[0]
+	}
+	i := int(math.Ceil(v / peak * float64(len(shades)-1)))
+	return shades[min(i, len(shades)-1)]
+}
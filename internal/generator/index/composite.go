@@ -1,5 +1,12 @@
 package index
 
+import (
+	"sort"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
 // CompositeKey represent a part of a composite key.
 type CompositeKey struct {
 	// IsConstant indicates whether the part is a literal constant or a reference to an attribute.
@@ -8,3 +15,138 @@ type CompositeKey struct {
 	// Value is either the constant string or the attribute name.
 	Value string
 }
+
+const (
+	// DefaultCompositeSeparator joins the parts of composite key values unless the schema declares one.
+	DefaultCompositeSeparator = "#"
+
+	// CompositeEscapeReject rejects values of composite key parts containing the separator.
+	CompositeEscapeReject = "reject"
+
+	// CompositeEscapeBackslash escapes the separator and backslashes in values of composite key parts.
+	CompositeEscapeBackslash = "backslash"
+)
+
+// CompositeFormat declares how the parts of composite key values are joined.
+// Composite keys are always declared with '#' between the part names ("status#category"),
+// the separator only applies to the values.
+//
+// Example:
+//
+//	"composite_keys": {
+//	  "separator": "|",
+//	  "escape": "backslash",
+//	  "separators": {"status#category": "~"}
+//	}
+type CompositeFormat struct {
+	// Separator joins the parts of all composite key values, "#" by default.
+	Separator string `json:"separator,omitempty"`
+
+	// Escape is the strategy for values containing the separator: "reject" (default) or "backslash".
+	Escape string `json:"escape,omitempty"`
+
+	// Separators overrides the separator of single composite keys, keyed by the declared key.
+	Separators map[string]string `json:"separators,omitempty"`
+}
+
+// TableSeparator returns the separator of composite keys without their own.
+func (f CompositeFormat) TableSeparator() string {
+	if f.Separator == "" {
+		return DefaultCompositeSeparator
+	}
+	return f.Separator
+}
+
+// SeparatorOf returns the separator of the values of a composite key.
+func (f CompositeFormat) SeparatorOf(key string) string {
+	if sep, ok := f.Separators[key]; ok && sep != "" {
+		return sep
+	}
+	return f.TableSeparator()
+}
+
+// IsEscaped reports whether separators in values of composite key parts are escaped with a backslash.
+func (f CompositeFormat) IsEscaped() bool {
+	return f.Escape == CompositeEscapeBackslash
+}
+
+// Join joins the values of the parts of a composite key, escaping them if declared.
+func (f CompositeFormat) Join(key string, values []string) string {
+	sep := f.SeparatorOf(key)
+	if !f.IsEscaped() {
+		return strings.Join(values, sep)
+	}
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = strings.ReplaceAll(strings.ReplaceAll(v, `\`, `\\`), sep, `\`+sep)
+	}
+	return strings.Join(escaped, sep)
+}
+
+// Split splits a value of a composite key into at most n part values, unescaping them if declared.
+func (f CompositeFormat) Split(key, value string, n int) []string {
+	sep := f.SeparatorOf(key)
+	if !f.IsEscaped() {
+		return strings.SplitN(value, sep, n)
+	}
+	var (
+		values []string
+		b      strings.Builder
+	)
+	for i := 0; i < len(value); {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			if strings.HasPrefix(value[i+1:], sep) {
+				b.WriteString(sep)
+				i += 1 + len(sep)
+			} else {
+				b.WriteByte(value[i+1])
+				i += 2
+			}
+		case len(values) < n-1 && strings.HasPrefix(value[i:], sep):
+			values = append(values, b.String())
+			b.Reset()
+			i += len(sep)
+		default:
+			b.WriteByte(value[i])
+			i++
+		}
+	}
+	return append(values, b.String())
+}
+
+// Diagnose returns problems of the composite key format, keys are the composite keys of the indexes.
+func (f CompositeFormat) Diagnose(path string, keys map[string]bool) diag.List {
+	var list diag.List
+	if f.Escape != "" && f.Escape != CompositeEscapeReject && f.Escape != CompositeEscapeBackslash {
+		list = append(list, diag.Errorf(diag.CodeIndexCompositeFormatInvalid, path+"/escape", "invalid composite key escape '%s'", f.Escape).
+			Suggest("use '%s' or '%s'", CompositeEscapeReject, CompositeEscapeBackslash))
+	}
+	check := func(path, sep string) {
+		if f.IsEscaped() && strings.Contains(sep, `\`) {
+			list = append(list, diag.Errorf(diag.CodeIndexCompositeFormatInvalid, path, "composite key separator '%s' can't contain a backslash, which escapes it", sep).
+				Suggest("use another separator or escape '%s'", CompositeEscapeReject))
+		}
+	}
+	check(path+"/separator", f.Separator)
+
+	names := make([]string, 0, len(f.Separators))
+	for key := range f.Separators {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	for _, key := range names {
+		sepPath := path + diag.Pointer("separators", key)
+		switch {
+		case !keys[key]:
+			list = append(list, diag.Errorf(diag.CodeIndexCompositeFormatInvalid, sepPath, "'%s' is not a composite key of a secondary index", key).
+				Suggest("declare separators of composite index keys, e.g. 'status#category'"))
+		case f.Separators[key] == "":
+			list = append(list, diag.Errorf(diag.CodeIndexCompositeFormatInvalid, sepPath, "separator of composite key '%s' is empty", key).
+				Suggest("set a separator or remove the entry"))
+		default:
+			check(sepPath, f.Separators[key])
+		}
+	}
+	return list
+}
@@ -12,7 +12,7 @@ package index
 import (
 	"strings"
 
-	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
 	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
 )
 
@@ -77,6 +77,9 @@ type Index struct {
 	ReadCapacity  *int `json:"read_capacity,omitempty"`
 	WriteCapacity *int `json:"write_capacity,omitempty"`
 
+	// WarmThroughput is the pre-warmed capacity of the index - only valid for GSI
+	WarmThroughput *WarmThroughput `json:"warm_throughput,omitempty"`
+
 	// Parsed composite key parts (populated during schema loading)
 	HashKeyParts  []CompositeKey `json:"-"`
 	RangeKeyParts []CompositeKey `json:"-"`
@@ -122,58 +125,71 @@ func (i Index) HasCompositeHashKey() bool {
 
 // Validate performs comprehensive validation of the secondary index configuration.
 func (i Index) Validate(tableRangeKey string) error {
+	return i.Diagnose("", tableRangeKey).Err()
+}
+
+// Diagnose returns all problems of the secondary index configuration.
+// path is the JSON pointer of the index in the schema (e.g. "/secondary_indexes/0").
+func (i Index) Diagnose(path, tableRangeKey string) diag.List {
+	var list diag.List
 	if !validIndexesTypes[strings.ToUpper(string(i.Type))] {
-		return logger.NewFailure("invalid index", nil).
-			With("name", i.Name).
-			With("available", conv.AvailableKeys(validIndexesTypes))
+		list = append(list, diag.Errorf(diag.CodeIndexTypeInvalid, path+"/type", "invalid type '%s' of index '%s'", i.Type, i.Name).
+			Suggest("use one of: %s", strings.Join(conv.AvailableKeys(validIndexesTypes), ", ")))
+		return list
 	}
 	if !validProjectionTypes[strings.ToUpper(i.ProjectionType)] {
-		return logger.NewFailure("invalid projection type", nil).
-			With("type", i.ProjectionType).
-			With("available", conv.AvailableKeys(validProjectionTypes))
+		list = append(list, diag.Errorf(diag.CodeIndexProjectionInvalid, path+"/projection_type", "invalid projection type '%s' of index '%s'", i.ProjectionType, i.Name).
+			Suggest("use one of: %s", strings.Join(conv.AvailableKeys(validProjectionTypes), ", ")))
 	}
 
 	if strings.ToUpper(i.ProjectionType) == "INCLUDE" && len(i.NonKeyAttributes) == 0 {
-		return logger.NewFailure("non_key_attributes must be specified when projection_type is 'INCLUDE'", nil)
+		list = append(list, diag.Errorf(diag.CodeIndexNonKeyMissing, path+"/non_key_attributes", "non_key_attributes must be specified when projection_type is 'INCLUDE' (index '%s')", i.Name).
+			Suggest("list projected attributes or use projection_type 'ALL'"))
 	}
 	if strings.ToUpper(i.ProjectionType) != "INCLUDE" && len(i.NonKeyAttributes) > 0 {
-		return logger.NewFailure("non_key_attributes can only be specified when projection_type is 'INCLUDE'", nil)
+		list = append(list, diag.Errorf(diag.CodeIndexNonKeyUnexpected, path+"/non_key_attributes", "non_key_attributes can only be specified when projection_type is 'INCLUDE' (index '%s')", i.Name).
+			Suggest("remove non_key_attributes or set projection_type to 'INCLUDE'"))
 	}
 
 	if i.IsLSI() {
-		if err := i.validateLSI(tableRangeKey); err != nil {
-			return err
-		}
+		list = append(list, i.diagnoseLSI(path, tableRangeKey)...)
 	}
 	if i.IsGSI() {
-		if err := i.validateGSI(); err != nil {
-			return err
-		}
+		list = append(list, i.diagnoseGSI(path)...)
 	}
-	return nil
+	return list
 }
 
-func (i Index) validateLSI(tableRangeKey string) error {
+func (i Index) diagnoseLSI(path, tableRangeKey string) diag.List {
+	var list diag.List
 	if i.RangeKey == "" {
-		return logger.NewFailure("LSI must specify range_key", nil).
-			With("name", i.Name)
+		list = append(list, diag.Errorf(diag.CodeIndexRangeKeyMissing, path+"/range_key", "LSI '%s' must specify range_key", i.Name).
+			Suggest("set range_key to an attribute other than the table range key"))
 	}
-	if i.RangeKey == tableRangeKey {
-		return logger.NewFailure("range_key cannot be the same as table's range_key", nil).
-			With("name", i.Name).
-			With("key", i.RangeKey)
+	if i.RangeKey != "" && i.RangeKey == tableRangeKey {
+		list = append(list, diag.Errorf(diag.CodeIndexRangeKeyDuplicate, path+"/range_key", "range_key '%s' of LSI '%s' cannot be the same as table's range_key", i.RangeKey, i.Name).
+			Suggest("use another attribute as the LSI range key"))
 	}
 	if i.ReadCapacity != nil || i.WriteCapacity != nil {
-		return logger.NewFailure("LSI cannot specify read/write capacity (uses table's provisioned throughput)", nil).
-			With("name", i.Name)
+		list = append(list, diag.Errorf(diag.CodeIndexCapacityUnexpected, path, "LSI '%s' cannot specify read/write capacity (uses table's provisioned throughput)", i.Name).
+			Suggest("remove read_capacity and write_capacity"))
 	}
-	return nil
+	if i.WarmThroughput != nil {
+		list = append(list, diag.Errorf(diag.CodeIndexCapacityUnexpected, path+"/warm_throughput", "LSI '%s' cannot specify warm_throughput (uses table's warm throughput)", i.Name).
+			Suggest("remove warm_throughput or set it on the table"))
+	}
+	return list
 }
 
-func (i Index) validateGSI() error {
+func (i Index) diagnoseGSI(path string) diag.List {
 	if i.HashKey == "" {
-		return logger.NewFailure("GSI must specify hash_key", nil).
-			With("name", i.Name)
+		return diag.List{
+			diag.Errorf(diag.CodeIndexHashKeyMissing, path+"/hash_key", "GSI '%s' must specify hash_key", i.Name).
+				Suggest("set hash_key to a declared attribute"),
+		}
+	}
+	if i.WarmThroughput != nil {
+		return i.WarmThroughput.Diagnose(path + "/warm_throughput")
 	}
 	return nil
 }
@@ -0,0 +1,38 @@
+package index
+
+import "github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+
+// WarmThroughput is the pre-warmed read and write capacity of a table or GSI
+// in units per second, available instantly on traffic spikes.
+//
+// Example:
+//
+//	"warm_throughput": {
+//	  "read_units_per_second": 15000,
+//	  "write_units_per_second": 5000
+//	}
+type WarmThroughput struct {
+	// ReadUnitsPerSecond is the warm read capacity. Optional.
+	ReadUnitsPerSecond *int64 `json:"read_units_per_second,omitempty"`
+
+	// WriteUnitsPerSecond is the warm write capacity. Optional.
+	WriteUnitsPerSecond *int64 `json:"write_units_per_second,omitempty"`
+}
+
+// Diagnose returns problems of the warm throughput at path (e.g. "/warm_throughput").
+func (w WarmThroughput) Diagnose(path string) diag.List {
+	var list diag.List
+	if w.ReadUnitsPerSecond == nil && w.WriteUnitsPerSecond == nil {
+		list = append(list, diag.Errorf(diag.CodeWarmThroughputInvalid, path, "warm_throughput must set read_units_per_second or write_units_per_second").
+			Suggest("set the units or remove warm_throughput"))
+	}
+	if w.ReadUnitsPerSecond != nil && *w.ReadUnitsPerSecond <= 0 {
+		list = append(list, diag.Errorf(diag.CodeWarmThroughputInvalid, path+"/read_units_per_second", "read_units_per_second must be positive, got %d", *w.ReadUnitsPerSecond).
+			Suggest("set a positive number of read units or remove it"))
+	}
+	if w.WriteUnitsPerSecond != nil && *w.WriteUnitsPerSecond <= 0 {
+		list = append(list, diag.Errorf(diag.CodeWarmThroughputInvalid, path+"/write_units_per_second", "write_units_per_second must be positive, got %d", *w.WriteUnitsPerSecond).
+			Suggest("set a positive number of write units or remove it"))
+	}
+	return list
+}
@@ -0,0 +1,155 @@
+// Package lock tracks previously generated outputs to skip unchanged schemas.
+//
+// The lock file (.godyno.lock) is stored in the output directory and maps every
+// schema to the fingerprint of its last render and the files it produced:
+//
+//	{
+//	  "version": "0.0.1",
+//	  "entries": {
+//	    "schemas/users.json": {"fingerprint": "…", "files": ["generated/users/users.go"]}
+//	  }
+//	}
+//
+// A schema is up to date when its fingerprint hasn't changed and all files still exist.
+package lock
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/fs"
+)
+
+// FileName is the lock file name inside the output directory.
+const FileName = ".godyno.lock"
+
+// Entry is the last render state of a single schema.
+type Entry struct {
+	// Fingerprint of the schema content, generation options and templates.
+	Fingerprint string `json:"fingerprint"`
+
+	// Files written by the render.
+	Files []string `json:"files"`
+}
+
+// Lock is the set of entries loaded from the output directory.
+// Safe for concurrent use.
+type Lock struct {
+	mu      sync.Mutex
+	path    string
+	version string
+	entries map[string]Entry
+	changed bool
+}
+
+type lockFile struct {
+	Version string           `json:"version"`
+	Entries map[string]Entry `json:"entries"`
+}
+
+// Load reads the lock file from outputDir.
+// A missing file or a file written by another generator version results in an empty lock.
+//
+// Example:
+//
+//	l, err := lock.Load("./generated", godyno.Version)
+func Load(outputDir, version string) (*Lock, error) {
+	l := &Lock{
+		path:    filepath.Join(outputDir, FileName),
+		version: version,
+		entries: make(map[string]Entry),
+	}
+
+	data, err := os.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, logger.NewFailure("failed to read lock file", err).
+			With("path", l.path)
+	}
+
+	var f lockFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, logger.NewFailure("failed to parse lock file", err).
+			With("path", l.path)
+	}
+	if f.Version != version {
+		logger.Log.Debug().
+			Str("path", l.path).
+			Str("locked", f.Version).
+			Str("current", version).
+			Msg("Lock file created by another version, ignored")
+		l.changed = true
+		return l, nil
+	}
+	for schema, entry := range f.Entries {
+		l.entries[schema] = entry
+	}
+	return l, nil
+}
+
+// UpToDate returns true if schema was rendered with the same fingerprint and all its files exist.
+func (l *Lock) UpToDate(schema, fingerprint string) bool {
+	l.mu.Lock()
+	entry, ok := l.entries[schema]
+	l.mu.Unlock()
+
+	if !ok || entry.Fingerprint != fingerprint || len(entry.Files) == 0 {
+		return false
+	}
+	for _, file := range entry.Files {
+		if _, err := os.Stat(file); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Files returns the files recorded for schema.
+func (l *Lock) Files(schema string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), l.entries[schema].Files...)
+}
+
+// Set records the render state of schema.
+func (l *Lock) Set(schema, fingerprint string, files []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[schema]
+	if ok && entry.Fingerprint == fingerprint && slices.Equal(entry.Files, files) {
+		return
+	}
+	l.entries[schema] = Entry{
+		Fingerprint: fingerprint,
+		Files:       append([]string(nil), files...),
+	}
+	l.changed = true
+}
+
+// Save writes the lock file if any entry has changed since Load.
+func (l *Lock) Save() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.changed {
+		return nil
+	}
+	data, err := json.MarshalIndent(lockFile{Version: l.version, Entries: l.entries}, "", "  ")
+	if err != nil {
+		return logger.NewFailure("failed to encode lock file", err).
+			With("path", l.path)
+	}
+	if err := fs.WriteToFile(l.path, append(data, '\n')); err != nil {
+		return err
+	}
+	l.changed = false
+	return nil
+}
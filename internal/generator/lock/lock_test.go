@@ -0,0 +1,125 @@
+package lock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rendered creates the files of a render in dir and returns their paths.
+func rendered(t *testing.T, dir string, names ...string) []string {
+	t.Helper()
+	files := make([]string, len(names))
+	for i, name := range names {
+		files[i] = filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(files[i], []byte("package users\n"), 0o644))
+	}
+	return files
+}
+
+// saved returns the lock of dir after recording a render of schema.
+func saved(t *testing.T, dir, schema, fingerprint string, files []string) *Lock {
+	t.Helper()
+	l, err := Load(dir, "1.0.0")
+	require.NoError(t, err)
+	l.Set(schema, fingerprint, files)
+	require.NoError(t, l.Save())
+
+	l, err = Load(dir, "1.0.0")
+	require.NoError(t, err)
+	return l
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Load(dir, "1.0.0")
+	require.NoError(t, err)
+	assert.False(t, l.UpToDate("users.json", "abc"))
+	assert.Empty(t, l.Files("users.json"))
+
+	require.NoError(t, l.Save())
+	assert.NoFileExists(t, filepath.Join(dir, FileName))
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte("{"), 0o644))
+
+	_, err := Load(dir, "1.0.0")
+	assert.Error(t, err)
+}
+
+func TestUpToDate_Unchanged(t *testing.T) {
+	dir := t.TempDir()
+	files := rendered(t, dir, "users.go", "users_test.go")
+	l := saved(t, dir, "users.json", "abc", files)
+
+	assert.True(t, l.UpToDate("users.json", "abc"))
+	assert.Equal(t, files, l.Files("users.json"))
+}
+
+func TestUpToDate_ChangedFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	l := saved(t, dir, "users.json", "abc", rendered(t, dir, "users.go"))
+
+	assert.False(t, l.UpToDate("users.json", "def"))
+	assert.False(t, l.UpToDate("orders.json", "abc"))
+}
+
+func TestUpToDate_MissingOutput(t *testing.T) {
+	dir := t.TempDir()
+	files := rendered(t, dir, "users.go", "users_test.go")
+	l := saved(t, dir, "users.json", "abc", files)
+	require.NoError(t, os.Remove(files[1]))
+
+	assert.False(t, l.UpToDate("users.json", "abc"))
+}
+
+func TestUpToDate_OtherVersion(t *testing.T) {
+	dir := t.TempDir()
+	saved(t, dir, "users.json", "abc", rendered(t, dir, "users.go"))
+
+	l, err := Load(dir, "2.0.0")
+	require.NoError(t, err)
+	assert.False(t, l.UpToDate("users.json", "abc"))
+
+	require.NoError(t, l.Save())
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"version": "2.0.0"`)
+	assert.NotContains(t, string(data), "users.json")
+}
+
+func TestSave_Changed(t *testing.T) {
+	dir := t.TempDir()
+	l := saved(t, dir, "users.json", "abc", rendered(t, dir, "users.go"))
+
+	files := rendered(t, dir, "users.go", "users_doc.go")
+	l.Set("users.json", "def", files)
+	require.NoError(t, l.Save())
+
+	l, err := Load(dir, "1.0.0")
+	require.NoError(t, err)
+	assert.True(t, l.UpToDate("users.json", "def"))
+	assert.Equal(t, files, l.Files("users.json"))
+}
+
+// TestSet_ForcedRender records an up to date schema again, as --force does after rendering it.
+func TestSet_ForcedRender(t *testing.T) {
+	dir := t.TempDir()
+	files := rendered(t, dir, "users.go")
+	l := saved(t, dir, "users.json", "abc", files)
+	require.True(t, l.UpToDate("users.json", "abc"))
+
+	l.Set("users.json", "abc", files)
+	require.NoError(t, os.Remove(filepath.Join(dir, FileName)))
+	require.NoError(t, l.Save())
+	assert.NoFileExists(t, filepath.Join(dir, FileName), "Unchanged entries should not rewrite the lock file")
+
+	l.Set("users.json", "abc", rendered(t, dir, "users.go", "users_test.go"))
+	require.NoError(t, l.Save())
+	assert.FileExists(t, filepath.Join(dir, FileName), "New files of a forced render should be recorded")
+}
@@ -0,0 +1,175 @@
+// Package module scaffolds a standalone Go module around generated packages.
+//
+// With --init-module the output directory becomes the module root:
+//
+//	generated/
+//	├── go.mod        module github.com/org/tables, pinned AWS SDK requirements
+//	├── doc.go        package overview with import paths of every table package
+//	├── users/users.go
+//	└── orders/orders.go
+//
+// go.mod is only created when missing, so a module which is already versioned
+// (and tidied) by its owners is never overwritten. doc.go is regenerated on every run.
+// go.sum isn't written: run "go mod tidy" in the module after the first generation.
+package module
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/fs"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/tmpl"
+
+	"golang.org/x/mod/module"
+)
+
+const (
+	// AWSSDKVersion is the github.com/aws/aws-sdk-go-v2 version generated code is built against.
+	AWSSDKVersion = "1.36.3"
+
+	// DynamoDBVersion is the github.com/aws/aws-sdk-go-v2/service/dynamodb version generated code is built against.
+	DynamoDBVersion = "1.43.1"
+
+	// GoModFile is the module manifest name.
+	GoModFile = "go.mod"
+
+	// DocFile is the package overview written to the module root.
+	DocFile = "doc.go"
+)
+
+// goModTemplate is the manifest with AWS SDK versions generated code is built against.
+const goModTemplate = `module {{.Path}}
+
+go 1.24
+
+require (
+	github.com/aws/aws-lambda-go v1.47.0
+	github.com/aws/aws-sdk-go-v2 v{{.SDKVersion}}
+	github.com/aws/aws-sdk-go-v2/config v1.29.14
+	github.com/aws/aws-sdk-go-v2/credentials v1.17.67
+	github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue v1.19.0
+	github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression v1.7.82
+	github.com/aws/aws-sdk-go-v2/service/dynamodb v{{.DynamoDBVersion}}
+	github.com/aws/smithy-go v1.22.2
+	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842
+)
+`
+
+const docTemplate = `// Code generated by go-dyno. DO NOT EDIT.
+
+// Package {{.Name}} contains DynamoDB table packages generated by go-dyno.
+//
+// Every table lives in its own sub-package:
+//{{range .Packages}}
+//   - {{.}}{{end}}
+package {{.Name}}
+`
+
+// CheckPath validates modulePath as a Go module path (e.g. "github.com/org/tables").
+func CheckPath(modulePath string) error {
+	if err := module.CheckPath(modulePath); err != nil {
+		return logger.NewFailure("invalid module path", err).
+			With("module", modulePath)
+	}
+	return nil
+}
+
+// GoMod returns the go.mod content for modulePath.
+func GoMod(modulePath string) []byte {
+	return []byte(tmpl.MustParseTemplateToString(goModTemplate, struct {
+		Path            string
+		SDKVersion      string
+		DynamoDBVersion string
+	}{modulePath, AWSSDKVersion, DynamoDBVersion}))
+}
+
+// PackageName returns the Go package name of the module root,
+// derived from the last path element without a major version suffix.
+//
+// Example:
+//
+//	PackageName("github.com/org/tables/v2") → "tables"
+func PackageName(modulePath string) string {
+	prefix, _, _ := module.SplitPathVersion(modulePath)
+	return conv.ToLowerInlineCase(conv.ToSafeName(path.Base(prefix)))
+}
+
+// Scaffold writes go.mod (if missing) and doc.go for modulePath into dir.
+// Sub-packages are discovered from the directories of dir which contain Go files.
+// Returns the paths of written files.
+//
+// Example:
+//
+//	files, err := module.Scaffold("./generated", "github.com/org/tables")
+func Scaffold(dir, modulePath string) ([]string, error) {
+	if err := CheckPath(modulePath); err != nil {
+		return nil, err
+	}
+
+	var written []string
+	goModPath := filepath.Join(dir, GoModFile)
+	if _, err := os.Stat(goModPath); err == nil {
+		logger.Log.Debug().
+			Str("path", goModPath).
+			Msg("go.mod already exists, kept")
+	} else {
+		if err := fs.WriteToFile(goModPath, GoMod(modulePath)); err != nil {
+			return nil, err
+		}
+		written = append(written, goModPath)
+	}
+
+	packages, err := subPackages(dir, modulePath)
+	if err != nil {
+		return nil, err
+	}
+	doc := tmpl.MustParseTemplateToString(docTemplate, struct {
+		Name     string
+		Packages []string
+	}{
+		Name:     PackageName(modulePath),
+		Packages: packages,
+	})
+	docPath := filepath.Join(dir, DocFile)
+	if err := fs.WriteToFile(docPath, []byte(doc)); err != nil {
+		return nil, err
+	}
+	written = append(written, docPath)
+
+	logger.Log.Info().
+		Str("module", modulePath).
+		Int("packages", len(packages)).
+		Msg("Module scaffolded")
+	return written, nil
+}
+
+// subPackages returns the import paths of dir's sub-directories which contain Go files.
+func subPackages(dir, modulePath string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, logger.NewFailure("failed to list output directory", err).
+			With("path", dir)
+	}
+
+	var packages []string
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		files, err := filepath.Glob(filepath.Join(dir, e.Name(), "*.go"))
+		if err != nil {
+			return nil, logger.NewFailure("failed to list package files", err).
+				With("path", filepath.Join(dir, e.Name()))
+		}
+		if len(files) > 0 {
+			packages = append(packages, path.Join(modulePath, e.Name()))
+		}
+	}
+	sort.Strings(packages)
+	return packages, nil
+}
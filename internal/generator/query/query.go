@@ -0,0 +1,176 @@
+// Package query defines named queries: approved access patterns declared in the schema
+// under "queries" and rendered as strongly-typed functions of the generated package.
+//
+// Example:
+//
+//	"queries": {
+//	  "recent_published_by_user": {
+//	    "hash_key": "user_id",
+//	    "range_key": "created_at",
+//	    "range_operator": "GTE",
+//	    "filters": [{"attribute": "status", "operator": "EQ", "value": "published"}],
+//	    "descending": true
+//	  }
+//	}
+//
+// renders QueryRecentPublishedByUser(userId string, createdAt int64) *QueryBuilder.
+package query
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
+)
+
+var (
+	// rangeOperators lists operators of range key conditions with their number of values.
+	rangeOperators = map[string]int{
+		"EQ":          1,
+		"GT":          1,
+		"GTE":         1,
+		"LT":          1,
+		"LTE":         1,
+		"BETWEEN":     2,
+		"BEGINS_WITH": 1,
+	}
+
+	// filterOperators lists operators of filters with their number of values.
+	filterOperators = map[string]int{
+		"EQ":           1,
+		"NE":           1,
+		"GT":           1,
+		"GTE":          1,
+		"LT":           1,
+		"LTE":          1,
+		"BETWEEN":      2,
+		"CONTAINS":     1,
+		"NOT_CONTAINS": 1,
+		"BEGINS_WITH":  1,
+		"EXISTS":       0,
+		"NOT_EXISTS":   0,
+	}
+)
+
+// Query is a named query of the schema.
+type Query struct {
+	// Description documents the access pattern in the generated code. Optional.
+	Description string `json:"description,omitempty"`
+
+	// Index is the secondary index the query reads.
+	// If empty, the table or the first index matching HashKey and RangeKey is used.
+	Index string `json:"index,omitempty"`
+
+	// HashKey is the attribute (or composite key) the query looks items up by. Optional:
+	// if set, the schema must have the table or an index with this hash key, otherwise the
+	// query would need a scan and generation fails.
+	HashKey string `json:"hash_key,omitempty"`
+
+	// RangeKey is the range key the results are ordered and bounded by. Optional, see HashKey.
+	RangeKey string `json:"range_key,omitempty"`
+
+	// RangeOperator is the operator of the range key condition, no range condition if empty.
+	// One of EQ, GT, GTE, LT, LTE, BETWEEN, BEGINS_WITH.
+	RangeOperator string `json:"range_operator,omitempty"`
+
+	// Filters are applied to the items read by the key condition.
+	Filters []Filter `json:"filters,omitempty"`
+
+	// Descending returns items in descending order of the range key.
+	Descending bool `json:"descending,omitempty"`
+
+	// Limit is the number of items evaluated per request, no limit if 0.
+	Limit int `json:"limit,omitempty"`
+}
+
+// RangeValues returns the number of values of the range key condition.
+func (q Query) RangeValues() int {
+	return rangeOperators[q.RangeOperator]
+}
+
+// Filter is a filter of a named query.
+type Filter struct {
+	// Attribute is the name of the filtered attribute.
+	Attribute string `json:"attribute"`
+
+	// Operator is one of EQ, NE, GT, GTE, LT, LTE, BETWEEN, CONTAINS, NOT_CONTAINS, BEGINS_WITH, EXISTS, NOT_EXISTS.
+	Operator string `json:"operator"`
+
+	// Value is the constant value of the filter (a list of two values for BETWEEN).
+	// If omitted, the value is a parameter of the generated function.
+	Value any `json:"value,omitempty"`
+}
+
+// IsConstant returns true if the filter value is declared in the schema.
+func (f Filter) IsConstant() bool {
+	return f.Value != nil
+}
+
+// Values returns the constant values of the filter.
+func (f Filter) Values() []any {
+	if list, ok := f.Value.([]any); ok && f.Operator == "BETWEEN" {
+		return list
+	}
+	if f.Value == nil {
+		return nil
+	}
+	return []any{f.Value}
+}
+
+// ValueCount returns the number of values of the filter operator.
+func (f Filter) ValueCount() int {
+	return filterOperators[f.Operator]
+}
+
+// GoName returns the name of the generated function of the query, e.g. "QueryRecentPublishedByUser".
+func GoName(name string) string {
+	return "Query" + conv.ToUpperCamelCase(name)
+}
+
+// Diagnose returns problems of the query which don't depend on the rest of the schema.
+// path is the JSON pointer of the query in the schema (e.g. "/queries/by_user").
+func (q Query) Diagnose(path string) diag.List {
+	var list diag.List
+	if q.RangeOperator != "" {
+		if _, ok := rangeOperators[q.RangeOperator]; !ok {
+			list = append(list, diag.Errorf(diag.CodeQueryOperatorInvalid, path+"/range_operator", "invalid range operator '%s'", q.RangeOperator).
+				Suggest("use one of: %s", strings.Join(slices.Sorted(maps.Keys(rangeOperators)), ", ")))
+		}
+	}
+	if q.Limit < 0 {
+		list = append(list, diag.Errorf(diag.CodeQueryValueInvalid, path+"/limit", "limit must not be negative, got %d", q.Limit).
+			Suggest("remove limit or set it to a positive number"))
+	}
+	for i, f := range q.Filters {
+		fpath := diag.Pointer("filters", i)
+		count, ok := filterOperators[f.Operator]
+		if !ok {
+			list = append(list, diag.Errorf(diag.CodeQueryOperatorInvalid, path+fpath+"/operator", "invalid filter operator '%s'", f.Operator).
+				Suggest("use one of: %s", strings.Join(slices.Sorted(maps.Keys(filterOperators)), ", ")))
+			continue
+		}
+		if !f.IsConstant() {
+			continue
+		}
+		switch values := f.Values(); {
+		case count == 0:
+			list = append(list, diag.Errorf(diag.CodeQueryValueInvalid, path+fpath+"/value", "operator '%s' takes no value", f.Operator).
+				Suggest("remove the value"))
+		case len(values) != count:
+			list = append(list, diag.Errorf(diag.CodeQueryValueInvalid, path+fpath+"/value", "operator '%s' takes %d values, got %d", f.Operator, count, len(values)).
+				Suggest("use a list of %d values", count))
+		default:
+			for _, v := range values {
+				switch v.(type) {
+				case string, float64, bool:
+				default:
+					list = append(list, diag.Errorf(diag.CodeQueryValueInvalid, path+fpath+"/value", "unsupported value %v of filter on '%s'", v, f.Attribute).
+						Suggest("use a string, number or boolean"))
+				}
+			}
+		}
+	}
+	return list
+}
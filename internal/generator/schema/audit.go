@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// AuditAttributes returns the audit timestamp attributes.
+func (s Schema) AuditAttributes() []attribute.Attribute {
+	var audit []attribute.Attribute
+	for _, attr := range s.AllAttributes() {
+		if attr.IsAuto() {
+			audit = append(audit, attr)
+		}
+	}
+	return audit
+}
+
+// diagnoseAudit reports audit timestamps generated writes can't manage:
+// each timestamp is declared once and is not a key, computed or the version.
+func (s *Schema) diagnoseAudit() diag.List {
+	var (
+		list     diag.List
+		declared = map[string]string{}
+		keys     = s.keyAttributes()
+	)
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if !attr.IsAuto() {
+				continue
+			}
+			path := diag.Pointer(section, i) + "/auto"
+			switch {
+			case declared[attr.Auto] != "":
+				list = append(list, diag.Errorf(diag.CodeAttributeAutoConflict, path, "attribute '%s' is marked as %s, but '%s' already is", attr.Name, attr.Auto, declared[attr.Auto]).
+					Suggest("mark a single attribute as %s", attr.Auto))
+			case keys[attr.Name]:
+				list = append(list, diag.Errorf(diag.CodeAttributeAutoConflict, path, "key attribute '%s' can't be an auto timestamp", attr.Name).
+					Suggest("use a non-key attribute, keys of an item never change"))
+			case attr.IsComputed() || attr.Version:
+				list = append(list, diag.Errorf(diag.CodeAttributeAutoConflict, path, "auto timestamp '%s' can't be computed or the version", attr.Name).
+					Suggest("remove computed and version from '%s'", attr.Name))
+			}
+			if declared[attr.Auto] == "" {
+				declared[attr.Auto] = attr.Name
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// ChecksumAttribute returns the attribute holding the item checksum, nil if none is declared.
+func (s Schema) ChecksumAttribute() *attribute.Attribute {
+	for _, attr := range s.AllAttributes() {
+		if attr.Checksum {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// diagnoseChecksum reports checksum attributes generated writes can't maintain:
+// a table has a single checksum attribute, which holds no other managed value.
+func (s *Schema) diagnoseChecksum() diag.List {
+	var (
+		list     diag.List
+		declared string
+		keys     = s.keyAttributes()
+	)
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if !attr.Checksum {
+				continue
+			}
+			path := diag.Pointer(section, i) + "/checksum"
+			switch {
+			case declared != "":
+				list = append(list, diag.Errorf(diag.CodeAttributeChecksumConflict, path, "attribute '%s' is marked as checksum, but '%s' already is", attr.Name, declared).
+					Suggest("mark a single attribute as checksum"))
+			case keys[attr.Name]:
+				list = append(list, diag.Errorf(diag.CodeAttributeChecksumConflict, path, "key attribute '%s' can't be the checksum", attr.Name).
+					Suggest("use a dedicated non-key attribute"))
+			case attr.IsComputed() || attr.Guard != "" || attr.Version || attr.IsAuto() || attr.IsSensitive():
+				list = append(list, diag.Errorf(diag.CodeAttributeChecksumConflict, path, "checksum attribute '%s' can't be computed, guarded, sensitive, the version or an auto timestamp", attr.Name).
+					Suggest("use a dedicated attribute, its value is managed by generated writes"))
+			}
+			if declared == "" {
+				declared = attr.Name
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+)
+
+// CompositeKeys returns how the parts of composite key values are joined.
+func (s Schema) CompositeKeys() index.CompositeFormat {
+	return s.raw.CompositeKeys
+}
+
+// diagnoseCompositeKeys reports invalid separators and escaping of composite key values.
+func (s *Schema) diagnoseCompositeKeys() diag.List {
+	keys := make(map[string]bool)
+	for _, idx := range s.raw.SecondaryIndexes {
+		for _, key := range []string{idx.HashKey, idx.RangeKey} {
+			if strings.Contains(key, "#") {
+				keys[key] = true
+			}
+		}
+	}
+	return s.raw.CompositeKeys.Diagnose("/composite_keys", keys)
+}
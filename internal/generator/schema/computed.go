@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// ComputedAttributes returns attributes derived from other attributes on write.
+func (s Schema) ComputedAttributes() []attribute.Attribute {
+	var computed []attribute.Attribute
+	for _, attr := range s.AllAttributes() {
+		if attr.IsComputed() {
+			computed = append(computed, attr)
+		}
+	}
+	return computed
+}
+
+// diagnoseComputed reports computed attributes whose inputs can't be resolved:
+// undeclared, computed or optional inputs, and primary key attributes, which updates can't recompute.
+func (s *Schema) diagnoseComputed() diag.List {
+	var (
+		list  diag.List
+		attrs = make(map[string]attribute.Attribute)
+	)
+	for _, attr := range s.AllAttributes() {
+		attrs[attr.Name] = attr
+	}
+	check := func(section string, items []attribute.Attribute) {
+		for i, attr := range items {
+			expr, err := attr.ComputedExpr()
+			if !attr.IsComputed() || err != nil {
+				continue
+			}
+			path := diag.Pointer(section, i) + "/computed"
+			if attr.Name == s.HashKey() || attr.Name == s.RangeKey() {
+				list = append(list, diag.Errorf(diag.CodeAttributeComputedInput, path, "primary key attribute '%s' can't be computed", attr.Name).
+					Suggest("compute an index key instead of the primary key"))
+			}
+			if len(expr.Refs()) == 0 {
+				list = append(list, diag.Errorf(diag.CodeAttributeComputedInput, path, "computed attribute '%s' uses no attributes", attr.Name).
+					Suggest("reference at least one attribute in the expression"))
+			}
+			for _, ref := range expr.Refs() {
+				input, ok := attrs[ref]
+				switch {
+				case !ok:
+					list = append(list, diag.Errorf(diag.CodeAttributeComputedInput, path, "computed attribute '%s' uses undeclared attribute '%s'", attr.Name, ref).
+						Suggest("add '%s' to common_attributes or fix the name", ref))
+				case input.IsComputed():
+					list = append(list, diag.Errorf(diag.CodeAttributeComputedInput, path, "computed attribute '%s' uses computed attribute '%s'", attr.Name, ref).
+						Suggest("inline the expression of '%s'", ref))
+				case input.IsOptional():
+					list = append(list, diag.Errorf(diag.CodeAttributeComputedInput, path, "computed attribute '%s' uses optional attribute '%s'", attr.Name, ref).
+						Suggest("remove required from '%s'", ref))
+				case input.HasCustomGoType():
+					list = append(list, diag.Errorf(diag.CodeAttributeComputedInput, path, "computed attribute '%s' uses attribute '%s' with go_type", attr.Name, ref).
+						Suggest("remove go_type from '%s'", ref))
+				}
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
@@ -0,0 +1,22 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// diagnoseDefaults reports primary key attributes with a default: every item would share the key.
+func (s *Schema) diagnoseDefaults() diag.List {
+	var list diag.List
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if attr.HasDefault() && (attr.Name == s.HashKey() || attr.Name == s.RangeKey()) {
+				list = append(list, diag.Errorf(diag.CodeAttributeDefaultInvalid, diag.Pointer(section, i)+"/default", "primary key attribute '%s' can't declare a default", attr.Name).
+					Suggest("remove default from '%s'", attr.Name))
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// reservedContextKeys are encryption context keys set by generated code.
+var reservedContextKeys = []string{"table", "attribute"}
+
+// Encryption declares encryption hints of the table.
+//
+// Example:
+//
+//	"encryption": {
+//	  "kms_key_id": "alias/users",
+//	  "context": {"app": "billing"}
+//	}
+type Encryption struct {
+	// KMSKeyID is the KMS key (ID, ARN or alias) protecting sensitive attributes.
+	KMSKeyID string `json:"kms_key_id,omitempty"`
+
+	// Context is the encryption context shared by all sensitive attributes.
+	Context map[string]string `json:"context,omitempty"`
+}
+
+// Encryption returns the encryption hints of the table.
+func (s Schema) Encryption() Encryption {
+	return s.raw.Encryption
+}
+
+// SensitiveAttributes returns attributes marked sensitive.
+func (s Schema) SensitiveAttributes() []attribute.Attribute {
+	var sensitive []attribute.Attribute
+	for _, attr := range s.AllAttributes() {
+		if attr.IsSensitive() {
+			sensitive = append(sensitive, attr)
+		}
+	}
+	return sensitive
+}
+
+// diagnoseEncryption reports sensitive key attributes, which client-side encryption
+// leaves in plaintext, and encryption contexts overriding keys set by generated code.
+func (s *Schema) diagnoseEncryption() diag.List {
+	var (
+		list diag.List
+		keys = s.keyAttributes()
+	)
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if attr.IsSensitive() && keys[attr.Name] {
+				list = append(list, diag.Warningf(diag.CodeAttributeSensitiveKey, diag.Pointer(section, i), "sensitive key attribute '%s' is not encrypted client-side, keys must stay queryable", attr.Name).
+					Suggest("use 'anonymize' for copies or rely on table server-side encryption for '%s'", attr.Name))
+			}
+			list = append(list, diagnoseContext(diag.Pointer(section, i)+"/encryption_context", attr.EncryptionContext)...)
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	list = append(list, diagnoseContext("/encryption/context", s.raw.Encryption.Context)...)
+	return list
+}
+
+func diagnoseContext(path string, context map[string]string) diag.List {
+	var list diag.List
+	for _, key := range reservedContextKeys {
+		if _, ok := context[key]; ok {
+			list = append(list, diag.Errorf(diag.CodeEncryptionContextReserved, path, "encryption context key '%s' is reserved", key).
+				Suggest("rename the key, generated code sets '%s' to the %s name", key, key))
+		}
+	}
+	return list
+}
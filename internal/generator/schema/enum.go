@@ -0,0 +1,22 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// diagnoseEnums reports enum primary key attributes: a few values make a few hot partitions.
+func (s *Schema) diagnoseEnums() diag.List {
+	var list diag.List
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if attr.IsEnum() && (attr.Name == s.HashKey() || attr.Name == s.RangeKey()) {
+				list = append(list, diag.Errorf(diag.CodeAttributeEnumConflict, diag.Pointer(section, i)+"/enum", "primary key attribute '%s' can't declare enum", attr.Name).
+					Suggest("query enum values with a secondary index on '%s'", attr.Name))
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"slices"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// DefaultSnapshotKeyPrefix is the prefix of the hash key of snapshot items.
+const DefaultSnapshotKeyPrefix = "snapshot#"
+
+// DefaultSnapshotEvery is the number of events between snapshots suggested by the generated SnapshotDue.
+const DefaultSnapshotEvery = 100
+
+// SnapshotAttributes are the attributes of snapshot items written by the generated SaveSnapshot.
+var SnapshotAttributes = []string{"snapshot_state"}
+
+// EventLog flags the table as the event log of event-sourced aggregates, generating AppendEvent,
+// LoadEvents, SaveSnapshot and LoadLatestSnapshot. Events are keyed by the aggregate ID hash key
+// and a monotonic sequence range key starting at 1. Snapshots are stored in the table itself:
+// their hash key is the key prefix followed by the aggregate ID, their range key the sequence they cover.
+//
+// Example:
+//
+//	"event_log": {
+//	  "snapshot_prefix": "snapshot#",
+//	  "snapshot_every": 100
+//	}
+type EventLog struct {
+	// SnapshotPrefix is prepended to aggregate IDs, "snapshot#" if not set.
+	SnapshotPrefix string `json:"snapshot_prefix,omitempty"`
+
+	// SnapshotEvery is the number of events between snapshots, 100 if not set.
+	SnapshotEvery int `json:"snapshot_every,omitempty"`
+}
+
+// HasEventLog returns true if the table is flagged as an event log.
+func (s Schema) HasEventLog() bool {
+	return s.raw.EventLog != nil
+}
+
+// EventLog returns the event log declared in the schema with defaults applied.
+func (s Schema) EventLog() EventLog {
+	var log EventLog
+	if s.raw.EventLog != nil {
+		log = *s.raw.EventLog
+	}
+	if log.SnapshotPrefix == "" {
+		log.SnapshotPrefix = DefaultSnapshotKeyPrefix
+	}
+	if log.SnapshotEvery == 0 {
+		log.SnapshotEvery = DefaultSnapshotEvery
+	}
+	return log
+}
+
+// diagnoseEventLog reports event logs the generated helpers can't work with: events need a plain
+// string hash key and an integer range key, snapshots attributes not declared by the schema.
+func (s *Schema) diagnoseEventLog() diag.List {
+	if s.raw.EventLog == nil {
+		return nil
+	}
+	var list diag.List
+	for _, attr := range s.AllAttributes() {
+		switch {
+		case attr.Name == s.HashKey() && attr.GoType() != "string":
+			list = append(list, diag.Errorf(diag.CodeEventLogInvalid, "/hash_key", "aggregate ID '%s' must be a plain string, it's %s", attr.Name, attr.GoType()).
+				Suggest("use an S hash key without enum or go_type"))
+		case attr.Name == s.RangeKey() && !slices.Contains(sequenceGoTypes, attr.GoType()):
+			list = append(list, diag.Errorf(diag.CodeEventLogInvalid, "/range_key", "event sequence '%s' must be an integer number, it's %s", attr.Name, attr.GoType()).
+				Suggest("use an N range key with an integer subtype, e.g. int64"))
+		}
+	}
+	if s.RangeKey() == "" {
+		list = append(list, diag.Errorf(diag.CodeEventLogInvalid, "/range_key", "event logs need a sequence range key").
+			Suggest("declare an N range key numbering the events of an aggregate"))
+	}
+	if s.raw.EventLog.SnapshotEvery < 0 {
+		list = append(list, diag.Errorf(diag.CodeEventLogInvalid, "/event_log/snapshot_every", "snapshot_every must be positive, got %d", s.raw.EventLog.SnapshotEvery).
+			Suggest("remove snapshot_every to snapshot every %d events", DefaultSnapshotEvery))
+	}
+	return append(list, s.diagnoseReservedAttributes("snapshot", "/event_log", SnapshotAttributes)...)
+}
+
+// sequenceGoTypes are the Go types of event sequence range keys.
+var sequenceGoTypes = []string{"int", "int32", "int64", "uint", "uint32", "uint64"}
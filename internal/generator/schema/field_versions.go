@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// FieldVersionsAttribute returns the attribute holding per-attribute modification times, nil if none is declared.
+func (s Schema) FieldVersionsAttribute() *attribute.Attribute {
+	for _, attr := range s.AllAttributes() {
+		if attr.FieldVersions {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// diagnoseFieldVersions reports field versions attributes generated updates can't maintain:
+// a table has a single one, which is not a key or otherwise managed.
+func (s *Schema) diagnoseFieldVersions() diag.List {
+	var (
+		list     diag.List
+		declared string
+		keys     = s.keyAttributes()
+	)
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if !attr.FieldVersions {
+				continue
+			}
+			path := diag.Pointer(section, i) + "/field_versions"
+			switch {
+			case declared != "":
+				list = append(list, diag.Errorf(diag.CodeAttributeFieldVersionsConflict, path, "attribute '%s' is marked as field versions, but '%s' already is", attr.Name, declared).
+					Suggest("mark a single attribute as field versions"))
+			case keys[attr.Name]:
+				list = append(list, diag.Errorf(diag.CodeAttributeFieldVersionsConflict, path, "key attribute '%s' can't hold field versions", attr.Name).
+					Suggest("use a dedicated non-key attribute"))
+			case attr.IsComputed() || attr.Guard != "" || attr.IsSensitive() || attr.Checksum:
+				list = append(list, diag.Errorf(diag.CodeAttributeFieldVersionsConflict, path, "field versions attribute '%s' can't be computed, guarded, sensitive or the checksum", attr.Name).
+					Suggest("use a dedicated attribute, its value is managed by generated updates"))
+			}
+			if declared == "" {
+				declared = attr.Name
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
@@ -0,0 +1,22 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// diagnoseGoTypes reports primary key attributes with go_type, generated keys use the DynamoDB types.
+func (s *Schema) diagnoseGoTypes() diag.List {
+	var list diag.List
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if attr.HasCustomGoType() && (attr.Name == s.HashKey() || attr.Name == s.RangeKey()) {
+				list = append(list, diag.Errorf(diag.CodeAttributeGoTypeConflict, diag.Pointer(section, i)+"/go_type", "primary key attribute '%s' can't declare go_type", attr.Name).
+					Suggest("remove go_type from '%s'", attr.Name))
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
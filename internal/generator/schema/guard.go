@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// GuardedAttributes returns attributes with a change guard.
+func (s Schema) GuardedAttributes() []attribute.Attribute {
+	var guarded []attribute.Attribute
+	for _, attr := range s.AllAttributes() {
+		if attr.Guard != "" {
+			guarded = append(guarded, attr)
+		}
+	}
+	return guarded
+}
+
+// diagnoseGuards warns about guards of primary key attributes:
+// updates never change keys, so the guard has no effect.
+func (s *Schema) diagnoseGuards() diag.List {
+	var list diag.List
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if attr.Guard != "" && (attr.Name == s.HashKey() || attr.Name == s.RangeKey()) {
+				list = append(list, diag.Warningf(diag.CodeAttributeGuardKey, diag.Pointer(section, i)+"/guard", "guard '%s' of primary key attribute '%s' has no effect", attr.Guard, attr.Name).
+					Suggest("remove the guard, primary key attributes are never updated"))
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
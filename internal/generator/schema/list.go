@@ -0,0 +1,32 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// diagnoseListStructs reports list element structs declared by several attributes,
+// each struct is generated once per attribute.
+func (s *Schema) diagnoseListStructs() diag.List {
+	var (
+		list     diag.List
+		declared = map[string]string{}
+	)
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			name := attr.ElementStruct()
+			if name == "" {
+				continue
+			}
+			if other, ok := declared[name]; ok {
+				list = append(list, diag.Errorf(diag.CodeAttributeListElementInvalid, diag.Pointer(section, i, "type"), "list element struct '%s' of '%s' is already declared by '%s'", name, attr.Name, other).
+					Suggest("use a distinct struct name"))
+				continue
+			}
+			declared[name] = attr.Name
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
@@ -0,0 +1,129 @@
+package schema
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// DefaultLockKeyPrefix is the prefix of the hash key of lock items.
+const DefaultLockKeyPrefix = "lock#"
+
+// LockAttributes are the attributes of lock items written by the generated Lock, Renew and Release.
+var LockAttributes = []string{"lock_owner", "lock_token", "lock_expires_at"}
+
+// Locks declares the lock items of the generated Lock, Renew and Release, stored in the table itself.
+// The hash key of a lock item is the key prefix followed by the resource ID,
+// its range key (if the table has one) is the sort key.
+//
+// Example:
+//
+//	"locks": {
+//	  "key_prefix": "lock#",
+//	  "sort_key": "LOCK"
+//	}
+type Locks struct {
+	// KeyPrefix is prepended to resource IDs, "lock#" if not set.
+	KeyPrefix string `json:"key_prefix,omitempty"`
+
+	// SortKey is the range key value of lock items: "lock" for string range keys, "0" for numbers if not set.
+	SortKey string `json:"sort_key,omitempty"`
+}
+
+// HasLocks returns true if the schema declares lock items.
+func (s Schema) HasLocks() bool {
+	return s.raw.Locks != nil
+}
+
+// Locks returns the lock items declared in the schema with defaults applied.
+func (s Schema) Locks() Locks {
+	var locks Locks
+	if s.raw.Locks != nil {
+		locks = *s.raw.Locks
+	}
+	if locks.KeyPrefix == "" {
+		locks.KeyPrefix = DefaultLockKeyPrefix
+	}
+	if locks.SortKey == "" {
+		locks.SortKey = s.helperSortKey("lock")
+	}
+	return locks
+}
+
+// diagnoseLocks reports primary keys lock items can't be stored under
+// and declared attributes colliding with the attributes of lock items.
+func (s *Schema) diagnoseLocks() diag.List {
+	if s.raw.Locks == nil {
+		return nil
+	}
+	return s.diagnoseHelperItems("lock", s.Locks().SortKey, LockAttributes)
+}
+
+// helperSortKey returns the default range key value of the items of a generated helper:
+// its name for string range keys, "0" for numbers, empty without a range key.
+func (s Schema) helperSortKey(name string) string {
+	switch {
+	case s.RangeKey() == "":
+		return ""
+	case s.attributeType(s.RangeKey()) == "N":
+		return "0"
+	}
+	return name
+}
+
+// diagnoseHelperItems reports primary keys the items of a generated helper (locks, quotas)
+// declared in the section name+"s" can't be stored under, and declared attributes colliding with theirs.
+func (s *Schema) diagnoseHelperItems(name, sortKey string, reserved []string) diag.List {
+	var (
+		list diag.List
+		path = "/" + name + "s"
+	)
+	if t := s.attributeType(s.HashKey()); t != "" && t != "S" {
+		list = append(list, diag.Errorf(diag.CodeHelperKeyInvalid, path, "%ss need a string hash key, '%s' is %s", name, s.HashKey(), t).
+			Suggest("remove %ss or store them in a table with a string hash key", name))
+	}
+	if rk := s.RangeKey(); rk != "" {
+		switch t := s.attributeType(rk); t {
+		case "", "S":
+		case "N":
+			if _, err := strconv.ParseFloat(sortKey, 64); err != nil {
+				list = append(list, diag.Errorf(diag.CodeHelperKeyInvalid, path+"/sort_key", "%s sort key '%s' is not a number, range key '%s' is N", name, sortKey, rk).
+					Suggest("set sort_key to a number, e.g. \"0\""))
+			}
+		default:
+			list = append(list, diag.Errorf(diag.CodeHelperKeyInvalid, path, "%ss need a string or number range key, '%s' is %s", name, rk, t).
+				Suggest("remove %ss or store them in a table with a string or number range key", name))
+		}
+	}
+	return append(list, s.diagnoseReservedAttributes(name, path, reserved)...)
+}
+
+// diagnoseReservedAttributes reports declared attributes colliding with the attributes
+// of the items of a generated helper, declared in the section at path.
+func (s *Schema) diagnoseReservedAttributes(name, path string, reserved []string) diag.List {
+	var list diag.List
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if slices.Contains(reserved, attr.Name) {
+				list = append(list, diag.Errorf(diag.CodeHelperAttributeReserved, diag.Pointer(section, i)+"/name", "attribute '%s' is reserved for %s items", attr.Name, name).
+					Suggest("rename the attribute or remove %s", strings.TrimPrefix(path, "/")))
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
+
+// attributeType returns the DynamoDB type of a declared attribute, empty if it isn't declared.
+func (s Schema) attributeType(name string) string {
+	for _, attr := range s.AllAttributes() {
+		if attr.Name == name {
+			return attr.Type
+		}
+	}
+	return ""
+}
@@ -0,0 +1,23 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// diagnoseNormalized reports normalized attributes which are no primary or secondary index key:
+// only key lookups are normalized.
+func (s *Schema) diagnoseNormalized() diag.List {
+	var list diag.List
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if attr.IsNormalized() && attr.Name != s.HashKey() && attr.Name != s.RangeKey() && !s.isIndexKey(attr.Name) {
+				list = append(list, diag.Errorf(diag.CodeAttributeNormalizeInvalid, diag.Pointer(section, i)+"/normalized", "normalized attribute '%s' is not a key of the table or a secondary index", attr.Name).
+					Suggest("remove normalized from '%s' or use it as an index key", attr.Name))
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
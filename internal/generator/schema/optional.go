@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// OptionalAttributes returns attributes declared with "required": false.
+func (s Schema) OptionalAttributes() []attribute.Attribute {
+	var optional []attribute.Attribute
+	for _, attr := range s.AllAttributes() {
+		if attr.IsOptional() {
+			optional = append(optional, attr)
+		}
+	}
+	return optional
+}
+
+// diagnoseOptional reports optional primary key attributes, every item has its primary key.
+func (s *Schema) diagnoseOptional() diag.List {
+	var list diag.List
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if attr.IsOptional() && (attr.Name == s.HashKey() || attr.Name == s.RangeKey()) {
+				list = append(list, diag.Errorf(diag.CodeAttributeOptionalConflict, diag.Pointer(section, i)+"/required", "primary key attribute '%s' can't be optional", attr.Name).
+					Suggest("remove required, optional index keys make sparse indexes"))
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// diagnosePayloads reports payload attributes used as keys: marshaled messages aren't
+// deterministic and compressed bytes can't be queried.
+func (s *Schema) diagnosePayloads() diag.List {
+	var (
+		list diag.List
+		keys = s.keyAttributes()
+	)
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if attr.IsPayload() && keys[attr.Name] {
+				list = append(list, diag.Errorf(diag.CodeAttributePayloadConflict, diag.Pointer(section, i)+"/payload", "key attribute '%s' can't hold a payload", attr.Name).
+					Suggest("store the payload in a common attribute"))
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
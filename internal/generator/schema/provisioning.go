@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
+)
+
+// validTableClasses lists DynamoDB table classes.
+var validTableClasses = map[string]bool{
+	"STANDARD":                   true,
+	"STANDARD_INFREQUENT_ACCESS": true,
+}
+
+// TableClass returns the table class declared in the schema, empty for the DynamoDB default (STANDARD).
+func (s Schema) TableClass() string {
+	return strings.ToUpper(s.raw.TableClass)
+}
+
+// WarmThroughput returns the warm throughput of the table, nil if not declared.
+func (s Schema) WarmThroughput() *index.WarmThroughput {
+	return s.raw.WarmThroughput
+}
+
+// diagnoseProvisioning reports unknown table classes and invalid warm throughput of the table.
+func (s *Schema) diagnoseProvisioning() diag.List {
+	var list diag.List
+	if s.raw.TableClass != "" && !validTableClasses[s.TableClass()] {
+		list = append(list, diag.Errorf(diag.CodeTableClassInvalid, "/table_class", "invalid table class '%s'", s.raw.TableClass).
+			Suggest("use one of: %s", strings.Join(conv.AvailableKeys(validTableClasses), ", ")))
+	}
+	if s.raw.WarmThroughput != nil {
+		list = append(list, s.raw.WarmThroughput.Diagnose("/warm_throughput")...)
+	}
+	return list
+}
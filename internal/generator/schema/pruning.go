@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// Time units of the range key of pruned tables, see Pruning.
+const (
+	PruneUnitSeconds      = "s"
+	PruneUnitMilliseconds = "ms"
+	PruneUnitRFC3339      = "rfc3339"
+)
+
+// Pruning flags the table for the generated PruneOlderThan, deleting items by the age of their range key.
+// The range key orders the items of a partition by time: Unix timestamps in the time unit
+// for number range keys, RFC 3339 UTC timestamps for string range keys.
+//
+// Example:
+//
+//	"pruning": {
+//	  "time_unit": "ms"
+//	}
+type Pruning struct {
+	// TimeUnit is the unit of number range keys: "s" (default) or "ms".
+	TimeUnit string `json:"time_unit,omitempty"`
+}
+
+// PruneTimeUnit returns the time unit of the range key of a pruned table:
+// "s" or "ms" for number range keys, "rfc3339" for strings, empty if the table isn't pruned.
+func (s Schema) PruneTimeUnit() string {
+	switch {
+	case s.raw.Pruning == nil:
+		return ""
+	case s.attributeType(s.RangeKey()) == "S":
+		return PruneUnitRFC3339
+	case s.raw.Pruning.TimeUnit == "":
+		return PruneUnitSeconds
+	}
+	return s.raw.Pruning.TimeUnit
+}
+
+// diagnosePruning reports pruned tables PruneOlderThan can't work with:
+// partitions need a string or number hash key, items a time range key.
+func (s *Schema) diagnosePruning() diag.List {
+	if s.raw.Pruning == nil {
+		return nil
+	}
+	var list diag.List
+	if t := s.attributeType(s.HashKey()); t != "" && t != "S" && t != "N" {
+		list = append(list, diag.Errorf(diag.CodePruningInvalid, "/hash_key", "pruned partitions need a string or number hash key, '%s' is %s", s.HashKey(), t).
+			Suggest("remove pruning or prune a table with a string or number hash key"))
+	}
+
+	unit := s.raw.Pruning.TimeUnit
+	switch t := s.attributeType(s.RangeKey()); {
+	case s.RangeKey() == "":
+		list = append(list, diag.Errorf(diag.CodePruningInvalid, "/range_key", "pruned tables need a time range key").
+			Suggest("declare an N or S range key holding the time of the items"))
+	case t == "S" && unit != "":
+		list = append(list, diag.Errorf(diag.CodePruningInvalid, "/pruning/time_unit", "time_unit applies to number range keys, '%s' holds RFC 3339 timestamps", s.RangeKey()).
+			Suggest("remove time_unit"))
+	case t == "N" && unit != "" && unit != PruneUnitSeconds && unit != PruneUnitMilliseconds:
+		list = append(list, diag.Errorf(diag.CodePruningInvalid, "/pruning/time_unit", "invalid time_unit '%s'", unit).
+			Suggest("use \"s\" or \"ms\""))
+	case t != "" && t != "S" && t != "N":
+		list = append(list, diag.Errorf(diag.CodePruningInvalid, "/range_key", "time range key '%s' must be N or S, it's %s", s.RangeKey(), t).
+			Suggest("change the type of '%s' to N holding Unix timestamps", s.RangeKey()))
+	}
+	return list
+}
@@ -0,0 +1,220 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/query"
+)
+
+// queryTarget is the table or a secondary index a named query can read.
+type queryTarget struct {
+	index      *index.Index // nil for the table
+	hashKey    string
+	rangeKey   string
+	rangeParts []index.CompositeKey
+}
+
+// name returns the description of the target used in diagnostics.
+func (t queryTarget) name() string {
+	if t.index == nil {
+		return "the table"
+	}
+	return fmt.Sprintf("index '%s'", t.index.Name)
+}
+
+// Queries returns the named queries declared in the schema, keyed by name.
+// Queries declaring keys instead of an index get the index resolved by the coverage check.
+func (s Schema) Queries() map[string]query.Query {
+	if len(s.raw.Queries) == 0 {
+		return nil
+	}
+	queries := make(map[string]query.Query, len(s.raw.Queries))
+	for name, q := range s.raw.Queries {
+		if target, err := s.resolveQuery(q); err == nil && target.index != nil {
+			q.Index = target.index.Name
+		}
+		queries[name] = q
+	}
+	return queries
+}
+
+// queryTargets returns the table followed by the secondary indexes in schema order.
+func (s Schema) queryTargets() []queryTarget {
+	targets := []queryTarget{{hashKey: s.HashKey(), rangeKey: s.RangeKey()}}
+	for i := range s.raw.SecondaryIndexes {
+		idx := &s.raw.SecondaryIndexes[i]
+		targets = append(targets, queryTarget{
+			index:      idx,
+			hashKey:    idx.GetEffectiveHashKey(s.HashKey()),
+			rangeKey:   idx.RangeKey,
+			rangeParts: idx.RangeKeyParts,
+		})
+	}
+	return targets
+}
+
+// unprojected returns the filtered attributes of q which the target doesn't return.
+func (s Schema) unprojected(t queryTarget, q query.Query) []string {
+	if t.index == nil || strings.EqualFold(t.index.ProjectionType, "ALL") {
+		return nil
+	}
+	projected := map[string]bool{s.HashKey(): true, s.RangeKey(): true, t.hashKey: true, t.rangeKey: true}
+	for _, attr := range t.index.NonKeyAttributes {
+		projected[attr] = true
+	}
+	var missing []string
+	for _, f := range q.Filters {
+		if !projected[f.Attribute] && !slices.Contains(missing, f.Attribute) {
+			missing = append(missing, f.Attribute)
+		}
+	}
+	return missing
+}
+
+// resolveQuery finds the target satisfying the named query with a key condition:
+// the declared index, or the first of the table and the indexes whose keys match the declared
+// hash and range keys and which project every filtered attribute. A query matching no target
+// would need a scan and is reported with the reason.
+func (s Schema) resolveQuery(q query.Query) (queryTarget, error) {
+	var reasons []string
+	for _, t := range s.queryTargets() {
+		switch {
+		case q.Index != "" && (t.index == nil || t.index.Name != q.Index):
+			continue
+		case q.Index == "" && q.HashKey == "" && t.index != nil:
+			continue
+		case q.HashKey != "" && t.hashKey != q.HashKey:
+			reasons = append(reasons, fmt.Sprintf("%s has hash key '%s'", t.name(), t.hashKey))
+			continue
+		case q.RangeKey != "" && t.rangeKey != q.RangeKey:
+			reasons = append(reasons, fmt.Sprintf("%s has range key '%s'", t.name(), t.rangeKey))
+			continue
+		case q.RangeOperator != "" && t.rangeKey == "":
+			reasons = append(reasons, fmt.Sprintf("%s has no range key", t.name()))
+			continue
+		}
+		if missing := s.unprojected(t, q); len(missing) > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s doesn't project %s", t.name(), strings.Join(missing, ", ")))
+			continue
+		}
+		return t, nil
+	}
+	if len(reasons) == 0 {
+		return queryTarget{}, fmt.Errorf("no table or index matches")
+	}
+	return queryTarget{}, fmt.Errorf("%s", strings.Join(reasons, "; "))
+}
+
+// diagnoseQueries reports named queries which can't be generated: undefined indexes or attributes,
+// queries no index satisfies without a scan, filters on key attributes and values of wrong types.
+func (s *Schema) diagnoseQueries() diag.List {
+	var (
+		list  diag.List
+		attrs = make(map[string]attribute.Attribute)
+		funcs = make(map[string]string)
+	)
+	for _, attr := range s.AllAttributes() {
+		attrs[attr.Name] = attr
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.raw.Queries)) {
+		q := s.raw.Queries[name]
+		path := diag.Pointer("queries", name)
+		if first, ok := funcs[query.GoName(name)]; ok {
+			list = append(list, diag.Errorf(diag.CodeQueryGoNameCollision, path, "query '%s' generates the same function %s as query '%s'", name, query.GoName(name), first).
+				Suggest("rename one of the queries"))
+		}
+		funcs[query.GoName(name)] = name
+
+		qList := q.Diagnose(path)
+		list = append(list, qList...)
+		if len(qList.Errors()) > 0 {
+			continue
+		}
+		if q.Index != "" && s.GetIndexByName(q.Index) == nil {
+			list = append(list, diag.Errorf(diag.CodeQueryIndexUndefined, path+"/index", "query '%s' uses undefined index '%s'", name, q.Index).
+				Suggest("add the index to secondary_indexes or fix the name"))
+			continue
+		}
+		for _, key := range []struct{ field, name string }{{"hash_key", q.HashKey}, {"range_key", q.RangeKey}} {
+			if key.name != "" && attrs[key.name].Name == "" && !s.isIndexKey(key.name) {
+				list = append(list, diag.Errorf(diag.CodeQueryAttributeUndefined, path+"/"+key.field, "query '%s' uses undeclared key '%s'", name, key.name).
+					Suggest("add '%s' to attributes or fix the name", key.name))
+			}
+		}
+
+		target, err := s.resolveQuery(q)
+		if err != nil {
+			list = append(list, diag.Errorf(diag.CodeQueryUnsatisfiable, path, "query '%s' requires a scan: %v", name, err).
+				Suggest("add an index with the keys of the query projecting its filtered attributes"))
+			continue
+		}
+		switch {
+		case len(target.rangeParts) > 0 && q.RangeOperator != "" && q.RangeOperator != "EQ":
+			list = append(list, diag.Errorf(diag.CodeQueryOperatorInvalid, path+"/range_operator", "composite range key '%s' supports only EQ, got '%s'", target.rangeKey, q.RangeOperator).
+				Suggest("use EQ or a simple range key"))
+		case q.RangeOperator == "BEGINS_WITH" && attrs[target.rangeKey].Type != "S":
+			list = append(list, diag.Errorf(diag.CodeQueryOperatorInvalid, path+"/range_operator", "BEGINS_WITH requires a string range key, '%s' is of type %s", target.rangeKey, attrs[target.rangeKey].Type).
+				Suggest("use another operator"))
+		}
+
+		for i, f := range q.Filters {
+			fpath := path + diag.Pointer("filters", i)
+			attr, ok := attrs[f.Attribute]
+			switch {
+			case !ok:
+				list = append(list, diag.Errorf(diag.CodeQueryAttributeUndefined, fpath+"/attribute", "query '%s' filters undeclared attribute '%s'", name, f.Attribute).
+					Suggest("add '%s' to common_attributes or fix the name", f.Attribute))
+				continue
+			case f.Attribute == target.hashKey || f.Attribute == target.rangeKey:
+				list = append(list, diag.Errorf(diag.CodeQueryAttributeUndefined, fpath+"/attribute", "query '%s' filters key attribute '%s'", name, f.Attribute).
+					Suggest("use the key condition instead of a filter"))
+				continue
+			}
+			for _, v := range f.Values() {
+				if !constantMatches(attr.Type, f.Operator, v) {
+					list = append(list, diag.Errorf(diag.CodeQueryValueInvalid, fpath+"/value", "value %v doesn't match type %s of '%s'", v, attr.Type, f.Attribute).
+						Suggest("use a value of type %s", attr.Type))
+				}
+			}
+		}
+	}
+	return list
+}
+
+// isIndexKey reports whether name is the hash or range key of a secondary index, e.g. a composite key.
+func (s Schema) isIndexKey(name string) bool {
+	for _, idx := range s.raw.SecondaryIndexes {
+		if idx.HashKey == name || idx.RangeKey == name {
+			return true
+		}
+	}
+	return false
+}
+
+// constantMatches reports whether a constant filter value decoded from JSON fits an attribute
+// of the DynamoDB type: the type itself, or an element for CONTAINS and NOT_CONTAINS of sets.
+func constantMatches(dynamoType, op string, v any) bool {
+	if op == "CONTAINS" || op == "NOT_CONTAINS" {
+		switch dynamoType {
+		case "SS":
+			dynamoType = "S"
+		case "NS":
+			dynamoType = "N"
+		}
+	}
+	switch v.(type) {
+	case string:
+		return dynamoType == "S"
+	case float64:
+		return dynamoType == "N"
+	case bool:
+		return dynamoType == "BOOL"
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// Queue declares the job queue of the generated Enqueue, Claim and Complete.
+// Jobs are items of the table: the range key of the index is the visibility attribute
+// holding the Unix milliseconds a job can be claimed from, its hash key names the queue.
+//
+// Example:
+//
+//	"queue": {
+//	  "index": "by_visibility"
+//	}
+type Queue struct {
+	// Index is the secondary index Claim queries visible jobs on.
+	Index string `json:"index"`
+}
+
+// QueueIndex returns the name of the index of the job queue, empty if none is declared.
+func (s Schema) QueueIndex() string {
+	if s.raw.Queue == nil {
+		return ""
+	}
+	return s.raw.Queue.Index
+}
+
+// diagnoseQueue reports job queue indexes Claim can't query:
+// their keys must be plain attributes, the range key a number.
+func (s *Schema) diagnoseQueue() diag.List {
+	if s.raw.Queue == nil {
+		return nil
+	}
+	const path = "/queue/index"
+	for _, idx := range s.raw.SecondaryIndexes {
+		if idx.Name != s.raw.Queue.Index {
+			continue
+		}
+		switch {
+		case strings.Contains(idx.HashKey, "#") || strings.Contains(idx.RangeKey, "#"):
+			return diag.List{diag.Errorf(diag.CodeQueueIndexInvalid, path, "queue index '%s' can't have composite keys", idx.Name).
+				Suggest("use an index keyed by the queue name and the visibility attribute")}
+		case idx.RangeKey == "":
+			return diag.List{diag.Errorf(diag.CodeQueueIndexInvalid, path, "queue index '%s' has no range key", idx.Name).
+				Suggest("set the range key of '%s' to a number attribute holding the visibility time", idx.Name)}
+		case s.attributeType(idx.RangeKey) != "N":
+			return diag.List{diag.Errorf(diag.CodeQueueIndexInvalid, path, "visibility attribute '%s' of queue index '%s' must be N", idx.RangeKey, idx.Name).
+				Suggest("change the type of '%s' to N", idx.RangeKey)}
+		}
+		return nil
+	}
+	return diag.List{diag.Errorf(diag.CodeQueueIndexInvalid, path, "queue index '%s' not found in secondary_indexes", s.raw.Queue.Index).
+		Suggest("declare the index or fix its name")}
+}
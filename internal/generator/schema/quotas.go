@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// DefaultQuotaKeyPrefix is the prefix of the hash key of quota window items.
+const DefaultQuotaKeyPrefix = "quota#"
+
+// QuotaAttributes are the attributes of quota window items written by the generated ConsumeQuota.
+var QuotaAttributes = []string{"quota_count"}
+
+// Quotas declares the window items of the generated ConsumeQuota, stored in the table itself.
+// The hash key of a window item is the key prefix followed by the subject and the window start,
+// its range key (if the table has one) is the sort key. Window items expire by the TTL attribute.
+//
+// Example:
+//
+//	"quotas": {
+//	  "key_prefix": "quota#"
+//	}
+type Quotas struct {
+	// KeyPrefix is prepended to subjects, "quota#" if not set.
+	KeyPrefix string `json:"key_prefix,omitempty"`
+
+	// SortKey is the range key value of window items: "quota" for string range keys, "0" for numbers if not set.
+	SortKey string `json:"sort_key,omitempty"`
+}
+
+// HasQuotas returns true if the schema declares quota window items.
+func (s Schema) HasQuotas() bool {
+	return s.raw.Quotas != nil
+}
+
+// Quotas returns the quota window items declared in the schema with defaults applied.
+func (s Schema) Quotas() Quotas {
+	var quotas Quotas
+	if s.raw.Quotas != nil {
+		quotas = *s.raw.Quotas
+	}
+	if quotas.KeyPrefix == "" {
+		quotas.KeyPrefix = DefaultQuotaKeyPrefix
+	}
+	if quotas.SortKey == "" {
+		quotas.SortKey = s.helperSortKey("quota")
+	}
+	return quotas
+}
+
+// diagnoseQuotas reports primary keys window items can't be stored under, declared attributes
+// colliding with the attributes of window items and tables keeping window items forever.
+func (s *Schema) diagnoseQuotas() diag.List {
+	if s.raw.Quotas == nil {
+		return nil
+	}
+	list := s.diagnoseHelperItems("quota", s.Quotas().SortKey, QuotaAttributes)
+	if s.TTLAttribute() == nil {
+		list = append(list, diag.Warningf(diag.CodeQuotaTTLMissing, "/quotas", "quota window items are never deleted without a TTL attribute").
+			Suggest("declare a number attribute with \"ttl\": true"))
+	}
+	return list
+}
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"regexp"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// regionPattern matches AWS region names, e.g. "eu-west-1" or "us-gov-east-1".
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+
+// Replica declares a Global Tables replica of the table.
+//
+// Example:
+//
+//	"replicas": [
+//	  {"region": "eu-west-1"},
+//	  {"region": "ap-southeast-2"}
+//	]
+type Replica struct {
+	// Region is the AWS region of the replica.
+	Region string `json:"region"`
+}
+
+// Replicas returns the replicas declared in the schema.
+func (s Schema) Replicas() []Replica {
+	return s.raw.Replicas
+}
+
+// ReplicaRegions returns the regions of the replicas declared in the schema.
+func (s Schema) ReplicaRegions() []string {
+	regions := make([]string, 0, len(s.raw.Replicas))
+	for _, r := range s.raw.Replicas {
+		regions = append(regions, r.Region)
+	}
+	return regions
+}
+
+// diagnoseReplicas reports invalid and duplicate replica regions.
+func (s *Schema) diagnoseReplicas() diag.List {
+	var (
+		list diag.List
+		seen = make(map[string]bool, len(s.raw.Replicas))
+	)
+	for i, r := range s.raw.Replicas {
+		path := diag.Pointer("replicas", i) + "/region"
+		switch {
+		case !regionPattern.MatchString(r.Region):
+			list = append(list, diag.Errorf(diag.CodeReplicaRegionInvalid, path, "invalid replica region '%s'", r.Region).
+				Suggest("use an AWS region name, e.g. 'eu-west-1'"))
+		case seen[r.Region]:
+			list = append(list, diag.Errorf(diag.CodeReplicaDuplicate, path, "replica region '%s' is declared more than once", r.Region).
+				Suggest("remove the duplicate replica"))
+		}
+		seen[r.Region] = true
+	}
+	return list
+}
@@ -9,8 +9,14 @@
 package schema
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
 	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/query"
 	"github.com/Mad-Pixels/go-dyno/internal/logger"
 	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
 	"github.com/Mad-Pixels/go-dyno/internal/utils/fs"
@@ -18,7 +24,8 @@ import (
 
 // Schema wraps the raw schema definition.
 type Schema struct {
-	raw schema
+	raw  schema
+	hash string
 }
 
 // NewSchema loads and parses a schema definition from the given file path.
@@ -28,14 +35,54 @@ func NewSchema(path string) (*Schema, error) {
 	if err := fs.ReadAndParseJSON(path, &spec.raw); err != nil {
 		return nil, err
 	}
+	if err := spec.computeHash(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// FromJSON parses a schema definition from raw JSON, e.g. a schema built in memory by the init wizard.
+func FromJSON(data []byte) (*Schema, error) {
+	var spec Schema
+
+	if err := json.Unmarshal(data, &spec.raw); err != nil {
+		return nil, logger.NewFailure("failed to parse JSON", err)
+	}
+	if err := spec.computeHash(); err != nil {
+		return nil, err
+	}
 	return &spec, nil
 }
 
+func (s *Schema) computeHash() error {
+	canonical, err := json.Marshal(s.raw)
+	if err != nil {
+		return logger.NewFailure("failed to encode schema", err)
+	}
+	sum := sha256.Sum256(canonical)
+	s.hash = hex.EncodeToString(sum[:])
+	return nil
+}
+
 // TableName returns the logical name of the DynamoDB table defined in the schema.
 func (s Schema) TableName() string {
 	return s.raw.TableName
 }
 
+// Hash returns the SHA-256 hex digest of the schema content.
+// Computed from the parsed definition, so formatting and key order in the file don't affect it.
+func (s Schema) Hash() string {
+	return s.hash
+}
+
+// Version returns the schema version (1 if not set).
+func (s Schema) Version() int {
+	if s.raw.SchemaVersion == 0 {
+		return 1
+	}
+	return s.raw.SchemaVersion
+}
+
 // HashKey returns the primary partition key of the table.
 func (s Schema) HashKey() string {
 	return s.raw.HashKey
@@ -113,16 +160,23 @@ func (s Schema) GetIndexByName(name string) *index.Index {
 
 // ValidateIndexNames checks for duplicate index names.
 func (s Schema) ValidateIndexNames() error {
-	seen := make(map[string]bool)
+	return s.diagnoseIndexNames().Err()
+}
 
-	for _, idx := range s.SecondaryIndexes() {
-		if seen[idx.Name] {
-			return logger.NewFailure("duplicate index name", nil).
-				With("name", idx.Name)
+func (s Schema) diagnoseIndexNames() diag.List {
+	var (
+		list diag.List
+		seen = make(map[string]int)
+	)
+	for i, idx := range s.SecondaryIndexes() {
+		if first, ok := seen[idx.Name]; ok {
+			list = append(list, diag.Errorf(diag.CodeIndexNameDuplicate, diag.Pointer("secondary_indexes", i, "name"), "duplicate index name '%s'", idx.Name).
+				Suggest("rename the index, '%s' is already used by %s", idx.Name, diag.Pointer("secondary_indexes", first)))
+			continue
 		}
-		seen[idx.Name] = true
+		seen[idx.Name] = i
 	}
-	return nil
+	return list
 }
 
 // GetOptimalIndexForQuery returns the most efficient index for a query with the given keys.
@@ -157,6 +211,10 @@ type schema struct {
 	// Go package and filename can be overridden by generator config.
 	TableName string `json:"table_name"`
 
+	// SchemaVersion is an optional monotonic version of the schema.
+	// It should be increased on every change of keys, attributes or indexes.
+	SchemaVersion int `json:"schema_version,omitempty"`
+
 	// HashKey is the primary partition key of the DynamoDB table.
 	// This field is required and must match one of the attribute names.
 	HashKey string `json:"hash_key"`
@@ -177,6 +235,43 @@ type schema struct {
 	// SecondaryIndexes defines Global or Local Secondary Indexes (GSI/LSI)
 	// used for advanced querying in DynamoDB. Each index has its own keys and projection.
 	SecondaryIndexes []index.Index `json:"secondary_indexes"`
+
+	// CompositeKeys declares the separator and escaping of composite key values. Optional.
+	CompositeKeys index.CompositeFormat `json:"composite_keys,omitzero"`
+
+	// Locks declares the lock items of the generated Lock, Renew and Release. Optional.
+	Locks *Locks `json:"locks,omitempty"`
+
+	// Quotas declares the window items of the generated ConsumeQuota. Optional.
+	Quotas *Quotas `json:"quotas,omitempty"`
+
+	// Queue declares the job queue of the generated Enqueue, Claim and Complete. Optional.
+	Queue *Queue `json:"queue,omitempty"`
+
+	// SessionStore flags the table as a session store. Optional.
+	SessionStore *SessionStore `json:"session_store,omitempty"`
+
+	// EventLog flags the table as the event log of event-sourced aggregates. Optional.
+	EventLog *EventLog `json:"event_log,omitempty"`
+
+	// Pruning flags the table for the generated PruneOlderThan. Optional.
+	Pruning *Pruning `json:"pruning,omitempty"`
+
+	// Encryption declares the KMS key and encryption context of sensitive attributes. Optional.
+	Encryption Encryption `json:"encryption,omitzero"`
+
+	// TableClass is the storage class of the table: "STANDARD" or "STANDARD_INFREQUENT_ACCESS". Optional.
+	TableClass string `json:"table_class,omitempty"`
+
+	// WarmThroughput is the pre-warmed capacity of the table. Optional.
+	WarmThroughput *index.WarmThroughput `json:"warm_throughput,omitempty"`
+
+	// Replicas declares the regions of Global Tables replicas. Optional.
+	Replicas []Replica `json:"replicas,omitempty"`
+
+	// Queries declares named queries, the approved access patterns of the table,
+	// generated as typed functions. Optional.
+	Queries map[string]query.Query `json:"queries,omitempty"`
 }
 
 func (s Schema) filterIndexesByType(predicate func(index.Index) bool) []index.Index {
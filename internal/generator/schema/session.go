@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// SessionStore flags the table as a session store, generating CreateSession, TouchSession and RevokeAllForUser.
+// Sessions are keyed by a random string hash key and expire by the TTL attribute;
+// the hash key of the user index is the user owning the session.
+//
+// Example:
+//
+//	"session_store": {
+//	  "user_index": "by_user"
+//	}
+type SessionStore struct {
+	// UserIndex is the secondary index RevokeAllForUser queries the sessions of a user on.
+	UserIndex string `json:"user_index"`
+}
+
+// SessionUserIndex returns the name of the user index of the session store, empty if the table isn't one.
+func (s Schema) SessionUserIndex() string {
+	if s.raw.SessionStore == nil {
+		return ""
+	}
+	return s.raw.SessionStore.UserIndex
+}
+
+// diagnoseSessionStore reports session stores the generated helpers can't work with:
+// sessions need a plain string hash key without range key, a TTL attribute and a user index.
+func (s *Schema) diagnoseSessionStore() diag.List {
+	if s.raw.SessionStore == nil {
+		return nil
+	}
+	var list diag.List
+	for _, attr := range s.AllAttributes() {
+		if attr.Name == s.HashKey() && attr.GoType() != "string" {
+			list = append(list, diag.Errorf(diag.CodeSessionStoreInvalid, "/hash_key", "session ID '%s' must be a plain string, it's %s", attr.Name, attr.GoType()).
+				Suggest("use an S hash key without enum or go_type"))
+		}
+	}
+	if s.RangeKey() != "" {
+		list = append(list, diag.Errorf(diag.CodeSessionStoreInvalid, "/range_key", "session stores are keyed by the session ID only").
+			Suggest("remove range_key '%s'", s.RangeKey()))
+	}
+	if s.TTLAttribute() == nil {
+		list = append(list, diag.Errorf(diag.CodeSessionStoreInvalid, "/session_store", "session stores need a TTL attribute").
+			Suggest("declare a number attribute with \"ttl\": true"))
+	}
+
+	const path = "/session_store/user_index"
+	for _, idx := range s.raw.SecondaryIndexes {
+		if idx.Name != s.raw.SessionStore.UserIndex {
+			continue
+		}
+		if strings.Contains(idx.HashKey, "#") {
+			list = append(list, diag.Errorf(diag.CodeSessionStoreInvalid, path, "user index '%s' can't have a composite hash key", idx.Name).
+				Suggest("use an index keyed by the user attribute"))
+		}
+		return list
+	}
+	return append(list, diag.Errorf(diag.CodeSessionStoreInvalid, path, "user index '%s' not found in secondary_indexes", s.raw.SessionStore.UserIndex).
+		Suggest("declare the index or fix its name"))
+}
@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// TTLAttribute returns the attribute holding the item expiry, nil if none is declared.
+func (s Schema) TTLAttribute() *attribute.Attribute {
+	for _, attr := range s.AllAttributes() {
+		if attr.TTL {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// diagnoseTTL reports TTL attributes DynamoDB can't use:
+// a table has a single TTL attribute, which is not a key or otherwise managed.
+func (s *Schema) diagnoseTTL() diag.List {
+	var (
+		list     diag.List
+		declared string
+		keys     = s.keyAttributes()
+	)
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if !attr.TTL {
+				continue
+			}
+			path := diag.Pointer(section, i) + "/ttl"
+			switch {
+			case declared != "":
+				list = append(list, diag.Errorf(diag.CodeAttributeTTLConflict, path, "attribute '%s' is marked as TTL, but '%s' already is", attr.Name, declared).
+					Suggest("mark a single attribute as TTL, DynamoDB supports one per table"))
+			case keys[attr.Name]:
+				list = append(list, diag.Errorf(diag.CodeAttributeTTLConflict, path, "key attribute '%s' can't be the TTL", attr.Name).
+					Suggest("use a non-key attribute"))
+			case attr.IsComputed() || attr.Version || attr.IsAuto() || attr.IsSensitive():
+				list = append(list, diag.Errorf(diag.CodeAttributeTTLConflict, path, "TTL attribute '%s' can't be computed, sensitive, the version or an auto timestamp", attr.Name).
+					Suggest("use a dedicated attribute holding the expiry"))
+			}
+			if declared == "" {
+				declared = attr.Name
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
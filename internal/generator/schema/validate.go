@@ -1,73 +1,128 @@
 package schema
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
 	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
 	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
 )
 
+// lsiLimit is the maximum number of LSIs per table.
+const lsiLimit = 10
+
 // Validate performs comprehensive schema validation.
 //
 // This includes:
+//   - Validation of the schema version
 //   - Validation of all attributes
+//   - Detection of attributes and indexes which map to the same Go identifier
 //   - Verification that hash/range keys are defined
 //   - Validation of index names and definitions
 //   - Enforcement of LSI limits
 //   - Parsing of composite key definitions
 //
-// Returns an error if any invalid configuration is found.
+// Returns the first error diagnostic (see Diagnose) if any invalid configuration is found.
 func (s *Schema) Validate() error {
-	for _, attr := range s.AllAttributes() {
-		if err := attr.Validate(); err != nil {
-			return err
-		}
+	return s.Diagnose().Err()
+}
+
+// Diagnose checks the whole schema and returns every problem found,
+// each with a code, a JSON pointer into the schema and a fix suggestion.
+// Secondary indexes are normalized (default type, LSI hash key, composite key parts) on the way.
+func (s *Schema) Diagnose() diag.List {
+	var list diag.List
+	if s.raw.TableName == "" {
+		list = append(list, diag.Errorf(diag.CodeTableNameEmpty, "/table_name", "table_name cannot be empty").
+			Suggest("set table_name to the DynamoDB table name"))
+	}
+	if s.raw.SchemaVersion < 0 {
+		list = append(list, diag.Errorf(diag.CodeSchemaVersionNegative, "/schema_version", "schema_version must not be negative, got %d", s.raw.SchemaVersion).
+			Suggest("remove schema_version or set it to 1"))
+	}
+	for i, attr := range s.raw.Attributes {
+		list = append(list, attr.Diagnose(diag.Pointer("attributes", i))...)
+	}
+	for i, attr := range s.raw.CommonAttributes {
+		list = append(list, attr.Diagnose(diag.Pointer("common_attributes", i))...)
 	}
+	list = append(list, s.diagnoseGoNames()...)
 
 	if !isAttributeDefined(s.HashKey(), s.AllAttributes()) {
-		return logger.NewFailure("hash_key is not defined in attributes", nil).
-			With("key", s.HashKey())
+		list = append(list, diag.Errorf(diag.CodeHashKeyUndefined, "/hash_key", "hash key '%s' not declared in attributes", s.HashKey()).
+			Suggest("add '%s' to attributes or fix the key name", s.HashKey()))
 	}
 	if rk := s.RangeKey(); rk != "" && !isAttributeDefined(rk, s.AllAttributes()) {
-		return logger.NewFailure("range_key is not defined in attributes", nil).
-			With("key", rk)
-	}
-	if err := s.ValidateIndexNames(); err != nil {
-		return err
+		list = append(list, diag.Errorf(diag.CodeRangeKeyUndefined, "/range_key", "range key '%s' not declared in attributes", rk).
+			Suggest("add '%s' to attributes or fix the key name", rk))
 	}
+	list = append(list, s.diagnoseIndexNames()...)
 
 	lsiCount := 0
 	for i := range s.raw.SecondaryIndexes {
-		idx := &s.raw.SecondaryIndexes[i]
-
+		var (
+			idx  = &s.raw.SecondaryIndexes[i]
+			path = diag.Pointer("secondary_indexes", i)
+		)
 		if idx.Type == "" {
 			idx.Type = index.GSI
 		}
 		if idx.IsLSI() {
 			idx.HashKey = s.HashKey()
 		}
-		if err := idx.Validate(s.RangeKey()); err != nil {
-			return err
+
+		idxList := idx.Diagnose(path, s.RangeKey())
+		list = append(list, idxList...)
+		if len(idxList.Errors()) > 0 {
+			continue
 		}
 
 		if idx.IsLSI() {
 			lsiCount++
-			if lsiCount > 10 {
-				return logger.NewFailure("too many LSI indexes", nil).
-					With("count", lsiCount).
-					With("limit", 10)
+			if lsiCount == lsiLimit+1 {
+				list = append(list, diag.Errorf(diag.CodeIndexLSILimit, path, "too many LSI indexes, DynamoDB allows %d per table", lsiLimit).
+					Suggest("convert some LSIs to GSIs"))
 			}
 		}
 
-		if err := validateIndexAttributes(idx, s.AllAttributes()); err != nil {
-			return err
-		}
-		if err := parseIndexCompositeKeys(idx, s.AllAttributes()); err != nil {
-			return err
+		attrList := diagnoseIndexAttributes(path, idx, s.AllAttributes())
+		list = append(list, attrList...)
+		if len(attrList) == 0 {
+			list = append(list, parseIndexCompositeKeys(path, idx, s.AllAttributes())...)
 		}
 	}
-	return nil
+	list = append(list, s.diagnoseUnusedAttributes()...)
+	list = append(list, s.diagnoseAnonymizedKeys()...)
+	list = append(list, s.diagnoseEncryption()...)
+	list = append(list, s.diagnoseProvisioning()...)
+	list = append(list, s.diagnoseReplicas()...)
+	list = append(list, s.diagnoseComputed()...)
+	list = append(list, s.diagnoseGuards()...)
+	list = append(list, s.diagnosePayloads()...)
+	list = append(list, s.diagnoseVersion()...)
+	list = append(list, s.diagnoseAudit()...)
+	list = append(list, s.diagnoseChecksum()...)
+	list = append(list, s.diagnoseTTL()...)
+	list = append(list, s.diagnoseFieldVersions()...)
+	list = append(list, s.diagnoseListStructs()...)
+	list = append(list, s.diagnoseOptional()...)
+	list = append(list, s.diagnoseGoTypes()...)
+	list = append(list, s.diagnoseEnums()...)
+	list = append(list, s.diagnoseNormalized()...)
+	list = append(list, s.diagnoseDefaults()...)
+	list = append(list, s.diagnoseCompositeKeys()...)
+	list = append(list, s.diagnoseLocks()...)
+	list = append(list, s.diagnoseQueue()...)
+	list = append(list, s.diagnoseQuotas()...)
+	list = append(list, s.diagnoseSessionStore()...)
+	list = append(list, s.diagnoseEventLog()...)
+	list = append(list, s.diagnosePruning()...)
+	list = append(list, s.diagnoseQueries()...)
+	return list
 }
 
 func isAttributeDefined(name string, attrs []attribute.Attribute) bool {
@@ -79,77 +134,167 @@ func isAttributeDefined(name string, attrs []attribute.Attribute) bool {
 	return false
 }
 
-func validateIndexAttributes(idx *index.Index, attrs []attribute.Attribute) error {
-	if idx.IsGSI() && idx.HashKey != "" {
-		if !isAttributeDefined(idx.HashKey, attrs) && !strings.Contains(idx.HashKey, "#") {
-			return logger.NewFailure("GSI hash_key is not defined", nil).
-				With("key", idx.HashKey)
+// diagnoseGoNames reports attributes (or indexes) which normalize to the same Go identifier,
+// e.g. "user-id" and "user_id" both become UserId.
+func (s *Schema) diagnoseGoNames() diag.List {
+	var (
+		list       diag.List
+		attrNames  = make(map[string][]string)
+		indexNames = make(map[string][]string)
+	)
+	for _, a := range s.AllAttributes() {
+		attrNames[a.GoName()] = append(attrNames[a.GoName()], a.Name)
+		if a.CustomGoName != "" {
+			logger.Log.Debug().
+				Str("name", a.Name).
+				Str("go_name", a.CustomGoName).
+				Msg("Attribute Go name overridden")
 		}
 	}
-	if idx.RangeKey != "" {
-		if !isAttributeDefined(idx.RangeKey, attrs) && !strings.Contains(idx.RangeKey, "#") {
-			return logger.NewFailure("range_key is not defined", nil).
-				With("key", idx.RangeKey)
+	for _, idx := range s.SecondaryIndexes() {
+		goName := conv.ToUpperCamelCase(conv.ToSafeName(idx.Name))
+		indexNames[goName] = append(indexNames[goName], idx.Name)
+	}
+
+	if collisions := collectCollisions(attrNames); len(collisions) > 0 {
+		list = append(list, diag.Errorf(diag.CodeAttributeGoNameCollision, "/attributes", "attributes collide on Go identifier %s", strings.Join(collisions, "; ")).
+			Suggest("set a unique go_name for colliding attributes"))
+	}
+	if collisions := collectCollisions(indexNames); len(collisions) > 0 {
+		list = append(list, diag.Errorf(diag.CodeIndexGoNameCollision, "/secondary_indexes", "indexes collide on Go identifier %s", strings.Join(collisions, "; ")).
+			Suggest("rename colliding indexes"))
+	}
+	return list
+}
+
+func collectCollisions(names map[string][]string) []string {
+	var collisions []string
+	for goName, sources := range names {
+		if len(sources) > 1 {
+			collisions = append(collisions, fmt.Sprintf("%s: %s", goName, strings.Join(sources, ", ")))
 		}
 	}
-	for _, nk := range idx.NonKeyAttributes {
-		if !isAttributeDefined(nk, attrs) {
-			return logger.NewFailure("non_key_attribute is not defined", nil).
-				With("key", nk)
+	sort.Strings(collisions)
+	return collisions
+}
+
+// diagnoseUnusedAttributes warns about key attributes which no key or index uses:
+// they are data fields and belong to common_attributes.
+func (s *Schema) diagnoseUnusedAttributes() diag.List {
+	var (
+		list diag.List
+		used = s.keyAttributes()
+	)
+	for i, attr := range s.raw.Attributes {
+		if attr.Name != "" && !used[attr.Name] {
+			list = append(list, diag.Warningf(diag.CodeAttributeUnused, diag.Pointer("attributes", i), "attribute '%s' is not used by any key or index", attr.Name).
+				Suggest("move '%s' to common_attributes", attr.Name))
 		}
 	}
-	return nil
+	return list
 }
 
-func parseIndexCompositeKeys(idx *index.Index, attrs []attribute.Attribute) error {
-	if idx.IsGSI() && strings.Contains(idx.HashKey, "#") {
-		parts := strings.Split(idx.HashKey, "#")
-		for _, p := range parts {
-			if !isAttributeDefined(p, attrs) {
-				return logger.NewFailure("invalid composite part in hash_key", nil).
-					With("key", p)
+// diagnoseAnonymizedKeys reports anonymization strategies breaking keys of cloned items:
+// removed keys make items unwritable, fakes may collide and merge items.
+func (s *Schema) diagnoseAnonymizedKeys() diag.List {
+	var (
+		list diag.List
+		keys = s.keyAttributes()
+	)
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if !keys[attr.Name] {
+				continue
+			}
+			path := diag.Pointer(section, i) + "/anonymize"
+			switch attr.Anonymize {
+			case attribute.AnonymizeNull:
+				list = append(list, diag.Errorf(diag.CodeAttributeAnonymizeKey, path, "key attribute '%s' can't be anonymized with '%s'", attr.Name, attr.Anonymize).
+					Suggest("use '%s' to keep keys unique", attribute.AnonymizeHash))
+			case attribute.AnonymizeFaker:
+				list = append(list, diag.Warningf(diag.CodeAttributeAnonymizeKey, path, "fake values of key attribute '%s' may collide and merge items", attr.Name).
+					Suggest("use '%s' to keep keys unique", attribute.AnonymizeHash))
 			}
-		}
-		idx.HashKeyParts = make([]index.CompositeKey, len(parts))
-		for j, p := range parts {
-			idx.HashKeyParts[j] = index.CompositeKey{IsConstant: !isAttributeDefined(p, attrs), Value: p}
 		}
 	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
 
-	if idx.IsLSI() && strings.Contains(idx.HashKey, "#") {
-		parts := strings.Split(idx.HashKey, "#")
-		for _, p := range parts {
-			if !isAttributeDefined(p, attrs) {
-				return logger.NewFailure("invalid composite part in LSI hash_key", nil).
-					With("key", p)
+// keyAttributes returns names of attributes used by the primary key or an index key,
+// composite key parts included.
+func (s *Schema) keyAttributes() map[string]bool {
+	keys := map[string]bool{s.HashKey(): true, s.RangeKey(): true}
+	for _, idx := range s.SecondaryIndexes() {
+		for _, key := range []string{idx.HashKey, idx.RangeKey} {
+			for _, part := range strings.Split(key, "#") {
+				keys[part] = true
 			}
 		}
-		idx.HashKeyParts = make([]index.CompositeKey, len(parts))
-		for j, p := range parts {
-			idx.HashKeyParts[j] = index.CompositeKey{IsConstant: !isAttributeDefined(p, attrs), Value: p}
+	}
+	return keys
+}
+
+func diagnoseIndexAttributes(path string, idx *index.Index, attrs []attribute.Attribute) diag.List {
+	var (
+		list      diag.List
+		indexType = idx.Type.String()
+	)
+	if idx.IsGSI() && idx.HashKey != "" {
+		if !isAttributeDefined(idx.HashKey, attrs) && !strings.Contains(idx.HashKey, "#") {
+			list = append(list, diag.Errorf(diag.CodeIndexKeyUndefined, path+"/hash_key", "GSI hash key '%s' not declared in attributes", idx.HashKey).
+				Suggest("add '%s' to attributes or fix the key name", idx.HashKey))
+		}
+	}
+	if idx.RangeKey != "" {
+		if !isAttributeDefined(idx.RangeKey, attrs) && !strings.Contains(idx.RangeKey, "#") {
+			list = append(list, diag.Errorf(diag.CodeIndexKeyUndefined, path+"/range_key", "%s range key '%s' not declared in attributes", indexType, idx.RangeKey).
+				Suggest("add '%s' to attributes or fix the key name", idx.RangeKey))
+		}
+	}
+	for i, nk := range idx.NonKeyAttributes {
+		if !isAttributeDefined(nk, attrs) {
+			list = append(list, diag.Errorf(diag.CodeIndexNonKeyUndefined, path+diag.Pointer("non_key_attributes", i), "non_key_attribute '%s' of index '%s' not declared in attributes", nk, idx.Name).
+				Suggest("declare '%s' in attributes or common_attributes", nk))
 		}
 	}
+	return list
+}
 
+func parseIndexCompositeKeys(path string, idx *index.Index, attrs []attribute.Attribute) diag.List {
+	var list diag.List
+	if strings.Contains(idx.HashKey, "#") {
+		parts, partList := parseCompositeKey(path+"/hash_key", idx.Type.String()+" hash key", idx.HashKey, attrs)
+		idx.HashKeyParts = parts
+		list = append(list, partList...)
+	}
 	if strings.Contains(idx.RangeKey, "#") {
-		parts := strings.Split(idx.RangeKey, "#")
-		for _, p := range parts {
-			if !isAttributeDefined(p, attrs) {
-				indexType := "range_key"
-
-				if idx.IsLSI() {
-					indexType = "LSI range_key"
-				} else if idx.IsGSI() {
-					indexType = "GSI range_key"
-				}
-
-				return logger.NewFailure("invalid composite part in "+indexType, nil).
-					With("key", p)
-			}
-		}
-		idx.RangeKeyParts = make([]index.CompositeKey, len(parts))
-		for j, p := range parts {
-			idx.RangeKeyParts[j] = index.CompositeKey{IsConstant: !isAttributeDefined(p, attrs), Value: p}
+		parts, partList := parseCompositeKey(path+"/range_key", idx.Type.String()+" range key", idx.RangeKey, attrs)
+		idx.RangeKeyParts = parts
+		list = append(list, partList...)
+	}
+	return list
+}
+
+func parseCompositeKey(path, scope, key string, attrs []attribute.Attribute) ([]index.CompositeKey, diag.List) {
+	var (
+		list  diag.List
+		parts = strings.Split(key, "#")
+	)
+	for _, p := range parts {
+		if !isAttributeDefined(p, attrs) {
+			list = append(list, diag.Errorf(diag.CodeIndexCompositePartUndefined, path, "invalid composite part '%s' in %s '%s'", p, scope, key).
+				Suggest("declare '%s' in attributes, every part of a composite key must be an attribute", p))
 		}
 	}
-	return nil
+	if len(list) > 0 {
+		return nil, list
+	}
+
+	composite := make([]index.CompositeKey, len(parts))
+	for j, p := range parts {
+		composite[j] = index.CompositeKey{IsConstant: !isAttributeDefined(p, attrs), Value: p}
+	}
+	return composite, nil
 }
@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/diag"
+)
+
+// VersionAttribute returns the attribute used for optimistic locking, nil if none is declared.
+func (s Schema) VersionAttribute() *attribute.Attribute {
+	for _, attr := range s.AllAttributes() {
+		if attr.Version {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// diagnoseVersion reports version attributes optimistic locking can't use:
+// a table has a single version attribute, which is not a key, computed or guarded.
+func (s *Schema) diagnoseVersion() diag.List {
+	var (
+		list     diag.List
+		declared string
+		keys     = s.keyAttributes()
+	)
+	check := func(section string, attrs []attribute.Attribute) {
+		for i, attr := range attrs {
+			if !attr.Version {
+				continue
+			}
+			path := diag.Pointer(section, i) + "/version"
+			switch {
+			case declared != "":
+				list = append(list, diag.Errorf(diag.CodeAttributeVersionConflict, path, "attribute '%s' is marked as version, but '%s' already is", attr.Name, declared).
+					Suggest("mark a single attribute as version"))
+			case keys[attr.Name]:
+				list = append(list, diag.Errorf(diag.CodeAttributeVersionConflict, path, "key attribute '%s' can't be the version", attr.Name).
+					Suggest("use a non-key attribute, keys of an item never change"))
+			case attr.IsComputed() || attr.Guard != "":
+				list = append(list, diag.Errorf(diag.CodeAttributeVersionConflict, path, "version attribute '%s' can't be computed or guarded", attr.Name).
+					Suggest("remove computed and guard from '%s', its value is managed by optimistic locking", attr.Name))
+			}
+			if declared == "" {
+				declared = attr.Name
+			}
+		}
+	}
+	check("attributes", s.raw.Attributes)
+	check("common_attributes", s.raw.CommonAttributes)
+	return list
+}
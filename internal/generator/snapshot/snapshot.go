@@ -0,0 +1,229 @@
+// Package snapshot provides golden-file testing for generated code.
+//
+// Every valid schema from a fixtures directory is rendered with a fixed set of
+// builder variants and compared byte-for-byte with the golden files:
+//
+//	<golden>/<schema>/<variant>.go.golden
+//	<golden>/<schema>/<variant>_test.go.golden  (variants which generate tests)
+//	<golden>/<schema>/<variant>_doc.go.golden   (variants which generate doc.go)
+//
+// Any template refactoring that changes the generated output is reported as a mismatch
+// until goldens are explicitly refreshed with Update.
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/mode"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/fs"
+)
+
+// Status describes the comparison result of a single golden file.
+type Status string
+
+const (
+	// StatusMatch means rendered output equals the golden file.
+	StatusMatch Status = "match"
+
+	// StatusMismatch means rendered output differs from the golden file.
+	StatusMismatch Status = "mismatch"
+
+	// StatusMissing means the golden file doesn't exist.
+	StatusMissing Status = "missing"
+
+	// StatusUpdated means the golden file was (re)written.
+	StatusUpdated Status = "updated"
+)
+
+// Variant describes one builder configuration rendered for every schema.
+type Variant struct {
+	// Name is used as golden file name.
+	Name string
+
+	// Apply configures the render builder.
+	Apply func(rb *generator.RenderBuilder)
+}
+
+// Variants is the list of builder configurations covered by goldens.
+// Extend it when a new generation option is added.
+var Variants = []Variant{
+	{
+		Name:  "default",
+		Apply: func(rb *generator.RenderBuilder) {},
+	},
+	{
+		Name: "min",
+		Apply: func(rb *generator.RenderBuilder) {
+			rb.WithMode(mode.MIN)
+		},
+	},
+	{
+		Name: "extended",
+		Apply: func(rb *generator.RenderBuilder) {
+			rb.WithMode(mode.ALL).
+				WithStreamEvents(true).
+				WithLogging(true).
+				WithChaos(true).
+				WithCircuitBreaker(true).
+				WithPropertyTests(true).
+				WithFuzzTests(true).
+				WithDoc(true)
+		},
+	},
+	{
+		Name: "no-scan",
+		Apply: func(rb *generator.RenderBuilder) {
+			rb.WithMode(mode.ALL).
+				WithLogging(true).
+				WithChaos(true).
+				WithCircuitBreaker(true).
+				WithDoc(true).
+				WithNoScan(true)
+		},
+	},
+}
+
+// Result is the comparison outcome for a single golden file.
+type Result struct {
+	// Path to the golden file.
+	Path string `json:"path"`
+
+	// Status of the comparison.
+	Status Status `json:"status"`
+
+	// Line is the first differing line (1-based) for StatusMismatch.
+	Line int `json:"line,omitempty"`
+}
+
+// render is a generated file of a variant compared with the golden of suffix.
+type render struct {
+	suffix string
+	build  func() (string, error)
+}
+
+// Run renders all valid schemas from fixturesDir and compares them with goldens in goldenDir.
+// If update is true, goldens are overwritten with the rendered output instead.
+// Schemas which fail validation are skipped, so negative fixtures may share the directory.
+//
+// Example:
+//
+//	results, err := snapshot.Run("tests/fixtures", "tests/golden", false)
+func Run(fixturesDir, goldenDir string, update bool) ([]Result, error) {
+	schemas, err := filepath.Glob(filepath.Join(fixturesDir, "*.json"))
+	if err != nil {
+		return nil, logger.NewFailure("failed to list fixtures", err).
+			With("path", fixturesDir)
+	}
+	if len(schemas) == 0 {
+		return nil, logger.NewFailure("no JSON schemas found", nil).
+			With("path", fixturesDir)
+	}
+	sort.Strings(schemas)
+
+	var (
+		results  []Result
+		progress = logger.NewProgress("selftest", len(schemas))
+	)
+	for _, schemaPath := range schemas {
+		name := strings.TrimSuffix(filepath.Base(schemaPath), ".json")
+		progress.Step(name)
+
+		g, err := generator.NewGenerator(schemaPath)
+		if err != nil {
+			continue
+		}
+		if err := g.Validate(); err != nil {
+			continue
+		}
+
+		for _, v := range Variants {
+			rb := g.NewRenderBuilder()
+			v.Apply(rb)
+
+			var (
+				base    = filepath.Join(goldenDir, name, v.Name)
+				renders = []render{{".go.golden", rb.Build}}
+			)
+			if rb.HasTests() {
+				renders = append(renders, render{"_test.go.golden", rb.BuildTests})
+			}
+			if rb.HasDoc() {
+				renders = append(renders, render{"_doc.go.golden", rb.BuildDoc})
+			}
+			for _, r := range renders {
+				code, err := r.build()
+				if err != nil {
+					return nil, err
+				}
+				res, err := compare(base+r.suffix, []byte(code), update)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, res)
+			}
+		}
+	}
+	progress.Done()
+	return results, nil
+}
+
+// Failed returns results which don't match their goldens.
+func Failed(results []Result) []Result {
+	var failed []Result
+	for _, r := range results {
+		if r.Status == StatusMismatch || r.Status == StatusMissing {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+func compare(path string, rendered []byte, update bool) (Result, error) {
+	if update {
+		if err := fs.WriteToFile(path, rendered); err != nil {
+			return Result{}, err
+		}
+		return Result{Path: path, Status: StatusUpdated}, nil
+	}
+
+	golden, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Result{Path: path, Status: StatusMissing}, nil
+	}
+	if err != nil {
+		return Result{}, logger.NewFailure("failed to read golden file", err).
+			With("path", path)
+	}
+	if bytes.Equal(golden, rendered) {
+		return Result{Path: path, Status: StatusMatch}, nil
+	}
+	return Result{Path: path, Status: StatusMismatch, Line: firstDiffLine(golden, rendered)}, nil
+}
+
+func firstDiffLine(a, b []byte) int {
+	var (
+		al = strings.Split(string(a), "\n")
+		bl = strings.Split(string(b), "\n")
+	)
+	for i := 0; i < len(al) && i < len(bl); i++ {
+		if al[i] != bl[i] {
+			return i + 1
+		}
+	}
+	return min(len(al), len(bl)) + 1
+}
+
+// String returns a short human-readable representation of the result.
+func (r Result) String() string {
+	if r.Status == StatusMismatch {
+		return fmt.Sprintf("%s: %s at line %d", r.Status, r.Path, r.Line)
+	}
+	return fmt.Sprintf("%s: %s", r.Status, r.Path)
+}
@@ -0,0 +1,310 @@
+// Package stats samples items of a live table and reports attribute statistics.
+//
+// For every attribute found in the sample it reports:
+//   - Fill rate: share of sampled items having the attribute
+//   - Average size in bytes (attribute name included, as DynamoDB bills it)
+//   - DynamoDB types seen, set cardinalities and the most frequent scalar values
+//
+// Compared with a schema, the report suggests schema adjustments: undeclared or
+// unused attributes, low-cardinality GSI keys, sparse indexes and large attributes
+// projected into GSIs.
+package stats
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// maxDistinct caps the distinct values tracked per attribute.
+	maxDistinct = 1000
+
+	// topValues is the number of most frequent values reported per attribute.
+	topValues = 5
+
+	// largeAttributeSize is the average size from which an attribute is considered large (bytes).
+	largeAttributeSize = 1024
+
+	// lowCardinality is the number of distinct GSI hash key values considered a hot partition risk.
+	lowCardinality = 10
+)
+
+// Scanner is the subset of the DynamoDB client used by Collect.
+type Scanner interface {
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+}
+
+// Report is the result of a sample.
+type Report struct {
+	Table       string           `json:"table"`
+	Sampled     int              `json:"sampled"`
+	AvgItemSize float64          `json:"avg_item_size"`
+	Attributes  []AttributeStats `json:"attributes"`
+	Suggestions []Suggestion     `json:"suggestions"`
+}
+
+// AttributeStats are the statistics of one attribute.
+type AttributeStats struct {
+	Name         string         `json:"name"`
+	Count        int            `json:"count"`
+	FillRate     float64        `json:"fill_rate"`
+	AvgSize      float64        `json:"avg_size"`
+	Types        map[string]int `json:"types"`
+	Distinct     int            `json:"distinct"`
+	DistinctMore bool           `json:"distinct_more,omitempty"` // more than Distinct values exist
+	TopValues    []ValueCount   `json:"top_values,omitempty"`
+	AvgSetSize   float64        `json:"avg_set_size,omitempty"`
+	MaxSetSize   int            `json:"max_set_size,omitempty"`
+}
+
+// ValueCount is a scalar value with the number of sampled items having it.
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Suggestion is a proposed schema adjustment.
+type Suggestion struct {
+	Scope   string `json:"scope"`
+	Message string `json:"message"`
+}
+
+// accumulator collects statistics of one attribute.
+type accumulator struct {
+	count    int
+	size     int
+	types    map[string]int
+	values   map[string]int
+	overflow bool
+	sets     int
+	setItems int
+	maxSet   int
+}
+
+// Collect scans up to sample items of the table.
+// progress is called after every page with the number of items sampled so far; it may be nil.
+func Collect(ctx context.Context, client Scanner, table string, sample int, progress func(sampled int)) (*Report, error) {
+	if sample < 1 {
+		return nil, logger.NewFailure("sample size must be positive", nil).
+			With("sample", sample)
+	}
+	var (
+		accs      = make(map[string]*accumulator)
+		sampled   int
+		totalSize int
+		input     = &dynamodb.ScanInput{TableName: aws.String(table)}
+	)
+	for sampled < sample {
+		out, err := client.Scan(ctx, input)
+		if err != nil {
+			return nil, logger.NewFailure("failed to scan table", err).
+				With("table", table)
+		}
+		for _, item := range out.Items {
+			if sampled == sample {
+				break
+			}
+			sampled++
+			for name, value := range item {
+				acc, ok := accs[name]
+				if !ok {
+					acc = &accumulator{types: map[string]int{}, values: map[string]int{}}
+					accs[name] = acc
+				}
+				totalSize += acc.add(name, value)
+			}
+		}
+		if progress != nil {
+			progress(sampled)
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+
+	report := &Report{
+		Table:       table,
+		Sampled:     sampled,
+		Attributes:  make([]AttributeStats, 0, len(accs)),
+		Suggestions: []Suggestion{},
+	}
+	if sampled > 0 {
+		report.AvgItemSize = float64(totalSize) / float64(sampled)
+	}
+	for name, acc := range accs {
+		report.Attributes = append(report.Attributes, acc.stats(name, sampled))
+	}
+	sort.Slice(report.Attributes, func(i, j int) bool {
+		return report.Attributes[i].Name < report.Attributes[j].Name
+	})
+	return report, nil
+}
+
+// add records one value and returns its size.
+func (a *accumulator) add(name string, value types.AttributeValue) int {
+	kind, text, size, setLen := describe(value)
+	size += len(name)
+
+	a.count++
+	a.size += size
+	a.types[kind]++
+	if setLen >= 0 {
+		a.sets++
+		a.setItems += setLen
+		a.maxSet = max(a.maxSet, setLen)
+	}
+	if text != "" || kind == "S" {
+		if _, ok := a.values[text]; ok || len(a.values) < maxDistinct {
+			a.values[text]++
+		} else {
+			a.overflow = true
+		}
+	}
+	return size
+}
+
+// stats converts the accumulator into AttributeStats.
+func (a *accumulator) stats(name string, sampled int) AttributeStats {
+	st := AttributeStats{
+		Name:         name,
+		Count:        a.count,
+		FillRate:     float64(a.count) / float64(sampled),
+		AvgSize:      float64(a.size) / float64(a.count),
+		Types:        a.types,
+		Distinct:     len(a.values),
+		DistinctMore: a.overflow,
+		MaxSetSize:   a.maxSet,
+	}
+	if a.sets > 0 {
+		st.AvgSetSize = float64(a.setItems) / float64(a.sets)
+	}
+	for value, count := range a.values {
+		st.TopValues = append(st.TopValues, ValueCount{Value: value, Count: count})
+	}
+	sort.Slice(st.TopValues, func(i, j int) bool {
+		if st.TopValues[i].Count != st.TopValues[j].Count {
+			return st.TopValues[i].Count > st.TopValues[j].Count
+		}
+		return st.TopValues[i].Value < st.TopValues[j].Value
+	})
+	if len(st.TopValues) > topValues {
+		st.TopValues = st.TopValues[:topValues]
+	}
+	return st
+}
+
+// describe returns the DynamoDB type of value, its text for distribution (scalars only),
+// its approximate stored size and the number of set members (-1 if not a set).
+func describe(value types.AttributeValue) (kind, text string, size, setLen int) {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return "S", v.Value, len(v.Value), -1
+	case *types.AttributeValueMemberN:
+		return "N", v.Value, numberSize(v.Value), -1
+	case *types.AttributeValueMemberB:
+		return "B", "", len(v.Value), -1
+	case *types.AttributeValueMemberBOOL:
+		return "BOOL", fmt.Sprint(v.Value), 1, -1
+	case *types.AttributeValueMemberNULL:
+		return "NULL", "null", 1, -1
+	case *types.AttributeValueMemberSS:
+		for _, s := range v.Value {
+			size += len(s)
+		}
+		return "SS", "", size, len(v.Value)
+	case *types.AttributeValueMemberNS:
+		for _, n := range v.Value {
+			size += numberSize(n)
+		}
+		return "NS", "", size, len(v.Value)
+	case *types.AttributeValueMemberBS:
+		for _, b := range v.Value {
+			size += len(b)
+		}
+		return "BS", "", size, len(v.Value)
+	case *types.AttributeValueMemberL:
+		size = 3
+		for _, elem := range v.Value {
+			_, _, elemSize, _ := describe(elem)
+			size += elemSize + 1
+		}
+		return "L", "", size, -1
+	case *types.AttributeValueMemberM:
+		size = 3
+		for name, elem := range v.Value {
+			_, _, elemSize, _ := describe(elem)
+			size += len(name) + elemSize + 1
+		}
+		return "M", "", size, -1
+	}
+	return "unknown", "", 0, -1
+}
+
+// numberSize approximates the stored size of a number: 1 byte per 2 significant digits plus 1.
+func numberSize(n string) int {
+	digits := strings.TrimLeft(strings.NewReplacer("-", "", ".", "").Replace(n), "0")
+	return (len(digits)+1)/2 + 1
+}
+
+// Suggest compares the report with the schema and fills report.Suggestions.
+func Suggest(report *Report, s *schema.Schema) {
+	found := make(map[string]AttributeStats, len(report.Attributes))
+	for _, st := range report.Attributes {
+		found[st.Name] = st
+	}
+	declared := make(map[string]bool)
+	for _, attr := range s.AllAttributes() {
+		declared[attr.Name] = true
+		if _, ok := found[attr.Name]; !ok && report.Sampled > 0 {
+			report.add(attr.Name, "attribute is declared but absent from all %d sampled items, consider removing it", report.Sampled)
+		}
+	}
+	for _, idx := range s.SecondaryIndexes() {
+		if hashKey, ok := found[idx.HashKey]; ok && idx.IsGSI() && len(idx.HashKeyParts) == 0 {
+			if !hashKey.DistinctMore && hashKey.Distinct < lowCardinality && hashKey.Count > lowCardinality*10 {
+				report.add("index "+idx.Name, "hash key %s has only %d distinct values in %d items: hot partition risk, consider a composite or sharded key", idx.HashKey, hashKey.Distinct, hashKey.Count)
+			}
+			if hashKey.FillRate < 0.5 {
+				report.add("index "+idx.Name, "sparse index: hash key %s is set in %.0f%% of items, only those are indexed", idx.HashKey, hashKey.FillRate*100)
+			}
+		}
+		if idx.IsGSI() && strings.EqualFold(idx.ProjectionType, "ALL") {
+			var large []string
+			for _, st := range report.Attributes {
+				if st.AvgSize >= largeAttributeSize && st.Name != idx.HashKey && st.Name != idx.RangeKey {
+					large = append(large, st.Name)
+				}
+			}
+			if len(large) > 0 {
+				report.add("index "+idx.Name, "projection ALL copies large attributes (%s, avg >= %d bytes) into the index, consider INCLUDE without them", strings.Join(large, ", "), largeAttributeSize)
+			}
+		}
+	}
+	for _, st := range report.Attributes {
+		if !declared[st.Name] {
+			report.add(st.Name, "attribute is set in %.0f%% of sampled items but not declared in the schema", st.FillRate*100)
+		}
+		if len(st.Types) > 1 {
+			kinds := make([]string, 0, len(st.Types))
+			for kind := range st.Types {
+				kinds = append(kinds, kind)
+			}
+			sort.Strings(kinds)
+			report.add(st.Name, "attribute has mixed types %s, generated code expects one type", strings.Join(kinds, ", "))
+		}
+	}
+}
+
+func (r *Report) add(scope, format string, args ...any) {
+	r.Suggestions = append(r.Suggestions, Suggestion{Scope: scope, Message: fmt.Sprintf(format, args...)})
+}
@@ -0,0 +1,202 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubScanner returns the items in pages of pageSize, resuming after the index in ExclusiveStartKey.
+type stubScanner struct {
+	items    []map[string]types.AttributeValue
+	pageSize int
+	err      error
+	scans    int
+}
+
+func (s *stubScanner) Scan(_ context.Context, params *dynamodb.ScanInput, _ ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	s.scans++
+	if s.err != nil {
+		return nil, s.err
+	}
+	start := 0
+	if key, ok := params.ExclusiveStartKey["page"].(*types.AttributeValueMemberN); ok {
+		start, _ = strconv.Atoi(key.Value)
+	}
+	end := min(start+s.pageSize, len(s.items))
+	out := &dynamodb.ScanOutput{Items: s.items[start:end]}
+	if end < len(s.items) {
+		out.LastEvaluatedKey = map[string]types.AttributeValue{"page": &types.AttributeValueMemberN{Value: strconv.Itoa(end)}}
+	}
+	return out, nil
+}
+
+func str(v string) types.AttributeValue { return &types.AttributeValueMemberS{Value: v} }
+
+func num(v string) types.AttributeValue { return &types.AttributeValueMemberN{Value: v} }
+
+// sampleItems returns n items with an id, a status of 2 values and a tags set on every other item.
+func sampleItems(n int) []map[string]types.AttributeValue {
+	items := make([]map[string]types.AttributeValue, n)
+	statuses := []string{"active", "active", "closed"}
+	for i := range items {
+		items[i] = map[string]types.AttributeValue{
+			"id":     str(fmt.Sprintf("id-%02d", i)),
+			"status": str(statuses[i%len(statuses)]),
+		}
+		if i%2 == 0 {
+			items[i]["tags"] = &types.AttributeValueMemberSS{Value: strings.Split("a,b,c,d"[:2*(i%4)+1], ",")}
+		}
+	}
+	return items
+}
+
+func attributeStats(t *testing.T, report *Report, name string) AttributeStats {
+	t.Helper()
+	for _, st := range report.Attributes {
+		if st.Name == name {
+			return st
+		}
+	}
+	require.Failf(t, "attribute not reported", "attribute %s", name)
+	return AttributeStats{}
+}
+
+func TestCollect_Pages(t *testing.T) {
+	client := &stubScanner{items: sampleItems(12), pageSize: 5}
+	var progress []int
+
+	report, err := Collect(context.Background(), client, "events", 100, func(sampled int) {
+		progress = append(progress, sampled)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "events", report.Table)
+	assert.Equal(t, 12, report.Sampled)
+	assert.Equal(t, 3, client.scans)
+	assert.Equal(t, []int{5, 10, 12}, progress)
+	assert.Equal(t, []string{"id", "status", "tags"}, []string{report.Attributes[0].Name, report.Attributes[1].Name, report.Attributes[2].Name})
+	assert.Empty(t, report.Suggestions)
+}
+
+func TestCollect_SampleLimit(t *testing.T) {
+	client := &stubScanner{items: sampleItems(12), pageSize: 5}
+
+	report, err := Collect(context.Background(), client, "events", 7, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.Sampled)
+	assert.Equal(t, 2, client.scans, "Should stop scanning once the sample is full")
+	assert.Equal(t, 7, attributeStats(t, report, "id").Count)
+}
+
+func TestCollect_AttributeStats(t *testing.T) {
+	report, err := Collect(context.Background(), &stubScanner{items: sampleItems(12), pageSize: 5}, "events", 100, nil)
+	require.NoError(t, err)
+
+	id := attributeStats(t, report, "id")
+	assert.Equal(t, 1.0, id.FillRate)
+	assert.Equal(t, float64(len("id")+len("id-00")), id.AvgSize, "Size should include the attribute name")
+	assert.Equal(t, map[string]int{"S": 12}, id.Types)
+	assert.Equal(t, 12, id.Distinct)
+	assert.Len(t, id.TopValues, topValues)
+
+	status := attributeStats(t, report, "status")
+	assert.Equal(t, 2, status.Distinct)
+	assert.Equal(t, []ValueCount{{Value: "active", Count: 8}, {Value: "closed", Count: 4}}, status.TopValues)
+
+	tags := attributeStats(t, report, "tags")
+	assert.Equal(t, 0.5, tags.FillRate)
+	assert.Equal(t, map[string]int{"SS": 6}, tags.Types)
+	assert.Equal(t, 2.0, tags.AvgSetSize, "Sets of 1 and 3 members alternate")
+	assert.Equal(t, 3, tags.MaxSetSize)
+	assert.Zero(t, tags.Distinct, "Set values should not be counted")
+}
+
+func TestCollect_NumberSize(t *testing.T) {
+	items := []map[string]types.AttributeValue{{"n": num("12345")}, {"n": num("-0.5")}}
+	report, err := Collect(context.Background(), &stubScanner{items: items, pageSize: 10}, "events", 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, float64(len("n"))+(4+2)/2.0, attributeStats(t, report, "n").AvgSize)
+}
+
+func TestCollect_InvalidSample(t *testing.T) {
+	client := &stubScanner{items: sampleItems(1), pageSize: 1}
+	_, err := Collect(context.Background(), client, "events", 0, nil)
+	assert.Error(t, err)
+	assert.Zero(t, client.scans)
+}
+
+func TestCollect_ScanError(t *testing.T) {
+	errScan := errors.New("access denied")
+	_, err := Collect(context.Background(), &stubScanner{err: errScan}, "events", 10, nil)
+	assert.ErrorIs(t, err, errScan)
+}
+
+const statsSchema = `{
+	"table_name": "events",
+	"hash_key": "id",
+	"attributes": [
+		{ "name": "id", "type": "S" },
+		{ "name": "status", "type": "S" },
+		{ "name": "owner", "type": "S" }
+	],
+	"common_attributes": [
+		{ "name": "archived", "type": "BOOL" }
+	],
+	"secondary_indexes": [
+		{ "name": "by_status", "type": "GSI", "hash_key": "status", "projection_type": "ALL" },
+		{ "name": "by_owner", "type": "GSI", "hash_key": "owner", "projection_type": "KEYS_ONLY" }
+	]
+}`
+
+func TestSuggest_Schema(t *testing.T) {
+	s, err := schema.FromJSON([]byte(statsSchema))
+	require.NoError(t, err)
+
+	items := make([]map[string]types.AttributeValue, 120)
+	for i := range items {
+		items[i] = map[string]types.AttributeValue{
+			"id":     str(fmt.Sprintf("id-%03d", i)),
+			"status": str([]string{"active", "closed"}[i%2]),
+			"body":   str(strings.Repeat("x", largeAttributeSize)),
+			"score":  num("1"),
+		}
+		if i%4 == 0 {
+			items[i]["owner"] = str(fmt.Sprintf("owner-%d", i))
+			items[i]["score"] = str("high")
+		}
+	}
+	report, err := Collect(context.Background(), &stubScanner{items: items, pageSize: 50}, "events", len(items), nil)
+	require.NoError(t, err)
+
+	Suggest(report, s)
+	assert.Equal(t, []Suggestion{
+		{Scope: "archived", Message: "attribute is declared but absent from all 120 sampled items, consider removing it"},
+		{Scope: "index by_status", Message: "hash key status has only 2 distinct values in 120 items: hot partition risk, consider a composite or sharded key"},
+		{Scope: "index by_status", Message: "projection ALL copies large attributes (body, avg >= 1024 bytes) into the index, consider INCLUDE without them"},
+		{Scope: "index by_owner", Message: "sparse index: hash key owner is set in 25% of items, only those are indexed"},
+		{Scope: "body", Message: "attribute is set in 100% of sampled items but not declared in the schema"},
+		{Scope: "score", Message: "attribute is set in 100% of sampled items but not declared in the schema"},
+		{Scope: "score", Message: "attribute has mixed types N, S, generated code expects one type"},
+	}, report.Suggestions)
+}
+
+func TestSuggest_EmptySample(t *testing.T) {
+	s, err := schema.FromJSON([]byte(statsSchema))
+	require.NoError(t, err)
+
+	report, err := Collect(context.Background(), &stubScanner{pageSize: 10}, "events", 10, nil)
+	require.NoError(t, err)
+	Suggest(report, s)
+	assert.Zero(t, report.AvgItemSize)
+	assert.Empty(t, report.Suggestions, "Absent attributes should not be reported without samples")
+}
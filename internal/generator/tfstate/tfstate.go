@@ -0,0 +1,351 @@
+// Package tfstate maps aws_dynamodb_table resources of Terraform to go-dyno schemas.
+//
+// Supported inputs:
+//   - a state file (terraform.tfstate, format version 4)
+//   - "terraform show -json" of a state
+//   - "terraform show -json" of a plan (planned values, prior state as fallback)
+//
+// Terraform declares only the key attributes of a table, so the schema holds the keys of
+// the table and its indexes plus attributes projected by indexes (assumed to be strings);
+// the remaining attributes must be added by hand.
+package tfstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/schema"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+)
+
+// resourceType is the Terraform resource of a DynamoDB table.
+const resourceType = "aws_dynamodb_table"
+
+// Table is an aws_dynamodb_table resource found in the input.
+type Table struct {
+	// Address is the resource address, e.g. "module.blog.aws_dynamodb_table.posts[0]".
+	Address string
+
+	values tableValues
+}
+
+// Name returns the DynamoDB table name of the resource.
+func (t Table) Name() string {
+	return t.values.Name
+}
+
+// tableValues are the attributes of an aws_dynamodb_table resource used by the schema.
+type tableValues struct {
+	Name                 string           `json:"name"`
+	HashKey              string           `json:"hash_key"`
+	RangeKey             string           `json:"range_key"`
+	BillingMode          string           `json:"billing_mode"`
+	TableClass           string           `json:"table_class"`
+	WarmThroughput       []warmThroughput `json:"warm_throughput"`
+	Attribute            []keyAttribute   `json:"attribute"`
+	GlobalSecondaryIndex []secondaryIndex `json:"global_secondary_index"`
+	LocalSecondaryIndex  []secondaryIndex `json:"local_secondary_index"`
+	Replica              []replica        `json:"replica"`
+	TTL                  []ttl            `json:"ttl"`
+}
+
+// ttl is a "ttl" block: the TTL attribute of the table.
+type ttl struct {
+	AttributeName string `json:"attribute_name"`
+	Enabled       bool   `json:"enabled"`
+}
+
+// replica is a "replica" block: a Global Tables replica of the table.
+type replica struct {
+	RegionName string `json:"region_name"`
+}
+
+// keyAttribute is an "attribute" block: a key attribute of the table or an index.
+type keyAttribute struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// secondaryIndex is a "global_secondary_index" or "local_secondary_index" block.
+type secondaryIndex struct {
+	Name             string           `json:"name"`
+	HashKey          string           `json:"hash_key"`
+	RangeKey         string           `json:"range_key"`
+	ProjectionType   string           `json:"projection_type"`
+	NonKeyAttributes []string         `json:"non_key_attributes"`
+	ReadCapacity     *int             `json:"read_capacity"`
+	WriteCapacity    *int             `json:"write_capacity"`
+	WarmThroughput   []warmThroughput `json:"warm_throughput"`
+}
+
+// warmThroughput is a "warm_throughput" block of a table or GSI.
+type warmThroughput struct {
+	ReadUnitsPerSecond  *int64 `json:"read_units_per_second"`
+	WriteUnitsPerSecond *int64 `json:"write_units_per_second"`
+}
+
+// toWarmThroughput maps a "warm_throughput" block, nil if there is none.
+func toWarmThroughput(blocks []warmThroughput) *index.WarmThroughput {
+	if len(blocks) == 0 || (blocks[0].ReadUnitsPerSecond == nil && blocks[0].WriteUnitsPerSecond == nil) {
+		return nil
+	}
+	return &index.WarmThroughput{
+		ReadUnitsPerSecond:  blocks[0].ReadUnitsPerSecond,
+		WriteUnitsPerSecond: blocks[0].WriteUnitsPerSecond,
+	}
+}
+
+// input covers the state file and "terraform show -json" formats of states and plans.
+type input struct {
+	Version   int             `json:"version"`
+	Resources []stateResource `json:"resources"`
+
+	Values        *showValues `json:"values"`
+	PlannedValues *showValues `json:"planned_values"`
+	PriorState    *struct {
+		Values *showValues `json:"values"`
+	} `json:"prior_state"`
+}
+
+// stateResource is a resource of a state file.
+type stateResource struct {
+	Module    string `json:"module"`
+	Mode      string `json:"mode"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Instances []struct {
+		IndexKey   any             `json:"index_key"`
+		Attributes json.RawMessage `json:"attributes"`
+	} `json:"instances"`
+}
+
+// showValues are the values of "terraform show -json".
+type showValues struct {
+	RootModule showModule `json:"root_module"`
+}
+
+// showModule is a module of "terraform show -json" with its resources and child modules.
+type showModule struct {
+	Resources []struct {
+		Address string          `json:"address"`
+		Mode    string          `json:"mode"`
+		Type    string          `json:"type"`
+		Values  json.RawMessage `json:"values"`
+	} `json:"resources"`
+	ChildModules []showModule `json:"child_modules"`
+}
+
+// Tables returns the aws_dynamodb_table resources of a Terraform state or plan in JSON.
+// Data sources are skipped.
+func Tables(data []byte) ([]Table, error) {
+	var in input
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, logger.NewFailure("invalid Terraform JSON", err)
+	}
+
+	var tables []Table
+	add := func(address string, raw json.RawMessage) error {
+		var values tableValues
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return logger.NewFailure("invalid aws_dynamodb_table resource", err).
+				With("address", address)
+		}
+		tables = append(tables, Table{Address: address, values: values})
+		return nil
+	}
+
+	switch {
+	case in.Resources != nil:
+		for _, r := range in.Resources {
+			if r.Mode != "managed" || r.Type != resourceType {
+				continue
+			}
+			for _, inst := range r.Instances {
+				if err := add(stateAddress(r, inst.IndexKey), inst.Attributes); err != nil {
+					return nil, err
+				}
+			}
+		}
+	default:
+		values := in.Values
+		if in.PlannedValues != nil {
+			values = in.PlannedValues
+		} else if values == nil && in.PriorState != nil {
+			values = in.PriorState.Values
+		}
+		if values == nil {
+			return nil, logger.NewFailure("no resources in Terraform JSON", nil).
+				With("hint", "use a state file or the output of 'terraform show -json'")
+		}
+		var walk func(m showModule) error
+		walk = func(m showModule) error {
+			for _, r := range m.Resources {
+				if r.Mode != "managed" || r.Type != resourceType {
+					continue
+				}
+				if err := add(r.Address, r.Values); err != nil {
+					return err
+				}
+			}
+			for _, child := range m.ChildModules {
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := walk(values.RootModule); err != nil {
+			return nil, err
+		}
+	}
+	return tables, nil
+}
+
+// stateAddress returns the address of a resource instance of a state file.
+func stateAddress(r stateResource, indexKey any) string {
+	address := r.Type + "." + r.Name
+	if r.Module != "" {
+		address = r.Module + "." + address
+	}
+	switch key := indexKey.(type) {
+	case string:
+		address += fmt.Sprintf("[%q]", key)
+	case float64:
+		address += fmt.Sprintf("[%d]", int(key))
+	}
+	return address
+}
+
+// Find returns the table with the address, or the only table if address is empty.
+func Find(tables []Table, address string) (Table, error) {
+	addresses := make([]string, len(tables))
+	for i, t := range tables {
+		if address != "" && t.Address == address {
+			return t, nil
+		}
+		addresses[i] = t.Address
+	}
+	switch {
+	case len(tables) == 0:
+		return Table{}, logger.NewFailure("no aws_dynamodb_table resource found", nil)
+	case address != "":
+		return Table{}, logger.NewFailure("aws_dynamodb_table resource not found", nil).
+			With("address", address).
+			With("available", addresses)
+	case len(tables) > 1:
+		return Table{}, logger.NewFailure("several aws_dynamodb_table resources found, select one", nil).
+			With("available", addresses)
+	}
+	return tables[0], nil
+}
+
+// Schema is a schema file built from a table, encoded in the schema file format.
+type Schema struct {
+	TableName        string                `json:"table_name"`
+	SchemaVersion    int                   `json:"schema_version"`
+	HashKey          string                `json:"hash_key"`
+	RangeKey         string                `json:"range_key,omitempty"`
+	Attributes       []attribute.Attribute `json:"attributes"`
+	CommonAttributes []attribute.Attribute `json:"common_attributes"`
+	SecondaryIndexes []index.Index         `json:"secondary_indexes"`
+	TableClass       string                `json:"table_class,omitempty"`
+	WarmThroughput   *index.WarmThroughput `json:"warm_throughput,omitempty"`
+	Replicas         []schema.Replica      `json:"replicas,omitempty"`
+
+	// Assumed lists attributes projected by INCLUDE indexes, which Terraform declares
+	// without a type; they are added as "S" attributes and should be checked.
+	Assumed []string `json:"-"`
+}
+
+// Schema maps the table to a schema: the table keys become "attributes", the other key
+// attributes of indexes and their projected attributes "common_attributes".
+// Index capacity is kept for provisioned tables; table class, warm throughput and replicas are kept as declared.
+// An enabled TTL becomes a "N" attribute marked as TTL.
+func (t Table) Schema() (*Schema, error) {
+	v := t.values
+	if v.Name == "" || v.HashKey == "" {
+		return nil, logger.NewFailure("aws_dynamodb_table resource has no name or hash key", nil).
+			With("address", t.Address).
+			With("hint", "values unknown until apply can't be imported, use a state or apply the plan")
+	}
+
+	s := &Schema{
+		TableName:        v.Name,
+		SchemaVersion:    1,
+		HashKey:          v.HashKey,
+		RangeKey:         v.RangeKey,
+		Attributes:       []attribute.Attribute{},
+		CommonAttributes: []attribute.Attribute{},
+		SecondaryIndexes: []index.Index{},
+		WarmThroughput:   toWarmThroughput(v.WarmThroughput),
+	}
+	if !strings.EqualFold(v.TableClass, "STANDARD") {
+		s.TableClass = v.TableClass
+	}
+	for _, r := range v.Replica {
+		s.Replicas = append(s.Replicas, schema.Replica{Region: r.RegionName})
+	}
+	for _, attr := range v.Attribute {
+		a := attribute.Attribute{Name: attr.Name, Type: attr.Type}
+		if attr.Name == v.HashKey || attr.Name == v.RangeKey {
+			s.Attributes = append(s.Attributes, a)
+		} else {
+			s.CommonAttributes = append(s.CommonAttributes, a)
+		}
+	}
+	if len(v.TTL) > 0 && v.TTL[0].Enabled && v.TTL[0].AttributeName != "" {
+		s.CommonAttributes = append(s.CommonAttributes, attribute.Attribute{Name: v.TTL[0].AttributeName, Type: "N", TTL: true})
+	}
+	for _, key := range []string{v.HashKey, v.RangeKey} {
+		if key != "" && !slices.ContainsFunc(v.Attribute, func(a keyAttribute) bool { return a.Name == key }) {
+			return nil, logger.NewFailure("key attribute is not declared in an attribute block", nil).
+				With("address", t.Address).
+				With("attribute", key)
+		}
+	}
+
+	provisioned := strings.EqualFold(v.BillingMode, "PROVISIONED")
+	for _, idx := range v.GlobalSecondaryIndex {
+		s.SecondaryIndexes = append(s.SecondaryIndexes, idx.toIndex(index.GSI, provisioned))
+	}
+	for _, idx := range v.LocalSecondaryIndex {
+		s.SecondaryIndexes = append(s.SecondaryIndexes, idx.toIndex(index.LSI, false))
+	}
+	for _, idx := range s.SecondaryIndexes {
+		for _, name := range idx.NonKeyAttributes {
+			if slices.ContainsFunc(v.Attribute, func(a keyAttribute) bool { return a.Name == name }) || slices.Contains(s.Assumed, name) {
+				continue
+			}
+			s.CommonAttributes = append(s.CommonAttributes, attribute.Attribute{Name: name, Type: "S"})
+			s.Assumed = append(s.Assumed, name)
+		}
+	}
+	return s, nil
+}
+
+// toIndex maps the index block to a schema index of the type.
+func (idx secondaryIndex) toIndex(typ index.Type, provisioned bool) index.Index {
+	out := index.Index{
+		Name:             idx.Name,
+		Type:             typ,
+		HashKey:          idx.HashKey,
+		RangeKey:         idx.RangeKey,
+		ProjectionType:   idx.ProjectionType,
+		NonKeyAttributes: idx.NonKeyAttributes,
+	}
+	if out.ProjectionType == "" {
+		out.ProjectionType = "ALL"
+	}
+	if provisioned {
+		out.ReadCapacity = idx.ReadCapacity
+		out.WriteCapacity = idx.WriteCapacity
+	}
+	if typ == index.GSI {
+		out.WarmThroughput = toWarmThroughput(idx.WarmThroughput)
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+// Package verify type-checks generated code before it's written.
+//
+// Generated files are placed into a temporary module which requires the AWS SDK
+// versions pinned by go-dyno and loaded with go/packages. Any type error means the
+// templates don't match the SDK API and is reported by the CLI instead of the user's build.
+//
+// Dependencies are resolved by the go command, so the module cache or GOPROXY must be available.
+package verify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/generator/module"
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/fs"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// maxErrors is the number of type errors attached to the failure.
+const maxErrors = 10
+
+// modulePath is the path of the temporary module generated code is checked in.
+const modulePath = "godyno.verify"
+
+// Build type-checks files (filename → content) as a single package named pkgName.
+// Companion _test.go files are checked as well.
+//
+// Example:
+//
+//	err := verify.Build(ctx, "users", map[string][]byte{"users.go": code})
+func Build(ctx context.Context, pkgName string, files map[string][]byte) error {
+	if _, err := exec.LookPath("go"); err != nil {
+		return logger.NewFailure("build verification requires the go command", err)
+	}
+
+	dir, err := os.MkdirTemp("", "godyno-verify-*")
+	if err != nil {
+		return logger.NewFailure("failed to create temporary directory", err)
+	}
+	defer func() {
+		_ = fs.RemovePath(dir)
+	}()
+
+	if err := fs.WriteToFile(filepath.Join(dir, "go.mod"), module.GoMod(modulePath)); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := fs.WriteToFile(filepath.Join(dir, pkgName, name), content); err != nil {
+			return err
+		}
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Context: ctx,
+		Mode:    packages.NeedName | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir:     dir,
+		Env:     append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off"),
+		Tests:   true,
+	}, "./...")
+	if err != nil {
+		return logger.NewFailure("failed to load generated package", err).
+			With("package", pkgName)
+	}
+
+	var (
+		seen  = make(map[string]bool)
+		found []string
+	)
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			msg := strings.ReplaceAll(e.Error(), dir+string(filepath.Separator), "")
+			if seen[msg] {
+				continue
+			}
+			seen[msg] = true
+			found = append(found, msg)
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	return logger.NewFailure("generated code doesn't build", errors.New(found[0])).
+		With("package", pkgName).
+		With("count", len(found)).
+		With("errors", found[:min(len(found), maxErrors)])
+}
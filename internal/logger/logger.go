@@ -88,3 +88,14 @@ func Init() {
 		With().
 		Logger()
 }
+
+// SetLevel overrides the log level of the global logger, e.g. from CLI verbosity flags.
+//
+// Example:
+//
+//	logger.SetLevel(zerolog.DebugLevel)
+func SetLevel(level zerolog.Level) {
+	logLevel = level
+	zerolog.SetGlobalLevel(level)
+	Log = Log.Level(level)
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const progressWidth = 20
+
+// Progress reports the advancement of a long operation (e.g. generation of many schemas)
+// as a bar in info log lines, so it follows the configured verbosity and is silent with --quiet.
+// It is safe for concurrent use.
+//
+// Example:
+//
+//	p := logger.NewProgress("generate", len(schemas))
+//	for _, s := range schemas {
+//		// ...
+//		p.Step(s)
+//	}
+//	p.Done()
+type Progress struct {
+	mu    sync.Mutex
+	name  string
+	total int
+	done  int
+	start time.Time
+}
+
+// NewProgress creates a progress reporter for total units of work.
+func NewProgress(name string, total int) *Progress {
+	return &Progress{
+		name:  name,
+		total: total,
+		start: time.Now(),
+	}
+}
+
+// Step marks one unit of work (item) as done and logs the current progress.
+func (p *Progress) Step(item string) {
+	p.Add(1, item)
+}
+
+// Add marks n units of work (e.g. items of a page) as done and logs the current progress.
+func (p *Progress) Add(n int, item string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done += n
+	Log.Info().
+		Str("item", item).
+		Msg(fmt.Sprintf("%s %s %d/%d", p.name, p.bar(), p.done, p.total))
+}
+
+// Done logs the completion of the operation with elapsed time.
+func (p *Progress) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	Log.Info().
+		Int("done", p.done).
+		Int("total", p.total).
+		Str("elapsed", time.Since(p.start).Round(time.Millisecond).String()).
+		Msg(fmt.Sprintf("%s completed", p.name))
+}
+
+func (p *Progress) bar() string {
+	filled := progressWidth
+	if p.total > 0 {
+		filled = min(progressWidth, p.done*progressWidth/p.total)
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat(".", progressWidth-filled) + "]"
+}
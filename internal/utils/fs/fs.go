@@ -158,6 +158,16 @@ func IsFileOrError(path string) error {
 	return nil
 }
 
+// IsDir reports whether the given path exists and is a directory.
+//
+// Example:
+//
+//	if fs.IsDir("schemas") { ... }
+func IsDir(path string) bool {
+	exist, isDir, err := statPath(path)
+	return err == nil && exist && isDir
+}
+
 // IsFileOrCreate checks if a file exists at the given path, and creates it if it does not.
 // Ensures the parent directory exists.
 //
@@ -184,3 +184,18 @@ func TestIsFileOrCreate_PathIsDirectory(t *testing.T) {
 	err := IsFileOrCreate(tmpDir)
 	assert.Error(t, err)
 }
+
+func TestIsDir_ExistingDir(t *testing.T) {
+	assert.True(t, IsDir(t.TempDir()))
+}
+
+func TestIsDir_PathIsFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "testfile")
+	os.WriteFile(filePath, []byte("test"), 0644)
+
+	assert.False(t, IsDir(filePath))
+}
+
+func TestIsDir_NonExistentPath(t *testing.T) {
+	assert.False(t, IsDir("/nonexistent/path"))
+}
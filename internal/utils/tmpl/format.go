@@ -0,0 +1,155 @@
+package tmpl
+
+import (
+	"errors"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
+	"golang.org/x/tools/imports"
+	"mvdan.cc/gofumpt/format"
+)
+
+// contextLines is the number of preceding lines used to pick a template line among several candidates.
+const contextLines = 5
+
+var actionRe = regexp.MustCompile(`\{\{.*?\}\}`)
+
+// formatSource formats rendered Go code with gofumpt and fixes its imports.
+// If the code doesn't parse, the error points to the rendered line and the template line that produced it,
+// so a broken template is reported here instead of as a compile error in the generated package.
+func formatSource(src []byte, tmpl string) ([]byte, error) {
+	if _, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments); err != nil {
+		return nil, syntaxFailure(err, src, tmpl)
+	}
+
+	formatted, err := format.Source(src, format.Options{})
+	if err != nil {
+		return nil, logger.NewFailure("internal: failed to format generated code with gofumpt", err)
+	}
+	imported, err := imports.Process("", formatted, &imports.Options{
+		Comments:  true,
+		TabWidth:  8,
+		TabIndent: true,
+	})
+	if err != nil {
+		return nil, logger.NewFailure("internal: failed to process imports with goimports", err)
+	}
+	return imported, nil
+}
+
+func syntaxFailure(err error, src []byte, tmpl string) error {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return logger.NewFailure("internal: generated code doesn't parse", err)
+	}
+
+	var (
+		first    = list[0]
+		rendered = strings.Split(string(src), "\n")
+		failure  = logger.NewFailure("internal: generated code doesn't parse", errors.New(first.Msg)).
+				With("line", first.Pos.Line).
+				With("column", first.Pos.Column)
+	)
+	if first.Pos.Line < 1 || first.Pos.Line > len(rendered) {
+		return failure
+	}
+	failure.With("code", strings.TrimSpace(rendered[first.Pos.Line-1]))
+
+	if line := locateTemplateLine(strings.Split(tmpl, "\n"), rendered, first.Pos.Line); line > 0 {
+		failure.
+			With("template_line", line).
+			With("template_code", strings.TrimSpace(strings.Split(tmpl, "\n")[line-1]))
+	}
+	return failure
+}
+
+// locateTemplateLine returns the 1-based template line which most likely produced the rendered line,
+// or 0 if it can't be determined unambiguously.
+func locateTemplateLine(tmplLines, rendered []string, line int) int {
+	target := rendered[line-1]
+	if strings.TrimSpace(target) == "" {
+		return 0
+	}
+
+	var (
+		best      = 0
+		bestScore = -1
+		tie       = false
+	)
+	for i, tl := range tmplLines {
+		if !lineMatches(tl, target) {
+			continue
+		}
+		score := contextScore(tmplLines[:i], rendered[:line-1])
+		switch {
+		case score > bestScore:
+			best, bestScore, tie = i+1, score, false
+		case score == bestScore:
+			tie = true
+		}
+	}
+	if tie {
+		return 0
+	}
+	return best
+}
+
+// contextScore counts how many of the preceding non-empty lines match, walking backwards.
+func contextScore(tmplLines, rendered []string) int {
+	var (
+		score int
+		ti    = len(tmplLines) - 1
+		ri    = len(rendered) - 1
+	)
+	for score < contextLines {
+		for ti >= 0 && !hasLiteral(tmplLines[ti]) {
+			ti--
+		}
+		for ri >= 0 && strings.TrimSpace(rendered[ri]) == "" {
+			ri--
+		}
+		if ti < 0 || ri < 0 || !lineMatches(tmplLines[ti], rendered[ri]) {
+			break
+		}
+		score++
+		ti--
+		ri--
+	}
+	return score
+}
+
+// lineMatches reports whether the rendered line could be produced by the template line:
+// the literal parts between actions must appear in order.
+func lineMatches(tmplLine, renderedLine string) bool {
+	if !hasLiteral(tmplLine) {
+		return false
+	}
+	var (
+		parts = actionRe.Split(strings.TrimSpace(tmplLine), -1)
+		rest  = strings.TrimSpace(renderedLine)
+	)
+	if len(parts) == 1 {
+		return rest == parts[0]
+	}
+	if !strings.HasPrefix(rest, parts[0]) {
+		return false
+	}
+	rest = rest[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return strings.HasSuffix(rest, parts[len(parts)-1])
+}
+
+func hasLiteral(tmplLine string) bool {
+	return strings.TrimSpace(actionRe.ReplaceAllString(tmplLine, "")) != ""
+}
@@ -0,0 +1,61 @@
+package tmpl
+
+import (
+	"testing"
+
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatSource_ValidCode(t *testing.T) {
+	tmpl := "package main\nfunc {{ .Name }}(){\nfmt.Println(1)\n}"
+	src := MustParseTemplateToString(tmpl, map[string]string{"Name": "run"})
+
+	result, err := formatSource([]byte(src), tmpl)
+	require.NoError(t, err)
+	assert.Contains(t, string(result), `import "fmt"`)
+	assert.Contains(t, string(result), "func run() {")
+}
+
+func TestFormatSource_ReportsTemplateLine(t *testing.T) {
+	tmpl := "package main\n\n{{ if .Extra }}\nvar extra = 1\n{{ end }}\nfunc {{ .Name }}( {\n}\n"
+	src := MustParseTemplateToString(tmpl, map[string]any{"Name": "run", "Extra": false})
+
+	_, err := formatSource([]byte(src), tmpl)
+	require.Error(t, err)
+
+	failure, ok := err.(*logger.Failure)
+	require.True(t, ok)
+	assert.Equal(t, 4, failure.Fields["line"])
+	assert.Equal(t, 6, failure.Fields["template_line"])
+	assert.Equal(t, "func {{ .Name }}( {", failure.Fields["template_code"])
+}
+
+func TestFormatSource_AmbiguousTemplateLine(t *testing.T) {
+	tmpl := "package main\n\nfunc a() {\n}\n\nfunc b() {\n}\n{{ .Broken }}\n"
+	src := MustParseTemplateToString(tmpl, map[string]string{"Broken": "func c() {\n}\n}"})
+
+	_, err := formatSource([]byte(src), tmpl)
+	require.Error(t, err)
+
+	failure, ok := err.(*logger.Failure)
+	require.True(t, ok)
+	assert.Equal(t, "}", failure.Fields["code"])
+	assert.NotContains(t, failure.Fields, "template_line")
+}
+
+func TestLocateTemplateLine_UsesContext(t *testing.T) {
+	var (
+		tmplLines = []string{"func a() {", "return {{ .A }}", "}", "func b() {", "return {{ .B }}", "}"}
+		rendered  = []string{"func a() {", "return 1", "}", "func b() {", "return ]", "}"}
+	)
+	assert.Equal(t, 5, locateTemplateLine(tmplLines, rendered, 5))
+}
+
+func TestLineMatches(t *testing.T) {
+	assert.True(t, lineMatches("func {{ .Name }}() {", "func run() {"))
+	assert.True(t, lineMatches("\treturn nil", "return nil"))
+	assert.False(t, lineMatches("return nil", "return nil, err"))
+	assert.False(t, lineMatches("{{ end }}", "}"))
+}
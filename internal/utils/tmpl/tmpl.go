@@ -2,7 +2,7 @@
 // built-in helpers for code generation.
 //
 // It supports:
-//   - Safe rendering of templates with panic-on-error semantics
+//   - Rendering of templates returning errors, or exiting on error for static templates (Must*)
 //   - Automatic formatting using gofumpt and goimports for production-ready Go code
 //   - Common template functions such as Join, CamelCase conversion, DynamoDB struct tags,
 //     Go type resolution for attributes, and more
@@ -14,6 +14,7 @@ package tmpl
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"strings"
 	"text/template"
@@ -23,8 +24,6 @@ import (
 	"github.com/Mad-Pixels/go-dyno/internal/logger"
 	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
 	"github.com/rs/zerolog"
-	"golang.org/x/tools/imports"
-	"mvdan.cc/gofumpt/format"
 )
 
 // MustParseTemplate renders the given Go text template `tmpl` into buffer `b`
@@ -53,7 +52,7 @@ import (
 //
 //	Hello, GoDyno! Your key: x1value
 func MustParseTemplate(b *bytes.Buffer, tmpl string, vars any) {
-	renderTemplate(b, tmpl, vars, false)
+	mustRender(renderTemplate(b, tmpl, vars, false))
 }
 
 // MustParseTemplateFormatted renders the given Go text template `tmpl` into buffer `b`
@@ -83,7 +82,7 @@ func MustParseTemplate(b *bytes.Buffer, tmpl string, vars any) {
 //		Name string
 //	}
 func MustParseTemplateFormatted(b *bytes.Buffer, tmpl string, vars any) {
-	renderTemplate(b, tmpl, vars, true)
+	mustRender(renderTemplate(b, tmpl, vars, true))
 }
 
 // MustParseTemplateToString renders the provided template with variables and
@@ -135,8 +134,54 @@ func MustParseTemplateFormattedToString(tmpl string, vars any) string {
 	return b.String()
 }
 
-// renderTemplate is the internal implementation for template rendering with optional formatting
-func renderTemplate(b *bytes.Buffer, tmpl string, vars any, shouldFormat bool) {
+// ParseTemplate renders the given Go text template `tmpl` into buffer `b`
+// using the provided `vars`, with the helper functions of MustParseTemplate.
+// Unlike MustParseTemplate, a failure is returned as a *logger.Failure.
+func ParseTemplate(b *bytes.Buffer, tmpl string, vars any) error {
+	return renderTemplate(b, tmpl, vars, false)
+}
+
+// ParseTemplateFormatted is ParseTemplate which also formats the result as
+// MustParseTemplateFormatted does. A failure is returned as a *logger.Failure.
+func ParseTemplateFormatted(b *bytes.Buffer, tmpl string, vars any) error {
+	return renderTemplate(b, tmpl, vars, true)
+}
+
+// ParseTemplateToString renders the provided template with variables and
+// returns the result as a string, or the failure of rendering.
+func ParseTemplateToString(tmpl string, vars any) (string, error) {
+	var b bytes.Buffer
+	if err := ParseTemplate(&b, tmpl, vars); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// ParseTemplateFormattedToString renders the provided template with variables,
+// formats the result and returns it as a string, or the failure of rendering.
+func ParseTemplateFormattedToString(tmpl string, vars any) (string, error) {
+	var b bytes.Buffer
+	if err := ParseTemplateFormatted(&b, tmpl, vars); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// mustRender logs the failure of rendering and exits.
+func mustRender(err error) {
+	if err == nil {
+		return
+	}
+	var failure *logger.Failure
+	if errors.As(err, &failure) {
+		failure.Log(zerolog.FatalLevel)
+	}
+	os.Exit(1)
+}
+
+// renderTemplate is the internal implementation for template rendering with optional formatting.
+// Every failure is returned as a *logger.Failure.
+func renderTemplate(b *bytes.Buffer, tmpl string, vars any, shouldFormat bool) error {
 	t, err := template.New("tmpl").Funcs(
 		template.FuncMap{
 			"Join":                   strings.Join,
@@ -160,37 +205,22 @@ func renderTemplate(b *bytes.Buffer, tmpl string, vars any, shouldFormat bool) {
 	).
 		Parse(tmpl)
 	if err != nil {
-		logger.NewFailure("internal: failed to create template", err).
-			Log(zerolog.FatalLevel)
-		os.Exit(1)
+		return logger.NewFailure("internal: failed to create template", err)
 	}
 
 	if err = t.Execute(b, vars); err != nil {
-		logger.NewFailure("internal: failed to write template data", err).
-			Log(zerolog.FatalLevel)
-		os.Exit(1)
+		return logger.NewFailure("internal: failed to write template data", err)
 	}
 
 	// Apply formatting if requested
 	if shouldFormat {
-		formatted, err := format.Source(b.Bytes(), format.Options{})
-		if err != nil {
-			logger.NewFailure("internal: failed to format generated code with gofumpt", err).
-				Log(zerolog.FatalLevel)
-			os.Exit(1)
-		}
-		imported, err := imports.Process("", formatted, &imports.Options{
-			Comments:  true,
-			TabWidth:  8,
-			TabIndent: true,
-		})
+		formatted, err := formatSource(b.Bytes(), tmpl)
 		if err != nil {
-			logger.NewFailure("internal: failed to process imports with goimports", err).
-				Log(zerolog.FatalLevel)
-			os.Exit(1)
+			return err
 		}
 
 		b.Reset()
-		b.Write(imported)
+		b.Write(formatted)
 	}
+	return nil
 }
@@ -2,9 +2,13 @@ package tmpl
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
+	"github.com/Mad-Pixels/go-dyno/internal/logger"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMustParseTemplate_BasicTemplate(t *testing.T) {
@@ -171,3 +175,26 @@ return "zero"
 	assert.Contains(t, result, `return "zero"`)
 	assert.Contains(t, result, "strconv")
 }
+
+func TestParseTemplate_InvalidTemplate(t *testing.T) {
+	var b bytes.Buffer
+	err := ParseTemplate(&b, "{{ .Name ", nil)
+
+	var failure *logger.Failure
+	require.True(t, errors.As(err, &failure))
+	assert.Equal(t, "internal: failed to create template", failure.Message)
+}
+
+func TestParseTemplate_ExecuteError(t *testing.T) {
+	_, err := ParseTemplateToString("{{ ToUpper .Missing }}", map[string]int{})
+
+	var failure *logger.Failure
+	require.True(t, errors.As(err, &failure))
+	assert.Equal(t, "internal: failed to write template data", failure.Message)
+}
+
+func TestParseTemplateFormattedToString_SyntaxError(t *testing.T) {
+	result, err := ParseTemplateFormattedToString("package main\nfunc {{ .Name }}( {\n}", map[string]string{"Name": "test"})
+	assert.Error(t, err)
+	assert.Empty(t, result)
+}
@@ -0,0 +1,61 @@
+// Package registry detects packages generated by different go-dyno versions linked into one binary.
+//
+// Every generated package exposes GeneratorVersion, which Register accepts as is:
+//
+//	func init() {
+//		registry.MustRegister("users", users.GeneratorVersion)
+//		registry.MustRegister("blogposts", blogposts.GeneratorVersion)
+//	}
+//
+// The registry lives in the process only, generated code doesn't import the package.
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	mu       sync.Mutex
+	versions = map[string]string{}
+)
+
+// Register records the go-dyno version a package was generated with.
+// Returns an error if a registered package was generated by another version;
+// registering the same package again with its version is a no-op.
+func Register(pkg, version string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if registered, ok := versions[pkg]; ok && registered != version {
+		return fmt.Errorf("godyno: package %s is registered with go-dyno %s and %s", pkg, registered, version)
+	}
+	for other, registered := range versions {
+		if registered != version {
+			return fmt.Errorf("godyno: package %s is generated by go-dyno %s, but %s by %s; regenerate all packages with the same version", pkg, version, other, registered)
+		}
+	}
+	versions[pkg] = version
+	return nil
+}
+
+// MustRegister is like Register but panics on a version mismatch.
+func MustRegister(pkg, version string) {
+	if err := Register(pkg, version); err != nil {
+		panic(err)
+	}
+}
+
+// Packages returns the registered package names in order.
+func Packages() []string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	packages := make([]string, 0, len(versions))
+	for pkg := range versions {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+	return packages
+}
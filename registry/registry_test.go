@@ -0,0 +1,44 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reset(t *testing.T) {
+	t.Helper()
+	mu.Lock()
+	versions = map[string]string{}
+	mu.Unlock()
+}
+
+func TestRegister_SameVersion(t *testing.T) {
+	reset(t)
+	require.NoError(t, Register("users", "1.2.0"))
+	require.NoError(t, Register("blogposts", "1.2.0"))
+	require.NoError(t, Register("users", "1.2.0"))
+	assert.Equal(t, []string{"blogposts", "users"}, Packages())
+}
+
+func TestRegister_MixedVersions(t *testing.T) {
+	reset(t)
+	require.NoError(t, Register("users", "1.2.0"))
+	err := Register("blogposts", "1.3.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "regenerate all packages")
+	assert.Equal(t, []string{"users"}, Packages())
+}
+
+func TestRegister_PackageVersionChanged(t *testing.T) {
+	reset(t)
+	require.NoError(t, Register("users", "1.2.0"))
+	assert.Error(t, Register("users", "1.3.0"))
+}
+
+func TestMustRegister_Panics(t *testing.T) {
+	reset(t)
+	MustRegister("users", "1.2.0")
+	assert.Panics(t, func() { MustRegister("blogposts", "1.3.0") })
+}
@@ -0,0 +1,128 @@
+package core
+
+// CompositeKeyTemplate provides parsing and building of composite key values
+const CompositeKeyTemplate = `
+// CompositeKeySeparator joins the parts of composite key values (e.g. "USER{{.CompositeKeys.TableSeparator}}123{{.CompositeKeys.TableSeparator}}active").
+{{- if .CompositeKeys.Separators}}
+// Keys declaring their own separator in compositeKeySeparators use it instead.
+{{- end}}
+const CompositeKeySeparator = {{printf "%q" .CompositeKeys.TableSeparator}}
+{{- if .CompositeKeys.Separators}}
+
+// compositeKeySeparators are the separators of composite keys declaring their own, keyed by attribute name.
+var compositeKeySeparators = map[string]string{
+    {{- range $key, $sep := .CompositeKeys.Separators}}
+    {{printf "%q" $key}}: {{printf "%q" $sep}},
+    {{- end}}
+}
+{{- end}}
+
+// compositeKeySeparator returns the separator of the composite key with the parts.
+func compositeKeySeparator(parts []CompositeKeyPart) string {
+    {{- if .CompositeKeys.Separators}}
+    names := make([]string, len(parts))
+    for i, part := range parts {
+        names[i] = part.Value
+    }
+    if sep, ok := compositeKeySeparators[strings.Join(names, "#")]; ok {
+        return sep
+    }
+    {{- end}}
+    return CompositeKeySeparator
+}
+
+// BuildCompositeKey joins constant parts and attribute values into a composite key value.
+{{- if .CompositeKeys.IsEscaped}}
+// Backslashes and separators in values are escaped with a backslash, ParseCompositeKey unescapes them.
+// Returns an error if a value is missing.
+{{- else}}
+// Returns an error if a value is missing or contains the separator,
+// since such a value could not be parsed back unambiguously.
+{{- end}}
+func BuildCompositeKey(parts []CompositeKeyPart, values map[string]string) (string, error) {
+    sep := compositeKeySeparator(parts)
+    out := make([]string, len(parts))
+    for i, part := range parts {
+        if part.IsConstant {
+            out[i] = part.Value
+            continue
+        }
+        v, ok := values[part.Value]
+        if !ok {
+            return "", fmt.Errorf("missing value for composite key part %s", part.Value)
+        }
+        {{- if .CompositeKeys.IsEscaped}}
+        out[i] = escapeCompositeValue(v, sep)
+        {{- else}}
+        if strings.Contains(v, sep) {
+            return "", fmt.Errorf("value for composite key part %s contains separator %q", part.Value, sep)
+        }
+        out[i] = v
+        {{- end}}
+    }
+    return strings.Join(out, sep), nil
+}
+
+// ParseCompositeKey splits a composite key value into its attribute values.
+// Constant parts must match exactly; returns attribute name → raw string value.
+// Example:
+//   values, err := ParseCompositeKey("USER{{.CompositeKeys.TableSeparator}}42", TableSchema.SecondaryIndexes[0].HashKeyParts)
+//   // values: map[user_id:42] for parts "USER" (constant) and "user_id"
+func ParseCompositeKey(value string, parts []CompositeKeyPart) (map[string]string, error) {
+    {{- if .CompositeKeys.IsEscaped}}
+    segments := splitCompositeValue(value, compositeKeySeparator(parts))
+    {{- else}}
+    segments := strings.Split(value, compositeKeySeparator(parts))
+    {{- end}}
+    if len(segments) != len(parts) {
+        return nil, fmt.Errorf("composite key %q has %d parts, expected %d", value, len(segments), len(parts))
+    }
+    values := make(map[string]string, len(parts))
+    for i, part := range parts {
+        if part.IsConstant {
+            if segments[i] != part.Value {
+                return nil, fmt.Errorf("composite key %q: part %d is %q, expected constant %q", value, i, segments[i], part.Value)
+            }
+            continue
+        }
+        values[part.Value] = segments[i]
+    }
+    return values, nil
+}
+{{- if .CompositeKeys.IsEscaped}}
+
+// escapeCompositeValue escapes backslashes and the separator in a value of a composite key part.
+func escapeCompositeValue(v, sep string) string {
+    v = strings.ReplaceAll(v, "\\", "\\\\")
+    return strings.ReplaceAll(v, sep, "\\"+sep)
+}
+
+// splitCompositeValue splits a composite key value at unescaped separators and unescapes the segments.
+func splitCompositeValue(value, sep string) []string {
+    var (
+        segments []string
+        b        strings.Builder
+    )
+    for i := 0; i < len(value); {
+        switch {
+        case value[i] == '\\' && i+1 < len(value):
+            if strings.HasPrefix(value[i+1:], sep) {
+                b.WriteString(sep)
+                i += 1 + len(sep)
+            } else {
+                b.WriteByte(value[i+1])
+                i += 2
+            }
+        case strings.HasPrefix(value[i:], sep):
+            segments = append(segments, b.String())
+            b.Reset()
+            i += len(sep)
+        default:
+            b.WriteByte(value[i])
+            i++
+        }
+    }
+    return append(segments, b.String())
+}
+{{- end}}
+`
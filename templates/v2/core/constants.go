@@ -4,16 +4,28 @@ package core
 const ConstantsTemplate = `
 const (
     // TableName is the DynamoDB table name for all operations.
+    // Example:
+    //   client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(TableName)})
     TableName = "{{.TableName}}"
+
+    // SchemaHash is the SHA-256 digest of the schema this code was generated from.
+    SchemaHash = "{{.SchemaHash}}"
+
+    // SchemaVersion is the version of the schema this code was generated from.
+    SchemaVersion = {{.SchemaVersion}}
    
     {{range .SecondaryIndexes}}
+    {{- $hashKey := .HashKey}}{{if .IsLSI}}{{$hashKey = $.HashKey}}{{end}}
     // Index{{ToSafeName .Name | ToUpperCamelCase}} is the "{{.Name}}" {{if eq .HashKey $.HashKey}}LSI{{else}}GSI{{end}} index.
+    // Keys: hash "{{$hashKey}}"{{if .RangeKey}}, range "{{.RangeKey}}"{{end}}; projection {{.ProjectionType}}.
+    // Example:
+    //   NewQueryBuilder().WithIndex(Index{{ToSafeName .Name | ToUpperCamelCase}}).With({{$.ExampleField $hashKey}}, EQ, {{$.ExampleKey $hashKey}}).Execute(ctx, client)
     Index{{ToSafeName .Name | ToUpperCamelCase}} = "{{.Name}}"
     {{- end}}
 
     {{range .AllAttributes}}
-    // Column{{ToSafeName .Name | ToUpperCamelCase}} is the "{{.Name}}" attribute name.
-    Column{{ToSafeName .Name | ToUpperCamelCase}} = "{{.Name}}"
+    // Column{{.GoName}} is the "{{.Name}}" attribute name.
+    Column{{.GoName}} = "{{.Name}}"
     {{- end}}
 )
 
@@ -0,0 +1,244 @@
+package core
+
+// CursorTemplate provides opaque cursor tokens and typed pages shared by Query and Scan
+const CursorTemplate = `
+// Page is one page of Query or Scan results.
+// NextCursor resumes after the page with StartFromCursor, it is empty on the last page.
+type Page struct {
+    Items      []SchemaItem ` + "`json:\"items\"`" + `
+    NextCursor string       ` + "`json:\"next_cursor,omitempty\"`" + `
+    HasMore    bool         ` + "`json:\"has_more\"`" + `
+}
+
+// cursorSecret is the HMAC key signing cursors, nil if cursors are not signed.
+var cursorSecret atomic.Value
+
+// SetCursorSecret makes EncodeCursor sign cursors with HMAC-SHA256 and DecodeCursor reject
+// cursors without a valid signature, so clients can't forge start keys. Call it once at startup;
+// a nil or empty secret disables signing.
+func SetCursorSecret(secret []byte) {
+    cursorSecret.Store(append([]byte(nil), secret...))
+}
+
+// EncodeCursor converts a LastEvaluatedKey to an opaque URL-safe token, empty for an empty key.
+// The token is not encrypted: key values can be read by decoding it.
+func EncodeCursor(lastEvaluatedKey map[string]types.AttributeValue) (string, error) {
+    encoded, err := encodeKeyAttributes(lastEvaluatedKey)
+    if err != nil || encoded == nil {
+        return "", err
+    }
+    payload, err := json.Marshal(encoded)
+    if err != nil {
+        return "", fmt.Errorf("failed to encode cursor: %v", err)
+    }
+    token := base64.RawURLEncoding.EncodeToString(payload)
+    if secret, _ := cursorSecret.Load().([]byte); len(secret) > 0 {
+        token += "." + base64.RawURLEncoding.EncodeToString(cursorSignature(secret, payload))
+    }
+    return token, nil
+}
+
+// DecodeCursor converts a token of EncodeCursor back to an exclusive start key, nil for an empty token.
+// Tokens with attributes which are not table or index keys, or with values of wrong types, are rejected;
+// StartFromCursor also rejects keys of another index than the one read.
+func DecodeCursor(token string) (map[string]types.AttributeValue, error) {
+    if token == "" {
+        return nil, nil
+    }
+    data, signature, signed := strings.Cut(token, ".")
+    payload, err := base64.RawURLEncoding.DecodeString(data)
+    if err != nil {
+        return nil, fmt.Errorf("invalid cursor: %v", err)
+    }
+    if secret, _ := cursorSecret.Load().([]byte); len(secret) > 0 {
+        mac, err := base64.RawURLEncoding.DecodeString(signature)
+        if !signed || err != nil || !hmac.Equal(mac, cursorSignature(secret, payload)) {
+            return nil, fmt.Errorf("invalid cursor: bad signature")
+        }
+    }
+    var encoded map[string]keyAttributeValue
+    if err := json.Unmarshal(payload, &encoded); err != nil {
+        return nil, fmt.Errorf("invalid cursor: %v", err)
+    }
+    for name, v := range encoded {
+        if !isKeyAttribute(name) {
+            return nil, fmt.Errorf("invalid cursor: '%s' is not a key attribute", name)
+        }
+        info, ok := TableSchema.FieldsMap[name]
+        if !ok {
+            continue
+        }
+        if (info.DynamoType == "S" && v.S == nil) || (info.DynamoType == "N" && v.N == nil) || (info.DynamoType == "B" && v.B == nil) {
+            return nil, fmt.Errorf("invalid cursor: attribute '%s' must be of type %s", name, info.DynamoType)
+        }
+    }
+    return decodeKeyAttributes(encoded)
+}
+
+// isKeyAttribute reports whether name is the hash or range key of the table or a secondary index.
+func isKeyAttribute(name string) bool {
+    if name == TableSchema.HashKey || (TableSchema.RangeKey != "" && name == TableSchema.RangeKey) {
+        return true
+    }
+    return isIndexKeyAttribute(name)
+}
+
+// isIndexKeyAttribute reports whether name is the hash or range key of a secondary index,
+// e.g. a composite key attribute which is not declared in the schema.
+func isIndexKeyAttribute(name string) bool {
+    for _, index := range TableSchema.SecondaryIndexes {
+        if index.HashKey == name || index.RangeKey == name {
+            return true
+        }
+    }
+    return false
+}
+
+// ErrPartialResult matches every *PartialResultError:
+//   if errors.Is(err, ErrPartialResult) { /* process items, resume later */ }
+var ErrPartialResult = errors.New("partial result")
+
+// ErrPageCapReached is wrapped by the *PartialResultError of a read stopped by MaxPages
+// before LimitResults items passed the filters.
+var ErrPageCapReached = errors.New("page cap reached")
+
+// PartialResultError reports an auto-paginating read interrupted by the cancellation or deadline
+// of its context, or stopped by its page cap. The items read before are returned with it; Cursor
+// resumes the read after them with StartFromCursor, it is empty if the read has to start over.
+type PartialResultError struct {
+    Items  int
+    Cursor string
+    Err    error
+}
+
+// Error implements error.
+func (e *PartialResultError) Error() string {
+    return fmt.Sprintf("partial result of %d items: %v", e.Items, e.Err)
+}
+
+// Unwrap returns the error of the context, context.Canceled or context.DeadlineExceeded, or ErrPageCapReached.
+func (e *PartialResultError) Unwrap() error {
+    return e.Err
+}
+
+// Is reports whether target is ErrPartialResult.
+func (e *PartialResultError) Is(target error) bool {
+    return target == ErrPartialResult
+}
+
+// keyAttributeNames returns the table key attributes and those of the index (none if empty),
+// the attributes of an exclusive start key of a read of the index.
+func keyAttributeNames(indexName string) []string {
+    names := []string{TableSchema.HashKey}
+    if TableSchema.RangeKey != "" {
+        names = append(names, TableSchema.RangeKey)
+    }
+    for _, idx := range TableSchema.SecondaryIndexes {
+        if idx.Name != indexName {
+            continue
+        }
+        for _, name := range []string{idx.HashKey, idx.RangeKey} {
+            if name != "" && !slices.Contains(names, name) {
+                names = append(names, name)
+            }
+        }
+    }
+    return names
+}
+
+// checkStartKey rejects a start key with attributes which are not keys of the table or of the index read.
+func checkStartKey(key map[string]types.AttributeValue, indexName string) error {
+    names := keyAttributeNames(indexName)
+    for name := range key {
+        if slices.Contains(names, name) {
+            continue
+        }
+        if indexName == "" {
+            return fmt.Errorf("invalid cursor: '%s' is not a key attribute of table %s", name, TableName)
+        }
+        return fmt.Errorf("invalid cursor: '%s' is not a key attribute of index %s", name, indexName)
+    }
+    return nil
+}
+
+// itemKey returns the exclusive start key resuming a read of the index (the table if empty) after item.
+func itemKey(item map[string]types.AttributeValue, indexName string) map[string]types.AttributeValue {
+    names := keyAttributeNames(indexName)
+    key := make(map[string]types.AttributeValue, len(names))
+    for _, name := range names {
+        if av, ok := item[name]; ok {
+            key[name] = av
+        }
+    }
+    return key
+}
+
+// finishPages returns the cursor resuming at startKey after a paginated read of n items.
+// A non-nil stop error (of the context or ErrPageCapReached) is returned as a *PartialResultError.
+func finishPages(n int, startKey map[string]types.AttributeValue, stop error) (string, error) {
+    cursor, err := EncodeCursor(startKey)
+    if err != nil {
+        return "", err
+    }
+    if stop != nil {
+        return cursor, &PartialResultError{Items: n, Cursor: cursor, Err: stop}
+    }
+    return cursor, nil
+}
+
+// cursorSignature returns the HMAC-SHA256 of a cursor payload.
+func cursorSignature(secret, payload []byte) []byte {
+    mac := hmac.New(sha256.New, secret)
+    mac.Write(payload)
+    return mac.Sum(nil)
+}
+
+// keyAttributeValue is the JSON form of a key attribute value: S, N or B (base64).
+type keyAttributeValue struct {
+    S *string ` + "`json:\"S,omitempty\"`" + `
+    N *string ` + "`json:\"N,omitempty\"`" + `
+    B []byte  ` + "`json:\"B,omitempty\"`" + `
+}
+
+// encodeKeyAttributes converts a key (e.g. LastEvaluatedKey) to its JSON form.
+func encodeKeyAttributes(key map[string]types.AttributeValue) (map[string]keyAttributeValue, error) {
+    if len(key) == 0 {
+        return nil, nil
+    }
+    encoded := make(map[string]keyAttributeValue, len(key))
+    for name, av := range key {
+        switch v := av.(type) {
+        case *types.AttributeValueMemberS:
+            encoded[name] = keyAttributeValue{S: aws.String(v.Value)}
+        case *types.AttributeValueMemberN:
+            encoded[name] = keyAttributeValue{N: aws.String(v.Value)}
+        case *types.AttributeValueMemberB:
+            encoded[name] = keyAttributeValue{B: v.Value}
+        default:
+            return nil, fmt.Errorf("key attribute '%s' has unsupported type %T", name, av)
+        }
+    }
+    return encoded, nil
+}
+
+// decodeKeyAttributes converts the JSON form of a key back to AttributeValues.
+func decodeKeyAttributes(encoded map[string]keyAttributeValue) (map[string]types.AttributeValue, error) {
+    if len(encoded) == 0 {
+        return nil, nil
+    }
+    key := make(map[string]types.AttributeValue, len(encoded))
+    for name, v := range encoded {
+        switch {
+        case v.S != nil:
+            key[name] = &types.AttributeValueMemberS{Value: *v.S}
+        case v.N != nil:
+            key[name] = &types.AttributeValueMemberN{Value: *v.N}
+        case v.B != nil:
+            key[name] = &types.AttributeValueMemberB{Value: v.B}
+        default:
+            return nil, fmt.Errorf("key attribute '%s' has no value", name)
+        }
+    }
+    return key, nil
+}
+`
@@ -0,0 +1,143 @@
+package core
+
+// IteratorTemplate provides the prefetching page iterator shared by Query and Scan
+const IteratorTemplate = `
+// pageFetcher reads one page starting after startKey (nil for the first page).
+type pageFetcher func(ctx context.Context, startKey map[string]types.AttributeValue) ([]SchemaItem, map[string]types.AttributeValue, error)
+
+// fetchedPage is a page read ahead by the iterator.
+type fetchedPage struct {
+    items   []SchemaItem
+    lastKey map[string]types.AttributeValue
+    err     error
+}
+
+// PageIterator reads Query or Scan results page by page.
+// The next page is fetched while the caller processes the current one, at most one page ahead,
+// hiding request latency of sequential processing. Safe for concurrent use: each page is
+// returned to exactly one caller of Next.
+// Example:
+//   it, err := NewQueryBuilder().With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).Iterate(ctx, client)
+//   if err != nil {
+//       return err
+//   }
+//   defer it.Close()
+//   for items, ok := it.Next(); ok; items, ok = it.Next() {
+//       process(items)
+//   }
+//   return it.Err()
+type PageIterator struct {
+    mu      sync.Mutex
+    pages   chan fetchedPage
+    cancel  context.CancelFunc
+    lastKey map[string]types.AttributeValue
+    err     error
+    closed  bool
+
+    // stopErr is the error of the caller's context which stopped prefetching,
+    // written before pages is closed.
+    stopErr error
+}
+
+// newPageIterator starts prefetching pages from startKey.
+func newPageIterator(ctx context.Context, startKey map[string]types.AttributeValue, fetch pageFetcher) *PageIterator {
+    prefetchCtx, cancel := context.WithCancel(ctx)
+    it := &PageIterator{
+        pages:  make(chan fetchedPage),
+        cancel: cancel,
+    }
+    go it.prefetch(ctx, prefetchCtx, startKey, fetch)
+    return it
+}
+
+// prefetch fetches pages until the results are exhausted, an error occurs or the iterator is closed.
+// The unbuffered channel blocks the fetch of page N+2 until page N+1 is taken.
+// If the caller's ctx is canceled before the last page is taken, its error is kept for Next,
+// also in place of the request error it caused: a truncated read must not look like a complete one. Close stops without an error.
+func (it *PageIterator) prefetch(ctx, prefetchCtx context.Context, startKey map[string]types.AttributeValue, fetch pageFetcher) {
+    defer close(it.pages)
+    for {
+        items, lastKey, err := fetch(prefetchCtx, startKey)
+        if err != nil && ctx.Err() != nil {
+            err = ctx.Err()
+        }
+        select {
+        case it.pages <- fetchedPage{items: items, lastKey: lastKey, err: err}:
+        case <-prefetchCtx.Done():
+            it.stopErr = ctx.Err()
+            return
+        }
+        if err != nil || len(lastKey) == 0 {
+            return
+        }
+        startKey = lastKey
+    }
+}
+
+// Next returns the next page of items, false when all pages are read, the iterator
+// is closed, a request failed or ctx was canceled (see Err).
+func (it *PageIterator) Next() ([]SchemaItem, bool) {
+    it.mu.Lock()
+    defer it.mu.Unlock()
+    if it.closed || it.err != nil {
+        return nil, false
+    }
+    page, ok := <-it.pages
+    if !ok {
+        it.err = it.stopErr
+        return nil, false
+    }
+    if page.err != nil {
+        it.err = page.err
+        return nil, false
+    }
+    it.lastKey = page.lastKey
+    return page.items, true
+}
+
+// Err returns the error which stopped the iteration, ctx.Err() if ctx was canceled before
+// the last page; nil if the results were exhausted or the iterator was closed.
+func (it *PageIterator) Err() error {
+    it.mu.Lock()
+    defer it.mu.Unlock()
+    return it.err
+}
+
+// LastEvaluatedKey returns the key after the last page returned by Next, nil after the last page.
+// Pass it to StartFrom to resume later.
+func (it *PageIterator) LastEvaluatedKey() map[string]types.AttributeValue {
+    it.mu.Lock()
+    defer it.mu.Unlock()
+    return it.lastKey
+}
+
+// Close stops prefetching. Next returns false afterwards.
+func (it *PageIterator) Close() {
+    it.cancel()
+    it.mu.Lock()
+    defer it.mu.Unlock()
+    it.closed = true
+}
+
+// iterateItems yields the items of the pages of it one by one and closes it when the loop ends.
+// An error of building the request or of a page is yielded once with a zero item and ends the loop.
+func iterateItems(it *PageIterator, err error) iter.Seq2[SchemaItem, error] {
+    return func(yield func(SchemaItem, error) bool) {
+        if err != nil {
+            yield(SchemaItem{}, err)
+            return
+        }
+        defer it.Close()
+        for items, ok := it.Next(); ok; items, ok = it.Next() {
+            for _, item := range items {
+                if !yield(item, nil) {
+                    return
+                }
+            }
+        }
+        if err := it.Err(); err != nil {
+            yield(SchemaItem{}, err)
+        }
+    }
+}
+`
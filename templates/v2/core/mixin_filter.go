@@ -34,4 +34,9 @@ func (kcm *KeyConditionMixin) WithLT(field string, value any) {
 func (kcm *KeyConditionMixin) WithLTE(field string, value any) {
     kcm.With(field, LTE, value)
 }
+
+// WithBeginsWith adds begins_with key condition for string sort keys.
+func (kcm *KeyConditionMixin) WithBeginsWith(field string, prefix string) {
+    kcm.With(field, BEGINS_WITH, prefix)
+}
 `
@@ -6,14 +6,18 @@ const MixinsTemplate = `
 // Supports all DynamoDB filter operators with type validation.
 type FilterMixin struct {
     FilterConditions  []expression.ConditionBuilder
+    FilterFields      []string    // attribute of each FilterConditions entry
+    AppliedFilters    []Condition // filters in the order they were added, replayed by UnmarshalJSON
     UsedKeys          map[string]bool
     Attributes        map[string]any
+    filterErr         error       // filter rejected by BuildConditionExpression, returned when the input is built
 }
 
 // NewFilterMixin creates a new FilterMixin instance with initialized maps.
 func NewFilterMixin() FilterMixin {
     return FilterMixin{
         FilterConditions: make([]expression.ConditionBuilder, 0),
+        FilterFields:     make([]string, 0),
         UsedKeys:         make(map[string]bool),
         Attributes:       make(map[string]any),
     }
@@ -22,6 +26,9 @@ func NewFilterMixin() FilterMixin {
 // Filter adds a filter condition using the universal operator system.
 // Validates operator compatibility and value types before adding.
 func (fm *FilterMixin) Filter(field string, op OperatorType, values ...any) {
+    {{- if .NormalizedAttributes}}
+    values = normalizeKeyValues(field, values)
+    {{- end}}
     if !ValidateValues(op, values) {
         return
     }
@@ -31,10 +38,13 @@ func (fm *FilterMixin) Filter(field string, op OperatorType, values ...any) {
 
     filterCond, err := BuildConditionExpression(field, op, values)
     if err != nil {
+        fm.filterErr = err
         return
     }
 
     fm.FilterConditions = append(fm.FilterConditions, filterCond)
+    fm.FilterFields = append(fm.FilterFields, field)
+    fm.AppliedFilters = append(fm.AppliedFilters, Condition{Field: field, Operator: op, Values: values, Type: FilterCondition})
     fm.UsedKeys[field] = true
 
     if op == EQ && len(values) == 1 {
@@ -42,10 +52,19 @@ func (fm *FilterMixin) Filter(field string, op OperatorType, values ...any) {
     }
 }
 
+// DefaultMaxPages caps the number of requests Execute sends to collect LimitResults items.
+const DefaultMaxPages = 100
+
 // PaginationMixin provides pagination support for Query and Scan operations.
 type PaginationMixin struct {
     LimitValue        *int
+    PageSizeValue     *int
+    ResultLimitValue  *int
+    MaxItemsValue     *int
+    MaxPagesValue     int
     ExclusiveStartKey map[string]types.AttributeValue
+    cursorErr         error // invalid cursor of StartFromCursor, returned when the input is built
+    fromCursor        bool  // ExclusiveStartKey is decoded from a cursor, checked against the index read
 }
 
 // NewPaginationMixin creates a new PaginationMixin instance.
@@ -53,23 +72,97 @@ func NewPaginationMixin() PaginationMixin {
     return PaginationMixin{}
 }
 
-// Limit sets the maximum number of items to return in one request.
+// Limit sets the maximum number of items to return.
+// Execute sends it as the request Limit unless PageSize is set, ExecuteAll caps the total with it.
 func (pm *PaginationMixin) Limit(limit int) {
     pm.LimitValue = &limit
 }
 
+// PageSize sets the number of items DynamoDB evaluates per request (the request Limit).
+// Tunes read capacity per call independently of how many items Limit returns.
+func (pm *PaginationMixin) PageSize(size int) {
+    pm.PageSizeValue = &size
+}
+
+// requestLimit returns the Limit sent with each request, nil for none.
+func (pm *PaginationMixin) requestLimit() *int32 {
+    switch {
+    case pm.PageSizeValue != nil:
+        return aws.Int32(int32(*pm.PageSizeValue))
+    case pm.LimitValue != nil:
+        return aws.Int32(int32(*pm.LimitValue))
+    }
+    return nil
+}
+
+// MaxItems sets the number of items ExecuteAll returns at most.
+// Unlike Limit, it doesn't change the Limit sent with each request.
+func (pm *PaginationMixin) MaxItems(maxItems int) {
+    pm.MaxItemsValue = &maxItems
+}
+
+// totalLimit returns the cap of items ExecuteAll returns, -1 for no cap.
+// MaxItems takes precedence over Limit.
+func (pm *PaginationMixin) totalLimit() int {
+    if pm.MaxItemsValue != nil {
+        return *pm.MaxItemsValue
+    }
+    if pm.LimitValue != nil {
+        return *pm.LimitValue
+    }
+    return -1
+}
+
+// LimitResults sets the number of items to return after filters are applied.
+// Unlike Limit (items evaluated per request), Execute keeps paginating until
+// limit items are collected, the data is exhausted or the page cap is reached.
+func (pm *PaginationMixin) LimitResults(limit int) {
+    pm.ResultLimitValue = &limit
+}
+
+// MaxPages sets the page cap for LimitResults, DefaultMaxPages if not positive.
+func (pm *PaginationMixin) MaxPages(pages int) {
+    pm.MaxPagesValue = pages
+}
+
+// maxPages returns the effective page cap for LimitResults.
+func (pm *PaginationMixin) maxPages() int {
+    if pm.MaxPagesValue > 0 {
+        return pm.MaxPagesValue
+    }
+    return DefaultMaxPages
+}
+
 // StartFrom sets the exclusive start key for pagination.
 // Use LastEvaluatedKey from previous response for next page.
 func (pm *PaginationMixin) StartFrom(lastEvaluatedKey map[string]types.AttributeValue) {
     pm.ExclusiveStartKey = lastEvaluatedKey
+    pm.cursorErr = nil
+    pm.fromCursor = false
+}
+
+// StartFromCursor sets the exclusive start key from a NextCursor token of a previous Page.
+// An invalid token fails the request when it is built; an empty token starts from the first page.
+func (pm *PaginationMixin) StartFromCursor(token string) {
+    pm.ExclusiveStartKey, pm.cursorErr = DecodeCursor(token)
+    pm.fromCursor = pm.ExclusiveStartKey != nil
+}
+
+// checkCursor returns the error of StartFromCursor, also if the cursor holds keys of another index than indexName.
+func (pm *PaginationMixin) checkCursor(indexName string) error {
+    if pm.cursorErr != nil || !pm.fromCursor {
+        return pm.cursorErr
+    }
+    return checkStartKey(pm.ExclusiveStartKey, indexName)
 }
 
 // KeyConditionMixin provides key condition logic for Query operations only.
 // Supports partition key and sort key conditions with automatic index selection.
 type KeyConditionMixin struct {
-    KeyConditions    map[string]expression.KeyConditionBuilder
-    SortDescending   bool
-    PreferredSortKey string
+    KeyConditions        map[string]expression.KeyConditionBuilder
+    AppliedKeyConditions []Condition // key conditions in the order they were added, replayed by UnmarshalJSON
+    SortDescending       bool
+    PreferredSortKey     string
 }
 
 // NewKeyConditionMixin creates a new KeyConditionMixin instance.
@@ -82,6 +175,9 @@ func NewKeyConditionMixin() KeyConditionMixin {
 // With adds a key condition using the universal operator system.
 // Only valid for partition and sort key attributes.
 func (kcm *KeyConditionMixin) With(field string, op OperatorType, values ...any) {
+    {{- if .NormalizedAttributes}}
+    values = normalizeKeyValues(field, values)
+    {{- end}}
     if !ValidateValues(op, values) {
         return
     }
@@ -101,6 +197,7 @@ func (kcm *KeyConditionMixin) With(field string, op OperatorType, values ...any)
         return
     }
     kcm.KeyConditions[field] = keyCond
+    kcm.AppliedKeyConditions = append(kcm.AppliedKeyConditions, Condition{Field: field, Operator: op, Values: values, Type: KeyCondition})
 }
 
 // WithPreferredSortKey sets preferred sort key for index selection.
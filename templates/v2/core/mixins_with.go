@@ -81,4 +81,50 @@ func (fm *FilterMixin) FilterNotIn(field string, values ...any) {
     }
     fm.Filter(field, NOT_IN, values...)
 }
+
+// FilterSizeEQ adds size(field) = size filter.
+// Size is the length of strings, bytes of binaries and elements of sets, lists and maps.
+func (fm *FilterMixin) FilterSizeEQ(field string, size int) {
+    fm.Filter(field, SIZE_EQ, size)
+}
+
+// FilterSizeGT adds size(field) > size filter.
+func (fm *FilterMixin) FilterSizeGT(field string, size int) {
+    fm.Filter(field, SIZE_GT, size)
+}
+
+// FilterSizeLT adds size(field) < size filter.
+func (fm *FilterMixin) FilterSizeLT(field string, size int) {
+    fm.Filter(field, SIZE_LT, size)
+}
+
+// FilterSizeGTE adds size(field) >= size filter.
+func (fm *FilterMixin) FilterSizeGTE(field string, size int) {
+    fm.Filter(field, SIZE_GTE, size)
+}
+
+// FilterSizeLTE adds size(field) <= size filter.
+func (fm *FilterMixin) FilterSizeLTE(field string, size int) {
+    fm.Filter(field, SIZE_LTE, size)
+}
+
+// FilterSizeBetween adds size(field) BETWEEN minSize AND maxSize filter, both inclusive.
+func (fm *FilterMixin) FilterSizeBetween(field string, minSize, maxSize int) {
+    fm.Filter(field, SIZE_BETWEEN, minSize, maxSize)
+}
+
+// FilterAttributeType adds attribute_type filter matching items storing field as the DynamoDB type:
+// S, SS, N, NS, B, BS, BOOL, NULL, L or M. Other types are ignored.
+func (fm *FilterMixin) FilterAttributeType(field string, attrType string) {
+    fm.Filter(field, ATTRIBUTE_TYPE, attrType)
+}
+
+// filterValues converts typed values to the values of a Filter condition.
+func filterValues[T any](values []T) []any {
+    out := make([]any, len(values))
+    for i, v := range values {
+        out[i] = v
+    }
+    return out
+}
 `
@@ -0,0 +1,89 @@
+package core
+
+// PlaceholdersTemplate provides attribute-derived expression placeholders for QueryBuilder and ScanBuilder
+const PlaceholdersTemplate = `
+// placeholderPattern matches the name (#0) and value (:0) placeholders of built expressions.
+var placeholderPattern = regexp.MustCompile("[#:][A-Za-z0-9_]+")
+
+// stablePlaceholders renames the numbered placeholders the expression builder generates after
+// the attributes they refer to, so the same request always has the same placeholders:
+// #0 becomes #<attribute>, values become :<attribute>, :<attribute>_2, ... after the attribute
+// preceding them in the expressions. Characters not allowed in placeholders are replaced by '_'.
+// The expressions are rewritten in place, the renamed names and values are returned.
+func stablePlaceholders(names map[string]string, values map[string]types.AttributeValue, exprs ...*string) (map[string]string, map[string]types.AttributeValue) {
+    renamed := make(map[string]string, len(names)+len(values))
+    taken := make(map[string]bool, len(names)+len(values))
+    unique := func(base string) string {
+        name := base
+        for i := 2; taken[name]; i++ {
+            name = fmt.Sprintf("%s_%d", base, i)
+        }
+        taken[name] = true
+        return name
+    }
+
+    placeholders := slices.Collect(maps.Keys(names))
+    slices.SortFunc(placeholders, func(a, b string) int {
+        return cmp.Or(cmp.Compare(names[a], names[b]), cmp.Compare(a, b))
+    })
+    for _, placeholder := range placeholders {
+        renamed[placeholder] = unique("#" + placeholderName(names[placeholder]))
+    }
+    for _, expr := range exprs {
+        if expr == nil {
+            continue
+        }
+        attr := "v"
+        for _, placeholder := range placeholderPattern.FindAllString(*expr, -1) {
+            if name, ok := names[placeholder]; ok {
+                attr = placeholderName(name)
+                continue
+            }
+            if _, ok := values[placeholder]; ok && renamed[placeholder] == "" {
+                renamed[placeholder] = unique(":" + attr)
+            }
+        }
+    }
+    for _, placeholder := range slices.Sorted(maps.Keys(values)) {
+        if renamed[placeholder] == "" {
+            renamed[placeholder] = unique(":v")
+        }
+    }
+
+    for _, expr := range exprs {
+        if expr != nil {
+            *expr = placeholderPattern.ReplaceAllStringFunc(*expr, func(placeholder string) string {
+                if name, ok := renamed[placeholder]; ok {
+                    return name
+                }
+                return placeholder
+            })
+        }
+    }
+    var stableNames map[string]string
+    if names != nil {
+        stableNames = make(map[string]string, len(names))
+        for placeholder, name := range names {
+            stableNames[renamed[placeholder]] = name
+        }
+    }
+    var stableValues map[string]types.AttributeValue
+    if values != nil {
+        stableValues = make(map[string]types.AttributeValue, len(values))
+        for placeholder, value := range values {
+            stableValues[renamed[placeholder]] = value
+        }
+    }
+    return stableNames, stableValues
+}
+
+// placeholderName returns the attribute name with characters not allowed in placeholders replaced by '_'.
+func placeholderName(attr string) string {
+    return strings.Map(func(r rune) rune {
+        if r == '_' || r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+            return r
+        }
+        return '_'
+    }, attr)
+}
+`
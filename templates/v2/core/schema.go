@@ -52,6 +52,19 @@ func buildAllowedOperators(dynamoType string) map[OperatorType]bool {
         allowed[EXISTS] = true
         allowed[NOT_EXISTS] = true
         
+    case "B": // Binary - compared byte-wise, no string functions
+        allowed[EQ] = true
+        allowed[NE] = true
+        allowed[GT] = true
+        allowed[LT] = true
+        allowed[GTE] = true
+        allowed[LTE] = true
+        allowed[BETWEEN] = true
+        allowed[IN] = true
+        allowed[NOT_IN] = true
+        allowed[EXISTS] = true
+        allowed[NOT_EXISTS] = true
+        
     case "BOOL": // Boolean - only equality and existence checks
         allowed[EQ] = true
         allowed[NE] = true
@@ -70,9 +83,7 @@ func buildAllowedOperators(dynamoType string) map[OperatorType]bool {
         allowed[EXISTS] = true
         allowed[NOT_EXISTS] = true
         
-    case "BS": // Binary Set - membership operations only
-        allowed[CONTAINS] = true
-        allowed[NOT_CONTAINS] = true
+    case "BS": // Binary Set - existence checks only, contains takes strings
         allowed[EXISTS] = true
         allowed[NOT_EXISTS] = true
         
@@ -95,6 +106,15 @@ func buildAllowedOperators(dynamoType string) map[OperatorType]bool {
         allowed[EXISTS] = true
         allowed[NOT_EXISTS] = true
     }
+
+    // attribute_type works with all types, size() with types having a length.
+    allowed[ATTRIBUTE_TYPE] = true
+    switch dynamoType {
+    case "S", "B", "SS", "NS", "BS", "L", "M":
+        for _, op := range sizeOperators {
+            allowed[op] = true
+        }
+    }
     return allowed
 }
 
@@ -111,8 +131,9 @@ type DynamoSchema struct {
 
 // Attribute represents a DynamoDB table attribute with its type.
 type Attribute struct {
-    Name string  // Attribute name
-    Type string  // DynamoDB type (S, N, BOOL, SS, NS, etc.)
+    Name      string  // Attribute name
+    Type      string  // DynamoDB type (S, N, BOOL, SS, NS, etc.)
+    Sensitive bool    // marked sensitive in the schema, see EncryptItem
 }
 
 // CompositeKeyPart represents a part of a composite key structure.
@@ -126,6 +147,7 @@ type CompositeKeyPart struct {
 // Supports both simple and composite key structures for advanced access patterns.
 type SecondaryIndex struct {
     Name             string
+    Type             string              // GSI or LSI
     HashKey          string
     RangeKey         string
     ProjectionType   string
@@ -136,11 +158,58 @@ type SecondaryIndex struct {
 
 // SchemaItem represents a single DynamoDB item with all table attributes.
 // All fields are properly tagged for AWS SDK marshaling/unmarshaling.
+// Example:
+//   item := SchemaItem{
+{{- range .AllAttributes}}{{if or (eq .Name $.HashKey) (eq .Name $.RangeKey)}}
+//       {{.GoName}}: {{.ExampleValue}},
+{{- end}}{{end}}
+//   }
 type SchemaItem struct {
 {{- range .AllAttributes}}
-    {{ToSafeName .Name | ToUpperCamelCase}} {{ToGolangBaseType .}} ` + "`{{ToDynamoDBStructTag .}}`" + `
+    {{.GoName}} {{.FieldType}} ` + "`{{ToDynamoDBStructTag .}}`" + `
 {{- end}}
 }
+{{- if .OptionalAttributes}}
+
+// Ptr returns a pointer to the value, e.g. to set optional attributes:
+//   item.{{(index .OptionalAttributes 0).GoName}} = Ptr({{(index .OptionalAttributes 0).FieldExampleValue}})
+func Ptr[T any](value T) *T {
+    return &value
+}
+{{- end}}
+{{- range .EnumAttributes}}
+{{- $enum := .}}
+
+// {{.EnumType}} is a value of the {{.Name}} attribute.
+type {{.EnumType}} string
+
+// Values of {{.EnumType}}.
+const (
+    {{- range .Enum}}
+    {{$enum.EnumConst .}} {{$enum.EnumType}} = {{printf "%q" .}}
+    {{- end}}
+)
+
+// {{.EnumType}}Values lists the values of {{.EnumType}} in schema order.
+var {{.EnumType}}Values = []{{.EnumType}}{ {{- range $i, $v := .Enum}}{{if $i}}, {{end}}{{$enum.EnumConst $v}}{{end -}} }
+
+// Validate returns an error if the value isn't one of {{.EnumType}}Values.
+func (v {{.EnumType}}) Validate() error {
+    if slices.Contains({{.EnumType}}Values, v) {
+        return nil
+    }
+    return fmt.Errorf("invalid {{.Name}} value %q", string(v))
+}
+{{- end}}
+{{- range .ListStructs}}
+
+// {{.ElementStruct}} is an element of the {{.Name}} list.
+type {{.ElementStruct}} struct {
+{{- range .Fields}}
+    {{.GoName}} {{.FieldType}} ` + "`{{ToDynamoDBStructTag .}}`" + `
+{{- end}}
+}
+{{- end}}
 
 // TableSchema contains the complete schema definition with pre-computed metadata.
 // Used throughout the generated code for validation and operator checking.
@@ -151,18 +220,19 @@ var TableSchema = DynamoSchema{
     
     Attributes: []Attribute{
         {{- range .Attributes}}
-        {Name: "{{.Name}}", Type: "{{.Type}}"},
+        {Name: "{{.Name}}", Type: "{{.Type}}"{{if .IsSensitive}}, Sensitive: true{{end}}},
         {{- end}}
     },
     CommonAttributes: []Attribute{
         {{- range .CommonAttributes}}
-        {Name: "{{.Name}}", Type: "{{.Type}}"},
+        {Name: "{{.Name}}", Type: "{{.Type}}"{{if .IsSensitive}}, Sensitive: true{{end}}},
         {{- end}}
     },
     SecondaryIndexes: []SecondaryIndex{
         {{- range .SecondaryIndexes}}
         {
             Name:           "{{.Name}}",
+            Type:           "{{.Type}}",
             HashKey:        "{{.HashKey}}",
             {{- if .HashKeyParts}}
             HashKeyParts: []CompositeKeyPart{
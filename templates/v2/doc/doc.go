@@ -0,0 +1,68 @@
+// Package doc provides the package-level documentation template of generated code.
+package doc
+
+// PackageDocTemplate summarizes table keys, indexes and access patterns for godoc
+const PackageDocTemplate = `
+// Package {{.PackageName}} provides typed access to the "{{.TableName}}" DynamoDB table.
+//
+// Primary key: hash "{{.HashKey}}"{{if .RangeKey}}, range "{{.RangeKey}}"{{end}}.
+// Schema version {{.SchemaVersion}}, hash {{.SchemaHash}}.
+//
+// # Access patterns
+//
+// Get, put and delete an item by its primary key:
+//
+//	key, err := KeyInputFromRaw({{.ExampleKey .HashKey}}, {{if .RangeKey}}{{.ExampleKey .RangeKey}}{{else}}nil{{end}})
+//	out, err := client.GetItem(ctx, &dynamodb.GetItemInput{TableName: aws.String(TableName), Key: key})
+//
+//	input, err := DeleteItemInputFromRaw({{.ExampleKey .HashKey}}, {{if .RangeKey}}{{.ExampleKey .RangeKey}}{{else}}nil{{end}})
+//
+// Query items of a partition:
+//
+//	items, err := NewQueryBuilder().
+//		With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).
+//		Execute(ctx, client)
+{{- range .SecondaryIndexes}}
+{{- $hashKey := .HashKey}}{{if .IsLSI}}{{$hashKey = $.HashKey}}{{end}}
+//
+// Query the "{{.Name}}" {{if .IsLSI}}LSI{{else}}GSI{{end}} (hash "{{$hashKey}}"{{if .RangeKey}}, range "{{.RangeKey}}"{{end}}, projection {{.ProjectionType}}):
+//
+//	items, err := NewQueryBuilder().
+//		WithIndex(Index{{ToSafeName .Name | ToUpperCamelCase}}).
+//		With({{$.ExampleField $hashKey}}, EQ, {{$.ExampleKey $hashKey}}).
+//		Execute(ctx, client)
+{{- end}}
+{{- if .NoScan}}
+//
+// The package is generated without ScanBuilder: every read uses a key condition of the table or an index.
+{{- else}}
+//
+// Scan the table with a filter:
+//
+//	items, err := NewScanBuilder().
+//		Filter({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).
+//		Execute(ctx, client)
+{{- end}}
+{{- with .NamedQueries}}
+//
+// # Named queries
+//
+// Approved access patterns declared in the schema:
+//
+{{- range .}}
+//   - {{.FuncName}}{{if .Description}}: {{.Description}}{{end}}
+{{- end}}
+{{- end}}
+{{- with .SensitiveAttributes}}
+//
+// # Sensitive attributes
+//
+// Marked sensitive in the schema:{{range .}} "{{.Name}}"{{end}}.
+{{- if $.KMSKeyID}}
+// KMS key: {{$.KMSKeyID}} (KMSKeyHint).
+{{- end}}
+// Non-key sensitive attributes are encrypted client-side by EncryptItem and DecryptItem
+// with an AttributeEncryptor, bound to EncryptionContext(attribute).
+{{- end}}
+package {{.PackageName}}
+`
@@ -0,0 +1,250 @@
+package example
+
+// AdminTemplate is an operator tool with get, put, query and delete commands and a flag per attribute
+const AdminTemplate = `
+{{- $pkg := .PackageName}}
+// Command {{.PackageName}}-admin inspects and fixes items of the "{{.TableName}}" table.
+// Every attribute of the schema is a flag, values are parsed by the attribute type
+// (sets are comma-separated, binaries base64, lists and maps JSON):
+//
+//	go run . get -{{.HashKey}} <value>{{if .RangeKey}} -{{.RangeKey}} <value>{{end}}
+//	go run . put [-f item.json] [-<attribute> <value>...]
+//	go run . query -<attribute> <value>... [-index <name>] [-limit <n>] [-desc]
+//	go run . delete -{{.HashKey}} <value>{{if .RangeKey}} -{{.RangeKey}} <value>{{end}}
+//
+// Items are printed and read as JSON of {{$pkg}}.SchemaItem.
+package main
+
+import (
+    "context"
+    "encoding/base64"
+    "encoding/json"
+    "flag"
+    "fmt"
+    "log"
+    "os"
+    "strconv"
+    "strings"
+
+    "github.com/aws/aws-sdk-go-v2/aws"
+    "github.com/aws/aws-sdk-go-v2/config"
+    "github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+    "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+    "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+    {{$pkg}} "{{.ImportPath}}"
+)
+
+const usage = "usage: {{.PackageName}}-admin get | put | query | delete [flags], -h for the flags of a command"
+
+func main() {
+    if len(os.Args) < 2 {
+        fmt.Fprintln(os.Stderr, usage)
+        os.Exit(2)
+    }
+    if err := run(context.Background(), os.Args[1], os.Args[2:]); err != nil {
+        log.Fatal(err)
+    }
+}
+
+// run executes a single command.
+func run(ctx context.Context, command string, args []string) error {
+    var (
+        fs     = flag.NewFlagSet(command, flag.ExitOnError)
+        values = attributeFlags(fs)
+        file   = fs.String("f", "", "put: JSON item to write, - for stdin")
+        index  = fs.String("index", "", "query: secondary index to read, selected automatically if empty")
+        limit  = fs.Int("limit", 0, "query: maximum number of items, all if 0")
+        desc   = fs.Bool("desc", false, "query: descending order of the range key")
+    )
+    if err := fs.Parse(args); err != nil {
+        return err
+    }
+
+    client, err := newClient(ctx)
+    if err != nil {
+        return err
+    }
+    switch command {
+    case "get":
+        key, err := itemKey(values)
+        if err != nil {
+            return err
+        }
+        item, err := {{$pkg}}.GetConsistent(ctx, client, key)
+        if err != nil {
+            return err
+        }
+        if item == nil {
+            return fmt.Errorf("item not found")
+        }
+        return printJSON(item)
+    case "put":
+        var item {{$pkg}}.SchemaItem
+        if err := readItem(*file, &item); err != nil {
+            return err
+        }
+        av, err := attributevalue.MarshalMap(values)
+        if err != nil {
+            return err
+        }
+        if err := attributevalue.UnmarshalMap(av, &item); err != nil {
+            return err
+        }
+        if err := {{$pkg}}.PutItem(ctx, client, item); err != nil {
+            return err
+        }
+        return printJSON(item)
+    case "query":
+        if len(values) == 0 {
+            return fmt.Errorf("query needs at least one attribute flag")
+        }
+        qb := {{$pkg}}.NewQueryBuilder()
+        for name, value := range values {
+            qb.With(name, {{$pkg}}.EQ, value)
+        }
+        if *index != "" {
+            qb.WithIndex(*index)
+        }
+        if *limit > 0 {
+            qb.Limit(*limit)
+        }
+        if *desc {
+            qb.OrderByDesc()
+        }
+        items, err := qb.ExecuteAll(ctx, client)
+        if err != nil {
+            return err
+        }
+        return printJSON(items)
+    case "delete":
+        key, err := itemKey(values)
+        if err != nil {
+            return err
+        }
+        out, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+            TableName:    aws.String({{$pkg}}.TableName),
+            Key:          key,
+            ReturnValues: types.ReturnValueAllOld,
+        })
+        if err != nil {
+            return err
+        }
+        if len(out.Attributes) == 0 {
+            return fmt.Errorf("item not found")
+        }
+        var item {{$pkg}}.SchemaItem
+        if err := attributevalue.UnmarshalMap(out.Attributes, &item); err != nil {
+            return err
+        }
+        return printJSON(item)
+    default:
+        return fmt.Errorf("unknown command %q\n%s", command, usage)
+    }
+}
+
+// attributeFlags registers a flag per attribute of the schema and returns the parsed values of the set ones.
+func attributeFlags(fs *flag.FlagSet) map[string]any {
+    values := make(map[string]any)
+    for _, attr := range []struct{ name, usage string }{
+    {{- range .AllAttributes}}
+        {"{{.Name}}", "{{.Type}} attribute \"{{.Name}}\"{{if eq .Name $.HashKey}} (hash key){{else if eq .Name $.RangeKey}} (range key){{end}}"},
+    {{- end}}
+    } {
+        name := attr.name
+        fs.Func(name, attr.usage, func(raw string) error {
+            value, err := parseValue(name, raw)
+            if err != nil {
+                return err
+            }
+            values[name] = value
+            return nil
+        })
+    }
+    return values
+}
+
+// parseValue converts a flag value to the Go type of the attribute.
+func parseValue(name, raw string) (any, error) {
+    switch {{$pkg}}.TableSchema.FieldsMap[name].DynamoType {
+    case "N":
+        return parseNumber(raw)
+    case "BOOL":
+        return strconv.ParseBool(raw)
+    case "B":
+        return base64.StdEncoding.DecodeString(raw)
+    case "SS":
+        return strings.Split(raw, ","), nil
+    case "NS":
+        var numbers []any
+        for _, part := range strings.Split(raw, ",") {
+            n, err := parseNumber(part)
+            if err != nil {
+                return nil, err
+            }
+            numbers = append(numbers, n)
+        }
+        return numbers, nil
+    case "BS":
+        var binaries [][]byte
+        for _, part := range strings.Split(raw, ",") {
+            b, err := base64.StdEncoding.DecodeString(part)
+            if err != nil {
+                return nil, err
+            }
+            binaries = append(binaries, b)
+        }
+        return binaries, nil
+    case "L", "M":
+        var value any
+        if err := json.Unmarshal([]byte(raw), &value); err != nil {
+            return nil, fmt.Errorf("invalid %s %q: %w", name, raw, err)
+        }
+        return value, nil
+    }
+    return raw, nil
+}
+
+// parseNumber converts a number to int64, or float64 if it has a fraction.
+func parseNumber(raw string) (any, error) {
+    if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+        return i, nil
+    }
+    return strconv.ParseFloat(raw, 64)
+}
+
+// itemKey returns the primary key of the item from the key attribute flags.
+func itemKey(values map[string]any) (map[string]types.AttributeValue, error) {
+    hashKey, ok := values[{{$pkg}}.TableSchema.HashKey]
+    if !ok {
+        return nil, fmt.Errorf("flag -%s is required", {{$pkg}}.TableSchema.HashKey)
+    }
+    rangeKey, ok := values[{{$pkg}}.TableSchema.RangeKey]
+    if !ok && {{$pkg}}.TableSchema.RangeKey != "" {
+        return nil, fmt.Errorf("flag -%s is required", {{$pkg}}.TableSchema.RangeKey)
+    }
+    return {{$pkg}}.KeyInputFromRaw(hashKey, rangeKey)
+}
+
+// readItem decodes a JSON item from path (stdin for "-"), item is kept if path is empty.
+func readItem(path string, item *{{$pkg}}.SchemaItem) error {
+    switch path {
+    case "":
+        return nil
+    case "-":
+        return json.NewDecoder(os.Stdin).Decode(item)
+    }
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return err
+    }
+    return json.Unmarshal(data, item)
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+    enc := json.NewEncoder(os.Stdout)
+    enc.SetIndent("", "  ")
+    return enc.Encode(v)
+}
+` + SharedTemplate
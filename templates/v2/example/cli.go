@@ -0,0 +1,84 @@
+package example
+
+// CLITemplate is a command-line tool with put, query and stream commands
+const CLITemplate = `
+// Command {{.PackageName}}-example is a command-line tool for the "{{.TableName}}" table.
+//
+//	go run . put                     write an example item
+//	go run . query <{{.KeyAttribute.Name}}>    query items by hash key
+//	go run . stream < event.json     process a DynamoDB stream event (JSON) from stdin
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "os"
+
+    "github.com/aws/aws-lambda-go/events"
+    "github.com/aws/aws-sdk-go-v2/aws"
+    "github.com/aws/aws-sdk-go-v2/config"
+    "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+    {{.PackageName}} "{{.ImportPath}}"
+)
+
+const usage = "usage: {{.PackageName}}-example put | query <{{.KeyAttribute.Name}}> | stream < event.json"
+
+func main() {
+    if len(os.Args) < 2 {
+        fmt.Fprintln(os.Stderr, usage)
+        os.Exit(2)
+    }
+    if err := run(context.Background(), os.Args[1], os.Args[2:]); err != nil {
+        log.Fatal(err)
+    }
+}
+
+// run executes a single command.
+func run(ctx context.Context, command string, args []string) error {
+    if command == "stream" {
+        var event events.DynamoDBEvent
+        if err := json.NewDecoder(os.Stdin).Decode(&event); err != nil {
+            return err
+        }
+        return handleStream(ctx, event)
+    }
+
+    client, err := newClient(ctx)
+    if err != nil {
+        return err
+    }
+    switch command {
+    case "put":
+        item := exampleItem()
+        if err := putItem(ctx, client, item); err != nil {
+            return err
+        }
+        return printJSON(item)
+    case "query":
+        if len(args) != 1 {
+            return fmt.Errorf("query needs a {{.KeyAttribute.Name}} value\n%s", usage)
+        }
+        hashKey, err := parseHashKey(args[0])
+        if err != nil {
+            return err
+        }
+        items, err := queryPartition(ctx, client, hashKey)
+        if err != nil {
+            return err
+        }
+        return printJSON(items)
+    default:
+        return fmt.Errorf("unknown command %q\n%s", command, usage)
+    }
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+    enc := json.NewEncoder(os.Stdout)
+    enc.SetIndent("", "  ")
+    return enc.Encode(v)
+}
+` + SharedTemplate
@@ -0,0 +1,96 @@
+package example
+
+// HTTPTemplate is a net/http server with item and stream endpoints
+const HTTPTemplate = `
+// Command {{.PackageName}}-example is an HTTP API for the "{{.TableName}}" table.
+//
+// Endpoints:
+//
+//	POST /items              write an item (JSON SchemaItem, example item if the body is empty)
+//	GET  /items/{hashKey}    query items by "{{.KeyAttribute.Name}}"
+//	POST /stream             process a DynamoDB stream event (JSON)
+//
+// Set ADDR to change the listen address (default ":8080").
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "log"
+    "net/http"
+    "os"
+
+    "github.com/aws/aws-lambda-go/events"
+    "github.com/aws/aws-sdk-go-v2/aws"
+    "github.com/aws/aws-sdk-go-v2/config"
+    "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+    {{.PackageName}} "{{.ImportPath}}"
+)
+
+func main() {
+    client, err := newClient(context.Background())
+    if err != nil {
+        log.Fatal(err)
+    }
+
+    addr := ":8080"
+    if v := os.Getenv("ADDR"); v != "" {
+        addr = v
+    }
+
+    mux := http.NewServeMux()
+    mux.HandleFunc("POST /items", func(w http.ResponseWriter, r *http.Request) {
+        item := exampleItem()
+        if err := json.NewDecoder(r.Body).Decode(&item); err != nil && !errors.Is(err, io.EOF) {
+            http.Error(w, err.Error(), http.StatusBadRequest)
+            return
+        }
+        if err := putItem(r.Context(), client, item); err != nil {
+            http.Error(w, err.Error(), http.StatusInternalServerError)
+            return
+        }
+        writeJSON(w, http.StatusCreated, item)
+    })
+    mux.HandleFunc("GET /items/{hashKey}", func(w http.ResponseWriter, r *http.Request) {
+        hashKey, err := parseHashKey(r.PathValue("hashKey"))
+        if err != nil {
+            http.Error(w, err.Error(), http.StatusBadRequest)
+            return
+        }
+        items, err := queryPartition(r.Context(), client, hashKey)
+        if err != nil {
+            http.Error(w, err.Error(), http.StatusInternalServerError)
+            return
+        }
+        writeJSON(w, http.StatusOK, items)
+    })
+    mux.HandleFunc("POST /stream", func(w http.ResponseWriter, r *http.Request) {
+        var event events.DynamoDBEvent
+        if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+            http.Error(w, err.Error(), http.StatusBadRequest)
+            return
+        }
+        if err := handleStream(r.Context(), event); err != nil {
+            http.Error(w, err.Error(), http.StatusInternalServerError)
+            return
+        }
+        w.WriteHeader(http.StatusNoContent)
+    })
+
+    log.Printf("listening on %s", addr)
+    log.Fatal(http.ListenAndServe(addr, mux))
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    if err := json.NewEncoder(w).Encode(v); err != nil {
+        log.Printf("write response: %v", err)
+    }
+}
+` + SharedTemplate
@@ -0,0 +1,56 @@
+package example
+
+// LambdaTemplate is a DynamoDB stream Lambda function with a local "seed" command
+const LambdaTemplate = `
+// Command {{.PackageName}}-example is a DynamoDB stream Lambda function for the "{{.TableName}}" table.
+//
+// Deployed to Lambda it logs every stream event. Run locally to write an example item and query it:
+//
+//	go run . seed
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "log"
+    "os"
+
+    "github.com/aws/aws-lambda-go/events"
+    "github.com/aws/aws-lambda-go/lambda"
+    "github.com/aws/aws-sdk-go-v2/aws"
+    "github.com/aws/aws-sdk-go-v2/config"
+    "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+    {{.PackageName}} "{{.ImportPath}}"
+)
+
+func main() {
+    if len(os.Args) > 1 && os.Args[1] == "seed" {
+        if err := seed(context.Background()); err != nil {
+            log.Fatal(err)
+        }
+        return
+    }
+    lambda.Start(handleStream)
+}
+
+// seed writes the example item and reads its partition back.
+func seed(ctx context.Context) error {
+    client, err := newClient(ctx)
+    if err != nil {
+        return err
+    }
+
+    item := exampleItem()
+    if err := putItem(ctx, client, item); err != nil {
+        return err
+    }
+    items, err := queryPartition(ctx, client, item.{{.KeyAttribute.GoName}})
+    if err != nil {
+        return err
+    }
+    log.Printf("partition %v has %d item(s)", item.{{.KeyAttribute.GoName}}, len(items))
+    return nil
+}
+` + SharedTemplate
@@ -0,0 +1,50 @@
+package example
+
+// ReadmeTemplate describes how to run the example application
+const ReadmeTemplate = `# {{.PackageName}}-example
+
+Example {{.Kind}} application for the "{{.TableName}}" DynamoDB table, generated by go-dyno {{.GeneratorVersion}}.
+
+- ` + "`{{.PackageName}}/`" + ` — generated table package (regenerate it with ` + "`godyno generate`" + `, don't edit)
+{{- if eq .Kind "admin"}}
+- ` + "`main.go`" + ` — get, put, query and delete commands with a flag per attribute
+{{- else}}
+- ` + "`main.go`" + ` — client setup, one write, one query and one stream handler to start from
+{{- end}}
+
+## Setup
+
+` + "```" + `sh
+go mod tidy
+# optional: DynamoDB Local or LocalStack instead of AWS
+export DYNAMODB_ENDPOINT=http://localhost:4566
+` + "```" + `
+
+The table must exist: hash key "{{.HashKey}}"{{if .RangeKey}}, range key "{{.RangeKey}}"{{end}}.
+
+## Run
+
+` + "```" + `sh
+{{- if eq .Kind "lambda"}}
+# write an example item and query its partition
+go run . seed
+
+# build the stream handler for the provided.al2023 runtime
+GOOS=linux GOARCH=arm64 go build -tags lambda.norpc -o bootstrap .
+{{- else if eq .Kind "http"}}
+go run .
+curl -X POST localhost:8080/items
+curl localhost:8080/items/{{.ExampleHashKeyText}}
+curl -X POST localhost:8080/stream -d @event.json
+{{- else if eq .Kind "admin"}}
+go run . get -{{.HashKey}} {{.ExampleHashKeyText}}{{if .RangeKey}} -{{.RangeKey}} <value>{{end}} > item.json
+go run . put -f item.json
+go run . query -{{.HashKey}} {{.ExampleHashKeyText}} -limit 10
+go run . delete -{{.HashKey}} {{.ExampleHashKeyText}}{{if .RangeKey}} -{{.RangeKey}} <value>{{end}}
+{{- else}}
+go run . put
+go run . query {{.ExampleHashKeyText}}
+go run . stream < event.json
+{{- end}}
+` + "```" + `
+`
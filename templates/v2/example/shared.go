@@ -0,0 +1,84 @@
+// Package example provides templates of example applications wired to generated code.
+package example
+
+// SharedTemplate provides client setup, one write, one query and one stream handler used by every example kind,
+// the admin tool uses only the client setup
+const SharedTemplate = `
+{{- $pkg := .PackageName}}
+{{- $key := .KeyAttribute}}
+// newClient creates a DynamoDB client from the default AWS config (environment, shared config or IAM role).
+// Set DYNAMODB_ENDPOINT to use DynamoDB Local or LocalStack, e.g. http://localhost:4566.
+func newClient(ctx context.Context) (*dynamodb.Client, error) {
+    cfg, err := config.LoadDefaultConfig(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+        if endpoint := os.Getenv("DYNAMODB_ENDPOINT"); endpoint != "" {
+            o.BaseEndpoint = aws.String(endpoint)
+        }
+    }), nil
+}
+{{- if ne .Kind "admin"}}
+
+// exampleItem returns a sample item of the "{{.TableName}}" table.
+func exampleItem() {{$pkg}}.SchemaItem {
+    return {{$pkg}}.SchemaItem{
+    {{- range .Attributes}}{{if and (ne .ExampleValue "nil") (not .IsOptional) (not .HasCustomGoType)}}
+        {{.GoName}}: {{.ExampleValue}},
+    {{- end}}{{end}}
+    }
+}
+
+// putItem writes item to the table.
+func putItem(ctx context.Context, client *dynamodb.Client, item {{$pkg}}.SchemaItem) error {
+    av, err := {{$pkg}}.ItemInput(item)
+    if err != nil {
+        return err
+    }
+    _, err = client.PutItem(ctx, &dynamodb.PutItemInput{
+        TableName: aws.String({{$pkg}}.TableName),
+        Item:      av,
+    })
+    return err
+}
+
+// queryPartition returns all items with the given "{{$key.Name}}" hash key.
+func queryPartition(ctx context.Context, client *dynamodb.Client, hashKey {{ToGolangBaseType $key}}) ([]{{$pkg}}.SchemaItem, error) {
+    return {{$pkg}}.NewQueryBuilder().
+        With({{$pkg}}.Column{{$key.GoName}}, {{$pkg}}.EQ, hashKey).
+        Execute(ctx, client)
+}
+{{if or (eq .Kind "cli") (eq .Kind "http")}}
+// parseHashKey converts a "{{$key.Name}}" value from text (command line, URL path).
+func parseHashKey(raw string) ({{ToGolangBaseType $key}}, error) {
+{{- if eq (ToGolangBaseType $key) "string"}}
+    return raw, nil
+{{- else if eq (ToGolangBaseType $key) "[]byte"}}
+    return []byte(raw), nil
+{{- else}}
+    var value {{ToGolangBaseType $key}}
+    if err := json.Unmarshal([]byte(raw), &value); err != nil {
+        return value, fmt.Errorf("invalid {{$key.Name}} %q: %w", raw, err)
+    }
+    return value, nil
+{{- end}}
+}
+{{end}}
+// handleStream processes DynamoDB stream events of the table: log every change.
+var handleStream = {{$pkg}}.CreateTriggerHandler(
+    func(ctx context.Context, item *{{$pkg}}.SchemaItem) error {
+        log.Printf("INSERT %+v", item)
+        return nil
+    },
+    func(ctx context.Context, oldItem, newItem *{{$pkg}}.SchemaItem) error {
+        log.Printf("MODIFY %+v -> %+v", oldItem, newItem)
+        return nil
+    },
+    func(ctx context.Context, keys map[string]events.DynamoDBAttributeValue) error {
+        log.Printf("REMOVE %v", keys)
+        return nil
+    },
+)
+{{- end}}
+`
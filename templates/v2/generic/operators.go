@@ -30,8 +30,28 @@ const (
     // Existence operators - work with all types
     EXISTS     OperatorType = "attribute_exists"
     NOT_EXISTS OperatorType = "attribute_not_exists"
+
+    // Size operators compare size(): length of strings, bytes of binaries, elements of sets, lists and maps
+    SIZE_EQ      OperatorType = "size ="
+    SIZE_GT      OperatorType = "size >"
+    SIZE_LT      OperatorType = "size <"
+    SIZE_GTE     OperatorType = "size >="
+    SIZE_LTE     OperatorType = "size <="
+    SIZE_BETWEEN OperatorType = "size BETWEEN"
+
+    // Type operator checks the DynamoDB type of the stored value - works with all types
+    ATTRIBUTE_TYPE OperatorType = "attribute_type"
 )
 
+// sizeOperators are the operators on size(), allowed for types with a length.
+var sizeOperators = []OperatorType{SIZE_EQ, SIZE_GT, SIZE_LT, SIZE_GTE, SIZE_LTE, SIZE_BETWEEN}
+
+// attributeTypes are the DynamoDB types ATTRIBUTE_TYPE accepts.
+var attributeTypes = map[string]bool{
+    "S": true, "SS": true, "N": true, "NS": true, "B": true, "BS": true,
+    "BOOL": true, "NULL": true, "L": true, "M": true,
+}
+
 // ConditionType defines whether this is a key condition or filter condition.
 // Key conditions are used in Query operations, filters in both Query and Scan.
 type ConditionType string
@@ -43,10 +63,10 @@ const (
 
 // Condition represents a single query or filter condition with validation metadata.
 type Condition struct {
-    Field     string         // Attribute name
-    Operator  OperatorType   // Operation type
-    Values    []any          // Operation values
-    Type      ConditionType  // Key or filter condition
+    Field     string         ` + "`json:\"field\"`" + `    // Attribute name
+    Operator  OperatorType   ` + "`json:\"operator\"`" + ` // Operation type
+    Values    []any          ` + "`json:\"values\"`" + `   // Operation values
+    Type      ConditionType  ` + "`json:\"type\"`" + `     // Key or filter condition
 }
 
 // Type-safe handler functions for different expression types.
@@ -74,6 +94,9 @@ var keyOperatorHandlers = map[OperatorType]KeyOperatorHandler{
     BETWEEN: func(field expression.KeyBuilder, values []any) expression.KeyConditionBuilder {
         return field.Between(expression.Value(values[0]), expression.Value(values[1]))
     },
+    BEGINS_WITH: func(field expression.KeyBuilder, values []any) expression.KeyConditionBuilder {
+        return field.BeginsWith(fmt.Sprintf("%v", values[0]))
+    },
 }
 
 // allowedKeyConditionOperators defines operators valid for key conditions.
@@ -83,8 +106,9 @@ var allowedKeyConditionOperators = map[OperatorType]bool{
     GT:      true,
     LT:      true,
     GTE:     true,
-    LTE:     true,
-    BETWEEN: true,
+    LTE:         true,
+    BETWEEN:     true,
+    BEGINS_WITH: true,
 }
 
 // Includes all operators supported in filter expressions.
@@ -128,11 +152,7 @@ var conditionOperatorHandlers = map[OperatorType]ConditionOperatorHandler{
         if len(values) == 1 {
             return field.Equal(expression.Value(values[0]))
         }
-        operands := make([]expression.OperandBuilder, len(values))
-        for i, v := range values {
-            operands[i] = expression.Value(v)
-        }
-        return field.In(operands[0], operands[1:]...)
+        return inCondition(field, values)
     },
     NOT_IN: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
         if len(values) == 0 {
@@ -141,11 +161,7 @@ var conditionOperatorHandlers = map[OperatorType]ConditionOperatorHandler{
         if len(values) == 1 {
             return field.NotEqual(expression.Value(values[0]))
         }
-        operands := make([]expression.OperandBuilder, len(values))
-        for i, v := range values {
-            operands[i] = expression.Value(v)
-        }
-        return expression.Not(field.In(operands[0], operands[1:]...))
+        return expression.Not(inCondition(field, values))
     },
     
     EXISTS: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
@@ -154,6 +170,58 @@ var conditionOperatorHandlers = map[OperatorType]ConditionOperatorHandler{
     NOT_EXISTS: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
         return expression.AttributeNotExists(field)
     },
+
+    SIZE_EQ: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+        return field.Size().Equal(expression.Value(values[0]))
+    },
+    SIZE_GT: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+        return field.Size().GreaterThan(expression.Value(values[0]))
+    },
+    SIZE_LT: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+        return field.Size().LessThan(expression.Value(values[0]))
+    },
+    SIZE_GTE: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+        return field.Size().GreaterThanEqual(expression.Value(values[0]))
+    },
+    SIZE_LTE: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+        return field.Size().LessThanEqual(expression.Value(values[0]))
+    },
+    SIZE_BETWEEN: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+        return field.Size().Between(expression.Value(values[0]), expression.Value(values[1]))
+    },
+
+    ATTRIBUTE_TYPE: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+        return field.AttributeType(expression.DynamoDBAttributeType(fmt.Sprintf("%v", values[0])))
+    },
+}
+
+// maxInOperands is the largest operand list of one IN comparison accepted by DynamoDB.
+// Longer lists are split into IN comparisons joined with OR; the whole expression
+// still has to fit the 4 KB expression limit.
+const maxInOperands = 100
+
+// MaxInValues is the largest value list of IN and NOT_IN conditions, longer lists are rejected.
+// With numbered placeholders a list of this size takes less than half of the 4 KB expression limit;
+// WithStablePlaceholders and long attribute names make it longer. Query larger sets in several
+// requests, or use BatchGetItems when the values are keys.
+const MaxInValues = 300
+
+// inCondition returns the IN comparison of field with at least two values,
+// split into OR-ed comparisons of at most maxInOperands values.
+func inCondition(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+    var chunks []expression.ConditionBuilder
+    for start := 0; start < len(values); start += maxInOperands {
+        chunk := values[start:min(start+maxInOperands, len(values))]
+        operands := make([]expression.OperandBuilder, len(chunk))
+        for i, v := range chunk {
+            operands[i] = expression.Value(v)
+        }
+        chunks = append(chunks, field.In(operands[0], operands[1:]...))
+    }
+    if len(chunks) == 1 {
+        return chunks[0]
+    }
+    return expression.Or(chunks[0], chunks[1], chunks[2:]...)
 }
 
 // ValidateValues checks if the number of values is correct for the operator.
@@ -162,7 +230,11 @@ func ValidateValues(op OperatorType, values []any) bool {
     switch op {
     case EQ, NE, GT, LT, GTE, LTE, CONTAINS, NOT_CONTAINS, BEGINS_WITH:
         return len(values) == 1
-    case BETWEEN:
+    case SIZE_EQ, SIZE_GT, SIZE_LT, SIZE_GTE, SIZE_LTE:
+        return len(values) == 1
+    case ATTRIBUTE_TYPE:
+        return len(values) == 1 && attributeTypes[fmt.Sprintf("%v", values[0])]
+    case BETWEEN, SIZE_BETWEEN:
         return len(values) == 2
     case IN, NOT_IN:
         return len(values) >= 1
@@ -199,7 +271,10 @@ func BuildConditionExpression(field string, op OperatorType, values []any) (expr
         return expression.ConditionBuilder{}, fmt.Errorf("operator %s not supported for field %s (type %s)", op, field, fieldInfo.DynamoType)
     }
     if !ValidateValues(op, values) {
-        return expression.ConditionBuilder{}, fmt.Errorf("invalid number of values for operator %s", op)
+        return expression.ConditionBuilder{}, fmt.Errorf("invalid values for operator %s", op)
+    }
+    if (op == IN || op == NOT_IN) && len(values) > MaxInValues {
+        return expression.ConditionBuilder{}, fmt.Errorf("operator %s on field %s: %d values exceed MaxInValues (%d)", op, field, len(values), MaxInValues)
     }
 
     handler := conditionOperatorHandlers[op]
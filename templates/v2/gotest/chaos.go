@@ -0,0 +1,273 @@
+package gotest
+
+// ChaosClientTemplate provides a fault-injection decorator for resilience tests of the generated code
+const ChaosClientTemplate = `
+// ChaosPolicy configures fault injection for ChaosClient.
+// Rates are probabilities in the [0, 1] range; the same Seed always produces the same fault sequence.
+type ChaosPolicy struct {
+    Seed             int64            // Seed for the pseudo-random fault sequence
+    ThrottleRate     float64          // Probability of failing a call with ProvisionedThroughputExceededException
+    PartialBatchRate float64          // Probability of moving each batch request into Unprocessed items/keys
+    LatencyRate      float64          // Probability of delaying a call
+    MinLatency       time.Duration    // Lower bound of injected latency
+    MaxLatency       time.Duration    // Upper bound of injected latency
+    Operations       map[string]bool  // Operations to affect (e.g. "Query"); empty means all
+}
+
+// ChaosClient decorates DynamoDBAPI and injects throttles, partial batch failures and latency.
+// Rendered into the companion test file only, so it never reaches production builds.
+type ChaosClient struct {
+    client DynamoDBAPI
+    policy ChaosPolicy
+    mu     sync.Mutex
+    rnd    *rand.Rand
+}
+
+// NewChaosClient wraps client with the given fault-injection policy.
+func NewChaosClient(client DynamoDBAPI, policy ChaosPolicy) *ChaosClient {
+    return &ChaosClient{
+        client: client,
+        policy: policy,
+        rnd:    rand.New(rand.NewSource(policy.Seed)),
+    }
+}
+
+// GetItem forwards the GetItem call with fault injection.
+func (cc *ChaosClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+    if err := cc.inject(ctx, "GetItem"); err != nil {
+        return nil, err
+    }
+    return cc.client.GetItem(ctx, params, optFns...)
+}
+
+// PutItem forwards the PutItem call with fault injection.
+func (cc *ChaosClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+    if err := cc.inject(ctx, "PutItem"); err != nil {
+        return nil, err
+    }
+    return cc.client.PutItem(ctx, params, optFns...)
+}
+
+// UpdateItem forwards the UpdateItem call with fault injection.
+func (cc *ChaosClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+    if err := cc.inject(ctx, "UpdateItem"); err != nil {
+        return nil, err
+    }
+    return cc.client.UpdateItem(ctx, params, optFns...)
+}
+
+// DeleteItem forwards the DeleteItem call with fault injection.
+func (cc *ChaosClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+    if err := cc.inject(ctx, "DeleteItem"); err != nil {
+        return nil, err
+    }
+    return cc.client.DeleteItem(ctx, params, optFns...)
+}
+
+// Query forwards the Query call with fault injection.
+func (cc *ChaosClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+    if err := cc.inject(ctx, "Query"); err != nil {
+        return nil, err
+    }
+    return cc.client.Query(ctx, params, optFns...)
+}
+
+{{- if not .NoScan}}
+
+// Scan forwards the Scan call with fault injection.
+func (cc *ChaosClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+    if err := cc.inject(ctx, "Scan"); err != nil {
+        return nil, err
+    }
+    return cc.client.Scan(ctx, params, optFns...)
+}
+{{- end}}
+
+// BatchGetItem forwards the BatchGetItem call and moves a random subset of keys into UnprocessedKeys.
+func (cc *ChaosClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+    if err := cc.inject(ctx, "BatchGetItem"); err != nil {
+        return nil, err
+    }
+    if !cc.affects("BatchGetItem") || cc.policy.PartialBatchRate <= 0 {
+        return cc.client.BatchGetItem(ctx, params, optFns...)
+    }
+    forwarded := make(map[string]types.KeysAndAttributes, len(params.RequestItems))
+    unprocessed := make(map[string]types.KeysAndAttributes)
+    for table, ka := range params.RequestItems {
+        var keep, drop []map[string]types.AttributeValue
+        for _, key := range ka.Keys {
+            if cc.roll(cc.policy.PartialBatchRate) {
+                drop = append(drop, key)
+            } else {
+                keep = append(keep, key)
+            }
+        }
+        if len(keep) > 0 {
+            kept := ka
+            kept.Keys = keep
+            forwarded[table] = kept
+        }
+        if len(drop) > 0 {
+            dropped := ka
+            dropped.Keys = drop
+            unprocessed[table] = dropped
+        }
+    }
+    out := &dynamodb.BatchGetItemOutput{Responses: map[string][]map[string]types.AttributeValue{}}
+    if len(forwarded) > 0 {
+        input := *params
+        input.RequestItems = forwarded
+        res, err := cc.client.BatchGetItem(ctx, &input, optFns...)
+        if err != nil {
+            return nil, err
+        }
+        out = res
+    }
+    for table, ka := range unprocessed {
+        if out.UnprocessedKeys == nil {
+            out.UnprocessedKeys = make(map[string]types.KeysAndAttributes)
+        }
+        existing := out.UnprocessedKeys[table]
+        ka.Keys = append(existing.Keys, ka.Keys...)
+        out.UnprocessedKeys[table] = ka
+    }
+    return out, nil
+}
+
+// BatchWriteItem forwards the BatchWriteItem call and moves a random subset of requests into UnprocessedItems.
+func (cc *ChaosClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+    if err := cc.inject(ctx, "BatchWriteItem"); err != nil {
+        return nil, err
+    }
+    if !cc.affects("BatchWriteItem") || cc.policy.PartialBatchRate <= 0 {
+        return cc.client.BatchWriteItem(ctx, params, optFns...)
+    }
+    forwarded := make(map[string][]types.WriteRequest, len(params.RequestItems))
+    unprocessed := make(map[string][]types.WriteRequest)
+    for table, requests := range params.RequestItems {
+        for _, req := range requests {
+            if cc.roll(cc.policy.PartialBatchRate) {
+                unprocessed[table] = append(unprocessed[table], req)
+            } else {
+                forwarded[table] = append(forwarded[table], req)
+            }
+        }
+    }
+    out := &dynamodb.BatchWriteItemOutput{}
+    if len(forwarded) > 0 {
+        input := *params
+        input.RequestItems = forwarded
+        res, err := cc.client.BatchWriteItem(ctx, &input, optFns...)
+        if err != nil {
+            return nil, err
+        }
+        out = res
+    }
+    for table, requests := range unprocessed {
+        if out.UnprocessedItems == nil {
+            out.UnprocessedItems = make(map[string][]types.WriteRequest)
+        }
+        out.UnprocessedItems[table] = append(out.UnprocessedItems[table], requests...)
+    }
+    return out, nil
+}
+
+// TransactGetItems forwards the TransactGetItems call with fault injection.
+func (cc *ChaosClient) TransactGetItems(ctx context.Context, params *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error) {
+    if err := cc.inject(ctx, "TransactGetItems"); err != nil {
+        return nil, err
+    }
+    return cc.client.TransactGetItems(ctx, params, optFns...)
+}
+
+// TransactWriteItems forwards the TransactWriteItems call with fault injection.
+func (cc *ChaosClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+    if err := cc.inject(ctx, "TransactWriteItems"); err != nil {
+        return nil, err
+    }
+    return cc.client.TransactWriteItems(ctx, params, optFns...)
+}
+
+// DescribeTable forwards the DescribeTable call with fault injection.
+func (cc *ChaosClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+    if err := cc.inject(ctx, "DescribeTable"); err != nil {
+        return nil, err
+    }
+    return cc.client.DescribeTable(ctx, params, optFns...)
+}
+
+// DescribeTimeToLive forwards the DescribeTimeToLive call with fault injection.
+func (cc *ChaosClient) DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+    if err := cc.inject(ctx, "DescribeTimeToLive"); err != nil {
+        return nil, err
+    }
+    return cc.client.DescribeTimeToLive(ctx, params, optFns...)
+}
+
+// ListTagsOfResource forwards the ListTagsOfResource call with fault injection.
+func (cc *ChaosClient) ListTagsOfResource(ctx context.Context, params *dynamodb.ListTagsOfResourceInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error) {
+    if err := cc.inject(ctx, "ListTagsOfResource"); err != nil {
+        return nil, err
+    }
+    return cc.client.ListTagsOfResource(ctx, params, optFns...)
+}
+
+// inject applies latency and throttling faults for the given operation.
+func (cc *ChaosClient) inject(ctx context.Context, operation string) error {
+    if !cc.affects(operation) {
+        return nil
+    }
+    if cc.roll(cc.policy.LatencyRate) {
+        if err := sleepContext(ctx, cc.latency()); err != nil {
+            return err
+        }
+    }
+    if cc.roll(cc.policy.ThrottleRate) {
+        return &types.ProvisionedThroughputExceededException{
+            Message: aws.String(fmt.Sprintf("chaos: injected throttle for %s", operation)),
+        }
+    }
+    return nil
+}
+
+// affects reports whether the policy applies to the given operation.
+func (cc *ChaosClient) affects(operation string) bool {
+    return len(cc.policy.Operations) == 0 || cc.policy.Operations[operation]
+}
+
+// roll returns true with the given probability.
+func (cc *ChaosClient) roll(rate float64) bool {
+    if rate <= 0 {
+        return false
+    }
+    cc.mu.Lock()
+    defer cc.mu.Unlock()
+    return cc.rnd.Float64() < rate
+}
+
+// latency returns a random duration between MinLatency and MaxLatency.
+func (cc *ChaosClient) latency() time.Duration {
+    spread := cc.policy.MaxLatency - cc.policy.MinLatency
+    if spread <= 0 {
+        return cc.policy.MinLatency
+    }
+    cc.mu.Lock()
+    defer cc.mu.Unlock()
+    return cc.policy.MinLatency + time.Duration(cc.rnd.Int63n(int64(spread)))
+}
+
+// sleepContext waits for the given duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+    if d <= 0 {
+        return nil
+    }
+    timer := time.NewTimer(d)
+    defer timer.Stop()
+    select {
+    case <-ctx.Done():
+        return ctx.Err()
+    case <-timer.C:
+        return nil
+    }
+}
+`
@@ -0,0 +1,80 @@
+package gotest
+
+// FuzzTestsTemplate provides fuzz targets for composite key parsing and item unmarshaling
+const FuzzTestsTemplate = `
+// FuzzParseCompositeKey probes composite key parsing with arbitrary values.
+// Values without the separator must round-trip; any input must never panic.
+func FuzzParseCompositeKey(f *testing.F) {
+    f.Add("user", "42")
+    f.Add("a#b", "")
+    f.Add("\xff\xfe", "99999999999999999999999999")
+    f.Fuzz(func(t *testing.T, first, second string) {
+        parts := []CompositeKeyPart{
+            {IsConstant: true, Value: "FUZZ"},
+            {Value: "first"},
+            {Value: "second"},
+        }
+        values := map[string]string{"first": first, "second": second}
+        key, err := BuildCompositeKey(parts, values)
+        if err != nil {
+            if !strings.Contains(first+second, CompositeKeySeparator) {
+                t.Fatalf("BuildCompositeKey failed for values without separator: %v", err)
+            }
+            _, _ = ParseCompositeKey(first+CompositeKeySeparator+second, parts)
+            return
+        }
+        parsed, err := ParseCompositeKey(key, parts)
+        if err != nil {
+            t.Fatalf("ParseCompositeKey(%q) failed: %v", key, err)
+        }
+        if !reflect.DeepEqual(parsed, values) {
+            t.Fatalf("round-trip mismatch: got %v, want %v", parsed, values)
+        }
+        {{- range $i, $idx := .SecondaryIndexes}}
+        {{- if $idx.HashKeyParts}}
+        _, _ = ParseCompositeKey(key, TableSchema.SecondaryIndexes[{{$i}}].HashKeyParts)
+        {{- end}}
+        {{- if $idx.RangeKeyParts}}
+        _, _ = ParseCompositeKey(key, TableSchema.SecondaryIndexes[{{$i}}].RangeKeyParts)
+        {{- end}}
+        {{- end}}
+    })
+}
+
+// FuzzUnmarshalItem probes UnmarshalItem with arbitrary attribute values.
+// Invalid numbers, huge numbers and invalid UTF-8 must yield errors, never panics.
+// Unmarshaled items must marshal again.
+func FuzzUnmarshalItem(f *testing.F) {
+    f.Add("value", "42", true)
+    f.Add("", "1e400", false)
+    f.Add("\xff", "-99999999999999999999999999999999999999", true)
+    f.Fuzz(func(t *testing.T, s string, n string, b bool) {
+        av := map[string]types.AttributeValue{
+            {{- range .AllAttributes}}
+            {{- if eq .Type "S"}}
+            "{{.Name}}": &types.AttributeValueMemberS{Value: s},
+            {{- else if eq .Type "N"}}
+            "{{.Name}}": &types.AttributeValueMemberN{Value: n},
+            {{- else if eq .Type "BOOL"}}
+            "{{.Name}}": &types.AttributeValueMemberBOOL{Value: b},
+            {{- else if eq .Type "SS"}}
+            "{{.Name}}": &types.AttributeValueMemberSS{Value: []string{s, n}},
+            {{- else if eq .Type "NS"}}
+            "{{.Name}}": &types.AttributeValueMemberNS{Value: []string{n}},
+            {{- else if eq .Type "B"}}
+            "{{.Name}}": &types.AttributeValueMemberB{Value: []byte(s)},
+            {{- else if eq .Type "BS"}}
+            "{{.Name}}": &types.AttributeValueMemberBS{Value: [][]byte{[]byte(s)}},
+            {{- end}}
+            {{- end}}
+        }
+        item, err := UnmarshalItem(av)
+        if err != nil {
+            return
+        }
+        if _, err := ToAttributeValues(*item); err != nil {
+            t.Fatalf("ToAttributeValues failed for unmarshaled item: %v", err)
+        }
+    })
+}
+`
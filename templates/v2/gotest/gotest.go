@@ -0,0 +1,2 @@
+// Package gotest provides templates for generated Go test files.
+package gotest
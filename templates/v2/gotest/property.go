@@ -0,0 +1,178 @@
+package gotest
+
+// PropertyTestsTemplate provides property-based marshal round-trip tests
+const PropertyTestsTemplate = `
+// TestSchemaItemRoundTrip checks that random valid SchemaItems survive ItemInput → UnmarshalMap.
+// Catches type-mapping regressions between the schema and the generated struct tags.
+func TestSchemaItemRoundTrip(t *testing.T) {
+    {{- if .RuleAttributes}}
+    // Random items ignore the validation rules of the schema.
+    defer func(validate bool) { ValidateOnPut = validate }(ValidateOnPut)
+    ValidateOnPut = false
+    {{- end}}
+    {{- if .DefaultAttributes}}
+    // Unset attributes of random items stay unset.
+    defer func(apply bool) { ApplyDefaults = apply }(ApplyDefaults)
+    ApplyDefaults = false
+    {{- end}}
+    cfg := &quick.Config{
+        MaxCount: 500,
+        Values: func(values []reflect.Value, r *rand.Rand) {
+            values[0] = reflect.ValueOf(randomSchemaItem(r))
+        },
+    }
+    roundTrip := func(item SchemaItem) bool {
+        av, err := ItemInput(item)
+        if err != nil {
+            t.Logf("ItemInput failed: %v", err)
+            return false
+        }
+        var decoded SchemaItem
+        if err := attributevalue.UnmarshalMap(av, &decoded); err != nil {
+            t.Logf("UnmarshalMap failed: %v", err)
+            return false
+        }
+        {{- if .AuditAttributes}}
+        // Audit timestamps are set on write.
+        {{- range .AuditAttributes}}
+        decoded.{{.GoName}} = item.{{.GoName}}
+        {{- end}}
+        {{- end}}
+        {{- with .ChecksumAttribute}}
+        // The checksum is computed on write.
+        decoded.{{.GoName}} = item.{{.GoName}}
+        {{- end}}
+        {{- with .FieldVersionsAttribute}}
+        // Field versions are initialized on write.
+        decoded.{{.GoName}} = item.{{.GoName}}
+        {{- end}}
+        return reflect.DeepEqual(item, decoded)
+    }
+    if err := quick.Check(roundTrip, cfg); err != nil {
+        t.Error(err)
+    }
+}
+
+// TestKeyInputRoundTrip checks that KeyInput always contains exactly the primary key attributes.
+func TestKeyInputRoundTrip(t *testing.T) {
+    cfg := &quick.Config{
+        MaxCount: 200,
+        Values: func(values []reflect.Value, r *rand.Rand) {
+            values[0] = reflect.ValueOf(randomSchemaItem(r))
+        },
+    }
+    keyOnly := func(item SchemaItem) bool {
+        key, err := KeyInput(item)
+        if err != nil {
+            t.Logf("KeyInput failed: %v", err)
+            return false
+        }
+        if _, ok := key[TableSchema.HashKey]; !ok {
+            return false
+        }
+        if TableSchema.RangeKey != "" {
+            if _, ok := key[TableSchema.RangeKey]; !ok {
+                return false
+            }
+            return len(key) == 2
+        }
+        return len(key) == 1
+    }
+    if err := quick.Check(keyOnly, cfg); err != nil {
+        t.Error(err)
+    }
+}
+
+// randomSchemaItem builds a SchemaItem with random values valid for every attribute type.
+// Attributes without a random generator (L, M, NULL, go_types other than time.Time) keep their zero value.
+func randomSchemaItem(r *rand.Rand) SchemaItem {
+    var item SchemaItem
+    {{- range .AllAttributes}}
+    {{- $t := .BaseFieldType}}
+    {{- $v := ""}}
+    {{- if and (eq $t "time.Time") (eq .Type "N")}}
+    {{- $v = "time.Unix(r.Int63n(1<<32), 0)"}}
+    {{- else if eq $t "time.Time"}}
+    {{- $v = "time.Unix(r.Int63n(1<<32), r.Int63n(1e9)).UTC()"}}
+    {{- else if .HasCustomGoType}}
+    {{- else if .IsEnum}}
+    {{- $v = printf "%sValues[r.Intn(len(%sValues))]" .EnumType .EnumType}}
+    {{- else if eq $t "string"}}
+    {{- $v = "randomString(r)"}}
+    {{- else if eq $t "bool"}}
+    {{- $v = "r.Intn(2) == 1"}}
+    {{- else if or (eq $t "int") (eq $t "int8") (eq $t "int16") (eq $t "int32") (eq $t "int64")}}
+    {{- $v = printf "%s(r.Int63())" $t}}
+    {{- else if or (eq $t "uint") (eq $t "uint8") (eq $t "uint16") (eq $t "uint32") (eq $t "uint64")}}
+    {{- $v = printf "%s(r.Uint64())" $t}}
+    {{- else if eq $t "float64"}}
+    {{- $v = "r.NormFloat64() * 1e6"}}
+    {{- else if eq $t "float32"}}
+    {{- $v = "float32(r.NormFloat64() * 1e3)"}}
+    {{- else if eq $t "[]string"}}
+    {{- $v = "randomSlice(r, func() string { return randomString(r) })"}}
+    {{- else if eq $t "[]byte"}}
+    {{- $v = "randomBytes(r)"}}
+    {{- else if eq $t "[][]byte"}}
+    {{- $v = "randomSlice(r, func() []byte { return randomBytes(r) })"}}
+    {{- else if or (eq $t "[]int") (eq $t "[]int8") (eq $t "[]int16") (eq $t "[]int32") (eq $t "[]int64")}}
+    {{- $v = printf "randomSlice(r, func() %s { return %s(r.Int63()) })" (Slice $t 2) (Slice $t 2)}}
+    {{- else if or (eq $t "[]uint") (eq $t "[]uint8") (eq $t "[]uint16") (eq $t "[]uint32") (eq $t "[]uint64")}}
+    {{- $v = printf "randomSlice(r, func() %s { return %s(r.Uint64()) })" (Slice $t 2) (Slice $t 2)}}
+    {{- else if or (eq $t "[]float32") (eq $t "[]float64")}}
+    {{- $v = printf "randomSlice(r, func() %s { return %s(r.NormFloat64() * 1e3) })" (Slice $t 2) (Slice $t 2)}}
+    {{- end}}
+    {{- if and $v .IsOptional}}
+    item.{{.GoName}} = randomOptional(r, func() {{$t}} { return {{$v}} })
+    {{- else if $v}}
+    item.{{.GoName}} = {{$v}}
+    {{- end}}
+    {{- end}}
+    {{- if .NormalizedAttributes}}
+    // Written items hold normalized keys.
+    normalizeKeys(&item)
+    {{- end}}
+    {{- if .ComputedAttributes}}
+    // Written items hold recalculated computed attributes.
+    computeAttributes(&item)
+    {{- end}}
+    return item
+}
+
+// randomString returns a non-empty alphanumeric string.
+func randomString(r *rand.Rand) string {
+    const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+    b := make([]byte, 1+r.Intn(32))
+    for i := range b {
+        b[i] = alphabet[r.Intn(len(alphabet))]
+    }
+    return string(b)
+}
+
+// randomBytes returns a non-empty random byte slice.
+func randomBytes(r *rand.Rand) []byte {
+    b := make([]byte, 1+r.Intn(32))
+    r.Read(b)
+    return b
+}
+{{- if .OptionalAttributes}}
+
+// randomOptional returns nil or a pointer to a value of gen, absent and present attributes alike.
+func randomOptional[T any](r *rand.Rand, gen func() T) *T {
+    if r.Intn(2) == 0 {
+        return nil
+    }
+    value := gen()
+    return &value
+}
+{{- end}}
+
+// randomSlice returns a non-empty slice filled by gen.
+func randomSlice[T any](r *rand.Rand, gen func() T) []T {
+    out := make([]T, 1+r.Intn(5))
+    for i := range out {
+        out[i] = gen()
+    }
+    return out
+}
+`
@@ -12,6 +12,16 @@ func IncrementAttribute(hashKeyValue any, rangeKeyValue any, attributeName strin
     if err := validateAttributeName(attributeName); err != nil {
         return nil, err
     }
+    {{- if .ComputedAttributes}}
+    if err := validateComputedInput(attributeName); err != nil {
+        return nil, err
+    }
+    {{- end}}
+    {{- if .GuardedAttributes}}
+    if err := validateGuardedChange(attributeName, incrementValue < 0); err != nil {
+        return nil, err
+    }
+    {{- end}}
     if err := validateIncrementValue(incrementValue); err != nil {
         return nil, err
     }
@@ -20,7 +30,7 @@ func IncrementAttribute(hashKeyValue any, rangeKeyValue any, attributeName strin
     if err != nil {
         return nil, fmt.Errorf("failed to create key for increment: %v", err)
     }
-    return &dynamodb.UpdateItemInput{
+    input := &dynamodb.UpdateItemInput{
         TableName:        aws.String(TableSchema.TableName),
         Key:              key,
         UpdateExpression: aws.String("ADD #attr :val"),
@@ -30,13 +40,20 @@ func IncrementAttribute(hashKeyValue any, rangeKeyValue any, attributeName strin
         ExpressionAttributeValues: map[string]types.AttributeValue{
             ":val": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", incrementValue)},
         },
-    }, nil
+    }
+    {{- if .FieldVersionsAttribute}}
+    recordFieldVersions(input, []string{attributeName}, FieldVersionClock())
+    {{- end}}
+    {{- if .ChecksumAttribute}}
+    removeChecksum(input)
+    {{- end}}
+    return input, nil
 }
 
-// AddToSet atomically adds values to a DynamoDB Set (SS or NS).
+// AddToSet atomically adds values to a DynamoDB Set (SS, NS or BS).
 // Uses DynamoDB's ADD operation for sets - duplicate values are automatically ignored.
 // Creates the set with provided values if the attribute doesn't exist.
-// Supports string sets ([]string) and numeric sets ([]int, []float64, etc.).
+// Supports string sets ([]string), binary sets ([][]byte) and numeric sets ([]int, []float64, etc.).
 func AddToSet(hashKeyValue any, rangeKeyValue any, attributeName string, values any) (*dynamodb.UpdateItemInput, error) {
     if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
         return nil, err
@@ -44,6 +61,16 @@ func AddToSet(hashKeyValue any, rangeKeyValue any, attributeName string, values
     if err := validateAttributeName(attributeName); err != nil {
         return nil, err
     }
+    {{- if .ComputedAttributes}}
+    if err := validateComputedInput(attributeName); err != nil {
+        return nil, err
+    }
+    {{- end}}
+    {{- if .GuardedAttributes}}
+    if err := validateGuardedChange(attributeName, false); err != nil {
+        return nil, err
+    }
+    {{- end}}
     if err := validateSetValues(values); err != nil {
         return nil, err
     }
@@ -58,6 +85,8 @@ func AddToSet(hashKeyValue any, rangeKeyValue any, attributeName string, values
     switch v := values.(type) {
     case []string:
         attributeValue = &types.AttributeValueMemberSS{Value: v}
+    case [][]byte:
+        attributeValue = &types.AttributeValueMemberBS{Value: v}
     {{- if gt (len $nsTypes) 0}}
     {{- range $nsTypes}}
     case {{.}}:
@@ -69,10 +98,10 @@ func AddToSet(hashKeyValue any, rangeKeyValue any, attributeName string, values
     {{- end}}
     {{- end}}
     default:
-        return nil, fmt.Errorf("unsupported type for set operation: %T, expected []string{{if gt (len $nsTypes) 0}} or numeric slice{{end}}", values)
+        return nil, fmt.Errorf("unsupported type for set operation: %T, expected []string{{if gt (len $nsTypes) 0}}, [][]byte or numeric slice{{else}} or [][]byte{{end}}", values)
     }
    
-    return &dynamodb.UpdateItemInput{
+    input := &dynamodb.UpdateItemInput{
         TableName:        aws.String(TableSchema.TableName),
         Key:              key,
         UpdateExpression: aws.String("ADD #attr :val"),
@@ -82,13 +111,20 @@ func AddToSet(hashKeyValue any, rangeKeyValue any, attributeName string, values
         ExpressionAttributeValues: map[string]types.AttributeValue{
             ":val": attributeValue,
         },
-    }, nil
+    }
+    {{- if .FieldVersionsAttribute}}
+    recordFieldVersions(input, []string{attributeName}, FieldVersionClock())
+    {{- end}}
+    {{- if .ChecksumAttribute}}
+    removeChecksum(input)
+    {{- end}}
+    return input, nil
 }
 
-// RemoveFromSet atomically removes values from a DynamoDB Set (SS or NS).
+// RemoveFromSet atomically removes values from a DynamoDB Set (SS, NS or BS).
 // Uses DynamoDB's DELETE operation for sets - non-existent values are ignored.
 // If all values are removed, the attribute is deleted from the item.
-// Supports string sets ([]string) and numeric sets ([]int, []float64, etc.).
+// Supports string sets ([]string), binary sets ([][]byte) and numeric sets ([]int, []float64, etc.).
 func RemoveFromSet(hashKeyValue any, rangeKeyValue any, attributeName string, values any) (*dynamodb.UpdateItemInput, error) {
     if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
         return nil, err
@@ -96,6 +132,16 @@ func RemoveFromSet(hashKeyValue any, rangeKeyValue any, attributeName string, va
     if err := validateAttributeName(attributeName); err != nil {
         return nil, err
     }
+    {{- if .ComputedAttributes}}
+    if err := validateComputedInput(attributeName); err != nil {
+        return nil, err
+    }
+    {{- end}}
+    {{- if .GuardedAttributes}}
+    if err := validateGuardedChange(attributeName, true); err != nil {
+        return nil, err
+    }
+    {{- end}}
     if err := validateSetValues(values); err != nil {
         return nil, err
     }
@@ -110,6 +156,8 @@ func RemoveFromSet(hashKeyValue any, rangeKeyValue any, attributeName string, va
     switch v := values.(type) {
     case []string:
         attributeValue = &types.AttributeValueMemberSS{Value: v}
+    case [][]byte:
+        attributeValue = &types.AttributeValueMemberBS{Value: v}
     {{- if gt (len $nsTypes) 0}}
     {{- range $nsTypes}}
     case {{.}}:
@@ -121,10 +169,10 @@ func RemoveFromSet(hashKeyValue any, rangeKeyValue any, attributeName string, va
     {{- end}}
     {{- end}}
     default:
-        return nil, fmt.Errorf("unsupported type for set operation: %T, expected []string{{if gt (len $nsTypes) 0}} or numeric slice{{end}}", values)
+        return nil, fmt.Errorf("unsupported type for set operation: %T, expected []string{{if gt (len $nsTypes) 0}}, [][]byte or numeric slice{{else}} or [][]byte{{end}}", values)
     }
    
-    return &dynamodb.UpdateItemInput{
+    input := &dynamodb.UpdateItemInput{
         TableName:        aws.String(TableSchema.TableName),
         Key:              key,
         UpdateExpression: aws.String("DELETE #attr :val"),
@@ -134,6 +182,13 @@ func RemoveFromSet(hashKeyValue any, rangeKeyValue any, attributeName string, va
         ExpressionAttributeValues: map[string]types.AttributeValue{
             ":val": attributeValue,
         },
-    }, nil
+    }
+    {{- if .FieldVersionsAttribute}}
+    recordFieldVersions(input, []string{attributeName}, FieldVersionClock())
+    {{- end}}
+    {{- if .ChecksumAttribute}}
+    removeChecksum(input)
+    {{- end}}
+    return input, nil
 }
 `
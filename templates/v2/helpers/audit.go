@@ -0,0 +1,46 @@
+package helpers
+
+// AuditHelpersTemplate provides the audit timestamps of writes declared with "auto" in the schema
+const AuditHelpersTemplate = `
+// AuditClock returns the time of audit timestamps.
+// Replace it in tests for deterministic timestamps.
+var AuditClock = time.Now
+
+// setAuditTimestamps sets the audit timestamps of an item before it is put or updated:
+{{- range .AuditAttributes}}
+{{- if eq .Auto "created_at"}}
+//   {{.Name}}: now if the item has none
+{{- else}}
+//   {{.Name}}: now
+{{- end}}
+{{- end}}
+func setAuditTimestamps(item *SchemaItem, now time.Time) {
+    {{- range .AuditAttributes}}
+    {{- if eq .Auto "created_at"}}
+    if item.{{.GoName}} == {{.ZeroValue}} {
+        item.{{.GoName}} = {{.AutoValue "now"}}
+    }
+    {{- else}}
+    item.{{.GoName}} = {{.AutoValue "now"}}
+    {{- end}}
+    {{- end}}
+}
+
+// addAuditTimestamps adds the audit timestamps missing in the marshaled updates of a partial update.
+// Timestamps set by the caller are kept.
+{{- with .AuditCreatedAttribute}}
+// {{.Name}} is written with if_not_exists, so it is only set on items without one.
+{{- end}}
+func addAuditTimestamps(updates map[string]types.AttributeValue, now time.Time) error {
+    {{- range .AuditAttributes}}
+    if _, ok := updates[Column{{.GoName}}]; !ok {
+        av, err := attributevalue.Marshal({{.AutoValue "now"}})
+        if err != nil {
+            return fmt.Errorf("failed to marshal audit timestamp %s: %v", Column{{.GoName}}, err)
+        }
+        updates[Column{{.GoName}}] = av
+    }
+    {{- end}}
+    return nil
+}
+`
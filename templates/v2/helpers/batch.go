@@ -0,0 +1,210 @@
+package helpers
+
+// BatchHelpersTemplate provides batch executors chunking requests and retrying unprocessed items
+const BatchHelpersTemplate = `
+const (
+    // maxBatchGetItems is the DynamoDB limit of keys in one BatchGetItem request.
+    maxBatchGetItems = 100
+
+    // maxBatchWriteItems is the DynamoDB limit of requests in one BatchWriteItem request.
+    maxBatchWriteItems = 25
+
+    // maxBatchRetries is the number of retries of unprocessed items before a batch fails.
+    maxBatchRetries = 8
+
+    // batchBackoffBase and batchBackoffMax bound the exponential backoff between retries.
+    batchBackoffBase = 50 * time.Millisecond
+    batchBackoffMax  = 5 * time.Second
+)
+
+// BatchGetItems reads the items by the keys of the given items (other attributes are ignored).
+// Keys are deduplicated and sent in chunks of 100; UnprocessedKeys are retried with exponential backoff.
+// Items are returned in the order of keys, items which don't exist are skipped.
+// Example:
+//   items, err := BatchGetItems(ctx, client, []SchemaItem{
+//       {
+{{- range .AllAttributes}}{{if or (eq .Name $.HashKey) (eq .Name $.RangeKey)}}
+//           {{.GoName}}: {{.ExampleValue}},
+{{- end}}{{end}}
+//       },
+//   })
+func BatchGetItems(ctx context.Context, client DynamoDBAPI, keys []SchemaItem) ([]SchemaItem, error) {
+    var (
+        requested = make([]string, 0, len(keys))
+        unique    = make([]map[string]types.AttributeValue, 0, len(keys))
+        seen      = make(map[string]bool, len(keys))
+    )
+    for i, item := range keys {
+        key, err := KeyInput(item)
+        if err != nil {
+            return nil, fmt.Errorf("key %d: %w", i, err)
+        }
+        id := batchKeyID(key)
+        requested = append(requested, id)
+        if !seen[id] {
+            seen[id] = true
+            unique = append(unique, key)
+        }
+    }
+
+    found := make(map[string]SchemaItem, len(unique))
+    for start := 0; start < len(unique); start += maxBatchGetItems {
+        end := start + maxBatchGetItems
+        if end > len(unique) {
+            end = len(unique)
+        }
+        pending := unique[start:end]
+        for attempt := 0; len(pending) > 0; attempt++ {
+            if attempt > maxBatchRetries {
+                return nil, fmt.Errorf("batch get: %d keys unprocessed after %d retries", len(pending), maxBatchRetries)
+            }
+            if attempt > 0 {
+                if err := batchBackoff(ctx, attempt); err != nil {
+                    return nil, err
+                }
+            }
+            out, err := client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+                RequestItems: map[string]types.KeysAndAttributes{
+                    TableSchema.TableName: {Keys: pending},
+                },
+            })
+            if err != nil {
+                return nil, fmt.Errorf("failed to execute batch get: %w", err)
+            }
+            for _, raw := range out.Responses[TableSchema.TableName] {
+                var item SchemaItem
+                if err := attributevalue.UnmarshalMap(raw, &item); err != nil {
+                    return nil, fmt.Errorf("failed to unmarshal item: %v", err)
+                }
+                found[batchKeyID(raw)] = item
+            }
+            pending = out.UnprocessedKeys[TableSchema.TableName].Keys
+        }
+    }
+
+    items := make([]SchemaItem, 0, len(found))
+    for _, id := range requested {
+        if item, ok := found[id]; ok {
+            items = append(items, item)
+        }
+    }
+    return items, nil
+}
+
+// BatchWriteError reports a partially failed BatchWriteItems call.
+// Unprocessed holds the requests which were not written: requests still unprocessed after
+// all retries and, if a request failed with Err, the requests of it and of the chunks after it.
+type BatchWriteError struct {
+    Unprocessed []types.WriteRequest
+    Total       int
+    Err         error
+}
+
+// Error implements error.
+func (e *BatchWriteError) Error() string {
+    if e.Err != nil {
+        return fmt.Sprintf("batch write: %d of %d requests unprocessed: %v", len(e.Unprocessed), e.Total, e.Err)
+    }
+    return fmt.Sprintf("batch write: %d of %d requests unprocessed after %d retries", len(e.Unprocessed), e.Total, maxBatchRetries)
+}
+
+// Unwrap returns the error of the failed request.
+func (e *BatchWriteError) Unwrap() error {
+    return e.Err
+}
+
+// BatchWriteItems puts and deletes the items (deletes use only their keys) in chunks of 25 requests.
+// UnprocessedItems are retried with exponential backoff; items still unprocessed are reported
+// by a *BatchWriteError while the remaining chunks are written. Puts run the BeforePut hooks.
+// An item must not be put and deleted, or put twice, in the same call.
+// Example:
+//   err := BatchWriteItems(ctx, client, newItems, expiredItems)
+//   var partial *BatchWriteError
+//   if errors.As(err, &partial) {
+//       // partial.Unprocessed can be retried later
+//   }
+func BatchWriteItems(ctx context.Context, client DynamoDBAPI, puts []SchemaItem, deletes []SchemaItem) error {
+    requests := make([]types.WriteRequest, 0, len(puts)+len(deletes))
+    for i, item := range puts {
+        av, err := ItemInput(item)
+        if err != nil {
+            return fmt.Errorf("put %d: %w", i, err)
+        }
+        requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: av}})
+    }
+    for i, item := range deletes {
+        key, err := KeyInput(item)
+        if err != nil {
+            return fmt.Errorf("delete %d: %w", i, err)
+        }
+        requests = append(requests, types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: key}})
+    }
+
+    var unprocessed []types.WriteRequest
+    for start := 0; start < len(requests); start += maxBatchWriteItems {
+        end := start + maxBatchWriteItems
+        if end > len(requests) {
+            end = len(requests)
+        }
+        pending := requests[start:end]
+        for attempt := 0; len(pending) > 0; attempt++ {
+            if attempt > maxBatchRetries {
+                unprocessed = append(unprocessed, pending...)
+                break
+            }
+            if attempt > 0 {
+                if err := batchBackoff(ctx, attempt); err != nil {
+                    unprocessed = append(append(unprocessed, pending...), requests[end:]...)
+                    return &BatchWriteError{Unprocessed: unprocessed, Total: len(requests), Err: err}
+                }
+            }
+            out, err := client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+                RequestItems: map[string][]types.WriteRequest{TableSchema.TableName: pending},
+            })
+            if err != nil {
+                unprocessed = append(append(unprocessed, pending...), requests[end:]...)
+                return &BatchWriteError{Unprocessed: unprocessed, Total: len(requests), Err: err}
+            }
+            pending = out.UnprocessedItems[TableSchema.TableName]
+        }
+    }
+    if len(unprocessed) > 0 {
+        return &BatchWriteError{Unprocessed: unprocessed, Total: len(requests)}
+    }
+    return nil
+}
+
+// batchKeyID returns a comparable identity of the primary key of an item or key map.
+func batchKeyID(item map[string]types.AttributeValue) string {
+    var b strings.Builder
+    for _, name := range []string{TableSchema.HashKey, TableSchema.RangeKey} {
+        switch v := item[name].(type) {
+        case *types.AttributeValueMemberS:
+            b.WriteString("S:" + v.Value)
+        case *types.AttributeValueMemberN:
+            b.WriteString("N:" + v.Value)
+        case *types.AttributeValueMemberB:
+            b.WriteString("B:" + string(v.Value))
+        }
+        b.WriteByte(0)
+    }
+    return b.String()
+}
+
+// batchBackoff waits before retry attempt (1-based) of unprocessed batch items:
+// exponential backoff from batchBackoffBase capped at batchBackoffMax, with full jitter.
+func batchBackoff(ctx context.Context, attempt int) error {
+    delay := batchBackoffMax
+    if attempt <= 16 && batchBackoffBase<<(attempt-1) < batchBackoffMax {
+        delay = batchBackoffBase << (attempt - 1)
+    }
+    timer := time.NewTimer(time.Duration(rand.Int63n(int64(delay)) + 1))
+    defer timer.Stop()
+    select {
+    case <-ctx.Done():
+        return ctx.Err()
+    case <-timer.C:
+        return nil
+    }
+}
+`
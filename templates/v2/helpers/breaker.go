@@ -0,0 +1,298 @@
+package helpers
+
+// BreakerHelpersTemplate provides a circuit breaker decorator failing fast or failing over while DynamoDB is unhealthy
+const BreakerHelpersTemplate = `
+// ErrCircuitOpen is returned by CircuitBreakerClient while the circuit is open and no fallback client is set.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitState is the state of a CircuitBreakerClient.
+type CircuitState int
+
+const (
+    // CircuitClosed forwards all calls and measures their failure rate.
+    CircuitClosed CircuitState = iota
+
+    // CircuitOpen rejects calls, or sends them to the fallback client, until OpenDuration passed.
+    CircuitOpen
+
+    // CircuitHalfOpen forwards a limited number of probe calls, which close or reopen the circuit.
+    CircuitHalfOpen
+)
+
+// String returns the name of the state, e.g. for metric labels.
+func (s CircuitState) String() string {
+    switch s {
+    case CircuitClosed:
+        return "closed"
+    case CircuitOpen:
+        return "open"
+    case CircuitHalfOpen:
+        return "half-open"
+    }
+    return "unknown"
+}
+
+// CircuitBreakerPolicy configures CircuitBreakerClient. Zero fields take the defaults in brackets.
+type CircuitBreakerPolicy struct {
+    Window         time.Duration                // Period the failure rate is measured over [10s]
+    MinRequests    int                          // Calls in a window needed before the circuit can trip [20]
+    FailureRate    float64                      // Rate of 5xx and throttling failures in a window tripping the circuit [0.5]
+    OpenDuration   time.Duration                // Time the circuit stays open before probing [30s]
+    HalfOpenProbes int                          // Probe calls of a half-open circuit, all must succeed to close it [1]
+    Fallback       DynamoDBAPI                  // Client serving calls while the circuit is open, e.g. of a replica region; nil rejects them
+    OnStateChange  func(from, to CircuitState)  // Called after every state transition, e.g. to export a metric
+}
+
+// CircuitBreakerClient decorates DynamoDBAPI with circuit breaker semantics: sustained 5xx and throttling
+// failures trip the circuit, an open circuit fails calls fast with ErrCircuitOpen or fails them over
+// to the fallback client, and after OpenDuration probe calls decide whether it closes again.
+// Client errors such as ConditionalCheckFailedException and canceled calls don't count as failures.
+// Example:
+//   breaker := NewCircuitBreakerClient(client, CircuitBreakerPolicy{
+//       Fallback: replicaClient,
+//       OnStateChange: func(from, to CircuitState) {
+//           circuitState.WithLabelValues(TableName).Set(float64(to))
+//       },
+//   })
+type CircuitBreakerClient struct {
+    client DynamoDBAPI
+    policy CircuitBreakerPolicy
+
+    mu          sync.Mutex
+    state       CircuitState
+    windowStart time.Time
+    calls       int
+    failures    int
+    openedAt    time.Time
+    probes      int
+    probesOK    int
+}
+
+// NewCircuitBreakerClient wraps client with a closed circuit breaker.
+func NewCircuitBreakerClient(client DynamoDBAPI, policy CircuitBreakerPolicy) *CircuitBreakerClient {
+    if policy.Window <= 0 {
+        policy.Window = 10 * time.Second
+    }
+    if policy.MinRequests <= 0 {
+        policy.MinRequests = 20
+    }
+    if policy.FailureRate <= 0 {
+        policy.FailureRate = 0.5
+    }
+    if policy.OpenDuration <= 0 {
+        policy.OpenDuration = 30 * time.Second
+    }
+    if policy.HalfOpenProbes <= 0 {
+        policy.HalfOpenProbes = 1
+    }
+    return &CircuitBreakerClient{
+        client:      client,
+        policy:      policy,
+        windowStart: time.Now(),
+    }
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreakerClient) State() CircuitState {
+    cb.mu.Lock()
+    defer cb.mu.Unlock()
+    return cb.state
+}
+
+// GetItem forwards the GetItem call through the circuit.
+func (cb *CircuitBreakerClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.GetItemOutput, error) {
+        return client.GetItem(ctx, params, optFns...)
+    })
+}
+
+// PutItem forwards the PutItem call through the circuit.
+func (cb *CircuitBreakerClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.PutItemOutput, error) {
+        return client.PutItem(ctx, params, optFns...)
+    })
+}
+
+// UpdateItem forwards the UpdateItem call through the circuit.
+func (cb *CircuitBreakerClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.UpdateItemOutput, error) {
+        return client.UpdateItem(ctx, params, optFns...)
+    })
+}
+
+// DeleteItem forwards the DeleteItem call through the circuit.
+func (cb *CircuitBreakerClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.DeleteItemOutput, error) {
+        return client.DeleteItem(ctx, params, optFns...)
+    })
+}
+
+// Query forwards the Query call through the circuit.
+func (cb *CircuitBreakerClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.QueryOutput, error) {
+        return client.Query(ctx, params, optFns...)
+    })
+}
+
+{{- if not .NoScan}}
+
+// Scan forwards the Scan call through the circuit.
+func (cb *CircuitBreakerClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.ScanOutput, error) {
+        return client.Scan(ctx, params, optFns...)
+    })
+}
+{{- end}}
+
+// BatchGetItem forwards the BatchGetItem call through the circuit.
+func (cb *CircuitBreakerClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.BatchGetItemOutput, error) {
+        return client.BatchGetItem(ctx, params, optFns...)
+    })
+}
+
+// BatchWriteItem forwards the BatchWriteItem call through the circuit.
+func (cb *CircuitBreakerClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.BatchWriteItemOutput, error) {
+        return client.BatchWriteItem(ctx, params, optFns...)
+    })
+}
+
+// TransactGetItems forwards the TransactGetItems call through the circuit.
+func (cb *CircuitBreakerClient) TransactGetItems(ctx context.Context, params *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.TransactGetItemsOutput, error) {
+        return client.TransactGetItems(ctx, params, optFns...)
+    })
+}
+
+// TransactWriteItems forwards the TransactWriteItems call through the circuit.
+func (cb *CircuitBreakerClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.TransactWriteItemsOutput, error) {
+        return client.TransactWriteItems(ctx, params, optFns...)
+    })
+}
+
+// DescribeTable forwards the DescribeTable call through the circuit.
+func (cb *CircuitBreakerClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.DescribeTableOutput, error) {
+        return client.DescribeTable(ctx, params, optFns...)
+    })
+}
+
+// DescribeTimeToLive forwards the DescribeTimeToLive call through the circuit.
+func (cb *CircuitBreakerClient) DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.DescribeTimeToLiveOutput, error) {
+        return client.DescribeTimeToLive(ctx, params, optFns...)
+    })
+}
+
+// ListTagsOfResource forwards the ListTagsOfResource call through the circuit.
+func (cb *CircuitBreakerClient) ListTagsOfResource(ctx context.Context, params *dynamodb.ListTagsOfResourceInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error) {
+    return circuitCall(cb, func(client DynamoDBAPI) (*dynamodb.ListTagsOfResourceOutput, error) {
+        return client.ListTagsOfResource(ctx, params, optFns...)
+    })
+}
+
+// circuitCall runs call with the client the circuit admits and records the outcome of calls to the primary client.
+func circuitCall[T any](cb *CircuitBreakerClient, call func(client DynamoDBAPI) (T, error)) (T, error) {
+    client, primary, probe, err := cb.acquire()
+    if err != nil {
+        var zero T
+        return zero, err
+    }
+    out, err := call(client)
+    if primary {
+        cb.record(probe, err)
+    }
+    return out, err
+}
+
+// acquire returns the client a call goes to, whether it is the primary client
+// and whether the call is a probe of a half-open circuit.
+func (cb *CircuitBreakerClient) acquire() (DynamoDBAPI, bool, bool, error) {
+    cb.mu.Lock()
+    from := cb.state
+    if cb.state == CircuitOpen && time.Since(cb.openedAt) >= cb.policy.OpenDuration {
+        cb.state, cb.probes, cb.probesOK = CircuitHalfOpen, 0, 0
+    }
+    var (
+        client         DynamoDBAPI
+        primary, probe bool
+        err            error
+    )
+    switch {
+    case cb.state == CircuitClosed:
+        client, primary = cb.client, true
+    case cb.state == CircuitHalfOpen && cb.probes < cb.policy.HalfOpenProbes:
+        cb.probes++
+        client, primary, probe = cb.client, true, true
+    case cb.policy.Fallback != nil:
+        client = cb.policy.Fallback
+    default:
+        err = ErrCircuitOpen
+    }
+    to := cb.state
+    cb.mu.Unlock()
+
+    cb.notify(from, to)
+    return client, primary, probe, err
+}
+
+// record counts the outcome of a call to the primary client and trips, closes or reopens the circuit.
+func (cb *CircuitBreakerClient) record(probe bool, err error) {
+    failed := isCircuitFailure(err)
+    now := time.Now()
+
+    cb.mu.Lock()
+    from := cb.state
+    switch {
+    case probe && cb.state == CircuitHalfOpen:
+        if failed {
+            cb.state, cb.openedAt = CircuitOpen, now
+            break
+        }
+        if cb.probesOK++; cb.probesOK >= cb.policy.HalfOpenProbes {
+            cb.state, cb.windowStart, cb.calls, cb.failures = CircuitClosed, now, 0, 0
+        }
+    case !probe && cb.state == CircuitClosed:
+        if now.Sub(cb.windowStart) >= cb.policy.Window {
+            cb.windowStart, cb.calls, cb.failures = now, 0, 0
+        }
+        cb.calls++
+        if failed {
+            cb.failures++
+        }
+        if cb.calls >= cb.policy.MinRequests && float64(cb.failures)/float64(cb.calls) >= cb.policy.FailureRate {
+            cb.state, cb.openedAt = CircuitOpen, now
+        }
+    }
+    to := cb.state
+    cb.mu.Unlock()
+
+    cb.notify(from, to)
+}
+
+// notify calls OnStateChange if the state changed, outside of the lock so the callback may call State.
+func (cb *CircuitBreakerClient) notify(from, to CircuitState) {
+    if from != to && cb.policy.OnStateChange != nil {
+        cb.policy.OnStateChange(from, to)
+    }
+}
+
+// isCircuitFailure reports whether an error counts towards tripping the circuit:
+// throttling errors and server errors (HTTP 5xx).
+func isCircuitFailure(err error) bool {
+    if err == nil || errors.Is(err, context.Canceled) {
+        return false
+    }
+    var apiErr smithy.APIError
+    if errors.As(err, &apiErr) {
+        switch apiErr.ErrorCode() {
+        case "ProvisionedThroughputExceededException", "RequestLimitExceeded", "ThrottlingException":
+            return true
+        }
+    }
+    var status interface{ HTTPStatusCode() int }
+    return errors.As(err, &status) && status.HTTPStatusCode() >= 500
+}
+`
@@ -0,0 +1,151 @@
+package helpers
+
+// ChecksumHelpersTemplate provides the item checksum declared with "checksum" in the schema
+const ChecksumHelpersTemplate = `
+{{- with .ChecksumAttribute}}
+// ChecksumAttribute is the attribute holding the SHA-256 checksum of the item.
+// Puts write it, partial updates remove it since they can't hash the whole item.
+const ChecksumAttribute = Column{{.GoName}}
+
+// ErrChecksumMismatch is returned when the checksum of a read item doesn't match its attributes:
+// the item was modified without the generated code.
+var ErrChecksumMismatch = errors.New("item checksum mismatch")
+
+// ErrChecksumMissing is returned when a read item has no checksum: it was last written
+// by a partial update or without the generated code.
+var ErrChecksumMissing = errors.New("item checksum missing")
+
+// ItemChecksum returns the hex SHA-256 of the canonical JSON of the item: its attributes
+// as marshaled by ToAttributeValues with set members sorted, {{.Name}}
+{{- if $.SensitiveAttributes}} and sensitive attributes{{end}} excluded.
+{{- if $.HasCompositeKeys}}
+// Composite index keys are excluded too, ItemInput writes them from their parts.
+{{- end}}
+func ItemChecksum(item SchemaItem) (string, error) {
+    av, err := ToAttributeValues(item)
+    if err != nil {
+        return "", err
+    }
+    delete(av, ChecksumAttribute)
+    {{- if $.HasCompositeKeys}}
+    for name := range compositeKeyParts {
+        delete(av, name)
+    }
+    {{- end}}
+    {{- if $.SensitiveAttributes}}
+    for name := range av {
+        if IsSensitive(name) {
+            delete(av, name)
+        }
+    }
+    {{- end}}
+    data, err := json.Marshal(canonicalAttributeValue(&types.AttributeValueMemberM{Value: av}))
+    if err != nil {
+        return "", fmt.Errorf("failed to encode item for checksum: %v", err)
+    }
+    sum := sha256.Sum256(data)
+    return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyChecksum checks the checksum of an item read from the table,
+// e.g. of Query or Scan results. GetItem verifies with WithChecksumVerification.
+// Returns an error matching ErrChecksumMissing or ErrChecksumMismatch.
+// Example:
+//   if err := VerifyChecksum(item); errors.Is(err, ErrChecksumMismatch) {
+//       // the item was modified out of band
+//   }
+func VerifyChecksum(item SchemaItem) error {
+    if item.{{.GoName}} == "" {
+        return ErrChecksumMissing
+    }
+    sum, err := ItemChecksum(item)
+    if err != nil {
+        return err
+    }
+    if sum != item.{{.GoName}} {
+        return fmt.Errorf("%w: stored %s, computed %s", ErrChecksumMismatch, item.{{.GoName}}, sum)
+    }
+    return nil
+}
+
+// setChecksum sets the checksum of an item before it is put.
+func setChecksum(item *SchemaItem) error {
+    sum, err := ItemChecksum(*item)
+    if err != nil {
+        return err
+    }
+    item.{{.GoName}} = sum
+    return nil
+}
+
+// removeChecksum adds the removal of the checksum to a partial update.
+func removeChecksum(input *dynamodb.UpdateItemInput) {
+    if input.ExpressionAttributeNames == nil {
+        input.ExpressionAttributeNames = map[string]string{}
+    }
+    input.ExpressionAttributeNames["#checksum"] = ChecksumAttribute
+    updateExpression := aws.ToString(input.UpdateExpression)
+    if strings.Contains(updateExpression, "REMOVE ") {
+        updateExpression = strings.Replace(updateExpression, "REMOVE ", "REMOVE #checksum, ", 1)
+    } else {
+        updateExpression += " REMOVE #checksum"
+    }
+    input.UpdateExpression = aws.String(updateExpression)
+}
+
+// validateChecksumUpdate rejects updates setting the checksum, which only puts maintain.
+func validateChecksumUpdate(updates map[string]any) error {
+    if _, ok := updates[ChecksumAttribute]; ok {
+        return fmt.Errorf("checksum attribute %s can't be updated", ChecksumAttribute)
+    }
+    return nil
+}
+
+// canonicalAttributeValue converts an attribute value to a JSON value with a stable encoding:
+// encoding/json sorts map keys, set members are sorted here.
+func canonicalAttributeValue(value types.AttributeValue) any {
+    switch v := value.(type) {
+    case *types.AttributeValueMemberS:
+        return map[string]any{"S": v.Value}
+    case *types.AttributeValueMemberN:
+        return map[string]any{"N": v.Value}
+    case *types.AttributeValueMemberB:
+        return map[string]any{"B": v.Value}
+    case *types.AttributeValueMemberBOOL:
+        return map[string]any{"BOOL": v.Value}
+    case *types.AttributeValueMemberNULL:
+        return map[string]any{"NULL": true}
+    case *types.AttributeValueMemberSS:
+        return map[string]any{"SS": sortedMembers(v.Value)}
+    case *types.AttributeValueMemberNS:
+        return map[string]any{"NS": sortedMembers(v.Value)}
+    case *types.AttributeValueMemberBS:
+        members := make([]string, len(v.Value))
+        for i, b := range v.Value {
+            members[i] = base64.StdEncoding.EncodeToString(b)
+        }
+        return map[string]any{"BS": sortedMembers(members)}
+    case *types.AttributeValueMemberL:
+        list := make([]any, len(v.Value))
+        for i, elem := range v.Value {
+            list[i] = canonicalAttributeValue(elem)
+        }
+        return map[string]any{"L": list}
+    case *types.AttributeValueMemberM:
+        m := make(map[string]any, len(v.Value))
+        for name, elem := range v.Value {
+            m[name] = canonicalAttributeValue(elem)
+        }
+        return map[string]any{"M": m}
+    }
+    return nil
+}
+
+// sortedMembers returns a sorted copy of set members.
+func sortedMembers(members []string) []string {
+    sorted := slices.Clone(members)
+    slices.Sort(sorted)
+    return sorted
+}
+{{- end}}
+`
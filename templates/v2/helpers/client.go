@@ -0,0 +1,73 @@
+package helpers
+
+// ClientHelpersTemplate provides DynamoDB client construction helpers
+const ClientHelpersTemplate = `
+const (
+    // LocalStackEndpoint is the default LocalStack edge endpoint.
+    LocalStackEndpoint = "http://localhost:4566"
+
+    // DynamoDBLocalEndpoint is the default dynamodb-local endpoint.
+    DynamoDBLocalEndpoint = "http://localhost:8000"
+)
+
+// ClientOptions configures the DynamoDB client created by NewClient.
+// Empty fields fall back to the default AWS SDK configuration chain.
+type ClientOptions struct {
+    Region          string  // AWS region, e.g. "us-east-1"
+    Endpoint        string  // Custom endpoint URL (LocalStack, dynamodb-local)
+    AccessKeyID     string  // Static access key, intended for local development
+    SecretAccessKey string  // Static secret key, used together with AccessKeyID
+    SessionToken    string  // Optional session token for static credentials
+}
+
+// LocalStackOptions returns ClientOptions for a LocalStack instance with dummy credentials.
+func LocalStackOptions(region string) ClientOptions {
+    return ClientOptions{
+        Region:          region,
+        Endpoint:        LocalStackEndpoint,
+        AccessKeyID:     "test",
+        SecretAccessKey: "test",
+    }
+}
+
+// DynamoDBLocalOptions returns ClientOptions for a dynamodb-local instance with dummy credentials.
+func DynamoDBLocalOptions(region string) ClientOptions {
+    return ClientOptions{
+        Region:          region,
+        Endpoint:        DynamoDBLocalEndpoint,
+        AccessKeyID:     "local",
+        SecretAccessKey: "local",
+    }
+}
+
+// RegionalEndpoint returns the public DynamoDB endpoint URL for the given region.
+// Example: RegionalEndpoint("eu-west-1") → "https://dynamodb.eu-west-1.amazonaws.com"
+func RegionalEndpoint(region string) string {
+    return fmt.Sprintf("https://dynamodb.%s.amazonaws.com", region)
+}
+
+// NewClient creates a DynamoDB client from the default AWS configuration chain.
+// Region, endpoint and static credentials from opts override the defaults when set.
+// Example:
+//   client, err := NewClient(ctx, LocalStackOptions("us-east-1"))
+func NewClient(ctx context.Context, opts ClientOptions) (*dynamodb.Client, error) {
+    var loadOpts []func(*config.LoadOptions) error
+    if opts.Region != "" {
+        loadOpts = append(loadOpts, config.WithRegion(opts.Region))
+    }
+    if opts.AccessKeyID != "" {
+        loadOpts = append(loadOpts, config.WithCredentialsProvider(
+            credentials.NewStaticCredentialsProvider(opts.AccessKeyID, opts.SecretAccessKey, opts.SessionToken),
+        ))
+    }
+    cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+    if err != nil {
+        return nil, fmt.Errorf("failed to load AWS config: %v", err)
+    }
+    return dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+        if opts.Endpoint != "" {
+            o.BaseEndpoint = aws.String(opts.Endpoint)
+        }
+    }), nil
+}
+`
@@ -0,0 +1,151 @@
+package helpers
+
+// CompatHelpersTemplate provides runtime checks of generated code against live table metadata
+const CompatHelpersTemplate = `
+const (
+    // SchemaHashTagKey is the table tag holding the schema hash of the deployed table definition.
+    SchemaHashTagKey = "godyno:schema-hash"
+
+    // SchemaVersionTagKey is the table tag holding the schema version of the deployed table definition.
+    SchemaVersionTagKey = "godyno:schema-version"
+)
+
+// SchemaTags returns table tags describing the schema this code was generated from.
+// Attach them on table creation (or with TagResource) to enable version checks in AssertSchemaCompatible.
+// Example:
+//   input := &dynamodb.CreateTableInput{TableName: aws.String(TableName), Tags: SchemaTags()}
+func SchemaTags() []types.Tag {
+    return []types.Tag{
+        {Key: aws.String(SchemaHashTagKey), Value: aws.String(SchemaHash)},
+        {Key: aws.String(SchemaVersionTagKey), Value: aws.String(strconv.Itoa(SchemaVersion))},
+    }
+}
+
+// AssertSchemaCompatible verifies that the live table matches the schema this code was generated from.
+// Checks table and index key schemas and key attribute types via DescribeTable.
+// If the table carries SchemaTags, a newer table version or a different hash for the same version is an error.
+// The hash of a table tagged with an older version, or without a SchemaHash tag, is not checked.
+// Call it on startup to catch deploys of stale code against changed tables.
+func AssertSchemaCompatible(ctx context.Context, client DynamoDBAPI) error {
+    out, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+        TableName: aws.String(TableName),
+    })
+    if err != nil {
+        return fmt.Errorf("failed to describe table %s: %v", TableName, err)
+    }
+    if out.Table == nil {
+        return fmt.Errorf("table %s: empty description", TableName)
+    }
+    table := out.Table
+
+    if err := compareKeySchema("table", table.KeySchema, TableSchema.HashKey, TableSchema.RangeKey); err != nil {
+        return err
+    }
+    if err := compareKeyTypes(table.AttributeDefinitions); err != nil {
+        return err
+    }
+
+    remote := make(map[string][]types.KeySchemaElement)
+    for _, gsi := range table.GlobalSecondaryIndexes {
+        remote[aws.ToString(gsi.IndexName)] = gsi.KeySchema
+    }
+    for _, lsi := range table.LocalSecondaryIndexes {
+        remote[aws.ToString(lsi.IndexName)] = lsi.KeySchema
+    }
+    for _, idx := range TableSchema.SecondaryIndexes {
+        keys, ok := remote[idx.Name]
+        if !ok {
+            return fmt.Errorf("index %s: not found on table %s", idx.Name, TableName)
+        }
+        if err := compareKeySchema("index "+idx.Name, keys, idx.HashKey, idx.RangeKey); err != nil {
+            return err
+        }
+    }
+
+    if table.TableArn == nil {
+        return nil
+    }
+    tags, err := client.ListTagsOfResource(ctx, &dynamodb.ListTagsOfResourceInput{
+        ResourceArn: table.TableArn,
+    })
+    if err != nil {
+        return fmt.Errorf("failed to list tags of table %s: %v", TableName, err)
+    }
+    return compareSchemaTags(tags.Tags)
+}
+
+// compareKeySchema checks that a key schema consists of the expected hash and range keys.
+func compareKeySchema(scope string, keys []types.KeySchemaElement, hashKey, rangeKey string) error {
+    var remoteHash, remoteRange string
+    for _, k := range keys {
+        switch k.KeyType {
+        case types.KeyTypeHash:
+            remoteHash = aws.ToString(k.AttributeName)
+        case types.KeyTypeRange:
+            remoteRange = aws.ToString(k.AttributeName)
+        }
+    }
+    if remoteHash != hashKey {
+        return fmt.Errorf("%s: hash key is %q, generated code expects %q", scope, remoteHash, hashKey)
+    }
+    if remoteRange != rangeKey {
+        return fmt.Errorf("%s: range key is %q, generated code expects %q", scope, remoteRange, rangeKey)
+    }
+    return nil
+}
+
+// compareKeyTypes checks that key attribute definitions match schema attribute types.
+// A key attribute the schema doesn't define is an error; composite index keys are only checked by name.
+func compareKeyTypes(defs []types.AttributeDefinition) error {
+    indexKeys := make(map[string]bool)
+    for _, idx := range TableSchema.SecondaryIndexes {
+        indexKeys[idx.HashKey] = true
+        indexKeys[idx.RangeKey] = true
+    }
+    for _, def := range defs {
+        name := aws.ToString(def.AttributeName)
+        field, ok := TableSchema.FieldsMap[name]
+        if !ok {
+            if indexKeys[name] {
+                continue
+            }
+            return fmt.Errorf("attribute %s: table key attribute is not defined in the generated schema", name)
+        }
+        if string(def.AttributeType) != field.DynamoType {
+            return fmt.Errorf("attribute %s: table type is %s, generated code expects %s", name, def.AttributeType, field.DynamoType)
+        }
+    }
+    return nil
+}
+
+// compareSchemaTags checks table schema tags against the generated SchemaVersion and SchemaHash.
+// Tables without schema tags are accepted. The hash is compared only for tables of the generated version
+// (or without a version tag): an older table with a missing or invalid SchemaHash tag passes unchecked.
+func compareSchemaTags(tags []types.Tag) error {
+    var hash, version string
+    for _, t := range tags {
+        switch aws.ToString(t.Key) {
+        case SchemaHashTagKey:
+            hash = aws.ToString(t.Value)
+        case SchemaVersionTagKey:
+            version = aws.ToString(t.Value)
+        }
+    }
+    if version != "" {
+        v, err := strconv.Atoi(version)
+        if err != nil {
+            return fmt.Errorf("table %s: invalid %s tag %q: %v", TableName, SchemaVersionTagKey, version, err)
+        }
+        if v > SchemaVersion {
+            return fmt.Errorf("table %s: schema version %d is newer than generated code version %d", TableName, v, SchemaVersion)
+        }
+        if v < SchemaVersion {
+            return nil
+        }
+    }
+    if hash != "" && hash != SchemaHash {
+        return fmt.Errorf("table %s: schema hash %s differs from generated code hash %s", TableName, hash, SchemaHash)
+    }
+    return nil
+}
+`
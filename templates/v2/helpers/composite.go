@@ -0,0 +1,167 @@
+package helpers
+
+// CompositeKeyHelpersTemplate provides writing the composite index keys of items from their parts
+const CompositeKeyHelpersTemplate = `
+// compositeKeyParts are the composite keys of the secondary indexes with their parts.
+// ItemInput and the update inputs write them from the values of their parts.
+var compositeKeyParts = func() map[string][]CompositeKeyPart {
+    keys := make(map[string][]CompositeKeyPart)
+    for _, idx := range TableSchema.SecondaryIndexes {
+        if idx.HashKeyParts != nil {
+            keys[idx.HashKey] = idx.HashKeyParts
+        }
+        if idx.RangeKeyParts != nil {
+            keys[idx.RangeKey] = idx.RangeKeyParts
+        }
+    }
+    return keys
+}()
+
+// SplitCompositeKey splits a value of a composite key attribute of the secondary indexes,
+// e.g. read back from an index, into its parts: attribute name → raw string value.
+{{- with .CompositeKeyParsers}}
+// Parse functions of the keys return the parts typed, e.g. {{(index . 0).FuncName}}.
+// Example:
+//   parts, err := SplitCompositeKey("{{(index . 0).Name}}", value)
+{{- end}}
+func SplitCompositeKey(attr, value string) (map[string]string, error) {
+    parts, ok := compositeKeyParts[attr]
+    if !ok {
+        return nil, fmt.Errorf("attribute '%s' is not a composite key", attr)
+    }
+    return ParseCompositeKey(value, parts)
+}
+{{- range .CompositeKeyParsers}}
+
+// {{.FuncName}} splits a value of the composite key "{{.Name}}" into its typed parts.
+func {{.FuncName}}(value string) ({{range .Parts}}{{.Result}} {{.GoType}}, {{end}}err error) {
+    parts, err := SplitCompositeKey("{{.Name}}", value)
+    if err != nil {
+        return
+    }
+    {{- range .Parts}}
+    {{- if eq .Type "N"}}
+    if {{.Result}}, err = parseCompositeNumber[{{.GoType}}](parts[Column{{.GoName}}]); err != nil {
+        err = fmt.Errorf("composite key part %s: %v", Column{{.GoName}}, err)
+        return
+    }
+    {{- else if eq .Type "BOOL"}}
+    if {{.Result}}, err = strconv.ParseBool(parts[Column{{.GoName}}]); err != nil {
+        err = fmt.Errorf("composite key part %s: %v", Column{{.GoName}}, err)
+        return
+    }
+    {{- else if .IsEnum}}
+    {{.Result}} = {{.EnumType}}(parts[Column{{.GoName}}])
+    {{- else}}
+    {{.Result}} = parts[Column{{.GoName}}]
+    {{- end}}
+    {{- end}}
+    return
+}
+{{- end}}
+
+// parseCompositeNumber parses a number part of a composite key value into its Go type.
+func parseCompositeNumber[T Signed | Unsigned | Float](s string) (T, error) {
+    var v T
+    err := attributevalue.Unmarshal(&types.AttributeValueMemberN{Value: s}, &v)
+    return v, err
+}
+
+// compositeKeyValue joins the values of the parts of a composite key as the query builders do.
+// Returns false if a part is missing or empty.
+func compositeKeyValue(parts []CompositeKeyPart, values map[string]types.AttributeValue) (string, bool, error) {
+    strs := make(map[string]string, len(parts))
+    for _, part := range parts {
+        if part.IsConstant {
+            continue
+        }
+        var s string
+        switch v := values[part.Value].(type) {
+        case *types.AttributeValueMemberS:
+            s = v.Value
+        case *types.AttributeValueMemberN:
+            s = v.Value
+        case *types.AttributeValueMemberBOOL:
+            s = strconv.FormatBool(v.Value)
+        case nil, *types.AttributeValueMemberNULL:
+        default:
+            return "", false, fmt.Errorf("composite key part %s must be a string, number or boolean", part.Value)
+        }
+        if s == "" {
+            return "", false, nil
+        }
+        strs[part.Value] = s
+    }
+    value, err := BuildCompositeKey(parts, strs)
+    if err != nil {
+        return "", false, err
+    }
+    return value, true, nil
+}
+
+// setCompositeKeys writes the composite keys into a marshaled item. Composite keys with a missing part
+// are deleted, leaving the item out of their indexes, and returned for updates to remove them.
+func setCompositeKeys(av map[string]types.AttributeValue) ([]string, error) {
+    var missing []string
+    for _, name := range slices.Sorted(maps.Keys(compositeKeyParts)) {
+        value, ok, err := compositeKeyValue(compositeKeyParts[name], av)
+        if err != nil {
+            return nil, fmt.Errorf("composite key '%s': %w", name, err)
+        }
+        if !ok {
+            delete(av, name)
+            missing = append(missing, name)
+            continue
+        }
+        av[name] = &types.AttributeValueMemberS{Value: value}
+    }
+    return missing, nil
+}
+
+// updateCompositeKeys adds the composite keys whose parts change to marshaled updates, taking parts
+// of the primary key from key. Returns the composite keys a changed part left without value, to be removed.
+// Partial updates of the parts of a composite key and direct updates of it are rejected.
+func updateCompositeKeys(key, updates map[string]types.AttributeValue) ([]string, error) {
+    var removed []string
+    for _, name := range slices.Sorted(maps.Keys(compositeKeyParts)) {
+        if _, ok := updates[name]; ok {
+            return nil, fmt.Errorf("composite key '%s' is written from its parts and can't be updated directly", name)
+        }
+        var (
+            parts   = compositeKeyParts[name]
+            values  = make(map[string]types.AttributeValue, len(parts))
+            changed []string
+            missing []string
+        )
+        for _, part := range parts {
+            if part.IsConstant {
+                continue
+            }
+            if value, ok := updates[part.Value]; ok {
+                values[part.Value] = value
+                changed = append(changed, part.Value)
+            } else if value, ok := key[part.Value]; ok {
+                values[part.Value] = value
+            } else {
+                missing = append(missing, part.Value)
+            }
+        }
+        if len(changed) == 0 {
+            continue
+        }
+        if len(missing) > 0 {
+            return nil, fmt.Errorf("composite key '%s' needs %s updated together with %s", name, strings.Join(missing, ", "), strings.Join(changed, ", "))
+        }
+        value, ok, err := compositeKeyValue(parts, values)
+        if err != nil {
+            return nil, fmt.Errorf("composite key '%s': %w", name, err)
+        }
+        if !ok {
+            removed = append(removed, name)
+            continue
+        }
+        updates[name] = &types.AttributeValueMemberS{Value: value}
+    }
+    return removed, nil
+}
+`
@@ -0,0 +1,63 @@
+package helpers
+
+// ComputedHelpersTemplate provides recalculation of computed attributes on write
+const ComputedHelpersTemplate = `
+// computedInputs maps computed attributes to the attributes their expressions read.
+var computedInputs = map[string][]string{
+    {{- range .ComputedAttributes}}
+    Column{{.GoName}}: { {{- range $i, $input := .Inputs}}{{if $i}}, {{end}}{{$.ExampleField $input}}{{end -}} },
+    {{- end}}
+}
+
+// computeAttributes recalculates computed attributes of the item from their inputs.
+// ItemInput and UpdateItemInput call it, so computed attributes never go stale on put.
+func computeAttributes(item *SchemaItem) {
+    {{- range .ComputedAttributes}}
+    item.{{.GoName}} = {{.ItemExpr}} // {{.Computed}}
+    {{- end}}
+}
+
+// computeUpdates adds computed attributes whose inputs change to updates.
+// A computed attribute is recalculated only if all its inputs are updated together,
+// partial updates of its inputs and direct updates of it are rejected.
+func computeUpdates(updates map[string]any) error {
+    for name, inputs := range computedInputs {
+        if _, ok := updates[name]; ok {
+            return fmt.Errorf("attribute '%s' is computed and can't be updated directly", name)
+        }
+        var changed, missing []string
+        for _, input := range inputs {
+            if _, ok := updates[input]; ok {
+                changed = append(changed, input)
+            } else {
+                missing = append(missing, input)
+            }
+        }
+        if len(changed) > 0 && len(missing) > 0 {
+            return fmt.Errorf("computed attribute '%s' needs %s updated together with %s", name, strings.Join(missing, ", "), strings.Join(changed, ", "))
+        }
+    }
+    {{- range .ComputedAttributes}}
+    if _, ok := updates[{{$.ExampleField (index .Inputs 0)}}]; ok {
+        updates[Column{{.GoName}}] = {{.UpdateExpr}}
+    }
+    {{- end}}
+    return nil
+}
+
+// validateComputedInput rejects atomic updates of attributes computed attributes depend on:
+// the new value is only known to DynamoDB, so computed attributes can't be recalculated.
+func validateComputedInput(attributeName string) error {
+    for name, inputs := range computedInputs {
+        for _, input := range inputs {
+            if input == attributeName {
+                return fmt.Errorf("attribute '%s' is an input of computed attribute '%s', use UpdateItemInputFromRaw", attributeName, name)
+            }
+        }
+    }
+    if _, ok := computedInputs[attributeName]; ok {
+        return fmt.Errorf("attribute '%s' is computed and can't be updated directly", attributeName)
+    }
+    return nil
+}
+`
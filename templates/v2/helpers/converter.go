@@ -1,6 +1,6 @@
 package helpers
 
-// ConverterHelpersTemplate ...
+// ConverterHelpersTemplate provides conversions between SchemaItem and DynamoDB AttributeValue maps
 const ConverterHelpersTemplate = `
 // MarshalMap converts any Go value (map, struct, etc.) to DynamoDB AttributeValue map
 // Uses AWS SDK's built-in marshaler for consistent behavior
@@ -12,6 +12,48 @@ func MarshalMap(input any) (map[string]types.AttributeValue, error) {
     return result, nil
 }
 
+// UnmarshalItem converts a DynamoDB AttributeValue map to SchemaItem
+// Uses AWS SDK's built-in unmarshaler for consistent behavior
+func UnmarshalItem(av map[string]types.AttributeValue) (*SchemaItem, error) {
+    item, err := FromAttributeValues(av)
+    if err != nil {
+        return nil, err
+    }
+    return &item, nil
+}
+
+// ToAttributeValues converts a SchemaItem to a DynamoDB AttributeValue map with the marshaling
+// rules of the generated code (dynamodbav struct tags, set types, omitted empty values).
+// Use it to pass items to SDK calls the package doesn't wrap. Unlike ItemInput
+// it doesn't run BeforePut hooks{{if .ComputedAttributes}} or recalculate computed attributes{{end}}.
+func ToAttributeValues(item SchemaItem) (map[string]types.AttributeValue, error) {
+    av, err := attributevalue.MarshalMap(item)
+    if err != nil {
+        return nil, fmt.Errorf("failed to marshal item: %v", err)
+    }
+    if err := checkNumbers(av); err != nil {
+        return nil, err
+    }
+    return av, nil
+}
+
+// FromAttributeValues converts a DynamoDB AttributeValue map, e.g. an item of another SDK call
+// or a stream record, to a SchemaItem. Attributes not declared in the schema are ignored,
+// numbers the fields can't hold return a *NumberRangeError.
+// Example:
+//   out, err := client.GetItem(ctx, input)
+//   item, err := FromAttributeValues(out.Item)
+func FromAttributeValues(av map[string]types.AttributeValue) (SchemaItem, error) {
+    var item SchemaItem
+    if err := checkNumbers(av); err != nil {
+        return SchemaItem{}, err
+    }
+    if err := attributevalue.UnmarshalMap(av, &item); err != nil {
+        return SchemaItem{}, fmt.Errorf("failed to unmarshal AttributeValue map: %v", err)
+    }
+    return item, nil
+}
+
 // Marshal converts a single Go value to DynamoDB AttributeValue
 // Uses AWS SDK's built-in marshaler for consistent behavior
 func Marshal(input any) (types.AttributeValue, error) {
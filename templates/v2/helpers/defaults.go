@@ -0,0 +1,27 @@
+package helpers
+
+// DefaultHelpersTemplate provides the default values declared with "default" in the schema
+const DefaultHelpersTemplate = `
+// ApplyDefaults makes ItemInput fill unset attributes, holding the zero value of their type
+// or nil if optional, with their defaults:
+{{- range .DefaultAttributes}}
+//   {{.Name}}: {{.DefaultValue}}
+{{- end}}
+// Set it to false at startup to write zero values as given.
+var ApplyDefaults = true
+
+// applyDefaults fills unset attributes of the item with their defaults.
+func applyDefaults(item *SchemaItem) {
+    {{- range .DefaultAttributes}}
+    {{- if .IsOptional}}
+    if item.{{.GoName}} == nil {
+        item.{{.GoName}} = Ptr[{{.GoType}}]({{.DefaultValue}})
+    }
+    {{- else}}
+    if item.{{.GoName}} == {{.ZeroValue}} {
+        item.{{.GoName}} = {{.DefaultValue}}
+    }
+    {{- end}}
+    {{- end}}
+}
+`
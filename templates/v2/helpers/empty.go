@@ -0,0 +1,96 @@
+package helpers
+
+// EmptyValueHelpersTemplate provides the policy of generated writes for empty values DynamoDB rejects
+const EmptyValueHelpersTemplate = `
+// EmptyValuePolicy decides how generated writes handle values DynamoDB rejects:
+// empty sets, and empty strings and binaries of index key attributes.
+// Empty primary key attributes are always an error.
+type EmptyValuePolicy int
+
+const (
+    // EmptyOmit leaves empty values out of puts and removes their attributes in updates.
+    EmptyOmit EmptyValuePolicy = iota
+
+    // EmptyNull stores empty sets as NULL. Empty index keys are omitted, key attributes can't be NULL.
+    EmptyNull
+
+    // EmptyError rejects writes with empty values with an error matching ErrEmptyValue.
+    EmptyError
+)
+
+// EmptyValues is the policy applied by ItemInput, UpdateItemInput and UpdateItemInputFromRaw.
+// Set it at startup.
+// Example:
+//   EmptyValues = EmptyError
+var EmptyValues = EmptyOmit
+
+// ErrEmptyValue is returned for empty values of writes rejected by the policy and for empty primary keys.
+var ErrEmptyValue = errors.New("empty value")
+
+// applyEmptyValues applies EmptyValues to the marshaled attributes of a write
+// and returns the omitted attributes, which updates remove.
+func applyEmptyValues(av map[string]types.AttributeValue) ([]string, error) {
+    var omitted []string
+    for _, name := range slices.Sorted(maps.Keys(av)) {
+        primaryKey := name == TableSchema.HashKey || name == TableSchema.RangeKey
+        indexKey := isIndexKeyAttribute(name)
+        if !isEmptyValue(av[name], primaryKey || indexKey) {
+            continue
+        }
+        switch {
+        case primaryKey:
+            return nil, fmt.Errorf("%w: primary key attribute %s", ErrEmptyValue, name)
+        case EmptyValues == EmptyError:
+            return nil, fmt.Errorf("%w: attribute %s", ErrEmptyValue, name)
+        case EmptyValues == EmptyNull && !indexKey:
+            av[name] = &types.AttributeValueMemberNULL{Value: true}
+        default:
+            delete(av, name)
+            omitted = append(omitted, name)
+        }
+    }
+    return omitted, nil
+}
+
+// isEmptyValue reports whether DynamoDB rejects the value: an empty set,
+// or an empty string or binary of a key attribute.
+func isEmptyValue(value types.AttributeValue, key bool) bool {
+    switch v := value.(type) {
+    case *types.AttributeValueMemberSS:
+        return len(v.Value) == 0
+    case *types.AttributeValueMemberNS:
+        return len(v.Value) == 0
+    case *types.AttributeValueMemberBS:
+        return len(v.Value) == 0
+    case *types.AttributeValueMemberS:
+        return key && v.Value == ""
+    case *types.AttributeValueMemberB:
+        return key && len(v.Value) == 0
+    }
+    return false
+}
+
+// removeAttributes adds the removal of the attributes to an update.
+func removeAttributes(input *dynamodb.UpdateItemInput, names []string) {
+    if len(names) == 0 {
+        return
+    }
+    if input.ExpressionAttributeNames == nil {
+        input.ExpressionAttributeNames = map[string]string{}
+    }
+    parts := make([]string, len(names))
+    for i, name := range names {
+        nameKey := fmt.Sprintf("#rm%d", i)
+        input.ExpressionAttributeNames[nameKey] = name
+        parts[i] = nameKey
+    }
+    remove := strings.Join(parts, ", ")
+    updateExpression := aws.ToString(input.UpdateExpression)
+    if strings.Contains(updateExpression, "REMOVE ") {
+        updateExpression = strings.Replace(updateExpression, "REMOVE ", "REMOVE "+remove+", ", 1)
+    } else {
+        updateExpression = strings.TrimSpace(updateExpression + " REMOVE " + remove)
+    }
+    input.UpdateExpression = aws.String(updateExpression)
+}
+`
@@ -0,0 +1,122 @@
+package helpers
+
+// EncryptionHelpersTemplate provides sensitive attribute metadata and client-side encryption hooks
+const EncryptionHelpersTemplate = `
+// KMSKeyHint is the KMS key (ID, ARN or alias) the schema declares for sensitive attributes, empty if not set.
+// Pass it to the AttributeEncryptor, e.g. as the key of KMS data keys.
+const KMSKeyHint = {{printf "%q" .KMSKeyID}}
+
+// SensitiveAttributes lists the attributes marked sensitive in the schema.
+var SensitiveAttributes = []string{
+{{- range .SensitiveAttributes}}
+    {{printf "%q" .Name}},
+{{- end}}
+}
+
+// encryptedAttributes are the sensitive attributes encrypted by EncryptItem.
+// Key attributes of the table and indexes stay plaintext to remain queryable.
+var encryptedAttributes = []string{
+{{- range .EncryptedAttributes}}
+    {{printf "%q" .Name}},
+{{- end}}
+}
+
+// tableEncryptionContext is the encryption context shared by all sensitive attributes.
+var tableEncryptionContext = map[string]string{
+{{- range $key, $value := .EncryptionContext}}
+    {{printf "%q" $key}}: {{printf "%q" $value}},
+{{- end}}
+}
+
+// attributeEncryptionContexts are the encryption contexts declared per attribute.
+var attributeEncryptionContexts = map[string]map[string]string{
+{{- range .SensitiveAttributes}}{{if .EncryptionContext}}
+    {{printf "%q" .Name}}: {
+    {{- range $key, $value := .EncryptionContext}}
+        {{printf "%q" $key}}: {{printf "%q" $value}},
+    {{- end}}
+    },
+{{- end}}{{end}}
+}
+
+// IsSensitive returns true for attributes marked sensitive in the schema.
+func IsSensitive(attr string) bool {
+    for _, name := range SensitiveAttributes {
+        if name == attr {
+            return true
+        }
+    }
+    return false
+}
+
+// EncryptionContext returns the encryption context of a sensitive attribute: the table context,
+// the attribute context and the "table" and "attribute" names. Bind it to ciphertexts
+// (as KMS encryption context or AAD) so values can't be moved between attributes or tables.
+// Returns nil for attributes which aren't sensitive.
+func EncryptionContext(attr string) map[string]string {
+    if !IsSensitive(attr) {
+        return nil
+    }
+    ec := make(map[string]string, len(tableEncryptionContext)+len(attributeEncryptionContexts[attr])+2)
+    for k, v := range tableEncryptionContext {
+        ec[k] = v
+    }
+    for k, v := range attributeEncryptionContexts[attr] {
+        ec[k] = v
+    }
+    ec["table"] = TableName
+    ec["attribute"] = attr
+    return ec
+}
+
+// AttributeEncryptor is the client-side encryption hook of sensitive attributes,
+// e.g. backed by KMS data keys or the AWS Database Encryption SDK.
+// Encrypt usually returns a B value, Decrypt must restore the original value.
+type AttributeEncryptor interface {
+    Encrypt(ctx context.Context, attr string, value types.AttributeValue, encryptionContext map[string]string) (types.AttributeValue, error)
+    Decrypt(ctx context.Context, attr string, value types.AttributeValue, encryptionContext map[string]string) (types.AttributeValue, error)
+}
+
+// EncryptItem encrypts the sensitive non-key attributes of a marshaled item in place.
+// Example:
+//   item, err := ItemInput(record)
+//   if err != nil {
+//       return err
+//   }
+//   if err := EncryptItem(ctx, encryptor, item); err != nil {
+//       return err
+//   }
+//   _, err = client.PutItem(ctx, &dynamodb.PutItemInput{TableName: aws.String(TableName), Item: item})
+func EncryptItem(ctx context.Context, enc AttributeEncryptor, item map[string]types.AttributeValue) error {
+    return transformSensitive(item, func(attr string, value types.AttributeValue) (types.AttributeValue, error) {
+        return enc.Encrypt(ctx, attr, value, EncryptionContext(attr))
+    })
+}
+
+// DecryptItem decrypts the sensitive non-key attributes of an item read from the table in place,
+// before unmarshaling it into SchemaItem.
+func DecryptItem(ctx context.Context, enc AttributeEncryptor, item map[string]types.AttributeValue) error {
+    return transformSensitive(item, func(attr string, value types.AttributeValue) (types.AttributeValue, error) {
+        return enc.Decrypt(ctx, attr, value, EncryptionContext(attr))
+    })
+}
+
+// transformSensitive replaces every present encrypted attribute of item by fn of its value.
+func transformSensitive(item map[string]types.AttributeValue, fn func(string, types.AttributeValue) (types.AttributeValue, error)) error {
+    for _, attr := range encryptedAttributes {
+        value, ok := item[attr]
+        if !ok {
+            continue
+        }
+        if _, isNull := value.(*types.AttributeValueMemberNULL); isNull {
+            continue
+        }
+        transformed, err := fn(attr, value)
+        if err != nil {
+            return fmt.Errorf("attribute %s: %w", attr, err)
+        }
+        item[attr] = transformed
+    }
+    return nil
+}
+`
@@ -0,0 +1,96 @@
+package helpers
+
+// EnumHelpersTemplate provides validation and typed query and scan methods of enum attributes
+const EnumHelpersTemplate = `
+// validateEnums checks the enum attributes of an item before it is written, empty values are unset.
+func validateEnums(item SchemaItem) error {
+    {{- range .EnumAttributes}}
+    {{- if .IsOptional}}
+    if item.{{.GoName}} != nil && *item.{{.GoName}} != "" {
+        if err := item.{{.GoName}}.Validate(); err != nil {
+            return err
+        }
+    }
+    {{- else}}
+    if item.{{.GoName}} != "" {
+        if err := item.{{.GoName}}.Validate(); err != nil {
+            return err
+        }
+    }
+    {{- end}}
+    {{- end}}
+    return nil
+}
+
+// validateEnumUpdates checks the values of enum attributes in updates, given as enum values or strings.
+func validateEnumUpdates(updates map[string]any) error {
+    for name, value := range updates {
+        var err error
+        switch name {
+        {{- range .EnumAttributes}}
+        case Column{{.GoName}}:
+            err = validateEnumValue[{{.EnumType}}](value)
+        {{- end}}
+        }
+        if err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// enumValue is the constraint of the generated enum types.
+type enumValue interface {
+    ~string
+    Validate() error
+}
+
+// validateEnumValue checks an update value of an enum attribute of type E.
+func validateEnumValue[E enumValue](value any) error {
+    switch v := value.(type) {
+    case E:
+        return v.Validate()
+    case string:
+        return E(v).Validate()
+    case nil:
+        return nil
+    }
+    return fmt.Errorf("invalid value type %T, expected %T", value, *new(E))
+}
+
+// enumValues converts enum values to the values of a Filter condition.
+func enumValues[E ~string](values []E) []any {
+    out := make([]any, len(values))
+    for i, v := range values {
+        out[i] = v
+    }
+    return out
+}
+{{- range .EnumAttributes}}
+{{- if $.IsIndexKey .Name}}
+
+// With{{.GoName}} adds the key condition {{.Name}} = value for querying an index on {{.Name}}.
+func (qb *QueryBuilder) With{{.GoName}}(value {{.EnumType}}) *QueryBuilder {
+    return qb.With(Column{{.GoName}}, EQ, value)
+}
+{{- end}}
+
+// Filter{{.GoName}} filters items whose {{.Name}} is one of the values.
+func (qb *QueryBuilder) Filter{{.GoName}}(values ...{{.EnumType}}) *QueryBuilder {
+    if len(values) == 1 {
+        return qb.Filter(Column{{.GoName}}, EQ, values[0])
+    }
+    return qb.Filter(Column{{.GoName}}, IN, enumValues(values)...)
+}
+{{- if not $.NoScan}}
+
+// Filter{{.GoName}} filters items whose {{.Name}} is one of the values.
+func (sb *ScanBuilder) Filter{{.GoName}}(values ...{{.EnumType}}) *ScanBuilder {
+    if len(values) == 1 {
+        return sb.Filter(Column{{.GoName}}, EQ, values[0])
+    }
+    return sb.Filter(Column{{.GoName}}, IN, enumValues(values)...)
+}
+{{- end}}
+{{- end}}
+`
@@ -0,0 +1,179 @@
+package helpers
+
+// EventLogHelpersTemplate provides event sourcing operations for tables declared with "event_log" in the schema
+const EventLogHelpersTemplate = `
+{{- if .EventLog}}
+{{- $aggregate := .Attribute .HashKey}}
+{{- $sequence := .Attribute .RangeKey}}
+// SnapshotKeyPrefix is prepended to aggregate IDs in the hash key of snapshot items.
+// Snapshots are stored next to the events: scans return them too,
+// skip hash keys starting with SnapshotKeyPrefix.
+const SnapshotKeyPrefix = {{printf "%q" .SnapshotKeyPrefix}}
+
+// SnapshotEvery is the number of events between snapshots suggested by SnapshotDue.
+const SnapshotEvery = {{.SnapshotEvery}}
+
+// snapshotStateAttribute is the attribute of snapshot items holding the marshaled state.
+const snapshotStateAttribute = "snapshot_state"
+
+// ErrEventConflict is returned by AppendEvent when the sequence of the event isn't the next one
+// of its aggregate: another writer appended it first, or previous events are missing.
+var ErrEventConflict = errors.New("event sequence conflict")
+
+// AppendEvent appends the event to the log of its aggregate. Its {{$sequence.Name}} must be the next sequence:
+// 1 for the first event, the sequence of the last event plus one otherwise. Later events are appended
+// in a transaction checking the previous event exists, the log never has gaps.
+// Returns an error matching ErrEventConflict if the sequence is taken or isn't the next one:
+// load the new events, check the command against them and append again.
+// Example:
+//   event.{{$sequence.GoName}} = last.{{$sequence.GoName}} + 1
+//   if err := AppendEvent(ctx, client, event); errors.Is(err, ErrEventConflict) {
+//       // reload and retry
+//   }
+func AppendEvent(ctx context.Context, client DynamoDBAPI, event SchemaItem) error {
+    if event.{{$sequence.GoName}} < 1 {
+        return fmt.Errorf("event sequence must start at 1, got %d", event.{{$sequence.GoName}})
+    }
+    input, err := PutItemInputIf(event, expression.Name(TableSchema.HashKey).AttributeNotExists())
+    if err != nil {
+        return err
+    }
+    if event.{{$sequence.GoName}} == 1 {
+        _, err = client.PutItem(ctx, input)
+    } else {
+        put := types.TransactWriteItem{
+            Put: &types.Put{
+                TableName:                 input.TableName,
+                Item:                      input.Item,
+                ConditionExpression:       input.ConditionExpression,
+                ExpressionAttributeNames:  input.ExpressionAttributeNames,
+                ExpressionAttributeValues: input.ExpressionAttributeValues,
+            },
+        }
+        err = NewTransactionBuilder().
+            Add(put, nil).
+            ConditionCheck(event.{{$aggregate.GoName}}, event.{{$sequence.GoName}}-1, expression.Name(TableSchema.HashKey).AttributeExists()).
+            Execute(ctx, client)
+    }
+    var (
+        failed   *types.ConditionalCheckFailedException
+        canceled *types.TransactionCanceledException
+    )
+    switch {
+    case errors.As(err, &failed), errors.As(err, &canceled):
+        return fmt.Errorf("%w: %s at %d", ErrEventConflict, event.{{$aggregate.GoName}}, event.{{$sequence.GoName}})
+    case err != nil:
+        return fmt.Errorf("failed to append event: %w", err)
+    }
+    return nil
+}
+
+// LoadEvents returns the events of the aggregate with a sequence after since, in order:
+// 0 for all events, the sequence returned by LoadLatestSnapshot to replay from a snapshot.
+// Example:
+//   events, err := LoadEvents(ctx, client, {{.ExampleKey $aggregate.Name}}, 0)
+func LoadEvents(ctx context.Context, client DynamoDBAPI, aggregateID string, since int64) ([]SchemaItem, error) {
+    keyCondition := expression.Key(TableSchema.HashKey).Equal(expression.Value(aggregateID)).
+        And(expression.Key(TableSchema.RangeKey).GreaterThan(expression.Value(since)))
+    expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+    if err != nil {
+        return nil, fmt.Errorf("failed to build event key condition: %v", err)
+    }
+    input := &dynamodb.QueryInput{
+        TableName:                 aws.String(TableSchema.TableName),
+        KeyConditionExpression:    expr.KeyCondition(),
+        ExpressionAttributeNames:  expr.Names(),
+        ExpressionAttributeValues: expr.Values(),
+        ConsistentRead:            aws.Bool(true),
+    }
+    var events []SchemaItem
+    for {
+        out, err := client.Query(ctx, input)
+        if err != nil {
+            return nil, fmt.Errorf("failed to query events of %s: %w", aggregateID, err)
+        }
+        for _, av := range out.Items {
+            event, err := UnmarshalItem(av)
+            if err != nil {
+                return nil, err
+            }
+            events = append(events, *event)
+        }
+        if len(out.LastEvaluatedKey) == 0 {
+            return events, nil
+        }
+        input.ExclusiveStartKey = out.LastEvaluatedKey
+    }
+}
+
+// SnapshotDue reports whether a snapshot should be saved after the event with the sequence,
+// every SnapshotEvery events.
+func SnapshotDue(sequence int64) bool {
+    return sequence > 0 && sequence%SnapshotEvery == 0
+}
+
+// SaveSnapshot stores the state of the aggregate after the event with the sequence,
+// marshaled with attributevalue. Older snapshots are kept, LoadLatestSnapshot reads the newest.
+// Example:
+//   if SnapshotDue(sequence) {
+//       err = SaveSnapshot(ctx, client, {{.ExampleKey $aggregate.Name}}, sequence, state)
+//   }
+func SaveSnapshot(ctx context.Context, client DynamoDBAPI, aggregateID string, sequence int64, state any) error {
+    if sequence < 1 {
+        return fmt.Errorf("snapshot sequence must be at least 1, got %d", sequence)
+    }
+    item, err := KeyInputFromRaw(SnapshotKeyPrefix+aggregateID, {{$sequence.GoType}}(sequence))
+    if err != nil {
+        return err
+    }
+    if item[snapshotStateAttribute], err = attributevalue.Marshal(state); err != nil {
+        return fmt.Errorf("failed to marshal snapshot state: %v", err)
+    }
+    if _, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+        TableName: aws.String(TableSchema.TableName),
+        Item:      item,
+    }); err != nil {
+        return fmt.Errorf("failed to save snapshot of %s: %w", aggregateID, err)
+    }
+    return nil
+}
+
+// LoadLatestSnapshot unmarshals the latest snapshot of the aggregate into state and returns its sequence,
+// 0 leaving state untouched if the aggregate has none. Replay the events after it from LoadEvents.
+// Example:
+//   var state AccountState
+//   since, err := LoadLatestSnapshot(ctx, client, {{.ExampleKey $aggregate.Name}}, &state)
+//   events, err := LoadEvents(ctx, client, {{.ExampleKey $aggregate.Name}}, since)
+func LoadLatestSnapshot(ctx context.Context, client DynamoDBAPI, aggregateID string, state any) (int64, error) {
+    expr, err := expression.NewBuilder().
+        WithKeyCondition(expression.Key(TableSchema.HashKey).Equal(expression.Value(SnapshotKeyPrefix + aggregateID))).
+        Build()
+    if err != nil {
+        return 0, fmt.Errorf("failed to build snapshot key condition: %v", err)
+    }
+    out, err := client.Query(ctx, &dynamodb.QueryInput{
+        TableName:                 aws.String(TableSchema.TableName),
+        KeyConditionExpression:    expr.KeyCondition(),
+        ExpressionAttributeNames:  expr.Names(),
+        ExpressionAttributeValues: expr.Values(),
+        ScanIndexForward:          aws.Bool(false),
+        Limit:                     aws.Int32(1),
+        ConsistentRead:            aws.Bool(true),
+    })
+    if err != nil {
+        return 0, fmt.Errorf("failed to query snapshots of %s: %w", aggregateID, err)
+    }
+    if len(out.Items) == 0 {
+        return 0, nil
+    }
+    var sequence int64
+    if err := attributevalue.Unmarshal(out.Items[0][TableSchema.RangeKey], &sequence); err != nil {
+        return 0, fmt.Errorf("failed to unmarshal snapshot sequence: %v", err)
+    }
+    if err := attributevalue.Unmarshal(out.Items[0][snapshotStateAttribute], state); err != nil {
+        return 0, fmt.Errorf("failed to unmarshal snapshot state: %v", err)
+    }
+    return sequence, nil
+}
+{{- end}}
+`
@@ -0,0 +1,132 @@
+package helpers
+
+// FieldVersionsHelpersTemplate provides per-attribute modification times declared with "field_versions" in the schema
+const FieldVersionsHelpersTemplate = `
+{{- with .FieldVersionsAttribute}}
+// FieldVersionsAttribute is the map attribute holding the last modification time of each attribute
+// in Unix milliseconds. Generated updates record the attributes they set; puts keep the versions
+// of the item and write an empty map for items without, which partial updates need to record into.
+const FieldVersionsAttribute = Column{{.GoName}}
+
+// FieldVersionClock returns the time recorded for modified attributes.
+// Replace it in tests for deterministic versions.
+var FieldVersionClock = time.Now
+
+// trackedFields are the attributes whose modifications are recorded.
+var trackedFields = map[string]bool{
+    {{- range $.AllAttributes}}
+    {{- if and (ne .Name $.HashKey) (ne .Name $.RangeKey) (not .FieldVersions) (not .Checksum)}}
+    Column{{.GoName}}: true,
+    {{- end}}
+    {{- end}}
+}
+
+// FieldModifiedAt returns the last modification time of an attribute of the item, zero if unknown.
+{{- with $.ExampleAttribute}}
+// Example:
+//   changed := FieldModifiedAt(item, Column{{.GoName}})
+{{- end}}
+func FieldModifiedAt(item SchemaItem, attr string) time.Time {
+    ms := fieldVersion(item.{{.GoName}}, attr)
+    if ms == 0 {
+        return time.Time{}
+    }
+    return time.UnixMilli(ms)
+}
+
+// FieldVersions returns the last modification times of the attributes of the item.
+func FieldVersions(item SchemaItem) map[string]time.Time {
+    versions := make(map[string]time.Time, len(item.{{.GoName}}))
+    for attr := range item.{{.GoName}} {
+        if ms := fieldVersion(item.{{.GoName}}, attr); ms > 0 {
+            versions[attr] = time.UnixMilli(ms)
+        }
+    }
+    return versions
+}
+
+// TouchFields records attributes of the item as modified now, e.g. before a put
+// of locally changed attributes with MergePutItems and MergeFieldVersions.
+func TouchFields(item *SchemaItem, attrs ...string) {
+    if item.{{.GoName}} == nil {
+        item.{{.GoName}} = map[string]any{}
+    }
+    now := FieldVersionClock().UnixMilli()
+    for _, attr := range attrs {
+        if trackedFields[attr] {
+            item.{{.GoName}}[attr] = now
+        }
+    }
+}
+
+// fieldVersion returns the version of an attribute in Unix milliseconds, 0 if unknown.
+func fieldVersion(versions map[string]any, attr string) int64 {
+    switch v := versions[attr].(type) {
+    case int64:
+        return v
+    case int:
+        return int64(v)
+    case float64:
+        return int64(v)
+    }
+    return 0
+}
+
+// initFieldVersions makes a put write the field versions map, partial updates record into it.
+func initFieldVersions(item *SchemaItem) {
+    if item.{{.GoName}} == nil {
+        item.{{.GoName}} = map[string]any{}
+    }
+}
+
+// stampFieldVersions records all tracked attributes as modified at now in a full update of the item.
+func stampFieldVersions(item *SchemaItem, now time.Time) {
+    initFieldVersions(item)
+    for attr := range trackedFields {
+        item.{{.GoName}}[attr] = now.UnixMilli()
+    }
+}
+
+// recordFieldVersions adds recording the attributes as modified at now to a partial update.
+// The item needs the field versions map, which generated puts write.
+func recordFieldVersions(input *dynamodb.UpdateItemInput, attrs []string, now time.Time) {
+    var parts []string
+    for _, attr := range attrs {
+        if !trackedFields[attr] {
+            continue
+        }
+        name := fmt.Sprintf("#fv%d", len(parts))
+        if input.ExpressionAttributeNames == nil {
+            input.ExpressionAttributeNames = map[string]string{}
+        }
+        input.ExpressionAttributeNames[name] = attr
+        parts = append(parts, fmt.Sprintf("#fv.%s = :fvnow", name))
+    }
+    if len(parts) == 0 {
+        return
+    }
+    input.ExpressionAttributeNames["#fv"] = FieldVersionsAttribute
+    if input.ExpressionAttributeValues == nil {
+        input.ExpressionAttributeValues = map[string]types.AttributeValue{}
+    }
+    input.ExpressionAttributeValues[":fvnow"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(now.UnixMilli(), 10)}
+
+    set := strings.Join(parts, ", ")
+    updateExpression := aws.ToString(input.UpdateExpression)
+    if strings.Contains(updateExpression, "SET ") {
+        updateExpression = strings.Replace(updateExpression, "SET ", "SET "+set+", ", 1)
+    } else {
+        updateExpression += " SET " + set
+    }
+    input.UpdateExpression = aws.String(updateExpression)
+}
+
+// validateFieldVersionsUpdate rejects updates setting the field versions, which generated updates maintain.
+func validateFieldVersionsUpdate(updates map[string]any) error {
+    if _, ok := updates[FieldVersionsAttribute]; ok {
+        return fmt.Errorf("field versions attribute %s can't be updated", FieldVersionsAttribute)
+    }
+    return nil
+}
+{{- end}}
+`
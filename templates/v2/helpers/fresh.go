@@ -0,0 +1,144 @@
+package helpers
+
+// FreshReadHelpersTemplate provides read-your-writes helpers returning typed items after updates
+// and the consistency fallback of reads
+const FreshReadHelpersTemplate = `
+// ReadFallback is the consistency fallback of reads by GetItem, GetItemFromRaw and QueryBuilder.
+// Reads with a fallback need strong consistency, so queries never select a GSI.
+type ReadFallback int
+
+const (
+    // NoReadFallback sends reads once with the requested consistency.
+    NoReadFallback ReadFallback = iota
+
+    // StrongThenEventual reads strongly consistent and retries eventually consistent if the read fails,
+    // e.g. when consistent reads are throttled or the region of a Global Tables replica is impaired.
+    StrongThenEventual
+
+    // EventualThenStrong reads eventually consistent and retries strongly consistent
+    // if a read item fails the FreshnessCheck (GetItem also retries missing items).
+    EventualThenStrong
+)
+
+// FreshnessCheck reports whether an eventually consistent read of the item is fresh enough,
+// e.g. its version or update time is not older than the last write of the caller.
+type FreshnessCheck func(item SchemaItem) bool
+{{- with .VersionAttribute}}
+
+// FreshFromVersion returns a FreshnessCheck accepting items with at least the version.
+func FreshFromVersion(version {{.GoType}}) FreshnessCheck {
+    return func(item SchemaItem) bool {
+        return item.{{.GoName}} >= version
+    }
+}
+{{- end}}
+
+// freshItems reports whether all raw items pass the freshness check.
+// Items that can't be unmarshaled are stale, so they are read again.
+func freshItems(raw []map[string]types.AttributeValue, fresh FreshnessCheck) bool {
+    if fresh == nil {
+        return true
+    }
+    for _, av := range raw {
+        var item SchemaItem
+        if err := attributevalue.UnmarshalMap(av, &item); err != nil || !fresh(item) {
+            return false
+        }
+    }
+    return true
+}
+
+// UpdateAndGet runs the update and returns the item as stored after it.
+// The update is sent with ReturnValues ALL_NEW; if the response carries no attributes
+// (e.g. a client decorator or emulator drops them) the item is read back with a consistent read.
+// Works with every UpdateItemInput builder: UpdateItemInputFromRaw, IncrementAttribute, AddToSet, ...
+{{- with .ExampleAttribute}}
+// Example:
+//   input, err := UpdateItemInputFromRaw({{$.ExampleKey $.HashKey}}, {{if $.RangeKey}}{{$.ExampleKey $.RangeKey}}{{else}}nil{{end}}, map[string]any{
+//       Column{{.GoName}}: {{.ExampleValue}},
+//   })
+//   if err != nil {
+//       return err
+//   }
+//   item, err := UpdateAndGet(ctx, client, input)
+{{- end}}
+func UpdateAndGet(ctx context.Context, client DynamoDBAPI, input *dynamodb.UpdateItemInput) (*SchemaItem, error) {
+    if input == nil {
+        return nil, fmt.Errorf("update input is required")
+    }
+    input.ReturnValues = types.ReturnValueAllNew
+    out, err := client.UpdateItem(ctx, input)
+    {{- if .GuardedAttributes}}
+    if violation := CheckGuards(input, err); violation != err {
+        return nil, violation
+    }
+    {{- end}}
+    if err != nil {
+        return nil, fmt.Errorf("failed to update item: %v", err)
+    }
+    if len(out.Attributes) == 0 {
+        return GetConsistent(ctx, client, input.Key)
+    }
+    var item SchemaItem
+    if err := attributevalue.UnmarshalMap(out.Attributes, &item); err != nil {
+        return nil, fmt.Errorf("failed to unmarshal updated item: %v", err)
+    }
+    return &item, nil
+}
+
+// TransactWriteAndGet runs the transaction and returns the items of this table it put or updated,
+// in transaction order. TransactWriteItems can't return item attributes, so every item is read back
+// with a consistent read after the transaction commits.
+func TransactWriteAndGet(ctx context.Context, client DynamoDBAPI, input *dynamodb.TransactWriteItemsInput) ([]SchemaItem, error) {
+    if input == nil {
+        return nil, fmt.Errorf("transaction input is required")
+    }
+    if _, err := client.TransactWriteItems(ctx, input); err != nil {
+        return nil, fmt.Errorf("failed to execute transaction: %v", err)
+    }
+    var items []SchemaItem
+    for _, write := range input.TransactItems {
+        var key map[string]types.AttributeValue
+        switch {
+        case write.Update != nil && aws.ToString(write.Update.TableName) == TableSchema.TableName:
+            key = write.Update.Key
+        case write.Put != nil && aws.ToString(write.Put.TableName) == TableSchema.TableName:
+            key = map[string]types.AttributeValue{TableSchema.HashKey: write.Put.Item[TableSchema.HashKey]}
+            if TableSchema.RangeKey != "" {
+                key[TableSchema.RangeKey] = write.Put.Item[TableSchema.RangeKey]
+            }
+        default:
+            continue
+        }
+        item, err := GetConsistent(ctx, client, key)
+        if err != nil {
+            return nil, err
+        }
+        if item != nil {
+            items = append(items, *item)
+        }
+    }
+    return items, nil
+}
+
+// GetConsistent reads the item by key with a strongly consistent read.
+// Returns nil without error if the item doesn't exist.
+func GetConsistent(ctx context.Context, client DynamoDBAPI, key map[string]types.AttributeValue) (*SchemaItem, error) {
+    out, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+        TableName:      aws.String(TableSchema.TableName),
+        Key:            key,
+        ConsistentRead: aws.Bool(true),
+    })
+    if err != nil {
+        return nil, fmt.Errorf("failed to read item: %v", err)
+    }
+    if len(out.Item) == 0 {
+        return nil, nil
+    }
+    var item SchemaItem
+    if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+        return nil, fmt.Errorf("failed to unmarshal item: %v", err)
+    }
+    return &item, nil
+}
+`
@@ -0,0 +1,159 @@
+package helpers
+
+// GuardHelpersTemplate provides condition expressions and typed errors of attribute change guards
+const GuardHelpersTemplate = `
+// Change guards of attributes declared in the schema.
+const (
+    // GuardImmutable rejects changes of the attribute once it is set.
+    GuardImmutable = "immutable"
+
+    // GuardMonotonicIncreasing rejects updates decreasing the attribute.
+    GuardMonotonicIncreasing = "monotonic_increasing"
+)
+
+// AttributeGuards maps guarded attributes to their guard.
+var AttributeGuards = map[string]string{
+    {{- range .GuardedAttributes}}
+    Column{{.GoName}}: {{if eq .Guard "immutable"}}GuardImmutable{{else}}GuardMonotonicIncreasing{{end}},
+    {{- end}}
+}
+
+// GuardViolationError reports an update rejected by an attribute change guard.
+// Err is the ConditionalCheckFailedException if DynamoDB rejected the update,
+// nil if the update was rejected before it was sent.
+type GuardViolationError struct {
+    Attribute string
+    Guard     string
+    Err       error
+}
+
+// Error implements error.
+func (e *GuardViolationError) Error() string {
+    return fmt.Sprintf("attribute '%s' violates guard '%s'", e.Attribute, e.Guard)
+}
+
+// Unwrap returns the ConditionalCheckFailedException of the update.
+func (e *GuardViolationError) Unwrap() error {
+    return e.Err
+}
+
+// applyGuards adds the conditions of guarded attributes set by the update to its condition expression:
+//   immutable:            attribute_not_exists(a) OR a = :new
+//   monotonic_increasing: attribute_not_exists(a) OR a <= :new
+// The old item is returned on condition failure, so CheckGuards can name the violated guard.
+func applyGuards(input *dynamodb.UpdateItemInput) {
+    var conditions []string
+    for _, nameKey := range sortedKeys(input.ExpressionAttributeNames) {
+        guard, ok := AttributeGuards[input.ExpressionAttributeNames[nameKey]]
+        if !ok {
+            continue
+        }
+        valueKey := guardValueKey(nameKey)
+        if _, ok := input.ExpressionAttributeValues[valueKey]; !ok {
+            continue
+        }
+        op := "="
+        if guard == GuardMonotonicIncreasing {
+            op = "<="
+        }
+        conditions = append(conditions, fmt.Sprintf("(attribute_not_exists(%s) OR %s %s %s)", nameKey, nameKey, op, valueKey))
+    }
+    if len(conditions) == 0 {
+        return
+    }
+    if input.ConditionExpression != nil {
+        conditions = append(conditions, "("+aws.ToString(input.ConditionExpression)+")")
+    }
+    input.ConditionExpression = aws.String(strings.Join(conditions, " AND "))
+    input.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+}
+
+// CheckGuards converts the error of running a guarded update input into a *GuardViolationError
+// if a guard rejected it; other errors, including failures of custom conditions, are returned as is.
+// Example:
+//   if _, err := client.UpdateItem(ctx, input); err != nil {
+//       var violation *GuardViolationError
+//       if errors.As(CheckGuards(input, err), &violation) {
+//           // violation.Attribute can't be changed this way
+//       }
+//   }
+func CheckGuards(input *dynamodb.UpdateItemInput, err error) error {
+    var failed *types.ConditionalCheckFailedException
+    if input == nil || !errors.As(err, &failed) || len(failed.Item) == 0 {
+        return err
+    }
+    for _, nameKey := range sortedKeys(input.ExpressionAttributeNames) {
+        name := input.ExpressionAttributeNames[nameKey]
+        guard, ok := AttributeGuards[name]
+        if !ok {
+            continue
+        }
+        old, ok := failed.Item[name]
+        value, set := input.ExpressionAttributeValues[guardValueKey(nameKey)]
+        if !ok || !set {
+            continue
+        }
+        cmp, comparable := compareGuardValues(old, value)
+        if (guard == GuardImmutable && cmp != 0) || (guard == GuardMonotonicIncreasing && comparable && cmp > 0) {
+            return &GuardViolationError{Attribute: name, Guard: guard, Err: err}
+        }
+    }
+    return err
+}
+
+// validateGuardedChange rejects atomic updates the guard of the attribute forbids:
+// immutable attributes can't be changed by ADD or DELETE, monotonic ones can't be decremented.
+func validateGuardedChange(attributeName string, decrease bool) error {
+    switch AttributeGuards[attributeName] {
+    case GuardImmutable:
+        return &GuardViolationError{Attribute: attributeName, Guard: GuardImmutable}
+    case GuardMonotonicIncreasing:
+        if decrease {
+            return &GuardViolationError{Attribute: attributeName, Guard: GuardMonotonicIncreasing}
+        }
+    }
+    return nil
+}
+
+// guardValueKey returns the value placeholder of an update expression name placeholder ("#attr0" -> ":val0").
+func guardValueKey(nameKey string) string {
+    return ":val" + strings.TrimPrefix(nameKey, "#attr")
+}
+
+// compareGuardValues compares two attribute values: numbers numerically, strings and binaries lexically.
+// Other types are only checked for equality; comparable is false for them.
+func compareGuardValues(a, b types.AttributeValue) (cmp int, comparable bool) {
+    switch av := a.(type) {
+    case *types.AttributeValueMemberN:
+        if bv, ok := b.(*types.AttributeValueMemberN); ok {
+            x, okX := new(big.Float).SetString(av.Value)
+            y, okY := new(big.Float).SetString(bv.Value)
+            if okX && okY {
+                return x.Cmp(y), true
+            }
+        }
+    case *types.AttributeValueMemberS:
+        if bv, ok := b.(*types.AttributeValueMemberS); ok {
+            return strings.Compare(av.Value, bv.Value), true
+        }
+    case *types.AttributeValueMemberB:
+        if bv, ok := b.(*types.AttributeValueMemberB); ok {
+            return bytes.Compare(av.Value, bv.Value), true
+        }
+    }
+    if reflect.DeepEqual(a, b) {
+        return 0, false
+    }
+    return 1, false
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys(m map[string]string) []string {
+    keys := make([]string, 0, len(m))
+    for k := range m {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    return keys
+}
+`
@@ -0,0 +1,100 @@
+package helpers
+
+// HealthHelpersTemplate provides HealthCheck reporting the availability of the table for readiness probes
+const HealthHelpersTemplate = `
+// ErrTableUnavailable is returned by HealthCheck when the table, one of its indexes
+{{- if .TTLAttribute}} or its TTL{{end}} isn't in a serving state.
+var ErrTableUnavailable = errors.New("table unavailable")
+
+// HealthCheck reports whether the table is ready to serve the generated code:
+// the table and its global secondary indexes are ACTIVE or UPDATING
+{{- with .TTLAttribute}}, TTL is enabled on {{.Name}}{{end}}
+// and a query limited to 1 item succeeds, which also checks data access permissions.
+// Returns an error matching ErrTableUnavailable for a table which isn't ready,
+// other errors for failed requests. Costs one DescribeTable
+{{- if .TTLAttribute}}, one DescribeTimeToLive{{end}} and half a read capacity unit.
+// Example:
+//   http.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+//       if err := HealthCheck(r.Context(), client); err != nil {
+//           http.Error(w, err.Error(), http.StatusServiceUnavailable)
+//       }
+//   })
+func HealthCheck(ctx context.Context, client DynamoDBAPI) error {
+    out, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+        TableName: aws.String(TableName),
+    })
+    if err != nil {
+        return fmt.Errorf("failed to describe table %s: %v", TableName, err)
+    }
+    if out.Table == nil {
+        return fmt.Errorf("%w: table %s has an empty description", ErrTableUnavailable, TableName)
+    }
+    if status := out.Table.TableStatus; !isServingStatus(string(status)) {
+        return fmt.Errorf("%w: table %s is %s", ErrTableUnavailable, TableName, status)
+    }
+
+    indexes := make(map[string]types.IndexStatus, len(out.Table.GlobalSecondaryIndexes))
+    for _, gsi := range out.Table.GlobalSecondaryIndexes {
+        indexes[aws.ToString(gsi.IndexName)] = gsi.IndexStatus
+    }
+    for _, idx := range TableSchema.SecondaryIndexes {
+        if idx.Type != "GSI" {
+            continue
+        }
+        status, ok := indexes[idx.Name]
+        if !ok {
+            return fmt.Errorf("%w: index %s not found on table %s", ErrTableUnavailable, idx.Name, TableName)
+        }
+        if !isServingStatus(string(status)) {
+            return fmt.Errorf("%w: index %s is %s", ErrTableUnavailable, idx.Name, status)
+        }
+    }
+    {{- with .TTLAttribute}}
+
+    ttl, err := client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{
+        TableName: aws.String(TableName),
+    })
+    if err != nil {
+        return fmt.Errorf("failed to describe TTL of table %s: %v", TableName, err)
+    }
+    if ttl.TimeToLiveDescription == nil {
+        return fmt.Errorf("%w: table %s has no TTL description", ErrTableUnavailable, TableName)
+    }
+    switch status := ttl.TimeToLiveDescription.TimeToLiveStatus; status {
+    case types.TimeToLiveStatusEnabled, types.TimeToLiveStatusEnabling:
+        if name := aws.ToString(ttl.TimeToLiveDescription.AttributeName); name != TTLAttribute {
+            return fmt.Errorf("%w: TTL of table %s is on %q, generated code expects %q", ErrTableUnavailable, TableName, name, TTLAttribute)
+        }
+    default:
+        return fmt.Errorf("%w: TTL of table %s is %s", ErrTableUnavailable, TableName, status)
+    }
+    {{- end}}
+
+    if _, err := client.Query(ctx, &dynamodb.QueryInput{
+        TableName:                 aws.String(TableName),
+        KeyConditionExpression:    aws.String("#pk = :pk"),
+        ExpressionAttributeNames:  map[string]string{"#pk": TableSchema.HashKey},
+        ExpressionAttributeValues: map[string]types.AttributeValue{":pk": healthProbeKey()},
+        Limit:                     aws.Int32(1),
+    }); err != nil {
+        return fmt.Errorf("failed to query table %s: %v", TableName, err)
+    }
+    return nil
+}
+
+// isServingStatus reports whether a table or index status serves reads and writes.
+func isServingStatus(status string) bool {
+    return status == string(types.TableStatusActive) || status == string(types.TableStatusUpdating)
+}
+
+// healthProbeKey returns the hash key value queried by HealthCheck, a value of the hash key type.
+func healthProbeKey() types.AttributeValue {
+    switch TableSchema.FieldsMap[TableSchema.HashKey].DynamoType {
+    case "N":
+        return &types.AttributeValueMemberN{Value: "0"}
+    case "B":
+        return &types.AttributeValueMemberB{Value: []byte{0}}
+    }
+    return &types.AttributeValueMemberS{Value: "godyno:health"}
+}
+`
@@ -0,0 +1,110 @@
+package helpers
+
+// HooksTemplate provides runtime-registered lifecycle hooks of generated write paths
+const HooksTemplate = `
+// hooks are the lifecycle hooks registered for this package.
+var hooks struct {
+    mu           sync.RWMutex
+    beforePut    []func(item *SchemaItem) error
+    afterPut     []func(ctx context.Context, item SchemaItem) error
+    beforeUpdate []func(hashKeyValue any, rangeKeyValue any, updates map[string]any) error
+}
+
+// BeforePut registers a hook called with every item written as a whole, before it is marshaled:
+// by ItemInput, PutItem, TxPut and TransactionBuilder.Put. The hook may modify the item
+// (derive computed attributes) or reject it with an error (enforce invariants).
+// Hooks run in registration order; register them at startup.
+{{- with .ExampleAttribute}}
+// Example:
+//   BeforePut(func(item *SchemaItem) error {
+//       item.{{.GoName}} = {{.ExampleValue}} // derive a computed attribute
+//       return nil
+//   })
+{{- end}}
+func BeforePut(fn func(item *SchemaItem) error) {
+    hooks.mu.Lock()
+    defer hooks.mu.Unlock()
+    hooks.beforePut = append(hooks.beforePut, fn)
+}
+
+// AfterPut registers a hook called after PutItem wrote an item, e.g. to emit a domain event.
+// An error of the hook is returned by PutItem, the item stays written.
+func AfterPut(fn func(ctx context.Context, item SchemaItem) error) {
+    hooks.mu.Lock()
+    defer hooks.mu.Unlock()
+    hooks.afterPut = append(hooks.afterPut, fn)
+}
+
+// BeforeUpdate registers a hook called with the key and the attribute updates of every partial
+// update, before the update input is built: by UpdateItemInputFromRaw, UpdateItemInputWithCondition,
+// TxUpdate and TransactionBuilder.Update. The hook may add or change updates or reject them.
+func BeforeUpdate(fn func(hashKeyValue any, rangeKeyValue any, updates map[string]any) error) {
+    hooks.mu.Lock()
+    defer hooks.mu.Unlock()
+    hooks.beforeUpdate = append(hooks.beforeUpdate, fn)
+}
+
+// ResetHooks removes all registered hooks, e.g. between tests.
+func ResetHooks() {
+    hooks.mu.Lock()
+    defer hooks.mu.Unlock()
+    hooks.beforePut = nil
+    hooks.afterPut = nil
+    hooks.beforeUpdate = nil
+}
+
+// runBeforePut calls the BeforePut hooks in order, stopping at the first error.
+func runBeforePut(item *SchemaItem) error {
+    hooks.mu.RLock()
+    fns := hooks.beforePut
+    hooks.mu.RUnlock()
+    for _, fn := range fns {
+        if err := fn(item); err != nil {
+            return fmt.Errorf("before put hook: %w", err)
+        }
+    }
+    return nil
+}
+
+// runAfterPut calls the AfterPut hooks in order, stopping at the first error.
+func runAfterPut(ctx context.Context, item SchemaItem) error {
+    hooks.mu.RLock()
+    fns := hooks.afterPut
+    hooks.mu.RUnlock()
+    for _, fn := range fns {
+        if err := fn(ctx, item); err != nil {
+            return fmt.Errorf("after put hook: %w", err)
+        }
+    }
+    return nil
+}
+
+// runBeforeUpdate calls the BeforeUpdate hooks in order, stopping at the first error.
+func runBeforeUpdate(hashKeyValue any, rangeKeyValue any, updates map[string]any) error {
+    hooks.mu.RLock()
+    fns := hooks.beforeUpdate
+    hooks.mu.RUnlock()
+    for _, fn := range fns {
+        if err := fn(hashKeyValue, rangeKeyValue, updates); err != nil {
+            return fmt.Errorf("before update hook: %w", err)
+        }
+    }
+    return nil
+}
+
+// PutItem writes the item like a put of ItemInput: BeforePut hooks run before the write,
+// AfterPut hooks after it succeeded, with the item as written.
+func PutItem(ctx context.Context, client DynamoDBAPI, item SchemaItem) error {
+    written, av, err := prepareItem(item)
+    if err != nil {
+        return err
+    }
+    if _, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+        TableName: aws.String(TableSchema.TableName),
+        Item:      av,
+    }); err != nil {
+        return fmt.Errorf("failed to put item: %w", err)
+    }
+    return runAfterPut(ctx, written)
+}
+`
@@ -0,0 +1,173 @@
+package helpers
+
+// LeaseHelpersTemplate provides locks with fencing tokens stored in the table, declared with "locks" in the schema
+const LeaseHelpersTemplate = `
+{{- if .Locks}}
+// LockKeyPrefix is prepended to resource IDs in the hash key of lock items.
+// Lock items are stored next to the items of the table: scans return them too,
+// skip hash keys starting with LockKeyPrefix.
+const LockKeyPrefix = {{printf "%q" .LockKeyPrefix}}
+
+// Attributes of lock items.
+const (
+    lockOwnerAttribute   = "lock_owner"
+    lockTokenAttribute   = "lock_token"
+    lockExpiresAttribute = "lock_expires_at"
+)
+
+// ErrLockHeld is returned by Lock when another owner holds an unexpired lease of the resource.
+var ErrLockHeld = errors.New("lock is held")
+
+// ErrLockLost is returned by Renew and Release when the lease was released
+// or expired and was taken by another owner.
+var ErrLockLost = errors.New("lock lost")
+
+// LockClock returns the time lease expiries are computed from and checked against.
+// Owners compare expiries written by other processes: keep clocks synchronized
+// and lease durations well above the expected skew.
+var LockClock = time.Now
+
+// Lease is a lock of a resource, held until ExpiresAt unless renewed.
+type Lease struct {
+    ResourceID string
+
+    // Owner identifies the holder, random for every Lock.
+    Owner string
+
+    // Token is the fencing token, increasing with every acquisition of the resource.
+    // Pass it to the guarded resource, which rejects writes with a lower token than the last one seen:
+    // a holder paused past its expiry can't overwrite the work of the next one.
+    Token int64
+
+    ExpiresAt time.Time
+}
+
+// Lock acquires the lock of resourceID for ttl if it's free or its lease expired.
+// Returns an error matching ErrLockHeld if another owner holds an unexpired lease.
+// Release keeps the lock item, so tokens keep increasing: don't delete lock items.
+// Example:
+//   lease, err := Lock(ctx, client, "report-42", 30*time.Second)
+//   if errors.Is(err, ErrLockHeld) {
+//       // retry later
+//   }
+//   defer Release(ctx, client, lease)
+func Lock(ctx context.Context, client DynamoDBAPI, resourceID string, ttl time.Duration) (*Lease, error) {
+    if ttl <= 0 {
+        return nil, fmt.Errorf("lock duration must be positive, got %s", ttl)
+    }
+    now := LockClock()
+    lease := &Lease{
+        ResourceID: resourceID,
+        Owner:      fmt.Sprintf("%016x", rand.Uint64()),
+        ExpiresAt:  now.Add(ttl),
+    }
+    update := expression.Set(expression.Name(lockOwnerAttribute), expression.Value(lease.Owner)).
+        Set(expression.Name(lockExpiresAttribute), expression.Value(lease.ExpiresAt.UnixMilli())).
+        Add(expression.Name(lockTokenAttribute), expression.Value(1))
+    condition := expression.Name(lockOwnerAttribute).AttributeNotExists().
+        Or(expression.Name(lockExpiresAttribute).LessThanEqual(expression.Value(now.UnixMilli())))
+    input, err := lockUpdateInput(resourceID, update, condition)
+    if err != nil {
+        return nil, err
+    }
+    input.ReturnValues = types.ReturnValueUpdatedNew
+    input.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+
+    out, err := client.UpdateItem(ctx, input)
+    if err != nil {
+        var failed *types.ConditionalCheckFailedException
+        if !errors.As(err, &failed) {
+            return nil, fmt.Errorf("failed to lock %s: %w", resourceID, err)
+        }
+        var expires int64
+        if av, ok := failed.Item[lockExpiresAttribute]; ok && attributevalue.Unmarshal(av, &expires) == nil {
+            return nil, fmt.Errorf("%w: %s until %s", ErrLockHeld, resourceID, time.UnixMilli(expires).Format(time.RFC3339))
+        }
+        return nil, fmt.Errorf("%w: %s", ErrLockHeld, resourceID)
+    }
+    if err := attributevalue.Unmarshal(out.Attributes[lockTokenAttribute], &lease.Token); err != nil {
+        return nil, fmt.Errorf("failed to unmarshal fencing token: %v", err)
+    }
+    return lease, nil
+}
+
+// Renew extends the lease to ttl from now if its owner still holds it.
+// Returns an error matching ErrLockLost otherwise, the guarded work has to stop.
+// Example:
+//   if err := Renew(ctx, client, lease, 30*time.Second); errors.Is(err, ErrLockLost) {
+//       // abort
+//   }
+func Renew(ctx context.Context, client DynamoDBAPI, lease *Lease, ttl time.Duration) error {
+    if lease == nil {
+        return fmt.Errorf("lease cannot be nil")
+    }
+    if ttl <= 0 {
+        return fmt.Errorf("lock duration must be positive, got %s", ttl)
+    }
+    expiresAt := LockClock().Add(ttl)
+    update := expression.Set(expression.Name(lockExpiresAttribute), expression.Value(expiresAt.UnixMilli()))
+    input, err := lockUpdateInput(lease.ResourceID, update, leaseHeld(lease))
+    if err != nil {
+        return err
+    }
+    if _, err := client.UpdateItem(ctx, input); err != nil {
+        return leaseError(lease, err)
+    }
+    lease.ExpiresAt = expiresAt
+    return nil
+}
+
+// Release frees the lock if the lease still holds it, keeping the fencing token.
+// Returns an error matching ErrLockLost if the lease was released or taken over.
+func Release(ctx context.Context, client DynamoDBAPI, lease *Lease) error {
+    if lease == nil {
+        return fmt.Errorf("lease cannot be nil")
+    }
+    update := expression.Remove(expression.Name(lockOwnerAttribute)).
+        Remove(expression.Name(lockExpiresAttribute))
+    input, err := lockUpdateInput(lease.ResourceID, update, leaseHeld(lease))
+    if err != nil {
+        return err
+    }
+    if _, err := client.UpdateItem(ctx, input); err != nil {
+        return leaseError(lease, err)
+    }
+    return nil
+}
+
+// leaseHeld returns the condition of the lock item being held by the lease.
+func leaseHeld(lease *Lease) expression.ConditionBuilder {
+    return expression.Name(lockOwnerAttribute).Equal(expression.Value(lease.Owner)).
+        And(expression.Name(lockTokenAttribute).Equal(expression.Value(lease.Token)))
+}
+
+// leaseError converts a failed condition of a lease update to ErrLockLost.
+func leaseError(lease *Lease, err error) error {
+    var failed *types.ConditionalCheckFailedException
+    if errors.As(err, &failed) {
+        return fmt.Errorf("%w: %s", ErrLockLost, lease.ResourceID)
+    }
+    return fmt.Errorf("failed to update lock %s: %w", lease.ResourceID, err)
+}
+
+// lockUpdateInput returns the conditional update of the lock item of resourceID.
+func lockUpdateInput(resourceID string, update expression.UpdateBuilder, condition expression.ConditionBuilder) (*dynamodb.UpdateItemInput, error) {
+    key, err := KeyInputFromRaw(LockKeyPrefix+resourceID, {{.HelperSortKey .LockSortKey}})
+    if err != nil {
+        return nil, err
+    }
+    expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+    if err != nil {
+        return nil, fmt.Errorf("failed to build lock expression: %v", err)
+    }
+    return &dynamodb.UpdateItemInput{
+        TableName:                 aws.String(TableSchema.TableName),
+        Key:                       key,
+        UpdateExpression:          expr.Update(),
+        ConditionExpression:       expr.Condition(),
+        ExpressionAttributeNames:  expr.Names(),
+        ExpressionAttributeValues: expr.Values(),
+    }, nil
+}
+{{- end}}
+`
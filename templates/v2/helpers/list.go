@@ -0,0 +1,73 @@
+package helpers
+
+// ListHelpersTemplate provides append and remove operations of typed list attributes ("L<S>")
+const ListHelpersTemplate = `
+{{- range .TypedLists}}
+// AppendTo{{.GoName}} appends values to the {{.Name}} list, creating the list if the item has none.
+// Example:
+//   input, err := AppendTo{{.GoName}}({{$.ExampleKey $.HashKey}}, {{if $.RangeKey}}{{$.ExampleKey $.RangeKey}}{{else}}nil{{end}}, {{.ElementExampleValue}})
+func AppendTo{{.GoName}}(hashKeyValue any, rangeKeyValue any, values ...{{.ElementGoType}}) (*dynamodb.UpdateItemInput, error) {
+    if len(values) == 0 {
+        return nil, fmt.Errorf("no values to append to %s", Column{{.GoName}})
+    }
+    list, err := attributevalue.Marshal(values)
+    if err != nil {
+        return nil, fmt.Errorf("failed to marshal values of %s: %v", Column{{.GoName}}, err)
+    }
+    return listUpdateInput(hashKeyValue, rangeKeyValue, Column{{.GoName}}, "SET #attr = list_append(if_not_exists(#attr, :empty), :val)", map[string]types.AttributeValue{
+        ":val":   list,
+        ":empty": &types.AttributeValueMemberL{Value: []types.AttributeValue{}},
+    })
+}
+
+// RemoveAtIndex{{.GoName}} removes the element at index from the {{.Name}} list, later elements move down.
+// Removing an index past the end of the list leaves it unchanged.
+func RemoveAtIndex{{.GoName}}(hashKeyValue any, rangeKeyValue any, index int) (*dynamodb.UpdateItemInput, error) {
+    if index < 0 {
+        return nil, fmt.Errorf("index of %s must not be negative, got %d", Column{{.GoName}}, index)
+    }
+    return listUpdateInput(hashKeyValue, rangeKeyValue, Column{{.GoName}}, fmt.Sprintf("REMOVE #attr[%d]", index), nil)
+}
+{{end}}
+{{- if .TypedLists}}
+// listUpdateInput creates the UpdateItemInput of a list operation on attributeName.
+func listUpdateInput(hashKeyValue any, rangeKeyValue any, attributeName string, updateExpression string, values map[string]types.AttributeValue) (*dynamodb.UpdateItemInput, error) {
+    if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+        return nil, err
+    }
+    {{- if .ComputedAttributes}}
+    if err := validateComputedInput(attributeName); err != nil {
+        return nil, err
+    }
+    {{- end}}
+    {{- if .GuardedAttributes}}
+    if err := validateGuardedChange(attributeName, false); err != nil {
+        return nil, err
+    }
+    {{- end}}
+
+    key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create key for list update: %v", err)
+    }
+    input := &dynamodb.UpdateItemInput{
+        TableName:        aws.String(TableSchema.TableName),
+        Key:              key,
+        UpdateExpression: aws.String(updateExpression),
+        ExpressionAttributeNames: map[string]string{
+            "#attr": attributeName,
+        },
+    }
+    if len(values) > 0 {
+        input.ExpressionAttributeValues = values
+    }
+    {{- if .FieldVersionsAttribute}}
+    recordFieldVersions(input, []string{attributeName}, FieldVersionClock())
+    {{- end}}
+    {{- if .ChecksumAttribute}}
+    removeChecksum(input)
+    {{- end}}
+    return input, nil
+}
+{{- end}}
+`
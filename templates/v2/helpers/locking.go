@@ -0,0 +1,78 @@
+package helpers
+
+// OptimisticLockHelpersTemplate provides optimistic locking on the version attribute declared in the schema
+const OptimisticLockHelpersTemplate = `
+{{- with .VersionAttribute}}
+// VersionAttribute is the attribute holding the item version used for optimistic locking.
+const VersionAttribute = Column{{.GoName}}
+
+// ErrVersionConflict matches every *VersionConflictError:
+//   if errors.Is(err, ErrVersionConflict) { /* reload the item and retry */ }
+var ErrVersionConflict = errors.New("version conflict")
+
+// VersionConflictError reports a save rejected because the stored item version
+// differs from the version the item was loaded with.
+// Actual is the stored version, zero if the item doesn't exist.
+type VersionConflictError struct {
+    Expected {{.GoType}}
+    Actual   {{.GoType}}
+    Err      error
+}
+
+// Error implements error.
+func (e *VersionConflictError) Error() string {
+    return fmt.Sprintf("version conflict on table %s: expected version %v, stored %v", TableName, e.Expected, e.Actual)
+}
+
+// Unwrap returns the ConditionalCheckFailedException of the save.
+func (e *VersionConflictError) Unwrap() error {
+    return e.Err
+}
+
+// Is reports whether target is ErrVersionConflict.
+func (e *VersionConflictError) Is(target error) bool {
+    return target == ErrVersionConflict
+}
+
+// SaveWithOptimisticLock puts the item if its stored version still equals item.{{.GoName}}
+// and increments item.{{.GoName}} on success. Items with version 0 are created only if they don't exist.
+// On a concurrent change the item keeps its version and a *VersionConflictError is returned.
+// Example:
+//   item, err := GetItemFromRaw(ctx, client, {{$.ExampleKey $.HashKey}}, {{if $.RangeKey}}{{$.ExampleKey $.RangeKey}}{{else}}nil{{end}})
+//   // modify item
+//   if err := SaveWithOptimisticLock(ctx, client, item); errors.Is(err, ErrVersionConflict) {
+//       // reload and retry
+//   }
+func SaveWithOptimisticLock(ctx context.Context, client DynamoDBAPI, item *SchemaItem) error {
+    if item == nil {
+        return fmt.Errorf("item cannot be nil")
+    }
+    expected := item.{{.GoName}}
+    condition := expression.Name(VersionAttribute).AttributeNotExists()
+    if expected != 0 {
+        condition = expression.Name(VersionAttribute).Equal(expression.Value(expected))
+    }
+
+    next := *item
+    next.{{.GoName}} = expected + 1
+    input, err := PutItemInputIf(next, condition)
+    if err != nil {
+        return err
+    }
+    input.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+    if _, err := client.PutItem(ctx, input); err != nil {
+        var failed *types.ConditionalCheckFailedException
+        if !errors.As(err, &failed) {
+            return err
+        }
+        conflict := &VersionConflictError{Expected: expected, Err: err}
+        if stored, ok := failed.Item[VersionAttribute]; ok {
+            _ = attributevalue.Unmarshal(stored, &conflict.Actual)
+        }
+        return conflict
+    }
+    item.{{.GoName}} = next.{{.GoName}}
+    return nil
+}
+{{- end}}
+`
@@ -0,0 +1,378 @@
+package helpers
+
+// LoggingHelpersTemplate provides a structured logging decorator for DynamoDB operations
+const LoggingHelpersTemplate = `
+// LoggingClient decorates DynamoDBAPI and emits one structured slog entry per operation.
+// Entries contain operation, table, index, duration, item count and error class, and the expressions
+// and keys of the request with attribute names resolved. Batch and transaction entries name every table
+// of the request, comma-separated. Values of sensitive and PII (anonymized)
+// attributes are redacted, WithRedactedValues redacts all values.
+// Logging can be toggled at runtime with Enable/Disable without rebuilding the client.
+// To route entries into zerolog or another logger, pass a *slog.Logger backed by its slog.Handler.
+type LoggingClient struct {
+    client  DynamoDBAPI
+    logger  *slog.Logger
+    level     slog.Level
+    redactAll bool
+    enabled   atomic.Bool
+}
+
+// NewLoggingClient wraps client with structured logging enabled at slog.LevelDebug.
+// Uses slog.Default() when logger is nil.
+func NewLoggingClient(client DynamoDBAPI, logger *slog.Logger) *LoggingClient {
+    if logger == nil {
+        logger = slog.Default()
+    }
+    lc := &LoggingClient{
+        client: client,
+        logger: logger,
+        level:  slog.LevelDebug,
+    }
+    lc.enabled.Store(true)
+    return lc
+}
+
+// WithLevel sets the level used for successful operations and returns LoggingClient for method chaining.
+// Failed operations are always logged at slog.LevelError.
+func (lc *LoggingClient) WithLevel(level slog.Level) *LoggingClient {
+    lc.level = level
+    return lc
+}
+
+// WithRedactedValues redacts the values of all attributes and returns LoggingClient for method chaining.
+// Entries keep attribute names, operators and the index.
+func (lc *LoggingClient) WithRedactedValues() *LoggingClient {
+    lc.redactAll = true
+    return lc
+}
+
+// Enable turns logging on. Safe for concurrent use.
+func (lc *LoggingClient) Enable() {
+    lc.enabled.Store(true)
+}
+
+// Disable turns logging off. Safe for concurrent use.
+func (lc *LoggingClient) Disable() {
+    lc.enabled.Store(false)
+}
+
+// Enabled reports whether logging is currently on.
+func (lc *LoggingClient) Enabled() bool {
+    return lc.enabled.Load()
+}
+
+// GetItem logs and forwards the GetItem call.
+func (lc *LoggingClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+    start := time.Now()
+    out, err := lc.client.GetItem(ctx, params, optFns...)
+    count := 0
+    if out != nil && out.Item != nil {
+        count = 1
+    }
+    lc.log(ctx, "GetItem", aws.ToString(params.TableName), "", start, count, err,
+        lc.key(params.Key))
+    return out, err
+}
+
+// PutItem logs and forwards the PutItem call.
+func (lc *LoggingClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+    start := time.Now()
+    out, err := lc.client.PutItem(ctx, params, optFns...)
+    lc.log(ctx, "PutItem", aws.ToString(params.TableName), "", start, 1, err,
+        lc.expression("condition", params.ConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues))
+    return out, err
+}
+
+// UpdateItem logs and forwards the UpdateItem call.
+func (lc *LoggingClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+    start := time.Now()
+    out, err := lc.client.UpdateItem(ctx, params, optFns...)
+    lc.log(ctx, "UpdateItem", aws.ToString(params.TableName), "", start, 1, err,
+        lc.key(params.Key),
+        lc.expression("update", params.UpdateExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues),
+        lc.expression("condition", params.ConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues))
+    return out, err
+}
+
+// DeleteItem logs and forwards the DeleteItem call.
+func (lc *LoggingClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+    start := time.Now()
+    out, err := lc.client.DeleteItem(ctx, params, optFns...)
+    lc.log(ctx, "DeleteItem", aws.ToString(params.TableName), "", start, 1, err,
+        lc.key(params.Key),
+        lc.expression("condition", params.ConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues))
+    return out, err
+}
+
+// Query logs and forwards the Query call.
+func (lc *LoggingClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+    start := time.Now()
+    out, err := lc.client.Query(ctx, params, optFns...)
+    count := 0
+    if out != nil {
+        count = len(out.Items)
+    }
+    lc.log(ctx, "Query", aws.ToString(params.TableName), aws.ToString(params.IndexName), start, count, err,
+        lc.expression("key_condition", params.KeyConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues),
+        lc.expression("filter", params.FilterExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues))
+    return out, err
+}
+
+{{- if not .NoScan}}
+
+// Scan logs and forwards the Scan call.
+func (lc *LoggingClient) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+    start := time.Now()
+    out, err := lc.client.Scan(ctx, params, optFns...)
+    count := 0
+    if out != nil {
+        count = len(out.Items)
+    }
+    lc.log(ctx, "Scan", aws.ToString(params.TableName), aws.ToString(params.IndexName), start, count, err,
+        lc.expression("filter", params.FilterExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues))
+    return out, err
+}
+{{- end}}
+
+// BatchGetItem logs and forwards the BatchGetItem call.
+func (lc *LoggingClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+    start := time.Now()
+    out, err := lc.client.BatchGetItem(ctx, params, optFns...)
+    count := 0
+    if out != nil {
+        for _, items := range out.Responses {
+            count += len(items)
+        }
+    }
+    lc.log(ctx, "BatchGetItem", joinTables(slices.Collect(maps.Keys(params.RequestItems))), "", start, count, err)
+    return out, err
+}
+
+// BatchWriteItem logs and forwards the BatchWriteItem call.
+func (lc *LoggingClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+    start := time.Now()
+    out, err := lc.client.BatchWriteItem(ctx, params, optFns...)
+    count := 0
+    for _, requests := range params.RequestItems {
+        count += len(requests)
+    }
+    lc.log(ctx, "BatchWriteItem", joinTables(slices.Collect(maps.Keys(params.RequestItems))), "", start, count, err)
+    return out, err
+}
+
+// TransactGetItems logs and forwards the TransactGetItems call.
+func (lc *LoggingClient) TransactGetItems(ctx context.Context, params *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error) {
+    start := time.Now()
+    out, err := lc.client.TransactGetItems(ctx, params, optFns...)
+    tables := make([]string, 0, len(params.TransactItems))
+    for _, item := range params.TransactItems {
+        if item.Get != nil {
+            tables = append(tables, aws.ToString(item.Get.TableName))
+        }
+    }
+    lc.log(ctx, "TransactGetItems", joinTables(tables), "", start, len(params.TransactItems), err)
+    return out, err
+}
+
+// TransactWriteItems logs and forwards the TransactWriteItems call.
+func (lc *LoggingClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+    start := time.Now()
+    out, err := lc.client.TransactWriteItems(ctx, params, optFns...)
+    tables := make([]string, 0, len(params.TransactItems))
+    for _, item := range params.TransactItems {
+        switch {
+        case item.Put != nil:
+            tables = append(tables, aws.ToString(item.Put.TableName))
+        case item.Update != nil:
+            tables = append(tables, aws.ToString(item.Update.TableName))
+        case item.Delete != nil:
+            tables = append(tables, aws.ToString(item.Delete.TableName))
+        case item.ConditionCheck != nil:
+            tables = append(tables, aws.ToString(item.ConditionCheck.TableName))
+        }
+    }
+    lc.log(ctx, "TransactWriteItems", joinTables(tables), "", start, len(params.TransactItems), err)
+    return out, err
+}
+
+// DescribeTable logs and forwards the DescribeTable call.
+func (lc *LoggingClient) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+    start := time.Now()
+    out, err := lc.client.DescribeTable(ctx, params, optFns...)
+    lc.log(ctx, "DescribeTable", aws.ToString(params.TableName), "", start, 0, err)
+    return out, err
+}
+
+// DescribeTimeToLive logs and forwards the DescribeTimeToLive call.
+func (lc *LoggingClient) DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+    start := time.Now()
+    out, err := lc.client.DescribeTimeToLive(ctx, params, optFns...)
+    lc.log(ctx, "DescribeTimeToLive", aws.ToString(params.TableName), "", start, 0, err)
+    return out, err
+}
+
+// ListTagsOfResource logs and forwards the ListTagsOfResource call.
+func (lc *LoggingClient) ListTagsOfResource(ctx context.Context, params *dynamodb.ListTagsOfResourceInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error) {
+    start := time.Now()
+    out, err := lc.client.ListTagsOfResource(ctx, params, optFns...)
+    count := 0
+    if out != nil {
+        count = len(out.Tags)
+    }
+    lc.log(ctx, "ListTagsOfResource", tableOfARN(aws.ToString(params.ResourceArn)), "", start, count, err)
+    return out, err
+}
+
+// log emits a single structured entry for a finished operation with the details of the request,
+// skipping empty details.
+func (lc *LoggingClient) log(ctx context.Context, operation, table, index string, start time.Time, count int, err error, details ...slog.Attr) {
+    if !lc.enabled.Load() {
+        return
+    }
+    attrs := []slog.Attr{
+        slog.String("operation", operation),
+        slog.String("table", table),
+        slog.Duration("duration", time.Since(start)),
+        slog.Int("items", count),
+    }
+    if index != "" {
+        attrs = append(attrs, slog.String("index", index))
+    }
+    for _, detail := range details {
+        if detail.Key != "" {
+            attrs = append(attrs, detail)
+        }
+    }
+    if err != nil {
+        attrs = append(attrs, slog.String("error_class", errorClass(err)), slog.String("error", err.Error()))
+        lc.logger.LogAttrs(ctx, slog.LevelError, "dynamodb operation failed", attrs...)
+        return
+    }
+    lc.logger.LogAttrs(ctx, lc.level, "dynamodb operation", attrs...)
+}
+
+// joinTables returns the distinct tables of a batch or transaction request, sorted and comma-separated.
+func joinTables(tables []string) string {
+    slices.Sort(tables)
+    return strings.Join(slices.Compact(tables), ",")
+}
+
+// tableOfARN returns the table name of a table ARN (arn:aws:dynamodb:<region>:<account>:table/<name>),
+// the ARN itself otherwise.
+func tableOfARN(arn string) string {
+    _, rest, ok := strings.Cut(arn, ":table/")
+    if !ok {
+        return arn
+    }
+    name, _, _ := strings.Cut(rest, "/")
+    return name
+}
+
+// redactedValue replaces logged values of redacted attributes.
+const redactedValue = "[REDACTED]"
+
+// redactedAttributes are the attributes whose values LoggingClient doesn't log:
+// sensitive attributes and PII attributes with an anonymize strategy.
+var redactedAttributes = map[string]bool{
+    {{- range .RedactedAttributes}}
+    Column{{.GoName}}: true,
+    {{- end}}
+}
+
+// conditionTokenPattern matches the placeholders and attribute names of request expressions.
+var conditionTokenPattern = regexp.MustCompile("[#:]?[A-Za-z0-9_]+")
+
+// expression returns the logged detail of a request expression with attribute names resolved
+// and values formatted, values compared with redacted attributes replaced by redactedValue.
+// Returns an empty detail for missing expressions and when logging is off.
+func (lc *LoggingClient) expression(key string, expr *string, names map[string]string, values map[string]types.AttributeValue) slog.Attr {
+    if expr == nil || *expr == "" || !lc.enabled.Load() {
+        return slog.Attr{}
+    }
+    attr := ""
+    described := conditionTokenPattern.ReplaceAllStringFunc(*expr, func(token string) string {
+        switch {
+        case strings.HasPrefix(token, "#"):
+            if name, ok := names[token]; ok {
+                attr = name
+                return name
+            }
+        case strings.HasPrefix(token, ":"):
+            if value, ok := values[token]; ok {
+                return lc.value(attr, value)
+            }
+        default:
+            if _, ok := TableSchema.FieldsMap[token]; ok {
+                attr = token
+            }
+        }
+        return token
+    })
+    return slog.String(key, described)
+}
+
+// key returns the logged detail of a primary key, e.g. user_id="u1" created=5.
+// Returns an empty detail for missing keys and when logging is off.
+func (lc *LoggingClient) key(key map[string]types.AttributeValue) slog.Attr {
+    if len(key) == 0 || !lc.enabled.Load() {
+        return slog.Attr{}
+    }
+    names := make([]string, 0, len(key))
+    for name := range key {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    parts := make([]string, len(names))
+    for i, name := range names {
+        parts[i] = name + "=" + lc.value(name, key[name])
+    }
+    return slog.String("key", strings.Join(parts, " "))
+}
+
+// value formats a value of attr for logging, redactedValue for redacted attributes.
+// Binaries and collections are summarized by their size.
+func (lc *LoggingClient) value(attr string, value types.AttributeValue) string {
+    if lc.redactAll || redactedAttributes[attr] {
+        return redactedValue
+    }
+    switch v := value.(type) {
+    case *types.AttributeValueMemberS:
+        return strconv.Quote(v.Value)
+    case *types.AttributeValueMemberN:
+        return v.Value
+    case *types.AttributeValueMemberBOOL:
+        return strconv.FormatBool(v.Value)
+    case *types.AttributeValueMemberNULL:
+        return "null"
+    case *types.AttributeValueMemberB:
+        return fmt.Sprintf("<%d bytes>", len(v.Value))
+    case *types.AttributeValueMemberSS:
+        return fmt.Sprintf("%q", v.Value)
+    case *types.AttributeValueMemberNS:
+        return fmt.Sprintf("%v", v.Value)
+    case *types.AttributeValueMemberBS:
+        return fmt.Sprintf("<set of %d binaries>", len(v.Value))
+    case *types.AttributeValueMemberL:
+        return fmt.Sprintf("<list of %d>", len(v.Value))
+    case *types.AttributeValueMemberM:
+        return fmt.Sprintf("<map of %d>", len(v.Value))
+    }
+    return "?"
+}
+
+// errorClass returns a stable classification for an operation error.
+// AWS API errors are reported by their error code (e.g. "ConditionalCheckFailedException").
+func errorClass(err error) string {
+    var apiErr smithy.APIError
+    switch {
+    case errors.As(err, &apiErr):
+        return apiErr.ErrorCode()
+    case errors.Is(err, context.Canceled):
+        return "Canceled"
+    case errors.Is(err, context.DeadlineExceeded):
+        return "DeadlineExceeded"
+    default:
+        return "Unknown"
+    }
+}
+`
@@ -18,10 +18,15 @@ type Float interface {
 
 // toIntStrings converts any signed or unsigned integer slice to string slice.
 // DynamoDB requires numeric sets as string arrays for the wire protocol.
+// Non-negative values are formatted unsigned, so uint64 values above math.MaxInt64 keep their value.
 func toIntStrings[T Signed | Unsigned](nums []T) []string {
 	out := make([]string, len(nums))
 	for i, n := range nums {
-		out[i] = strconv.FormatInt(int64(n), 10)
+		if n < 0 {
+			out[i] = strconv.FormatInt(int64(n), 10)
+		} else {
+			out[i] = strconv.FormatUint(uint64(n), 10)
+		}
 	}
 	return out
 }
@@ -59,6 +64,9 @@ func extractNonKeyAttributes(allAttributes map[string]types.AttributeValue) map[
 // Generates safe attribute names and values to avoid DynamoDB reserved words.
 // Returns expression string, name mappings, and value mappings.
 // Example: "SET #attr0 = :val0, #attr1 = :val1"
+{{- with .AuditCreatedAttribute}}
+// The {{.Name}} audit timestamp is set with if_not_exists, so updates never change it.
+{{- end}}
 func buildUpdateExpression(updates map[string]types.AttributeValue) (string, map[string]string, map[string]types.AttributeValue) {
     if len(updates) == 0 {
         return "", nil, nil
@@ -71,6 +79,15 @@ func buildUpdateExpression(updates map[string]types.AttributeValue) (string, map
     for attrName, attrValue := range updates {
         nameKey := fmt.Sprintf("#attr%d", i)
         valueKey := fmt.Sprintf(":val%d", i)
+        {{- with .AuditCreatedAttribute}}
+        if attrName == Column{{.GoName}} {
+            updateParts = append(updateParts, fmt.Sprintf("%s = if_not_exists(%s, %s)", nameKey, nameKey, valueKey))
+            attrNames[nameKey] = attrName
+            attrValues[valueKey] = attrValue
+            i++
+            continue
+        }
+        {{- end}}
         
         updateParts = append(updateParts, fmt.Sprintf("%s = %s", nameKey, valueKey))
         attrNames[nameKey] = attrName
@@ -105,6 +122,7 @@ func mergeExpressionAttributes(
 // marshalUpdatesWithSchema marshals updates map using schema type information.
 // Provides type-safe marshaling by consulting the table schema for field types.
 // Handles special DynamoDB types (Sets) that require custom marshaling logic.
+// Numbers are validated by checkNumbers.
 func marshalUpdatesWithSchema(updates map[string]any) (map[string]types.AttributeValue, error) {
     result := make(map[string]types.AttributeValue, len(updates))
     for fieldName, value := range updates {
@@ -123,9 +141,53 @@ func marshalUpdatesWithSchema(updates map[string]any) (map[string]types.Attribut
             result[fieldName] = av
         }
     }
+    if err := checkNumbers(result); err != nil {
+        return nil, err
+    }
+    return result, nil
+}
+
+// marshalConditionValues marshals the values of UpdateItemInputWithTypedCondition by placeholder.
+func marshalConditionValues(values map[string]ConditionValue) (map[string]types.AttributeValue, error) {
+    if values == nil {
+        return nil, nil
+    }
+    result := make(map[string]types.AttributeValue, len(values))
+    for placeholder, value := range values {
+        if _, ok := TableSchema.FieldsMap[value.Attribute]; value.Attribute != "" && !ok {
+            return nil, fmt.Errorf("condition value %s: unknown attribute '%s'", placeholder, value.Attribute)
+        }
+        av, err := marshalConditionValue(value.Attribute, value.Value)
+        if err != nil {
+            return nil, fmt.Errorf("failed to marshal condition value %s: %v", placeholder, err)
+        }
+        result[placeholder] = av
+    }
     return result, nil
 }
 
+// marshalConditionValue marshals a condition value compared with attr, empty if unknown.
+// A single element compared with a set (contains) is marshaled as an element, not a set.
+func marshalConditionValue(attr string, value any) (types.AttributeValue, error) {
+    if av, ok := value.(types.AttributeValue); ok {
+        return av, nil
+    }
+    fieldInfo, ok := TableSchema.FieldsMap[attr]
+    if !ok {
+        return attributevalue.Marshal(value)
+    }
+    switch fieldInfo.DynamoType {
+    case "SS", "NS", "BS":
+        if reflect.ValueOf(value).Kind() != reflect.Slice {
+            return attributevalue.Marshal(value)
+        }
+        if _, ok := value.([]byte); ok && fieldInfo.DynamoType == "BS" {
+            return attributevalue.Marshal(value)
+        }
+    }
+    return marshalValueByType(value, fieldInfo.DynamoType)
+}
+
 // marshalValueByType marshals value according to specific DynamoDB type.
 // Handles special cases like String Sets (SS) and Number Sets (NS) that require
 // custom marshaling logic not provided by the default AWS SDK marshaler.
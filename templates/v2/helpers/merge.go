@@ -0,0 +1,299 @@
+package helpers
+
+// MergeHelpersTemplate provides MergePutItems upserting items with a conflict resolution strategy
+const MergeHelpersTemplate = `
+// MergeStrategy resolves conflicts of MergePutItems between given and stored items.
+type MergeStrategy int
+
+const (
+    // MergeOurs writes the given items over the stored ones.
+    MergeOurs MergeStrategy = iota
+
+    // MergeTheirs keeps the stored items and only writes items the table doesn't have yet.
+    MergeTheirs
+{{- with .AuditUpdatedAttribute}}
+
+    // MergeNewestWins merges given and stored items by {{.Name}}: attributes of the newer
+    // item win, attributes it leaves empty (empty string, list, map or NULL) are kept from the older one.
+    // Given items without {{.Name}} count as updated now.
+    MergeNewestWins
+{{- end}}
+{{- with .FieldVersionsAttribute}}
+
+    // MergeFieldVersions merges given and stored items attribute by attribute: the value
+    // with the newer {{.Name}} entry wins, ties and attributes without entries keep the given value.
+    // Record local changes of given items with TouchFields.
+    MergeFieldVersions
+{{- end}}
+)
+
+// maxMergeAttempts is the number of times MergePutItems reads and merges a chunk
+// whose transaction is canceled by a concurrent write.
+const maxMergeAttempts = 3
+
+// MergePutItems upserts the items, resolving conflicts with stored items by the strategy.
+// Items are processed in chunks of 100: the stored items are read in one transaction, merged
+// and written in another, every put conditioned on the stored item being unchanged
+{{- with .VersionAttribute}}
+// (same {{.Name}}, which is incremented by the write).
+{{- else}}{{with .AuditUpdatedAttribute}}
+// (same {{.Name}}).
+{{- else}}
+// (only its existence is checked, declare a version attribute for full protection).
+{{- end}}{{end}}
+// A chunk canceled by a concurrent write is read and merged again, up to maxMergeAttempts times.
+// Returns the items as written, in the order of items; chunks written before an error are kept.
+// Example:
+//   merged, err := MergePutItems(ctx, client, items, MergeOurs)
+func MergePutItems(ctx context.Context, client DynamoDBAPI, items []SchemaItem, strategy MergeStrategy) ([]SchemaItem, error) {
+    seen := make(map[string]int, len(items))
+    for i, item := range items {
+        key := fmt.Sprintf("%v", ItemKeyOf(item))
+        if j, ok := seen[key]; ok {
+            return nil, fmt.Errorf("items %d and %d have the same key %s", j, i, key)
+        }
+        seen[key] = i
+    }
+
+    merged := make([]SchemaItem, 0, len(items))
+    for start := 0; start < len(items); start += maxTransactItems {
+        chunk := items[start:min(start+maxTransactItems, len(items))]
+        var (
+            written []SchemaItem
+            err     error
+        )
+        for attempt := 1; attempt <= maxMergeAttempts; attempt++ {
+            written, err = mergePutChunk(ctx, client, chunk, strategy)
+            var canceled *types.TransactionCanceledException
+            if !errors.As(err, &canceled) {
+                break
+            }
+        }
+        if err != nil {
+            return merged, fmt.Errorf("failed to merge items %d-%d: %w", start, start+len(chunk)-1, err)
+        }
+        merged = append(merged, written...)
+    }
+    return merged, nil
+}
+
+// mergePutChunk reads the stored items of at most maxTransactItems items,
+// merges them by the strategy and writes the result in one transaction.
+func mergePutChunk(ctx context.Context, client DynamoDBAPI, items []SchemaItem, strategy MergeStrategy) ([]SchemaItem, error) {
+    keys := make([]ItemKey, len(items))
+    for i, item := range items {
+        keys[i] = ItemKeyOf(item)
+    }
+    stored, err := TransactGet(ctx, client, keys...)
+    if err != nil {
+        return nil, err
+    }
+
+    tb := NewTransactionBuilder()
+    written := make([]SchemaItem, len(items))
+    for i, item := range items {
+        result, put, err := mergeItem(item, stored[i], strategy)
+        if err != nil {
+            return nil, fmt.Errorf("item %d: %w", i, err)
+        }
+        written[i] = result
+        if put != nil {
+            tb.Add(*put, nil)
+        }
+    }
+    if tb.Len() == 0 {
+        return written, nil
+    }
+    if err := tb.Execute(ctx, client); err != nil {
+        return nil, err
+    }
+    return written, nil
+}
+
+// mergeItem merges the given item with the stored one (nil if the table has none) and returns
+// the resulting item with the conditional put writing it, nil if nothing has to be written.
+func mergeItem(item SchemaItem, stored *SchemaItem, strategy MergeStrategy) (SchemaItem, *types.TransactWriteItem, error) {
+    if stored != nil && strategy == MergeTheirs {
+        return *stored, nil, nil
+    }
+    {{- with .AuditCreatedAttribute}}
+    if stored != nil && item.{{.GoName}} == {{.ZeroValue}} {
+        item.{{.GoName}} = stored.{{.GoName}}
+    }
+    {{- end}}
+    {{- with .AuditUpdatedAttribute}}
+    if strategy == MergeNewestWins && item.{{.GoName}} == {{.ZeroValue}} {
+        item.{{.GoName}} = {{.AutoValue "AuditClock()"}}
+    }
+    {{- end}}
+    av, err := ItemInput(item)
+    if err != nil {
+        return SchemaItem{}, nil, err
+    }
+
+    condition := expression.Name(TableSchema.HashKey).AttributeNotExists()
+    if stored != nil {
+        {{- with .AuditUpdatedAttribute}}
+        if strategy == MergeNewestWins {
+            storedAV, err := attributevalue.MarshalMap(*stored)
+            if err != nil {
+                return SchemaItem{}, nil, fmt.Errorf("failed to marshal stored item: %v", err)
+            }
+            // ItemInput stamped now, the merge keeps the timestamps being compared.
+            if av[Column{{.GoName}}], err = attributevalue.Marshal(item.{{.GoName}}); err != nil {
+                return SchemaItem{}, nil, fmt.Errorf("failed to marshal %s: %v", Column{{.GoName}}, err)
+            }
+            if auditNewer(stored.{{.GoName}}, item.{{.GoName}}) {
+                av = mergeAttributeValues(storedAV, av)
+            } else {
+                av = mergeAttributeValues(av, storedAV)
+            }
+        }
+        {{- end}}
+        {{- if .FieldVersionsAttribute}}
+        if strategy == MergeFieldVersions {
+            if av, err = mergeFieldVersions(av, *stored); err != nil {
+                return SchemaItem{}, nil, err
+            }
+        }
+        {{- end}}
+        condition = mergeUnchanged(*stored)
+    }
+    {{- with .VersionAttribute}}
+    var version {{.GoType}}
+    if stored != nil {
+        version = stored.{{.GoName}}
+    }
+    if av[Column{{.GoName}}], err = attributevalue.Marshal(version + 1); err != nil {
+        return SchemaItem{}, nil, fmt.Errorf("failed to marshal %s: %v", Column{{.GoName}}, err)
+    }
+    {{- end}}
+
+    result, err := UnmarshalItem(av)
+    if err != nil {
+        return SchemaItem{}, nil, err
+    }
+    {{- if .ChecksumAttribute}}
+    if err := setChecksum(result); err != nil {
+        return SchemaItem{}, nil, err
+    }
+    av[ChecksumAttribute] = &types.AttributeValueMemberS{Value: result.{{.ChecksumAttribute.GoName}}}
+    {{- end}}
+    expr, err := buildCondition(condition)
+    if err != nil {
+        return SchemaItem{}, nil, err
+    }
+    return *result, &types.TransactWriteItem{
+        Put: &types.Put{
+            TableName:                 aws.String(TableSchema.TableName),
+            Item:                      av,
+            ConditionExpression:       expr.Condition(),
+            ExpressionAttributeNames:  expr.Names(),
+            ExpressionAttributeValues: expr.Values(),
+        },
+    }, nil
+}
+
+// mergeUnchanged returns the condition of a put over the stored item:
+{{- with .VersionAttribute}}
+// it still has the same {{.Name}}.
+func mergeUnchanged(stored SchemaItem) expression.ConditionBuilder {
+    return expression.Name(Column{{.GoName}}).Equal(expression.Value(stored.{{.GoName}}))
+}
+{{- else}}{{with .AuditUpdatedAttribute}}
+// it still has the same {{.Name}}.
+func mergeUnchanged(stored SchemaItem) expression.ConditionBuilder {
+    return expression.Name(Column{{.GoName}}).Equal(expression.Value(stored.{{.GoName}}))
+}
+{{- else}}
+// it still exists.
+func mergeUnchanged(stored SchemaItem) expression.ConditionBuilder {
+    return expression.Name(TableSchema.HashKey).AttributeExists()
+}
+{{- end}}{{end}}
+{{- with .AuditUpdatedAttribute}}
+
+// auditNewer reports whether the {{.Name}} timestamp a is after b.
+func auditNewer(a, b {{.GoType}}) bool {
+    {{- if eq .Type "N"}}
+    return a > b
+    {{- else}}
+    ta, errA := time.Parse(time.RFC3339Nano, a)
+    tb, errB := time.Parse(time.RFC3339Nano, b)
+    if errA != nil || errB != nil {
+        return a > b
+    }
+    return ta.After(tb)
+    {{- end}}
+}
+
+// mergeAttributeValues merges the attributes of the newer item over the older one,
+// skipping empty attributes of the newer item.
+func mergeAttributeValues(newer, older map[string]types.AttributeValue) map[string]types.AttributeValue {
+    merged := make(map[string]types.AttributeValue, len(older)+len(newer))
+    for name, value := range older {
+        merged[name] = value
+    }
+    for name, value := range newer {
+        if _, ok := merged[name]; ok && isEmptyAttributeValue(value) {
+            continue
+        }
+        merged[name] = value
+    }
+    return merged
+}
+
+// isEmptyAttributeValue reports whether the attribute value holds no data.
+func isEmptyAttributeValue(value types.AttributeValue) bool {
+    switch v := value.(type) {
+    case *types.AttributeValueMemberNULL:
+        return true
+    case *types.AttributeValueMemberS:
+        return v.Value == ""
+    case *types.AttributeValueMemberL:
+        return len(v.Value) == 0
+    case *types.AttributeValueMemberM:
+        return len(v.Value) == 0
+    }
+    return false
+}
+{{- end}}
+{{- with .FieldVersionsAttribute}}
+
+// mergeFieldVersions merges the marshaled given item with the stored item attribute by attribute
+// by their {{.Name}} entries. The merged item holds the newer entry of every attribute.
+func mergeFieldVersions(given map[string]types.AttributeValue, stored SchemaItem) (map[string]types.AttributeValue, error) {
+    storedAV, err := attributevalue.MarshalMap(stored)
+    if err != nil {
+        return nil, fmt.Errorf("failed to marshal stored item: %v", err)
+    }
+    var givenVersions map[string]any
+    if err := attributevalue.Unmarshal(given[FieldVersionsAttribute], &givenVersions); err != nil {
+        return nil, fmt.Errorf("failed to unmarshal %s: %v", FieldVersionsAttribute, err)
+    }
+
+    merged := make(map[string]types.AttributeValue, len(given))
+    versions := make(map[string]int64, len(trackedFields))
+    for name, value := range given {
+        merged[name] = value
+    }
+    for name := range trackedFields {
+        givenVersion, storedVersion := fieldVersion(givenVersions, name), fieldVersion(stored.{{.GoName}}, name)
+        if storedVersion > givenVersion {
+            if value, ok := storedAV[name]; ok {
+                merged[name] = value
+            } else {
+                delete(merged, name)
+            }
+        }
+        if version := max(givenVersion, storedVersion); version > 0 {
+            versions[name] = version
+        }
+    }
+    if merged[FieldVersionsAttribute], err = attributevalue.Marshal(versions); err != nil {
+        return nil, fmt.Errorf("failed to marshal %s: %v", FieldVersionsAttribute, err)
+    }
+    return merged, nil
+}
+{{- end}}
+`
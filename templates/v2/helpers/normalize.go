@@ -0,0 +1,72 @@
+package helpers
+
+// NormalizeHelpersTemplate provides the normalization of key attributes declared "normalized" in the schema
+const NormalizeHelpersTemplate = `
+// NormalizeKey returns the normalized form of a value of a normalized key attribute,
+// values of other attributes and non-string values are returned unchanged.
+// Puts, key inputs, updates and query conditions normalize their values,
+// use NormalizeKey for keys of requests built without the generated code.
+// Example:
+//   key := NormalizeKey(Column{{(index .NormalizedAttributes 0).GoName}}, " Value ")
+func NormalizeKey(attr string, value any) any {
+    switch v := value.(type) {
+    case string:
+        return normalizeKeyString(attr, v)
+    case *string:
+        if v != nil {
+            normalized := normalizeKeyString(attr, *v)
+            return &normalized
+        }
+    }
+    return value
+}
+
+// normalizeKeyString applies the normalization steps of the attribute to a string value.
+func normalizeKeyString(attr string, s string) string {
+    switch attr {
+    {{- range .NormalizedAttributes}}
+    case Column{{.GoName}}:
+        {{- range .NormalizeSteps}}
+        {{- if eq . "nfc"}}
+        s = norm.NFC.String(s)
+        {{- else if eq . "trim"}}
+        s = strings.TrimSpace(s)
+        {{- else if eq . "fold"}}
+        s = cases.Fold().String(s)
+        {{- end}}
+        {{- end}}
+    {{- end}}
+    }
+    return s
+}
+
+// normalizeKeyValues normalizes the values of a condition on the attribute.
+func normalizeKeyValues(attr string, values []any) []any {
+    normalized := make([]any, len(values))
+    for i, value := range values {
+        normalized[i] = NormalizeKey(attr, value)
+    }
+    return normalized
+}
+
+// normalizeKeys normalizes the key attributes of an item before it is written or used as a key.
+func normalizeKeys(item *SchemaItem) {
+    {{- range .NormalizedAttributes}}
+    {{- if .IsOptional}}
+    if item.{{.GoName}} != nil {
+        normalized := normalizeKeyString(Column{{.GoName}}, *item.{{.GoName}})
+        item.{{.GoName}} = &normalized
+    }
+    {{- else}}
+    item.{{.GoName}} = normalizeKeyString(Column{{.GoName}}, item.{{.GoName}})
+    {{- end}}
+    {{- end}}
+}
+
+// normalizeUpdates normalizes the values of normalized key attributes in a partial update.
+func normalizeUpdates(updates map[string]any) {
+    for attr, value := range updates {
+        updates[attr] = NormalizeKey(attr, value)
+    }
+}
+`
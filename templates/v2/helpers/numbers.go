@@ -0,0 +1,97 @@
+package helpers
+
+// NumberHelpersTemplate provides range and precision guards of numeric attributes
+const NumberHelpersTemplate = `
+// NumberRangeError reports a number which DynamoDB or the Go field of its attribute can't hold:
+// more than 38 significant digits, a magnitude outside 1e-130 to 1e126, NaN or infinity,
+// or a value overflowing or losing precision in the field type (e.g. 300 or 1.5 in an int8 field).
+// Marshaling items and updates and unmarshaling items return it instead of failing later.
+type NumberRangeError struct {
+    Attribute string
+    Value     string
+    Type      string // the Go field type, "N" for the DynamoDB number range
+}
+
+// Error implements error.
+func (e *NumberRangeError) Error() string {
+    return fmt.Sprintf("number %s of attribute '%s' doesn't fit %s", e.Value, e.Attribute, e.Type)
+}
+
+// numberFieldTypes maps number and number set attributes to the Go types of their fields.
+var numberFieldTypes = map[string]string{
+    {{- range .AllAttributes}}
+    {{- if .NumberGoType}}
+    Column{{.GoName}}: "{{.NumberGoType}}",
+    {{- end}}
+    {{- end}}
+}
+
+// checkNumbers validates the numbers of top-level N and NS attributes; nested numbers aren't checked.
+func checkNumbers(av map[string]types.AttributeValue) error {
+    for name, value := range av {
+        switch v := value.(type) {
+        case *types.AttributeValueMemberN:
+            if err := checkNumber(name, v.Value); err != nil {
+                return err
+            }
+        case *types.AttributeValueMemberNS:
+            for _, n := range v.Value {
+                if err := checkNumber(name, n); err != nil {
+                    return err
+                }
+            }
+        }
+    }
+    return nil
+}
+
+// checkNumber validates a number of the attribute against the DynamoDB range and its field type.
+func checkNumber(attr, n string) error {
+    if !dynamoNumberValid(n) {
+        return &NumberRangeError{Attribute: attr, Value: n, Type: "N"}
+    }
+    if goType, ok := numberFieldTypes[attr]; ok && !numberFits(n, goType) {
+        return &NumberRangeError{Attribute: attr, Value: n, Type: goType}
+    }
+    return nil
+}
+
+// dynamoNumberValid reports whether n is a DynamoDB number: at most 38 significant digits
+// with the first one at a decimal exponent from -130 to 125.
+func dynamoNumberValid(n string) bool {
+    mantissa, exp, hasExp := strings.Cut(strings.ToLower(strings.TrimLeft(n, "+-")), "e")
+    e := 0
+    if hasExp {
+        var err error
+        if e, err = strconv.Atoi(exp); err != nil {
+            return false
+        }
+    }
+    intPart, frac, _ := strings.Cut(mantissa, ".")
+    all := intPart + frac
+    if all == "" || strings.Trim(all, "0123456789") != "" {
+        return false
+    }
+    digits := strings.TrimLeft(all, "0")
+    if digits == "" {
+        return true
+    }
+    exponent := e + len(intPart) - (len(all) - len(digits)) - 1
+    return len(strings.TrimRight(digits, "0")) <= 38 && exponent >= -130 && exponent <= 125
+}
+
+// numberFits reports whether n converts to the Go type without overflow or loss of precision.
+func numberFits(n, goType string) bool {
+    bits, _ := strconv.Atoi(strings.TrimLeft(goType, "intufloa"))
+    var err error
+    switch {
+    case strings.HasPrefix(goType, "float"):
+        _, err = strconv.ParseFloat(n, bits)
+    case strings.HasPrefix(goType, "uint"):
+        _, err = strconv.ParseUint(n, 10, bits)
+    default:
+        _, err = strconv.ParseInt(n, 10, bits)
+    }
+    return err == nil
+}
+`
@@ -0,0 +1,98 @@
+package helpers
+
+// PayloadHelpersTemplate provides typed accessors of protobuf payloads declared with "payload" in the schema
+const PayloadHelpersTemplate = `
+// Encodings of payload attributes, stored in their first byte.
+const (
+    payloadRaw     byte = 0
+    payloadDeflate byte = 1
+)
+
+// maxPayloadSize bounds the decompressed size of payloads, guarding against compression bombs.
+const maxPayloadSize = 16 << 20
+
+// EncodePayload marshals a protobuf message to the bytes stored in payload attributes:
+// an encoding byte followed by the message, DEFLATE compressed if that makes it smaller.
+// Use it for raw updates of payload attributes; items have typed Set accessors.
+func EncodePayload(msg proto.Message) ([]byte, error) {
+    data, err := proto.Marshal(msg)
+    if err != nil {
+        return nil, fmt.Errorf("failed to marshal payload: %v", err)
+    }
+    var buf bytes.Buffer
+    buf.WriteByte(payloadDeflate)
+    w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+    if err != nil {
+        return nil, fmt.Errorf("failed to compress payload: %v", err)
+    }
+    if _, err := w.Write(data); err != nil {
+        return nil, fmt.Errorf("failed to compress payload: %v", err)
+    }
+    if err := w.Close(); err != nil {
+        return nil, fmt.Errorf("failed to compress payload: %v", err)
+    }
+    if buf.Len() > len(data) {
+        return append([]byte{payloadRaw}, data...), nil
+    }
+    return buf.Bytes(), nil
+}
+
+// DecodePayload unmarshals the bytes of a payload attribute written by EncodePayload into msg.
+func DecodePayload(data []byte, msg proto.Message) error {
+    if len(data) == 0 {
+        return errors.New("empty payload")
+    }
+    body := data[1:]
+    switch data[0] {
+    case payloadRaw:
+    case payloadDeflate:
+        r := flate.NewReader(bytes.NewReader(body))
+        defer r.Close()
+        var err error
+        if body, err = io.ReadAll(io.LimitReader(r, maxPayloadSize+1)); err != nil {
+            return fmt.Errorf("failed to decompress payload: %v", err)
+        }
+        if len(body) > maxPayloadSize {
+            return fmt.Errorf("payload exceeds %d bytes decompressed", maxPayloadSize)
+        }
+    default:
+        return fmt.Errorf("unknown payload encoding %d", data[0])
+    }
+    if err := proto.Unmarshal(body, msg); err != nil {
+        return fmt.Errorf("failed to unmarshal payload: %v", err)
+    }
+    return nil
+}
+{{- range .PayloadAttributes}}
+
+// Get{{.GoName}} returns the {{.PayloadType}} stored in {{.Name}}, nil if the item has none.
+func (item SchemaItem) Get{{.GoName}}() (*{{.PayloadType}}, error) {
+    if len(item.{{.GoName}}) == 0 {
+        return nil, nil
+    }
+    msg := &{{.PayloadType}}{}
+    if err := DecodePayload(item.{{.GoName}}, msg); err != nil {
+        return nil, fmt.Errorf("%s: %w", Column{{.GoName}}, err)
+    }
+    return msg, nil
+}
+
+// Set{{.GoName}} stores the message in {{.Name}}, marshaled and compressed; nil removes it.
+// Example:
+//   if err := item.Set{{.GoName}}(&{{.PayloadType}}{}); err != nil {
+//       return err
+//   }
+func (item *SchemaItem) Set{{.GoName}}(msg *{{.PayloadType}}) error {
+    if msg == nil {
+        item.{{.GoName}} = nil
+        return nil
+    }
+    data, err := EncodePayload(msg)
+    if err != nil {
+        return fmt.Errorf("%s: %w", Column{{.GoName}}, err)
+    }
+    item.{{.GoName}} = data
+    return nil
+}
+{{- end}}
+`
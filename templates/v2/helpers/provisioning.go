@@ -0,0 +1,108 @@
+package helpers
+
+// ProvisioningHelpersTemplate provides the CreateTable request of the table declared in the schema
+const ProvisioningHelpersTemplate = `
+// CreateTableInput returns the CreateTable request of the table declared in the schema:
+// key schema, key attribute definitions, secondary indexes, table class, warm throughput and SchemaTags.
+// The table is on-demand; set BillingMode and ProvisionedThroughput for provisioned capacity.
+// Tables with replicas get the NEW_AND_OLD_IMAGES stream Global Tables replicate from;
+// add the replicas with ReplicaUpdateInputs once the table is ACTIVE.
+{{- if .TTLAttribute}}
+// CreateTable can't enable TTL, send TimeToLiveInput once the table is ACTIVE.
+{{- end}}
+// Example:
+//   _, err := client.CreateTable(ctx, CreateTableInput())
+func CreateTableInput() *dynamodb.CreateTableInput {
+    input := &dynamodb.CreateTableInput{
+        TableName:   aws.String(TableName),
+        BillingMode: types.BillingModePayPerRequest,
+        KeySchema:   createTableKeySchema("{{.HashKey}}", "{{.RangeKey}}"),
+        AttributeDefinitions: []types.AttributeDefinition{
+            {{- range .KeyAttributeDefinitions}}
+            {AttributeName: aws.String("{{.Name}}"), AttributeType: types.ScalarAttributeType("{{.Type}}")},
+            {{- end}}
+        },
+        Tags: SchemaTags(),
+    }
+    {{- if .TableClass}}
+    input.TableClass = types.TableClass("{{.TableClass}}")
+    {{- end}}
+    {{- if .Replicas}}
+    input.StreamSpecification = &types.StreamSpecification{
+        StreamEnabled:  aws.Bool(true),
+        StreamViewType: types.StreamViewTypeNewAndOldImages,
+    }
+    {{- end}}
+    {{- with .WarmThroughput}}
+    input.WarmThroughput = &types.WarmThroughput{
+        {{- if .ReadUnitsPerSecond}}
+        ReadUnitsPerSecond: aws.Int64({{.ReadUnitsPerSecond}}),
+        {{- end}}
+        {{- if .WriteUnitsPerSecond}}
+        WriteUnitsPerSecond: aws.Int64({{.WriteUnitsPerSecond}}),
+        {{- end}}
+    }
+    {{- end}}
+    {{- range .SecondaryIndexes}}
+    {{- if .IsLSI}}
+    input.LocalSecondaryIndexes = append(input.LocalSecondaryIndexes, types.LocalSecondaryIndex{
+        IndexName:  aws.String("{{.Name}}"),
+        KeySchema:  createTableKeySchema("{{$.HashKey}}", "{{.RangeKey}}"),
+        Projection: createTableProjection("{{.ProjectionType}}"{{range .NonKeyAttributes}}, "{{.}}"{{end}}),
+    })
+    {{- else}}
+    input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, types.GlobalSecondaryIndex{
+        IndexName:  aws.String("{{.Name}}"),
+        KeySchema:  createTableKeySchema("{{.HashKey}}", "{{.RangeKey}}"),
+        Projection: createTableProjection("{{.ProjectionType}}"{{range .NonKeyAttributes}}, "{{.}}"{{end}}),
+        {{- with .WarmThroughput}}
+        WarmThroughput: &types.WarmThroughput{
+            {{- if .ReadUnitsPerSecond}}
+            ReadUnitsPerSecond: aws.Int64({{.ReadUnitsPerSecond}}),
+            {{- end}}
+            {{- if .WriteUnitsPerSecond}}
+            WriteUnitsPerSecond: aws.Int64({{.WriteUnitsPerSecond}}),
+            {{- end}}
+        },
+        {{- end}}
+    })
+    {{- end}}
+    {{- end}}
+    return input
+}
+{{- with .TTLAttribute}}
+
+// TimeToLiveInput returns the UpdateTimeToLive request enabling TTL on {{.Name}}.
+// Example:
+//   _, err := client.UpdateTimeToLive(ctx, TimeToLiveInput())
+func TimeToLiveInput() *dynamodb.UpdateTimeToLiveInput {
+    return &dynamodb.UpdateTimeToLiveInput{
+        TableName: aws.String(TableName),
+        TimeToLiveSpecification: &types.TimeToLiveSpecification{
+            AttributeName: aws.String(TTLAttribute),
+            Enabled:       aws.Bool(true),
+        },
+    }
+}
+{{- end}}
+
+// createTableKeySchema returns the key schema of a hash key and an optional range key.
+func createTableKeySchema(hashKey, rangeKey string) []types.KeySchemaElement {
+    keys := []types.KeySchemaElement{
+        {AttributeName: aws.String(hashKey), KeyType: types.KeyTypeHash},
+    }
+    if rangeKey != "" {
+        keys = append(keys, types.KeySchemaElement{AttributeName: aws.String(rangeKey), KeyType: types.KeyTypeRange})
+    }
+    return keys
+}
+
+// createTableProjection returns an index projection of the type with optional non-key attributes (INCLUDE).
+func createTableProjection(projectionType string, nonKeyAttributes ...string) *types.Projection {
+    p := &types.Projection{ProjectionType: types.ProjectionType(projectionType)}
+    if len(nonKeyAttributes) > 0 {
+        p.NonKeyAttributes = nonKeyAttributes
+    }
+    return p
+}
+`
@@ -0,0 +1,219 @@
+package helpers
+
+// PruneHelpersTemplate provides PruneOlderThan deleting old items of tables declared with "pruning" in the schema
+const PruneHelpersTemplate = `
+{{- if .PruneTimeUnit}}
+{{- $partition := .Attribute .HashKey}}
+{{- $time := .Attribute .RangeKey}}
+// PruneProgress reports the progress of PruneOlderThan after every deleted batch.
+type PruneProgress struct {
+    // Partition is the {{$partition.Name}} being pruned.
+    Partition {{$partition.GoType}}
+
+    // Partitions is the number of partitions pruned so far, the current one included.
+    Partitions int
+
+    // Deleted is the number of items deleted so far.
+    Deleted int
+}
+
+// PruneOption configures PruneOlderThan.
+type PruneOption func(*pruneOptions)
+
+type pruneOptions struct {
+    partitions []{{$partition.GoType}}
+    rate       int
+    progress   func(PruneProgress)
+}
+
+// WithPrunePartitions prunes the given partitions only, without scanning the table for them.
+func WithPrunePartitions(partitions ...{{$partition.GoType}}) PruneOption {
+    return func(o *pruneOptions) {
+        o.partitions = partitions
+    }
+}
+
+// WithPruneRate deletes at most n items per second, so pruning doesn't starve the table of write capacity.
+func WithPruneRate(n int) PruneOption {
+    return func(o *pruneOptions) {
+        o.rate = n
+    }
+}
+
+// WithPruneProgress calls fn after every deleted batch.
+func WithPruneProgress(fn func(PruneProgress)) PruneOption {
+    return func(o *pruneOptions) {
+        o.progress = fn
+    }
+}
+
+// PruneOlderThan deletes the items with a {{$time.Name}} before cutoff, for data TTL doesn't expire:
+// items written without an expiry, or kept longer than the retention now allows.
+// Every partition is queried by {{$time.Name}}, oldest first, and its old items are deleted in batches of 25.
+{{- if .NoScan}}
+// Partitions are named with WithPrunePartitions, the package is generated without Scan to find them.
+{{- else}}
+// Partitions are found by a Scan reading the keys of every item unless WithPrunePartitions names them.
+{{- end}}
+{{- if eq .PruneTimeUnit "rfc3339"}}
+// {{$time.Name}} values are compared as strings with cutoff as an RFC 3339 UTC timestamp.
+{{- end}}
+// Returns the number of items deleted, also if an error stops the pruning.
+// Example:
+//   deleted, err := PruneOlderThan(ctx, client, time.Now().AddDate(0, 0, -90),
+//       WithPruneRate(500),
+//       WithPruneProgress(func(p PruneProgress) {
+//           log.Printf("%d partitions, %d items deleted", p.Partitions, p.Deleted)
+//       }),
+//   )
+func PruneOlderThan(ctx context.Context, client DynamoDBAPI, cutoff time.Time, opts ...PruneOption) (int, error) {
+    var options pruneOptions
+    for _, opt := range opts {
+        opt(&options)
+    }
+    if options.rate < 0 {
+        return 0, fmt.Errorf("prune rate must not be negative, got %d", options.rate)
+    }
+    {{- if eq .PruneTimeUnit "ms"}}
+    before := cutoff.UnixMilli()
+    {{- else if eq .PruneTimeUnit "rfc3339"}}
+    before := cutoff.UTC().Format(time.RFC3339)
+    {{- else}}
+    before := cutoff.Unix()
+    {{- end}}
+    partitions := options.partitions
+    if partitions == nil {
+        {{- if .NoScan}}
+        return 0, errors.New("prune partitions must be named with WithPrunePartitions")
+        {{- else}}
+        var err error
+        if partitions, err = prunePartitions(ctx, client, before); err != nil {
+            return 0, err
+        }
+        {{- end}}
+    }
+
+    var (
+        started  = time.Now()
+        progress PruneProgress
+    )
+    for _, partition := range partitions {
+        progress.Partition = partition
+        progress.Partitions++
+        keyCondition := expression.Key(TableSchema.HashKey).Equal(expression.Value(partition)).
+            And(expression.Key(TableSchema.RangeKey).LessThan(expression.Value(before)))
+        expr, err := expression.NewBuilder().
+            WithKeyCondition(keyCondition).
+            WithProjection(expression.NamesList(expression.Name(TableSchema.HashKey), expression.Name(TableSchema.RangeKey))).
+            Build()
+        if err != nil {
+            return progress.Deleted, fmt.Errorf("failed to build prune key condition: %v", err)
+        }
+        input := &dynamodb.QueryInput{
+            TableName:                 aws.String(TableSchema.TableName),
+            KeyConditionExpression:    expr.KeyCondition(),
+            ProjectionExpression:      expr.Projection(),
+            ExpressionAttributeNames:  expr.Names(),
+            ExpressionAttributeValues: expr.Values(),
+            Limit:                     aws.Int32(maxBatchWriteItems),
+        }
+        for {
+            out, err := client.Query(ctx, input)
+            if err != nil {
+                return progress.Deleted, fmt.Errorf("failed to query partition %v: %w", partition, err)
+            }
+            items := make([]SchemaItem, 0, len(out.Items))
+            for _, av := range out.Items {
+                item, err := UnmarshalItem(av)
+                if err != nil {
+                    return progress.Deleted, err
+                }
+                items = append(items, *item)
+            }
+            if len(items) > 0 {
+                if err := BatchWriteItems(ctx, client, nil, items); err != nil {
+                    return progress.Deleted, fmt.Errorf("failed to prune partition %v: %w", partition, err)
+                }
+                progress.Deleted += len(items)
+                if options.progress != nil {
+                    options.progress(progress)
+                }
+                if err := prunePace(ctx, started, progress.Deleted, options.rate); err != nil {
+                    return progress.Deleted, err
+                }
+            }
+            if len(out.LastEvaluatedKey) == 0 {
+                break
+            }
+            input.ExclusiveStartKey = out.LastEvaluatedKey
+        }
+    }
+    return progress.Deleted, nil
+}
+
+{{- if not .NoScan}}
+
+// prunePartitions scans the table for the partitions holding items with a {{$time.Name}} before the cutoff value,
+// in scan order.
+func prunePartitions(ctx context.Context, client DynamoDBAPI, before any) ([]{{$partition.GoType}}, error) {
+    expr, err := expression.NewBuilder().
+        WithFilter(expression.Name(TableSchema.RangeKey).LessThan(expression.Value(before))).
+        WithProjection(expression.NamesList(expression.Name(TableSchema.HashKey))).
+        Build()
+    if err != nil {
+        return nil, fmt.Errorf("failed to build partition scan: %v", err)
+    }
+    input := &dynamodb.ScanInput{
+        TableName:                 aws.String(TableSchema.TableName),
+        FilterExpression:          expr.Filter(),
+        ProjectionExpression:      expr.Projection(),
+        ExpressionAttributeNames:  expr.Names(),
+        ExpressionAttributeValues: expr.Values(),
+    }
+    var (
+        partitions []{{$partition.GoType}}
+        seen       = map[{{$partition.GoType}}]bool{}
+    )
+    for {
+        out, err := client.Scan(ctx, input)
+        if err != nil {
+            return nil, fmt.Errorf("failed to scan partitions: %w", err)
+        }
+        for _, av := range out.Items {
+            var partition {{$partition.GoType}}
+            if err := attributevalue.Unmarshal(av[TableSchema.HashKey], &partition); err != nil {
+                return nil, fmt.Errorf("failed to unmarshal partition: %v", err)
+            }
+            if !seen[partition] {
+                seen[partition] = true
+                partitions = append(partitions, partition)
+            }
+        }
+        if len(out.LastEvaluatedKey) == 0 {
+            return partitions, nil
+        }
+        input.ExclusiveStartKey = out.LastEvaluatedKey
+    }
+}
+{{- end}}
+
+// prunePace sleeps until the deleted items fit into rate items per second since started, 0 for no limit.
+func prunePace(ctx context.Context, started time.Time, deleted, rate int) error {
+    if rate == 0 {
+        return nil
+    }
+    wait := time.Until(started.Add(time.Duration(deleted) * time.Second / time.Duration(rate)))
+    if wait <= 0 {
+        return nil
+    }
+    timer := time.NewTimer(wait)
+    defer timer.Stop()
+    select {
+    case <-ctx.Done():
+        return ctx.Err()
+    case <-timer.C:
+        return nil
+    }
+}
+{{- end}}
+`
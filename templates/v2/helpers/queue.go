@@ -0,0 +1,126 @@
+package helpers
+
+// QueueHelpersTemplate provides a job queue on the table declared with "queue" in the schema
+const QueueHelpersTemplate = `
+{{- with .Queue}}
+{{- $queue := $.Attribute .HashKey}}
+{{- $visibility := $.Attribute .RangeKey}}
+// QueueVisibilityAttribute is the attribute holding the Unix milliseconds a job can be claimed from.
+// Claim queries jobs visible by now on Index{{ToSafeName .Name | ToUpperCamelCase}}, keyed by {{$queue.Name}}.
+const QueueVisibilityAttribute = Column{{$visibility.GoName}}
+
+// ErrQueueEmpty is returned by Claim when no job of the queue is visible,
+// or all visible jobs were claimed concurrently.
+var ErrQueueEmpty = errors.New("no visible job in queue")
+
+// ErrJobLost is returned by Complete when the visibility timeout of the job elapsed
+// and another worker claimed it, or the job was already completed.
+var ErrJobLost = errors.New("job lost")
+
+// QueueClock returns the time visibility is computed from and checked against.
+// Replace it in tests for deterministic visibility.
+var QueueClock = time.Now
+
+// maxClaimCandidates is the number of visible jobs Claim tries to claim before giving up.
+const maxClaimCandidates = 10
+
+// Enqueue puts a new job, visible to Claim after delay.
+// Fails with a ConditionalCheckFailedException if an item with the same key exists.
+// Example:
+//   err := Enqueue(ctx, client, job, 0)
+func Enqueue(ctx context.Context, client DynamoDBAPI, job SchemaItem, delay time.Duration) error {
+    job.{{$visibility.GoName}} = {{$visibility.GoType}}(QueueClock().Add(delay).UnixMilli())
+    input, err := PutItemInputIf(job, expression.Name(TableSchema.HashKey).AttributeNotExists())
+    if err != nil {
+        return err
+    }
+    if _, err := client.PutItem(ctx, input); err != nil {
+        return fmt.Errorf("failed to enqueue job: %w", err)
+    }
+    return nil
+}
+
+// Claim claims the oldest visible job of the queue for timeout: the job is hidden from other workers
+// until then and reappears if it isn't completed in time, so jobs have to be idempotent.
+// Returns the claimed job, pass it to Complete; an error matching ErrQueueEmpty if no job could be claimed.
+// Example:
+//   job, err := Claim(ctx, client, {{$.ExampleKey $queue.Name}}, time.Minute)
+//   if errors.Is(err, ErrQueueEmpty) {
+//       // poll later
+//   }
+func Claim(ctx context.Context, client DynamoDBAPI, queue {{$queue.GoType}}, timeout time.Duration) (*SchemaItem, error) {
+    if timeout <= 0 {
+        return nil, fmt.Errorf("visibility timeout must be positive, got %s", timeout)
+    }
+    now := QueueClock().UnixMilli()
+    keyCondition := expression.Key(Column{{$queue.GoName}}).Equal(expression.Value(queue)).
+        And(expression.Key(QueueVisibilityAttribute).LessThanEqual(expression.Value(now)))
+    expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+    if err != nil {
+        return nil, fmt.Errorf("failed to build queue key condition: %v", err)
+    }
+    out, err := client.Query(ctx, &dynamodb.QueryInput{
+        TableName:                 aws.String(TableSchema.TableName),
+        IndexName:                 aws.String(Index{{ToSafeName .Name | ToUpperCamelCase}}),
+        KeyConditionExpression:    expr.KeyCondition(),
+        ExpressionAttributeNames:  expr.Names(),
+        ExpressionAttributeValues: expr.Values(),
+        Limit:                     aws.Int32(maxClaimCandidates),
+    })
+    if err != nil {
+        return nil, fmt.Errorf("failed to query visible jobs: %w", err)
+    }
+    for _, av := range out.Items {
+        candidate, err := UnmarshalItem(av)
+        if err != nil {
+            return nil, err
+        }
+        job, err := claimJob(ctx, client, *candidate, now, timeout)
+        var failed *types.ConditionalCheckFailedException
+        if errors.As(err, &failed) {
+            continue
+        }
+        return job, err
+    }
+    return nil, ErrQueueEmpty
+}
+
+// claimJob hides the job until timeout from now if it's still visible at now.
+func claimJob(ctx context.Context, client DynamoDBAPI, candidate SchemaItem, now int64, timeout time.Duration) (*SchemaItem, error) {
+    key := ItemKeyOf(candidate)
+    updates := map[string]any{
+        QueueVisibilityAttribute: {{$visibility.GoType}}(QueueClock().Add(timeout).UnixMilli()),
+    }
+    condition := expression.Name(QueueVisibilityAttribute).LessThanEqual(expression.Value(now))
+    input, err := UpdateItemInputIf(key.HashKey, key.RangeKey, updates, condition)
+    if err != nil {
+        return nil, err
+    }
+    input.ReturnValues = types.ReturnValueAllNew
+    out, err := client.UpdateItem(ctx, input)
+    if err != nil {
+        return nil, err
+    }
+    return UnmarshalItem(out.Attributes)
+}
+
+// Complete deletes a job returned by Claim.
+// Returns an error matching ErrJobLost if the job was claimed again after its visibility timeout.
+func Complete(ctx context.Context, client DynamoDBAPI, job SchemaItem) error {
+    key := ItemKeyOf(job)
+    condition := expression.Name(QueueVisibilityAttribute).Equal(expression.Value(job.{{$visibility.GoName}}))
+    input, err := DeleteItemInputIf(key.HashKey, key.RangeKey, condition)
+    if err != nil {
+        return err
+    }
+    if _, err := client.DeleteItem(ctx, input); err != nil {
+        var failed *types.ConditionalCheckFailedException
+        if errors.As(err, &failed) {
+            return fmt.Errorf("%w: %v", ErrJobLost, key)
+        }
+        return fmt.Errorf("failed to complete job: %w", err)
+    }
+    return nil
+}
+{{- end}}
+`
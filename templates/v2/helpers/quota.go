@@ -0,0 +1,172 @@
+package helpers
+
+// QuotaHelpersTemplate provides rate and quota counters stored in the table, declared with "quotas" in the schema
+const QuotaHelpersTemplate = `
+{{- if .Quotas}}
+// QuotaKeyPrefix is prepended to subjects in the hash key of quota window items.
+// Window items are stored next to the items of the table: scans return them too,
+// skip hash keys starting with QuotaKeyPrefix.
+const QuotaKeyPrefix = {{printf "%q" .QuotaKeyPrefix}}
+
+// quotaCountAttribute is the attribute of window items counting the consumed units.
+const quotaCountAttribute = "quota_count"
+
+// ErrQuotaExceeded matches every *QuotaExceededError:
+//   if errors.Is(err, ErrQuotaExceeded) { /* reject the request */ }
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaExceededError reports a ConsumeQuota rejected because the subject used up its quota.
+type QuotaExceededError struct {
+    Subject string
+    Limit   int64
+
+    // RetryAfter is the time until the current window ends.
+    // Sliding windows may still be used up then.
+    RetryAfter time.Duration
+}
+
+// Error implements error.
+func (e *QuotaExceededError) Error() string {
+    return fmt.Sprintf("quota of %s exceeded: limit %d, retry after %s", e.Subject, e.Limit, e.RetryAfter)
+}
+
+// Is reports whether target is ErrQuotaExceeded.
+func (e *QuotaExceededError) Is(target error) bool {
+    return target == ErrQuotaExceeded
+}
+
+// QuotaClock returns the time windows are computed from.
+// Replace it in tests for deterministic windows.
+var QuotaClock = time.Now
+
+// QuotaOption configures ConsumeQuota.
+type QuotaOption func(*quotaOptions)
+
+type quotaOptions struct {
+    cost    int64
+    sliding bool
+}
+
+// WithQuotaCost consumes n units instead of 1, e.g. for weighted requests.
+func WithQuotaCost(n int64) QuotaOption {
+    return func(o *quotaOptions) {
+        o.cost = n
+    }
+}
+
+// WithSlidingWindow counts the previous window weighted by its overlap with the window ending now,
+// smoothing bursts at window boundaries. Costs a read of the previous window.
+func WithSlidingWindow() QuotaOption {
+    return func(o *quotaOptions) {
+        o.sliding = true
+    }
+}
+
+// ConsumeQuota consumes a unit of the quota of subject, limit units per window, and returns the units
+// left in the window. Windows are fixed and aligned to the Unix epoch unless WithSlidingWindow;
+// counters of different window durations are separate.
+// Returns a *QuotaExceededError matching ErrQuotaExceeded if the quota is used up, consuming nothing.
+{{- if not .TTLAttribute}}
+// Declare a TTL attribute to delete window items after use.
+{{- end}}
+// Example:
+//   remaining, err := ConsumeQuota(ctx, client, "user-42", 100, time.Minute)
+//   if errors.Is(err, ErrQuotaExceeded) {
+//       // reject with 429
+//   }
+func ConsumeQuota(ctx context.Context, client DynamoDBAPI, subject string, limit int64, window time.Duration, opts ...QuotaOption) (int64, error) {
+    options := quotaOptions{cost: 1}
+    for _, opt := range opts {
+        opt(&options)
+    }
+    if window < time.Millisecond {
+        return 0, fmt.Errorf("quota window must be at least 1ms, got %s", window)
+    }
+    if options.cost <= 0 {
+        return 0, fmt.Errorf("quota cost must be positive, got %d", options.cost)
+    }
+
+    now := QuotaClock()
+    start := time.UnixMilli(now.UnixMilli() - now.UnixMilli()%window.Milliseconds())
+    exceeded := &QuotaExceededError{Subject: subject, Limit: limit, RetryAfter: start.Add(window).Sub(now)}
+    allowance := limit
+    if options.sliding {
+        previous, err := quotaCount(ctx, client, quotaKey(subject, window, start.Add(-window)))
+        if err != nil {
+            return 0, err
+        }
+        overlap := 1 - float64(now.Sub(start))/float64(window)
+        allowance -= int64(math.Ceil(float64(previous) * overlap))
+    }
+    if allowance < options.cost {
+        return 0, exceeded
+    }
+
+    key, err := KeyInputFromRaw(quotaKey(subject, window, start), {{.HelperSortKey .QuotaSortKey}})
+    if err != nil {
+        return 0, err
+    }
+    update := expression.Add(expression.Name(quotaCountAttribute), expression.Value(options.cost))
+    {{- if .TTLAttribute}}
+    // The next window reads this one when sliding.
+    update = update.Set(expression.Name(TTLAttribute), expression.Value(start.Add(2*window).Unix()))
+    {{- end}}
+    condition := expression.Name(quotaCountAttribute).AttributeNotExists().
+        Or(expression.Name(quotaCountAttribute).LessThanEqual(expression.Value(allowance - options.cost)))
+    expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+    if err != nil {
+        return 0, fmt.Errorf("failed to build quota expression: %v", err)
+    }
+    out, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+        TableName:                 aws.String(TableSchema.TableName),
+        Key:                       key,
+        UpdateExpression:          expr.Update(),
+        ConditionExpression:       expr.Condition(),
+        ExpressionAttributeNames:  expr.Names(),
+        ExpressionAttributeValues: expr.Values(),
+        ReturnValues:              types.ReturnValueUpdatedNew,
+    })
+    if err != nil {
+        var failed *types.ConditionalCheckFailedException
+        if errors.As(err, &failed) {
+            return 0, exceeded
+        }
+        return 0, fmt.Errorf("failed to consume quota of %s: %w", subject, err)
+    }
+    var count int64
+    if err := attributevalue.Unmarshal(out.Attributes[quotaCountAttribute], &count); err != nil {
+        return 0, fmt.Errorf("failed to unmarshal quota count: %v", err)
+    }
+    return allowance - count, nil
+}
+
+// quotaKey returns the hash key of the window item of subject starting at start.
+func quotaKey(subject string, window time.Duration, start time.Time) string {
+    return fmt.Sprintf("%s%s#%d#%d", QuotaKeyPrefix, subject, window.Milliseconds(), start.UnixMilli())
+}
+
+// quotaCount returns the units consumed in the window item with the hash key, 0 if it doesn't exist.
+func quotaCount(ctx context.Context, client DynamoDBAPI, hashKey string) (int64, error) {
+    key, err := KeyInputFromRaw(hashKey, {{.HelperSortKey .QuotaSortKey}})
+    if err != nil {
+        return 0, err
+    }
+    out, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+        TableName: aws.String(TableSchema.TableName),
+        Key:       key,
+    })
+    if err != nil {
+        return 0, fmt.Errorf("failed to read quota window: %w", err)
+    }
+    av, ok := out.Item[quotaCountAttribute]
+    if !ok {
+        return 0, nil
+    }
+    var count int64
+    if err := attributevalue.Unmarshal(av, &count); err != nil {
+        return 0, fmt.Errorf("failed to unmarshal quota count: %v", err)
+    }
+    return count, nil
+}
+{{- end}}
+`
@@ -0,0 +1,69 @@
+package helpers
+
+// ReplicaHelpersTemplate provides Global Tables replica helpers
+const ReplicaHelpersTemplate = `
+// ReplicaRegions lists the Global Tables replica regions declared in the schema.
+var ReplicaRegions = []string{
+    {{- range .Replicas}}
+    "{{.}}",
+    {{- end}}
+}
+
+// IsReplicaRegion reports whether the table has a replica declared in the region.
+func IsReplicaRegion(region string) bool {
+    return slices.Contains(ReplicaRegions, region)
+}
+
+{{- if IsALL .Mode}}
+
+// NewReplicaClient creates a DynamoDB client for the table replica in the region.
+// The region overrides opts.Region; the other options apply as in NewClient.
+// Example:
+//   client, err := NewReplicaClient(ctx, "eu-west-1", ClientOptions{})
+func NewReplicaClient(ctx context.Context, region string, opts ClientOptions) (*dynamodb.Client, error) {
+    if !IsReplicaRegion(region) {
+        return nil, fmt.Errorf("no replica of table %s declared in region %s, replicas: %v", TableName, region, ReplicaRegions)
+    }
+    opts.Region = region
+    return NewClient(ctx, opts)
+}
+
+// NewReplicaClients creates a DynamoDB client per replica region, keyed by region.
+// Example:
+//   clients, err := NewReplicaClients(ctx, ClientOptions{})
+//   out, err := clients["eu-west-1"].GetItem(ctx, input)
+func NewReplicaClients(ctx context.Context, opts ClientOptions) (map[string]*dynamodb.Client, error) {
+    clients := make(map[string]*dynamodb.Client, len(ReplicaRegions))
+    for _, region := range ReplicaRegions {
+        client, err := NewReplicaClient(ctx, region, opts)
+        if err != nil {
+            return nil, fmt.Errorf("failed to create client for replica region %s: %v", region, err)
+        }
+        clients[region] = client
+    }
+    return clients, nil
+}
+{{- end}}
+
+// ReplicaUpdateInputs returns the UpdateTable requests adding the declared replicas
+// to the table created with CreateTableInput, one per replica.
+// DynamoDB adds a single replica per request: send them in order and wait
+// until the table is ACTIVE before sending the next one.
+// Example:
+//   for _, input := range ReplicaUpdateInputs() {
+//       _, err := client.UpdateTable(ctx, input)
+//       // wait for the table to become ACTIVE
+//   }
+func ReplicaUpdateInputs() []*dynamodb.UpdateTableInput {
+    inputs := make([]*dynamodb.UpdateTableInput, 0, len(ReplicaRegions))
+    for _, region := range ReplicaRegions {
+        inputs = append(inputs, &dynamodb.UpdateTableInput{
+            TableName: aws.String(TableName),
+            ReplicaUpdates: []types.ReplicationGroupUpdate{
+                {Create: &types.CreateReplicationGroupMemberAction{RegionName: aws.String(region)}},
+            },
+        })
+    }
+    return inputs
+}
+`
@@ -0,0 +1,70 @@
+package helpers
+
+// RuleHelpersTemplate provides SchemaItem.Validate checking the validation rules declared in the schema
+const RuleHelpersTemplate = `
+// ValidateOnPut makes ItemInput reject items violating the validation rules, see SchemaItem.Validate.
+// Set it at startup.
+var ValidateOnPut = true
+
+// Validate checks the item against the validation rules of the schema and returns all violations
+// joined with errors.Join, nil if the item is valid.
+// Example:
+//   if err := item.Validate(); err != nil {
+//       log.Printf("invalid item:\n%v", err) // one violation per line
+//   }
+func (item SchemaItem) Validate() error {
+    var errs []error
+    {{- range .RuleAttributes}}
+    {{- if .IsOptional}}
+    if item.{{.GoName}} != nil {
+        errs = append(errs, validate{{.GoName}}(*item.{{.GoName}})...)
+    }
+    {{- else}}
+    errs = append(errs, validate{{.GoName}}(item.{{.GoName}})...)
+    {{- end}}
+    {{- end}}
+    return errors.Join(errs...)
+}
+{{- range .RuleAttributes}}
+{{- $attr := .}}
+{{- if .Pattern}}
+
+// pattern{{.GoName}} is the pattern of {{.Name}} values.
+var pattern{{.GoName}} = regexp.MustCompile({{printf "%q" .Pattern}})
+{{- end}}
+
+// validate{{.GoName}} checks a value of {{.Name}} against its rules.
+func validate{{.GoName}}(v {{.GoType}}) []error {
+    var errs []error
+    {{- if .HasLengthRule}}
+    n := {{if eq .Type "S"}}utf8.RuneCountInString(string(v)){{else}}len(v){{end}}
+    {{- with .MinLength}}
+    if n < {{.}} {
+        errs = append(errs, fmt.Errorf("%s: length %d is less than %d", Column{{$attr.GoName}}, n, {{.}}))
+    }
+    {{- end}}
+    {{- with .MaxLength}}
+    if n > {{.}} {
+        errs = append(errs, fmt.Errorf("%s: length %d is greater than %d", Column{{$attr.GoName}}, n, {{.}}))
+    }
+    {{- end}}
+    {{- end}}
+    {{- with .Min}}
+    if float64(v) < {{.}} {
+        errs = append(errs, fmt.Errorf("%s: %v is less than %v", Column{{$attr.GoName}}, v, {{.}}))
+    }
+    {{- end}}
+    {{- with .Max}}
+    if float64(v) > {{.}} {
+        errs = append(errs, fmt.Errorf("%s: %v is greater than %v", Column{{$attr.GoName}}, v, {{.}}))
+    }
+    {{- end}}
+    {{- if .Pattern}}
+    if !pattern{{.GoName}}.MatchString(string(v)) {
+        errs = append(errs, fmt.Errorf("%s: %q doesn't match %s", Column{{.GoName}}, v, pattern{{.GoName}}))
+    }
+    {{- end}}
+    return errs
+}
+{{- end}}
+`
@@ -0,0 +1,115 @@
+package helpers
+
+// SessionHelpersTemplate provides session store operations for tables declared with "session_store" in the schema
+const SessionHelpersTemplate = `
+{{- with .SessionStore}}
+{{- $user := $.Attribute .HashKey}}
+{{- $session := $.Attribute $.HashKey}}
+// ErrSessionNotFound is returned by TouchSession for sessions which don't exist or expired.
+var ErrSessionNotFound = errors.New("session not found")
+
+// NewSessionID returns a random session ID: 256 bits from crypto/rand, URL-safe base64 encoded.
+func NewSessionID() (string, error) {
+    buf := make([]byte, 32)
+    if _, err := cryptorand.Read(buf); err != nil {
+        return "", fmt.Errorf("failed to generate session ID: %v", err)
+    }
+    return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// CreateSession puts a new session expiring after ttl and returns it as stored.
+// An empty {{$session.Name}} is set to NewSessionID; an existing session with the same ID is never overwritten.
+// Example:
+//   session, err := CreateSession(ctx, client, SchemaItem{ {{- $user.GoName}}: {{$.ExampleKey $user.Name}}}, 24*time.Hour)
+func CreateSession(ctx context.Context, client DynamoDBAPI, session SchemaItem, ttl time.Duration) (SchemaItem, error) {
+    if ttl <= 0 {
+        return SchemaItem{}, fmt.Errorf("session ttl must be positive, got %s", ttl)
+    }
+    if session.{{$session.GoName}} == "" {
+        id, err := NewSessionID()
+        if err != nil {
+            return SchemaItem{}, err
+        }
+        session.{{$session.GoName}} = id
+    }
+    session = session.WithTTL(ttl)
+    input, err := PutItemInputIf(session, expression.Name(TableSchema.HashKey).AttributeNotExists())
+    if err != nil {
+        return SchemaItem{}, err
+    }
+    if _, err := client.PutItem(ctx, input); err != nil {
+        return SchemaItem{}, fmt.Errorf("failed to create session: %w", err)
+    }
+    return session, nil
+}
+
+// TouchSession extends the session to expire ttl from now (sliding expiration).
+// Returns an error matching ErrSessionNotFound if the session doesn't exist or expired:
+// DynamoDB deletes expired sessions late, they can't be touched back to life.
+func TouchSession(ctx context.Context, client DynamoDBAPI, sessionID string, ttl time.Duration) error {
+    if ttl <= 0 {
+        return fmt.Errorf("session ttl must be positive, got %s", ttl)
+    }
+    updates := map[string]any{TTLAttribute: TTLValue(ttl)}
+    condition := expression.Name(TTLAttribute).GreaterThan(expression.Value(TTLClock().Unix()))
+    input, err := UpdateItemInputIf(sessionID, nil, updates, condition)
+    if err != nil {
+        return err
+    }
+    if _, err := client.UpdateItem(ctx, input); err != nil {
+        var failed *types.ConditionalCheckFailedException
+        if errors.As(err, &failed) {
+            return fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+        }
+        return fmt.Errorf("failed to touch session: %w", err)
+    }
+    return nil
+}
+
+// RevokeAllForUser deletes all sessions of the user, found on Index{{ToSafeName .Name | ToUpperCamelCase}},
+// and returns the number of sessions deleted. The index is eventually consistent:
+// a session created right before the call may survive it.
+// Example:
+//   revoked, err := RevokeAllForUser(ctx, client, {{$.ExampleKey $user.Name}})
+func RevokeAllForUser(ctx context.Context, client DynamoDBAPI, userID {{$user.GoType}}) (int, error) {
+    expr, err := expression.NewBuilder().
+        WithKeyCondition(expression.Key(Column{{$user.GoName}}).Equal(expression.Value(userID))).
+        Build()
+    if err != nil {
+        return 0, fmt.Errorf("failed to build user key condition: %v", err)
+    }
+    input := &dynamodb.QueryInput{
+        TableName:                 aws.String(TableSchema.TableName),
+        IndexName:                 aws.String(Index{{ToSafeName .Name | ToUpperCamelCase}}),
+        KeyConditionExpression:    expr.KeyCondition(),
+        ExpressionAttributeNames:  expr.Names(),
+        ExpressionAttributeValues: expr.Values(),
+    }
+    var sessions []SchemaItem
+    for {
+        out, err := client.Query(ctx, input)
+        if err != nil {
+            return 0, fmt.Errorf("failed to query sessions of user: %w", err)
+        }
+        for _, av := range out.Items {
+            session, err := UnmarshalItem(av)
+            if err != nil {
+                return 0, err
+            }
+            sessions = append(sessions, *session)
+        }
+        if len(out.LastEvaluatedKey) == 0 {
+            break
+        }
+        input.ExclusiveStartKey = out.LastEvaluatedKey
+    }
+    if len(sessions) == 0 {
+        return 0, nil
+    }
+    if err := BatchWriteItems(ctx, client, nil, sessions); err != nil {
+        return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+    }
+    return len(sessions), nil
+}
+{{- end}}
+`
@@ -9,10 +9,8 @@ func ExtractFromDynamoDBStreamEvent(dbEvent events.DynamoDBEventRecord) (*Schema
     if dbEvent.Change.NewImage == nil {
         return nil, fmt.Errorf("new image is nil in the event")
     }
-    dynamoAttrs := toDynamoMap(dbEvent.Change.NewImage)
-    
-    var item SchemaItem
-    if err := attributevalue.UnmarshalMap(dynamoAttrs, &item); err != nil {
+    item, err := FromStreamAttributeValues(dbEvent.Change.NewImage)
+    if err != nil {
         return nil, fmt.Errorf("failed to unmarshal DynamoDB stream event: %v", err)
     }
     return &item, nil
@@ -25,57 +23,144 @@ func ExtractOldFromDynamoDBStreamEvent(dbEvent events.DynamoDBEventRecord) (*Sch
     if dbEvent.Change.OldImage == nil {
         return nil, fmt.Errorf("old image is nil in the event")
     }
-    dynamoAttrs := toDynamoMap(dbEvent.Change.OldImage)
-    
-    var item SchemaItem
-    if err := attributevalue.UnmarshalMap(dynamoAttrs, &item); err != nil {
+    item, err := FromStreamAttributeValues(dbEvent.Change.OldImage)
+    if err != nil {
         return nil, fmt.Errorf("failed to unmarshal old DynamoDB stream event: %v", err)
     }
     return &item, nil
 }
 
-// toDynamoMap converts Lambda events.DynamoDBAttributeValue to SDK types.AttributeValue.
-func toDynamoMap(streamAttrs map[string]events.DynamoDBAttributeValue) map[string]types.AttributeValue {
+// FromStreamAttributeValues converts a Lambda stream image (NewImage, OldImage or Keys) to a SchemaItem.
+// All DynamoDB types are supported, including sets, nested lists and maps, and binary values.
+func FromStreamAttributeValues(image map[string]events.DynamoDBAttributeValue) (SchemaItem, error) {
+    av, err := StreamToAttributeValues(image)
+    if err != nil {
+        return SchemaItem{}, err
+    }
+    return FromAttributeValues(av)
+}
+
+// ToStreamAttributeValues converts a SchemaItem to a Lambda stream image,
+// e.g. to build events.DynamoDBEventRecord fixtures in tests of trigger handlers.
+func ToStreamAttributeValues(item SchemaItem) (map[string]events.DynamoDBAttributeValue, error) {
+    av, err := ToAttributeValues(item)
+    if err != nil {
+        return nil, err
+    }
+    return AttributeValuesToStream(av)
+}
+
+// StreamToAttributeValues converts Lambda events.DynamoDBAttributeValue map to SDK types.AttributeValue map.
+func StreamToAttributeValues(streamAttrs map[string]events.DynamoDBAttributeValue) (map[string]types.AttributeValue, error) {
     dynamoAttrs := make(map[string]types.AttributeValue, len(streamAttrs))
     for key, streamAttr := range streamAttrs {
-        dynamoAttrs[key] = toDynamoAttr(streamAttr)
+        attr, err := toDynamoAttr(streamAttr)
+        if err != nil {
+            return nil, fmt.Errorf("attribute '%s': %w", key, err)
+        }
+        dynamoAttrs[key] = attr
+    }
+    return dynamoAttrs, nil
+}
+
+// AttributeValuesToStream converts SDK types.AttributeValue map to Lambda events.DynamoDBAttributeValue map.
+func AttributeValuesToStream(dynamoAttrs map[string]types.AttributeValue) (map[string]events.DynamoDBAttributeValue, error) {
+    streamAttrs := make(map[string]events.DynamoDBAttributeValue, len(dynamoAttrs))
+    for key, dynamoAttr := range dynamoAttrs {
+        attr, err := toStreamAttr(dynamoAttr)
+        if err != nil {
+            return nil, fmt.Errorf("attribute '%s': %w", key, err)
+        }
+        streamAttrs[key] = attr
     }
-    return dynamoAttrs
+    return streamAttrs, nil
 }
 
 // toDynamoAttr converts single Lambda AttributeValue to SDK AttributeValue.
-func toDynamoAttr(streamAttr events.DynamoDBAttributeValue) types.AttributeValue {
+func toDynamoAttr(streamAttr events.DynamoDBAttributeValue) (types.AttributeValue, error) {
     switch streamAttr.DataType() {
     case events.DataTypeString:
-        return &types.AttributeValueMemberS{Value: streamAttr.String()}
+        return &types.AttributeValueMemberS{Value: streamAttr.String()}, nil
     case events.DataTypeNumber:
-        return &types.AttributeValueMemberN{Value: streamAttr.Number()}
+        return &types.AttributeValueMemberN{Value: streamAttr.Number()}, nil
     case events.DataTypeBoolean:
-        return &types.AttributeValueMemberBOOL{Value: streamAttr.Boolean()}
+        return &types.AttributeValueMemberBOOL{Value: streamAttr.Boolean()}, nil
     case events.DataTypeStringSet:
-        return &types.AttributeValueMemberSS{Value: streamAttr.StringSet()}
+        return &types.AttributeValueMemberSS{Value: streamAttr.StringSet()}, nil
     case events.DataTypeNumberSet:
-        return &types.AttributeValueMemberNS{Value: streamAttr.NumberSet()}
+        return &types.AttributeValueMemberNS{Value: streamAttr.NumberSet()}, nil
     case events.DataTypeBinarySet:
-        return &types.AttributeValueMemberBS{Value: streamAttr.BinarySet()}
+        return &types.AttributeValueMemberBS{Value: streamAttr.BinarySet()}, nil
     case events.DataTypeBinary:
-        return &types.AttributeValueMemberB{Value: streamAttr.Binary()}
+        return &types.AttributeValueMemberB{Value: streamAttr.Binary()}, nil
     case events.DataTypeList:
         list := make([]types.AttributeValue, len(streamAttr.List()))
         for i, item := range streamAttr.List() {
-            list[i] = toDynamoAttr(item)
+            attr, err := toDynamoAttr(item)
+            if err != nil {
+                return nil, fmt.Errorf("list element %d: %w", i, err)
+            }
+            list[i] = attr
         }
-        return &types.AttributeValueMemberL{Value: list}
+        return &types.AttributeValueMemberL{Value: list}, nil
     case events.DataTypeMap:
         m := make(map[string]types.AttributeValue, len(streamAttr.Map()))
         for k, v := range streamAttr.Map() {
-            m[k] = toDynamoAttr(v)
+            attr, err := toDynamoAttr(v)
+            if err != nil {
+                return nil, fmt.Errorf("map key '%s': %w", k, err)
+            }
+            m[k] = attr
         }
-        return &types.AttributeValueMemberM{Value: m}
+        return &types.AttributeValueMemberM{Value: m}, nil
     case events.DataTypeNull:
-        return &types.AttributeValueMemberNULL{Value: true}
+        return &types.AttributeValueMemberNULL{Value: true}, nil
     default:
-        return &types.AttributeValueMemberNULL{Value: true}
+        return nil, fmt.Errorf("unsupported stream data type %d", streamAttr.DataType())
+    }
+}
+
+// toStreamAttr converts single SDK AttributeValue to Lambda AttributeValue.
+func toStreamAttr(dynamoAttr types.AttributeValue) (events.DynamoDBAttributeValue, error) {
+    switch v := dynamoAttr.(type) {
+    case *types.AttributeValueMemberS:
+        return events.NewStringAttribute(v.Value), nil
+    case *types.AttributeValueMemberN:
+        return events.NewNumberAttribute(v.Value), nil
+    case *types.AttributeValueMemberBOOL:
+        return events.NewBooleanAttribute(v.Value), nil
+    case *types.AttributeValueMemberB:
+        return events.NewBinaryAttribute(v.Value), nil
+    case *types.AttributeValueMemberSS:
+        return events.NewStringSetAttribute(v.Value), nil
+    case *types.AttributeValueMemberNS:
+        return events.NewNumberSetAttribute(v.Value), nil
+    case *types.AttributeValueMemberBS:
+        return events.NewBinarySetAttribute(v.Value), nil
+    case *types.AttributeValueMemberL:
+        list := make([]events.DynamoDBAttributeValue, len(v.Value))
+        for i, item := range v.Value {
+            attr, err := toStreamAttr(item)
+            if err != nil {
+                return events.DynamoDBAttributeValue{}, fmt.Errorf("list element %d: %w", i, err)
+            }
+            list[i] = attr
+        }
+        return events.NewListAttribute(list), nil
+    case *types.AttributeValueMemberM:
+        m := make(map[string]events.DynamoDBAttributeValue, len(v.Value))
+        for k, item := range v.Value {
+            attr, err := toStreamAttr(item)
+            if err != nil {
+                return events.DynamoDBAttributeValue{}, fmt.Errorf("map key '%s': %w", k, err)
+            }
+            m[k] = attr
+        }
+        return events.NewMapAttribute(m), nil
+    case *types.AttributeValueMemberNULL:
+        return events.NewNullAttribute(), nil
+    default:
+        return events.DynamoDBAttributeValue{}, fmt.Errorf("unsupported attribute value %T", dynamoAttr)
     }
 }
 
@@ -105,7 +190,7 @@ func IsFieldModified(dbEvent events.DynamoDBEventRecord, fieldName string) bool
 }
 
 // streamAttributeValuesEqual compares two stream AttributeValues for equality.
-// Handles all DynamoDB data types with proper set comparison for SS/NS.
+// Handles all DynamoDB data types with order-insensitive set comparison.
 func streamAttributeValuesEqual(a, b events.DynamoDBAttributeValue) bool {
     if a.DataType() != b.DataType() {
         return false
@@ -119,31 +204,39 @@ func streamAttributeValuesEqual(a, b events.DynamoDBAttributeValue) bool {
     case events.DataTypeBoolean:
         return a.Boolean() == b.Boolean()
     case events.DataTypeStringSet:
-        aSet, bSet := a.StringSet(), b.StringSet()
-        if len(aSet) != len(bSet) {
-            return false
+        return sameStreamSet(a.StringSet(), b.StringSet())
+    case events.DataTypeNumberSet:
+        return sameStreamSet(a.NumberSet(), b.NumberSet())
+    case events.DataTypeBinary:
+        return bytes.Equal(a.Binary(), b.Binary())
+    case events.DataTypeBinarySet:
+        aSet, bSet := make([]string, 0, len(a.BinarySet())), make([]string, 0, len(b.BinarySet()))
+        for _, item := range a.BinarySet() {
+            aSet = append(aSet, string(item))
         }
-        setMap := make(map[string]bool, len(aSet))
-        for _, item := range aSet {
-            setMap[item] = true
+        for _, item := range b.BinarySet() {
+            bSet = append(bSet, string(item))
         }
-        for _, item := range bSet {
-            if !setMap[item] {
+        return sameStreamSet(aSet, bSet)
+    case events.DataTypeList:
+        aList, bList := a.List(), b.List()
+        if len(aList) != len(bList) {
+            return false
+        }
+        for i := range aList {
+            if !streamAttributeValuesEqual(aList[i], bList[i]) {
                 return false
             }
         }
         return true
-    case events.DataTypeNumberSet:
-        aSet, bSet := a.NumberSet(), b.NumberSet()
-        if len(aSet) != len(bSet) {
+    case events.DataTypeMap:
+        aMap, bMap := a.Map(), b.Map()
+        if len(aMap) != len(bMap) {
             return false
         }
-        setMap := make(map[string]bool, len(aSet))
-        for _, item := range aSet {
-            setMap[item] = true
-        }
-        for _, item := range bSet {
-            if !setMap[item] {
+        for k, v := range aMap {
+            other, ok := bMap[k]
+            if !ok || !streamAttributeValuesEqual(v, other) {
                 return false
             }
         }
@@ -155,6 +248,23 @@ func streamAttributeValuesEqual(a, b events.DynamoDBAttributeValue) bool {
     }
 }
 
+// sameStreamSet compares two sets regardless of element order.
+func sameStreamSet(a, b []string) bool {
+    if len(a) != len(b) {
+        return false
+    }
+    setMap := make(map[string]bool, len(a))
+    for _, item := range a {
+        setMap[item] = true
+    }
+    for _, item := range b {
+        if !setMap[item] {
+            return false
+        }
+    }
+    return true
+}
+
 // ExtractBothFromDynamoDBStreamEvent extracts both old and new items from stream event.
 // Returns nil for missing images (e.g., oldItem is nil for INSERT events).
 // Useful for MODIFY events where you need to compare before/after states.
@@ -0,0 +1,42 @@
+package helpers
+
+// TTLHelpersTemplate provides the item expiry declared with "ttl" in the schema
+const TTLHelpersTemplate = `
+{{- with .TTLAttribute}}
+// TTLAttribute is the attribute holding the expiry of the item in Unix seconds.
+// DynamoDB deletes expired items in the background, typically within a few days.
+const TTLAttribute = Column{{.GoName}}
+
+// TTLClock returns the time expiries are computed from and checked against.
+// Replace it in tests for deterministic expiries.
+var TTLClock = time.Now
+
+// TTLValue returns the {{.Name}} value of an item expiring after d, e.g. for raw updates:
+//   updates[TTLAttribute] = TTLValue(24 * time.Hour)
+func TTLValue(d time.Duration) {{.GoType}} {
+    return {{.GoType}}(TTLClock().Add(d).Unix())
+}
+
+// WithTTL returns a copy of the item expiring after d.
+// Example:
+//   av, err := ItemInput(item.WithTTL(24 * time.Hour))
+func (item SchemaItem) WithTTL(d time.Duration) SchemaItem {
+    item.{{.GoName}} = TTLValue(d)
+    return item
+}
+
+// IsExpired reports whether the item has an expiry which passed.
+// Expired items are returned by reads until DynamoDB deletes them.
+func (item SchemaItem) IsExpired() bool {
+    return item.{{.GoName}} > 0 && int64(item.{{.GoName}}) <= TTLClock().Unix()
+}
+
+// UpdateWithTTL adds setting the expiry to d from now to an update expression.
+// Example:
+//   update := UpdateWithTTL(expression.UpdateBuilder{}, 30*24*time.Hour)
+//   input, err := UpdateItemInputWithExpression({{$.ExampleKey $.HashKey}}, {{if $.RangeKey}}{{$.ExampleKey $.RangeKey}}{{else}}nil{{end}}, update, nil)
+func UpdateWithTTL(update expression.UpdateBuilder, d time.Duration) expression.UpdateBuilder {
+    return update.Set(expression.Name(TTLAttribute), expression.Value(TTLValue(d)))
+}
+{{- end}}
+`
@@ -4,7 +4,7 @@ package helpers
 const ValidationHelpersTemplate = `
 // validateKeyPart checks if key part (hash or range) value is valid for DynamoDB.
 // Hash keys are required and cannot be nil/empty, range keys are optional.
-// Supports string, numeric types commonly used as DynamoDB keys.
+// Supports string, numeric and binary types used as DynamoDB keys.
 func validateKeyPart(partName string, value any) error {
     if value == nil {
         if partName == "hash" {
@@ -18,6 +18,10 @@ func validateKeyPart(partName string, value any) error {
         if v == "" && partName == "hash" {
             return fmt.Errorf("hash key string cannot be empty")
         }
+    case []byte:
+        if len(v) == 0 && partName == "hash" {
+            return fmt.Errorf("hash key binary cannot be empty")
+        }
     case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
     case float32, float64:
     default:
@@ -89,13 +93,22 @@ func validateSetValues(values any) error {
                 return fmt.Errorf("string set item %d cannot be empty", i)
             }
         }
+    case [][]byte:
+        if len(v) == 0 {
+            return fmt.Errorf("binary set cannot be empty")
+        }
+        for i, b := range v {
+            if len(b) == 0 {
+                return fmt.Errorf("binary set item %d cannot be empty", i)
+            }
+        }
     case []int, []int8, []int16, []int32, []int64, []uint, []uint8, []uint16, []uint32, []uint64, []float32, []float64:
         rv := reflect.ValueOf(v)
         if rv.Len() == 0 {
             return fmt.Errorf("number set cannot be empty")
         }
     default:
-        return fmt.Errorf("unsupported set type: %T, expected []string or numeric slice", values)
+        return fmt.Errorf("unsupported set type: %T, expected []string, [][]byte or numeric slice", values)
     }
     return nil
 }
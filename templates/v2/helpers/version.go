@@ -0,0 +1,80 @@
+package helpers
+
+// VersionHelpersTemplate provides the generator version and init-time DynamoDB module guard
+const VersionHelpersTemplate = `
+const (
+    // GeneratorVersion is the go-dyno version this code was generated with.
+    // Pass it to github.com/Mad-Pixels/go-dyno/registry to detect packages generated by different versions.
+    GeneratorVersion = "{{.GeneratorVersion}}"
+
+    // MinDynamoDBVersion is the oldest github.com/aws/aws-sdk-go-v2/service/dynamodb version this code supports.
+    MinDynamoDBVersion = "{{.MinDynamoDBVersion}}"
+)
+
+// dynamoDBModule is the module MinDynamoDBVersion applies to.
+const dynamoDBModule = "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+// init fails loudly if the binary links a DynamoDB module older than MinDynamoDBVersion.
+func init() {
+    if err := checkGeneratorCompatibility(); err != nil {
+        panic(err)
+    }
+}
+
+// checkGeneratorCompatibility verifies the DynamoDB module version linked into the binary.
+// Binaries without build info or with a replaced local module are not checked.
+func checkGeneratorCompatibility() error {
+    linked := linkedModuleVersion(dynamoDBModule)
+    if linked != "" && compareVersions(linked, MinDynamoDBVersion) < 0 {
+        return fmt.Errorf("godyno: package for table %s requires %s >= %s, linked %s", TableName, dynamoDBModule, MinDynamoDBVersion, linked)
+    }
+    return nil
+}
+
+// linkedModuleVersion returns the version of the module path linked into the binary,
+// empty if it is unknown.
+func linkedModuleVersion(path string) string {
+    info, ok := debug.ReadBuildInfo()
+    if !ok {
+        return ""
+    }
+    for _, dep := range info.Deps {
+        if dep.Path != path {
+            continue
+        }
+        if dep.Replace != nil {
+            dep = dep.Replace
+        }
+        if strings.HasPrefix(dep.Version, "v") {
+            return dep.Version
+        }
+        return ""
+    }
+    return ""
+}
+
+// compareVersions compares dotted numeric versions ("1.36.3"), ignoring a "v" prefix and pre-release suffix.
+// Returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+    var (
+        pa = strings.Split(strings.TrimPrefix(a, "v"), ".")
+        pb = strings.Split(strings.TrimPrefix(b, "v"), ".")
+    )
+    for i := 0; i < len(pa) || i < len(pb); i++ {
+        var na, nb int
+        if i < len(pa) {
+            na, _ = strconv.Atoi(strings.SplitN(pa[i], "-", 2)[0])
+        }
+        if i < len(pb) {
+            nb, _ = strconv.Atoi(strings.SplitN(pb[i], "-", 2)[0])
+        }
+        switch {
+        case na < nb:
+            return -1
+        case na > nb:
+            return 1
+        }
+    }
+    return 0
+}
+`
@@ -0,0 +1,168 @@
+package inputs
+
+// ConditionInputsTemplate provides typed condition helpers and conditional write inputs
+const ConditionInputsTemplate = `
+// TYPED CONDITIONS
+// Condition<Attribute><Operator> helpers build conditions of conditional writes.
+// Number and binary sets only support Exists and NotExists (contains takes strings).
+// Combine them with And, Or and expression.Not and pass them to PutItemInputIf,
+// UpdateItemInputIf, DeleteItemInputIf or TxConditionCheck.
+{{- range .AllAttributes}}
+{{- $name := .GoName}}
+{{- if eq .Type "SS"}}
+
+// Condition{{$name}}Contains checks that the {{.Name}} set contains the value.
+func Condition{{$name}}Contains(value string) expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).Contains(value)
+}
+{{- else if or (eq .Type "S") (eq .Type "N") (eq .Type "B") (eq .Type "BOOL")}}
+
+// Condition{{$name}}EQ checks that {{.Name}} equals the value.
+func Condition{{$name}}EQ(value {{.GoType}}) expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).Equal(expression.Value(value))
+}
+
+// Condition{{$name}}NE checks that {{.Name}} does not equal the value.
+func Condition{{$name}}NE(value {{.GoType}}) expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).NotEqual(expression.Value(value))
+}
+{{- if ne .Type "BOOL"}}
+
+// Condition{{$name}}GT checks that {{.Name}} is greater than the value.
+func Condition{{$name}}GT(value {{.GoType}}) expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).GreaterThan(expression.Value(value))
+}
+
+// Condition{{$name}}GTE checks that {{.Name}} is greater than or equal to the value.
+func Condition{{$name}}GTE(value {{.GoType}}) expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).GreaterThanEqual(expression.Value(value))
+}
+
+// Condition{{$name}}LT checks that {{.Name}} is less than the value.
+func Condition{{$name}}LT(value {{.GoType}}) expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).LessThan(expression.Value(value))
+}
+
+// Condition{{$name}}LTE checks that {{.Name}} is less than or equal to the value.
+func Condition{{$name}}LTE(value {{.GoType}}) expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).LessThanEqual(expression.Value(value))
+}
+
+// Condition{{$name}}Between checks that {{.Name}} is between start and end, inclusive.
+func Condition{{$name}}Between(start, end {{.GoType}}) expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).Between(expression.Value(start), expression.Value(end))
+}
+{{- end}}
+{{- if eq .Type "S"}}
+
+// Condition{{$name}}BeginsWith checks that {{.Name}} starts with the prefix.
+func Condition{{$name}}BeginsWith(prefix string) expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).BeginsWith(prefix)
+}
+
+// Condition{{$name}}Contains checks that {{.Name}} contains the substring.
+func Condition{{$name}}Contains(substr string) expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).Contains(substr)
+}
+{{- end}}
+{{- end}}
+
+// Condition{{$name}}Exists checks that the item has {{.Name}}.
+func Condition{{$name}}Exists() expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).AttributeExists()
+}
+
+// Condition{{$name}}NotExists checks that the item has no {{.Name}}.
+{{- if eq .Name $.HashKey}}
+// Used with PutItemInputIf it only creates new items.
+{{- end}}
+func Condition{{$name}}NotExists() expression.ConditionBuilder {
+    return expression.Name(Column{{$name}}).AttributeNotExists()
+}
+{{- end}}
+
+// PutItemInputIf creates a PutItemInput writing the item only if the condition holds.
+{{- with .Attribute .HashKey}}
+// Example:
+//   input, err := PutItemInputIf(item, Condition{{.GoName}}NotExists())
+//   _, err = client.PutItem(ctx, input)
+{{- end}}
+func PutItemInputIf(item SchemaItem, condition expression.ConditionBuilder) (*dynamodb.PutItemInput, error) {
+    expr, err := buildCondition(condition)
+    if err != nil {
+        return nil, err
+    }
+    av, err := ItemInput(item)
+    if err != nil {
+        return nil, fmt.Errorf("failed to marshal item for conditional put: %v", err)
+    }
+    return &dynamodb.PutItemInput{
+        TableName:                 aws.String(TableSchema.TableName),
+        Item:                      av,
+        ConditionExpression:       expr.Condition(),
+        ExpressionAttributeNames:  expr.Names(),
+        ExpressionAttributeValues: expr.Values(),
+    }, nil
+}
+
+// UpdateItemInputIf creates an UpdateItemInput like UpdateItemInputFromRaw
+// that updates the item only if the condition holds.
+{{- with .Attribute .HashKey}}
+// Example:
+//   input, err := UpdateItemInputIf({{$.ExampleKey $.HashKey}}, {{if $.RangeKey}}{{$.ExampleKey $.RangeKey}}{{else}}nil{{end}}, updates, Condition{{.GoName}}Exists())
+{{- end}}
+func UpdateItemInputIf(hashKeyValue any, rangeKeyValue any, updates map[string]any, condition expression.ConditionBuilder) (*dynamodb.UpdateItemInput, error) {
+    expr, err := buildCondition(condition)
+    if err != nil {
+        return nil, err
+    }
+    input, err := UpdateItemInputFromRaw(hashKeyValue, rangeKeyValue, updates)
+    if err != nil {
+        return nil, err
+    }
+    conditionExpression := aws.ToString(expr.Condition())
+    if input.ConditionExpression != nil {
+        conditionExpression = aws.ToString(input.ConditionExpression) + " AND (" + conditionExpression + ")"
+    }
+    input.ConditionExpression = aws.String(conditionExpression)
+    input.ExpressionAttributeNames, input.ExpressionAttributeValues = mergeExpressionAttributes(
+        input.ExpressionAttributeNames,
+        input.ExpressionAttributeValues,
+        expr.Names(),
+        expr.Values(),
+    )
+    return input, nil
+}
+
+// DeleteItemInputIf creates a DeleteItemInput deleting the item only if the condition holds.
+{{- with .Attribute .HashKey}}
+// Example:
+//   input, err := DeleteItemInputIf({{$.ExampleKey $.HashKey}}, {{if $.RangeKey}}{{$.ExampleKey $.RangeKey}}{{else}}nil{{end}}, Condition{{.GoName}}Exists())
+{{- end}}
+func DeleteItemInputIf(hashKeyValue any, rangeKeyValue any, condition expression.ConditionBuilder) (*dynamodb.DeleteItemInput, error) {
+    expr, err := buildCondition(condition)
+    if err != nil {
+        return nil, err
+    }
+    input, err := DeleteItemInputFromRaw(hashKeyValue, rangeKeyValue)
+    if err != nil {
+        return nil, err
+    }
+    input.ConditionExpression = expr.Condition()
+    input.ExpressionAttributeNames = expr.Names()
+    input.ExpressionAttributeValues = expr.Values()
+    return input, nil
+}
+
+// buildCondition builds the condition expression with its attribute names and values.
+func buildCondition(condition expression.ConditionBuilder) (expression.Expression, error) {
+    expr, err := expression.NewBuilder().WithCondition(condition).Build()
+    if err != nil {
+        return expression.Expression{}, fmt.Errorf("failed to build condition expression: %v", err)
+    }
+    if err := validateConditionExpression(aws.ToString(expr.Condition())); err != nil {
+        return expression.Expression{}, err
+    }
+    return expr, nil
+}
+`
@@ -5,11 +5,89 @@ const ItemInputsTemplate = `
 // ItemInput converts a SchemaItem to DynamoDB AttributeValue map format.
 // Uses AWS SDK's attributevalue package for safe and consistent marshaling.
 // The resulting map can be used in PutItem, UpdateItem, and other DynamoDB operations.
+// BeforePut hooks run first and may modify the item or reject it{{if .ComputedAttributes}}, then computed attributes are recalculated{{end}}.
+{{- if .AuditAttributes}}
+// Audit timestamps are set last, see setAuditTimestamps.
+{{- end}}
+{{- if .ChecksumAttribute}}
+// The checksum is computed from the final item, see ItemChecksum.
+{{- end}}
+{{- if .EnumAttributes}}
+// Enum attributes are validated, see Validate of their types.
+{{- end}}
+{{- if .RuleAttributes}}
+// Items violating the validation rules of the schema are rejected, see ValidateOnPut.
+{{- end}}
+{{- if .HasCompositeKeys}}
+// Composite index keys are written from their parts, items missing a part are left out of the index.
+{{- end}}
+{{- if .DefaultAttributes}}
+// Unset attributes with a default are filled in, see ApplyDefaults.
+{{- end}}
+{{- if .NormalizedAttributes}}
+// Normalized key attributes are normalized, see NormalizeKey.
+{{- end}}
+// Empty values DynamoDB rejects are handled by EmptyValues.
+// Example:
+//   av, err := ItemInput(item)
+//   _, err = client.PutItem(ctx, &dynamodb.PutItemInput{TableName: aws.String(TableName), Item: av})
 func ItemInput(item SchemaItem) (map[string]types.AttributeValue, error) {
-    attributeValues, err := attributevalue.MarshalMap(item)
+    _, av, err := prepareItem(item)
+    return av, err
+}
+
+// prepareItem runs the steps of writing an item as a whole, see ItemInput, and returns
+// the item as written with its marshaled attributes. ItemInput, PutItem and TxPut share it.
+func prepareItem(item SchemaItem) (SchemaItem, map[string]types.AttributeValue, error) {
+    if err := runBeforePut(&item); err != nil {
+        return SchemaItem{}, nil, err
+    }
+    {{- if .NormalizedAttributes}}
+    normalizeKeys(&item)
+    {{- end}}
+    {{- if .DefaultAttributes}}
+    if ApplyDefaults {
+        applyDefaults(&item)
+    }
+    {{- end}}
+    {{- if .ComputedAttributes}}
+    computeAttributes(&item)
+    {{- end}}
+    {{- if .EnumAttributes}}
+    if err := validateEnums(item); err != nil {
+        return SchemaItem{}, nil, err
+    }
+    {{- end}}
+    {{- if .RuleAttributes}}
+    if ValidateOnPut {
+        if err := item.Validate(); err != nil {
+            return SchemaItem{}, nil, err
+        }
+    }
+    {{- end}}
+    {{- if .AuditAttributes}}
+    setAuditTimestamps(&item, AuditClock())
+    {{- end}}
+    {{- if .FieldVersionsAttribute}}
+    initFieldVersions(&item)
+    {{- end}}
+    {{- if .ChecksumAttribute}}
+    if err := setChecksum(&item); err != nil {
+        return SchemaItem{}, nil, err
+    }
+    {{- end}}
+    av, err := ToAttributeValues(item)
     if err != nil {
-        return nil, fmt.Errorf("failed to marshal item: %v", err)
+        return SchemaItem{}, nil, err
+    }
+    {{- if .HasCompositeKeys}}
+    if _, err := setCompositeKeys(av); err != nil {
+        return SchemaItem{}, nil, err
+    }
+    {{- end}}
+    if _, err := applyEmptyValues(av); err != nil {
+        return SchemaItem{}, nil, err
     }
-    return attributeValues, nil
+    return item, av, nil
 }
 `
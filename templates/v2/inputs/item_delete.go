@@ -19,6 +19,8 @@ func DeleteItemInput(item SchemaItem) (*dynamodb.DeleteItemInput, error) {
 // DeleteItemInputFromRaw creates a DeleteItemInput from raw key values.
 // Use when you only have the key values and want to delete the item.
 // More efficient than DeleteItemInput when you don't have the full item.
+// Example:
+//   input, err := DeleteItemInputFromRaw({{.ExampleKey .HashKey}}, {{if .RangeKey}}{{.ExampleKey .RangeKey}}{{else}}nil{{end}})
 func DeleteItemInputFromRaw(hashKeyValue any, rangeKeyValue any) (*dynamodb.DeleteItemInput, error) {
     if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
         return nil, err
@@ -0,0 +1,184 @@
+package inputs
+
+// GetInputsTemplate provides typed reads of single items by primary key
+const GetInputsTemplate = `
+// ErrItemNotFound is returned by GetItem and GetItemFromRaw when the table has no item with the key.
+// Check it with errors.Is.
+var ErrItemNotFound = errors.New("item not found")
+
+// GetItemOption customizes the reads of GetItem and GetItemFromRaw.
+type GetItemOption func(o *getItemOptions)
+
+// getItemOptions are the GetItemInput and the read fallback of a GetItem call.
+type getItemOptions struct {
+    input     *dynamodb.GetItemInput
+    fallback  ReadFallback
+    freshness FreshnessCheck
+    {{- if .ChecksumAttribute}}
+    verify    bool
+    {{- end}}
+}
+
+// WithConsistentRead makes GetItem and GetItemFromRaw use a strongly consistent read.
+// Example:
+//   item, err := GetItem(ctx, client, key, WithConsistentRead())
+func WithConsistentRead() GetItemOption {
+    return func(o *getItemOptions) {
+        o.input.ConsistentRead = aws.Bool(true)
+    }
+}
+
+// WithStrongThenFallback reads strongly consistent and falls back to an eventually consistent
+// read if the consistent read fails, see StrongThenEventual.
+// Example:
+//   item, err := GetItem(ctx, client, key, WithStrongThenFallback())
+func WithStrongThenFallback() GetItemOption {
+    return func(o *getItemOptions) {
+        o.fallback = StrongThenEventual
+    }
+}
+
+// WithStalenessCheck reads eventually consistent and re-reads strongly consistent
+// if the item is missing or fresh rejects it, see EventualThenStrong.
+{{- with .VersionAttribute}}
+// Example:
+//   item, err := GetItem(ctx, client, key, WithStalenessCheck(FreshFromVersion(lastWritten)))
+{{- end}}
+func WithStalenessCheck(fresh FreshnessCheck) GetItemOption {
+    return func(o *getItemOptions) {
+        o.fallback = EventualThenStrong
+        o.freshness = fresh
+    }
+}
+
+{{- if .ChecksumAttribute}}
+
+// WithChecksumVerification verifies the checksum of the read item, see VerifyChecksum.
+// Example:
+//   item, err := GetItem(ctx, client, key, WithChecksumVerification())
+//   if errors.Is(err, ErrChecksumMismatch) {
+//       // the item was modified out of band
+//   }
+func WithChecksumVerification() GetItemOption {
+    return func(o *getItemOptions) {
+        o.verify = true
+    }
+}
+{{- end}}
+
+// GetItemInput creates a GetItemInput from a SchemaItem holding the primary key.
+// Only the key attributes of the item are used.
+func GetItemInput(item SchemaItem) (*dynamodb.GetItemInput, error) {
+    key, err := KeyInput(item)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create key from item for get: %v", err)
+    }
+    return &dynamodb.GetItemInput{
+        TableName: aws.String(TableSchema.TableName),
+        Key:       key,
+    }, nil
+}
+
+// GetItemInputFromRaw creates a GetItemInput from raw key values.
+// Example:
+//   input, err := GetItemInputFromRaw({{.ExampleKey .HashKey}}, {{if .RangeKey}}{{.ExampleKey .RangeKey}}{{else}}nil{{end}})
+func GetItemInputFromRaw(hashKeyValue any, rangeKeyValue any) (*dynamodb.GetItemInput, error) {
+    if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+        return nil, err
+    }
+    key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+    if err != nil {
+        return nil, fmt.Errorf("failed to create key for get: %v", err)
+    }
+    return &dynamodb.GetItemInput{
+        TableName: aws.String(TableSchema.TableName),
+        Key:       key,
+    }, nil
+}
+
+// GetItem reads the item with the primary key of key, other attributes of key are ignored.
+// Reads are eventually consistent unless WithConsistentRead, WithStrongThenFallback
+// or WithStalenessCheck is passed.
+// Returns ErrItemNotFound if the table has no such item.
+func GetItem(ctx context.Context, client DynamoDBAPI, key SchemaItem, opts ...GetItemOption) (*SchemaItem, error) {
+    input, err := GetItemInput(key)
+    if err != nil {
+        return nil, err
+    }
+    return getItem(ctx, client, input, opts)
+}
+
+// GetItemFromRaw reads the item with the raw key values.
+// Returns ErrItemNotFound if the table has no such item.
+// Example:
+//   item, err := GetItemFromRaw(ctx, client, {{.ExampleKey .HashKey}}, {{if .RangeKey}}{{.ExampleKey .RangeKey}}{{else}}nil{{end}})
+//   if errors.Is(err, ErrItemNotFound) {
+//       // create it
+//   }
+func GetItemFromRaw(ctx context.Context, client DynamoDBAPI, hashKeyValue any, rangeKeyValue any, opts ...GetItemOption) (*SchemaItem, error) {
+    input, err := GetItemInputFromRaw(hashKeyValue, rangeKeyValue)
+    if err != nil {
+        return nil, err
+    }
+    return getItem(ctx, client, input, opts)
+}
+
+// getItem applies the options and reads the item{{if .ChecksumAttribute}}, verifying its checksum if requested{{end}}.
+func getItem(ctx context.Context, client DynamoDBAPI, input *dynamodb.GetItemInput, opts []GetItemOption) (*SchemaItem, error) {
+    o := getItemOptions{input: input}
+    for _, opt := range opts {
+        opt(&o)
+    }
+    {{- if .ChecksumAttribute}}
+    item, err := readWithFallback(ctx, client, input, o)
+    if err != nil || !o.verify {
+        return item, err
+    }
+    if err := VerifyChecksum(*item); err != nil {
+        return nil, err
+    }
+    return item, nil
+    {{- else}}
+    return readWithFallback(ctx, client, input, o)
+    {{- end}}
+}
+
+// readWithFallback reads the item, retrying with the other consistency
+// as the read fallback of the options requires.
+func readWithFallback(ctx context.Context, client DynamoDBAPI, input *dynamodb.GetItemInput, o getItemOptions) (*SchemaItem, error) {
+    consistent, eventual := *input, *input
+    consistent.ConsistentRead = aws.Bool(true)
+    eventual.ConsistentRead = aws.Bool(false)
+
+    switch o.fallback {
+    case StrongThenEventual:
+        item, err := readItem(ctx, client, &consistent)
+        if err == nil || errors.Is(err, ErrItemNotFound) || ctx.Err() != nil {
+            return item, err
+        }
+        return readItem(ctx, client, &eventual)
+    case EventualThenStrong:
+        item, err := readItem(ctx, client, &eventual)
+        if err != nil && !errors.Is(err, ErrItemNotFound) {
+            return nil, err
+        }
+        if err == nil && (o.freshness == nil || o.freshness(*item)) {
+            return item, nil
+        }
+        return readItem(ctx, client, &consistent)
+    }
+    return readItem(ctx, client, input)
+}
+
+// readItem executes the GetItem request and unmarshals the item.
+func readItem(ctx context.Context, client DynamoDBAPI, input *dynamodb.GetItemInput) (*SchemaItem, error) {
+    out, err := client.GetItem(ctx, input)
+    if err != nil {
+        return nil, fmt.Errorf("failed to get item: %w", err)
+    }
+    if len(out.Item) == 0 {
+        return nil, ErrItemNotFound
+    }
+    return UnmarshalItem(out.Item)
+}
+`
@@ -4,8 +4,28 @@ package inputs
 const UpdateInputsTemplate = `
 // UpdateItemInput creates an UpdateItemInput from a complete SchemaItem.
 // Automatically extracts the key and updates all non-key attributes.
+{{- if .HasCompositeKeys}}
+// Composite index keys are rewritten from their parts, removed if a part is missing.
+{{- end}}
 // Use when you want to update an entire item with new values.
 func UpdateItemInput(item SchemaItem) (*dynamodb.UpdateItemInput, error) {
+    {{- if .NormalizedAttributes}}
+    normalizeKeys(&item)
+    {{- end}}
+    {{- if .ComputedAttributes}}
+    computeAttributes(&item)
+    {{- end}}
+    {{- if .AuditAttributes}}
+    setAuditTimestamps(&item, AuditClock())
+    {{- end}}
+    {{- if .FieldVersionsAttribute}}
+    stampFieldVersions(&item, FieldVersionClock())
+    {{- end}}
+    {{- if .ChecksumAttribute}}
+    if err := setChecksum(&item); err != nil {
+        return nil, err
+    }
+    {{- end}}
     key, err := KeyInput(item)
     if err != nil {
         return nil, fmt.Errorf("failed to create key from item for update: %v", err)
@@ -14,31 +34,104 @@ func UpdateItemInput(item SchemaItem) (*dynamodb.UpdateItemInput, error) {
     if err != nil {
         return nil, fmt.Errorf("failed to marshal item for update: %v", err)
     }
+    {{- if .HasCompositeKeys}}
+    missing, err := setCompositeKeys(allAttributes)
+    if err != nil {
+        return nil, err
+    }
+    {{- end}}
+    omitted, err := applyEmptyValues(allAttributes)
+    if err != nil {
+        return nil, err
+    }
+    {{- if .HasCompositeKeys}}
+    omitted = append(omitted, missing...)
+    {{- end}}
     updates := extractNonKeyAttributes(allAttributes)
-    if len(updates) == 0 {
+    if len(updates) == 0 && len(omitted) == 0 {
         return nil, fmt.Errorf("no non-key attributes to update")
     }
     updateExpression, attrNames, attrValues := buildUpdateExpression(updates)
    
-    return &dynamodb.UpdateItemInput{
+    input := &dynamodb.UpdateItemInput{
         TableName:                 aws.String(TableSchema.TableName),
         Key:                       key,
         UpdateExpression:          aws.String(updateExpression),
         ExpressionAttributeNames:  attrNames,
         ExpressionAttributeValues: attrValues,
-    }, nil
+    }
+    removeAttributes(input, omitted)
+    {{- if .GuardedAttributes}}
+    applyGuards(input)
+    {{- end}}
+    return input, nil
 }
 
 // UpdateItemInputFromRaw creates an UpdateItemInput from raw key values and update map.
 // More efficient for partial updates when you only want to modify specific attributes.
 // Use when you know exactly which fields to update without loading the full item.
+// BeforeUpdate hooks run first and may modify updates or reject them.
+// Empty sets are handled by EmptyValues, by default their attributes are removed.
+{{- if .ComputedAttributes}}
+// Computed attributes are added to updates when all their inputs change.
+{{- end}}
+{{- if .GuardedAttributes}}
+// Guarded attributes add their conditions, see CheckGuards.
+{{- end}}
+{{- if .AuditAttributes}}
+// Audit timestamps missing in updates are added, see addAuditTimestamps.
+{{- end}}
+{{- if .ChecksumAttribute}}
+// The item checksum is removed, a partial update can't hash the whole item.
+{{- end}}
+{{- if .FieldVersionsAttribute}}
+// The updated attributes are recorded in the field versions, see FieldVersionsAttribute.
+{{- end}}
+{{- if .NormalizedAttributes}}
+// Keys and updated key attributes are normalized, see NormalizeKey.
+{{- end}}
+{{- if .HasCompositeKeys}}
+// Composite index keys are rewritten when all their parts are updated, partial updates of their parts are rejected.
+{{- end}}
+{{- with .ExampleAttribute}}
+// Example:
+//   input, err := UpdateItemInputFromRaw({{$.ExampleKey $.HashKey}}, {{if $.RangeKey}}{{$.ExampleKey $.RangeKey}}{{else}}nil{{end}}, map[string]any{
+//       Column{{.GoName}}: {{.ExampleValue}},
+//   })
+{{- end}}
 func UpdateItemInputFromRaw(hashKeyValue any, rangeKeyValue any, updates map[string]any) (*dynamodb.UpdateItemInput, error) {
     if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
         return nil, err
     }
+    if err := runBeforeUpdate(hashKeyValue, rangeKeyValue, updates); err != nil {
+        return nil, err
+    }
+    {{- if .ComputedAttributes}}
+    if err := computeUpdates(updates); err != nil {
+        return nil, err
+    }
+    {{- end}}
     if err := validateUpdatesMap(updates); err != nil {
         return nil, err
     }
+    {{- if .EnumAttributes}}
+    if err := validateEnumUpdates(updates); err != nil {
+        return nil, err
+    }
+    {{- end}}
+    {{- if .NormalizedAttributes}}
+    normalizeUpdates(updates)
+    {{- end}}
+    {{- if .ChecksumAttribute}}
+    if err := validateChecksumUpdate(updates); err != nil {
+        return nil, err
+    }
+    {{- end}}
+    {{- if .FieldVersionsAttribute}}
+    if err := validateFieldVersionsUpdate(updates); err != nil {
+        return nil, err
+    }
+    {{- end}}
     key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
     if err != nil {
         return nil, fmt.Errorf("failed to create key for update: %v", err)
@@ -47,19 +140,49 @@ func UpdateItemInputFromRaw(hashKeyValue any, rangeKeyValue any, updates map[str
     if err != nil {
         return nil, fmt.Errorf("failed to marshal updates: %v", err)
     }
+    {{- if .HasCompositeKeys}}
+    removed, err := updateCompositeKeys(key, marshaledUpdates)
+    if err != nil {
+        return nil, err
+    }
+    {{- end}}
+    omitted, err := applyEmptyValues(marshaledUpdates)
+    if err != nil {
+        return nil, err
+    }
+    {{- if .HasCompositeKeys}}
+    omitted = append(omitted, removed...)
+    {{- end}}
+    {{- if .AuditAttributes}}
+    if err := addAuditTimestamps(marshaledUpdates, AuditClock()); err != nil {
+        return nil, err
+    }
+    {{- end}}
     updateExpression, attrNames, attrValues := buildUpdateExpression(marshaledUpdates)
    
-    return &dynamodb.UpdateItemInput{
+    input := &dynamodb.UpdateItemInput{
         TableName:                 aws.String(TableSchema.TableName),
         Key:                       key,
         UpdateExpression:          aws.String(updateExpression),
         ExpressionAttributeNames:  attrNames,
         ExpressionAttributeValues: attrValues,
-    }, nil
+    }
+    removeAttributes(input, omitted)
+    {{- if .FieldVersionsAttribute}}
+    recordFieldVersions(input, append(slices.Sorted(maps.Keys(marshaledUpdates)), omitted...), FieldVersionClock())
+    {{- end}}
+    {{- if .GuardedAttributes}}
+    applyGuards(input)
+    {{- end}}
+    {{- if .ChecksumAttribute}}
+    removeChecksum(input)
+    {{- end}}
+    return input, nil
 }
 
 // UpdateItemInputWithCondition creates a conditional UpdateItemInput.
 // Updates the item only if the condition expression evaluates to true.
+// Condition values are marshaled attribute values, see UpdateItemInputWithTypedCondition for Go values.
 func UpdateItemInputWithCondition(
     hashKeyValue any, 
     rangeKeyValue any, 
@@ -81,6 +204,9 @@ func UpdateItemInputWithCondition(
     if err != nil {
         return nil, err
     }
+    if updateInput.ConditionExpression != nil {
+        conditionExpression = aws.ToString(updateInput.ConditionExpression) + " AND (" + conditionExpression + ")"
+    }
     updateInput.ConditionExpression = aws.String(conditionExpression)
    
     updateInput.ExpressionAttributeNames, updateInput.ExpressionAttributeValues = mergeExpressionAttributes(
@@ -92,9 +218,43 @@ func UpdateItemInputWithCondition(
     return updateInput, nil
 }
 
+// ConditionValue is a Go value of a condition expression placeholder and the attribute it is compared with.
+// Value is marshaled like updates of Attribute (e.g. []string as a string set, a single string compared
+// with a set by contains as a string); an empty Attribute marshals it generically and a types.AttributeValue is kept.
+type ConditionValue struct {
+    Attribute string
+    Value     any
+}
+
+// UpdateItemInputWithTypedCondition is UpdateItemInputWithCondition with Go condition values,
+// marshaled by the schema type of the attribute each one names.
+// Example:
+//   input, err := UpdateItemInputWithTypedCondition(
+//       {{.ExampleKey .HashKey}}, {{if .RangeKey}}{{.ExampleKey .RangeKey}}{{else}}nil{{end}}, updates,
+//       "#status = :expected", map[string]string{"#status": "status"},
+//       map[string]ConditionValue{":expected": {Attribute: "status", Value: "active"}},
+//   )
+func UpdateItemInputWithTypedCondition(
+    hashKeyValue any,
+    rangeKeyValue any,
+    updates map[string]any,
+    conditionExpression string,
+    conditionAttributeNames map[string]string,
+    conditionAttributeValues map[string]ConditionValue,
+) (*dynamodb.UpdateItemInput, error) {
+    values, err := marshalConditionValues(conditionAttributeValues)
+    if err != nil {
+        return nil, err
+    }
+    return UpdateItemInputWithCondition(hashKeyValue, rangeKeyValue, updates, conditionExpression, conditionAttributeNames, values)
+}
+
 // UpdateItemInputWithExpression creates an UpdateItemInput using DynamoDB expression builders.
 // Provides maximum flexibility for complex update operations (SET, ADD, REMOVE, DELETE).
 // Use for advanced scenarios like atomic increments, list operations, or complex conditions.
+{{- if .FieldVersionsAttribute}}
+// Field versions aren't recorded, set them with TouchFields and a put if needed.
+{{- end}}
 // Example: 
 //   updateExpr := expression.Set(expression.Name("counter"), expression.Name("counter").Plus(expression.Value(1)))
 //   condExpr := expression.Name("version").Equal(expression.Value(currentVersion))
@@ -131,6 +291,9 @@ func UpdateItemInputWithExpression(hashKeyValue any, rangeKeyValue any, updateBu
     if conditionBuilder != nil {
         input.ConditionExpression = expr.Condition()
     }
+    {{- if .ChecksumAttribute}}
+    removeChecksum(input)
+    {{- end}}
     return input, nil
 }
 `
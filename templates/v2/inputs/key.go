@@ -7,14 +7,17 @@ const KeyInputsTemplate = `
 // Use when you have a complete item and need to create a key for operations.
 // Handles both simple (hash only) and composite (hash + range) keys automatically.
 func KeyInput(item SchemaItem) (map[string]types.AttributeValue, error) {
+    {{- if .NormalizedAttributes}}
+    normalizeKeys(&item)
+    {{- end}}
     var hashKeyValue any
     {{range .AllAttributes}}{{if eq .Name $.HashKey}}
-    hashKeyValue = item.{{ToSafeName .Name | ToUpperCamelCase}}
+    hashKeyValue = item.{{.GoName}}
     {{end}}{{end}}
     
     var rangeKeyValue any
     {{if .RangeKey}}{{range .AllAttributes}}{{if eq .Name $.RangeKey}}
-    rangeKeyValue = item.{{ToSafeName .Name | ToUpperCamelCase}}
+    rangeKeyValue = item.{{.GoName}}
     {{end}}{{end}}{{end}}
     
     if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
@@ -43,8 +46,18 @@ func KeyInput(item SchemaItem) (map[string]types.AttributeValue, error) {
 // KeyInputFromRaw creates a DynamoDB key map from raw key values without validation.
 // More efficient than KeyInput when you already have validated key values.
 // Assumes validation has been done by the caller - use with caution.
+{{- if .NormalizedAttributes}}
+// Normalized key values are normalized, see NormalizeKey.
+{{- end}}
 // Handles both simple (hash only) and composite (hash + range) keys automatically.
+// Example:
+//   key, err := KeyInputFromRaw({{.ExampleKey .HashKey}}, {{if .RangeKey}}{{.ExampleKey .RangeKey}}{{else}}nil{{end}})
+//   out, err := client.GetItem(ctx, &dynamodb.GetItemInput{TableName: aws.String(TableName), Key: key})
 func KeyInputFromRaw(hashKeyValue any, rangeKeyValue any) (map[string]types.AttributeValue, error) {
+    {{- if .NormalizedAttributes}}
+    hashKeyValue = NormalizeKey(TableSchema.HashKey, hashKeyValue)
+    rangeKeyValue = NormalizeKey(TableSchema.RangeKey, rangeKeyValue)
+    {{- end}}
     key := make(map[string]types.AttributeValue)
    
     hashKeyAV, err := attributevalue.Marshal(hashKeyValue)
@@ -64,4 +77,23 @@ func KeyInputFromRaw(hashKeyValue any, rangeKeyValue any) (map[string]types.Attr
     {{end}}
     return key, nil
 }
+
+// ItemKey identifies an item by the raw values of its primary key.
+// RangeKey is nil for tables without a range key.
+type ItemKey struct {
+    HashKey  any
+    RangeKey any
+}
+
+// ItemKeyOf returns the primary key of the item.
+func ItemKeyOf(item SchemaItem) ItemKey {
+    key := ItemKey{}
+    {{- range .AllAttributes}}{{if eq .Name $.HashKey}}
+    key.HashKey = item.{{.GoName}}
+    {{- end}}{{end}}
+    {{- if .RangeKey}}{{range .AllAttributes}}{{if eq .Name $.RangeKey}}
+    key.RangeKey = item.{{.GoName}}
+    {{- end}}{{end}}{{end}}
+    return key
+}
 `
@@ -0,0 +1,280 @@
+package inputs
+
+// TransactInputsTemplate provides typed TransactWriteItem entries for single and cross-table transactions
+const TransactInputsTemplate = `
+// TxPut creates a transaction entry writing the item.
+// Entries of any generated package can be combined into one transaction with TransactionBuilder.Add
+// or the github.com/Mad-Pixels/go-dyno/txn coordinator:
+//   err := txn.New().
+//       Add(TxPut(item)).
+//       Add(otherpkg.TxDelete(otherKey, nil)).
+//       Execute(ctx, client)
+func TxPut(item SchemaItem) (types.TransactWriteItem, error) {
+    _, av, err := prepareItem(item)
+    if err != nil {
+        return types.TransactWriteItem{}, err
+    }
+    return types.TransactWriteItem{
+        Put: &types.Put{
+            TableName: aws.String(TableSchema.TableName),
+            Item:      av,
+        },
+    }, nil
+}
+
+// TxUpdate creates a transaction entry setting the given attributes of an item.
+{{- with .ExampleAttribute}}
+// Example:
+//   entry, err := TxUpdate({{$.ExampleKey $.HashKey}}, {{if $.RangeKey}}{{$.ExampleKey $.RangeKey}}{{else}}nil{{end}}, map[string]any{
+//       Column{{.GoName}}: {{.ExampleValue}},
+//   })
+{{- end}}
+func TxUpdate(hashKeyValue any, rangeKeyValue any, updates map[string]any) (types.TransactWriteItem, error) {
+    input, err := UpdateItemInputFromRaw(hashKeyValue, rangeKeyValue, updates)
+    if err != nil {
+        return types.TransactWriteItem{}, err
+    }
+    return types.TransactWriteItem{
+        Update: &types.Update{
+            TableName:                           input.TableName,
+            Key:                                 input.Key,
+            UpdateExpression:                    input.UpdateExpression,
+            ExpressionAttributeNames:            input.ExpressionAttributeNames,
+            ExpressionAttributeValues:           input.ExpressionAttributeValues,
+            ConditionExpression:                 input.ConditionExpression,
+            ReturnValuesOnConditionCheckFailure: input.ReturnValuesOnConditionCheckFailure,
+        },
+    }, nil
+}
+
+// TxDelete creates a transaction entry deleting an item by key.
+func TxDelete(hashKeyValue any, rangeKeyValue any) (types.TransactWriteItem, error) {
+    if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+        return types.TransactWriteItem{}, err
+    }
+    key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+    if err != nil {
+        return types.TransactWriteItem{}, fmt.Errorf("failed to create key for delete: %v", err)
+    }
+    return types.TransactWriteItem{
+        Delete: &types.Delete{
+            TableName: aws.String(TableSchema.TableName),
+            Key:       key,
+        },
+    }, nil
+}
+
+// TxConditionCheck creates a transaction entry which fails the whole transaction
+// unless the condition holds for the item, without modifying it.
+func TxConditionCheck(hashKeyValue any, rangeKeyValue any, condition expression.ConditionBuilder) (types.TransactWriteItem, error) {
+    if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+        return types.TransactWriteItem{}, err
+    }
+    key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+    if err != nil {
+        return types.TransactWriteItem{}, fmt.Errorf("failed to create key for condition check: %v", err)
+    }
+    expr, err := expression.NewBuilder().WithCondition(condition).Build()
+    if err != nil {
+        return types.TransactWriteItem{}, fmt.Errorf("failed to build condition expression: %v", err)
+    }
+    return types.TransactWriteItem{
+        ConditionCheck: &types.ConditionCheck{
+            TableName:                 aws.String(TableSchema.TableName),
+            Key:                       key,
+            ConditionExpression:       expr.Condition(),
+            ExpressionAttributeNames:  expr.Names(),
+            ExpressionAttributeValues: expr.Values(),
+        },
+    }, nil
+}
+`
+
+// TransactionBuilderTemplate provides TransactionBuilder combining typed actions into one TransactWriteItems call
+const TransactionBuilderTemplate = `
+// maxTransactItems is the DynamoDB limit of actions in one transaction.
+const maxTransactItems = 100
+
+// TransactionBuilder combines typed Put, Update, Delete and ConditionCheck actions
+// into one TransactWriteItemsInput. Actions of other generated packages join with Add,
+// so a single transaction can span several tables while every entry stays typed.
+// The first error of any action is kept and returned by Build and Execute.
+// Example:
+//   err := NewTransactionBuilder().
+//       Put(item).
+//       ConditionCheck({{.ExampleKey .HashKey}}, {{if .RangeKey}}{{.ExampleKey .RangeKey}}{{else}}nil{{end}}, expression.AttributeExists(expression.Name({{.ExampleField .HashKey}}))).
+//       Add(otherpkg.TxDelete(otherKey, nil)).
+//       Execute(ctx, client)
+type TransactionBuilder struct {
+    items []types.TransactWriteItem
+    token string
+    err   error
+}
+
+// NewTransactionBuilder creates an empty TransactionBuilder.
+func NewTransactionBuilder() *TransactionBuilder {
+    return &TransactionBuilder{}
+}
+
+// Put adds an action writing the item.
+func (tb *TransactionBuilder) Put(item SchemaItem) *TransactionBuilder {
+    return tb.Add(TxPut(item))
+}
+
+// Update adds an action setting the given attributes of an item.
+func (tb *TransactionBuilder) Update(hashKeyValue any, rangeKeyValue any, updates map[string]any) *TransactionBuilder {
+    return tb.Add(TxUpdate(hashKeyValue, rangeKeyValue, updates))
+}
+
+// Delete adds an action deleting an item by key.
+func (tb *TransactionBuilder) Delete(hashKeyValue any, rangeKeyValue any) *TransactionBuilder {
+    return tb.Add(TxDelete(hashKeyValue, rangeKeyValue))
+}
+
+// ConditionCheck adds an action failing the transaction unless the condition holds for the item.
+func (tb *TransactionBuilder) ConditionCheck(hashKeyValue any, rangeKeyValue any, condition expression.ConditionBuilder) *TransactionBuilder {
+    return tb.Add(TxConditionCheck(hashKeyValue, rangeKeyValue, condition))
+}
+
+// Add appends an action built by a Tx* function of this or any other generated package.
+func (tb *TransactionBuilder) Add(item types.TransactWriteItem, err error) *TransactionBuilder {
+    if tb.err != nil {
+        return tb
+    }
+    if err != nil {
+        tb.err = fmt.Errorf("transaction action %d: %w", len(tb.items), err)
+        return tb
+    }
+    tb.items = append(tb.items, item)
+    return tb
+}
+
+// WithClientRequestToken makes the transaction idempotent: retries with the same token
+// within 10 minutes are not applied twice.
+func (tb *TransactionBuilder) WithClientRequestToken(token string) *TransactionBuilder {
+    tb.token = token
+    return tb
+}
+
+// Len returns the number of actions.
+func (tb *TransactionBuilder) Len() int {
+    return len(tb.items)
+}
+
+// Build returns the TransactWriteItemsInput of the actions.
+func (tb *TransactionBuilder) Build() (*dynamodb.TransactWriteItemsInput, error) {
+    if tb.err != nil {
+        return nil, tb.err
+    }
+    if len(tb.items) == 0 {
+        return nil, errors.New("transaction has no actions")
+    }
+    if len(tb.items) > maxTransactItems {
+        return nil, fmt.Errorf("transaction has %d actions, DynamoDB allows at most %d", len(tb.items), maxTransactItems)
+    }
+    input := &dynamodb.TransactWriteItemsInput{TransactItems: tb.items}
+    if tb.token != "" {
+        input.ClientRequestToken = aws.String(tb.token)
+    }
+    return input, nil
+}
+
+// Execute runs the transaction. If DynamoDB cancels it, the error names the failed
+// actions by index and table and wraps the TransactionCanceledException.
+func (tb *TransactionBuilder) Execute(ctx context.Context, client DynamoDBAPI) error {
+    input, err := tb.Build()
+    if err != nil {
+        return err
+    }
+    _, err = client.TransactWriteItems(ctx, input)
+    var canceled *types.TransactionCanceledException
+    if !errors.As(err, &canceled) {
+        return err
+    }
+    var failed []string
+    for i, reason := range canceled.CancellationReasons {
+        code := aws.ToString(reason.Code)
+        if code == "" || code == "None" || i >= len(tb.items) {
+            continue
+        }
+        failed = append(failed, fmt.Sprintf("action %d (%s): %s", i, transactTableName(tb.items[i]), code))
+    }
+    return fmt.Errorf("transaction canceled: %s: %w", strings.Join(failed, ", "), err)
+}
+
+// transactTableName returns the table an action writes to.
+func transactTableName(item types.TransactWriteItem) string {
+    switch {
+    case item.Put != nil:
+        return aws.ToString(item.Put.TableName)
+    case item.Update != nil:
+        return aws.ToString(item.Update.TableName)
+    case item.Delete != nil:
+        return aws.ToString(item.Delete.TableName)
+    case item.ConditionCheck != nil:
+        return aws.ToString(item.ConditionCheck.TableName)
+    }
+    return ""
+}
+`
+
+// TransactGetTemplate provides TransactGet reading several items atomically into typed SchemaItems
+const TransactGetTemplate = `
+// TransactGetInput creates a TransactGetItemsInput reading the items by key.
+// At most 100 keys are allowed in one transaction.
+func TransactGetInput(keys ...ItemKey) (*dynamodb.TransactGetItemsInput, error) {
+    if len(keys) == 0 {
+        return nil, errors.New("transaction has no keys")
+    }
+    if len(keys) > maxTransactItems {
+        return nil, fmt.Errorf("transaction has %d keys, DynamoDB allows at most %d", len(keys), maxTransactItems)
+    }
+    items := make([]types.TransactGetItem, 0, len(keys))
+    for i, k := range keys {
+        if err := validateKeyInputs(k.HashKey, k.RangeKey); err != nil {
+            return nil, fmt.Errorf("key %d: %w", i, err)
+        }
+        key, err := KeyInputFromRaw(k.HashKey, k.RangeKey)
+        if err != nil {
+            return nil, fmt.Errorf("key %d: %w", i, err)
+        }
+        items = append(items, types.TransactGetItem{
+            Get: &types.Get{
+                TableName: aws.String(TableSchema.TableName),
+                Key:       key,
+            },
+        })
+    }
+    return &dynamodb.TransactGetItemsInput{TransactItems: items}, nil
+}
+
+// TransactGet reads the items by key in one serializable transaction and returns them
+// in the order of the keys. Items which don't exist are returned as nil.
+// Example:
+//   items, err := TransactGet(ctx, client,
+//       ItemKey{HashKey: {{.ExampleKey .HashKey}}{{if .RangeKey}}, RangeKey: {{.ExampleKey .RangeKey}}{{end}}},
+//       ItemKeyOf(otherItem),
+//   )
+func TransactGet(ctx context.Context, client DynamoDBAPI, keys ...ItemKey) ([]*SchemaItem, error) {
+    input, err := TransactGetInput(keys...)
+    if err != nil {
+        return nil, err
+    }
+    out, err := client.TransactGetItems(ctx, input)
+    if err != nil {
+        return nil, fmt.Errorf("failed to execute transactional get: %w", err)
+    }
+    items := make([]*SchemaItem, len(keys))
+    for i, response := range out.Responses {
+        if i >= len(items) || len(response.Item) == 0 {
+            continue
+        }
+        var item SchemaItem
+        if err := attributevalue.UnmarshalMap(response.Item, &item); err != nil {
+            return nil, fmt.Errorf("failed to unmarshal item %d: %v", i, err)
+        }
+        items[i] = &item
+    }
+    return items, nil
+}
+`
@@ -7,11 +7,25 @@ const QueryBuilderBuildTemplate = `
 // - Preferred sort key hints from user
 // - Number of composite key parts matched
 // - Index efficiency for the given query pattern
+// - Projection of filtered attributes, see OnUnprojectedFilter
+// WithIndex restricts the selection to the given index.
 // Returns index name, key conditions, filter conditions, pagination key, and any errors.
 func (qb *QueryBuilder) Build() (string, expression.KeyConditionBuilder, *expression.ConditionBuilder, map[string]types.AttributeValue, error) {
+    if qb.consistentIndexOnly() && qb.IndexName != "" {
+        if info := GetIndexInfo(qb.IndexName); info != nil && info.Type == "GSI" {
+            return "", expression.KeyConditionBuilder{}, nil, nil, fmt.Errorf("consistent reads are not supported on global secondary index %s", qb.IndexName)
+        }
+    }
     var filterCond *expression.ConditionBuilder
-    sortedIndexes := make([]SecondaryIndex, len(TableSchema.SecondaryIndexes))
-    copy(sortedIndexes, TableSchema.SecondaryIndexes)
+    var sortedIndexes []SecondaryIndex
+    for _, idx := range TableSchema.SecondaryIndexes {
+        if qb.consistentIndexOnly() && idx.Type == "GSI" {
+            continue
+        }
+        if !qb.tableOnly && (qb.IndexName == "" || idx.Name == qb.IndexName) {
+            sortedIndexes = append(sortedIndexes, idx)
+        }
+    }
     
     sort.Slice(sortedIndexes, func(i, j int) bool {
         if qb.PreferredSortKey != "" {
@@ -30,12 +44,21 @@ func (qb *QueryBuilder) Build() (string, expression.KeyConditionBuilder, *expres
         return iParts > jParts
     })
 
+    qb.hydrateFilter = nil
+    var skipped []string
+    var skippedFields []string
     for _, idx := range sortedIndexes {
-        hashKeyCondition, hashKeyMatch := qb.buildHashKeyCondition(idx)
+        hashKeyCondition, hashKeyMatch, err := qb.buildHashKeyCondition(idx)
+        if err != nil {
+            return "", expression.KeyConditionBuilder{}, nil, nil, err
+        }
         if !hashKeyMatch {
             continue
         }
-        rangeKeyCondition, rangeKeyMatch := qb.buildRangeKeyCondition(idx)
+        rangeKeyCondition, rangeKeyMatch, err := qb.buildRangeKeyCondition(idx)
+        if err != nil {
+            return "", expression.KeyConditionBuilder{}, nil, nil, err
+        }
         if !rangeKeyMatch {
             continue
         }
@@ -43,11 +66,24 @@ func (qb *QueryBuilder) Build() (string, expression.KeyConditionBuilder, *expres
         if rangeKeyCondition != nil {
             keyCondition = keyCondition.And(*rangeKeyCondition)
         }
+        if missing := qb.unprojectedFields(idx); len(missing) > 0 {
+            switch qb.UnprojectedFilter {
+            case UnprojectedFail:
+                return "", expression.KeyConditionBuilder{}, nil, nil, fmt.Errorf("index %s (projection %s) does not project filtered attributes: %s", idx.Name, idx.ProjectionType, strings.Join(missing, ", "))
+            case UnprojectedHydrate:
+                filterCond, qb.hydrateFilter = qb.splitFilterConditions(idx)
+                return idx.Name, keyCondition, filterCond, qb.ExclusiveStartKey, nil
+            default:
+                skipped = append(skipped, idx.Name)
+                skippedFields = append(skippedFields, missing...)
+                continue
+            }
+        }
         filterCond = qb.buildFilterCondition(idx)
         return idx.Name, keyCondition, filterCond, qb.ExclusiveStartKey, nil
     }
 
-    if qb.UsedKeys[TableSchema.HashKey] {
+    if qb.UsedKeys[TableSchema.HashKey] && qb.IndexName == "" {
         indexName := ""
         keyCondition := expression.Key(TableSchema.HashKey).Equal(expression.Value(qb.Attributes[TableSchema.HashKey]))
 
@@ -60,9 +96,9 @@ func (qb *QueryBuilder) Build() (string, expression.KeyConditionBuilder, *expres
         }
         var filterConditions []expression.ConditionBuilder
         filterConditions = append(filterConditions, qb.FilterConditions...)
-        for attrName, value := range qb.Attributes {
+        for _, attrName := range slices.Sorted(maps.Keys(qb.Attributes)) {
             if attrName != TableSchema.HashKey && attrName != TableSchema.RangeKey {
-                filterConditions = append(filterConditions, expression.Name(attrName).Equal(expression.Value(value)))
+                filterConditions = append(filterConditions, expression.Name(attrName).Equal(expression.Value(qb.Attributes[attrName])))
             }
         }
         if len(filterConditions) > 0 {
@@ -74,6 +110,9 @@ func (qb *QueryBuilder) Build() (string, expression.KeyConditionBuilder, *expres
         }
         return indexName, keyCondition, filterCond, qb.ExclusiveStartKey, nil
     }
+    if len(skipped) > 0 {
+        return "", expression.KeyConditionBuilder{}, nil, nil, fmt.Errorf("no suitable index found for the provided keys: indexes %s do not project filtered attributes %s, use OnUnprojectedFilter(UnprojectedHydrate) to read them from the table", strings.Join(skipped, ", "), strings.Join(skippedFields, ", "))
+    }
     return "", expression.KeyConditionBuilder{}, nil, nil, fmt.Errorf("no suitable index found for the provided keys")
 }
 
@@ -90,53 +129,62 @@ func (qb *QueryBuilder) calculateIndexParts(idx SecondaryIndex) int {
 }
 
 // buildHashKeyCondition creates the hash key condition for a given index.
-func (qb *QueryBuilder) buildHashKeyCondition(idx SecondaryIndex) (*expression.KeyConditionBuilder, bool) {
+// Returns an error if a composite key value can't be built, see buildCompositeKeyValue.
+func (qb *QueryBuilder) buildHashKeyCondition(idx SecondaryIndex) (*expression.KeyConditionBuilder, bool, error) {
     if idx.HashKeyParts != nil {
         if qb.hasAllKeys(idx.HashKeyParts) {
-            cond := qb.buildCompositeKeyCondition(idx.HashKeyParts)
-            return &cond, true
+            cond, err := qb.buildCompositeKeyCondition(idx.HashKeyParts)
+            if err != nil {
+                return nil, false, err
+            }
+            return &cond, true, nil
         }
     } else if idx.HashKey != "" && qb.UsedKeys[idx.HashKey] {
         cond := expression.Key(idx.HashKey).Equal(expression.Value(qb.Attributes[idx.HashKey]))
-        return &cond, true
+        return &cond, true, nil
     }
-    return nil, false
+    return nil, false, nil
 }
 
 // buildRangeKeyCondition creates the range key condition for a given index.
-func (qb *QueryBuilder) buildRangeKeyCondition(idx SecondaryIndex) (*expression.KeyConditionBuilder, bool) {
+// Returns an error if a composite key value can't be built, see buildCompositeKeyValue.
+func (qb *QueryBuilder) buildRangeKeyCondition(idx SecondaryIndex) (*expression.KeyConditionBuilder, bool, error) {
     if idx.RangeKeyParts != nil {
         if qb.hasAllKeys(idx.RangeKeyParts) {
-            cond := qb.buildCompositeKeyCondition(idx.RangeKeyParts)
-            return &cond, true
+            cond, err := qb.buildCompositeKeyCondition(idx.RangeKeyParts)
+            if err != nil {
+                return nil, false, err
+            }
+            return &cond, true, nil
         }
     } else if idx.RangeKey != "" {
         if qb.UsedKeys[idx.RangeKey] {
             if cond, exists := qb.KeyConditions[idx.RangeKey]; exists {
-                return &cond, true
+                return &cond, true, nil
             } else {
                 cond := expression.Key(idx.RangeKey).Equal(expression.Value(qb.Attributes[idx.RangeKey]))
-                return &cond, true
+                return &cond, true, nil
             }
         } else {
-            return nil, true
+            return nil, true, nil
         }
     } else {
-        return nil, true
+        return nil, true, nil
     }
-    return nil, false
+    return nil, false, nil
 }
 
-// buildFilterCondition creates filter conditions for attributes not part of the index keys.
+// buildFilterCondition creates filter conditions for attributes not part of the index keys,
+// in attribute name order so the same query always builds the same request.
 func (qb *QueryBuilder) buildFilterCondition(idx SecondaryIndex) *expression.ConditionBuilder {
     var filterConditions []expression.ConditionBuilder
     
     filterConditions = append(filterConditions, qb.FilterConditions...)
-    for attrName, value := range qb.Attributes {
+    for _, attrName := range slices.Sorted(maps.Keys(qb.Attributes)) {
         if qb.isPartOfIndexKey(attrName, idx) {
             continue
         }
-        filterConditions = append(filterConditions, expression.Name(attrName).Equal(expression.Value(value)))
+        filterConditions = append(filterConditions, expression.Name(attrName).Equal(expression.Value(qb.Attributes[attrName])))
     }
     if len(filterConditions) == 0 {
         return nil
@@ -174,10 +222,19 @@ func (qb *QueryBuilder) isPartOfIndexKey(attrName string, idx SecondaryIndex) bo
 // BuildQuery constructs the final DynamoDB QueryInput with all expressions and parameters.
 // Combines key conditions, filter conditions, pagination, and sorting options.
 func (qb *QueryBuilder) BuildQuery() (*dynamodb.QueryInput, error) {
+    if qb.cursorErr != nil {
+        return nil, qb.cursorErr
+    }
+    if qb.filterErr != nil {
+        return nil, qb.filterErr
+    }
     indexName, keyCond, filterCond, exclusiveStartKey, err := qb.Build()
     if err != nil {
         return nil, err
     }
+    if err := qb.checkCursor(indexName); err != nil {
+        return nil, err
+    }
     exprBuilder := expression.NewBuilder().WithKeyCondition(keyCond)
     if filterCond != nil {
         exprBuilder = exprBuilder.WithFilter(*filterCond)
@@ -199,29 +256,318 @@ func (qb *QueryBuilder) BuildQuery() (*dynamodb.QueryInput, error) {
     if filterCond != nil {
         input.FilterExpression = expr.Filter()
     }
-    if qb.LimitValue != nil {
-        input.Limit = aws.Int32(int32(*qb.LimitValue))
+    if qb.ConsistentRead {
+        input.ConsistentRead = aws.Bool(true)
     }
+    input.Limit = qb.requestLimit()
     if exclusiveStartKey != nil {
         input.ExclusiveStartKey = exclusiveStartKey
     }
+    if qb.StablePlaceholders {
+        input.ExpressionAttributeNames, input.ExpressionAttributeValues = stablePlaceholders(input.ExpressionAttributeNames, input.ExpressionAttributeValues, input.KeyConditionExpression, input.FilterExpression)
+    }
     return input, nil
 }
 
+// QueryRequest is the request a QueryBuilder would send in a comparable form, see BuildForTest.
+// Attribute names are resolved in the expressions, values keep their placeholders and are decoded
+// in Values: numbers as int64 or float64, other values as attributevalue.Unmarshal decodes them.
+type QueryRequest struct {
+    IndexName      string
+    KeyCondition   string
+    Filter         string
+    Values         map[string]any
+    Descending     bool
+    ConsistentRead bool
+    Limit          int32
+}
+
+// BuildForTest builds the query like Execute without sending it and returns the normalized request,
+// so unit tests can assert the query construction without a DynamoDB endpoint.
+// Example:
+//   req, err := NewQueryBuilder().With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).BuildForTest()
+//   // req.IndexName == "", req.KeyCondition == "{{.HashKey}} = :0"
+func (qb *QueryBuilder) BuildForTest() (QueryRequest, error) {
+    input, err := qb.BuildQuery()
+    if err != nil {
+        return QueryRequest{}, err
+    }
+    resolve := func(expr *string) string {
+        return simulatedNamePattern.ReplaceAllStringFunc(aws.ToString(expr), func(name string) string {
+            if attr, ok := input.ExpressionAttributeNames[name]; ok {
+                return attr
+            }
+            return name
+        })
+    }
+    req := QueryRequest{
+        IndexName:      aws.ToString(input.IndexName),
+        KeyCondition:   resolve(input.KeyConditionExpression),
+        Filter:         resolve(input.FilterExpression),
+        Values:         make(map[string]any, len(input.ExpressionAttributeValues)),
+        Descending:     !aws.ToBool(input.ScanIndexForward),
+        ConsistentRead: aws.ToBool(input.ConsistentRead),
+        Limit:          aws.ToInt32(input.Limit),
+    }
+    for placeholder, av := range input.ExpressionAttributeValues {
+        if req.Values[placeholder], err = simulatedValue(av); err != nil {
+            return QueryRequest{}, fmt.Errorf("failed to decode %s: %v", placeholder, err)
+        }
+    }
+    return req, nil
+}
+
+// simulatedNamePattern matches the attribute name placeholders of built expressions.
+var simulatedNamePattern = regexp.MustCompile("#\\w+")
+
+// simulatedValue decodes an expression value for BuildForTest.
+func simulatedValue(av types.AttributeValue) (any, error) {
+    if n, ok := av.(*types.AttributeValueMemberN); ok {
+        if i, err := strconv.ParseInt(n.Value, 10, 64); err == nil {
+            return i, nil
+        }
+        return strconv.ParseFloat(n.Value, 64)
+    }
+    var value any
+    if err := attributevalue.Unmarshal(av, &value); err != nil {
+        return nil, err
+    }
+    return value, nil
+}
+
 // Execute runs the query against DynamoDB and returns strongly-typed results.
 // Handles the complete query lifecycle: build input, execute, unmarshal results.
-func (qb *QueryBuilder) Execute(ctx context.Context, client *dynamodb.Client) ([]SchemaItem, error) {
+// With UnprojectedHydrate, index hits are re-read from the table to apply the remaining filters.
+// With LimitResults, pages are fetched until enough items pass the filters (ExecuteWithCursor
+// also returns the cursor resuming after them); if ctx ends or MaxPages requests are sent before,
+// the items read so far are returned with a *PartialResultError.
+{{- if .ProjectedIndexes}}
+// Indexes with KEYS_ONLY or INCLUDE projection return only the attributes they project,
+// the other attributes of the items are zero-valued: Execute<Index>Index returns them typed instead.
+{{- end}}
+func (qb *QueryBuilder) Execute(ctx context.Context, client DynamoDBAPI) ([]SchemaItem, error) {
+    if qb.ResultLimitValue != nil {
+        items, _, err := qb.executePages(ctx, client, *qb.ResultLimitValue, qb.maxPages())
+        return items, err
+    }
     input, err := qb.BuildQuery()
     if err != nil {
         return nil, err
     }
-    result, err := client.Query(ctx, input)
+    result, err := qb.query(ctx, client, input)
     if err != nil {
         return nil, fmt.Errorf("failed to execute query: %v", err)
     }
-    var items []SchemaItem
-    err = attributevalue.UnmarshalListOfMaps(result.Items, &items)
+    return qb.unmarshalItems(ctx, client, result.Items)
+}
+
+// ExecuteAll runs the query following LastEvaluatedKey until every page is read
+// and returns at most MaxItems (or Limit) items, all matching items if neither is set.
+// PageSize controls the number of items evaluated per request; ExecuteWithCursor also returns the cursor
+// resuming after the returned items. If ctx ends before the last page, the items read so far are returned
+// with a *PartialResultError holding the cursor to resume from.
+// Example:
+//   items, err := NewQueryBuilder().With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).ExecuteAll(ctx, client)
+//   var partial *PartialResultError
+//   if errors.As(err, &partial) {
+//       resumeLater(partial.Cursor) // items holds the results read before
+//   }
+func (qb *QueryBuilder) ExecuteAll(ctx context.Context, client DynamoDBAPI) ([]SchemaItem, error) {
+    items, _, err := qb.executePages(ctx, client, qb.totalLimit(), -1)
+    return items, err
+}
+
+// ExecuteWithCursor reads like Execute with LimitResults, like ExecuteAll without it, and returns
+// the items with the cursor resuming after them, empty if all matching items were read.
+// The cursor is not kept by the builder, so it can be reused or shared between reads.
+// Example:
+//   page, err := NewQueryBuilder().With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).LimitResults(20).StartFromCursor(cursor).ExecuteWithCursor(ctx, client)
+//   if page.HasMore {
+//       cursor = page.NextCursor
+//   }
+func (qb *QueryBuilder) ExecuteWithCursor(ctx context.Context, client DynamoDBAPI) (Page, error) {
+    limit, maxPages := qb.totalLimit(), -1
+    if qb.ResultLimitValue != nil {
+        limit, maxPages = *qb.ResultLimitValue, qb.maxPages()
+    }
+    items, cursor, err := qb.executePages(ctx, client, limit, maxPages)
+    return Page{Items: items, NextCursor: cursor, HasMore: cursor != ""}, err
+}
+
+// ExecutePaginated runs one request of the query and returns its page with the cursor of the next one.
+// PageSize (or Limit) sets the number of items evaluated per page; with filters a page may hold
+// fewer items, even none, while HasMore is true.
+// Example:
+//   page, err := NewQueryBuilder().With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).PageSize(20).StartFromCursor(r.URL.Query().Get("cursor")).ExecutePaginated(ctx, client)
+//   json.NewEncoder(w).Encode(page)
+func (qb *QueryBuilder) ExecutePaginated(ctx context.Context, client DynamoDBAPI) (Page, error) {
+    input, err := qb.BuildQuery()
     if err != nil {
+        return Page{}, err
+    }
+    result, err := qb.query(ctx, client, input)
+    if err != nil {
+        return Page{}, fmt.Errorf("failed to execute query: %v", err)
+    }
+    items, err := qb.unmarshalItems(ctx, client, result.Items)
+    if err != nil {
+        return Page{}, err
+    }
+    cursor, err := EncodeCursor(result.LastEvaluatedKey)
+    if err != nil {
+        return Page{}, err
+    }
+    return Page{Items: items, NextCursor: cursor, HasMore: cursor != ""}, nil
+}
+
+// Iterate returns a PageIterator over the query results which prefetches one page ahead.
+// PageSize controls the number of items evaluated per request; StartFrom sets the first page.
+func (qb *QueryBuilder) Iterate(ctx context.Context, client DynamoDBAPI) (*PageIterator, error) {
+    input, err := qb.BuildQuery()
+    if err != nil {
+        return nil, err
+    }
+    fetch := func(ctx context.Context, startKey map[string]types.AttributeValue) ([]SchemaItem, map[string]types.AttributeValue, error) {
+        pageInput := *input
+        pageInput.ExclusiveStartKey = startKey
+        result, err := qb.query(ctx, client, &pageInput)
+        if err != nil {
+            return nil, nil, fmt.Errorf("failed to execute query: %v", err)
+        }
+        items, err := qb.unmarshalItems(ctx, client, result.Items)
+        return items, result.LastEvaluatedKey, err
+    }
+    return newPageIterator(ctx, input.ExclusiveStartKey, fetch), nil
+}
+
+// Items returns an iterator over the query results which reads pages lazily, one page ahead,
+// so large results are processed without buffering them. Stopping the loop stops reading;
+// canceling ctx ends the loop with ctx.Err(), so a truncated read is never mistaken for a complete one.
+// Example:
+//   for item, err := range NewQueryBuilder().With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).Items(ctx, client) {
+//       if err != nil {
+//           return err
+//       }
+//       process(item)
+//   }
+func (qb *QueryBuilder) Items(ctx context.Context, client DynamoDBAPI) iter.Seq2[SchemaItem, error] {
+    return iterateItems(qb.Iterate(ctx, client))
+}
+
+// Count returns the number of items matching the query without transferring them:
+// requests use Select COUNT and follow LastEvaluatedKey until the partition is exhausted.
+// Example:
+//   n, err := NewQueryBuilder().With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).Count(ctx, client)
+func (qb *QueryBuilder) Count(ctx context.Context, client DynamoDBAPI) (int64, error) {
+    count, _, err := qb.CountScanned(ctx, client)
+    return count, err
+}
+
+// CountScanned is Count which also returns the number of items evaluated before filters,
+// the read cost of the query. Filters on attributes an index doesn't project can't be counted.
+func (qb *QueryBuilder) CountScanned(ctx context.Context, client DynamoDBAPI) (count, scanned int64, err error) {
+    input, err := qb.BuildQuery()
+    if err != nil {
+        return 0, 0, err
+    }
+    if qb.hydrateFilter != nil {
+        return 0, 0, fmt.Errorf("filters on unprojected attributes of index %s can't be counted, query the table instead", aws.ToString(input.IndexName))
+    }
+    input.Select = types.SelectCount
+    for {
+        result, err := qb.query(ctx, client, input)
+        if err != nil {
+            return 0, 0, fmt.Errorf("failed to execute query: %v", err)
+        }
+        count += int64(result.Count)
+        scanned += int64(result.ScannedCount)
+        if len(result.LastEvaluatedKey) == 0 {
+            return count, scanned, nil
+        }
+        input.ExclusiveStartKey = result.LastEvaluatedKey
+    }
+}
+
+// query sends one Query request, repeated with the other consistency as the read fallback requires.
+func (qb *QueryBuilder) query(ctx context.Context, client DynamoDBAPI, input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+    consistent, eventual := *input, *input
+    consistent.ConsistentRead = aws.Bool(true)
+    eventual.ConsistentRead = aws.Bool(false)
+
+    switch qb.readFallback {
+    case StrongThenEventual:
+        result, err := client.Query(ctx, &consistent)
+        if err == nil || ctx.Err() != nil {
+            return result, err
+        }
+        return client.Query(ctx, &eventual)
+    case EventualThenStrong:
+        result, err := client.Query(ctx, &eventual)
+        if err != nil || input.Select == types.SelectCount || freshItems(result.Items, qb.freshness) {
+            return result, err
+        }
+        return client.Query(ctx, &consistent)
+    }
+    return client.Query(ctx, input)
+}
+
+// executePages paginates the query until limit items pass the filters, the partition
+// is exhausted or maxPages requests are sent. Negative values disable the respective cap.
+// Items of a page past limit are left unread, the returned cursor resumes after the returned ones;
+// reaching maxPages first returns the items with a *PartialResultError wrapping ErrPageCapReached.
+func (qb *QueryBuilder) executePages(ctx context.Context, client DynamoDBAPI, limit, maxPages int) ([]SchemaItem, string, error) {
+    input, err := qb.BuildQuery()
+    if err != nil {
+        return nil, "", err
+    }
+    var items []SchemaItem
+    resume := input.ExclusiveStartKey
+    for page := 0; limit < 0 || len(items) < limit; page++ {
+        if maxPages >= 0 && page >= maxPages {
+            cursor, err := finishPages(len(items), resume, ErrPageCapReached)
+            return items, cursor, err
+        }
+        result, err := qb.query(ctx, client, input)
+        if err != nil {
+            if ctx.Err() != nil {
+                cursor, err := finishPages(len(items), resume, ctx.Err())
+                return items, cursor, err
+            }
+            return nil, "", fmt.Errorf("failed to execute query: %v", err)
+        }
+        for _, raw := range result.Items {
+            if limit >= 0 && len(items) >= limit {
+                cursor, err := finishPages(len(items), resume, nil)
+                return items, cursor, err
+            }
+            hit, err := qb.unmarshalItems(ctx, client, []map[string]types.AttributeValue{raw})
+            if err != nil {
+                if ctx.Err() != nil {
+                    cursor, err := finishPages(len(items), resume, ctx.Err())
+                    return items, cursor, err
+                }
+                return nil, "", err
+            }
+            items = append(items, hit...)
+            resume = itemKey(raw, aws.ToString(input.IndexName))
+        }
+        resume = result.LastEvaluatedKey
+        if len(resume) == 0 {
+            break
+        }
+        input.ExclusiveStartKey = resume
+    }
+    cursor, err := finishPages(len(items), resume, nil)
+    return items, cursor, err
+}
+
+// unmarshalItems converts a page of query results, hydrating index hits if required.
+func (qb *QueryBuilder) unmarshalItems(ctx context.Context, client DynamoDBAPI, raw []map[string]types.AttributeValue) ([]SchemaItem, error) {
+    if qb.hydrateFilter != nil {
+        return qb.hydrate(ctx, client, raw)
+    }
+    var items []SchemaItem
+    if err := attributevalue.UnmarshalListOfMaps(raw, &items); err != nil {
         return nil, fmt.Errorf("failed to unmarshal result: %v", err)
     }
     return items, nil
@@ -10,10 +10,31 @@ type QueryBuilder struct {
     PaginationMixin   // Limit and pagination support
     KeyConditionMixin // Key conditions for partition and sort keys
     IndexName string  // Optional index name override
+
+    ConsistentRead    bool                         // Strongly consistent reads, only the table and LSIs are queried
+    StablePlaceholders bool                        // Attribute-derived expression placeholders, see WithStablePlaceholders
+    UnprojectedFilter UnprojectedFilterPolicy     // Handling of filters on attributes not projected into a GSI
+    hydrateFilter     *expression.ConditionBuilder // Filters applied when re-reading index hits from the table
+    indexKeys         []indexKeyCondition          // WithIndex*Key calls in order, replayed by UnmarshalJSON
+    tableOnly         bool                         // Never select a secondary index (named queries of the table)
+    readFallback      ReadFallback                 // Consistency fallback of requests, not kept by MarshalJSON
+    freshness         FreshnessCheck               // Freshness check of EventualThenStrong
+}
+
+// indexKeyCondition records a key condition set by index name (WithIndexHashKey, WithIndexRangeKey*).
+type indexKeyCondition struct {
+    Index    string       ` + "`json:\"index\"`" + `
+    Range    bool         ` + "`json:\"range,omitempty\"`" + `
+    Operator OperatorType ` + "`json:\"operator\"`" + `
+    Values   []any        ` + "`json:\"values\"`" + `
 }
 
 // NewQueryBuilder creates a new QueryBuilder instance with initialized mixins.
 // All mixins are properly initialized for immediate use.
+// Example:
+//   items, err := NewQueryBuilder().
+//       With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).
+//       Execute(ctx, client)
 func NewQueryBuilder() *QueryBuilder {
     return &QueryBuilder{
         FilterMixin:       NewFilterMixin(),
@@ -23,12 +44,42 @@ func NewQueryBuilder() *QueryBuilder {
 }
 
 // Limit sets the maximum number of items and returns QueryBuilder for method chaining.
-// Controls the number of items returned in a single request.
+// Execute sends it as the request Limit unless PageSize is set;
+// ExecuteAll returns at most limit items across all pages.
 func (qb *QueryBuilder) Limit(limit int) *QueryBuilder {
     qb.PaginationMixin.Limit(limit)
     return qb
 }
 
+// PageSize sets the number of items DynamoDB evaluates per request and returns QueryBuilder for method chaining.
+// Use it to tune capacity consumed per call, independently of Limit.
+func (qb *QueryBuilder) PageSize(size int) *QueryBuilder {
+    qb.PaginationMixin.PageSize(size)
+    return qb
+}
+
+// LimitResults sets the number of items Execute returns after filters are applied.
+// Execute paginates until limit matching items are collected or MaxPages requests are sent.
+func (qb *QueryBuilder) LimitResults(limit int) *QueryBuilder {
+    qb.PaginationMixin.LimitResults(limit)
+    return qb
+}
+
+// MaxItems sets the number of items ExecuteAll returns at most and returns QueryBuilder for method chaining.
+// ExecuteAll follows LastEvaluatedKey until the data is exhausted or maxItems items are collected,
+// the request Limit (Limit or PageSize) stays unchanged.
+func (qb *QueryBuilder) MaxItems(maxItems int) *QueryBuilder {
+    qb.PaginationMixin.MaxItems(maxItems)
+    return qb
+}
+
+// MaxPages caps the number of requests LimitResults may send (DefaultMaxPages by default).
+// Reaching it returns the items read with a *PartialResultError wrapping ErrPageCapReached.
+func (qb *QueryBuilder) MaxPages(pages int) *QueryBuilder {
+    qb.PaginationMixin.MaxPages(pages)
+    return qb
+}
+
 // StartFrom sets the exclusive start key and returns QueryBuilder for method chaining.
 // Use LastEvaluatedKey from previous response for pagination.
 func (qb *QueryBuilder) StartFrom(lastEvaluatedKey map[string]types.AttributeValue) *QueryBuilder {
@@ -36,14 +87,56 @@ func (qb *QueryBuilder) StartFrom(lastEvaluatedKey map[string]types.AttributeVal
     return qb
 }
 
+// StartFromCursor resumes after the page whose NextCursor is token and returns QueryBuilder for method chaining.
+// Cursors come from ExecutePaginated and may be round-tripped through web clients.
+func (qb *QueryBuilder) StartFromCursor(token string) *QueryBuilder {
+    qb.PaginationMixin.StartFromCursor(token)
+    return qb
+}
+
 // WithIndex sets the index name for query a secondary index.
-// Allows query GSI or LSI instead of main table.
+// Allows query GSI or LSI instead of main table; Build fails if the key conditions don't match it.
 // Index must exist and be in ACTIVE state.
 func (qb *QueryBuilder) WithIndex(indexName string) *QueryBuilder {
     qb.IndexName = indexName
     return qb
 }
 
+// WithConsistentRead requests strongly consistent reads and returns QueryBuilder for method chaining.
+// GSIs don't support consistent reads: they are skipped by index selection,
+// and Build fails if WithIndex names one.
+func (qb *QueryBuilder) WithConsistentRead() *QueryBuilder {
+    qb.ConsistentRead = true
+    return qb
+}
+
+// WithStablePlaceholders names the expression placeholders of built requests after their attributes
+// (#{{.HashKey}}, :{{.HashKey}}) instead of numbering them, so request snapshots don't depend on build order.
+func (qb *QueryBuilder) WithStablePlaceholders() *QueryBuilder {
+    qb.StablePlaceholders = true
+    return qb
+}
+
+// WithStrongThenFallback sends every request strongly consistent and repeats it eventually consistent
+// if it fails, see StrongThenEventual. Like WithConsistentRead, GSIs can't be queried.
+func (qb *QueryBuilder) WithStrongThenFallback() *QueryBuilder {
+    qb.readFallback = StrongThenEventual
+    return qb
+}
+
+// WithStalenessCheck sends every request eventually consistent and repeats it strongly consistent
+// if an item of the page fails fresh, see EventualThenStrong. Like WithConsistentRead, GSIs can't be queried.
+func (qb *QueryBuilder) WithStalenessCheck(fresh FreshnessCheck) *QueryBuilder {
+    qb.readFallback = EventualThenStrong
+    qb.freshness = fresh
+    return qb
+}
+
+// consistentIndexOnly reports whether the query needs an index supporting consistent reads.
+func (qb *QueryBuilder) consistentIndexOnly() bool {
+    return qb.ConsistentRead || qb.readFallback != NoReadFallback
+}
+
 // OrderByDesc sets descending sort order and returns QueryBuilder for method chaining.
 // Only affects sort key ordering, not filter results.
 func (qb *QueryBuilder) OrderByDesc() *QueryBuilder {
@@ -97,7 +190,10 @@ func (qb *QueryBuilder) setCompositeKey(keyName string, parts []CompositeKeyPart
             qb.UsedKeys[part.Value] = true
         }
     }
-    compositeValue := qb.buildCompositeKeyValue(parts)
+    compositeValue, err := qb.buildCompositeKeyValue(parts)
+    if err != nil {
+        return // Build reports the rejected value when it selects the index
+    }
     qb.Attributes[keyName] = compositeValue
     qb.UsedKeys[keyName] = true
     qb.KeyConditions[keyName] = expression.Key(keyName).Equal(expression.Value(compositeValue))
@@ -105,4 +105,64 @@ func (qb *QueryBuilder) FilterNotIn(field string, values ...any) *QueryBuilder {
     qb.FilterMixin.FilterNotIn(field, values...)
     return qb
 }
+
+// FilterSizeEQ adds size(field) = size filter and returns QueryBuilder for method chaining.
+// Size is the length of strings, bytes of binaries and elements of sets, lists and maps.
+func (qb *QueryBuilder) FilterSizeEQ(field string, size int) *QueryBuilder {
+    qb.FilterMixin.FilterSizeEQ(field, size)
+    return qb
+}
+
+// FilterSizeGT adds size(field) > size filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSizeGT(field string, size int) *QueryBuilder {
+    qb.FilterMixin.FilterSizeGT(field, size)
+    return qb
+}
+
+// FilterSizeLT adds size(field) < size filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSizeLT(field string, size int) *QueryBuilder {
+    qb.FilterMixin.FilterSizeLT(field, size)
+    return qb
+}
+
+// FilterSizeGTE adds size(field) >= size filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSizeGTE(field string, size int) *QueryBuilder {
+    qb.FilterMixin.FilterSizeGTE(field, size)
+    return qb
+}
+
+// FilterSizeLTE adds size(field) <= size filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSizeLTE(field string, size int) *QueryBuilder {
+    qb.FilterMixin.FilterSizeLTE(field, size)
+    return qb
+}
+
+// FilterSizeBetween adds size(field) BETWEEN minSize AND maxSize filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSizeBetween(field string, minSize, maxSize int) *QueryBuilder {
+    qb.FilterMixin.FilterSizeBetween(field, minSize, maxSize)
+    return qb
+}
+
+// FilterAttributeType adds attribute_type filter and returns QueryBuilder for method chaining.
+// Useful in data quality scans for items storing field with another type than the schema declares.
+func (qb *QueryBuilder) FilterAttributeType(field string, attrType string) *QueryBuilder {
+    qb.FilterMixin.FilterAttributeType(field, attrType)
+    return qb
+}
+{{- range .InFilterAttributes}}
+
+// Filter{{.GoName}}In filters items whose {{.Name}} is one of the values and returns QueryBuilder for method chaining.
+// Lists longer than 100 values are split into IN comparisons joined with OR,
+// lists longer than MaxInValues fail when the input is built.
+func (qb *QueryBuilder) Filter{{.GoName}}In(values ...{{.GoType}}) *QueryBuilder {
+    qb.FilterMixin.FilterIn(Column{{.GoName}}, filterValues(values)...)
+    return qb
+}
+
+// Filter{{.GoName}}NotIn filters items whose {{.Name}} is none of the values and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) Filter{{.GoName}}NotIn(values ...{{.GoType}}) *QueryBuilder {
+    qb.FilterMixin.FilterNotIn(Column{{.GoName}}, filterValues(values)...)
+    return qb
+}
+{{- end}}
 `
@@ -5,6 +5,9 @@ const QueryBuilderWithTemplate = `
 // With adds key condition and returns QueryBuilder for method chaining.
 // Only works with partition and sort key attributes for efficient querying.
 func (qb *QueryBuilder) With(field string, op OperatorType, values ...any) *QueryBuilder {
+    {{- if .NormalizedAttributes}}
+    values = normalizeKeyValues(field, values)
+    {{- end}}
     qb.KeyConditionMixin.With(field, op, values...)
     if op == EQ && len(values) == 1 {
         qb.Attributes[field] = values[0]
@@ -21,6 +24,9 @@ const QueryBuilderWithSugarTemplate = `
 // WithEQ adds equality key condition and returns QueryBuilder for method chaining.
 // Required for partition keys, commonly used for sort keys.
 func (qb *QueryBuilder) WithEQ(field string, value any) *QueryBuilder {
+    {{- if .NormalizedAttributes}}
+    value = NormalizeKey(field, value)
+    {{- end}}
     qb.KeyConditionMixin.WithEQ(field, value)
     qb.Attributes[field] = value
     qb.UsedKeys[field] = true
@@ -30,6 +36,9 @@ func (qb *QueryBuilder) WithEQ(field string, value any) *QueryBuilder {
 // WithBetween adds range key condition and returns QueryBuilder for method chaining.
 // Only valid for sort keys, not partition keys.
 func (qb *QueryBuilder) WithBetween(field string, start, end any) *QueryBuilder {
+    {{- if .NormalizedAttributes}}
+    start, end = NormalizeKey(field, start), NormalizeKey(field, end)
+    {{- end}}
     qb.KeyConditionMixin.WithBetween(field, start, end)
     qb.Attributes[field+"_start"] = start
     qb.Attributes[field+"_end"] = end
@@ -40,6 +49,9 @@ func (qb *QueryBuilder) WithBetween(field string, start, end any) *QueryBuilder
 // WithGT adds greater than key condition and returns QueryBuilder for method chaining.
 // Only valid for sort keys in range queries.
 func (qb *QueryBuilder) WithGT(field string, value any) *QueryBuilder {
+    {{- if .NormalizedAttributes}}
+    value = NormalizeKey(field, value)
+    {{- end}}
     qb.KeyConditionMixin.WithGT(field, value)
     qb.Attributes[field] = value 
     qb.UsedKeys[field] = true
@@ -49,6 +61,9 @@ func (qb *QueryBuilder) WithGT(field string, value any) *QueryBuilder {
 // WithGTE adds greater than or equal key condition and returns QueryBuilder for method chaining.
 // Only valid for sort keys in range queries.
 func (qb *QueryBuilder) WithGTE(field string, value any) *QueryBuilder {
+    {{- if .NormalizedAttributes}}
+    value = NormalizeKey(field, value)
+    {{- end}}
     qb.KeyConditionMixin.WithGTE(field, value)
     qb.Attributes[field] = value
     qb.UsedKeys[field] = true
@@ -58,6 +73,9 @@ func (qb *QueryBuilder) WithGTE(field string, value any) *QueryBuilder {
 // WithLT adds less than key condition and returns QueryBuilder for method chaining.
 // Only valid for sort keys in range queries.
 func (qb *QueryBuilder) WithLT(field string, value any) *QueryBuilder {
+    {{- if .NormalizedAttributes}}
+    value = NormalizeKey(field, value)
+    {{- end}}
     qb.KeyConditionMixin.WithLT(field, value)
     qb.Attributes[field] = value
     qb.UsedKeys[field] = true
@@ -67,12 +85,34 @@ func (qb *QueryBuilder) WithLT(field string, value any) *QueryBuilder {
 // WithLTE adds less than or equal key condition and returns QueryBuilder for method chaining.
 // Only valid for sort keys in range queries.
 func (qb *QueryBuilder) WithLTE(field string, value any) *QueryBuilder {
+    {{- if .NormalizedAttributes}}
+    value = NormalizeKey(field, value)
+    {{- end}}
     qb.KeyConditionMixin.WithLTE(field, value)
     qb.Attributes[field] = value
     qb.UsedKeys[field] = true
     return qb
 }
 
+// WithBeginsWith adds begins_with key condition and returns QueryBuilder for method chaining.
+// Only valid for string sort keys, e.g. hierarchical keys like "ORDER#2024#01".
+func (qb *QueryBuilder) WithBeginsWith(field string, prefix string) *QueryBuilder {
+    {{- if .NormalizedAttributes}}
+    prefix = fmt.Sprint(NormalizeKey(field, prefix))
+    {{- end}}
+    qb.KeyConditionMixin.WithBeginsWith(field, prefix)
+    qb.Attributes[field] = prefix
+    qb.UsedKeys[field] = true
+    return qb
+}
+{{- range .BeginsWithKeys}}
+
+// With{{.GoName}}BeginsWith adds the key condition begins_with({{.Name}}, prefix) and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) With{{.GoName}}BeginsWith(prefix string) *QueryBuilder {
+    return qb.WithBeginsWith(Column{{.GoName}}, prefix)
+}
+{{- end}}
+
 // WithIndexHashKey sets hash key for any index by name.
 // Automatically handles both simple and composite keys based on schema metadata.
 // For composite keys, pass values in the order they appear in the schema.
@@ -81,6 +121,9 @@ func (qb *QueryBuilder) WithIndexHashKey(indexName string, values ...any) *Query
     if index == nil {
         return qb
     }
+    {{- if .NormalizedAttributes}}
+    values = normalizeKeyValues(index.HashKey, values)
+    {{- end}}
     if index.HashKeyParts != nil {
         nonConstantParts := qb.getNonConstantParts(index.HashKeyParts)
         if len(values) != len(nonConstantParts) {
@@ -95,6 +138,7 @@ func (qb *QueryBuilder) WithIndexHashKey(indexName string, values ...any) *Query
         qb.UsedKeys[index.HashKey] = true
         qb.KeyConditions[index.HashKey] = expression.Key(index.HashKey).Equal(expression.Value(values[0]))
     }
+    qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Operator: EQ, Values: values})
     return qb
 }
 
@@ -106,6 +150,9 @@ func (qb *QueryBuilder) WithIndexRangeKey(indexName string, values ...any) *Quer
     if index == nil || index.RangeKey == "" {
         return qb
     }
+    {{- if .NormalizedAttributes}}
+    values = normalizeKeyValues(index.RangeKey, values)
+    {{- end}}
     if index.RangeKeyParts != nil {
         nonConstantParts := qb.getNonConstantParts(index.RangeKeyParts)
         if len(values) != len(nonConstantParts) {
@@ -120,6 +167,7 @@ func (qb *QueryBuilder) WithIndexRangeKey(indexName string, values ...any) *Quer
         qb.UsedKeys[index.RangeKey] = true
         qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).Equal(expression.Value(values[0]))
     }
+    qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: EQ, Values: values})
     return qb
 }
 
@@ -130,10 +178,14 @@ func (qb *QueryBuilder) WithIndexRangeKeyBetween(indexName string, start, end an
     if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
         return qb 
     }
+    {{- if .NormalizedAttributes}}
+    start, end = NormalizeKey(index.RangeKey, start), NormalizeKey(index.RangeKey, end)
+    {{- end}}
     qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).Between(expression.Value(start), expression.Value(end))
     qb.UsedKeys[index.RangeKey] = true
     qb.Attributes[index.RangeKey+"_start"] = start
     qb.Attributes[index.RangeKey+"_end"] = end
+    qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: BETWEEN, Values: []any{start, end}})
     return qb
 }
 
@@ -144,9 +196,13 @@ func (qb *QueryBuilder) WithIndexRangeKeyGT(indexName string, value any) *QueryB
     if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
         return qb
     }
+    {{- if .NormalizedAttributes}}
+    value = NormalizeKey(index.RangeKey, value)
+    {{- end}}
     qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).GreaterThan(expression.Value(value))
     qb.UsedKeys[index.RangeKey] = true
     qb.Attributes[index.RangeKey] = value
+    qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: GT, Values: []any{value}})
     return qb
 }
 
@@ -157,9 +213,13 @@ func (qb *QueryBuilder) WithIndexRangeKeyLT(indexName string, value any) *QueryB
     if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
         return qb
     }
+    {{- if .NormalizedAttributes}}
+    value = NormalizeKey(index.RangeKey, value)
+    {{- end}}
     qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).LessThan(expression.Value(value))
     qb.UsedKeys[index.RangeKey] = true
     qb.Attributes[index.RangeKey] = value
+    qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: LT, Values: []any{value}})
     return qb
 }
 
@@ -170,9 +230,13 @@ func (qb *QueryBuilder) WithIndexRangeKeyGTE(indexName string, value any) *Query
     if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
         return qb
     }
+    {{- if .NormalizedAttributes}}
+    value = NormalizeKey(index.RangeKey, value)
+    {{- end}}
     qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).GreaterThanEqual(expression.Value(value))
     qb.UsedKeys[index.RangeKey] = true
     qb.Attributes[index.RangeKey] = value
+    qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: GTE, Values: []any{value}})
     return qb
 }
 
@@ -183,9 +247,30 @@ func (qb *QueryBuilder) WithIndexRangeKeyLTE(indexName string, value any) *Query
     if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
         return qb
     }
+    {{- if .NormalizedAttributes}}
+    value = NormalizeKey(index.RangeKey, value)
+    {{- end}}
     qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).LessThanEqual(expression.Value(value))
     qb.UsedKeys[index.RangeKey] = true
     qb.Attributes[index.RangeKey] = value
+    qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: LTE, Values: []any{value}})
+    return qb
+}
+
+// WithIndexRangeKeyBeginsWith sets range key condition for any index with BEGINS_WITH operator.
+// Only works with simple string range keys, not composite ones.
+func (qb *QueryBuilder) WithIndexRangeKeyBeginsWith(indexName string, prefix string) *QueryBuilder {
+    index := qb.getIndexByName(indexName)
+    if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
+        return qb
+    }
+    {{- if .NormalizedAttributes}}
+    prefix = fmt.Sprint(NormalizeKey(index.RangeKey, prefix))
+    {{- end}}
+    qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).BeginsWith(prefix)
+    qb.UsedKeys[index.RangeKey] = true
+    qb.Attributes[index.RangeKey] = prefix
+    qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: BEGINS_WITH, Values: []any{prefix}})
     return qb
 }
 `
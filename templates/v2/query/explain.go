@@ -0,0 +1,153 @@
+package query
+
+// QueryBuilderExplainTemplate provides Explain describing the index selection and condition split of a query
+const QueryBuilderExplainTemplate = `
+// QueryPlan describes how a QueryBuilder selects its index and splits its conditions, see Explain.
+type QueryPlan struct {
+    // IndexName is the selected index, empty for the table.
+    IndexName string
+
+    // KeyCondition and Filter are the expressions of the request with attribute names resolved,
+    // values keep their placeholders like in BuildForTest.
+    KeyCondition string
+    Filter       string
+
+    // KeyAttributes are the attributes of the key condition.
+    KeyAttributes []string
+
+    // FilterAttributes are the attributes filtered after reading, with the ones applied to hydrated items.
+    FilterAttributes []string
+
+    // Demoted are the attributes set with key conditions (With, WithEQ) which the selected index
+    // isn't keyed by: they are applied as equality filters instead.
+    Demoted []string
+
+    // Hydrated is true if index hits are re-read from the table to apply filters
+    // on attributes the index doesn't project, see UnprojectedHydrate.
+    Hydrated bool
+
+    // FilterHeavy estimates whether the query degrades to filtering a whole partition:
+    // filters apply while the key condition only fixes the partition key, so every item
+    // of the partition is read and paid for. An index keyed by the Demoted attributes avoids it.
+    FilterHeavy bool
+
+    // Rejected are the secondary indexes not selected, in schema order.
+    Rejected []RejectedIndex
+}
+
+// RejectedIndex is a secondary index a QueryPlan didn't select, with the reason.
+type RejectedIndex struct {
+    Name   string
+    Reason string
+}
+
+// Explain returns the plan of the query without sending it: the selected index, why the other
+// indexes were rejected and which conditions became key conditions or filters.
+// If no index can be selected, the plan lists the rejected indexes with the error of Build.
+// Example:
+//   plan, err := NewQueryBuilder().With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).Explain()
+//   for _, idx := range plan.Rejected {
+//       log.Printf("%s: %s", idx.Name, idx.Reason)
+//   }
+func (qb *QueryBuilder) Explain() (QueryPlan, error) {
+    var plan QueryPlan
+    input, err := qb.BuildQuery()
+    if err == nil {
+        plan.IndexName = aws.ToString(input.IndexName)
+        plan.KeyCondition, plan.KeyAttributes = explainExpression(input.KeyConditionExpression, input.ExpressionAttributeNames)
+        plan.Filter, plan.FilterAttributes = explainExpression(input.FilterExpression, input.ExpressionAttributeNames)
+        if qb.hydrateFilter != nil {
+            plan.Hydrated = true
+            expr, buildErr := expression.NewBuilder().WithFilter(*qb.hydrateFilter).Build()
+            if buildErr != nil {
+                return QueryPlan{}, fmt.Errorf("failed to build hydration filter: %v", buildErr)
+            }
+            _, hydrated := explainExpression(expr.Filter(), expr.Names())
+            attrs := append(plan.FilterAttributes, hydrated...)
+            slices.Sort(attrs)
+            plan.FilterAttributes = slices.Compact(attrs)
+        }
+        for _, name := range plan.FilterAttributes {
+            if _, ok := qb.Attributes[name]; ok {
+                plan.Demoted = append(plan.Demoted, name)
+            }
+        }
+        plan.FilterHeavy = len(plan.FilterAttributes) > 0 && len(plan.KeyAttributes) < 2
+    }
+    for _, idx := range TableSchema.SecondaryIndexes {
+        if err == nil && idx.Name == plan.IndexName {
+            continue
+        }
+        plan.Rejected = append(plan.Rejected, RejectedIndex{Name: idx.Name, Reason: qb.rejectionReason(idx, plan.IndexName)})
+    }
+    return plan, err
+}
+
+// rejectionReason returns why Build doesn't select the index over the selected one (empty for the table).
+func (qb *QueryBuilder) rejectionReason(idx SecondaryIndex, selected string) string {
+    switch {
+    case qb.tableOnly:
+        return "named queries of the table don't use indexes"
+    case qb.IndexName != "" && idx.Name != qb.IndexName:
+        return fmt.Sprintf("WithIndex selects %s", qb.IndexName)
+    case qb.consistentIndexOnly() && idx.Type == "GSI":
+        return "global secondary indexes don't support consistent reads"
+    }
+    _, ok, err := qb.buildHashKeyCondition(idx)
+    if err != nil {
+        return err.Error()
+    }
+    if !ok {
+        if missing := qb.missingKeyParts(idx.HashKeyParts); len(missing) > 0 {
+            return fmt.Sprintf("no condition on hash key parts %s", strings.Join(missing, ", "))
+        }
+        return fmt.Sprintf("no equality condition on hash key %s", idx.HashKey)
+    }
+    _, ok, err = qb.buildRangeKeyCondition(idx)
+    if err != nil {
+        return err.Error()
+    }
+    if !ok {
+        return fmt.Sprintf("no condition on range key parts %s", strings.Join(qb.missingKeyParts(idx.RangeKeyParts), ", "))
+    }
+    if missing := qb.unprojectedFields(idx); len(missing) > 0 && qb.UnprojectedFilter != UnprojectedHydrate {
+        return fmt.Sprintf("projection %s doesn't include filtered attributes %s", idx.ProjectionType, strings.Join(missing, ", "))
+    }
+    chosen := qb.getIndexByName(selected)
+    switch {
+    case chosen == nil:
+        return "matches the key conditions, but the query failed"
+    case qb.PreferredSortKey != "" && chosen.RangeKey == qb.PreferredSortKey && idx.RangeKey != qb.PreferredSortKey:
+        return fmt.Sprintf("ranked below %s, which has the preferred sort key %s", selected, qb.PreferredSortKey)
+    case qb.calculateIndexParts(*chosen) > qb.calculateIndexParts(idx):
+        return fmt.Sprintf("ranked below %s, which has more composite key parts", selected)
+    }
+    return fmt.Sprintf("ranked below %s", selected)
+}
+
+// missingKeyParts returns the non-constant composite key parts without a key condition.
+func (qb *QueryBuilder) missingKeyParts(parts []CompositeKeyPart) []string {
+    var missing []string
+    for _, part := range parts {
+        if !part.IsConstant && !qb.UsedKeys[part.Value] {
+            missing = append(missing, part.Value)
+        }
+    }
+    return missing
+}
+
+// explainExpression resolves the attribute names of a built expression
+// and returns it with the sorted attributes it references.
+func explainExpression(expr *string, names map[string]string) (string, []string) {
+    var attrs []string
+    resolved := simulatedNamePattern.ReplaceAllStringFunc(aws.ToString(expr), func(name string) string {
+        if attr, ok := names[name]; ok {
+            attrs = append(attrs, attr)
+            return attr
+        }
+        return name
+    })
+    slices.Sort(attrs)
+    return resolved, slices.Compact(attrs)
+}
+`
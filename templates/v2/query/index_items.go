@@ -0,0 +1,85 @@
+package query
+
+// QueryBuilderIndexItemsTemplate provides typed results of indexes which don't project all attributes
+const QueryBuilderIndexItemsTemplate = `
+{{- range .ProjectedIndexes}}
+
+// {{.GoName}}IndexItem is a result of index {{.Name}} ({{.ProjectionType}} projection) holding the
+// attributes it projects: Execute returns SchemaItem with the other attributes zero-valued, see Execute{{.GoName}}Index.
+type {{.GoName}}IndexItem struct {
+{{- range .Attributes}}
+    {{.GoName}} {{.FieldType}} ` + "`{{ToDynamoDBStructTag .}}`" + `
+{{- end}}
+}
+
+// Execute{{.GoName}}Index runs the query on index {{.Name}} and returns its results
+// with the attributes the index projects. The index is selected like with WithIndex, so Build fails
+// if the conditions don't match its keys. Pagination follows Execute, UnprojectedHydrate isn't supported:
+// hydrated hits are table items, use Execute.
+func (qb *QueryBuilder) Execute{{.GoName}}Index(ctx context.Context, client DynamoDBAPI) ([]{{.GoName}}IndexItem, error) {
+    raw, err := qb.WithIndex(Index{{ToSafeName .Name | ToUpperCamelCase}}).queryIndexItems(ctx, client)
+    if err != nil && !errors.Is(err, ErrPartialResult) {
+        return nil, err
+    }
+    var items []{{.GoName}}IndexItem
+    if err := attributevalue.UnmarshalListOfMaps(raw, &items); err != nil {
+        return nil, fmt.Errorf("failed to unmarshal result: %v", err)
+    }
+    return items, err
+}
+{{- end}}
+{{- if .ProjectedIndexes}}
+
+// queryIndexItems runs the query on its index and returns the raw results for Execute<Index>Index.
+// With LimitResults, pages are fetched until enough items are read or MaxPages requests are sent,
+// as in Execute; one request is sent otherwise.
+func (qb *QueryBuilder) queryIndexItems(ctx context.Context, client DynamoDBAPI) ([]map[string]types.AttributeValue, error) {
+    input, err := qb.BuildQuery()
+    if err != nil {
+        return nil, err
+    }
+    if qb.hydrateFilter != nil {
+        return nil, fmt.Errorf("filters on unprojected attributes of index %s need Execute with UnprojectedHydrate", aws.ToString(input.IndexName))
+    }
+    if qb.ResultLimitValue == nil {
+        result, err := qb.query(ctx, client, input)
+        if err != nil {
+            return nil, fmt.Errorf("failed to execute query: %v", err)
+        }
+        return result.Items, nil
+    }
+    limit, maxPages := *qb.ResultLimitValue, qb.maxPages()
+    var raw []map[string]types.AttributeValue
+    resume := input.ExclusiveStartKey
+    finish := func(stop error) ([]map[string]types.AttributeValue, error) {
+        _, err := finishPages(len(raw), resume, stop)
+        return raw, err
+    }
+    for page := 0; len(raw) < limit; page++ {
+        if page >= maxPages {
+            return finish(ErrPageCapReached)
+        }
+        result, err := qb.query(ctx, client, input)
+        if err != nil {
+            if ctx.Err() != nil {
+                return finish(ctx.Err())
+            }
+            return nil, fmt.Errorf("failed to execute query: %v", err)
+        }
+        for _, item := range result.Items {
+            if len(raw) >= limit {
+                return raw, nil
+            }
+            raw = append(raw, item)
+            resume = itemKey(item, aws.ToString(input.IndexName))
+        }
+        resume = result.LastEvaluatedKey
+        if len(resume) == 0 {
+            break
+        }
+        input.ExclusiveStartKey = resume
+    }
+    return raw, nil
+}
+{{- end}}
+`
@@ -0,0 +1,207 @@
+package query
+
+// QueryBuilderJSONTemplate provides JSON serialization of QueryBuilder state for caching and replay
+const QueryBuilderJSONTemplate = `
+// queryBuilderState is the JSON form of a QueryBuilder: the conditions in the order
+// they were added plus index preference and pagination settings.
+type queryBuilderState struct {
+    KeyConditions     []Condition                    ` + "`json:\"key_conditions,omitempty\"`" + `
+    IndexKeys         []indexKeyCondition            ` + "`json:\"index_keys,omitempty\"`" + `
+    Filters           []Condition                    ` + "`json:\"filters,omitempty\"`" + `
+    IndexName         string                         ` + "`json:\"index_name,omitempty\"`" + `
+    TableOnly         bool                           ` + "`json:\"table_only,omitempty\"`" + `
+    ConsistentRead    bool                           ` + "`json:\"consistent_read,omitempty\"`" + `
+    StablePlaceholders bool                          ` + "`json:\"stable_placeholders,omitempty\"`" + `
+    PreferredSortKey  string                         ` + "`json:\"preferred_sort_key,omitempty\"`" + `
+    SortDescending    bool                           ` + "`json:\"sort_descending,omitempty\"`" + `
+    UnprojectedFilter UnprojectedFilterPolicy        ` + "`json:\"unprojected_filter,omitempty\"`" + `
+    Limit             *int                           ` + "`json:\"limit,omitempty\"`" + `
+    PageSize          *int                           ` + "`json:\"page_size,omitempty\"`" + `
+    LimitResults      *int                           ` + "`json:\"limit_results,omitempty\"`" + `
+    MaxItems          *int                           ` + "`json:\"max_items,omitempty\"`" + `
+    MaxPages          int                            ` + "`json:\"max_pages,omitempty\"`" + `
+    StartKey          map[string]keyAttributeValue   ` + "`json:\"start_key,omitempty\"`" + `
+}
+
+// MarshalJSON serializes the query: key conditions, filters, index preference and pagination,
+// so a prepared query can be cached, logged or sent to another service and replayed with UnmarshalJSON.
+// Example:
+//   data, err := json.Marshal(NewQueryBuilder().With({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).Limit(10))
+//   var qb QueryBuilder
+//   err = json.Unmarshal(data, &qb)
+//   items, err := qb.Execute(ctx, client)
+func (qb *QueryBuilder) MarshalJSON() ([]byte, error) {
+    startKey, err := encodeKeyAttributes(qb.ExclusiveStartKey)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(queryBuilderState{
+        KeyConditions:     qb.AppliedKeyConditions,
+        IndexKeys:         qb.indexKeys,
+        Filters:           qb.AppliedFilters,
+        IndexName:         qb.IndexName,
+        TableOnly:         qb.tableOnly,
+        ConsistentRead:    qb.ConsistentRead,
+        StablePlaceholders: qb.StablePlaceholders,
+        PreferredSortKey:  qb.PreferredSortKey,
+        SortDescending:    qb.SortDescending,
+        UnprojectedFilter: qb.UnprojectedFilter,
+        Limit:             qb.LimitValue,
+        PageSize:          qb.PageSizeValue,
+        LimitResults:      qb.ResultLimitValue,
+        MaxItems:          qb.MaxItemsValue,
+        MaxPages:          qb.MaxPagesValue,
+        StartKey:          startKey,
+    })
+}
+
+// UnmarshalJSON replaces the query with the serialized one. Conditions are replayed through
+// With, WithIndex*Key and Filter, so they are validated against the schema again;
+// values are restored to the Go types of their attributes (numbers, binaries, booleans).
+func (qb *QueryBuilder) UnmarshalJSON(data []byte) error {
+    var state queryBuilderState
+    decoder := json.NewDecoder(bytes.NewReader(data))
+    decoder.UseNumber()
+    if err := decoder.Decode(&state); err != nil {
+        return fmt.Errorf("failed to decode query: %v", err)
+    }
+    startKey, err := decodeKeyAttributes(state.StartKey)
+    if err != nil {
+        return err
+    }
+
+    *qb = *NewQueryBuilder()
+    for _, c := range state.KeyConditions {
+        values, err := restoreConditionValues(c.Field, c.Values)
+        if err != nil {
+            return err
+        }
+        qb.With(c.Field, c.Operator, values...)
+    }
+    {{- if IsALL .Mode}}
+    for _, c := range state.IndexKeys {
+        if err := qb.replayIndexKey(c); err != nil {
+            return err
+        }
+    }
+    {{- else}}
+    if len(state.IndexKeys) > 0 {
+        return fmt.Errorf("index key conditions require code generated in ALL mode")
+    }
+    {{- end}}
+    for _, c := range state.Filters {
+        values, err := restoreConditionValues(c.Field, c.Values)
+        if err != nil {
+            return err
+        }
+        qb.Filter(c.Field, c.Operator, values...)
+    }
+    if len(qb.AppliedKeyConditions)+len(qb.indexKeys)+len(qb.AppliedFilters) != len(state.KeyConditions)+len(state.IndexKeys)+len(state.Filters) {
+        return fmt.Errorf("query conditions don't match the schema of table %s", TableSchema.TableName)
+    }
+
+    qb.IndexName = state.IndexName
+    qb.tableOnly = state.TableOnly
+    qb.ConsistentRead = state.ConsistentRead
+    qb.StablePlaceholders = state.StablePlaceholders
+    qb.PreferredSortKey = state.PreferredSortKey
+    qb.SortDescending = state.SortDescending
+    qb.UnprojectedFilter = state.UnprojectedFilter
+    qb.LimitValue = state.Limit
+    qb.PageSizeValue = state.PageSize
+    qb.ResultLimitValue = state.LimitResults
+    qb.MaxItemsValue = state.MaxItems
+    qb.MaxPagesValue = state.MaxPages
+    qb.ExclusiveStartKey = startKey
+    return nil
+}
+{{- if IsALL .Mode}}
+
+// replayIndexKey applies a recorded WithIndex*Key call.
+func (qb *QueryBuilder) replayIndexKey(c indexKeyCondition) error {
+    index := qb.getIndexByName(c.Index)
+    if index == nil {
+        return fmt.Errorf("unknown index '%s'", c.Index)
+    }
+    key, parts := index.HashKey, index.HashKeyParts
+    if c.Range {
+        key, parts = index.RangeKey, index.RangeKeyParts
+    }
+    values := make([]any, len(c.Values))
+    for i, v := range c.Values {
+        field := key
+        if nonConstant := qb.getNonConstantParts(parts); i < len(nonConstant) {
+            field = nonConstant[i].Value
+        }
+        value, err := restoreValue(field, v)
+        if err != nil {
+            return err
+        }
+        values[i] = value
+    }
+
+    switch {
+    case !c.Range && c.Operator == EQ:
+        qb.WithIndexHashKey(c.Index, values...)
+    case c.Range && c.Operator == EQ:
+        qb.WithIndexRangeKey(c.Index, values...)
+    case c.Range && c.Operator == BETWEEN && len(values) == 2:
+        qb.WithIndexRangeKeyBetween(c.Index, values[0], values[1])
+    case c.Range && c.Operator == GT && len(values) == 1:
+        qb.WithIndexRangeKeyGT(c.Index, values[0])
+    case c.Range && c.Operator == LT && len(values) == 1:
+        qb.WithIndexRangeKeyLT(c.Index, values[0])
+    case c.Range && c.Operator == GTE && len(values) == 1:
+        qb.WithIndexRangeKeyGTE(c.Index, values[0])
+    case c.Range && c.Operator == LTE && len(values) == 1:
+        qb.WithIndexRangeKeyLTE(c.Index, values[0])
+    case c.Range && c.Operator == BEGINS_WITH && len(values) == 1:
+        qb.WithIndexRangeKeyBeginsWith(c.Index, fmt.Sprint(values[0]))
+    default:
+        return fmt.Errorf("invalid key condition %s of index '%s'", c.Operator, c.Index)
+    }
+    return nil
+}
+{{- end}}
+
+// restoreConditionValues restores the Go types of decoded condition values of the field.
+func restoreConditionValues(field string, values []any) ([]any, error) {
+    restored := make([]any, len(values))
+    for i, v := range values {
+        value, err := restoreValue(field, v)
+        if err != nil {
+            return nil, err
+        }
+        restored[i] = value
+    }
+    return restored, nil
+}
+
+// restoreValue converts a value decoded from JSON (with UseNumber) to the Go type of the field:
+// numbers of N and NS fields, base64 strings of B and BS fields and booleans.
+// Values of unknown fields (e.g. composite index keys) are kept.
+func restoreValue(field string, v any) (any, error) {
+    info, ok := TableSchema.FieldsMap[field]
+    if !ok {
+        return v, nil
+    }
+    switch info.DynamoType {
+    case "N", "NS":
+        n, ok := v.(json.Number)
+        if !ok {
+            return nil, fmt.Errorf("value of '%s' must be a number, got %T", field, v)
+        }
+        if i, err := n.Int64(); err == nil {
+            return i, nil
+        }
+        return n.Float64()
+    case "B", "BS":
+        s, ok := v.(string)
+        if !ok {
+            return nil, fmt.Errorf("value of '%s' must be base64 binary, got %T", field, v)
+        }
+        return base64.StdEncoding.DecodeString(s)
+    }
+    return v, nil
+}
+`
@@ -0,0 +1,50 @@
+package query
+
+// NamedQueriesTemplate provides typed functions of the named queries declared in the schema
+const NamedQueriesTemplate = `
+{{- if .NamedQueries}}
+// useTable restricts the query to the table, so no secondary index is selected.
+func (qb *QueryBuilder) useTable() *QueryBuilder {
+    qb.tableOnly = true
+    return qb
+}
+
+// withKeyCondition sets a range key condition of the table or an index by attribute name.
+func (qb *QueryBuilder) withKeyCondition(field string, op OperatorType, values ...any) *QueryBuilder {
+    {{- if $.NormalizedAttributes}}
+    values = normalizeKeyValues(field, values)
+    {{- end}}
+    key := expression.Key(field)
+    switch op {
+    case EQ:
+        qb.KeyConditions[field] = key.Equal(expression.Value(values[0]))
+    case GT:
+        qb.KeyConditions[field] = key.GreaterThan(expression.Value(values[0]))
+    case GTE:
+        qb.KeyConditions[field] = key.GreaterThanEqual(expression.Value(values[0]))
+    case LT:
+        qb.KeyConditions[field] = key.LessThan(expression.Value(values[0]))
+    case LTE:
+        qb.KeyConditions[field] = key.LessThanEqual(expression.Value(values[0]))
+    case BETWEEN:
+        qb.KeyConditions[field] = key.Between(expression.Value(values[0]), expression.Value(values[1]))
+    case BEGINS_WITH:
+        qb.KeyConditions[field] = key.BeginsWith(fmt.Sprint(values[0]))
+    default:
+        return qb
+    }
+    qb.UsedKeys[field] = true
+    qb.Attributes[field] = values[0]
+    return qb
+}
+{{- range .NamedQueries}}
+
+// {{.FuncName}} builds the "{{.Name}}" query declared in the schema{{if .Description}}: {{.Description}}{{end}}.
+// Execute it with Execute, ExecuteAll, ExecutePaginated, Iterate, Items or Count.
+func {{.FuncName}}({{.Params}}) *QueryBuilder {
+    return {{range $i, $call := .Calls}}{{if $i}}.
+        {{end}}{{$call}}{{end}}
+}
+{{- end}}
+{{- end}}
+`
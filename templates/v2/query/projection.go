@@ -0,0 +1,154 @@
+package query
+
+// QueryBuilderProjectionTemplate provides detection of filters on attributes not projected into a GSI
+const QueryBuilderProjectionTemplate = `
+// UnprojectedFilterPolicy controls what a query does when a filter references attributes
+// a GSI doesn't project (KEYS_ONLY or INCLUDE projection). DynamoDB evaluates such filters
+// against missing attributes, so they silently drop matching items.
+// LSIs are not affected: DynamoDB reads non-projected attributes from the table.
+type UnprojectedFilterPolicy int
+
+const (
+    // UnprojectedSwitchIndex skips indexes which don't project filtered attributes and
+    // falls back to the next matching index or the table (default).
+    UnprojectedSwitchIndex UnprojectedFilterPolicy = iota
+
+    // UnprojectedHydrate queries the index with the filters it can evaluate, then re-reads
+    // every hit from the table applying the remaining filters (one extra Query per hit).
+    // Applies to Execute only.
+    UnprojectedHydrate
+
+    // UnprojectedFail returns an error naming the index and the non-projected attributes.
+    UnprojectedFail
+)
+
+// OnUnprojectedFilter sets the policy for filters on attributes not projected into the selected GSI.
+// Example:
+//   items, err := NewQueryBuilder().
+//       With(ColumnStatus, EQ, "active").
+//       Filter(ColumnTotal, GT, 100).
+//       OnUnprojectedFilter(UnprojectedHydrate).
+//       Execute(ctx, client)
+func (qb *QueryBuilder) OnUnprojectedFilter(policy UnprojectedFilterPolicy) *QueryBuilder {
+    qb.UnprojectedFilter = policy
+    return qb
+}
+
+// isProjected reports whether a filter on attrName can be evaluated on the index.
+func isProjected(attrName string, idx SecondaryIndex) bool {
+    if idx.Type == "LSI" || idx.ProjectionType == "ALL" {
+        return true
+    }
+    switch attrName {
+    case TableSchema.HashKey, TableSchema.RangeKey, idx.HashKey, idx.RangeKey:
+        return true
+    }
+    if idx.ProjectionType == "INCLUDE" {
+        for _, nk := range idx.NonKeyAttributes {
+            if nk == attrName {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// unprojectedFields returns the filtered attributes the index doesn't project, sorted.
+func (qb *QueryBuilder) unprojectedFields(idx SecondaryIndex) []string {
+    seen := make(map[string]bool)
+    for _, field := range qb.FilterFields {
+        if !isProjected(field, idx) {
+            seen[field] = true
+        }
+    }
+    for attrName := range qb.Attributes {
+        if !qb.isPartOfIndexKey(attrName, idx) && !isProjected(attrName, idx) {
+            seen[attrName] = true
+        }
+    }
+    fields := make([]string, 0, len(seen))
+    for field := range seen {
+        fields = append(fields, field)
+    }
+    sort.Strings(fields)
+    return fields
+}
+
+// splitFilterConditions splits the filter conditions for the index into the ones it can evaluate
+// and the ones referencing attributes it doesn't project.
+func (qb *QueryBuilder) splitFilterConditions(idx SecondaryIndex) (*expression.ConditionBuilder, *expression.ConditionBuilder) {
+    var projected, residual []expression.ConditionBuilder
+    for i, cond := range qb.FilterConditions {
+        if i < len(qb.FilterFields) && !isProjected(qb.FilterFields[i], idx) {
+            residual = append(residual, cond)
+            continue
+        }
+        projected = append(projected, cond)
+    }
+    for attrName, value := range qb.Attributes {
+        if qb.isPartOfIndexKey(attrName, idx) {
+            continue
+        }
+        cond := expression.Name(attrName).Equal(expression.Value(value))
+        if !isProjected(attrName, idx) {
+            residual = append(residual, cond)
+            continue
+        }
+        projected = append(projected, cond)
+    }
+    return combineConditions(projected), combineConditions(residual)
+}
+
+// combineConditions joins conditions with AND, nil if there are none.
+func combineConditions(conditions []expression.ConditionBuilder) *expression.ConditionBuilder {
+    if len(conditions) == 0 {
+        return nil
+    }
+    combined := conditions[0]
+    for _, cond := range conditions[1:] {
+        combined = combined.And(cond)
+    }
+    return &combined
+}
+
+// hydrate re-reads index hits from the table and keeps the items matching the filters
+// the index couldn't evaluate.
+func (qb *QueryBuilder) hydrate(ctx context.Context, client DynamoDBAPI, hits []map[string]types.AttributeValue) ([]SchemaItem, error) {
+    filterExpr, err := expression.NewBuilder().WithFilter(*qb.hydrateFilter).Build()
+    if err != nil {
+        return nil, fmt.Errorf("failed to build hydration filter: %v", err)
+    }
+    keyCondition := "#hydrate_hk = :hydrate_hk"
+    if TableSchema.RangeKey != "" {
+        keyCondition += " AND #hydrate_rk = :hydrate_rk"
+    }
+
+    items := make([]SchemaItem, 0, len(hits))
+    for _, hit := range hits {
+        names := map[string]string{"#hydrate_hk": TableSchema.HashKey}
+        values := map[string]types.AttributeValue{":hydrate_hk": hit[TableSchema.HashKey]}
+        if TableSchema.RangeKey != "" {
+            names["#hydrate_rk"] = TableSchema.RangeKey
+            values[":hydrate_rk"] = hit[TableSchema.RangeKey]
+        }
+        names, values = mergeExpressionAttributes(names, values, filterExpr.Names(), filterExpr.Values())
+
+        result, err := client.Query(ctx, &dynamodb.QueryInput{
+            TableName:                 aws.String(TableName),
+            KeyConditionExpression:    aws.String(keyCondition),
+            FilterExpression:          filterExpr.Filter(),
+            ExpressionAttributeNames:  names,
+            ExpressionAttributeValues: values,
+        })
+        if err != nil {
+            return nil, fmt.Errorf("failed to hydrate item from table: %v", err)
+        }
+        var page []SchemaItem
+        if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+            return nil, fmt.Errorf("failed to unmarshal hydrated item: %v", err)
+        }
+        items = append(items, page...)
+    }
+    return items, nil
+}
+`
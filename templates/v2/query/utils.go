@@ -13,10 +13,13 @@ func (qb *QueryBuilder) hasAllKeys(parts []CompositeKeyPart) bool {
 }
 
 // buildCompositeKeyCondition creates a key condition for composite keys.
-func (qb *QueryBuilder) buildCompositeKeyCondition(parts []CompositeKeyPart) expression.KeyConditionBuilder {
+func (qb *QueryBuilder) buildCompositeKeyCondition(parts []CompositeKeyPart) (expression.KeyConditionBuilder, error) {
 	compositeKeyName := qb.getCompositeKeyName(parts)
-	compositeValue := qb.buildCompositeKeyValue(parts)
-	return expression.Key(compositeKeyName).Equal(expression.Value(compositeValue))
+	compositeValue, err := qb.buildCompositeKeyValue(parts)
+	if err != nil {
+		return expression.KeyConditionBuilder{}, err
+	}
+	return expression.Key(compositeKeyName).Equal(expression.Value(compositeValue)), nil
 }
 
 // getCompositeKeyName generates the attribute name for a composite key.
@@ -36,19 +39,31 @@ func (qb *QueryBuilder) getCompositeKeyName(parts []CompositeKeyPart) string {
 }
 
 // buildCompositeKeyValue constructs the actual value for a composite key.
-func (qb *QueryBuilder) buildCompositeKeyValue(parts []CompositeKeyPart) string {
+{{- if not .CompositeKeys.IsEscaped}}
+// Returns an error if a value contains the separator, as BuildCompositeKey does.
+{{- end}}
+func (qb *QueryBuilder) buildCompositeKeyValue(parts []CompositeKeyPart) (string, error) {
 	if len(parts) == 0 {
-		return ""
+		return "", nil
 	}
+	sep := compositeKeySeparator(parts)
 	values := make([]string, len(parts))
 	for i, part := range parts {
 		if part.IsConstant {
 			values[i] = part.Value
 		} else {
-			values[i] = qb.formatAttributeValue(qb.Attributes[part.Value])
+			{{- if .CompositeKeys.IsEscaped}}
+			values[i] = escapeCompositeValue(qb.formatAttributeValue(qb.Attributes[part.Value]), sep)
+			{{- else}}
+			v := qb.formatAttributeValue(qb.Attributes[part.Value])
+			if strings.Contains(v, sep) {
+				return "", fmt.Errorf("value for composite key part %s contains separator %q", part.Value, sep)
+			}
+			values[i] = v
+			{{- end}}
 		}
 	}
-	return strings.Join(values, "#")
+	return strings.Join(values, sep), nil
 }
 
 // formatAttributeValue converts any Go value to its string representation for composite keys.
@@ -6,12 +6,24 @@ const ScanBuilderBuildTemplate = `
 // Combines filter conditions, projection attributes, pagination, and parallel scan settings.
 // Handles expression building and attribute mapping automatically.
 func (sb *ScanBuilder) BuildScan() (*dynamodb.ScanInput, error) {
+    if err := sb.checkCursor(sb.IndexName); err != nil {
+        return nil, err
+    }
+    if sb.filterErr != nil {
+        return nil, sb.filterErr
+    }
+    if err := sb.validateConsistentRead(); err != nil {
+        return nil, err
+    }
     input := &dynamodb.ScanInput{
         TableName: aws.String(TableName),
     }
     if sb.IndexName != "" {
         input.IndexName = aws.String(sb.IndexName)
     }
+    if sb.ConsistentRead {
+        input.ConsistentRead = aws.Bool(true)
+    }
     var exprBuilder expression.Builder
     hasExpression := false
     
@@ -52,10 +64,11 @@ func (sb *ScanBuilder) BuildScan() (*dynamodb.ScanInput, error) {
         if expr.Values() != nil {
             input.ExpressionAttributeValues = expr.Values()
         }
+        if sb.StablePlaceholders {
+            input.ExpressionAttributeNames, input.ExpressionAttributeValues = stablePlaceholders(input.ExpressionAttributeNames, input.ExpressionAttributeValues, input.FilterExpression, input.ProjectionExpression)
+        }
     }
-    if sb.LimitValue != nil {
-        input.Limit = aws.Int32(int32(*sb.LimitValue))
-    }
+    input.Limit = sb.requestLimit()
     if sb.ExclusiveStartKey != nil {
         input.ExclusiveStartKey = sb.ExclusiveStartKey
     }
@@ -66,10 +79,35 @@ func (sb *ScanBuilder) BuildScan() (*dynamodb.ScanInput, error) {
     return input, nil
 }
 
+// validateConsistentRead rejects consistent reads of a global secondary index.
+func (sb *ScanBuilder) validateConsistentRead() error {
+    if !sb.ConsistentRead || sb.IndexName == "" {
+        return nil
+    }
+    if info := GetIndexInfo(sb.IndexName); info != nil && info.Type == "GSI" {
+        return fmt.Errorf("consistent reads are not supported on global secondary index %s", sb.IndexName)
+    }
+    return nil
+}
+
 // Execute runs the scan against DynamoDB and returns strongly-typed results.
 // Handles the complete scan lifecycle: build input, execute, unmarshal results.
 // Returns all items that match the filter conditions as SchemaItem structs.
-func (sb *ScanBuilder) Execute(ctx context.Context, client *dynamodb.Client) ([]SchemaItem, error) {
+// With LimitResults, pages are fetched until enough items pass the filters (ExecuteWithCursor
+// also returns the cursor resuming after them); if ctx ends or MaxPages requests are sent before,
+// the items read so far are returned with a *PartialResultError.
+// With FilterHashKeyIn, one Query per hash key replaces the scan.
+func (sb *ScanBuilder) Execute(ctx context.Context, client DynamoDBAPI) ([]SchemaItem, error) {
+    if sb.HashKeyValues != nil {
+        if sb.ResultLimitValue != nil {
+            return sb.executeFanOut(ctx, client, *sb.ResultLimitValue, sb.maxPages())
+        }
+        return sb.executeFanOut(ctx, client, -1, 1)
+    }
+    if sb.ResultLimitValue != nil {
+        items, _, err := sb.executePages(ctx, client, *sb.ResultLimitValue, sb.maxPages())
+        return items, err
+    }
     input, err := sb.BuildScan()
     if err != nil {
         return nil, err
@@ -85,4 +123,258 @@ func (sb *ScanBuilder) Execute(ctx context.Context, client *dynamodb.Client) ([]
     }
     return items, nil
 }
+
+// ExecuteAll runs the scan following LastEvaluatedKey until every page is read
+// and returns at most MaxItems (or Limit) items, all matching items if neither is set.
+// PageSize controls the number of items evaluated per request.
+// ExecuteWithCursor also returns the cursor resuming after the returned items; if ctx ends before the last page,
+// the items read so far are returned with a *PartialResultError holding the cursor to resume from.
+// Neither applies with FilterHashKeyIn.
+func (sb *ScanBuilder) ExecuteAll(ctx context.Context, client DynamoDBAPI) ([]SchemaItem, error) {
+    if sb.HashKeyValues != nil {
+        return sb.executeFanOut(ctx, client, sb.totalLimit(), -1)
+    }
+    items, _, err := sb.executePages(ctx, client, sb.totalLimit(), -1)
+    return items, err
+}
+
+// ExecuteWithCursor reads like Execute with LimitResults, like ExecuteAll without it, and returns
+// the items with the cursor resuming after them, empty if all matching items were read.
+// The cursor is not kept by the builder, so it can be reused or shared between reads.
+// Example:
+//   page, err := NewScanBuilder().LimitResults(100).StartFromCursor(cursor).ExecuteWithCursor(ctx, client)
+//   if page.HasMore {
+//       cursor = page.NextCursor
+//   }
+func (sb *ScanBuilder) ExecuteWithCursor(ctx context.Context, client DynamoDBAPI) (Page, error) {
+    if sb.HashKeyValues != nil {
+        return Page{}, fmt.Errorf("FilterHashKeyIn is not supported by ExecuteWithCursor, use Execute or ExecuteAll")
+    }
+    limit, maxPages := sb.totalLimit(), -1
+    if sb.ResultLimitValue != nil {
+        limit, maxPages = *sb.ResultLimitValue, sb.maxPages()
+    }
+    items, cursor, err := sb.executePages(ctx, client, limit, maxPages)
+    return Page{Items: items, NextCursor: cursor, HasMore: cursor != ""}, err
+}
+
+// ExecutePaginated runs one request of the scan and returns its page with the cursor of the next one.
+// PageSize (or Limit) sets the number of items evaluated per page; with filters a page may hold
+// fewer items, even none, while HasMore is true.
+// Example:
+//   page, err := NewScanBuilder().PageSize(50).StartFromCursor(cursor).ExecutePaginated(ctx, client)
+//   if page.HasMore {
+//       cursor = page.NextCursor
+//   }
+func (sb *ScanBuilder) ExecutePaginated(ctx context.Context, client DynamoDBAPI) (Page, error) {
+    if sb.HashKeyValues != nil {
+        return Page{}, fmt.Errorf("FilterHashKeyIn is not supported by ExecutePaginated, use Execute or ExecuteAll")
+    }
+    input, err := sb.BuildScan()
+    if err != nil {
+        return Page{}, err
+    }
+    result, err := client.Scan(ctx, input)
+    if err != nil {
+        return Page{}, fmt.Errorf("failed to execute scan: %v", err)
+    }
+    var items []SchemaItem
+    if err := attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+        return Page{}, fmt.Errorf("failed to unmarshal scan result: %v", err)
+    }
+    cursor, err := EncodeCursor(result.LastEvaluatedKey)
+    if err != nil {
+        return Page{}, err
+    }
+    return Page{Items: items, NextCursor: cursor, HasMore: cursor != ""}, nil
+}
+
+// Iterate returns a PageIterator over the scan results which prefetches one page ahead.
+// PageSize controls the number of items evaluated per request; StartFrom sets the first page.
+func (sb *ScanBuilder) Iterate(ctx context.Context, client DynamoDBAPI) (*PageIterator, error) {
+    if sb.HashKeyValues != nil {
+        return nil, fmt.Errorf("FilterHashKeyIn is not supported by Iterate, use Execute or ExecuteAll")
+    }
+    input, err := sb.BuildScan()
+    if err != nil {
+        return nil, err
+    }
+    fetch := func(ctx context.Context, startKey map[string]types.AttributeValue) ([]SchemaItem, map[string]types.AttributeValue, error) {
+        pageInput := *input
+        pageInput.ExclusiveStartKey = startKey
+        result, err := client.Scan(ctx, &pageInput)
+        if err != nil {
+            return nil, nil, fmt.Errorf("failed to execute scan: %v", err)
+        }
+        var items []SchemaItem
+        if err := attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+            return nil, nil, fmt.Errorf("failed to unmarshal scan result: %v", err)
+        }
+        return items, result.LastEvaluatedKey, nil
+    }
+    return newPageIterator(ctx, input.ExclusiveStartKey, fetch), nil
+}
+
+// Items returns an iterator over the scan results which reads pages lazily, one page ahead,
+// so large results are processed without buffering them. Stopping the loop stops reading;
+// canceling ctx ends the loop with ctx.Err(), so a truncated read is never mistaken for a complete one.
+// Example:
+//   for item, err := range NewScanBuilder().PageSize(100).Items(ctx, client) {
+//       if err != nil {
+//           return err
+//       }
+//       process(item)
+//   }
+func (sb *ScanBuilder) Items(ctx context.Context, client DynamoDBAPI) iter.Seq2[SchemaItem, error] {
+    return iterateItems(sb.Iterate(ctx, client))
+}
+
+// Count returns the number of items matching the filters without transferring them:
+// requests use Select COUNT and follow LastEvaluatedKey until the table (or segment) is exhausted.
+// Projection is ignored.
+// Example:
+//   n, err := NewScanBuilder().Filter({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).Count(ctx, client)
+func (sb *ScanBuilder) Count(ctx context.Context, client DynamoDBAPI) (int64, error) {
+    count, _, err := sb.CountScanned(ctx, client)
+    return count, err
+}
+
+// CountScanned is Count which also returns the number of items evaluated before filters,
+// the read cost of the scan.
+func (sb *ScanBuilder) CountScanned(ctx context.Context, client DynamoDBAPI) (count, scanned int64, err error) {
+    if sb.HashKeyValues != nil {
+        return 0, 0, fmt.Errorf("FilterHashKeyIn is not supported by Count, use Execute or ExecuteAll")
+    }
+    counter := *sb
+    counter.ProjectionAttributes = nil
+    input, err := counter.BuildScan()
+    if err != nil {
+        return 0, 0, err
+    }
+    input.Select = types.SelectCount
+    for {
+        result, err := client.Scan(ctx, input)
+        if err != nil {
+            return 0, 0, fmt.Errorf("failed to execute scan: %v", err)
+        }
+        count += int64(result.Count)
+        scanned += int64(result.ScannedCount)
+        if len(result.LastEvaluatedKey) == 0 {
+            return count, scanned, nil
+        }
+        input.ExclusiveStartKey = result.LastEvaluatedKey
+    }
+}
+
+// DefaultStreamBuffer is the number of items ExecuteStream buffers ahead of the consumer.
+const DefaultStreamBuffer = 1000
+
+// ExecuteStream pages through the scan in the background and sends the items to a channel
+// of DefaultStreamBuffer items: reading pauses while the channel is full, so memory stays
+// bounded however large the table is. Both channels are closed when the scan ends; at most
+// one error is sent, after which no more items follow. Cancel ctx to stop early: ctx.Err()
+// is then sent to errs, so a truncated scan is never mistaken for a complete one.
+// Example:
+//   items, errs := NewScanBuilder().PageSize(500).ExecuteStream(ctx, client)
+//   for item := range items {
+//       process(item)
+//   }
+//   if err := <-errs; err != nil {
+//       return err
+//   }
+func (sb *ScanBuilder) ExecuteStream(ctx context.Context, client DynamoDBAPI) (<-chan SchemaItem, <-chan error) {
+    var (
+        items = make(chan SchemaItem, DefaultStreamBuffer)
+        errs  = make(chan error, 1)
+    )
+    go func() {
+        defer close(errs)
+        defer close(items)
+        for item, err := range sb.Items(ctx, client) {
+            if err != nil {
+                errs <- err
+                return
+            }
+            select {
+            case items <- item:
+            case <-ctx.Done():
+                errs <- ctx.Err()
+                return
+            }
+        }
+    }()
+    return items, errs
+}
+
+// executePages paginates the scan until limit items pass the filters, the table (or segment)
+// is exhausted or maxPages requests are sent. Negative values disable the respective cap.
+// Items of a page past limit are left unread, the returned cursor resumes after the returned ones;
+// reaching maxPages first returns the items with a *PartialResultError wrapping ErrPageCapReached.
+func (sb *ScanBuilder) executePages(ctx context.Context, client DynamoDBAPI, limit, maxPages int) ([]SchemaItem, string, error) {
+    input, added, err := sb.buildPagedScan(limit)
+    if err != nil {
+        return nil, "", err
+    }
+    var items []SchemaItem
+    resume := input.ExclusiveStartKey
+    for page := 0; limit < 0 || len(items) < limit; page++ {
+        if maxPages >= 0 && page >= maxPages {
+            cursor, err := finishPages(len(items), resume, ErrPageCapReached)
+            return items, cursor, err
+        }
+        result, err := client.Scan(ctx, input)
+        if err != nil {
+            if ctx.Err() != nil {
+                cursor, err := finishPages(len(items), resume, ctx.Err())
+                return items, cursor, err
+            }
+            return nil, "", fmt.Errorf("failed to execute scan: %v", err)
+        }
+        for _, raw := range result.Items {
+            if limit >= 0 && len(items) >= limit {
+                cursor, err := finishPages(len(items), resume, nil)
+                return items, cursor, err
+            }
+            resume = itemKey(raw, aws.ToString(input.IndexName))
+            if len(added) > 0 {
+                raw = maps.Clone(raw)
+                for _, name := range added {
+                    delete(raw, name)
+                }
+            }
+            var item SchemaItem
+            if err := attributevalue.UnmarshalMap(raw, &item); err != nil {
+                return nil, "", fmt.Errorf("failed to unmarshal scan result: %v", err)
+            }
+            items = append(items, item)
+        }
+        resume = result.LastEvaluatedKey
+        if len(resume) == 0 {
+            break
+        }
+        input.ExclusiveStartKey = resume
+    }
+    cursor, err := finishPages(len(items), resume, nil)
+    return items, cursor, err
+}
+
+// buildPagedScan builds the input of executePages. A read capped at limit items (not negative) resumes
+// after its last item, so key attributes missing from the projection are added to it: they are returned
+// to be removed from the items again.
+func (sb *ScanBuilder) buildPagedScan(limit int) (*dynamodb.ScanInput, []string, error) {
+    if limit < 0 || len(sb.ProjectionAttributes) == 0 {
+        input, err := sb.BuildScan()
+        return input, nil, err
+    }
+    var added []string
+    for _, name := range keyAttributeNames(sb.IndexName) {
+        if !slices.Contains(sb.ProjectionAttributes, name) {
+            added = append(added, name)
+        }
+    }
+    paged := *sb
+    paged.ProjectionAttributes = append(slices.Clone(sb.ProjectionAttributes), added...)
+    input, err := paged.BuildScan()
+    return input, added, err
+}
 `
@@ -12,6 +12,9 @@ type ScanBuilder struct {
     IndexName            string               // Optional secondary index to scan
     ProjectionAttributes []string             // Specific attributes to return
     ParallelScanConfig   *ParallelScanConfig  // Parallel scan configuration
+    HashKeyValues        []any                // Hash keys queried instead of scanning, see FilterHashKeyIn
+    ConsistentRead       bool                 // Strongly consistent reads, not supported on GSIs
+    StablePlaceholders   bool                 // Attribute-derived expression placeholders, see WithStablePlaceholders
 }
 
 // ParallelScanConfig configures parallel scan operations for improved throughput.
@@ -24,6 +27,10 @@ type ParallelScanConfig struct {
 
 // NewScanBuilder creates a new ScanBuilder instance with initialized mixins.
 // All mixins are properly initialized for immediate use.
+// Example:
+//   items, err := NewScanBuilder().
+//       Filter({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).
+//       Execute(ctx, client)
 func NewScanBuilder() *ScanBuilder {
     return &ScanBuilder{
         FilterMixin:     NewFilterMixin(),
@@ -32,12 +39,42 @@ func NewScanBuilder() *ScanBuilder {
 }
 
 // Limit sets the maximum number of items and returns ScanBuilder for method chaining.
-// Controls the number of items returned in a single scan request.
+// Execute sends it as the request Limit unless PageSize is set;
+// ExecuteAll returns at most limit items across all pages.
 func (sb *ScanBuilder) Limit(limit int) *ScanBuilder {
     sb.PaginationMixin.Limit(limit)
     return sb
 }
 
+// PageSize sets the number of items DynamoDB evaluates per request and returns ScanBuilder for method chaining.
+// Use it to tune capacity consumed per call, independently of Limit.
+func (sb *ScanBuilder) PageSize(size int) *ScanBuilder {
+    sb.PaginationMixin.PageSize(size)
+    return sb
+}
+
+// LimitResults sets the number of items Execute returns after filters are applied.
+// Execute paginates until limit matching items are collected or MaxPages requests are sent.
+func (sb *ScanBuilder) LimitResults(limit int) *ScanBuilder {
+    sb.PaginationMixin.LimitResults(limit)
+    return sb
+}
+
+// MaxItems sets the number of items ExecuteAll returns at most and returns ScanBuilder for method chaining.
+// ExecuteAll follows LastEvaluatedKey until the data is exhausted or maxItems items are collected,
+// the request Limit (Limit or PageSize) stays unchanged.
+func (sb *ScanBuilder) MaxItems(maxItems int) *ScanBuilder {
+    sb.PaginationMixin.MaxItems(maxItems)
+    return sb
+}
+
+// MaxPages caps the number of requests LimitResults may send (DefaultMaxPages by default).
+// Reaching it returns the items read with a *PartialResultError wrapping ErrPageCapReached.
+func (sb *ScanBuilder) MaxPages(pages int) *ScanBuilder {
+    sb.PaginationMixin.MaxPages(pages)
+    return sb
+}
+
 // StartFrom sets the exclusive start key and returns ScanBuilder for method chaining.
 // Use LastEvaluatedKey from previous response for pagination.
 func (sb *ScanBuilder) StartFrom(lastEvaluatedKey map[string]types.AttributeValue) *ScanBuilder {
@@ -45,6 +82,13 @@ func (sb *ScanBuilder) StartFrom(lastEvaluatedKey map[string]types.AttributeValu
     return sb
 }
 
+// StartFromCursor resumes after the page whose NextCursor is token and returns ScanBuilder for method chaining.
+// Cursors come from ExecutePaginated and may be round-tripped through web clients.
+func (sb *ScanBuilder) StartFromCursor(token string) *ScanBuilder {
+    sb.PaginationMixin.StartFromCursor(token)
+    return sb
+}
+
 // WithIndex sets the index name for scanning a secondary index.
 // Allows scanning GSI or LSI instead of the main table.
 // Index must exist and be in ACTIVE state.
@@ -53,6 +97,20 @@ func (sb *ScanBuilder) WithIndex(indexName string) *ScanBuilder {
     return sb
 }
 
+// WithConsistentRead requests strongly consistent reads and returns ScanBuilder for method chaining.
+// GSIs don't support consistent reads: BuildScan fails if WithIndex names one.
+func (sb *ScanBuilder) WithConsistentRead() *ScanBuilder {
+    sb.ConsistentRead = true
+    return sb
+}
+
+// WithStablePlaceholders names the expression placeholders of built requests after their attributes
+// instead of numbering them, so request snapshots don't depend on build order.
+func (sb *ScanBuilder) WithStablePlaceholders() *ScanBuilder {
+    sb.StablePlaceholders = true
+    return sb
+}
+
 // WithProjection sets the projection attributes to return specific fields only.
 // Reduces network traffic and costs by returning only needed attributes.
 // Pass attribute names that should be included in the response.
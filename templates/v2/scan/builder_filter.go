@@ -105,4 +105,64 @@ func (sb *ScanBuilder) FilterNotIn(field string, values ...any) *ScanBuilder {
     sb.FilterMixin.FilterNotIn(field, values...)
     return sb
 }
+
+// FilterSizeEQ adds size(field) = size filter and returns ScanBuilder for method chaining.
+// Size is the length of strings, bytes of binaries and elements of sets, lists and maps.
+func (sb *ScanBuilder) FilterSizeEQ(field string, size int) *ScanBuilder {
+    sb.FilterMixin.FilterSizeEQ(field, size)
+    return sb
+}
+
+// FilterSizeGT adds size(field) > size filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSizeGT(field string, size int) *ScanBuilder {
+    sb.FilterMixin.FilterSizeGT(field, size)
+    return sb
+}
+
+// FilterSizeLT adds size(field) < size filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSizeLT(field string, size int) *ScanBuilder {
+    sb.FilterMixin.FilterSizeLT(field, size)
+    return sb
+}
+
+// FilterSizeGTE adds size(field) >= size filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSizeGTE(field string, size int) *ScanBuilder {
+    sb.FilterMixin.FilterSizeGTE(field, size)
+    return sb
+}
+
+// FilterSizeLTE adds size(field) <= size filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSizeLTE(field string, size int) *ScanBuilder {
+    sb.FilterMixin.FilterSizeLTE(field, size)
+    return sb
+}
+
+// FilterSizeBetween adds size(field) BETWEEN minSize AND maxSize filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSizeBetween(field string, minSize, maxSize int) *ScanBuilder {
+    sb.FilterMixin.FilterSizeBetween(field, minSize, maxSize)
+    return sb
+}
+
+// FilterAttributeType adds attribute_type filter and returns ScanBuilder for method chaining.
+// Useful in data quality scans for items storing field with another type than the schema declares.
+func (sb *ScanBuilder) FilterAttributeType(field string, attrType string) *ScanBuilder {
+    sb.FilterMixin.FilterAttributeType(field, attrType)
+    return sb
+}
+{{- range .InFilterAttributes}}
+
+// Filter{{.GoName}}In filters items whose {{.Name}} is one of the values and returns ScanBuilder for method chaining.
+// Lists longer than 100 values are split into IN comparisons joined with OR,
+// lists longer than MaxInValues fail when the input is built.
+func (sb *ScanBuilder) Filter{{.GoName}}In(values ...{{.GoType}}) *ScanBuilder {
+    sb.FilterMixin.FilterIn(Column{{.GoName}}, filterValues(values)...)
+    return sb
+}
+
+// Filter{{.GoName}}NotIn filters items whose {{.Name}} is none of the values and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) Filter{{.GoName}}NotIn(values ...{{.GoType}}) *ScanBuilder {
+    sb.FilterMixin.FilterNotIn(Column{{.GoName}}, filterValues(values)...)
+    return sb
+}
+{{- end}}
 `
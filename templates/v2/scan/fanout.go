@@ -0,0 +1,161 @@
+package scan
+
+// ScanBuilderFanOutTemplate provides FilterHashKeyIn turning a scan over known hash keys into per-key queries
+const ScanBuilderFanOutTemplate = `
+// hashKeyFanOutConcurrency bounds the number of concurrent per-key queries of FilterHashKeyIn.
+const hashKeyFanOutConcurrency = 8
+
+// FilterHashKeyIn restricts the scan to items whose hash key (of the table, or of the index set
+// by WithIndex) is one of values. Execute, ExecuteAll and LimitResults then send one Query per key
+// instead of scanning, applying the other filters, projection and PageSize to each query.
+// Results are merged in the order of values; duplicate values are queried once.
+// Iterate and ExecuteParallel don't support it and return an error.
+// Example:
+//   items, err := NewScanBuilder().
+//       FilterHashKeyIn({{.ExampleKey .HashKey}}).
+//       Execute(ctx, client)
+func (sb *ScanBuilder) FilterHashKeyIn(values ...any) *ScanBuilder {
+    if sb.HashKeyValues == nil {
+        sb.HashKeyValues = make([]any, 0, len(values))
+    }
+    sb.HashKeyValues = append(sb.HashKeyValues, values...)
+    return sb
+}
+
+// fanOutHashKey returns the hash key attribute queried by FilterHashKeyIn.
+func (sb *ScanBuilder) fanOutHashKey() (string, error) {
+    if sb.IndexName == "" {
+        return TableSchema.HashKey, nil
+    }
+    for _, idx := range TableSchema.SecondaryIndexes {
+        if idx.Name != sb.IndexName {
+            continue
+        }
+        if idx.HashKey == "" {
+            return TableSchema.HashKey, nil
+        }
+        return idx.HashKey, nil
+    }
+    return "", fmt.Errorf("unknown index %s", sb.IndexName)
+}
+
+// buildFanOutQueries creates one QueryInput per distinct hash key value.
+func (sb *ScanBuilder) buildFanOutQueries() ([]*dynamodb.QueryInput, error) {
+    hashKey, err := sb.fanOutHashKey()
+    if err != nil {
+        return nil, err
+    }
+    if err := sb.validateConsistentRead(); err != nil {
+        return nil, err
+    }
+    var (
+        inputs []*dynamodb.QueryInput
+        seen   = make(map[string]bool, len(sb.HashKeyValues))
+    )
+    for _, value := range sb.HashKeyValues {
+        id := fmt.Sprintf("%T:%v", value, value)
+        if seen[id] {
+            continue
+        }
+        seen[id] = true
+
+        exprBuilder := expression.NewBuilder().WithKeyCondition(expression.Key(hashKey).Equal(expression.Value(value)))
+        if filter := combineConditions(sb.FilterConditions); filter != nil {
+            exprBuilder = exprBuilder.WithFilter(*filter)
+        }
+        if len(sb.ProjectionAttributes) > 0 {
+            projection := expression.NamesList(expression.Name(sb.ProjectionAttributes[0]))
+            for _, attr := range sb.ProjectionAttributes[1:] {
+                projection = projection.AddNames(expression.Name(attr))
+            }
+            exprBuilder = exprBuilder.WithProjection(projection)
+        }
+        expr, err := exprBuilder.Build()
+        if err != nil {
+            return nil, fmt.Errorf("failed to build query expression for hash key %v: %v", value, err)
+        }
+        input := &dynamodb.QueryInput{
+            TableName:                 aws.String(TableName),
+            KeyConditionExpression:    expr.KeyCondition(),
+            ExpressionAttributeNames:  expr.Names(),
+            ExpressionAttributeValues: expr.Values(),
+            Limit:                     sb.requestLimit(),
+        }
+        if len(sb.FilterConditions) > 0 {
+            input.FilterExpression = expr.Filter()
+        }
+        if len(sb.ProjectionAttributes) > 0 {
+            input.ProjectionExpression = expr.Projection()
+        }
+        if sb.IndexName != "" {
+            input.IndexName = aws.String(sb.IndexName)
+        }
+        if sb.ConsistentRead {
+            input.ConsistentRead = aws.Bool(true)
+        }
+        if sb.StablePlaceholders {
+            input.ExpressionAttributeNames, input.ExpressionAttributeValues = stablePlaceholders(input.ExpressionAttributeNames, input.ExpressionAttributeValues, input.KeyConditionExpression, input.FilterExpression, input.ProjectionExpression)
+        }
+        inputs = append(inputs, input)
+    }
+    return inputs, nil
+}
+
+// executeFanOut runs the per-key queries concurrently, reading at most maxPages pages
+// (negative for all) of each key, and returns at most limit items (negative for all).
+func (sb *ScanBuilder) executeFanOut(ctx context.Context, client DynamoDBAPI, limit, maxPages int) ([]SchemaItem, error) {
+    inputs, err := sb.buildFanOutQueries()
+    if err != nil {
+        return nil, err
+    }
+    var (
+        results = make([][]SchemaItem, len(inputs))
+        errs    = make([]error, len(inputs))
+        sem     = make(chan struct{}, hashKeyFanOutConcurrency)
+        wg      sync.WaitGroup
+    )
+    for i, input := range inputs {
+        wg.Add(1)
+        go func(i int, input *dynamodb.QueryInput) {
+            defer wg.Done()
+            sem <- struct{}{}
+            defer func() { <-sem }()
+            results[i], errs[i] = queryPages(ctx, client, input, limit, maxPages)
+        }(i, input)
+    }
+    wg.Wait()
+
+    var items []SchemaItem
+    for i := range inputs {
+        if errs[i] != nil {
+            return nil, errs[i]
+        }
+        items = append(items, results[i]...)
+    }
+    if limit >= 0 && len(items) > limit {
+        items = items[:limit]
+    }
+    return items, nil
+}
+
+// queryPages paginates one query until limit items are read or maxPages requests are sent.
+func queryPages(ctx context.Context, client DynamoDBAPI, input *dynamodb.QueryInput, limit, maxPages int) ([]SchemaItem, error) {
+    var items []SchemaItem
+    for page := 0; (maxPages < 0 || page < maxPages) && (limit < 0 || len(items) < limit); page++ {
+        result, err := client.Query(ctx, input)
+        if err != nil {
+            return nil, fmt.Errorf("failed to execute query: %v", err)
+        }
+        var pageItems []SchemaItem
+        if err := attributevalue.UnmarshalListOfMaps(result.Items, &pageItems); err != nil {
+            return nil, fmt.Errorf("failed to unmarshal query result: %v", err)
+        }
+        items = append(items, pageItems...)
+        if len(result.LastEvaluatedKey) == 0 {
+            break
+        }
+        input.ExclusiveStartKey = result.LastEvaluatedKey
+    }
+    return items, nil
+}
+`
@@ -0,0 +1,505 @@
+package scan
+
+// ScanBuilderParallelTemplate provides the parallel scan orchestrator with resumable segment checkpoints
+const ScanBuilderParallelTemplate = `
+// SegmentCheckpoint is the progress of one parallel scan segment.
+// LastEvaluatedKey is the position after the last handled page; Done marks a finished segment.
+// TotalSegments is the split of the table the segment belongs to: a position is only valid
+// within the same split.
+type SegmentCheckpoint struct {
+    Segment          int
+    TotalSegments    int
+    LastEvaluatedKey map[string]types.AttributeValue
+    Done             bool
+}
+
+// CheckpointStore persists segment checkpoints of a parallel scan job,
+// so an interrupted job resumes where it stopped instead of rescanning.
+// Implementations must be safe for concurrent use by segment workers.
+type CheckpointStore interface {
+    // Load returns the saved checkpoints of the job by segment, empty for a new job.
+    Load(ctx context.Context, job string) (map[int]SegmentCheckpoint, error)
+    // Save records the checkpoint of one segment.
+    Save(ctx context.Context, job string, checkpoint SegmentCheckpoint) error
+    // Clear removes all checkpoints of the job once every segment is done.
+    Clear(ctx context.Context, job string) error
+}
+
+// ParallelScanOptions configures ExecuteParallel.
+type ParallelScanOptions struct {
+    TotalSegments int             // Number of segments the table is split into
+    Concurrency   int             // Number of segments scanned at once, TotalSegments if not positive
+    Checkpoints   CheckpointStore // Optional store making the job resumable
+    Job           string          // Job identifier in the checkpoint store, required with Checkpoints
+}
+
+// PageHandler processes one page of items read from a segment.
+type PageHandler func(ctx context.Context, segment int, items []SchemaItem) error
+
+// ExecuteParallel scans the table in opts.TotalSegments segments by a pool of opts.Concurrency workers
+// and calls handle for every page, concurrently from different segments.
+// With a checkpoint store the segment position is saved after each handled page, so a page may be
+// handled again after a crash (at-least-once) but finished pages are never rescanned.
+// Checkpoints are cleared when all segments complete. Checkpoints saved with another TotalSegments
+// are rejected, since segments of another split cover other keys: resume the job with its original
+// TotalSegments or clear it. The first error cancels the other segments; errors of segments which
+// failed before the cancellation are joined into the returned error.
+// Example:
+//   err := NewScanBuilder().ExecuteParallel(ctx, client, ParallelScanOptions{
+//       TotalSegments: 8,
+//       Checkpoints:   NewFileCheckpointStore("/var/lib/scan"),
+//       Job:           "reindex-2024-06",
+//   }, func(ctx context.Context, segment int, items []SchemaItem) error {
+//       return process(items)
+//   })
+func (sb *ScanBuilder) ExecuteParallel(ctx context.Context, client DynamoDBAPI, opts ParallelScanOptions, handle PageHandler) error {
+    if opts.TotalSegments < 1 {
+        return fmt.Errorf("parallel scan needs at least one segment, got %d", opts.TotalSegments)
+    }
+    if sb.HashKeyValues != nil {
+        return fmt.Errorf("FilterHashKeyIn is not supported by ExecuteParallel, use Execute or ExecuteAll")
+    }
+    if opts.Checkpoints != nil && opts.Job == "" {
+        return fmt.Errorf("parallel scan with checkpoints needs a job identifier")
+    }
+    input, err := sb.BuildScan()
+    if err != nil {
+        return err
+    }
+    saved := map[int]SegmentCheckpoint{}
+    if opts.Checkpoints != nil {
+        if saved, err = opts.Checkpoints.Load(ctx, opts.Job); err != nil {
+            return fmt.Errorf("failed to load checkpoints of job %s: %v", opts.Job, err)
+        }
+        for _, checkpoint := range saved {
+            if checkpoint.TotalSegments != opts.TotalSegments {
+                return fmt.Errorf("checkpoints of job %s were saved with %d segments, got %d: resume with the same TotalSegments or clear the job",
+                    opts.Job, checkpoint.TotalSegments, opts.TotalSegments)
+            }
+        }
+    }
+
+    workers := opts.Concurrency
+    if workers <= 0 || workers > opts.TotalSegments {
+        workers = opts.TotalSegments
+    }
+
+    ctx, cancel := context.WithCancel(ctx)
+    defer cancel()
+    var (
+        wg   sync.WaitGroup
+        mu   sync.Mutex
+        errs []error
+        pool = make(chan struct{}, workers)
+    )
+    for segment := 0; segment < opts.TotalSegments; segment++ {
+        checkpoint := saved[segment]
+        if checkpoint.Done {
+            continue
+        }
+        segmentInput := *input
+        segmentInput.Segment = aws.Int32(int32(segment))
+        segmentInput.TotalSegments = aws.Int32(int32(opts.TotalSegments))
+        if checkpoint.LastEvaluatedKey != nil {
+            segmentInput.ExclusiveStartKey = checkpoint.LastEvaluatedKey
+        }
+
+        wg.Add(1)
+        go func(segment int, input *dynamodb.ScanInput) {
+            defer wg.Done()
+            select {
+            case pool <- struct{}{}:
+                defer func() { <-pool }()
+            case <-ctx.Done():
+                return
+            }
+            if err := scanSegment(ctx, client, input, segment, opts, handle); err != nil {
+                mu.Lock()
+                defer mu.Unlock()
+                if len(errs) == 0 || ctx.Err() == nil {
+                    errs = append(errs, err)
+                }
+                cancel()
+            }
+        }(segment, &segmentInput)
+    }
+    wg.Wait()
+    if len(errs) > 0 {
+        return errors.Join(errs...)
+    }
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+    if opts.Checkpoints != nil {
+        if err := opts.Checkpoints.Clear(ctx, opts.Job); err != nil {
+            return fmt.Errorf("failed to clear checkpoints of job %s: %v", opts.Job, err)
+        }
+    }
+    return nil
+}
+
+// ExecuteParallelAll scans the table in totalSegments concurrent segments and returns all items,
+// merged in segment order. Use ExecuteParallel with a handler or ExecuteParallelStream for tables
+// which don't fit in memory.
+// Example:
+//   items, err := NewScanBuilder().Filter({{.ExampleField .HashKey}}, EQ, {{.ExampleKey .HashKey}}).ExecuteParallelAll(ctx, client, 4)
+func (sb *ScanBuilder) ExecuteParallelAll(ctx context.Context, client DynamoDBAPI, totalSegments int) ([]SchemaItem, error) {
+    var (
+        mu       sync.Mutex
+        segments = make(map[int][]SchemaItem)
+    )
+    err := sb.ExecuteParallel(ctx, client, ParallelScanOptions{TotalSegments: totalSegments}, func(_ context.Context, segment int, items []SchemaItem) error {
+        mu.Lock()
+        defer mu.Unlock()
+        segments[segment] = append(segments[segment], items...)
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    var items []SchemaItem
+    for segment := 0; segment < totalSegments; segment++ {
+        items = append(items, segments[segment]...)
+    }
+    return items, nil
+}
+
+// ExecuteParallelStream scans the table in totalSegments concurrent segments and sends the items
+// to a channel of DefaultStreamBuffer items as they are read, in no particular order.
+// Segments pause while the channel is full. Both channels are closed when the scan ends;
+// at most one (joined) error is sent. Cancel ctx to stop early.
+// Example:
+//   items, errs := NewScanBuilder().ExecuteParallelStream(ctx, client, 8)
+//   for item := range items {
+//       process(item)
+//   }
+//   if err := <-errs; err != nil {
+//       return err
+//   }
+func (sb *ScanBuilder) ExecuteParallelStream(ctx context.Context, client DynamoDBAPI, totalSegments int) (<-chan SchemaItem, <-chan error) {
+    var (
+        items = make(chan SchemaItem, DefaultStreamBuffer)
+        errs  = make(chan error, 1)
+    )
+    go func() {
+        defer close(errs)
+        defer close(items)
+        err := sb.ExecuteParallel(ctx, client, ParallelScanOptions{TotalSegments: totalSegments}, func(ctx context.Context, _ int, page []SchemaItem) error {
+            for _, item := range page {
+                select {
+                case items <- item:
+                case <-ctx.Done():
+                    return ctx.Err()
+                }
+            }
+            return nil
+        })
+        if err != nil {
+            errs <- err
+        }
+    }()
+    return items, errs
+}
+
+// scanSegment reads one segment page by page, saving a checkpoint after every handled page.
+func scanSegment(ctx context.Context, client DynamoDBAPI, input *dynamodb.ScanInput, segment int, opts ParallelScanOptions, handle PageHandler) error {
+    for {
+        result, err := client.Scan(ctx, input)
+        if err != nil {
+            return fmt.Errorf("failed to scan segment %d: %v", segment, err)
+        }
+        var items []SchemaItem
+        if err := attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+            return fmt.Errorf("failed to unmarshal segment %d: %v", segment, err)
+        }
+        if err := handle(ctx, segment, items); err != nil {
+            return fmt.Errorf("segment %d: %w", segment, err)
+        }
+
+        done := len(result.LastEvaluatedKey) == 0
+        if opts.Checkpoints != nil {
+            checkpoint := SegmentCheckpoint{
+                Segment:          segment,
+                TotalSegments:    opts.TotalSegments,
+                LastEvaluatedKey: result.LastEvaluatedKey,
+                Done:             done,
+            }
+            if err := opts.Checkpoints.Save(ctx, opts.Job, checkpoint); err != nil {
+                return fmt.Errorf("failed to save checkpoint of segment %d: %v", segment, err)
+            }
+        }
+        if done {
+            return nil
+        }
+        input.ExclusiveStartKey = result.LastEvaluatedKey
+    }
+}
+
+// checkpointKeyValue is the JSON form of a key attribute (keys are always S, N or B).
+type checkpointKeyValue struct {
+    S *string ` + "`json:\"S,omitempty\"`" + `
+    N *string ` + "`json:\"N,omitempty\"`" + `
+    B []byte  ` + "`json:\"B,omitempty\"`" + `
+}
+
+// checkpointRecord is the JSON form of a SegmentCheckpoint.
+type checkpointRecord struct {
+    Segment          int                           ` + "`json:\"segment\"`" + `
+    TotalSegments    int                           ` + "`json:\"total_segments\"`" + `
+    LastEvaluatedKey map[string]checkpointKeyValue ` + "`json:\"last_evaluated_key,omitempty\"`" + `
+    Done             bool                          ` + "`json:\"done\"`" + `
+}
+
+// encodeCheckpoint converts a checkpoint to its JSON form.
+func encodeCheckpoint(checkpoint SegmentCheckpoint) (checkpointRecord, error) {
+    record := checkpointRecord{Segment: checkpoint.Segment, TotalSegments: checkpoint.TotalSegments, Done: checkpoint.Done}
+    if len(checkpoint.LastEvaluatedKey) == 0 {
+        return record, nil
+    }
+    record.LastEvaluatedKey = make(map[string]checkpointKeyValue, len(checkpoint.LastEvaluatedKey))
+    for name, av := range checkpoint.LastEvaluatedKey {
+        switch v := av.(type) {
+        case *types.AttributeValueMemberS:
+            record.LastEvaluatedKey[name] = checkpointKeyValue{S: aws.String(v.Value)}
+        case *types.AttributeValueMemberN:
+            record.LastEvaluatedKey[name] = checkpointKeyValue{N: aws.String(v.Value)}
+        case *types.AttributeValueMemberB:
+            record.LastEvaluatedKey[name] = checkpointKeyValue{B: v.Value}
+        default:
+            return record, fmt.Errorf("unsupported key attribute type %T of %s", av, name)
+        }
+    }
+    return record, nil
+}
+
+// decodeCheckpoint converts a checkpoint from its JSON form.
+func decodeCheckpoint(record checkpointRecord) SegmentCheckpoint {
+    checkpoint := SegmentCheckpoint{Segment: record.Segment, TotalSegments: record.TotalSegments, Done: record.Done}
+    if len(record.LastEvaluatedKey) == 0 {
+        return checkpoint
+    }
+    checkpoint.LastEvaluatedKey = make(map[string]types.AttributeValue, len(record.LastEvaluatedKey))
+    for name, v := range record.LastEvaluatedKey {
+        switch {
+        case v.S != nil:
+            checkpoint.LastEvaluatedKey[name] = &types.AttributeValueMemberS{Value: *v.S}
+        case v.N != nil:
+            checkpoint.LastEvaluatedKey[name] = &types.AttributeValueMemberN{Value: *v.N}
+        default:
+            checkpoint.LastEvaluatedKey[name] = &types.AttributeValueMemberB{Value: v.B}
+        }
+    }
+    return checkpoint
+}
+
+// blobCheckpointStore keeps all checkpoints of a job in one JSON document.
+// Read-modify-write cycles are serialized by the mutex, so a job must run in a single process.
+type blobCheckpointStore struct {
+    mu     sync.Mutex
+    read   func(ctx context.Context, job string) ([]byte, error) // nil data if the job has no document
+    write  func(ctx context.Context, job string, data []byte) error
+    remove func(ctx context.Context, job string) error
+}
+
+// Load implements CheckpointStore.
+func (s *blobCheckpointStore) Load(ctx context.Context, job string) (map[int]SegmentCheckpoint, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    records, err := s.records(ctx, job)
+    if err != nil {
+        return nil, err
+    }
+    checkpoints := make(map[int]SegmentCheckpoint, len(records))
+    for _, record := range records {
+        checkpoints[record.Segment] = decodeCheckpoint(record)
+    }
+    return checkpoints, nil
+}
+
+// Save implements CheckpointStore.
+func (s *blobCheckpointStore) Save(ctx context.Context, job string, checkpoint SegmentCheckpoint) error {
+    record, err := encodeCheckpoint(checkpoint)
+    if err != nil {
+        return err
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    records, err := s.records(ctx, job)
+    if err != nil {
+        return err
+    }
+    records[checkpoint.Segment] = record
+    data, err := json.Marshal(records)
+    if err != nil {
+        return err
+    }
+    return s.write(ctx, job, data)
+}
+
+// Clear implements CheckpointStore.
+func (s *blobCheckpointStore) Clear(ctx context.Context, job string) error {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.remove(ctx, job)
+}
+
+// records reads the job document, segments are keyed by their number.
+func (s *blobCheckpointStore) records(ctx context.Context, job string) (map[int]checkpointRecord, error) {
+    records := make(map[int]checkpointRecord)
+    data, err := s.read(ctx, job)
+    if err != nil || len(data) == 0 {
+        return records, err
+    }
+    if err := json.Unmarshal(data, &records); err != nil {
+        return nil, fmt.Errorf("invalid checkpoint document of job %s: %v", job, err)
+    }
+    return records, nil
+}
+
+// NewFileCheckpointStore returns a CheckpointStore writing one <job>.json file per job into dir.
+func NewFileCheckpointStore(dir string) CheckpointStore {
+    path := func(job string) string {
+        return filepath.Join(dir, job+".json")
+    }
+    return &blobCheckpointStore{
+        read: func(_ context.Context, job string) ([]byte, error) {
+            data, err := os.ReadFile(path(job))
+            if errors.Is(err, os.ErrNotExist) {
+                return nil, nil
+            }
+            return data, err
+        },
+        write: func(_ context.Context, job string, data []byte) error {
+            if err := os.MkdirAll(dir, 0o755); err != nil {
+                return err
+            }
+            tmp := path(job) + ".tmp"
+            if err := os.WriteFile(tmp, data, 0o644); err != nil {
+                return err
+            }
+            return os.Rename(tmp, path(job))
+        },
+        remove: func(_ context.Context, job string) error {
+            if err := os.Remove(path(job)); err != nil && !errors.Is(err, os.ErrNotExist) {
+                return err
+            }
+            return nil
+        },
+    }
+}
+
+// ObjectStorage is the subset of an object store (e.g. S3) used by NewS3CheckpointStore.
+// Wrap *s3.Client with GetObject/PutObject/DeleteObject on a fixed bucket;
+// the generated code doesn't depend on the S3 SDK.
+type ObjectStorage interface {
+    // GetObject returns the object data, nil data without error if the object doesn't exist.
+    GetObject(ctx context.Context, key string) ([]byte, error)
+    PutObject(ctx context.Context, key string, data []byte) error
+    DeleteObject(ctx context.Context, key string) error
+}
+
+// NewS3CheckpointStore returns a CheckpointStore writing one <prefix><job>.json object per job.
+func NewS3CheckpointStore(objects ObjectStorage, prefix string) CheckpointStore {
+    key := func(job string) string {
+        return prefix + job + ".json"
+    }
+    return &blobCheckpointStore{
+        read: func(ctx context.Context, job string) ([]byte, error) {
+            return objects.GetObject(ctx, key(job))
+        },
+        write: func(ctx context.Context, job string, data []byte) error {
+            return objects.PutObject(ctx, key(job), data)
+        },
+        remove: func(ctx context.Context, job string) error {
+            return objects.DeleteObject(ctx, key(job))
+        },
+    }
+}
+
+// DynamoDBCheckpointStore keeps checkpoints in a DynamoDB table with a string hash key "job"
+// and a number range key "segment", one item per segment. Safe across processes.
+type DynamoDBCheckpointStore struct {
+    Client DynamoDBAPI
+    Table  string
+}
+
+// NewDynamoDBCheckpointStore returns a CheckpointStore backed by the given checkpoint table.
+func NewDynamoDBCheckpointStore(client DynamoDBAPI, table string) *DynamoDBCheckpointStore {
+    return &DynamoDBCheckpointStore{Client: client, Table: table}
+}
+
+// Load implements CheckpointStore.
+func (s *DynamoDBCheckpointStore) Load(ctx context.Context, job string) (map[int]SegmentCheckpoint, error) {
+    checkpoints := make(map[int]SegmentCheckpoint)
+    input := &dynamodb.QueryInput{
+        TableName:                 aws.String(s.Table),
+        KeyConditionExpression:    aws.String("#job = :job"),
+        ExpressionAttributeNames:  map[string]string{"#job": "job"},
+        ExpressionAttributeValues: map[string]types.AttributeValue{":job": &types.AttributeValueMemberS{Value: job}},
+        ConsistentRead:            aws.Bool(true),
+    }
+    for {
+        result, err := s.Client.Query(ctx, input)
+        if err != nil {
+            return nil, err
+        }
+        for _, item := range result.Items {
+            data, ok := item["checkpoint"].(*types.AttributeValueMemberS)
+            if !ok {
+                continue
+            }
+            var record checkpointRecord
+            if err := json.Unmarshal([]byte(data.Value), &record); err != nil {
+                return nil, fmt.Errorf("invalid checkpoint of job %s: %v", job, err)
+            }
+            checkpoints[record.Segment] = decodeCheckpoint(record)
+        }
+        if len(result.LastEvaluatedKey) == 0 {
+            return checkpoints, nil
+        }
+        input.ExclusiveStartKey = result.LastEvaluatedKey
+    }
+}
+
+// Save implements CheckpointStore.
+func (s *DynamoDBCheckpointStore) Save(ctx context.Context, job string, checkpoint SegmentCheckpoint) error {
+    record, err := encodeCheckpoint(checkpoint)
+    if err != nil {
+        return err
+    }
+    data, err := json.Marshal(record)
+    if err != nil {
+        return err
+    }
+    _, err = s.Client.PutItem(ctx, &dynamodb.PutItemInput{
+        TableName: aws.String(s.Table),
+        Item: map[string]types.AttributeValue{
+            "job":        &types.AttributeValueMemberS{Value: job},
+            "segment":    &types.AttributeValueMemberN{Value: strconv.Itoa(checkpoint.Segment)},
+            "checkpoint": &types.AttributeValueMemberS{Value: string(data)},
+        },
+    })
+    return err
+}
+
+// Clear implements CheckpointStore.
+func (s *DynamoDBCheckpointStore) Clear(ctx context.Context, job string) error {
+    checkpoints, err := s.Load(ctx, job)
+    if err != nil {
+        return err
+    }
+    for segment := range checkpoints {
+        _, err := s.Client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+            TableName: aws.String(s.Table),
+            Key: map[string]types.AttributeValue{
+                "job":     &types.AttributeValueMemberS{Value: job},
+                "segment": &types.AttributeValueMemberN{Value: strconv.Itoa(segment)},
+            },
+        })
+        if err != nil {
+            return err
+        }
+    }
+    return nil
+}
+`
@@ -2,7 +2,10 @@ package v2
 
 import (
 	"github.com/Mad-Pixels/go-dyno/templates/v2/core"
+	"github.com/Mad-Pixels/go-dyno/templates/v2/doc"
+	"github.com/Mad-Pixels/go-dyno/templates/v2/example"
 	"github.com/Mad-Pixels/go-dyno/templates/v2/generic"
+	"github.com/Mad-Pixels/go-dyno/templates/v2/gotest"
 	"github.com/Mad-Pixels/go-dyno/templates/v2/helpers"
 	"github.com/Mad-Pixels/go-dyno/templates/v2/inputs"
 	"github.com/Mad-Pixels/go-dyno/templates/v2/query"
@@ -21,7 +24,11 @@ package {{.PackageName}}
 
 ` + core.SchemaTemplate + `
 
-` + core.MixinsTemplate + `
+` + core.ClientTemplate + `
+
+` + core.CompositeKeyTemplate + `
+
+` + core.MixinsTemplate + core.PlaceholdersTemplate + core.IteratorTemplate + core.CursorTemplate + `
 {{if IsALL .Mode}}
 ` + core.FilterMixinSugarTemplate + core.KeyConditionMixinSugarTemplate + `
 {{end}}
@@ -30,19 +37,117 @@ package {{.PackageName}}
 {{if IsALL .Mode}}
 ` + query.QueryBuilderWithSugarTemplate + query.QueryBuilderFilterSugarTemplate + `
 {{end}}
-` + query.QueryBuilderBuildTemplate + query.QueryBuilderUtilsTemplate + `
+` + query.QueryBuilderBuildTemplate + query.QueryBuilderIndexItemsTemplate + query.QueryBuilderProjectionTemplate + query.QueryBuilderUtilsTemplate + query.NamedQueriesTemplate + `
+{{if IsALL .Mode}}
+` + query.QueryBuilderExplainTemplate + query.QueryBuilderJSONTemplate + `
+{{end}}
 
+{{if not .NoScan}}
 ` + scan.ScanBuilderTemplate + scan.ScanBuilderFilterTemplate + `
 {{if IsALL .Mode}}
 ` + scan.ScanBuilderFilterSugarTemplate + `
 {{end}}
-` + scan.ScanBuilderBuildTemplate + `
+` + scan.ScanBuilderBuildTemplate + scan.ScanBuilderFanOutTemplate + `
+{{if IsALL .Mode}}
+` + scan.ScanBuilderParallelTemplate + `
+{{end}}
+{{end}}
 
-` + inputs.ItemInputsTemplate + inputs.UpdateInputsTemplate + inputs.DeleteInputsTemplate + inputs.KeyInputsTemplate + `
+` + inputs.ItemInputsTemplate + helpers.HooksTemplate + inputs.UpdateInputsTemplate + inputs.DeleteInputsTemplate + inputs.ConditionInputsTemplate + inputs.TransactInputsTemplate + inputs.KeyInputsTemplate + inputs.GetInputsTemplate + `
+{{if or (IsALL .Mode) .EventLog}}
+` + inputs.TransactionBuilderTemplate + `
+{{end}}
+{{if IsALL .Mode}}
+` + inputs.TransactGetTemplate + `
+{{end}}
 
-` + helpers.AtomicHelpersTemplate + `
+` + helpers.AtomicHelpersTemplate + helpers.ListHelpersTemplate + helpers.BatchHelpersTemplate + helpers.FreshReadHelpersTemplate + helpers.VersionHelpersTemplate + helpers.LeaseHelpersTemplate + helpers.QueueHelpersTemplate + helpers.QuotaHelpersTemplate + helpers.SessionHelpersTemplate + helpers.EventLogHelpersTemplate + helpers.PruneHelpersTemplate + helpers.CompatHelpersTemplate + `
+{{if IsALL .Mode}}
+` + helpers.ClientHelpersTemplate + helpers.HealthHelpersTemplate + helpers.ProvisioningHelpersTemplate + helpers.MergeHelpersTemplate + `
+{{end}}
+{{if .Replicas}}
+` + helpers.ReplicaHelpersTemplate + `
+{{end}}
+{{if .ComputedAttributes}}
+` + helpers.ComputedHelpersTemplate + `
+{{end}}
+{{if .GuardedAttributes}}
+` + helpers.GuardHelpersTemplate + `
+{{end}}
+{{if .EnumAttributes}}
+` + helpers.EnumHelpersTemplate + `
+{{end}}
+{{if .RuleAttributes}}
+` + helpers.RuleHelpersTemplate + `
+{{end}}
+{{if .HasCompositeKeys}}
+` + helpers.CompositeKeyHelpersTemplate + `
+{{end}}
+{{if .DefaultAttributes}}
+` + helpers.DefaultHelpersTemplate + `
+{{end}}
+{{if .NormalizedAttributes}}
+` + helpers.NormalizeHelpersTemplate + `
+{{end}}
+{{if .VersionAttribute}}
+` + helpers.OptimisticLockHelpersTemplate + `
+{{end}}
+{{if .AuditAttributes}}
+` + helpers.AuditHelpersTemplate + `
+{{end}}
+{{if .ChecksumAttribute}}
+` + helpers.ChecksumHelpersTemplate + `
+{{end}}
+{{if .TTLAttribute}}
+` + helpers.TTLHelpersTemplate + `
+{{end}}
+{{if .FieldVersionsAttribute}}
+` + helpers.FieldVersionsHelpersTemplate + `
+{{end}}
+{{if .SensitiveAttributes}}
+` + helpers.EncryptionHelpersTemplate + `
+{{end}}
+{{if .PayloadAttributes}}
+` + helpers.PayloadHelpersTemplate + `
+{{end}}
 {{if .UseStreamEvents}}
 ` + helpers.StreamHelpersTemplate + `
 {{end}}
-` + helpers.ConverterHelpersTemplate + helpers.MarshalingHelpersTemplate + helpers.ValidationHelpersTemplate + `
+{{if .UseLogging}}
+` + helpers.LoggingHelpersTemplate + `
+{{end}}
+{{if .UseBreaker}}
+` + helpers.BreakerHelpersTemplate + `
+{{end}}
+` + helpers.ConverterHelpersTemplate + helpers.MarshalingHelpersTemplate + helpers.NumberHelpersTemplate + helpers.EmptyValueHelpersTemplate + helpers.ValidationHelpersTemplate + `
 `
+
+// TestTemplate renders the optional companion test file (<filename>_test.go)
+const TestTemplate = `
+package {{.PackageName}}
+
+` + gotest.ImportsTemplate + `
+{{if .UsePropertyTests}}
+` + gotest.PropertyTestsTemplate + `
+{{end}}
+{{if .UseFuzzTests}}
+` + gotest.FuzzTestsTemplate + `
+{{end}}
+{{if .UseChaos}}
+` + gotest.ChaosClientTemplate + `
+{{end}}
+`
+
+// DocTemplate renders the optional package-level doc file (doc.go)
+const DocTemplate = doc.PackageDocTemplate
+
+// ExampleTemplates render main.go of an example application, keyed by kind ("lambda", "http", "cli", "admin")
+var ExampleTemplates = map[string]string{
+	"lambda": example.LambdaTemplate,
+	"http":   example.HTTPTemplate,
+	"cli":    example.CLITemplate,
+	"admin":  example.AdminTemplate,
+}
+
+// ExampleReadmeTemplate renders README.md of an example application
+const ExampleReadmeTemplate = example.ReadmeTemplate
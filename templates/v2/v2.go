@@ -7,9 +7,18 @@
 package v2
 
 import (
+	"fmt"
+	"go/token"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/Mad-Pixels/go-dyno/internal/generator/attribute"
 	"github.com/Mad-Pixels/go-dyno/internal/generator/index"
 	"github.com/Mad-Pixels/go-dyno/internal/generator/mode"
+	"github.com/Mad-Pixels/go-dyno/internal/generator/query"
+	"github.com/Mad-Pixels/go-dyno/internal/utils/conv"
 )
 
 // TemplateMap defines the full set of metadata used to generate DynamoDB-related code.
@@ -21,6 +30,18 @@ type TemplateMap struct {
 	// TableName is the name of the DynamoDB table.
 	TableName string
 
+	// SchemaHash is the SHA-256 digest of the schema definition.
+	SchemaHash string
+
+	// SchemaVersion is the schema version declared in the schema (1 by default).
+	SchemaVersion int
+
+	// GeneratorVersion is the go-dyno version embedded into generated code.
+	GeneratorVersion string
+
+	// MinDynamoDBVersion is the oldest AWS SDK DynamoDB module version generated code supports.
+	MinDynamoDBVersion string
+
 	// HashKey is the primary partition key of the table.
 	HashKey string
 
@@ -42,6 +63,797 @@ type TemplateMap struct {
 	// SecondaryIndexes defines all global and local secondary indexes for the table.
 	SecondaryIndexes []index.Index
 
+	// CompositeKeys declares the separator and escaping of composite key values.
+	CompositeKeys index.CompositeFormat
+
+	// KMSKeyID is the KMS key hint of sensitive attributes declared in the schema.
+	KMSKeyID string
+
+	// EncryptionContext is the table-level encryption context of sensitive attributes.
+	EncryptionContext map[string]string
+
+	// TableClass is the table class declared in the schema, empty for the default.
+	TableClass string
+
+	// WarmThroughput is the warm throughput of the table declared in the schema.
+	WarmThroughput *index.WarmThroughput
+
+	// Replicas are the Global Tables replica regions declared in the schema.
+	Replicas []string
+
+	// Locks is true if the schema declares lock items, generating Lock, Renew and Release.
+	Locks bool
+
+	// LockKeyPrefix is prepended to resource IDs in the hash key of lock items.
+	LockKeyPrefix string
+
+	// LockSortKey is the range key value of lock items, empty for tables without a range key.
+	LockSortKey string
+
+	// Quotas is true if the schema declares quota window items, generating ConsumeQuota.
+	Quotas bool
+
+	// QuotaKeyPrefix is prepended to subjects in the hash key of quota window items.
+	QuotaKeyPrefix string
+
+	// QuotaSortKey is the range key value of quota window items, empty for tables without a range key.
+	QuotaSortKey string
+
+	// QueueIndex is the index of the job queue declared in the schema, empty if none.
+	QueueIndex string
+
+	// SessionIndex is the user index of the session store declared in the schema, empty if the table isn't one.
+	SessionIndex string
+
+	// EventLog is true if the table is flagged as an event log, generating AppendEvent, LoadEvents and snapshots.
+	EventLog bool
+
+	// SnapshotKeyPrefix is prepended to aggregate IDs in the hash key of snapshot items.
+	SnapshotKeyPrefix string
+
+	// SnapshotEvery is the number of events between snapshots.
+	SnapshotEvery int
+
+	// PruneTimeUnit is the time unit of the range key of a table flagged for pruning: "s", "ms" or "rfc3339".
+	// Empty if the table isn't, PruneOlderThan is only generated for pruned tables.
+	PruneTimeUnit string
+
+	// Queries are the named queries declared in the schema, keyed by name.
+	Queries map[string]query.Query
+
 	// UseStreamEvents option: generate or not methods related with DynmaoDB StreamEvents.
 	UseStreamEvents bool
+
+	// UseLogging option: generate or not the structured logging client decorator.
+	UseLogging bool
+
+	// UseChaos option: generate or not the fault-injection client decorator for tests.
+	UseChaos bool
+
+	// UseBreaker option: generate or not the circuit breaker client decorator.
+	UseBreaker bool
+
+	// UsePropertyTests option: generate or not property-based round-trip tests in a separate test file.
+	UsePropertyTests bool
+
+	// UseFuzzTests option: generate or not fuzz targets in a separate test file.
+	UseFuzzTests bool
+
+	// UseDoc option: generate or not the package-level doc.go with access patterns.
+	UseDoc bool
+
+	// NoScan option: omit ScanBuilder and scan operations, so the package never scans the table.
+	NoScan bool
+}
+
+// ExampleKey returns a sample value expression for key used in generated doc examples.
+// key is an attribute name or a composite key (e.g. "USER#user_id"), whose attribute parts
+// are replaced by sample values and constant parts are kept.
+func (t TemplateMap) ExampleKey(key string) string {
+	if attr, ok := t.attribute(key); ok {
+		return attr.ExampleValue()
+	}
+	if !strings.Contains(key, "#") {
+		return strconv.Quote(key + "-1")
+	}
+
+	parts := strings.Split(key, "#")
+	for i, part := range parts {
+		attr, ok := t.attribute(part)
+		if !ok {
+			continue
+		}
+		if v, err := strconv.Unquote(attr.ExampleValue()); err == nil {
+			parts[i] = v
+			continue
+		}
+		parts[i] = attr.ExampleValue()
+	}
+	return strconv.Quote(t.CompositeKeys.Join(key, parts))
+}
+
+// ExampleField returns the expression naming key in generated doc examples:
+// the column constant for an attribute, the quoted name otherwise.
+func (t TemplateMap) ExampleField(key string) string {
+	if attr, ok := t.attribute(key); ok {
+		return "Column" + attr.GoName()
+	}
+	return strconv.Quote(key)
+}
+
+// Attribute returns the attribute with the name, nil for composite keys and unknown names.
+func (t TemplateMap) Attribute(name string) *attribute.Attribute {
+	if attr, ok := t.attribute(name); ok {
+		return &attr
+	}
+	return nil
+}
+
+func (t TemplateMap) attribute(name string) (attribute.Attribute, bool) {
+	for _, attr := range t.AllAttributes {
+		if attr.Name == name {
+			return attr, true
+		}
+	}
+	return attribute.Attribute{}, false
+}
+
+// SensitiveAttributes returns attributes marked sensitive in the schema.
+func (t TemplateMap) SensitiveAttributes() []attribute.Attribute {
+	var sensitive []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.IsSensitive() {
+			sensitive = append(sensitive, attr)
+		}
+	}
+	return sensitive
+}
+
+// EncryptedAttributes returns sensitive attributes encrypted client-side:
+// attributes of the primary key or an index key stay plaintext to remain queryable.
+func (t TemplateMap) EncryptedAttributes() []attribute.Attribute {
+	keys := map[string]bool{t.HashKey: true, t.RangeKey: true}
+	for _, idx := range t.SecondaryIndexes {
+		for _, key := range []string{idx.HashKey, idx.RangeKey} {
+			for _, part := range strings.Split(key, "#") {
+				keys[part] = true
+			}
+		}
+	}
+	var encrypted []attribute.Attribute
+	for _, attr := range t.SensitiveAttributes() {
+		if !keys[attr.Name] {
+			encrypted = append(encrypted, attr)
+		}
+	}
+	return encrypted
+}
+
+// ComputedAttribute is a computed attribute with its expression rendered as Go code.
+type ComputedAttribute struct {
+	attribute.Attribute
+
+	// Inputs are the names of attributes the expression reads.
+	Inputs []string
+
+	// ItemExpr computes the value from the SchemaItem named item.
+	ItemExpr string
+
+	// UpdateExpr computes the value from the updates map named updates.
+	UpdateExpr string
+}
+
+// ComputedAttributes returns attributes derived from other attributes on write.
+// Non-string inputs are formatted with fmt.Sprint.
+func (t TemplateMap) ComputedAttributes() []ComputedAttribute {
+	var computed []ComputedAttribute
+	for _, attr := range t.AllAttributes {
+		expr, err := attr.ComputedExpr()
+		if !attr.IsComputed() || err != nil {
+			continue
+		}
+		computed = append(computed, ComputedAttribute{
+			Attribute: attr,
+			Inputs:    expr.Refs(),
+			ItemExpr: expr.GoExpr(func(ref string) string {
+				input, _ := t.attribute(ref)
+				if input.GoType() == "string" {
+					return "item." + input.GoName()
+				}
+				return "fmt.Sprint(item." + input.GoName() + ")"
+			}),
+			UpdateExpr: expr.GoExpr(func(ref string) string {
+				return "fmt.Sprint(updates[" + t.ExampleField(ref) + "])"
+			}),
+		})
+	}
+	return computed
+}
+
+// GuardedAttributes returns attributes with a change guard, primary key attributes excluded.
+func (t TemplateMap) GuardedAttributes() []attribute.Attribute {
+	var guarded []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.Guard != "" && attr.Name != t.HashKey && attr.Name != t.RangeKey {
+			guarded = append(guarded, attr)
+		}
+	}
+	return guarded
+}
+
+// VersionAttribute returns the attribute used for optimistic locking, nil if none is declared.
+func (t TemplateMap) VersionAttribute() *attribute.Attribute {
+	for _, attr := range t.AllAttributes {
+		if attr.Version {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// ChecksumAttribute returns the attribute holding the item checksum, nil if none is declared.
+func (t TemplateMap) ChecksumAttribute() *attribute.Attribute {
+	for _, attr := range t.AllAttributes {
+		if attr.Checksum {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// TTLAttribute returns the attribute holding the item expiry, nil if none is declared.
+func (t TemplateMap) TTLAttribute() *attribute.Attribute {
+	for _, attr := range t.AllAttributes {
+		if attr.TTL {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// FieldVersionsAttribute returns the attribute holding per-attribute modification times, nil if none is declared.
+func (t TemplateMap) FieldVersionsAttribute() *attribute.Attribute {
+	for _, attr := range t.AllAttributes {
+		if attr.FieldVersions {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// OptionalAttributes returns attributes declared with "required": false, generated as pointer fields.
+func (t TemplateMap) OptionalAttributes() []attribute.Attribute {
+	var optional []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.IsOptional() {
+			optional = append(optional, attr)
+		}
+	}
+	return optional
+}
+
+// EnumAttributes returns string attributes with allowed values, generated as typed strings.
+func (t TemplateMap) EnumAttributes() []attribute.Attribute {
+	var enums []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.IsEnum() {
+			enums = append(enums, attr)
+		}
+	}
+	return enums
+}
+
+// BeginsWithKeys returns the string range keys of the table and its indexes, composite keys excluded,
+// generated with typed With<Name>BeginsWith key conditions.
+func (t TemplateMap) BeginsWithKeys() []attribute.Attribute {
+	names := []string{t.RangeKey}
+	for _, idx := range t.SecondaryIndexes {
+		names = append(names, idx.RangeKey)
+	}
+	var (
+		keys []attribute.Attribute
+		seen = map[string]bool{}
+	)
+	for _, name := range names {
+		attr, ok := t.attribute(name)
+		if !ok || seen[name] || attr.Type != "S" {
+			continue
+		}
+		seen[name] = true
+		keys = append(keys, attr)
+	}
+	return keys
+}
+
+// InFilterAttributes returns scalar attributes filterable by a list of values, generated
+// with typed Filter<Name>In and Filter<Name>NotIn. Encrypted attributes and attributes
+// with a go_type are left out: their stored values differ from the Go values.
+func (t TemplateMap) InFilterAttributes() []attribute.Attribute {
+	encrypted := map[string]bool{}
+	for _, attr := range t.EncryptedAttributes() {
+		encrypted[attr.Name] = true
+	}
+	var attrs []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		switch {
+		case attr.Type != "S" && attr.Type != "N" && attr.Type != "B":
+		case attr.HasCustomGoType(), encrypted[attr.Name]:
+		default:
+			attrs = append(attrs, attr)
+		}
+	}
+	return attrs
+}
+
+// RuleAttributes returns attributes with validation rules, checked by the generated SchemaItem.Validate.
+func (t TemplateMap) RuleAttributes() []attribute.Attribute {
+	var rules []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.HasRules() {
+			rules = append(rules, attr)
+		}
+	}
+	return rules
+}
+
+// HelperSortKey returns the range key value of lock or quota items as a Go expression, "nil" without a range key.
+func (t TemplateMap) HelperSortKey(value string) string {
+	if t.RangeKey == "" {
+		return "nil"
+	}
+	if attr := t.Attribute(t.RangeKey); attr != nil && attr.Type == "N" {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+// Queue returns the index of the job queue, nil if none is declared.
+func (t TemplateMap) Queue() *index.Index {
+	for _, idx := range t.SecondaryIndexes {
+		if t.QueueIndex != "" && idx.Name == t.QueueIndex {
+			return &idx
+		}
+	}
+	return nil
+}
+
+// SessionStore returns the user index of the session store, nil if the table isn't one.
+func (t TemplateMap) SessionStore() *index.Index {
+	for _, idx := range t.SecondaryIndexes {
+		if t.SessionIndex != "" && idx.Name == t.SessionIndex {
+			return &idx
+		}
+	}
+	return nil
+}
+
+// HasCompositeKeys returns true if a secondary index has a composite key, written by the generated inputs.
+func (t TemplateMap) HasCompositeKeys() bool {
+	for _, idx := range t.SecondaryIndexes {
+		if len(idx.HashKeyParts) > 0 || len(idx.RangeKeyParts) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactedAttributes returns attributes whose values the generated logging doesn't log:
+// sensitive attributes and PII attributes with an anonymize strategy.
+func (t TemplateMap) RedactedAttributes() []attribute.Attribute {
+	var redacted []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.IsSensitive() || attr.Anonymize != "" {
+			redacted = append(redacted, attr)
+		}
+	}
+	return redacted
+}
+
+// DefaultAttributes returns attributes with a default value, filled in by the generated ItemInput.
+func (t TemplateMap) DefaultAttributes() []attribute.Attribute {
+	var defaults []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.HasDefault() {
+			defaults = append(defaults, attr)
+		}
+	}
+	return defaults
+}
+
+// NormalizedAttributes returns string key attributes whose values are normalized before writes and lookups.
+func (t TemplateMap) NormalizedAttributes() []attribute.Attribute {
+	var normalized []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.IsNormalized() {
+			normalized = append(normalized, attr)
+		}
+	}
+	return normalized
+}
+
+// IsIndexKey returns true if the attribute is the hash or range key of a secondary index.
+func (t TemplateMap) IsIndexKey(name string) bool {
+	for _, idx := range t.SecondaryIndexes {
+		if idx.HashKey == name || idx.RangeKey == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GoTypeImports returns the sorted import paths of go_type and payload message packages outside
+// the standard library, which the imports of the generated code don't cover.
+func (t TemplateMap) GoTypeImports() []string {
+	imports := map[string]bool{}
+	for _, attr := range t.AllAttributes {
+		for _, a := range append([]attribute.Attribute{attr}, attr.Fields...) {
+			if pkg := a.GoTypeImport(); strings.Contains(strings.Split(pkg, "/")[0], ".") {
+				imports[pkg] = true
+			}
+		}
+		if attr.IsPayload() {
+			imports[attr.PayloadImport()] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(imports))
+}
+
+// PayloadAttributes returns the attributes holding protobuf payloads, generating typed accessors.
+func (t TemplateMap) PayloadAttributes() []attribute.Attribute {
+	var payloads []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.IsPayload() {
+			payloads = append(payloads, attr)
+		}
+	}
+	return payloads
+}
+
+// ListStructs returns the typed lists with struct elements ("L<M:Name>").
+func (t TemplateMap) ListStructs() []attribute.Attribute {
+	var lists []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.ElementStruct() != "" {
+			lists = append(lists, attr)
+		}
+	}
+	return lists
+}
+
+// TypedLists returns the typed list attributes, primary key attributes excluded.
+func (t TemplateMap) TypedLists() []attribute.Attribute {
+	var lists []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.IsTypedList() && attr.Name != t.HashKey && attr.Name != t.RangeKey {
+			lists = append(lists, attr)
+		}
+	}
+	return lists
+}
+
+// AuditAttributes returns the audit timestamp attributes, primary key attributes excluded.
+func (t TemplateMap) AuditAttributes() []attribute.Attribute {
+	var audit []attribute.Attribute
+	for _, attr := range t.AllAttributes {
+		if attr.IsAuto() && attr.Name != t.HashKey && attr.Name != t.RangeKey {
+			audit = append(audit, attr)
+		}
+	}
+	return audit
+}
+
+// AuditCreatedAttribute returns the created_at audit timestamp, nil if none is declared.
+func (t TemplateMap) AuditCreatedAttribute() *attribute.Attribute {
+	for _, attr := range t.AuditAttributes() {
+		if attr.Auto == attribute.AutoCreatedAt {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// AuditUpdatedAttribute returns the updated_at audit timestamp, nil if none is declared.
+func (t TemplateMap) AuditUpdatedAttribute() *attribute.Attribute {
+	for _, attr := range t.AuditAttributes() {
+		if attr.Auto == attribute.AutoUpdatedAt {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// CompositeKeyParser is a composite key of the secondary indexes rendered as a typed parse function.
+type CompositeKeyParser struct {
+	// Name is the composite key attribute, e.g. "status#category".
+	Name string
+
+	// FuncName is the name of the generated function, e.g. "ParseStatusCategory".
+	FuncName string
+
+	// Parts are the attribute parts of the key in order, constant parts excluded.
+	Parts []CompositeKeyParserPart
+}
+
+// CompositeKeyParserPart is an attribute part of a composite key with its result name in the parse function.
+type CompositeKeyParserPart struct {
+	attribute.Attribute
+
+	// Result is the name of the result holding the part, e.g. "isPublished".
+	Result string
+}
+
+// CompositeKeyParsers returns the composite keys of the secondary indexes, each once, whose attribute parts
+// are strings, numbers or booleans without go_type, parsed by generated Parse<Key> functions.
+func (t TemplateMap) CompositeKeyParsers() []CompositeKeyParser {
+	var (
+		parsers []CompositeKeyParser
+		seen    = make(map[string]bool)
+	)
+	add := func(name string, parts []index.CompositeKey) {
+		if len(parts) == 0 || seen[name] {
+			return
+		}
+		seen[name] = true
+		parser := CompositeKeyParser{Name: name, FuncName: "Parse" + conv.ToUpperCamelCase(conv.ToSafeName(name))}
+		results := make(map[string]bool)
+		for _, part := range parts {
+			if part.IsConstant {
+				continue
+			}
+			attr, ok := t.attribute(part.Value)
+			if !ok || attr.HasCustomGoType() || (attr.Type != "S" && attr.Type != "N" && attr.Type != "BOOL") {
+				return
+			}
+			goName := attr.GoName()
+			result := strings.ToLower(goName[:1]) + goName[1:]
+			if token.IsKeyword(result) || result == "err" || results[result] {
+				result += "Part"
+			}
+			results[result] = true
+			parser.Parts = append(parser.Parts, CompositeKeyParserPart{Attribute: attr, Result: result})
+		}
+		parsers = append(parsers, parser)
+	}
+	for _, idx := range t.SecondaryIndexes {
+		add(idx.HashKey, idx.HashKeyParts)
+		add(idx.RangeKey, idx.RangeKeyParts)
+	}
+	return parsers
+}
+
+// ProjectedIndex is a KEYS_ONLY or INCLUDE secondary index rendered as a typed result struct.
+type ProjectedIndex struct {
+	index.Index
+
+	// GoName is the Go name of the index without an "Index" suffix, e.g. "PublishedByLikes",
+	// naming the generated PublishedByLikesIndexItem struct and ExecutePublishedByLikesIndex method.
+	GoName string
+
+	// Attributes are the declared attributes the index projects, in schema order:
+	// the table and index keys and the non-key attributes of INCLUDE projections.
+	// Composite keys are not declared attributes and are left out.
+	Attributes []attribute.Attribute
+}
+
+// ProjectedIndexes returns the secondary indexes which don't project all attributes, in schema order.
+func (t TemplateMap) ProjectedIndexes() []ProjectedIndex {
+	var indexes []ProjectedIndex
+	for _, idx := range t.SecondaryIndexes {
+		if idx.ProjectionType != "KEYS_ONLY" && idx.ProjectionType != "INCLUDE" {
+			continue
+		}
+		projected := map[string]bool{t.HashKey: true, t.RangeKey: true, idx.HashKey: true, idx.RangeKey: true}
+		if idx.ProjectionType == "INCLUDE" {
+			for _, name := range idx.NonKeyAttributes {
+				projected[name] = true
+			}
+		}
+		p := ProjectedIndex{Index: idx, GoName: strings.TrimSuffix(conv.ToUpperCamelCase(conv.ToSafeName(idx.Name)), "Index")}
+		for _, attr := range t.AllAttributes {
+			if projected[attr.Name] {
+				p.Attributes = append(p.Attributes, attr)
+			}
+		}
+		indexes = append(indexes, p)
+	}
+	return indexes
+}
+
+// NamedQuery is a named query of the schema rendered as a function building a QueryBuilder.
+type NamedQuery struct {
+	query.Query
+
+	// Name is the name of the query in the schema.
+	Name string
+
+	// FuncName is the name of the generated function.
+	FuncName string
+
+	// Params are the parameters of the generated function, e.g. "userId string, since int64".
+	Params string
+
+	// Calls are the QueryBuilder method calls of the function body, in order.
+	Calls []string
+}
+
+// NamedQueries returns the named queries of the schema sorted by name.
+// Parameters are the hash key values, the range key values of RangeOperator
+// and the values of filters without a constant value.
+func (t TemplateMap) NamedQueries() []NamedQuery {
+	var queries []NamedQuery
+	for _, name := range slices.Sorted(maps.Keys(t.Queries)) {
+		var (
+			q      = t.Queries[name]
+			params []string
+			seen   = make(map[string]int)
+			calls  = []string{"NewQueryBuilder()"}
+		)
+		// param declares a parameter of the attribute, of its element type for element conditions of sets.
+		param := func(attrName, suffix string, element bool) string {
+			attr, _ := t.attribute(attrName)
+			goName := attr.GoName() + suffix
+			p := strings.ToLower(goName[:1]) + goName[1:]
+			if seen[p]++; seen[p] > 1 {
+				p += strconv.Itoa(seen[p])
+			}
+			goType := attr.GoType()
+			if element && (attr.Type == "SS" || attr.Type == "NS" || attr.Type == "BS") {
+				goType = strings.TrimPrefix(goType, "[]")
+			}
+			params = append(params, p+" "+goType)
+			return p
+		}
+
+		hashKey, rangeKey := t.HashKey, t.RangeKey
+		var hashParts, rangeParts []index.CompositeKey
+		if idx := t.index(q.Index); idx != nil {
+			hashKey, rangeKey = idx.GetEffectiveHashKey(t.HashKey), idx.RangeKey
+			hashParts, rangeParts = idx.HashKeyParts, idx.RangeKeyParts
+			calls = append(calls, fmt.Sprintf("WithIndex(Index%s)", conv.ToUpperCamelCase(conv.ToSafeName(idx.Name))))
+		} else {
+			calls = append(calls, "useTable()")
+		}
+		for _, key := range keyAttributes(hashKey, hashParts) {
+			calls = append(calls, fmt.Sprintf("With(%s, EQ, %s)", t.ExampleField(key), param(key, "", false)))
+		}
+		switch {
+		case q.RangeOperator == "":
+		case q.RangeOperator == "EQ":
+			for _, key := range keyAttributes(rangeKey, rangeParts) {
+				calls = append(calls, fmt.Sprintf("With(%s, EQ, %s)", t.ExampleField(key), param(key, "", false)))
+			}
+		case q.RangeOperator == "BETWEEN":
+			calls = append(calls, fmt.Sprintf("withKeyCondition(%s, BETWEEN, %s, %s)", t.ExampleField(rangeKey), param(rangeKey, "From", false), param(rangeKey, "To", false)))
+		default:
+			calls = append(calls, fmt.Sprintf("withKeyCondition(%s, %s, %s)", t.ExampleField(rangeKey), q.RangeOperator, param(rangeKey, "", false)))
+		}
+		for _, f := range q.Filters {
+			args := []string{t.ExampleField(f.Attribute), f.Operator}
+			switch {
+			case f.IsConstant():
+				for _, v := range f.Values() {
+					args = append(args, goLiteral(v))
+				}
+			case f.ValueCount() == 2:
+				args = append(args, param(f.Attribute, "From", false), param(f.Attribute, "To", false))
+			case f.ValueCount() == 1:
+				args = append(args, param(f.Attribute, "", f.Operator == "CONTAINS" || f.Operator == "NOT_CONTAINS"))
+			}
+			calls = append(calls, "Filter("+strings.Join(args, ", ")+")")
+		}
+		if q.Descending {
+			calls = append(calls, "OrderByDesc()")
+		}
+		if q.Limit > 0 {
+			calls = append(calls, fmt.Sprintf("Limit(%d)", q.Limit))
+		}
+
+		queries = append(queries, NamedQuery{
+			Query:    q,
+			Name:     name,
+			FuncName: query.GoName(name),
+			Params:   strings.Join(params, ", "),
+			Calls:    calls,
+		})
+	}
+	return queries
+}
+
+// KeyAttributeDefinitions returns the attributes used as keys of the table and its indexes,
+// each once, as CreateTable attribute definitions. Composite index keys are strings.
+func (t TemplateMap) KeyAttributeDefinitions() []attribute.Attribute {
+	var (
+		defs []attribute.Attribute
+		seen = make(map[string]bool)
+	)
+	add := func(name string, composite bool) {
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		if attr, ok := t.attribute(name); ok && !composite {
+			defs = append(defs, attribute.Attribute{Name: name, Type: attr.Type})
+			return
+		}
+		defs = append(defs, attribute.Attribute{Name: name, Type: "S"})
+	}
+	add(t.HashKey, false)
+	add(t.RangeKey, false)
+	for _, idx := range t.SecondaryIndexes {
+		add(idx.HashKey, idx.HasCompositeHashKey())
+		add(idx.RangeKey, len(idx.RangeKeyParts) > 0)
+	}
+	return defs
+}
+
+func (t TemplateMap) index(name string) *index.Index {
+	for i := range t.SecondaryIndexes {
+		if t.SecondaryIndexes[i].Name == name {
+			return &t.SecondaryIndexes[i]
+		}
+	}
+	return nil
+}
+
+// keyAttributes returns the attributes of a key: the attribute parts of a composite key, the key otherwise.
+func keyAttributes(key string, parts []index.CompositeKey) []string {
+	if len(parts) == 0 {
+		return []string{key}
+	}
+	var attrs []string
+	for _, part := range parts {
+		if !part.IsConstant {
+			attrs = append(attrs, part.Value)
+		}
+	}
+	return attrs
+}
+
+// goLiteral renders a constant value decoded from JSON as a Go literal.
+func goLiteral(v any) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ExampleAttribute returns the first required non-key attribute without go_type used in generated update examples, or nil.
+func (t TemplateMap) ExampleAttribute() *attribute.Attribute {
+	for _, attr := range t.AllAttributes {
+		if attr.Name != t.HashKey && attr.Name != t.RangeKey && !attr.IsOptional() && !attr.HasCustomGoType() {
+			return &attr
+		}
+	}
+	return nil
+}
+
+// ExampleMap is the input of example application templates: the table metadata
+// plus the location of the generated package inside the example module.
+type ExampleMap struct {
+	TemplateMap
+
+	// Kind is the example application type: "lambda", "http", "cli" or "admin".
+	Kind string
+
+	// ImportPath is the import path of the generated package, e.g. "example.com/orders-example/orders".
+	ImportPath string
+}
+
+// KeyAttribute returns the attribute of the table hash key.
+func (e ExampleMap) KeyAttribute() attribute.Attribute {
+	attr, _ := e.attribute(e.HashKey)
+	return attr
+}
+
+// ExampleHashKeyText returns the sample hash key value as typed on a command line or in a URL.
+func (e ExampleMap) ExampleHashKeyText() string {
+	value := e.ExampleKey(e.HashKey)
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
 }
@@ -0,0 +1,117 @@
+package localstack
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	basestring "github.com/Mad-Pixels/go-dyno/tests/localstack/generated/basestringall"
+)
+
+// TestBatchGetItems focuses on BatchGetItems chunking and retries of unprocessed keys.
+// This test reads through a client processing only part of every request.
+//
+// Test Coverage:
+// - Chunks of at most 100 keys
+// - UnprocessedKeys retried until every key is read
+// - Duplicate keys deduplicated in requests, items returned in the order of keys
+// - Missing items skipped
+// - Failure when keys stay unprocessed after all retries
+//
+// Schema: base-string__all.json
+// - Table: "base-string-all"
+// - Hash Key: id (S)
+// - Range Key: category (S)
+func TestBatchGetItems(t *testing.T) {
+	client := ConnectToLocalStack(t, DefaultLocalStackConfig())
+	ctx, cancel := TestContext(3 * time.Minute)
+	defer cancel()
+
+	t.Logf("Testing BatchGetItems on: %s", basestring.TableName)
+
+	t.Run("BatchGet_Chunks", func(t *testing.T) {
+		testBatchGetChunks(t, client, ctx)
+	})
+
+	t.Run("BatchGet_Unprocessed", func(t *testing.T) {
+		testBatchGetUnprocessed(t, client, ctx)
+	})
+}
+
+// partialGetClient reads at most limit keys of every BatchGetItem request
+// and returns the others as UnprocessedKeys.
+type partialGetClient struct {
+	*dynamodb.Client
+	limit    int
+	requests []int
+}
+
+func (c *partialGetClient) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	keys := params.RequestItems[basestring.TableName].Keys
+	c.requests = append(c.requests, len(keys))
+
+	n := min(c.limit, len(keys))
+	out := &dynamodb.BatchGetItemOutput{Responses: map[string][]map[string]types.AttributeValue{}}
+	if n > 0 {
+		processed, err := c.Client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+			RequestItems: map[string]types.KeysAndAttributes{basestring.TableName: {Keys: keys[:n]}},
+		}, optFns...)
+		if err != nil {
+			return nil, err
+		}
+		out.Responses = processed.Responses
+	}
+	if n < len(keys) {
+		out.UnprocessedKeys = map[string]types.KeysAndAttributes{basestring.TableName: {Keys: keys[n:]}}
+	}
+	return out, nil
+}
+
+// batchItems returns n items of the test run with the id prefix.
+func batchItems(prefix string, n int) []basestring.SchemaItem {
+	items := make([]basestring.SchemaItem, n)
+	for i := range items {
+		items[i] = basestring.SchemaItem{Id: fmt.Sprintf("%s-%03d", prefix, i), Category: "batch", Title: fmt.Sprintf("item %d", i)}
+	}
+	return items
+}
+
+func testBatchGetChunks(t *testing.T, client *dynamodb.Client, ctx context.Context) {
+	prefix := fmt.Sprintf("batch-get-%d", time.Now().UnixNano())
+	stored := batchItems(prefix, 250)
+	require.NoError(t, basestring.BatchWriteItems(ctx, client, stored, nil))
+
+	keys := append([]basestring.SchemaItem{}, stored...)
+	keys = append(keys, basestring.SchemaItem{Id: prefix + "-missing", Category: "batch"}, stored[0], stored[249])
+
+	partial := &partialGetClient{Client: client, limit: 40}
+	items, err := basestring.BatchGetItems(ctx, partial, keys)
+	require.NoError(t, err, "Unprocessed keys should be retried")
+
+	assert.Equal(t, []int{100, 60, 20, 100, 60, 20, 51, 11}, partial.requests,
+		"Should send chunks of 100 deduplicated keys and retry the unprocessed ones")
+	require.Len(t, items, 252, "Should skip the missing item and repeat duplicate keys")
+	for i, item := range stored {
+		assert.Equal(t, item, items[i], "Items should be returned in the order of keys")
+	}
+	assert.Equal(t, stored[0], items[250])
+	assert.Equal(t, stored[249], items[251])
+}
+
+func testBatchGetUnprocessed(t *testing.T, client *dynamodb.Client, ctx context.Context) {
+	prefix := fmt.Sprintf("batch-get-stuck-%d", time.Now().UnixNano())
+	stored := batchItems(prefix, 3)
+	require.NoError(t, basestring.BatchWriteItems(ctx, client, stored, nil))
+
+	stuck := &partialGetClient{Client: client, limit: 0}
+	_, err := basestring.BatchGetItems(ctx, stuck, stored)
+	require.Error(t, err, "Keys unprocessed after all retries should fail the batch")
+	assert.Contains(t, err.Error(), "3 keys unprocessed")
+	assert.Len(t, stuck.requests, 9, "Should send the request and retry it 8 times")
+}
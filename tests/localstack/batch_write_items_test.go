@@ -0,0 +1,164 @@
+package localstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	basestring "github.com/Mad-Pixels/go-dyno/tests/localstack/generated/basestringall"
+)
+
+// TestBatchWriteItems focuses on BatchWriteItems chunking, retries and partial failures.
+// This test writes through a client processing only part of every request.
+//
+// Test Coverage:
+// - Chunks of at most 25 requests, puts and deletes
+// - UnprocessedItems retried until every request is written
+// - BatchWriteError reporting requests unprocessed after all retries, remaining chunks written
+// - BatchWriteError wrapping a failed request, with the requests of it and of the chunks after it
+//
+// Schema: base-string__all.json
+// - Table: "base-string-all"
+// - Hash Key: id (S)
+// - Range Key: category (S)
+func TestBatchWriteItems(t *testing.T) {
+	client := ConnectToLocalStack(t, DefaultLocalStackConfig())
+	ctx, cancel := TestContext(3 * time.Minute)
+	defer cancel()
+
+	t.Logf("Testing BatchWriteItems on: %s", basestring.TableName)
+
+	t.Run("BatchWrite_Retry", func(t *testing.T) {
+		testBatchWriteRetry(t, client, ctx)
+	})
+
+	t.Run("BatchWrite_Unprocessed", func(t *testing.T) {
+		testBatchWriteUnprocessed(t, client, ctx)
+	})
+
+	t.Run("BatchWrite_Failed", func(t *testing.T) {
+		testBatchWriteFailed(t, client, ctx)
+	})
+}
+
+// partialWriteClient writes at most limit requests of every BatchWriteItem request and returns
+// the others as UnprocessedItems. Requests of items with an id containing stuck are never written,
+// the request number failAt (1-based) fails with err.
+type partialWriteClient struct {
+	*dynamodb.Client
+	limit    int
+	stuck    string
+	failAt   int
+	err      error
+	requests []int
+}
+
+func (c *partialWriteClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	requests := params.RequestItems[basestring.TableName]
+	c.requests = append(c.requests, len(requests))
+	if len(c.requests) == c.failAt {
+		return nil, c.err
+	}
+
+	var processed, unprocessed []types.WriteRequest
+	for _, request := range requests {
+		if len(processed) == c.limit || (c.stuck != "" && strings.Contains(writeRequestID(request), c.stuck)) {
+			unprocessed = append(unprocessed, request)
+			continue
+		}
+		processed = append(processed, request)
+	}
+	if len(processed) > 0 {
+		if _, err := c.Client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{basestring.TableName: processed},
+		}, optFns...); err != nil {
+			return nil, err
+		}
+	}
+	out := &dynamodb.BatchWriteItemOutput{}
+	if len(unprocessed) > 0 {
+		out.UnprocessedItems = map[string][]types.WriteRequest{basestring.TableName: unprocessed}
+	}
+	return out, nil
+}
+
+// writeRequestID returns the id of the item of a put or delete request.
+func writeRequestID(request types.WriteRequest) string {
+	var key map[string]types.AttributeValue
+	switch {
+	case request.PutRequest != nil:
+		key = request.PutRequest.Item
+	case request.DeleteRequest != nil:
+		key = request.DeleteRequest.Key
+	}
+	if id, ok := key[basestring.ColumnId].(*types.AttributeValueMemberS); ok {
+		return id.Value
+	}
+	return ""
+}
+
+// storedBatchItems returns the stored items of the given keys.
+func storedBatchItems(t *testing.T, client *dynamodb.Client, ctx context.Context, keys []basestring.SchemaItem) []basestring.SchemaItem {
+	t.Helper()
+	items, err := basestring.BatchGetItems(ctx, client, keys)
+	require.NoError(t, err)
+	return items
+}
+
+func testBatchWriteRetry(t *testing.T, client *dynamodb.Client, ctx context.Context) {
+	items := batchItems(fmt.Sprintf("batch-write-%d", time.Now().UnixNano()), 60)
+
+	partial := &partialWriteClient{Client: client, limit: 10}
+	require.NoError(t, basestring.BatchWriteItems(ctx, partial, items, nil), "Unprocessed puts should be retried")
+	assert.Equal(t, []int{25, 15, 5, 25, 15, 5, 10}, partial.requests,
+		"Should send chunks of 25 requests and retry the unprocessed ones")
+	assert.Equal(t, items, storedBatchItems(t, client, ctx, items))
+
+	partial.requests = nil
+	require.NoError(t, basestring.BatchWriteItems(ctx, partial, items[:20], items[40:]), "Should put and delete in one call")
+	assert.Equal(t, []int{25, 15, 5, 15, 5}, partial.requests)
+	assert.Equal(t, items[:40], storedBatchItems(t, client, ctx, items), "Deleted items should be gone")
+}
+
+func testBatchWriteUnprocessed(t *testing.T, client *dynamodb.Client, ctx context.Context) {
+	items := batchItems(fmt.Sprintf("batch-write-partial-%d", time.Now().UnixNano()), 30)
+	items[3].Id += "-stuck"
+
+	stuck := &partialWriteClient{Client: client, limit: 25, stuck: "-stuck"}
+	err := basestring.BatchWriteItems(ctx, stuck, items, nil)
+	var partial *basestring.BatchWriteError
+	require.True(t, errors.As(err, &partial), "Stuck put should return BatchWriteError, got %v", err)
+	assert.NoError(t, partial.Err, "Unprocessed requests should not report a request error")
+	assert.Equal(t, 30, partial.Total)
+	require.Len(t, partial.Unprocessed, 1)
+	assert.Equal(t, items[3].Id, writeRequestID(partial.Unprocessed[0]))
+
+	stored := storedBatchItems(t, client, ctx, items)
+	assert.Len(t, stored, 29, "Requests other than the stuck one should be written, including the next chunk")
+	assert.NotContains(t, stored, items[3])
+}
+
+func testBatchWriteFailed(t *testing.T, client *dynamodb.Client, ctx context.Context) {
+	items := batchItems(fmt.Sprintf("batch-write-failed-%d", time.Now().UnixNano()), 60)
+	errThrottled := errors.New("throttled")
+
+	failing := &partialWriteClient{Client: client, limit: 25, failAt: 2, err: errThrottled}
+	err := basestring.BatchWriteItems(ctx, failing, items, nil)
+	var partial *basestring.BatchWriteError
+	require.True(t, errors.As(err, &partial), "Failed request should return BatchWriteError, got %v", err)
+	assert.ErrorIs(t, err, errThrottled, "BatchWriteError should wrap the failed request")
+	assert.Equal(t, 60, partial.Total)
+	require.Len(t, partial.Unprocessed, 35, "Requests of the failed chunk and of the chunks after it should be unprocessed")
+	assert.Equal(t, items[25].Id, writeRequestID(partial.Unprocessed[0]))
+	assert.Equal(t, []int{25, 25}, failing.requests, "Should stop at the failed request")
+
+	assert.Equal(t, items[:25], storedBatchItems(t, client, ctx, items), "Chunks before the failure should be written")
+}
@@ -0,0 +1,7771 @@
+package apiusage
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// TableName is the DynamoDB table name for all operations.
+	// Example:
+	//   client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(TableName)})
+	TableName = "api-usage"
+
+	// SchemaHash is the SHA-256 digest of the schema this code was generated from.
+	SchemaHash = "994162f4ca91f7baaf019a1ce67cc7fd914731eacd32a57fa6a1358ea4bffd59"
+
+	// SchemaVersion is the version of the schema this code was generated from.
+	SchemaVersion = 1
+
+	// ColumnPk is the "pk" attribute name.
+	ColumnPk = "pk"
+	// ColumnSk is the "sk" attribute name.
+	ColumnSk = "sk"
+	// ColumnRequests is the "requests" attribute name.
+	ColumnRequests = "requests"
+	// ColumnExpiresAt is the "expires_at" attribute name.
+	ColumnExpiresAt = "expires_at"
+)
+
+var (
+	// AttributeNames contains all table attribute names for projection expressions.
+	// Example: expression.NamesList(expression.Name(AttributeNames[0]))
+	AttributeNames = []string{
+		"pk",
+		"sk",
+		"requests",
+		"expires_at",
+	}
+
+	// KeyAttributeNames contains primary key attributes for key operations.
+	// Example: validateKeys(item, KeyAttributeNames)
+	KeyAttributeNames = []string{
+		"pk",
+		"sk",
+	}
+)
+
+// OperatorType defines the type of operation for queries and filters.
+// Provides type-safe operator constants for DynamoDB expressions.
+type OperatorType string
+
+const (
+	// Equality and comparison operators - work with all comparable types
+	EQ  OperatorType = "="  // Equal to
+	NE  OperatorType = "<>" // Not equal to
+	GT  OperatorType = ">"  // Greater than
+	LT  OperatorType = "<"  // Less than
+	GTE OperatorType = ">=" // Greater than or equal
+	LTE OperatorType = "<=" // Less than or equal
+
+	// Range operator for between comparisons
+	BETWEEN OperatorType = "BETWEEN"
+
+	// String operators - work with String types and Sets
+	CONTAINS     OperatorType = "contains"
+	NOT_CONTAINS OperatorType = "not_contains"
+	BEGINS_WITH  OperatorType = "begins_with"
+
+	// Set operators for scalar values only (not DynamoDB Sets SS/NS)
+	IN     OperatorType = "IN"
+	NOT_IN OperatorType = "NOT_IN"
+
+	// Existence operators - work with all types
+	EXISTS     OperatorType = "attribute_exists"
+	NOT_EXISTS OperatorType = "attribute_not_exists"
+
+	// Size operators compare size(): length of strings, bytes of binaries, elements of sets, lists and maps
+	SIZE_EQ      OperatorType = "size ="
+	SIZE_GT      OperatorType = "size >"
+	SIZE_LT      OperatorType = "size <"
+	SIZE_GTE     OperatorType = "size >="
+	SIZE_LTE     OperatorType = "size <="
+	SIZE_BETWEEN OperatorType = "size BETWEEN"
+
+	// Type operator checks the DynamoDB type of the stored value - works with all types
+	ATTRIBUTE_TYPE OperatorType = "attribute_type"
+)
+
+// sizeOperators are the operators on size(), allowed for types with a length.
+var sizeOperators = []OperatorType{SIZE_EQ, SIZE_GT, SIZE_LT, SIZE_GTE, SIZE_LTE, SIZE_BETWEEN}
+
+// attributeTypes are the DynamoDB types ATTRIBUTE_TYPE accepts.
+var attributeTypes = map[string]bool{
+	"S": true, "SS": true, "N": true, "NS": true, "B": true, "BS": true,
+	"BOOL": true, "NULL": true, "L": true, "M": true,
+}
+
+// ConditionType defines whether this is a key condition or filter condition.
+// Key conditions are used in Query operations, filters in both Query and Scan.
+type ConditionType string
+
+const (
+	KeyCondition    ConditionType = "KEY"
+	FilterCondition ConditionType = "FILTER"
+)
+
+// Condition represents a single query or filter condition with validation metadata.
+type Condition struct {
+	Field    string        `json:"field"`    // Attribute name
+	Operator OperatorType  `json:"operator"` // Operation type
+	Values   []any         `json:"values"`   // Operation values
+	Type     ConditionType `json:"type"`     // Key or filter condition
+}
+
+// Type-safe handler functions for different expression types.
+// Provides compile-time safety for DynamoDB expression building.
+type (
+	KeyOperatorHandler       func(expression.KeyBuilder, []any) expression.KeyConditionBuilder
+	ConditionOperatorHandler func(expression.NameBuilder, []any) expression.ConditionBuilder
+)
+
+// Only includes operators valid for key conditions (partition/sort keys).
+var keyOperatorHandlers = map[OperatorType]KeyOperatorHandler{
+	EQ: func(field expression.KeyBuilder, values []any) expression.KeyConditionBuilder {
+		return field.Equal(expression.Value(values[0]))
+	},
+	GT: func(field expression.KeyBuilder, values []any) expression.KeyConditionBuilder {
+		return field.GreaterThan(expression.Value(values[0]))
+	},
+	LT: func(field expression.KeyBuilder, values []any) expression.KeyConditionBuilder {
+		return field.LessThan(expression.Value(values[0]))
+	},
+	GTE: func(field expression.KeyBuilder, values []any) expression.KeyConditionBuilder {
+		return field.GreaterThanEqual(expression.Value(values[0]))
+	},
+	LTE: func(field expression.KeyBuilder, values []any) expression.KeyConditionBuilder {
+		return field.LessThanEqual(expression.Value(values[0]))
+	},
+	BETWEEN: func(field expression.KeyBuilder, values []any) expression.KeyConditionBuilder {
+		return field.Between(expression.Value(values[0]), expression.Value(values[1]))
+	},
+	BEGINS_WITH: func(field expression.KeyBuilder, values []any) expression.KeyConditionBuilder {
+		return field.BeginsWith(fmt.Sprintf("%v", values[0]))
+	},
+}
+
+// allowedKeyConditionOperators defines operators valid for key conditions.
+// Single source of truth for key condition validation.
+var allowedKeyConditionOperators = map[OperatorType]bool{
+	EQ:          true,
+	GT:          true,
+	LT:          true,
+	GTE:         true,
+	LTE:         true,
+	BETWEEN:     true,
+	BEGINS_WITH: true,
+}
+
+// Includes all operators supported in filter expressions.
+var conditionOperatorHandlers = map[OperatorType]ConditionOperatorHandler{
+	EQ: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.Equal(expression.Value(values[0]))
+	},
+	NE: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.NotEqual(expression.Value(values[0]))
+	},
+	GT: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.GreaterThan(expression.Value(values[0]))
+	},
+	LT: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.LessThan(expression.Value(values[0]))
+	},
+	GTE: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.GreaterThanEqual(expression.Value(values[0]))
+	},
+	LTE: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.LessThanEqual(expression.Value(values[0]))
+	},
+	BETWEEN: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.Between(expression.Value(values[0]), expression.Value(values[1]))
+	},
+
+	CONTAINS: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.Contains(fmt.Sprintf("%v", values[0]))
+	},
+	NOT_CONTAINS: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return expression.Not(field.Contains(fmt.Sprintf("%v", values[0])))
+	},
+	BEGINS_WITH: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.BeginsWith(fmt.Sprintf("%v", values[0]))
+	},
+
+	IN: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		if len(values) == 0 {
+			return expression.AttributeNotExists(field)
+		}
+		if len(values) == 1 {
+			return field.Equal(expression.Value(values[0]))
+		}
+		return inCondition(field, values)
+	},
+	NOT_IN: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		if len(values) == 0 {
+			return expression.AttributeExists(field)
+		}
+		if len(values) == 1 {
+			return field.NotEqual(expression.Value(values[0]))
+		}
+		return expression.Not(inCondition(field, values))
+	},
+
+	EXISTS: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return expression.AttributeExists(field)
+	},
+	NOT_EXISTS: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return expression.AttributeNotExists(field)
+	},
+
+	SIZE_EQ: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.Size().Equal(expression.Value(values[0]))
+	},
+	SIZE_GT: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.Size().GreaterThan(expression.Value(values[0]))
+	},
+	SIZE_LT: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.Size().LessThan(expression.Value(values[0]))
+	},
+	SIZE_GTE: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.Size().GreaterThanEqual(expression.Value(values[0]))
+	},
+	SIZE_LTE: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.Size().LessThanEqual(expression.Value(values[0]))
+	},
+	SIZE_BETWEEN: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.Size().Between(expression.Value(values[0]), expression.Value(values[1]))
+	},
+
+	ATTRIBUTE_TYPE: func(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+		return field.AttributeType(expression.DynamoDBAttributeType(fmt.Sprintf("%v", values[0])))
+	},
+}
+
+// maxInOperands is the largest operand list of one IN comparison accepted by DynamoDB.
+// Longer lists are split into IN comparisons joined with OR; the whole expression
+// still has to fit the 4 KB expression limit.
+const maxInOperands = 100
+
+// MaxInValues is the largest value list of IN and NOT_IN conditions, longer lists are rejected.
+// With numbered placeholders a list of this size takes less than half of the 4 KB expression limit;
+// WithStablePlaceholders and long attribute names make it longer. Query larger sets in several
+// requests, or use BatchGetItems when the values are keys.
+const MaxInValues = 300
+
+// inCondition returns the IN comparison of field with at least two values,
+// split into OR-ed comparisons of at most maxInOperands values.
+func inCondition(field expression.NameBuilder, values []any) expression.ConditionBuilder {
+	var chunks []expression.ConditionBuilder
+	for start := 0; start < len(values); start += maxInOperands {
+		chunk := values[start:min(start+maxInOperands, len(values))]
+		operands := make([]expression.OperandBuilder, len(chunk))
+		for i, v := range chunk {
+			operands[i] = expression.Value(v)
+		}
+		chunks = append(chunks, field.In(operands[0], operands[1:]...))
+	}
+	if len(chunks) == 1 {
+		return chunks[0]
+	}
+	return expression.Or(chunks[0], chunks[1], chunks[2:]...)
+}
+
+// ValidateValues checks if the number of values is correct for the operator.
+// Prevents runtime errors by validating value count at build time.
+func ValidateValues(op OperatorType, values []any) bool {
+	switch op {
+	case EQ, NE, GT, LT, GTE, LTE, CONTAINS, NOT_CONTAINS, BEGINS_WITH:
+		return len(values) == 1
+	case SIZE_EQ, SIZE_GT, SIZE_LT, SIZE_GTE, SIZE_LTE:
+		return len(values) == 1
+	case ATTRIBUTE_TYPE:
+		return len(values) == 1 && attributeTypes[fmt.Sprintf("%v", values[0])]
+	case BETWEEN, SIZE_BETWEEN:
+		return len(values) == 2
+	case IN, NOT_IN:
+		return len(values) >= 1
+	case EXISTS, NOT_EXISTS:
+		return len(values) == 0
+	default:
+		return false
+	}
+}
+
+// IsKeyConditionOperator checks if operator can be used in key conditions.
+// Key conditions have stricter rules than filter conditions.
+func IsKeyConditionOperator(op OperatorType) bool {
+	return allowedKeyConditionOperators[op]
+}
+
+// ValidateOperator checks if operator is valid for the given field using schema.
+// Provides type-safe operator validation based on DynamoDB field types.
+func ValidateOperator(fieldName string, op OperatorType) bool {
+	if fi, ok := TableSchema.FieldsMap[fieldName]; ok {
+		return fi.SupportsOperator(op)
+	}
+	return false
+}
+
+// BuildConditionExpression converts operator to DynamoDB filter expression.
+// Creates type-safe filter conditions with full validation.
+func BuildConditionExpression(field string, op OperatorType, values []any) (expression.ConditionBuilder, error) {
+	fieldInfo, exists := TableSchema.FieldsMap[field]
+	if !exists {
+		return expression.ConditionBuilder{}, fmt.Errorf("field %s not found in schema", field)
+	}
+	if !fieldInfo.SupportsOperator(op) {
+		return expression.ConditionBuilder{}, fmt.Errorf("operator %s not supported for field %s (type %s)", op, field, fieldInfo.DynamoType)
+	}
+	if !ValidateValues(op, values) {
+		return expression.ConditionBuilder{}, fmt.Errorf("invalid values for operator %s", op)
+	}
+	if (op == IN || op == NOT_IN) && len(values) > MaxInValues {
+		return expression.ConditionBuilder{}, fmt.Errorf("operator %s on field %s: %d values exceed MaxInValues (%d)", op, field, len(values), MaxInValues)
+	}
+
+	handler := conditionOperatorHandlers[op]
+	fieldExpr := expression.Name(field)
+	result := handler(fieldExpr, values)
+	return result, nil
+}
+
+// BuildKeyConditionExpression converts operator to DynamoDB key condition.
+// Creates type-safe key conditions for Query operations only.
+func BuildKeyConditionExpression(field string, op OperatorType, values []any) (expression.KeyConditionBuilder, error) {
+	fieldInfo, exists := TableSchema.FieldsMap[field]
+	if !exists {
+		return expression.KeyConditionBuilder{}, fmt.Errorf("field %s not found in schema", field)
+	}
+	if !fieldInfo.IsKey {
+		return expression.KeyConditionBuilder{}, fmt.Errorf("field %s is not a key field", field)
+	}
+	if !fieldInfo.SupportsOperator(op) {
+		return expression.KeyConditionBuilder{}, fmt.Errorf("operator %s not supported for field %s (type %s)", op, field, fieldInfo.DynamoType)
+	}
+	if !ValidateValues(op, values) {
+		return expression.KeyConditionBuilder{}, fmt.Errorf("invalid number of values for operator %s", op)
+	}
+
+	handler := keyOperatorHandlers[op]
+	fieldExpr := expression.Key(field)
+	result := handler(fieldExpr, values)
+	return result, nil
+}
+
+// FieldInfo contains metadata about a schema field with operator validation.
+type FieldInfo struct {
+	DynamoType       string
+	IsKey            bool
+	IsHashKey        bool
+	IsRangeKey       bool
+	AllowedOperators map[OperatorType]bool
+}
+
+// SupportsOperator checks if this field supports the given operator.
+// Returns false for invalid operator/type combinations.
+func (fi FieldInfo) SupportsOperator(op OperatorType) bool {
+	return fi.AllowedOperators[op]
+}
+
+// buildAllowedOperators returns the set of allowed operators for a DynamoDB type.
+// Implements DynamoDB operator compatibility rules for each data type.
+func buildAllowedOperators(dynamoType string) map[OperatorType]bool {
+	allowed := make(map[OperatorType]bool)
+
+	switch dynamoType {
+	case "S": // String - supports all comparison and string operations
+		allowed[EQ] = true
+		allowed[NE] = true
+		allowed[GT] = true
+		allowed[LT] = true
+		allowed[GTE] = true
+		allowed[LTE] = true
+		allowed[BETWEEN] = true
+		allowed[CONTAINS] = true
+		allowed[NOT_CONTAINS] = true
+		allowed[BEGINS_WITH] = true
+		allowed[IN] = true
+		allowed[NOT_IN] = true
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+
+	case "N": // Number - supports comparison operations, no string functions
+		allowed[EQ] = true
+		allowed[NE] = true
+		allowed[GT] = true
+		allowed[LT] = true
+		allowed[GTE] = true
+		allowed[LTE] = true
+		allowed[BETWEEN] = true
+		allowed[IN] = true
+		allowed[NOT_IN] = true
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+
+	case "B": // Binary - compared byte-wise, no string functions
+		allowed[EQ] = true
+		allowed[NE] = true
+		allowed[GT] = true
+		allowed[LT] = true
+		allowed[GTE] = true
+		allowed[LTE] = true
+		allowed[BETWEEN] = true
+		allowed[IN] = true
+		allowed[NOT_IN] = true
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+
+	case "BOOL": // Boolean - only equality and existence checks
+		allowed[EQ] = true
+		allowed[NE] = true
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+
+	case "SS": // String Set - membership operations only, not IN/NOT_IN
+		allowed[CONTAINS] = true
+		allowed[NOT_CONTAINS] = true
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+
+	case "NS": // Number Set - membership operations only, not IN/NOT_IN
+		allowed[CONTAINS] = true
+		allowed[NOT_CONTAINS] = true
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+
+	case "BS": // Binary Set - existence checks only, contains takes strings
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+
+	case "L": // List - only existence checks
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+
+	case "M": // Map - only existence checks
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+
+	case "NULL": // Null - only existence checks
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+
+	default:
+		// Unknown types - basic operations only
+		allowed[EQ] = true
+		allowed[NE] = true
+		allowed[EXISTS] = true
+		allowed[NOT_EXISTS] = true
+	}
+
+	// attribute_type works with all types, size() with types having a length.
+	allowed[ATTRIBUTE_TYPE] = true
+	switch dynamoType {
+	case "S", "B", "SS", "NS", "BS", "L", "M":
+		for _, op := range sizeOperators {
+			allowed[op] = true
+		}
+	}
+	return allowed
+}
+
+// DynamoSchema represents the complete table schema with indexes and metadata.
+type DynamoSchema struct {
+	TableName        string
+	HashKey          string
+	RangeKey         string
+	Attributes       []Attribute
+	CommonAttributes []Attribute
+	SecondaryIndexes []SecondaryIndex
+	FieldsMap        map[string]FieldInfo
+}
+
+// Attribute represents a DynamoDB table attribute with its type.
+type Attribute struct {
+	Name      string // Attribute name
+	Type      string // DynamoDB type (S, N, BOOL, SS, NS, etc.)
+	Sensitive bool   // marked sensitive in the schema, see EncryptItem
+}
+
+// CompositeKeyPart represents a part of a composite key structure.
+// Used for complex key patterns in GSI/LSI definitions.
+type CompositeKeyPart struct {
+	IsConstant bool   // true if this part is a constant value
+	Value      string // the constant value or attribute name
+}
+
+// SecondaryIndex represents a GSI or LSI with optional composite keys.
+// Supports both simple and composite key structures for advanced access patterns.
+type SecondaryIndex struct {
+	Name             string
+	Type             string // GSI or LSI
+	HashKey          string
+	RangeKey         string
+	ProjectionType   string
+	HashKeyParts     []CompositeKeyPart // for composite hash keys
+	RangeKeyParts    []CompositeKeyPart // for composite range keys
+	NonKeyAttributes []string           // projected attributes for INCLUDE
+}
+
+// SchemaItem represents a single DynamoDB item with all table attributes.
+// All fields are properly tagged for AWS SDK marshaling/unmarshaling.
+// Example:
+//
+//	item := SchemaItem{
+//	    Pk: "pk-1",
+//	    Sk: "sk-1",
+//	}
+type SchemaItem struct {
+	Pk        string `dynamodbav:"pk"`
+	Sk        string `dynamodbav:"sk"`
+	Requests  int    `dynamodbav:"requests"`
+	ExpiresAt int64  `dynamodbav:"expires_at"`
+}
+
+// TableSchema contains the complete schema definition with pre-computed metadata.
+// Used throughout the generated code for validation and operator checking.
+var TableSchema = DynamoSchema{
+	TableName: "api-usage",
+	HashKey:   "pk",
+	RangeKey:  "sk",
+
+	Attributes: []Attribute{
+		{Name: "pk", Type: "S"},
+		{Name: "sk", Type: "S"},
+	},
+	CommonAttributes: []Attribute{
+		{Name: "requests", Type: "N"},
+		{Name: "expires_at", Type: "N"},
+	},
+	SecondaryIndexes: []SecondaryIndex{},
+	FieldsMap: map[string]FieldInfo{
+		"pk": {
+			DynamoType:       "S",
+			IsKey:            true,
+			IsHashKey:        true,
+			IsRangeKey:       false,
+			AllowedOperators: buildAllowedOperators("S"),
+		},
+		"sk": {
+			DynamoType:       "S",
+			IsKey:            true,
+			IsHashKey:        false,
+			IsRangeKey:       true,
+			AllowedOperators: buildAllowedOperators("S"),
+		},
+		"requests": {
+			DynamoType:       "N",
+			IsKey:            false,
+			IsHashKey:        false,
+			IsRangeKey:       false,
+			AllowedOperators: buildAllowedOperators("N"),
+		},
+		"expires_at": {
+			DynamoType:       "N",
+			IsKey:            false,
+			IsHashKey:        false,
+			IsRangeKey:       false,
+			AllowedOperators: buildAllowedOperators("N"),
+		},
+	},
+}
+
+// DynamoDBAPI is the subset of the DynamoDB client used by generated code.
+// *dynamodb.Client satisfies it; decorators and test doubles can wrap it.
+type DynamoDBAPI interface {
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+	TransactGetItems(ctx context.Context, params *dynamodb.TransactGetItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactGetItemsOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error)
+	ListTagsOfResource(ctx context.Context, params *dynamodb.ListTagsOfResourceInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ListTagsOfResourceOutput, error)
+}
+
+// Compile-time check that the AWS SDK client implements DynamoDBAPI.
+var _ DynamoDBAPI = (*dynamodb.Client)(nil)
+
+// CompositeKeySeparator joins the parts of composite key values (e.g. "USER#123#active").
+const CompositeKeySeparator = "#"
+
+// compositeKeySeparator returns the separator of the composite key with the parts.
+func compositeKeySeparator(parts []CompositeKeyPart) string {
+	return CompositeKeySeparator
+}
+
+// BuildCompositeKey joins constant parts and attribute values into a composite key value.
+// Returns an error if a value is missing or contains the separator,
+// since such a value could not be parsed back unambiguously.
+func BuildCompositeKey(parts []CompositeKeyPart, values map[string]string) (string, error) {
+	sep := compositeKeySeparator(parts)
+	out := make([]string, len(parts))
+	for i, part := range parts {
+		if part.IsConstant {
+			out[i] = part.Value
+			continue
+		}
+		v, ok := values[part.Value]
+		if !ok {
+			return "", fmt.Errorf("missing value for composite key part %s", part.Value)
+		}
+		if strings.Contains(v, sep) {
+			return "", fmt.Errorf("value for composite key part %s contains separator %q", part.Value, sep)
+		}
+		out[i] = v
+	}
+	return strings.Join(out, sep), nil
+}
+
+// ParseCompositeKey splits a composite key value into its attribute values.
+// Constant parts must match exactly; returns attribute name → raw string value.
+// Example:
+//
+//	values, err := ParseCompositeKey("USER#42", TableSchema.SecondaryIndexes[0].HashKeyParts)
+//	// values: map[user_id:42] for parts "USER" (constant) and "user_id"
+func ParseCompositeKey(value string, parts []CompositeKeyPart) (map[string]string, error) {
+	segments := strings.Split(value, compositeKeySeparator(parts))
+	if len(segments) != len(parts) {
+		return nil, fmt.Errorf("composite key %q has %d parts, expected %d", value, len(segments), len(parts))
+	}
+	values := make(map[string]string, len(parts))
+	for i, part := range parts {
+		if part.IsConstant {
+			if segments[i] != part.Value {
+				return nil, fmt.Errorf("composite key %q: part %d is %q, expected constant %q", value, i, segments[i], part.Value)
+			}
+			continue
+		}
+		values[part.Value] = segments[i]
+	}
+	return values, nil
+}
+
+// FilterMixin provides common filtering logic for Query and Scan operations.
+// Supports all DynamoDB filter operators with type validation.
+type FilterMixin struct {
+	FilterConditions []expression.ConditionBuilder
+	FilterFields     []string    // attribute of each FilterConditions entry
+	AppliedFilters   []Condition // filters in the order they were added, replayed by UnmarshalJSON
+	UsedKeys         map[string]bool
+	Attributes       map[string]any
+	filterErr        error // filter rejected by BuildConditionExpression, returned when the input is built
+}
+
+// NewFilterMixin creates a new FilterMixin instance with initialized maps.
+func NewFilterMixin() FilterMixin {
+	return FilterMixin{
+		FilterConditions: make([]expression.ConditionBuilder, 0),
+		FilterFields:     make([]string, 0),
+		UsedKeys:         make(map[string]bool),
+		Attributes:       make(map[string]any),
+	}
+}
+
+// Filter adds a filter condition using the universal operator system.
+// Validates operator compatibility and value types before adding.
+func (fm *FilterMixin) Filter(field string, op OperatorType, values ...any) {
+	if !ValidateValues(op, values) {
+		return
+	}
+	if !ValidateOperator(field, op) {
+		return
+	}
+
+	filterCond, err := BuildConditionExpression(field, op, values)
+	if err != nil {
+		fm.filterErr = err
+		return
+	}
+
+	fm.FilterConditions = append(fm.FilterConditions, filterCond)
+	fm.FilterFields = append(fm.FilterFields, field)
+	fm.AppliedFilters = append(fm.AppliedFilters, Condition{Field: field, Operator: op, Values: values, Type: FilterCondition})
+	fm.UsedKeys[field] = true
+
+	if op == EQ && len(values) == 1 {
+		fm.Attributes[field] = values[0]
+	}
+}
+
+// DefaultMaxPages caps the number of requests Execute sends to collect LimitResults items.
+const DefaultMaxPages = 100
+
+// PaginationMixin provides pagination support for Query and Scan operations.
+type PaginationMixin struct {
+	LimitValue        *int
+	PageSizeValue     *int
+	ResultLimitValue  *int
+	MaxItemsValue     *int
+	MaxPagesValue     int
+	ExclusiveStartKey map[string]types.AttributeValue
+	cursorErr         error // invalid cursor of StartFromCursor, returned when the input is built
+	fromCursor        bool  // ExclusiveStartKey is decoded from a cursor, checked against the index read
+}
+
+// NewPaginationMixin creates a new PaginationMixin instance.
+func NewPaginationMixin() PaginationMixin {
+	return PaginationMixin{}
+}
+
+// Limit sets the maximum number of items to return.
+// Execute sends it as the request Limit unless PageSize is set, ExecuteAll caps the total with it.
+func (pm *PaginationMixin) Limit(limit int) {
+	pm.LimitValue = &limit
+}
+
+// PageSize sets the number of items DynamoDB evaluates per request (the request Limit).
+// Tunes read capacity per call independently of how many items Limit returns.
+func (pm *PaginationMixin) PageSize(size int) {
+	pm.PageSizeValue = &size
+}
+
+// requestLimit returns the Limit sent with each request, nil for none.
+func (pm *PaginationMixin) requestLimit() *int32 {
+	switch {
+	case pm.PageSizeValue != nil:
+		return aws.Int32(int32(*pm.PageSizeValue))
+	case pm.LimitValue != nil:
+		return aws.Int32(int32(*pm.LimitValue))
+	}
+	return nil
+}
+
+// MaxItems sets the number of items ExecuteAll returns at most.
+// Unlike Limit, it doesn't change the Limit sent with each request.
+func (pm *PaginationMixin) MaxItems(maxItems int) {
+	pm.MaxItemsValue = &maxItems
+}
+
+// totalLimit returns the cap of items ExecuteAll returns, -1 for no cap.
+// MaxItems takes precedence over Limit.
+func (pm *PaginationMixin) totalLimit() int {
+	if pm.MaxItemsValue != nil {
+		return *pm.MaxItemsValue
+	}
+	if pm.LimitValue != nil {
+		return *pm.LimitValue
+	}
+	return -1
+}
+
+// LimitResults sets the number of items to return after filters are applied.
+// Unlike Limit (items evaluated per request), Execute keeps paginating until
+// limit items are collected, the data is exhausted or the page cap is reached.
+func (pm *PaginationMixin) LimitResults(limit int) {
+	pm.ResultLimitValue = &limit
+}
+
+// MaxPages sets the page cap for LimitResults, DefaultMaxPages if not positive.
+func (pm *PaginationMixin) MaxPages(pages int) {
+	pm.MaxPagesValue = pages
+}
+
+// maxPages returns the effective page cap for LimitResults.
+func (pm *PaginationMixin) maxPages() int {
+	if pm.MaxPagesValue > 0 {
+		return pm.MaxPagesValue
+	}
+	return DefaultMaxPages
+}
+
+// StartFrom sets the exclusive start key for pagination.
+// Use LastEvaluatedKey from previous response for next page.
+func (pm *PaginationMixin) StartFrom(lastEvaluatedKey map[string]types.AttributeValue) {
+	pm.ExclusiveStartKey = lastEvaluatedKey
+	pm.cursorErr = nil
+	pm.fromCursor = false
+}
+
+// StartFromCursor sets the exclusive start key from a NextCursor token of a previous Page.
+// An invalid token fails the request when it is built; an empty token starts from the first page.
+func (pm *PaginationMixin) StartFromCursor(token string) {
+	pm.ExclusiveStartKey, pm.cursorErr = DecodeCursor(token)
+	pm.fromCursor = pm.ExclusiveStartKey != nil
+}
+
+// checkCursor returns the error of StartFromCursor, also if the cursor holds keys of another index than indexName.
+func (pm *PaginationMixin) checkCursor(indexName string) error {
+	if pm.cursorErr != nil || !pm.fromCursor {
+		return pm.cursorErr
+	}
+	return checkStartKey(pm.ExclusiveStartKey, indexName)
+}
+
+// KeyConditionMixin provides key condition logic for Query operations only.
+// Supports partition key and sort key conditions with automatic index selection.
+type KeyConditionMixin struct {
+	KeyConditions        map[string]expression.KeyConditionBuilder
+	AppliedKeyConditions []Condition // key conditions in the order they were added, replayed by UnmarshalJSON
+	SortDescending       bool
+	PreferredSortKey     string
+}
+
+// NewKeyConditionMixin creates a new KeyConditionMixin instance.
+func NewKeyConditionMixin() KeyConditionMixin {
+	return KeyConditionMixin{
+		KeyConditions: make(map[string]expression.KeyConditionBuilder),
+	}
+}
+
+// With adds a key condition using the universal operator system.
+// Only valid for partition and sort key attributes.
+func (kcm *KeyConditionMixin) With(field string, op OperatorType, values ...any) {
+	if !ValidateValues(op, values) {
+		return
+	}
+	fieldInfo, exists := TableSchema.FieldsMap[field]
+	if !exists {
+		return
+	}
+	if !fieldInfo.IsKey {
+		return
+	}
+	if !ValidateOperator(field, op) {
+		return
+	}
+
+	keyCond, err := BuildKeyConditionExpression(field, op, values)
+	if err != nil {
+		return
+	}
+	kcm.KeyConditions[field] = keyCond
+	kcm.AppliedKeyConditions = append(kcm.AppliedKeyConditions, Condition{Field: field, Operator: op, Values: values, Type: KeyCondition})
+}
+
+// WithPreferredSortKey sets preferred sort key for index selection.
+// Useful when multiple indexes match the query pattern.
+func (kcm *KeyConditionMixin) WithPreferredSortKey(key string) {
+	kcm.PreferredSortKey = key
+}
+
+// OrderByDesc sets descending sort order for results.
+// Only affects sort key ordering, not filter results.
+func (kcm *KeyConditionMixin) OrderByDesc() {
+	kcm.SortDescending = true
+}
+
+// OrderByAsc sets ascending sort order for results (default).
+func (kcm *KeyConditionMixin) OrderByAsc() {
+	kcm.SortDescending = false
+}
+
+// placeholderPattern matches the name (#0) and value (:0) placeholders of built expressions.
+var placeholderPattern = regexp.MustCompile("[#:][A-Za-z0-9_]+")
+
+// stablePlaceholders renames the numbered placeholders the expression builder generates after
+// the attributes they refer to, so the same request always has the same placeholders:
+// #0 becomes #<attribute>, values become :<attribute>, :<attribute>_2, ... after the attribute
+// preceding them in the expressions. Characters not allowed in placeholders are replaced by '_'.
+// The expressions are rewritten in place, the renamed names and values are returned.
+func stablePlaceholders(names map[string]string, values map[string]types.AttributeValue, exprs ...*string) (map[string]string, map[string]types.AttributeValue) {
+	renamed := make(map[string]string, len(names)+len(values))
+	taken := make(map[string]bool, len(names)+len(values))
+	unique := func(base string) string {
+		name := base
+		for i := 2; taken[name]; i++ {
+			name = fmt.Sprintf("%s_%d", base, i)
+		}
+		taken[name] = true
+		return name
+	}
+
+	placeholders := slices.Collect(maps.Keys(names))
+	slices.SortFunc(placeholders, func(a, b string) int {
+		return cmp.Or(cmp.Compare(names[a], names[b]), cmp.Compare(a, b))
+	})
+	for _, placeholder := range placeholders {
+		renamed[placeholder] = unique("#" + placeholderName(names[placeholder]))
+	}
+	for _, expr := range exprs {
+		if expr == nil {
+			continue
+		}
+		attr := "v"
+		for _, placeholder := range placeholderPattern.FindAllString(*expr, -1) {
+			if name, ok := names[placeholder]; ok {
+				attr = placeholderName(name)
+				continue
+			}
+			if _, ok := values[placeholder]; ok && renamed[placeholder] == "" {
+				renamed[placeholder] = unique(":" + attr)
+			}
+		}
+	}
+	for _, placeholder := range slices.Sorted(maps.Keys(values)) {
+		if renamed[placeholder] == "" {
+			renamed[placeholder] = unique(":v")
+		}
+	}
+
+	for _, expr := range exprs {
+		if expr != nil {
+			*expr = placeholderPattern.ReplaceAllStringFunc(*expr, func(placeholder string) string {
+				if name, ok := renamed[placeholder]; ok {
+					return name
+				}
+				return placeholder
+			})
+		}
+	}
+	var stableNames map[string]string
+	if names != nil {
+		stableNames = make(map[string]string, len(names))
+		for placeholder, name := range names {
+			stableNames[renamed[placeholder]] = name
+		}
+	}
+	var stableValues map[string]types.AttributeValue
+	if values != nil {
+		stableValues = make(map[string]types.AttributeValue, len(values))
+		for placeholder, value := range values {
+			stableValues[renamed[placeholder]] = value
+		}
+	}
+	return stableNames, stableValues
+}
+
+// placeholderName returns the attribute name with characters not allowed in placeholders replaced by '_'.
+func placeholderName(attr string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return '_'
+	}, attr)
+}
+
+// pageFetcher reads one page starting after startKey (nil for the first page).
+type pageFetcher func(ctx context.Context, startKey map[string]types.AttributeValue) ([]SchemaItem, map[string]types.AttributeValue, error)
+
+// fetchedPage is a page read ahead by the iterator.
+type fetchedPage struct {
+	items   []SchemaItem
+	lastKey map[string]types.AttributeValue
+	err     error
+}
+
+// PageIterator reads Query or Scan results page by page.
+// The next page is fetched while the caller processes the current one, at most one page ahead,
+// hiding request latency of sequential processing. Safe for concurrent use: each page is
+// returned to exactly one caller of Next.
+// Example:
+//
+//	it, err := NewQueryBuilder().With(ColumnPk, EQ, "pk-1").Iterate(ctx, client)
+//	if err != nil {
+//	    return err
+//	}
+//	defer it.Close()
+//	for items, ok := it.Next(); ok; items, ok = it.Next() {
+//	    process(items)
+//	}
+//	return it.Err()
+type PageIterator struct {
+	mu      sync.Mutex
+	pages   chan fetchedPage
+	cancel  context.CancelFunc
+	lastKey map[string]types.AttributeValue
+	err     error
+	closed  bool
+
+	// stopErr is the error of the caller's context which stopped prefetching,
+	// written before pages is closed.
+	stopErr error
+}
+
+// newPageIterator starts prefetching pages from startKey.
+func newPageIterator(ctx context.Context, startKey map[string]types.AttributeValue, fetch pageFetcher) *PageIterator {
+	prefetchCtx, cancel := context.WithCancel(ctx)
+	it := &PageIterator{
+		pages:  make(chan fetchedPage),
+		cancel: cancel,
+	}
+	go it.prefetch(ctx, prefetchCtx, startKey, fetch)
+	return it
+}
+
+// prefetch fetches pages until the results are exhausted, an error occurs or the iterator is closed.
+// The unbuffered channel blocks the fetch of page N+2 until page N+1 is taken.
+// If the caller's ctx is canceled before the last page is taken, its error is kept for Next,
+// also in place of the request error it caused: a truncated read must not look like a complete one. Close stops without an error.
+func (it *PageIterator) prefetch(ctx, prefetchCtx context.Context, startKey map[string]types.AttributeValue, fetch pageFetcher) {
+	defer close(it.pages)
+	for {
+		items, lastKey, err := fetch(prefetchCtx, startKey)
+		if err != nil && ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		select {
+		case it.pages <- fetchedPage{items: items, lastKey: lastKey, err: err}:
+		case <-prefetchCtx.Done():
+			it.stopErr = ctx.Err()
+			return
+		}
+		if err != nil || len(lastKey) == 0 {
+			return
+		}
+		startKey = lastKey
+	}
+}
+
+// Next returns the next page of items, false when all pages are read, the iterator
+// is closed, a request failed or ctx was canceled (see Err).
+func (it *PageIterator) Next() ([]SchemaItem, bool) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if it.closed || it.err != nil {
+		return nil, false
+	}
+	page, ok := <-it.pages
+	if !ok {
+		it.err = it.stopErr
+		return nil, false
+	}
+	if page.err != nil {
+		it.err = page.err
+		return nil, false
+	}
+	it.lastKey = page.lastKey
+	return page.items, true
+}
+
+// Err returns the error which stopped the iteration, ctx.Err() if ctx was canceled before
+// the last page; nil if the results were exhausted or the iterator was closed.
+func (it *PageIterator) Err() error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.err
+}
+
+// LastEvaluatedKey returns the key after the last page returned by Next, nil after the last page.
+// Pass it to StartFrom to resume later.
+func (it *PageIterator) LastEvaluatedKey() map[string]types.AttributeValue {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.lastKey
+}
+
+// Close stops prefetching. Next returns false afterwards.
+func (it *PageIterator) Close() {
+	it.cancel()
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.closed = true
+}
+
+// iterateItems yields the items of the pages of it one by one and closes it when the loop ends.
+// An error of building the request or of a page is yielded once with a zero item and ends the loop.
+func iterateItems(it *PageIterator, err error) iter.Seq2[SchemaItem, error] {
+	return func(yield func(SchemaItem, error) bool) {
+		if err != nil {
+			yield(SchemaItem{}, err)
+			return
+		}
+		defer it.Close()
+		for items, ok := it.Next(); ok; items, ok = it.Next() {
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(SchemaItem{}, err)
+		}
+	}
+}
+
+// Page is one page of Query or Scan results.
+// NextCursor resumes after the page with StartFromCursor, it is empty on the last page.
+type Page struct {
+	Items      []SchemaItem `json:"items"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+	HasMore    bool         `json:"has_more"`
+}
+
+// cursorSecret is the HMAC key signing cursors, nil if cursors are not signed.
+var cursorSecret atomic.Value
+
+// SetCursorSecret makes EncodeCursor sign cursors with HMAC-SHA256 and DecodeCursor reject
+// cursors without a valid signature, so clients can't forge start keys. Call it once at startup;
+// a nil or empty secret disables signing.
+func SetCursorSecret(secret []byte) {
+	cursorSecret.Store(append([]byte(nil), secret...))
+}
+
+// EncodeCursor converts a LastEvaluatedKey to an opaque URL-safe token, empty for an empty key.
+// The token is not encrypted: key values can be read by decoding it.
+func EncodeCursor(lastEvaluatedKey map[string]types.AttributeValue) (string, error) {
+	encoded, err := encodeKeyAttributes(lastEvaluatedKey)
+	if err != nil || encoded == nil {
+		return "", err
+	}
+	payload, err := json.Marshal(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %v", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(payload)
+	if secret, _ := cursorSecret.Load().([]byte); len(secret) > 0 {
+		token += "." + base64.RawURLEncoding.EncodeToString(cursorSignature(secret, payload))
+	}
+	return token, nil
+}
+
+// DecodeCursor converts a token of EncodeCursor back to an exclusive start key, nil for an empty token.
+// Tokens with attributes which are not table or index keys, or with values of wrong types, are rejected;
+// StartFromCursor also rejects keys of another index than the one read.
+func DecodeCursor(token string) (map[string]types.AttributeValue, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, signature, signed := strings.Cut(token, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	if secret, _ := cursorSecret.Load().([]byte); len(secret) > 0 {
+		mac, err := base64.RawURLEncoding.DecodeString(signature)
+		if !signed || err != nil || !hmac.Equal(mac, cursorSignature(secret, payload)) {
+			return nil, fmt.Errorf("invalid cursor: bad signature")
+		}
+	}
+	var encoded map[string]keyAttributeValue
+	if err := json.Unmarshal(payload, &encoded); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	for name, v := range encoded {
+		if !isKeyAttribute(name) {
+			return nil, fmt.Errorf("invalid cursor: '%s' is not a key attribute", name)
+		}
+		info, ok := TableSchema.FieldsMap[name]
+		if !ok {
+			continue
+		}
+		if (info.DynamoType == "S" && v.S == nil) || (info.DynamoType == "N" && v.N == nil) || (info.DynamoType == "B" && v.B == nil) {
+			return nil, fmt.Errorf("invalid cursor: attribute '%s' must be of type %s", name, info.DynamoType)
+		}
+	}
+	return decodeKeyAttributes(encoded)
+}
+
+// isKeyAttribute reports whether name is the hash or range key of the table or a secondary index.
+func isKeyAttribute(name string) bool {
+	if name == TableSchema.HashKey || (TableSchema.RangeKey != "" && name == TableSchema.RangeKey) {
+		return true
+	}
+	return isIndexKeyAttribute(name)
+}
+
+// isIndexKeyAttribute reports whether name is the hash or range key of a secondary index,
+// e.g. a composite key attribute which is not declared in the schema.
+func isIndexKeyAttribute(name string) bool {
+	for _, index := range TableSchema.SecondaryIndexes {
+		if index.HashKey == name || index.RangeKey == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrPartialResult matches every *PartialResultError:
+//
+//	if errors.Is(err, ErrPartialResult) { /* process items, resume later */ }
+var ErrPartialResult = errors.New("partial result")
+
+// ErrPageCapReached is wrapped by the *PartialResultError of a read stopped by MaxPages
+// before LimitResults items passed the filters.
+var ErrPageCapReached = errors.New("page cap reached")
+
+// PartialResultError reports an auto-paginating read interrupted by the cancellation or deadline
+// of its context, or stopped by its page cap. The items read before are returned with it; Cursor
+// resumes the read after them with StartFromCursor, it is empty if the read has to start over.
+type PartialResultError struct {
+	Items  int
+	Cursor string
+	Err    error
+}
+
+// Error implements error.
+func (e *PartialResultError) Error() string {
+	return fmt.Sprintf("partial result of %d items: %v", e.Items, e.Err)
+}
+
+// Unwrap returns the error of the context, context.Canceled or context.DeadlineExceeded, or ErrPageCapReached.
+func (e *PartialResultError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrPartialResult.
+func (e *PartialResultError) Is(target error) bool {
+	return target == ErrPartialResult
+}
+
+// keyAttributeNames returns the table key attributes and those of the index (none if empty),
+// the attributes of an exclusive start key of a read of the index.
+func keyAttributeNames(indexName string) []string {
+	names := []string{TableSchema.HashKey}
+	if TableSchema.RangeKey != "" {
+		names = append(names, TableSchema.RangeKey)
+	}
+	for _, idx := range TableSchema.SecondaryIndexes {
+		if idx.Name != indexName {
+			continue
+		}
+		for _, name := range []string{idx.HashKey, idx.RangeKey} {
+			if name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// checkStartKey rejects a start key with attributes which are not keys of the table or of the index read.
+func checkStartKey(key map[string]types.AttributeValue, indexName string) error {
+	names := keyAttributeNames(indexName)
+	for name := range key {
+		if slices.Contains(names, name) {
+			continue
+		}
+		if indexName == "" {
+			return fmt.Errorf("invalid cursor: '%s' is not a key attribute of table %s", name, TableName)
+		}
+		return fmt.Errorf("invalid cursor: '%s' is not a key attribute of index %s", name, indexName)
+	}
+	return nil
+}
+
+// itemKey returns the exclusive start key resuming a read of the index (the table if empty) after item.
+func itemKey(item map[string]types.AttributeValue, indexName string) map[string]types.AttributeValue {
+	names := keyAttributeNames(indexName)
+	key := make(map[string]types.AttributeValue, len(names))
+	for _, name := range names {
+		if av, ok := item[name]; ok {
+			key[name] = av
+		}
+	}
+	return key
+}
+
+// finishPages returns the cursor resuming at startKey after a paginated read of n items.
+// A non-nil stop error (of the context or ErrPageCapReached) is returned as a *PartialResultError.
+func finishPages(n int, startKey map[string]types.AttributeValue, stop error) (string, error) {
+	cursor, err := EncodeCursor(startKey)
+	if err != nil {
+		return "", err
+	}
+	if stop != nil {
+		return cursor, &PartialResultError{Items: n, Cursor: cursor, Err: stop}
+	}
+	return cursor, nil
+}
+
+// cursorSignature returns the HMAC-SHA256 of a cursor payload.
+func cursorSignature(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// keyAttributeValue is the JSON form of a key attribute value: S, N or B (base64).
+type keyAttributeValue struct {
+	S *string `json:"S,omitempty"`
+	N *string `json:"N,omitempty"`
+	B []byte  `json:"B,omitempty"`
+}
+
+// encodeKeyAttributes converts a key (e.g. LastEvaluatedKey) to its JSON form.
+func encodeKeyAttributes(key map[string]types.AttributeValue) (map[string]keyAttributeValue, error) {
+	if len(key) == 0 {
+		return nil, nil
+	}
+	encoded := make(map[string]keyAttributeValue, len(key))
+	for name, av := range key {
+		switch v := av.(type) {
+		case *types.AttributeValueMemberS:
+			encoded[name] = keyAttributeValue{S: aws.String(v.Value)}
+		case *types.AttributeValueMemberN:
+			encoded[name] = keyAttributeValue{N: aws.String(v.Value)}
+		case *types.AttributeValueMemberB:
+			encoded[name] = keyAttributeValue{B: v.Value}
+		default:
+			return nil, fmt.Errorf("key attribute '%s' has unsupported type %T", name, av)
+		}
+	}
+	return encoded, nil
+}
+
+// decodeKeyAttributes converts the JSON form of a key back to AttributeValues.
+func decodeKeyAttributes(encoded map[string]keyAttributeValue) (map[string]types.AttributeValue, error) {
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+	key := make(map[string]types.AttributeValue, len(encoded))
+	for name, v := range encoded {
+		switch {
+		case v.S != nil:
+			key[name] = &types.AttributeValueMemberS{Value: *v.S}
+		case v.N != nil:
+			key[name] = &types.AttributeValueMemberN{Value: *v.N}
+		case v.B != nil:
+			key[name] = &types.AttributeValueMemberB{Value: v.B}
+		default:
+			return nil, fmt.Errorf("key attribute '%s' has no value", name)
+		}
+	}
+	return key, nil
+}
+
+// CONVENIENCE METHODS - Only available in ALL mode
+
+// FilterEQ adds equality filter condition.
+func (fm *FilterMixin) FilterEQ(field string, value any) {
+	fm.Filter(field, EQ, value)
+}
+
+// FilterContains adds contains filter for strings or sets.
+func (fm *FilterMixin) FilterContains(field string, value any) {
+	fm.Filter(field, CONTAINS, value)
+}
+
+// FilterNotContains adds not contains filter for strings or sets.
+func (fm *FilterMixin) FilterNotContains(field string, value any) {
+	fm.Filter(field, NOT_CONTAINS, value)
+}
+
+// FilterBeginsWith adds begins_with filter for strings.
+func (fm *FilterMixin) FilterBeginsWith(field string, value any) {
+	fm.Filter(field, BEGINS_WITH, value)
+}
+
+// FilterBetween adds range filter for comparable values.
+func (fm *FilterMixin) FilterBetween(field string, start, end any) {
+	fm.Filter(field, BETWEEN, start, end)
+}
+
+// FilterGT adds greater than filter.
+func (fm *FilterMixin) FilterGT(field string, value any) {
+	fm.Filter(field, GT, value)
+}
+
+// FilterLT adds less than filter.
+func (fm *FilterMixin) FilterLT(field string, value any) {
+	fm.Filter(field, LT, value)
+}
+
+// FilterGTE adds greater than or equal filter.
+func (fm *FilterMixin) FilterGTE(field string, value any) {
+	fm.Filter(field, GTE, value)
+}
+
+// FilterLTE adds less than or equal filter.
+func (fm *FilterMixin) FilterLTE(field string, value any) {
+	fm.Filter(field, LTE, value)
+}
+
+// FilterExists checks if attribute exists.
+func (fm *FilterMixin) FilterExists(field string) {
+	fm.Filter(field, EXISTS)
+}
+
+// FilterNotExists checks if attribute does not exist.
+func (fm *FilterMixin) FilterNotExists(field string) {
+	fm.Filter(field, NOT_EXISTS)
+}
+
+// FilterNE adds not equal filter.
+func (fm *FilterMixin) FilterNE(field string, value any) {
+	fm.Filter(field, NE, value)
+}
+
+// FilterIn adds IN filter for scalar values.
+// For DynamoDB Sets (SS/NS), use FilterContains instead.
+func (fm *FilterMixin) FilterIn(field string, values ...any) {
+	if len(values) == 0 {
+		return
+	}
+	fm.Filter(field, IN, values...)
+}
+
+// FilterNotIn adds NOT_IN filter for scalar values.
+// For DynamoDB Sets (SS/NS), use FilterNotContains instead.
+func (fm *FilterMixin) FilterNotIn(field string, values ...any) {
+	if len(values) == 0 {
+		return
+	}
+	fm.Filter(field, NOT_IN, values...)
+}
+
+// FilterSizeEQ adds size(field) = size filter.
+// Size is the length of strings, bytes of binaries and elements of sets, lists and maps.
+func (fm *FilterMixin) FilterSizeEQ(field string, size int) {
+	fm.Filter(field, SIZE_EQ, size)
+}
+
+// FilterSizeGT adds size(field) > size filter.
+func (fm *FilterMixin) FilterSizeGT(field string, size int) {
+	fm.Filter(field, SIZE_GT, size)
+}
+
+// FilterSizeLT adds size(field) < size filter.
+func (fm *FilterMixin) FilterSizeLT(field string, size int) {
+	fm.Filter(field, SIZE_LT, size)
+}
+
+// FilterSizeGTE adds size(field) >= size filter.
+func (fm *FilterMixin) FilterSizeGTE(field string, size int) {
+	fm.Filter(field, SIZE_GTE, size)
+}
+
+// FilterSizeLTE adds size(field) <= size filter.
+func (fm *FilterMixin) FilterSizeLTE(field string, size int) {
+	fm.Filter(field, SIZE_LTE, size)
+}
+
+// FilterSizeBetween adds size(field) BETWEEN minSize AND maxSize filter, both inclusive.
+func (fm *FilterMixin) FilterSizeBetween(field string, minSize, maxSize int) {
+	fm.Filter(field, SIZE_BETWEEN, minSize, maxSize)
+}
+
+// FilterAttributeType adds attribute_type filter matching items storing field as the DynamoDB type:
+// S, SS, N, NS, B, BS, BOOL, NULL, L or M. Other types are ignored.
+func (fm *FilterMixin) FilterAttributeType(field string, attrType string) {
+	fm.Filter(field, ATTRIBUTE_TYPE, attrType)
+}
+
+// filterValues converts typed values to the values of a Filter condition.
+func filterValues[T any](values []T) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+// CONVENIENCE METHODS - Only available in ALL mode
+
+// WithEQ adds equality key condition.
+// Required for partition key, optional for sort key.
+func (kcm *KeyConditionMixin) WithEQ(field string, value any) {
+	kcm.With(field, EQ, value)
+}
+
+// WithBetween adds range key condition for sort keys.
+func (kcm *KeyConditionMixin) WithBetween(field string, start, end any) {
+	kcm.With(field, BETWEEN, start, end)
+}
+
+// WithGT adds greater than key condition for sort keys.
+func (kcm *KeyConditionMixin) WithGT(field string, value any) {
+	kcm.With(field, GT, value)
+}
+
+// WithGTE adds greater than or equal key condition for sort keys.
+func (kcm *KeyConditionMixin) WithGTE(field string, value any) {
+	kcm.With(field, GTE, value)
+}
+
+// WithLT adds less than key condition for sort keys.
+func (kcm *KeyConditionMixin) WithLT(field string, value any) {
+	kcm.With(field, LT, value)
+}
+
+// WithLTE adds less than or equal key condition for sort keys.
+func (kcm *KeyConditionMixin) WithLTE(field string, value any) {
+	kcm.With(field, LTE, value)
+}
+
+// WithBeginsWith adds begins_with key condition for string sort keys.
+func (kcm *KeyConditionMixin) WithBeginsWith(field string, prefix string) {
+	kcm.With(field, BEGINS_WITH, prefix)
+}
+
+// QueryBuilder provides a fluent interface for building type-safe DynamoDB queries.
+// Combines FilterMixin, PaginationMixin, and KeyConditionMixin for comprehensive query building.
+// Supports automatic index selection, composite keys, and all DynamoDB query patterns.
+type QueryBuilder struct {
+	FilterMixin              // Filter conditions for any table attribute
+	PaginationMixin          // Limit and pagination support
+	KeyConditionMixin        // Key conditions for partition and sort keys
+	IndexName         string // Optional index name override
+
+	ConsistentRead     bool                         // Strongly consistent reads, only the table and LSIs are queried
+	StablePlaceholders bool                         // Attribute-derived expression placeholders, see WithStablePlaceholders
+	UnprojectedFilter  UnprojectedFilterPolicy      // Handling of filters on attributes not projected into a GSI
+	hydrateFilter      *expression.ConditionBuilder // Filters applied when re-reading index hits from the table
+	indexKeys          []indexKeyCondition          // WithIndex*Key calls in order, replayed by UnmarshalJSON
+	tableOnly          bool                         // Never select a secondary index (named queries of the table)
+	readFallback       ReadFallback                 // Consistency fallback of requests, not kept by MarshalJSON
+	freshness          FreshnessCheck               // Freshness check of EventualThenStrong
+}
+
+// indexKeyCondition records a key condition set by index name (WithIndexHashKey, WithIndexRangeKey*).
+type indexKeyCondition struct {
+	Index    string       `json:"index"`
+	Range    bool         `json:"range,omitempty"`
+	Operator OperatorType `json:"operator"`
+	Values   []any        `json:"values"`
+}
+
+// NewQueryBuilder creates a new QueryBuilder instance with initialized mixins.
+// All mixins are properly initialized for immediate use.
+// Example:
+//
+//	items, err := NewQueryBuilder().
+//	    With(ColumnPk, EQ, "pk-1").
+//	    Execute(ctx, client)
+func NewQueryBuilder() *QueryBuilder {
+	return &QueryBuilder{
+		FilterMixin:       NewFilterMixin(),
+		PaginationMixin:   NewPaginationMixin(),
+		KeyConditionMixin: NewKeyConditionMixin(),
+	}
+}
+
+// Limit sets the maximum number of items and returns QueryBuilder for method chaining.
+// Execute sends it as the request Limit unless PageSize is set;
+// ExecuteAll returns at most limit items across all pages.
+func (qb *QueryBuilder) Limit(limit int) *QueryBuilder {
+	qb.PaginationMixin.Limit(limit)
+	return qb
+}
+
+// PageSize sets the number of items DynamoDB evaluates per request and returns QueryBuilder for method chaining.
+// Use it to tune capacity consumed per call, independently of Limit.
+func (qb *QueryBuilder) PageSize(size int) *QueryBuilder {
+	qb.PaginationMixin.PageSize(size)
+	return qb
+}
+
+// LimitResults sets the number of items Execute returns after filters are applied.
+// Execute paginates until limit matching items are collected or MaxPages requests are sent.
+func (qb *QueryBuilder) LimitResults(limit int) *QueryBuilder {
+	qb.PaginationMixin.LimitResults(limit)
+	return qb
+}
+
+// MaxItems sets the number of items ExecuteAll returns at most and returns QueryBuilder for method chaining.
+// ExecuteAll follows LastEvaluatedKey until the data is exhausted or maxItems items are collected,
+// the request Limit (Limit or PageSize) stays unchanged.
+func (qb *QueryBuilder) MaxItems(maxItems int) *QueryBuilder {
+	qb.PaginationMixin.MaxItems(maxItems)
+	return qb
+}
+
+// MaxPages caps the number of requests LimitResults may send (DefaultMaxPages by default).
+// Reaching it returns the items read with a *PartialResultError wrapping ErrPageCapReached.
+func (qb *QueryBuilder) MaxPages(pages int) *QueryBuilder {
+	qb.PaginationMixin.MaxPages(pages)
+	return qb
+}
+
+// StartFrom sets the exclusive start key and returns QueryBuilder for method chaining.
+// Use LastEvaluatedKey from previous response for pagination.
+func (qb *QueryBuilder) StartFrom(lastEvaluatedKey map[string]types.AttributeValue) *QueryBuilder {
+	qb.PaginationMixin.StartFrom(lastEvaluatedKey)
+	return qb
+}
+
+// StartFromCursor resumes after the page whose NextCursor is token and returns QueryBuilder for method chaining.
+// Cursors come from ExecutePaginated and may be round-tripped through web clients.
+func (qb *QueryBuilder) StartFromCursor(token string) *QueryBuilder {
+	qb.PaginationMixin.StartFromCursor(token)
+	return qb
+}
+
+// WithIndex sets the index name for query a secondary index.
+// Allows query GSI or LSI instead of main table; Build fails if the key conditions don't match it.
+// Index must exist and be in ACTIVE state.
+func (qb *QueryBuilder) WithIndex(indexName string) *QueryBuilder {
+	qb.IndexName = indexName
+	return qb
+}
+
+// WithConsistentRead requests strongly consistent reads and returns QueryBuilder for method chaining.
+// GSIs don't support consistent reads: they are skipped by index selection,
+// and Build fails if WithIndex names one.
+func (qb *QueryBuilder) WithConsistentRead() *QueryBuilder {
+	qb.ConsistentRead = true
+	return qb
+}
+
+// WithStablePlaceholders names the expression placeholders of built requests after their attributes
+// (#pk, :pk) instead of numbering them, so request snapshots don't depend on build order.
+func (qb *QueryBuilder) WithStablePlaceholders() *QueryBuilder {
+	qb.StablePlaceholders = true
+	return qb
+}
+
+// WithStrongThenFallback sends every request strongly consistent and repeats it eventually consistent
+// if it fails, see StrongThenEventual. Like WithConsistentRead, GSIs can't be queried.
+func (qb *QueryBuilder) WithStrongThenFallback() *QueryBuilder {
+	qb.readFallback = StrongThenEventual
+	return qb
+}
+
+// WithStalenessCheck sends every request eventually consistent and repeats it strongly consistent
+// if an item of the page fails fresh, see EventualThenStrong. Like WithConsistentRead, GSIs can't be queried.
+func (qb *QueryBuilder) WithStalenessCheck(fresh FreshnessCheck) *QueryBuilder {
+	qb.readFallback = EventualThenStrong
+	qb.freshness = fresh
+	return qb
+}
+
+// consistentIndexOnly reports whether the query needs an index supporting consistent reads.
+func (qb *QueryBuilder) consistentIndexOnly() bool {
+	return qb.ConsistentRead || qb.readFallback != NoReadFallback
+}
+
+// OrderByDesc sets descending sort order and returns QueryBuilder for method chaining.
+// Only affects sort key ordering, not filter results.
+func (qb *QueryBuilder) OrderByDesc() *QueryBuilder {
+	qb.KeyConditionMixin.OrderByDesc()
+	return qb
+}
+
+// OrderByAsc sets ascending sort order and returns QueryBuilder for method chaining.
+// This is the default sort order.
+func (qb *QueryBuilder) OrderByAsc() *QueryBuilder {
+	qb.KeyConditionMixin.OrderByAsc()
+	return qb
+}
+
+// WithPreferredSortKey sets the preferred sort key and returns QueryBuilder for method chaining.
+// Hints the index selection algorithm when multiple indexes could satisfy the query.
+func (qb *QueryBuilder) WithPreferredSortKey(key string) *QueryBuilder {
+	qb.KeyConditionMixin.WithPreferredSortKey(key)
+	return qb
+}
+
+// HELPER METHODS for universal index access
+
+// getIndexByName finds index by name in schema metadata.
+func (qb *QueryBuilder) getIndexByName(indexName string) *SecondaryIndex {
+	for i := range TableSchema.SecondaryIndexes {
+		if TableSchema.SecondaryIndexes[i].Name == indexName {
+			return &TableSchema.SecondaryIndexes[i]
+		}
+	}
+	return nil
+}
+
+// getNonConstantParts returns only non-constant parts of composite key.
+func (qb *QueryBuilder) getNonConstantParts(parts []CompositeKeyPart) []CompositeKeyPart {
+	var result []CompositeKeyPart
+	for _, part := range parts {
+		if !part.IsConstant {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// setCompositeKey builds and sets composite key from parts and values.
+func (qb *QueryBuilder) setCompositeKey(keyName string, parts []CompositeKeyPart, values []any) {
+	nonConstantParts := qb.getNonConstantParts(parts)
+	for i, part := range nonConstantParts {
+		if i < len(values) {
+			qb.Attributes[part.Value] = values[i]
+			qb.UsedKeys[part.Value] = true
+		}
+	}
+	compositeValue, err := qb.buildCompositeKeyValue(parts)
+	if err != nil {
+		return // Build reports the rejected value when it selects the index
+	}
+	qb.Attributes[keyName] = compositeValue
+	qb.UsedKeys[keyName] = true
+	qb.KeyConditions[keyName] = expression.Key(keyName).Equal(expression.Value(compositeValue))
+}
+
+// SCHEMA INTROSPECTION METHODS
+
+// GetIndexNames returns all available index names.
+func GetIndexNames() []string {
+	names := make([]string, len(TableSchema.SecondaryIndexes))
+	for i, index := range TableSchema.SecondaryIndexes {
+		names[i] = index.Name
+	}
+	return names
+}
+
+// GetIndexInfo returns detailed information about an index.
+func GetIndexInfo(indexName string) *IndexInfo {
+	for _, index := range TableSchema.SecondaryIndexes {
+		if index.Name == indexName {
+			return &IndexInfo{
+				Name:             index.Name,
+				Type:             getIndexType(index),
+				HashKey:          index.HashKey,
+				RangeKey:         index.RangeKey,
+				IsHashComposite:  len(index.HashKeyParts) > 0,
+				IsRangeComposite: len(index.RangeKeyParts) > 0,
+				HashKeyParts:     countNonConstantParts(index.HashKeyParts),
+				RangeKeyParts:    countNonConstantParts(index.RangeKeyParts),
+				ProjectionType:   index.ProjectionType,
+			}
+		}
+	}
+	return nil
+}
+
+// IndexInfo provides metadata about a table index.
+type IndexInfo struct {
+	Name             string
+	Type             string
+	HashKey          string
+	RangeKey         string
+	IsHashComposite  bool
+	IsRangeComposite bool
+	HashKeyParts     int
+	RangeKeyParts    int
+	ProjectionType   string
+}
+
+// getIndexType returns human-readable index type.
+func getIndexType(index SecondaryIndex) string {
+	if index.HashKey == "" {
+		return "LSI"
+	}
+	return "GSI"
+}
+
+// countNonConstantParts counts non-constant parts in composite key.
+func countNonConstantParts(parts []CompositeKeyPart) int {
+	count := 0
+	for _, part := range parts {
+		if !part.IsConstant {
+			count++
+		}
+	}
+	return count
+}
+
+// With adds key condition and returns QueryBuilder for method chaining.
+// Only works with partition and sort key attributes for efficient querying.
+func (qb *QueryBuilder) With(field string, op OperatorType, values ...any) *QueryBuilder {
+	qb.KeyConditionMixin.With(field, op, values...)
+	if op == EQ && len(values) == 1 {
+		qb.Attributes[field] = values[0]
+		qb.UsedKeys[field] = true
+	}
+	return qb
+}
+
+// Filter adds a filter condition and returns QueryBuilder for method chaining.
+// Wraps FilterMixin.Filter with fluent interface support.
+func (qb *QueryBuilder) Filter(field string, op OperatorType, values ...any) *QueryBuilder {
+	qb.FilterMixin.Filter(field, op, values...)
+	return qb
+}
+
+// CONVENIENCE METHODS - Only available in ALL mode
+
+// WithEQ adds equality key condition and returns QueryBuilder for method chaining.
+// Required for partition keys, commonly used for sort keys.
+func (qb *QueryBuilder) WithEQ(field string, value any) *QueryBuilder {
+	qb.KeyConditionMixin.WithEQ(field, value)
+	qb.Attributes[field] = value
+	qb.UsedKeys[field] = true
+	return qb
+}
+
+// WithBetween adds range key condition and returns QueryBuilder for method chaining.
+// Only valid for sort keys, not partition keys.
+func (qb *QueryBuilder) WithBetween(field string, start, end any) *QueryBuilder {
+	qb.KeyConditionMixin.WithBetween(field, start, end)
+	qb.Attributes[field+"_start"] = start
+	qb.Attributes[field+"_end"] = end
+	qb.UsedKeys[field] = true
+	return qb
+}
+
+// WithGT adds greater than key condition and returns QueryBuilder for method chaining.
+// Only valid for sort keys in range queries.
+func (qb *QueryBuilder) WithGT(field string, value any) *QueryBuilder {
+	qb.KeyConditionMixin.WithGT(field, value)
+	qb.Attributes[field] = value
+	qb.UsedKeys[field] = true
+	return qb
+}
+
+// WithGTE adds greater than or equal key condition and returns QueryBuilder for method chaining.
+// Only valid for sort keys in range queries.
+func (qb *QueryBuilder) WithGTE(field string, value any) *QueryBuilder {
+	qb.KeyConditionMixin.WithGTE(field, value)
+	qb.Attributes[field] = value
+	qb.UsedKeys[field] = true
+	return qb
+}
+
+// WithLT adds less than key condition and returns QueryBuilder for method chaining.
+// Only valid for sort keys in range queries.
+func (qb *QueryBuilder) WithLT(field string, value any) *QueryBuilder {
+	qb.KeyConditionMixin.WithLT(field, value)
+	qb.Attributes[field] = value
+	qb.UsedKeys[field] = true
+	return qb
+}
+
+// WithLTE adds less than or equal key condition and returns QueryBuilder for method chaining.
+// Only valid for sort keys in range queries.
+func (qb *QueryBuilder) WithLTE(field string, value any) *QueryBuilder {
+	qb.KeyConditionMixin.WithLTE(field, value)
+	qb.Attributes[field] = value
+	qb.UsedKeys[field] = true
+	return qb
+}
+
+// WithBeginsWith adds begins_with key condition and returns QueryBuilder for method chaining.
+// Only valid for string sort keys, e.g. hierarchical keys like "ORDER#2024#01".
+func (qb *QueryBuilder) WithBeginsWith(field string, prefix string) *QueryBuilder {
+	qb.KeyConditionMixin.WithBeginsWith(field, prefix)
+	qb.Attributes[field] = prefix
+	qb.UsedKeys[field] = true
+	return qb
+}
+
+// WithSkBeginsWith adds the key condition begins_with(sk, prefix) and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) WithSkBeginsWith(prefix string) *QueryBuilder {
+	return qb.WithBeginsWith(ColumnSk, prefix)
+}
+
+// WithIndexHashKey sets hash key for any index by name.
+// Automatically handles both simple and composite keys based on schema metadata.
+// For composite keys, pass values in the order they appear in the schema.
+func (qb *QueryBuilder) WithIndexHashKey(indexName string, values ...any) *QueryBuilder {
+	index := qb.getIndexByName(indexName)
+	if index == nil {
+		return qb
+	}
+	if index.HashKeyParts != nil {
+		nonConstantParts := qb.getNonConstantParts(index.HashKeyParts)
+		if len(values) != len(nonConstantParts) {
+			return qb
+		}
+		qb.setCompositeKey(index.HashKey, index.HashKeyParts, values)
+	} else {
+		if len(values) != 1 {
+			return qb
+		}
+		qb.Attributes[index.HashKey] = values[0]
+		qb.UsedKeys[index.HashKey] = true
+		qb.KeyConditions[index.HashKey] = expression.Key(index.HashKey).Equal(expression.Value(values[0]))
+	}
+	qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Operator: EQ, Values: values})
+	return qb
+}
+
+// WithIndexRangeKey sets range key for any index by name.
+// Automatically handles both simple and composite keys based on schema metadata.
+// For composite keys, pass values in the order they appear in the schema.
+func (qb *QueryBuilder) WithIndexRangeKey(indexName string, values ...any) *QueryBuilder {
+	index := qb.getIndexByName(indexName)
+	if index == nil || index.RangeKey == "" {
+		return qb
+	}
+	if index.RangeKeyParts != nil {
+		nonConstantParts := qb.getNonConstantParts(index.RangeKeyParts)
+		if len(values) != len(nonConstantParts) {
+			return qb
+		}
+		qb.setCompositeKey(index.RangeKey, index.RangeKeyParts, values)
+	} else {
+		if len(values) != 1 {
+			return qb
+		}
+		qb.Attributes[index.RangeKey] = values[0]
+		qb.UsedKeys[index.RangeKey] = true
+		qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).Equal(expression.Value(values[0]))
+	}
+	qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: EQ, Values: values})
+	return qb
+}
+
+// WithIndexRangeKeyBetween sets range key condition for any index with BETWEEN operator.
+// Only works with simple range keys, not composite ones.
+func (qb *QueryBuilder) WithIndexRangeKeyBetween(indexName string, start, end any) *QueryBuilder {
+	index := qb.getIndexByName(indexName)
+	if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
+		return qb
+	}
+	qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).Between(expression.Value(start), expression.Value(end))
+	qb.UsedKeys[index.RangeKey] = true
+	qb.Attributes[index.RangeKey+"_start"] = start
+	qb.Attributes[index.RangeKey+"_end"] = end
+	qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: BETWEEN, Values: []any{start, end}})
+	return qb
+}
+
+// WithIndexRangeKeyGT sets range key condition for any index with GT operator.
+// Only works with simple range keys, not composite ones.
+func (qb *QueryBuilder) WithIndexRangeKeyGT(indexName string, value any) *QueryBuilder {
+	index := qb.getIndexByName(indexName)
+	if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
+		return qb
+	}
+	qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).GreaterThan(expression.Value(value))
+	qb.UsedKeys[index.RangeKey] = true
+	qb.Attributes[index.RangeKey] = value
+	qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: GT, Values: []any{value}})
+	return qb
+}
+
+// WithIndexRangeKeyLT sets range key condition for any index with LT operator.
+// Only works with simple range keys, not composite ones.
+func (qb *QueryBuilder) WithIndexRangeKeyLT(indexName string, value any) *QueryBuilder {
+	index := qb.getIndexByName(indexName)
+	if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
+		return qb
+	}
+	qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).LessThan(expression.Value(value))
+	qb.UsedKeys[index.RangeKey] = true
+	qb.Attributes[index.RangeKey] = value
+	qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: LT, Values: []any{value}})
+	return qb
+}
+
+// WithIndexRangeKeyGTE sets range key condition for any index with GTE operator.
+// Only works with simple range keys, not composite ones.
+func (qb *QueryBuilder) WithIndexRangeKeyGTE(indexName string, value any) *QueryBuilder {
+	index := qb.getIndexByName(indexName)
+	if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
+		return qb
+	}
+	qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).GreaterThanEqual(expression.Value(value))
+	qb.UsedKeys[index.RangeKey] = true
+	qb.Attributes[index.RangeKey] = value
+	qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: GTE, Values: []any{value}})
+	return qb
+}
+
+// WithIndexRangeKeyLTE sets range key condition for any index with LTE operator.
+// Only works with simple range keys, not composite ones.
+func (qb *QueryBuilder) WithIndexRangeKeyLTE(indexName string, value any) *QueryBuilder {
+	index := qb.getIndexByName(indexName)
+	if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
+		return qb
+	}
+	qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).LessThanEqual(expression.Value(value))
+	qb.UsedKeys[index.RangeKey] = true
+	qb.Attributes[index.RangeKey] = value
+	qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: LTE, Values: []any{value}})
+	return qb
+}
+
+// WithIndexRangeKeyBeginsWith sets range key condition for any index with BEGINS_WITH operator.
+// Only works with simple string range keys, not composite ones.
+func (qb *QueryBuilder) WithIndexRangeKeyBeginsWith(indexName string, prefix string) *QueryBuilder {
+	index := qb.getIndexByName(indexName)
+	if index == nil || index.RangeKey == "" || index.RangeKeyParts != nil {
+		return qb
+	}
+	qb.KeyConditions[index.RangeKey] = expression.Key(index.RangeKey).BeginsWith(prefix)
+	qb.UsedKeys[index.RangeKey] = true
+	qb.Attributes[index.RangeKey] = prefix
+	qb.indexKeys = append(qb.indexKeys, indexKeyCondition{Index: indexName, Range: true, Operator: BEGINS_WITH, Values: []any{prefix}})
+	return qb
+}
+
+// CONVENIENCE METHODS - Only available in ALL mode
+
+// FilterEQ adds equality filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterEQ(field string, value any) *QueryBuilder {
+	qb.FilterMixin.FilterEQ(field, value)
+	return qb
+}
+
+// FilterContains adds contains filter and returns QueryBuilder for method chaining.
+// Works with String attributes (substring) and Set attributes (membership).
+func (qb *QueryBuilder) FilterContains(field string, value any) *QueryBuilder {
+	qb.FilterMixin.FilterContains(field, value)
+	return qb
+}
+
+// FilterNotContains adds not contains filter and returns QueryBuilder for method chaining.
+// Opposite of FilterContains for exclusion filtering.
+func (qb *QueryBuilder) FilterNotContains(field string, value any) *QueryBuilder {
+	qb.FilterMixin.FilterNotContains(field, value)
+	return qb
+}
+
+// FilterBeginsWith adds begins_with filter and returns QueryBuilder for method chaining.
+// Only works with String attributes for prefix matching.
+func (qb *QueryBuilder) FilterBeginsWith(field string, value any) *QueryBuilder {
+	qb.FilterMixin.FilterBeginsWith(field, value)
+	return qb
+}
+
+// FilterBetween adds range filter and returns QueryBuilder for method chaining.
+// Works with comparable types for inclusive range filtering.
+func (qb *QueryBuilder) FilterBetween(field string, start, end any) *QueryBuilder {
+	qb.FilterMixin.FilterBetween(field, start, end)
+	return qb
+}
+
+// FilterGT adds greater than filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterGT(field string, value any) *QueryBuilder {
+	qb.FilterMixin.FilterGT(field, value)
+	return qb
+}
+
+// FilterLT adds less than filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterLT(field string, value any) *QueryBuilder {
+	qb.FilterMixin.FilterLT(field, value)
+	return qb
+}
+
+// FilterGTE adds greater than or equal filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterGTE(field string, value any) *QueryBuilder {
+	qb.FilterMixin.FilterGTE(field, value)
+	return qb
+}
+
+// FilterLTE adds less than or equal filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterLTE(field string, value any) *QueryBuilder {
+	qb.FilterMixin.FilterLTE(field, value)
+	return qb
+}
+
+// FilterExists adds attribute exists filter and returns QueryBuilder for method chaining.
+// Checks if the specified attribute exists in the item.
+func (qb *QueryBuilder) FilterExists(field string) *QueryBuilder {
+	qb.FilterMixin.FilterExists(field)
+	return qb
+}
+
+// FilterNotExists adds attribute not exists filter and returns QueryBuilder for method chaining.
+// Checks if the specified attribute does not exist in the item.
+func (qb *QueryBuilder) FilterNotExists(field string) *QueryBuilder {
+	qb.FilterMixin.FilterNotExists(field)
+	return qb
+}
+
+// FilterNE adds not equal filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterNE(field string, value any) *QueryBuilder {
+	qb.FilterMixin.FilterNE(field, value)
+	return qb
+}
+
+// FilterIn adds IN filter and returns QueryBuilder for method chaining.
+// For scalar values only - use FilterContains for DynamoDB Sets.
+func (qb *QueryBuilder) FilterIn(field string, values ...any) *QueryBuilder {
+	qb.FilterMixin.FilterIn(field, values...)
+	return qb
+}
+
+// FilterNotIn adds NOT_IN filter and returns QueryBuilder for method chaining.
+// For scalar values only - use FilterNotContains for DynamoDB Sets.
+func (qb *QueryBuilder) FilterNotIn(field string, values ...any) *QueryBuilder {
+	qb.FilterMixin.FilterNotIn(field, values...)
+	return qb
+}
+
+// FilterSizeEQ adds size(field) = size filter and returns QueryBuilder for method chaining.
+// Size is the length of strings, bytes of binaries and elements of sets, lists and maps.
+func (qb *QueryBuilder) FilterSizeEQ(field string, size int) *QueryBuilder {
+	qb.FilterMixin.FilterSizeEQ(field, size)
+	return qb
+}
+
+// FilterSizeGT adds size(field) > size filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSizeGT(field string, size int) *QueryBuilder {
+	qb.FilterMixin.FilterSizeGT(field, size)
+	return qb
+}
+
+// FilterSizeLT adds size(field) < size filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSizeLT(field string, size int) *QueryBuilder {
+	qb.FilterMixin.FilterSizeLT(field, size)
+	return qb
+}
+
+// FilterSizeGTE adds size(field) >= size filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSizeGTE(field string, size int) *QueryBuilder {
+	qb.FilterMixin.FilterSizeGTE(field, size)
+	return qb
+}
+
+// FilterSizeLTE adds size(field) <= size filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSizeLTE(field string, size int) *QueryBuilder {
+	qb.FilterMixin.FilterSizeLTE(field, size)
+	return qb
+}
+
+// FilterSizeBetween adds size(field) BETWEEN minSize AND maxSize filter and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSizeBetween(field string, minSize, maxSize int) *QueryBuilder {
+	qb.FilterMixin.FilterSizeBetween(field, minSize, maxSize)
+	return qb
+}
+
+// FilterAttributeType adds attribute_type filter and returns QueryBuilder for method chaining.
+// Useful in data quality scans for items storing field with another type than the schema declares.
+func (qb *QueryBuilder) FilterAttributeType(field string, attrType string) *QueryBuilder {
+	qb.FilterMixin.FilterAttributeType(field, attrType)
+	return qb
+}
+
+// FilterPkIn filters items whose pk is one of the values and returns QueryBuilder for method chaining.
+// Lists longer than 100 values are split into IN comparisons joined with OR,
+// lists longer than MaxInValues fail when the input is built.
+func (qb *QueryBuilder) FilterPkIn(values ...string) *QueryBuilder {
+	qb.FilterMixin.FilterIn(ColumnPk, filterValues(values)...)
+	return qb
+}
+
+// FilterPkNotIn filters items whose pk is none of the values and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterPkNotIn(values ...string) *QueryBuilder {
+	qb.FilterMixin.FilterNotIn(ColumnPk, filterValues(values)...)
+	return qb
+}
+
+// FilterSkIn filters items whose sk is one of the values and returns QueryBuilder for method chaining.
+// Lists longer than 100 values are split into IN comparisons joined with OR,
+// lists longer than MaxInValues fail when the input is built.
+func (qb *QueryBuilder) FilterSkIn(values ...string) *QueryBuilder {
+	qb.FilterMixin.FilterIn(ColumnSk, filterValues(values)...)
+	return qb
+}
+
+// FilterSkNotIn filters items whose sk is none of the values and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterSkNotIn(values ...string) *QueryBuilder {
+	qb.FilterMixin.FilterNotIn(ColumnSk, filterValues(values)...)
+	return qb
+}
+
+// FilterRequestsIn filters items whose requests is one of the values and returns QueryBuilder for method chaining.
+// Lists longer than 100 values are split into IN comparisons joined with OR,
+// lists longer than MaxInValues fail when the input is built.
+func (qb *QueryBuilder) FilterRequestsIn(values ...int) *QueryBuilder {
+	qb.FilterMixin.FilterIn(ColumnRequests, filterValues(values)...)
+	return qb
+}
+
+// FilterRequestsNotIn filters items whose requests is none of the values and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterRequestsNotIn(values ...int) *QueryBuilder {
+	qb.FilterMixin.FilterNotIn(ColumnRequests, filterValues(values)...)
+	return qb
+}
+
+// FilterExpiresAtIn filters items whose expires_at is one of the values and returns QueryBuilder for method chaining.
+// Lists longer than 100 values are split into IN comparisons joined with OR,
+// lists longer than MaxInValues fail when the input is built.
+func (qb *QueryBuilder) FilterExpiresAtIn(values ...int64) *QueryBuilder {
+	qb.FilterMixin.FilterIn(ColumnExpiresAt, filterValues(values)...)
+	return qb
+}
+
+// FilterExpiresAtNotIn filters items whose expires_at is none of the values and returns QueryBuilder for method chaining.
+func (qb *QueryBuilder) FilterExpiresAtNotIn(values ...int64) *QueryBuilder {
+	qb.FilterMixin.FilterNotIn(ColumnExpiresAt, filterValues(values)...)
+	return qb
+}
+
+// Build analyzes the query conditions and selects the optimal index for execution.
+// Implements smart index selection algorithm considering:
+// - Preferred sort key hints from user
+// - Number of composite key parts matched
+// - Index efficiency for the given query pattern
+// - Projection of filtered attributes, see OnUnprojectedFilter
+// WithIndex restricts the selection to the given index.
+// Returns index name, key conditions, filter conditions, pagination key, and any errors.
+func (qb *QueryBuilder) Build() (string, expression.KeyConditionBuilder, *expression.ConditionBuilder, map[string]types.AttributeValue, error) {
+	if qb.consistentIndexOnly() && qb.IndexName != "" {
+		if info := GetIndexInfo(qb.IndexName); info != nil && info.Type == "GSI" {
+			return "", expression.KeyConditionBuilder{}, nil, nil, fmt.Errorf("consistent reads are not supported on global secondary index %s", qb.IndexName)
+		}
+	}
+	var filterCond *expression.ConditionBuilder
+	var sortedIndexes []SecondaryIndex
+	for _, idx := range TableSchema.SecondaryIndexes {
+		if qb.consistentIndexOnly() && idx.Type == "GSI" {
+			continue
+		}
+		if !qb.tableOnly && (qb.IndexName == "" || idx.Name == qb.IndexName) {
+			sortedIndexes = append(sortedIndexes, idx)
+		}
+	}
+
+	sort.Slice(sortedIndexes, func(i, j int) bool {
+		if qb.PreferredSortKey != "" {
+			iMatches := sortedIndexes[i].RangeKey == qb.PreferredSortKey
+			jMatches := sortedIndexes[j].RangeKey == qb.PreferredSortKey
+
+			if iMatches && !jMatches {
+				return true
+			}
+			if !iMatches && jMatches {
+				return false
+			}
+		}
+		iParts := qb.calculateIndexParts(sortedIndexes[i])
+		jParts := qb.calculateIndexParts(sortedIndexes[j])
+		return iParts > jParts
+	})
+
+	qb.hydrateFilter = nil
+	var skipped []string
+	var skippedFields []string
+	for _, idx := range sortedIndexes {
+		hashKeyCondition, hashKeyMatch, err := qb.buildHashKeyCondition(idx)
+		if err != nil {
+			return "", expression.KeyConditionBuilder{}, nil, nil, err
+		}
+		if !hashKeyMatch {
+			continue
+		}
+		rangeKeyCondition, rangeKeyMatch, err := qb.buildRangeKeyCondition(idx)
+		if err != nil {
+			return "", expression.KeyConditionBuilder{}, nil, nil, err
+		}
+		if !rangeKeyMatch {
+			continue
+		}
+		keyCondition := *hashKeyCondition
+		if rangeKeyCondition != nil {
+			keyCondition = keyCondition.And(*rangeKeyCondition)
+		}
+		if missing := qb.unprojectedFields(idx); len(missing) > 0 {
+			switch qb.UnprojectedFilter {
+			case UnprojectedFail:
+				return "", expression.KeyConditionBuilder{}, nil, nil, fmt.Errorf("index %s (projection %s) does not project filtered attributes: %s", idx.Name, idx.ProjectionType, strings.Join(missing, ", "))
+			case UnprojectedHydrate:
+				filterCond, qb.hydrateFilter = qb.splitFilterConditions(idx)
+				return idx.Name, keyCondition, filterCond, qb.ExclusiveStartKey, nil
+			default:
+				skipped = append(skipped, idx.Name)
+				skippedFields = append(skippedFields, missing...)
+				continue
+			}
+		}
+		filterCond = qb.buildFilterCondition(idx)
+		return idx.Name, keyCondition, filterCond, qb.ExclusiveStartKey, nil
+	}
+
+	if qb.UsedKeys[TableSchema.HashKey] && qb.IndexName == "" {
+		indexName := ""
+		keyCondition := expression.Key(TableSchema.HashKey).Equal(expression.Value(qb.Attributes[TableSchema.HashKey]))
+
+		if TableSchema.RangeKey != "" && qb.UsedKeys[TableSchema.RangeKey] {
+			if cond, exists := qb.KeyConditions[TableSchema.RangeKey]; exists {
+				keyCondition = keyCondition.And(cond)
+			} else {
+				keyCondition = keyCondition.And(expression.Key(TableSchema.RangeKey).Equal(expression.Value(qb.Attributes[TableSchema.RangeKey])))
+			}
+		}
+		var filterConditions []expression.ConditionBuilder
+		filterConditions = append(filterConditions, qb.FilterConditions...)
+		for _, attrName := range slices.Sorted(maps.Keys(qb.Attributes)) {
+			if attrName != TableSchema.HashKey && attrName != TableSchema.RangeKey {
+				filterConditions = append(filterConditions, expression.Name(attrName).Equal(expression.Value(qb.Attributes[attrName])))
+			}
+		}
+		if len(filterConditions) > 0 {
+			combinedFilter := filterConditions[0]
+			for _, cond := range filterConditions[1:] {
+				combinedFilter = combinedFilter.And(cond)
+			}
+			filterCond = &combinedFilter
+		}
+		return indexName, keyCondition, filterCond, qb.ExclusiveStartKey, nil
+	}
+	if len(skipped) > 0 {
+		return "", expression.KeyConditionBuilder{}, nil, nil, fmt.Errorf("no suitable index found for the provided keys: indexes %s do not project filtered attributes %s, use OnUnprojectedFilter(UnprojectedHydrate) to read them from the table", strings.Join(skipped, ", "), strings.Join(skippedFields, ", "))
+	}
+	return "", expression.KeyConditionBuilder{}, nil, nil, fmt.Errorf("no suitable index found for the provided keys")
+}
+
+// calculateIndexParts counts the number of composite key parts in an index.
+func (qb *QueryBuilder) calculateIndexParts(idx SecondaryIndex) int {
+	parts := 0
+	if idx.HashKeyParts != nil {
+		parts += len(idx.HashKeyParts)
+	}
+	if idx.RangeKeyParts != nil {
+		parts += len(idx.RangeKeyParts)
+	}
+	return parts
+}
+
+// buildHashKeyCondition creates the hash key condition for a given index.
+// Returns an error if a composite key value can't be built, see buildCompositeKeyValue.
+func (qb *QueryBuilder) buildHashKeyCondition(idx SecondaryIndex) (*expression.KeyConditionBuilder, bool, error) {
+	if idx.HashKeyParts != nil {
+		if qb.hasAllKeys(idx.HashKeyParts) {
+			cond, err := qb.buildCompositeKeyCondition(idx.HashKeyParts)
+			if err != nil {
+				return nil, false, err
+			}
+			return &cond, true, nil
+		}
+	} else if idx.HashKey != "" && qb.UsedKeys[idx.HashKey] {
+		cond := expression.Key(idx.HashKey).Equal(expression.Value(qb.Attributes[idx.HashKey]))
+		return &cond, true, nil
+	}
+	return nil, false, nil
+}
+
+// buildRangeKeyCondition creates the range key condition for a given index.
+// Returns an error if a composite key value can't be built, see buildCompositeKeyValue.
+func (qb *QueryBuilder) buildRangeKeyCondition(idx SecondaryIndex) (*expression.KeyConditionBuilder, bool, error) {
+	if idx.RangeKeyParts != nil {
+		if qb.hasAllKeys(idx.RangeKeyParts) {
+			cond, err := qb.buildCompositeKeyCondition(idx.RangeKeyParts)
+			if err != nil {
+				return nil, false, err
+			}
+			return &cond, true, nil
+		}
+	} else if idx.RangeKey != "" {
+		if qb.UsedKeys[idx.RangeKey] {
+			if cond, exists := qb.KeyConditions[idx.RangeKey]; exists {
+				return &cond, true, nil
+			} else {
+				cond := expression.Key(idx.RangeKey).Equal(expression.Value(qb.Attributes[idx.RangeKey]))
+				return &cond, true, nil
+			}
+		} else {
+			return nil, true, nil
+		}
+	} else {
+		return nil, true, nil
+	}
+	return nil, false, nil
+}
+
+// buildFilterCondition creates filter conditions for attributes not part of the index keys,
+// in attribute name order so the same query always builds the same request.
+func (qb *QueryBuilder) buildFilterCondition(idx SecondaryIndex) *expression.ConditionBuilder {
+	var filterConditions []expression.ConditionBuilder
+
+	filterConditions = append(filterConditions, qb.FilterConditions...)
+	for _, attrName := range slices.Sorted(maps.Keys(qb.Attributes)) {
+		if qb.isPartOfIndexKey(attrName, idx) {
+			continue
+		}
+		filterConditions = append(filterConditions, expression.Name(attrName).Equal(expression.Value(qb.Attributes[attrName])))
+	}
+	if len(filterConditions) == 0 {
+		return nil
+	}
+	combinedFilter := filterConditions[0]
+	for _, cond := range filterConditions[1:] {
+		combinedFilter = combinedFilter.And(cond)
+	}
+	return &combinedFilter
+}
+
+// isPartOfIndexKey checks if an attribute is part of the index's key structure.
+func (qb *QueryBuilder) isPartOfIndexKey(attrName string, idx SecondaryIndex) bool {
+	if idx.HashKeyParts != nil {
+		for _, part := range idx.HashKeyParts {
+			if !part.IsConstant && part.Value == attrName {
+				return true
+			}
+		}
+	} else if attrName == idx.HashKey {
+		return true
+	}
+	if idx.RangeKeyParts != nil {
+		for _, part := range idx.RangeKeyParts {
+			if !part.IsConstant && part.Value == attrName {
+				return true
+			}
+		}
+	} else if attrName == idx.RangeKey {
+		return true
+	}
+	return false
+}
+
+// BuildQuery constructs the final DynamoDB QueryInput with all expressions and parameters.
+// Combines key conditions, filter conditions, pagination, and sorting options.
+func (qb *QueryBuilder) BuildQuery() (*dynamodb.QueryInput, error) {
+	if qb.cursorErr != nil {
+		return nil, qb.cursorErr
+	}
+	if qb.filterErr != nil {
+		return nil, qb.filterErr
+	}
+	indexName, keyCond, filterCond, exclusiveStartKey, err := qb.Build()
+	if err != nil {
+		return nil, err
+	}
+	if err := qb.checkCursor(indexName); err != nil {
+		return nil, err
+	}
+	exprBuilder := expression.NewBuilder().WithKeyCondition(keyCond)
+	if filterCond != nil {
+		exprBuilder = exprBuilder.WithFilter(*filterCond)
+	}
+	expr, err := exprBuilder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build expression: %v", err)
+	}
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(TableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ScanIndexForward:          aws.Bool(!qb.SortDescending),
+	}
+	if indexName != "" {
+		input.IndexName = aws.String(indexName)
+	}
+	if filterCond != nil {
+		input.FilterExpression = expr.Filter()
+	}
+	if qb.ConsistentRead {
+		input.ConsistentRead = aws.Bool(true)
+	}
+	input.Limit = qb.requestLimit()
+	if exclusiveStartKey != nil {
+		input.ExclusiveStartKey = exclusiveStartKey
+	}
+	if qb.StablePlaceholders {
+		input.ExpressionAttributeNames, input.ExpressionAttributeValues = stablePlaceholders(input.ExpressionAttributeNames, input.ExpressionAttributeValues, input.KeyConditionExpression, input.FilterExpression)
+	}
+	return input, nil
+}
+
+// QueryRequest is the request a QueryBuilder would send in a comparable form, see BuildForTest.
+// Attribute names are resolved in the expressions, values keep their placeholders and are decoded
+// in Values: numbers as int64 or float64, other values as attributevalue.Unmarshal decodes them.
+type QueryRequest struct {
+	IndexName      string
+	KeyCondition   string
+	Filter         string
+	Values         map[string]any
+	Descending     bool
+	ConsistentRead bool
+	Limit          int32
+}
+
+// BuildForTest builds the query like Execute without sending it and returns the normalized request,
+// so unit tests can assert the query construction without a DynamoDB endpoint.
+// Example:
+//
+//	req, err := NewQueryBuilder().With(ColumnPk, EQ, "pk-1").BuildForTest()
+//	// req.IndexName == "", req.KeyCondition == "pk = :0"
+func (qb *QueryBuilder) BuildForTest() (QueryRequest, error) {
+	input, err := qb.BuildQuery()
+	if err != nil {
+		return QueryRequest{}, err
+	}
+	resolve := func(expr *string) string {
+		return simulatedNamePattern.ReplaceAllStringFunc(aws.ToString(expr), func(name string) string {
+			if attr, ok := input.ExpressionAttributeNames[name]; ok {
+				return attr
+			}
+			return name
+		})
+	}
+	req := QueryRequest{
+		IndexName:      aws.ToString(input.IndexName),
+		KeyCondition:   resolve(input.KeyConditionExpression),
+		Filter:         resolve(input.FilterExpression),
+		Values:         make(map[string]any, len(input.ExpressionAttributeValues)),
+		Descending:     !aws.ToBool(input.ScanIndexForward),
+		ConsistentRead: aws.ToBool(input.ConsistentRead),
+		Limit:          aws.ToInt32(input.Limit),
+	}
+	for placeholder, av := range input.ExpressionAttributeValues {
+		if req.Values[placeholder], err = simulatedValue(av); err != nil {
+			return QueryRequest{}, fmt.Errorf("failed to decode %s: %v", placeholder, err)
+		}
+	}
+	return req, nil
+}
+
+// simulatedNamePattern matches the attribute name placeholders of built expressions.
+var simulatedNamePattern = regexp.MustCompile("#\\w+")
+
+// simulatedValue decodes an expression value for BuildForTest.
+func simulatedValue(av types.AttributeValue) (any, error) {
+	if n, ok := av.(*types.AttributeValueMemberN); ok {
+		if i, err := strconv.ParseInt(n.Value, 10, 64); err == nil {
+			return i, nil
+		}
+		return strconv.ParseFloat(n.Value, 64)
+	}
+	var value any
+	if err := attributevalue.Unmarshal(av, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Execute runs the query against DynamoDB and returns strongly-typed results.
+// Handles the complete query lifecycle: build input, execute, unmarshal results.
+// With UnprojectedHydrate, index hits are re-read from the table to apply the remaining filters.
+// With LimitResults, pages are fetched until enough items pass the filters (ExecuteWithCursor
+// also returns the cursor resuming after them); if ctx ends or MaxPages requests are sent before,
+// the items read so far are returned with a *PartialResultError.
+func (qb *QueryBuilder) Execute(ctx context.Context, client DynamoDBAPI) ([]SchemaItem, error) {
+	if qb.ResultLimitValue != nil {
+		items, _, err := qb.executePages(ctx, client, *qb.ResultLimitValue, qb.maxPages())
+		return items, err
+	}
+	input, err := qb.BuildQuery()
+	if err != nil {
+		return nil, err
+	}
+	result, err := qb.query(ctx, client, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %v", err)
+	}
+	return qb.unmarshalItems(ctx, client, result.Items)
+}
+
+// ExecuteAll runs the query following LastEvaluatedKey until every page is read
+// and returns at most MaxItems (or Limit) items, all matching items if neither is set.
+// PageSize controls the number of items evaluated per request; ExecuteWithCursor also returns the cursor
+// resuming after the returned items. If ctx ends before the last page, the items read so far are returned
+// with a *PartialResultError holding the cursor to resume from.
+// Example:
+//
+//	items, err := NewQueryBuilder().With(ColumnPk, EQ, "pk-1").ExecuteAll(ctx, client)
+//	var partial *PartialResultError
+//	if errors.As(err, &partial) {
+//	    resumeLater(partial.Cursor) // items holds the results read before
+//	}
+func (qb *QueryBuilder) ExecuteAll(ctx context.Context, client DynamoDBAPI) ([]SchemaItem, error) {
+	items, _, err := qb.executePages(ctx, client, qb.totalLimit(), -1)
+	return items, err
+}
+
+// ExecuteWithCursor reads like Execute with LimitResults, like ExecuteAll without it, and returns
+// the items with the cursor resuming after them, empty if all matching items were read.
+// The cursor is not kept by the builder, so it can be reused or shared between reads.
+// Example:
+//
+//	page, err := NewQueryBuilder().With(ColumnPk, EQ, "pk-1").LimitResults(20).StartFromCursor(cursor).ExecuteWithCursor(ctx, client)
+//	if page.HasMore {
+//	    cursor = page.NextCursor
+//	}
+func (qb *QueryBuilder) ExecuteWithCursor(ctx context.Context, client DynamoDBAPI) (Page, error) {
+	limit, maxPages := qb.totalLimit(), -1
+	if qb.ResultLimitValue != nil {
+		limit, maxPages = *qb.ResultLimitValue, qb.maxPages()
+	}
+	items, cursor, err := qb.executePages(ctx, client, limit, maxPages)
+	return Page{Items: items, NextCursor: cursor, HasMore: cursor != ""}, err
+}
+
+// ExecutePaginated runs one request of the query and returns its page with the cursor of the next one.
+// PageSize (or Limit) sets the number of items evaluated per page; with filters a page may hold
+// fewer items, even none, while HasMore is true.
+// Example:
+//
+//	page, err := NewQueryBuilder().With(ColumnPk, EQ, "pk-1").PageSize(20).StartFromCursor(r.URL.Query().Get("cursor")).ExecutePaginated(ctx, client)
+//	json.NewEncoder(w).Encode(page)
+func (qb *QueryBuilder) ExecutePaginated(ctx context.Context, client DynamoDBAPI) (Page, error) {
+	input, err := qb.BuildQuery()
+	if err != nil {
+		return Page{}, err
+	}
+	result, err := qb.query(ctx, client, input)
+	if err != nil {
+		return Page{}, fmt.Errorf("failed to execute query: %v", err)
+	}
+	items, err := qb.unmarshalItems(ctx, client, result.Items)
+	if err != nil {
+		return Page{}, err
+	}
+	cursor, err := EncodeCursor(result.LastEvaluatedKey)
+	if err != nil {
+		return Page{}, err
+	}
+	return Page{Items: items, NextCursor: cursor, HasMore: cursor != ""}, nil
+}
+
+// Iterate returns a PageIterator over the query results which prefetches one page ahead.
+// PageSize controls the number of items evaluated per request; StartFrom sets the first page.
+func (qb *QueryBuilder) Iterate(ctx context.Context, client DynamoDBAPI) (*PageIterator, error) {
+	input, err := qb.BuildQuery()
+	if err != nil {
+		return nil, err
+	}
+	fetch := func(ctx context.Context, startKey map[string]types.AttributeValue) ([]SchemaItem, map[string]types.AttributeValue, error) {
+		pageInput := *input
+		pageInput.ExclusiveStartKey = startKey
+		result, err := qb.query(ctx, client, &pageInput)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to execute query: %v", err)
+		}
+		items, err := qb.unmarshalItems(ctx, client, result.Items)
+		return items, result.LastEvaluatedKey, err
+	}
+	return newPageIterator(ctx, input.ExclusiveStartKey, fetch), nil
+}
+
+// Items returns an iterator over the query results which reads pages lazily, one page ahead,
+// so large results are processed without buffering them. Stopping the loop stops reading;
+// canceling ctx ends the loop with ctx.Err(), so a truncated read is never mistaken for a complete one.
+// Example:
+//
+//	for item, err := range NewQueryBuilder().With(ColumnPk, EQ, "pk-1").Items(ctx, client) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(item)
+//	}
+func (qb *QueryBuilder) Items(ctx context.Context, client DynamoDBAPI) iter.Seq2[SchemaItem, error] {
+	return iterateItems(qb.Iterate(ctx, client))
+}
+
+// Count returns the number of items matching the query without transferring them:
+// requests use Select COUNT and follow LastEvaluatedKey until the partition is exhausted.
+// Example:
+//
+//	n, err := NewQueryBuilder().With(ColumnPk, EQ, "pk-1").Count(ctx, client)
+func (qb *QueryBuilder) Count(ctx context.Context, client DynamoDBAPI) (int64, error) {
+	count, _, err := qb.CountScanned(ctx, client)
+	return count, err
+}
+
+// CountScanned is Count which also returns the number of items evaluated before filters,
+// the read cost of the query. Filters on attributes an index doesn't project can't be counted.
+func (qb *QueryBuilder) CountScanned(ctx context.Context, client DynamoDBAPI) (count, scanned int64, err error) {
+	input, err := qb.BuildQuery()
+	if err != nil {
+		return 0, 0, err
+	}
+	if qb.hydrateFilter != nil {
+		return 0, 0, fmt.Errorf("filters on unprojected attributes of index %s can't be counted, query the table instead", aws.ToString(input.IndexName))
+	}
+	input.Select = types.SelectCount
+	for {
+		result, err := qb.query(ctx, client, input)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to execute query: %v", err)
+		}
+		count += int64(result.Count)
+		scanned += int64(result.ScannedCount)
+		if len(result.LastEvaluatedKey) == 0 {
+			return count, scanned, nil
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
+
+// query sends one Query request, repeated with the other consistency as the read fallback requires.
+func (qb *QueryBuilder) query(ctx context.Context, client DynamoDBAPI, input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	consistent, eventual := *input, *input
+	consistent.ConsistentRead = aws.Bool(true)
+	eventual.ConsistentRead = aws.Bool(false)
+
+	switch qb.readFallback {
+	case StrongThenEventual:
+		result, err := client.Query(ctx, &consistent)
+		if err == nil || ctx.Err() != nil {
+			return result, err
+		}
+		return client.Query(ctx, &eventual)
+	case EventualThenStrong:
+		result, err := client.Query(ctx, &eventual)
+		if err != nil || input.Select == types.SelectCount || freshItems(result.Items, qb.freshness) {
+			return result, err
+		}
+		return client.Query(ctx, &consistent)
+	}
+	return client.Query(ctx, input)
+}
+
+// executePages paginates the query until limit items pass the filters, the partition
+// is exhausted or maxPages requests are sent. Negative values disable the respective cap.
+// Items of a page past limit are left unread, the returned cursor resumes after the returned ones;
+// reaching maxPages first returns the items with a *PartialResultError wrapping ErrPageCapReached.
+func (qb *QueryBuilder) executePages(ctx context.Context, client DynamoDBAPI, limit, maxPages int) ([]SchemaItem, string, error) {
+	input, err := qb.BuildQuery()
+	if err != nil {
+		return nil, "", err
+	}
+	var items []SchemaItem
+	resume := input.ExclusiveStartKey
+	for page := 0; limit < 0 || len(items) < limit; page++ {
+		if maxPages >= 0 && page >= maxPages {
+			cursor, err := finishPages(len(items), resume, ErrPageCapReached)
+			return items, cursor, err
+		}
+		result, err := qb.query(ctx, client, input)
+		if err != nil {
+			if ctx.Err() != nil {
+				cursor, err := finishPages(len(items), resume, ctx.Err())
+				return items, cursor, err
+			}
+			return nil, "", fmt.Errorf("failed to execute query: %v", err)
+		}
+		for _, raw := range result.Items {
+			if limit >= 0 && len(items) >= limit {
+				cursor, err := finishPages(len(items), resume, nil)
+				return items, cursor, err
+			}
+			hit, err := qb.unmarshalItems(ctx, client, []map[string]types.AttributeValue{raw})
+			if err != nil {
+				if ctx.Err() != nil {
+					cursor, err := finishPages(len(items), resume, ctx.Err())
+					return items, cursor, err
+				}
+				return nil, "", err
+			}
+			items = append(items, hit...)
+			resume = itemKey(raw, aws.ToString(input.IndexName))
+		}
+		resume = result.LastEvaluatedKey
+		if len(resume) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = resume
+	}
+	cursor, err := finishPages(len(items), resume, nil)
+	return items, cursor, err
+}
+
+// unmarshalItems converts a page of query results, hydrating index hits if required.
+func (qb *QueryBuilder) unmarshalItems(ctx context.Context, client DynamoDBAPI, raw []map[string]types.AttributeValue) ([]SchemaItem, error) {
+	if qb.hydrateFilter != nil {
+		return qb.hydrate(ctx, client, raw)
+	}
+	var items []SchemaItem
+	if err := attributevalue.UnmarshalListOfMaps(raw, &items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %v", err)
+	}
+	return items, nil
+}
+
+// UnprojectedFilterPolicy controls what a query does when a filter references attributes
+// a GSI doesn't project (KEYS_ONLY or INCLUDE projection). DynamoDB evaluates such filters
+// against missing attributes, so they silently drop matching items.
+// LSIs are not affected: DynamoDB reads non-projected attributes from the table.
+type UnprojectedFilterPolicy int
+
+const (
+	// UnprojectedSwitchIndex skips indexes which don't project filtered attributes and
+	// falls back to the next matching index or the table (default).
+	UnprojectedSwitchIndex UnprojectedFilterPolicy = iota
+
+	// UnprojectedHydrate queries the index with the filters it can evaluate, then re-reads
+	// every hit from the table applying the remaining filters (one extra Query per hit).
+	// Applies to Execute only.
+	UnprojectedHydrate
+
+	// UnprojectedFail returns an error naming the index and the non-projected attributes.
+	UnprojectedFail
+)
+
+// OnUnprojectedFilter sets the policy for filters on attributes not projected into the selected GSI.
+// Example:
+//
+//	items, err := NewQueryBuilder().
+//	    With(ColumnStatus, EQ, "active").
+//	    Filter(ColumnTotal, GT, 100).
+//	    OnUnprojectedFilter(UnprojectedHydrate).
+//	    Execute(ctx, client)
+func (qb *QueryBuilder) OnUnprojectedFilter(policy UnprojectedFilterPolicy) *QueryBuilder {
+	qb.UnprojectedFilter = policy
+	return qb
+}
+
+// isProjected reports whether a filter on attrName can be evaluated on the index.
+func isProjected(attrName string, idx SecondaryIndex) bool {
+	if idx.Type == "LSI" || idx.ProjectionType == "ALL" {
+		return true
+	}
+	switch attrName {
+	case TableSchema.HashKey, TableSchema.RangeKey, idx.HashKey, idx.RangeKey:
+		return true
+	}
+	if idx.ProjectionType == "INCLUDE" {
+		for _, nk := range idx.NonKeyAttributes {
+			if nk == attrName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// unprojectedFields returns the filtered attributes the index doesn't project, sorted.
+func (qb *QueryBuilder) unprojectedFields(idx SecondaryIndex) []string {
+	seen := make(map[string]bool)
+	for _, field := range qb.FilterFields {
+		if !isProjected(field, idx) {
+			seen[field] = true
+		}
+	}
+	for attrName := range qb.Attributes {
+		if !qb.isPartOfIndexKey(attrName, idx) && !isProjected(attrName, idx) {
+			seen[attrName] = true
+		}
+	}
+	fields := make([]string, 0, len(seen))
+	for field := range seen {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// splitFilterConditions splits the filter conditions for the index into the ones it can evaluate
+// and the ones referencing attributes it doesn't project.
+func (qb *QueryBuilder) splitFilterConditions(idx SecondaryIndex) (*expression.ConditionBuilder, *expression.ConditionBuilder) {
+	var projected, residual []expression.ConditionBuilder
+	for i, cond := range qb.FilterConditions {
+		if i < len(qb.FilterFields) && !isProjected(qb.FilterFields[i], idx) {
+			residual = append(residual, cond)
+			continue
+		}
+		projected = append(projected, cond)
+	}
+	for attrName, value := range qb.Attributes {
+		if qb.isPartOfIndexKey(attrName, idx) {
+			continue
+		}
+		cond := expression.Name(attrName).Equal(expression.Value(value))
+		if !isProjected(attrName, idx) {
+			residual = append(residual, cond)
+			continue
+		}
+		projected = append(projected, cond)
+	}
+	return combineConditions(projected), combineConditions(residual)
+}
+
+// combineConditions joins conditions with AND, nil if there are none.
+func combineConditions(conditions []expression.ConditionBuilder) *expression.ConditionBuilder {
+	if len(conditions) == 0 {
+		return nil
+	}
+	combined := conditions[0]
+	for _, cond := range conditions[1:] {
+		combined = combined.And(cond)
+	}
+	return &combined
+}
+
+// hydrate re-reads index hits from the table and keeps the items matching the filters
+// the index couldn't evaluate.
+func (qb *QueryBuilder) hydrate(ctx context.Context, client DynamoDBAPI, hits []map[string]types.AttributeValue) ([]SchemaItem, error) {
+	filterExpr, err := expression.NewBuilder().WithFilter(*qb.hydrateFilter).Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build hydration filter: %v", err)
+	}
+	keyCondition := "#hydrate_hk = :hydrate_hk"
+	if TableSchema.RangeKey != "" {
+		keyCondition += " AND #hydrate_rk = :hydrate_rk"
+	}
+
+	items := make([]SchemaItem, 0, len(hits))
+	for _, hit := range hits {
+		names := map[string]string{"#hydrate_hk": TableSchema.HashKey}
+		values := map[string]types.AttributeValue{":hydrate_hk": hit[TableSchema.HashKey]}
+		if TableSchema.RangeKey != "" {
+			names["#hydrate_rk"] = TableSchema.RangeKey
+			values[":hydrate_rk"] = hit[TableSchema.RangeKey]
+		}
+		names, values = mergeExpressionAttributes(names, values, filterExpr.Names(), filterExpr.Values())
+
+		result, err := client.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(TableName),
+			KeyConditionExpression:    aws.String(keyCondition),
+			FilterExpression:          filterExpr.Filter(),
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to hydrate item from table: %v", err)
+		}
+		var page []SchemaItem
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal hydrated item: %v", err)
+		}
+		items = append(items, page...)
+	}
+	return items, nil
+}
+
+// hasAllKeys checks if all non-constant parts of a composite key are available.
+func (qb *QueryBuilder) hasAllKeys(parts []CompositeKeyPart) bool {
+	for _, part := range parts {
+		if !part.IsConstant && !qb.UsedKeys[part.Value] {
+			return false
+		}
+	}
+	return true
+}
+
+// buildCompositeKeyCondition creates a key condition for composite keys.
+func (qb *QueryBuilder) buildCompositeKeyCondition(parts []CompositeKeyPart) (expression.KeyConditionBuilder, error) {
+	compositeKeyName := qb.getCompositeKeyName(parts)
+	compositeValue, err := qb.buildCompositeKeyValue(parts)
+	if err != nil {
+		return expression.KeyConditionBuilder{}, err
+	}
+	return expression.Key(compositeKeyName).Equal(expression.Value(compositeValue)), nil
+}
+
+// getCompositeKeyName generates the attribute name for a composite key.
+func (qb *QueryBuilder) getCompositeKeyName(parts []CompositeKeyPart) string {
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return parts[0].Value
+	default:
+		names := make([]string, len(parts))
+		for i, part := range parts {
+			names[i] = part.Value
+		}
+		return strings.Join(names, "#")
+	}
+}
+
+// buildCompositeKeyValue constructs the actual value for a composite key.
+// Returns an error if a value contains the separator, as BuildCompositeKey does.
+func (qb *QueryBuilder) buildCompositeKeyValue(parts []CompositeKeyPart) (string, error) {
+	if len(parts) == 0 {
+		return "", nil
+	}
+	sep := compositeKeySeparator(parts)
+	values := make([]string, len(parts))
+	for i, part := range parts {
+		if part.IsConstant {
+			values[i] = part.Value
+		} else {
+			v := qb.formatAttributeValue(qb.Attributes[part.Value])
+			if strings.Contains(v, sep) {
+				return "", fmt.Errorf("value for composite key part %s contains separator %q", part.Value, sep)
+			}
+			values[i] = v
+		}
+	}
+	return strings.Join(values, sep), nil
+}
+
+// formatAttributeValue converts any Go value to its string representation for composite keys.
+func (qb *QueryBuilder) formatAttributeValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+	av, err := attributevalue.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	switch typed := av.(type) {
+	case *types.AttributeValueMemberS:
+		return typed.Value
+	case *types.AttributeValueMemberN:
+		return typed.Value
+	case *types.AttributeValueMemberBOOL:
+		if typed.Value {
+			return "true"
+		}
+		return "false"
+	case *types.AttributeValueMemberSS:
+		return strings.Join(typed.Value, ",")
+	case *types.AttributeValueMemberNS:
+		return strings.Join(typed.Value, ",")
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// QueryPlan describes how a QueryBuilder selects its index and splits its conditions, see Explain.
+type QueryPlan struct {
+	// IndexName is the selected index, empty for the table.
+	IndexName string
+
+	// KeyCondition and Filter are the expressions of the request with attribute names resolved,
+	// values keep their placeholders like in BuildForTest.
+	KeyCondition string
+	Filter       string
+
+	// KeyAttributes are the attributes of the key condition.
+	KeyAttributes []string
+
+	// FilterAttributes are the attributes filtered after reading, with the ones applied to hydrated items.
+	FilterAttributes []string
+
+	// Demoted are the attributes set with key conditions (With, WithEQ) which the selected index
+	// isn't keyed by: they are applied as equality filters instead.
+	Demoted []string
+
+	// Hydrated is true if index hits are re-read from the table to apply filters
+	// on attributes the index doesn't project, see UnprojectedHydrate.
+	Hydrated bool
+
+	// FilterHeavy estimates whether the query degrades to filtering a whole partition:
+	// filters apply while the key condition only fixes the partition key, so every item
+	// of the partition is read and paid for. An index keyed by the Demoted attributes avoids it.
+	FilterHeavy bool
+
+	// Rejected are the secondary indexes not selected, in schema order.
+	Rejected []RejectedIndex
+}
+
+// RejectedIndex is a secondary index a QueryPlan didn't select, with the reason.
+type RejectedIndex struct {
+	Name   string
+	Reason string
+}
+
+// Explain returns the plan of the query without sending it: the selected index, why the other
+// indexes were rejected and which conditions became key conditions or filters.
+// If no index can be selected, the plan lists the rejected indexes with the error of Build.
+// Example:
+//
+//	plan, err := NewQueryBuilder().With(ColumnPk, EQ, "pk-1").Explain()
+//	for _, idx := range plan.Rejected {
+//	    log.Printf("%s: %s", idx.Name, idx.Reason)
+//	}
+func (qb *QueryBuilder) Explain() (QueryPlan, error) {
+	var plan QueryPlan
+	input, err := qb.BuildQuery()
+	if err == nil {
+		plan.IndexName = aws.ToString(input.IndexName)
+		plan.KeyCondition, plan.KeyAttributes = explainExpression(input.KeyConditionExpression, input.ExpressionAttributeNames)
+		plan.Filter, plan.FilterAttributes = explainExpression(input.FilterExpression, input.ExpressionAttributeNames)
+		if qb.hydrateFilter != nil {
+			plan.Hydrated = true
+			expr, buildErr := expression.NewBuilder().WithFilter(*qb.hydrateFilter).Build()
+			if buildErr != nil {
+				return QueryPlan{}, fmt.Errorf("failed to build hydration filter: %v", buildErr)
+			}
+			_, hydrated := explainExpression(expr.Filter(), expr.Names())
+			attrs := append(plan.FilterAttributes, hydrated...)
+			slices.Sort(attrs)
+			plan.FilterAttributes = slices.Compact(attrs)
+		}
+		for _, name := range plan.FilterAttributes {
+			if _, ok := qb.Attributes[name]; ok {
+				plan.Demoted = append(plan.Demoted, name)
+			}
+		}
+		plan.FilterHeavy = len(plan.FilterAttributes) > 0 && len(plan.KeyAttributes) < 2
+	}
+	for _, idx := range TableSchema.SecondaryIndexes {
+		if err == nil && idx.Name == plan.IndexName {
+			continue
+		}
+		plan.Rejected = append(plan.Rejected, RejectedIndex{Name: idx.Name, Reason: qb.rejectionReason(idx, plan.IndexName)})
+	}
+	return plan, err
+}
+
+// rejectionReason returns why Build doesn't select the index over the selected one (empty for the table).
+func (qb *QueryBuilder) rejectionReason(idx SecondaryIndex, selected string) string {
+	switch {
+	case qb.tableOnly:
+		return "named queries of the table don't use indexes"
+	case qb.IndexName != "" && idx.Name != qb.IndexName:
+		return fmt.Sprintf("WithIndex selects %s", qb.IndexName)
+	case qb.consistentIndexOnly() && idx.Type == "GSI":
+		return "global secondary indexes don't support consistent reads"
+	}
+	_, ok, err := qb.buildHashKeyCondition(idx)
+	if err != nil {
+		return err.Error()
+	}
+	if !ok {
+		if missing := qb.missingKeyParts(idx.HashKeyParts); len(missing) > 0 {
+			return fmt.Sprintf("no condition on hash key parts %s", strings.Join(missing, ", "))
+		}
+		return fmt.Sprintf("no equality condition on hash key %s", idx.HashKey)
+	}
+	_, ok, err = qb.buildRangeKeyCondition(idx)
+	if err != nil {
+		return err.Error()
+	}
+	if !ok {
+		return fmt.Sprintf("no condition on range key parts %s", strings.Join(qb.missingKeyParts(idx.RangeKeyParts), ", "))
+	}
+	if missing := qb.unprojectedFields(idx); len(missing) > 0 && qb.UnprojectedFilter != UnprojectedHydrate {
+		return fmt.Sprintf("projection %s doesn't include filtered attributes %s", idx.ProjectionType, strings.Join(missing, ", "))
+	}
+	chosen := qb.getIndexByName(selected)
+	switch {
+	case chosen == nil:
+		return "matches the key conditions, but the query failed"
+	case qb.PreferredSortKey != "" && chosen.RangeKey == qb.PreferredSortKey && idx.RangeKey != qb.PreferredSortKey:
+		return fmt.Sprintf("ranked below %s, which has the preferred sort key %s", selected, qb.PreferredSortKey)
+	case qb.calculateIndexParts(*chosen) > qb.calculateIndexParts(idx):
+		return fmt.Sprintf("ranked below %s, which has more composite key parts", selected)
+	}
+	return fmt.Sprintf("ranked below %s", selected)
+}
+
+// missingKeyParts returns the non-constant composite key parts without a key condition.
+func (qb *QueryBuilder) missingKeyParts(parts []CompositeKeyPart) []string {
+	var missing []string
+	for _, part := range parts {
+		if !part.IsConstant && !qb.UsedKeys[part.Value] {
+			missing = append(missing, part.Value)
+		}
+	}
+	return missing
+}
+
+// explainExpression resolves the attribute names of a built expression
+// and returns it with the sorted attributes it references.
+func explainExpression(expr *string, names map[string]string) (string, []string) {
+	var attrs []string
+	resolved := simulatedNamePattern.ReplaceAllStringFunc(aws.ToString(expr), func(name string) string {
+		if attr, ok := names[name]; ok {
+			attrs = append(attrs, attr)
+			return attr
+		}
+		return name
+	})
+	slices.Sort(attrs)
+	return resolved, slices.Compact(attrs)
+}
+
+// queryBuilderState is the JSON form of a QueryBuilder: the conditions in the order
+// they were added plus index preference and pagination settings.
+type queryBuilderState struct {
+	KeyConditions      []Condition                  `json:"key_conditions,omitempty"`
+	IndexKeys          []indexKeyCondition          `json:"index_keys,omitempty"`
+	Filters            []Condition                  `json:"filters,omitempty"`
+	IndexName          string                       `json:"index_name,omitempty"`
+	TableOnly          bool                         `json:"table_only,omitempty"`
+	ConsistentRead     bool                         `json:"consistent_read,omitempty"`
+	StablePlaceholders bool                         `json:"stable_placeholders,omitempty"`
+	PreferredSortKey   string                       `json:"preferred_sort_key,omitempty"`
+	SortDescending     bool                         `json:"sort_descending,omitempty"`
+	UnprojectedFilter  UnprojectedFilterPolicy      `json:"unprojected_filter,omitempty"`
+	Limit              *int                         `json:"limit,omitempty"`
+	PageSize           *int                         `json:"page_size,omitempty"`
+	LimitResults       *int                         `json:"limit_results,omitempty"`
+	MaxItems           *int                         `json:"max_items,omitempty"`
+	MaxPages           int                          `json:"max_pages,omitempty"`
+	StartKey           map[string]keyAttributeValue `json:"start_key,omitempty"`
+}
+
+// MarshalJSON serializes the query: key conditions, filters, index preference and pagination,
+// so a prepared query can be cached, logged or sent to another service and replayed with UnmarshalJSON.
+// Example:
+//
+//	data, err := json.Marshal(NewQueryBuilder().With(ColumnPk, EQ, "pk-1").Limit(10))
+//	var qb QueryBuilder
+//	err = json.Unmarshal(data, &qb)
+//	items, err := qb.Execute(ctx, client)
+func (qb *QueryBuilder) MarshalJSON() ([]byte, error) {
+	startKey, err := encodeKeyAttributes(qb.ExclusiveStartKey)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(queryBuilderState{
+		KeyConditions:      qb.AppliedKeyConditions,
+		IndexKeys:          qb.indexKeys,
+		Filters:            qb.AppliedFilters,
+		IndexName:          qb.IndexName,
+		TableOnly:          qb.tableOnly,
+		ConsistentRead:     qb.ConsistentRead,
+		StablePlaceholders: qb.StablePlaceholders,
+		PreferredSortKey:   qb.PreferredSortKey,
+		SortDescending:     qb.SortDescending,
+		UnprojectedFilter:  qb.UnprojectedFilter,
+		Limit:              qb.LimitValue,
+		PageSize:           qb.PageSizeValue,
+		LimitResults:       qb.ResultLimitValue,
+		MaxItems:           qb.MaxItemsValue,
+		MaxPages:           qb.MaxPagesValue,
+		StartKey:           startKey,
+	})
+}
+
+// UnmarshalJSON replaces the query with the serialized one. Conditions are replayed through
+// With, WithIndex*Key and Filter, so they are validated against the schema again;
+// values are restored to the Go types of their attributes (numbers, binaries, booleans).
+func (qb *QueryBuilder) UnmarshalJSON(data []byte) error {
+	var state queryBuilderState
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&state); err != nil {
+		return fmt.Errorf("failed to decode query: %v", err)
+	}
+	startKey, err := decodeKeyAttributes(state.StartKey)
+	if err != nil {
+		return err
+	}
+
+	*qb = *NewQueryBuilder()
+	for _, c := range state.KeyConditions {
+		values, err := restoreConditionValues(c.Field, c.Values)
+		if err != nil {
+			return err
+		}
+		qb.With(c.Field, c.Operator, values...)
+	}
+	for _, c := range state.IndexKeys {
+		if err := qb.replayIndexKey(c); err != nil {
+			return err
+		}
+	}
+	for _, c := range state.Filters {
+		values, err := restoreConditionValues(c.Field, c.Values)
+		if err != nil {
+			return err
+		}
+		qb.Filter(c.Field, c.Operator, values...)
+	}
+	if len(qb.AppliedKeyConditions)+len(qb.indexKeys)+len(qb.AppliedFilters) != len(state.KeyConditions)+len(state.IndexKeys)+len(state.Filters) {
+		return fmt.Errorf("query conditions don't match the schema of table %s", TableSchema.TableName)
+	}
+
+	qb.IndexName = state.IndexName
+	qb.tableOnly = state.TableOnly
+	qb.ConsistentRead = state.ConsistentRead
+	qb.StablePlaceholders = state.StablePlaceholders
+	qb.PreferredSortKey = state.PreferredSortKey
+	qb.SortDescending = state.SortDescending
+	qb.UnprojectedFilter = state.UnprojectedFilter
+	qb.LimitValue = state.Limit
+	qb.PageSizeValue = state.PageSize
+	qb.ResultLimitValue = state.LimitResults
+	qb.MaxItemsValue = state.MaxItems
+	qb.MaxPagesValue = state.MaxPages
+	qb.ExclusiveStartKey = startKey
+	return nil
+}
+
+// replayIndexKey applies a recorded WithIndex*Key call.
+func (qb *QueryBuilder) replayIndexKey(c indexKeyCondition) error {
+	index := qb.getIndexByName(c.Index)
+	if index == nil {
+		return fmt.Errorf("unknown index '%s'", c.Index)
+	}
+	key, parts := index.HashKey, index.HashKeyParts
+	if c.Range {
+		key, parts = index.RangeKey, index.RangeKeyParts
+	}
+	values := make([]any, len(c.Values))
+	for i, v := range c.Values {
+		field := key
+		if nonConstant := qb.getNonConstantParts(parts); i < len(nonConstant) {
+			field = nonConstant[i].Value
+		}
+		value, err := restoreValue(field, v)
+		if err != nil {
+			return err
+		}
+		values[i] = value
+	}
+
+	switch {
+	case !c.Range && c.Operator == EQ:
+		qb.WithIndexHashKey(c.Index, values...)
+	case c.Range && c.Operator == EQ:
+		qb.WithIndexRangeKey(c.Index, values...)
+	case c.Range && c.Operator == BETWEEN && len(values) == 2:
+		qb.WithIndexRangeKeyBetween(c.Index, values[0], values[1])
+	case c.Range && c.Operator == GT && len(values) == 1:
+		qb.WithIndexRangeKeyGT(c.Index, values[0])
+	case c.Range && c.Operator == LT && len(values) == 1:
+		qb.WithIndexRangeKeyLT(c.Index, values[0])
+	case c.Range && c.Operator == GTE && len(values) == 1:
+		qb.WithIndexRangeKeyGTE(c.Index, values[0])
+	case c.Range && c.Operator == LTE && len(values) == 1:
+		qb.WithIndexRangeKeyLTE(c.Index, values[0])
+	case c.Range && c.Operator == BEGINS_WITH && len(values) == 1:
+		qb.WithIndexRangeKeyBeginsWith(c.Index, fmt.Sprint(values[0]))
+	default:
+		return fmt.Errorf("invalid key condition %s of index '%s'", c.Operator, c.Index)
+	}
+	return nil
+}
+
+// restoreConditionValues restores the Go types of decoded condition values of the field.
+func restoreConditionValues(field string, values []any) ([]any, error) {
+	restored := make([]any, len(values))
+	for i, v := range values {
+		value, err := restoreValue(field, v)
+		if err != nil {
+			return nil, err
+		}
+		restored[i] = value
+	}
+	return restored, nil
+}
+
+// restoreValue converts a value decoded from JSON (with UseNumber) to the Go type of the field:
+// numbers of N and NS fields, base64 strings of B and BS fields and booleans.
+// Values of unknown fields (e.g. composite index keys) are kept.
+func restoreValue(field string, v any) (any, error) {
+	info, ok := TableSchema.FieldsMap[field]
+	if !ok {
+		return v, nil
+	}
+	switch info.DynamoType {
+	case "N", "NS":
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("value of '%s' must be a number, got %T", field, v)
+		}
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+		return n.Float64()
+	case "B", "BS":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of '%s' must be base64 binary, got %T", field, v)
+		}
+		return base64.StdEncoding.DecodeString(s)
+	}
+	return v, nil
+}
+
+// ScanBuilder provides a fluent interface for building DynamoDB scan operations.
+// Scans read every item in a table or index, applying filters after data is read.
+// Use Query for efficient key-based access; use Scan for full table analysis.
+// Combines FilterMixin and PaginationMixin for comprehensive scan functionality.
+type ScanBuilder struct {
+	FilterMixin                              // Filter conditions applied after reading items
+	PaginationMixin                          // Limit and pagination support
+	IndexName            string              // Optional secondary index to scan
+	ProjectionAttributes []string            // Specific attributes to return
+	ParallelScanConfig   *ParallelScanConfig // Parallel scan configuration
+	HashKeyValues        []any               // Hash keys queried instead of scanning, see FilterHashKeyIn
+	ConsistentRead       bool                // Strongly consistent reads, not supported on GSIs
+	StablePlaceholders   bool                // Attribute-derived expression placeholders, see WithStablePlaceholders
+}
+
+// ParallelScanConfig configures parallel scan operations for improved throughput.
+// Divides the table into segments that can be scanned concurrently.
+// Each worker scans one segment, reducing overall scan time for large tables.
+type ParallelScanConfig struct {
+	TotalSegments int // Total number of segments to divide the table into
+	Segment       int // Which segment this scan worker should process (0-based)
+}
+
+// NewScanBuilder creates a new ScanBuilder instance with initialized mixins.
+// All mixins are properly initialized for immediate use.
+// Example:
+//
+//	items, err := NewScanBuilder().
+//	    Filter(ColumnPk, EQ, "pk-1").
+//	    Execute(ctx, client)
+func NewScanBuilder() *ScanBuilder {
+	return &ScanBuilder{
+		FilterMixin:     NewFilterMixin(),
+		PaginationMixin: NewPaginationMixin(),
+	}
+}
+
+// Limit sets the maximum number of items and returns ScanBuilder for method chaining.
+// Execute sends it as the request Limit unless PageSize is set;
+// ExecuteAll returns at most limit items across all pages.
+func (sb *ScanBuilder) Limit(limit int) *ScanBuilder {
+	sb.PaginationMixin.Limit(limit)
+	return sb
+}
+
+// PageSize sets the number of items DynamoDB evaluates per request and returns ScanBuilder for method chaining.
+// Use it to tune capacity consumed per call, independently of Limit.
+func (sb *ScanBuilder) PageSize(size int) *ScanBuilder {
+	sb.PaginationMixin.PageSize(size)
+	return sb
+}
+
+// LimitResults sets the number of items Execute returns after filters are applied.
+// Execute paginates until limit matching items are collected or MaxPages requests are sent.
+func (sb *ScanBuilder) LimitResults(limit int) *ScanBuilder {
+	sb.PaginationMixin.LimitResults(limit)
+	return sb
+}
+
+// MaxItems sets the number of items ExecuteAll returns at most and returns ScanBuilder for method chaining.
+// ExecuteAll follows LastEvaluatedKey until the data is exhausted or maxItems items are collected,
+// the request Limit (Limit or PageSize) stays unchanged.
+func (sb *ScanBuilder) MaxItems(maxItems int) *ScanBuilder {
+	sb.PaginationMixin.MaxItems(maxItems)
+	return sb
+}
+
+// MaxPages caps the number of requests LimitResults may send (DefaultMaxPages by default).
+// Reaching it returns the items read with a *PartialResultError wrapping ErrPageCapReached.
+func (sb *ScanBuilder) MaxPages(pages int) *ScanBuilder {
+	sb.PaginationMixin.MaxPages(pages)
+	return sb
+}
+
+// StartFrom sets the exclusive start key and returns ScanBuilder for method chaining.
+// Use LastEvaluatedKey from previous response for pagination.
+func (sb *ScanBuilder) StartFrom(lastEvaluatedKey map[string]types.AttributeValue) *ScanBuilder {
+	sb.PaginationMixin.StartFrom(lastEvaluatedKey)
+	return sb
+}
+
+// StartFromCursor resumes after the page whose NextCursor is token and returns ScanBuilder for method chaining.
+// Cursors come from ExecutePaginated and may be round-tripped through web clients.
+func (sb *ScanBuilder) StartFromCursor(token string) *ScanBuilder {
+	sb.PaginationMixin.StartFromCursor(token)
+	return sb
+}
+
+// WithIndex sets the index name for scanning a secondary index.
+// Allows scanning GSI or LSI instead of the main table.
+// Index must exist and be in ACTIVE state.
+func (sb *ScanBuilder) WithIndex(indexName string) *ScanBuilder {
+	sb.IndexName = indexName
+	return sb
+}
+
+// WithConsistentRead requests strongly consistent reads and returns ScanBuilder for method chaining.
+// GSIs don't support consistent reads: BuildScan fails if WithIndex names one.
+func (sb *ScanBuilder) WithConsistentRead() *ScanBuilder {
+	sb.ConsistentRead = true
+	return sb
+}
+
+// WithStablePlaceholders names the expression placeholders of built requests after their attributes
+// instead of numbering them, so request snapshots don't depend on build order.
+func (sb *ScanBuilder) WithStablePlaceholders() *ScanBuilder {
+	sb.StablePlaceholders = true
+	return sb
+}
+
+// WithProjection sets the projection attributes to return specific fields only.
+// Reduces network traffic and costs by returning only needed attributes.
+// Pass attribute names that should be included in the response.
+func (sb *ScanBuilder) WithProjection(attributes []string) *ScanBuilder {
+	sb.ProjectionAttributes = attributes
+	return sb
+}
+
+// WithParallelScan configures parallel scan settings for improved throughput.
+// Divides the table into segments for concurrent processing by multiple workers.
+// totalSegments: how many segments to divide the table (typically number of workers)
+// segment: which segment this worker processes (0-based, must be < totalSegments)
+func (sb *ScanBuilder) WithParallelScan(totalSegments, segment int) *ScanBuilder {
+	sb.ParallelScanConfig = &ParallelScanConfig{
+		TotalSegments: totalSegments,
+		Segment:       segment,
+	}
+	return sb
+}
+
+// Filter adds a filter condition and returns ScanBuilder for method chaining.
+// Wraps FilterMixin.Filter with fluent interface support.
+func (sb *ScanBuilder) Filter(field string, op OperatorType, values ...any) *ScanBuilder {
+	sb.FilterMixin.Filter(field, op, values...)
+	return sb
+}
+
+// CONVENIENCE METHODS - Only available in ALL mode
+
+// FilterEQ adds equality filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterEQ(field string, value any) *ScanBuilder {
+	sb.FilterMixin.FilterEQ(field, value)
+	return sb
+}
+
+// FilterContains adds contains filter and returns ScanBuilder for method chaining.
+// Works with String attributes (substring) and Set attributes (membership).
+func (sb *ScanBuilder) FilterContains(field string, value any) *ScanBuilder {
+	sb.FilterMixin.FilterContains(field, value)
+	return sb
+}
+
+// FilterNotContains adds not contains filter and returns ScanBuilder for method chaining.
+// Opposite of FilterContains for exclusion filtering.
+func (sb *ScanBuilder) FilterNotContains(field string, value any) *ScanBuilder {
+	sb.FilterMixin.FilterNotContains(field, value)
+	return sb
+}
+
+// FilterBeginsWith adds begins_with filter and returns ScanBuilder for method chaining.
+// Only works with String attributes for prefix matching.
+func (sb *ScanBuilder) FilterBeginsWith(field string, value any) *ScanBuilder {
+	sb.FilterMixin.FilterBeginsWith(field, value)
+	return sb
+}
+
+// FilterBetween adds range filter and returns ScanBuilder for method chaining.
+// Works with comparable types for inclusive range filtering.
+func (sb *ScanBuilder) FilterBetween(field string, start, end any) *ScanBuilder {
+	sb.FilterMixin.FilterBetween(field, start, end)
+	return sb
+}
+
+// FilterGT adds greater than filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterGT(field string, value any) *ScanBuilder {
+	sb.FilterMixin.FilterGT(field, value)
+	return sb
+}
+
+// FilterLT adds less than filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterLT(field string, value any) *ScanBuilder {
+	sb.FilterMixin.FilterLT(field, value)
+	return sb
+}
+
+// FilterGTE adds greater than or equal filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterGTE(field string, value any) *ScanBuilder {
+	sb.FilterMixin.FilterGTE(field, value)
+	return sb
+}
+
+// FilterLTE adds less than or equal filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterLTE(field string, value any) *ScanBuilder {
+	sb.FilterMixin.FilterLTE(field, value)
+	return sb
+}
+
+// FilterExists adds attribute exists filter and returns ScanBuilder for method chaining.
+// Checks if the specified attribute exists in the item.
+func (sb *ScanBuilder) FilterExists(field string) *ScanBuilder {
+	sb.FilterMixin.FilterExists(field)
+	return sb
+}
+
+// FilterNotExists adds attribute not exists filter and returns ScanBuilder for method chaining.
+// Checks if the specified attribute does not exist in the item.
+func (sb *ScanBuilder) FilterNotExists(field string) *ScanBuilder {
+	sb.FilterMixin.FilterNotExists(field)
+	return sb
+}
+
+// FilterNE adds not equal filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterNE(field string, value any) *ScanBuilder {
+	sb.FilterMixin.FilterNE(field, value)
+	return sb
+}
+
+// FilterIn adds IN filter and returns ScanBuilder for method chaining.
+// For scalar values only - use FilterContains for DynamoDB Sets.
+func (sb *ScanBuilder) FilterIn(field string, values ...any) *ScanBuilder {
+	sb.FilterMixin.FilterIn(field, values...)
+	return sb
+}
+
+// FilterNotIn adds NOT_IN filter and returns ScanBuilder for method chaining.
+// For scalar values only - use FilterNotContains for DynamoDB Sets.
+func (sb *ScanBuilder) FilterNotIn(field string, values ...any) *ScanBuilder {
+	sb.FilterMixin.FilterNotIn(field, values...)
+	return sb
+}
+
+// FilterSizeEQ adds size(field) = size filter and returns ScanBuilder for method chaining.
+// Size is the length of strings, bytes of binaries and elements of sets, lists and maps.
+func (sb *ScanBuilder) FilterSizeEQ(field string, size int) *ScanBuilder {
+	sb.FilterMixin.FilterSizeEQ(field, size)
+	return sb
+}
+
+// FilterSizeGT adds size(field) > size filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSizeGT(field string, size int) *ScanBuilder {
+	sb.FilterMixin.FilterSizeGT(field, size)
+	return sb
+}
+
+// FilterSizeLT adds size(field) < size filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSizeLT(field string, size int) *ScanBuilder {
+	sb.FilterMixin.FilterSizeLT(field, size)
+	return sb
+}
+
+// FilterSizeGTE adds size(field) >= size filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSizeGTE(field string, size int) *ScanBuilder {
+	sb.FilterMixin.FilterSizeGTE(field, size)
+	return sb
+}
+
+// FilterSizeLTE adds size(field) <= size filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSizeLTE(field string, size int) *ScanBuilder {
+	sb.FilterMixin.FilterSizeLTE(field, size)
+	return sb
+}
+
+// FilterSizeBetween adds size(field) BETWEEN minSize AND maxSize filter and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSizeBetween(field string, minSize, maxSize int) *ScanBuilder {
+	sb.FilterMixin.FilterSizeBetween(field, minSize, maxSize)
+	return sb
+}
+
+// FilterAttributeType adds attribute_type filter and returns ScanBuilder for method chaining.
+// Useful in data quality scans for items storing field with another type than the schema declares.
+func (sb *ScanBuilder) FilterAttributeType(field string, attrType string) *ScanBuilder {
+	sb.FilterMixin.FilterAttributeType(field, attrType)
+	return sb
+}
+
+// FilterPkIn filters items whose pk is one of the values and returns ScanBuilder for method chaining.
+// Lists longer than 100 values are split into IN comparisons joined with OR,
+// lists longer than MaxInValues fail when the input is built.
+func (sb *ScanBuilder) FilterPkIn(values ...string) *ScanBuilder {
+	sb.FilterMixin.FilterIn(ColumnPk, filterValues(values)...)
+	return sb
+}
+
+// FilterPkNotIn filters items whose pk is none of the values and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterPkNotIn(values ...string) *ScanBuilder {
+	sb.FilterMixin.FilterNotIn(ColumnPk, filterValues(values)...)
+	return sb
+}
+
+// FilterSkIn filters items whose sk is one of the values and returns ScanBuilder for method chaining.
+// Lists longer than 100 values are split into IN comparisons joined with OR,
+// lists longer than MaxInValues fail when the input is built.
+func (sb *ScanBuilder) FilterSkIn(values ...string) *ScanBuilder {
+	sb.FilterMixin.FilterIn(ColumnSk, filterValues(values)...)
+	return sb
+}
+
+// FilterSkNotIn filters items whose sk is none of the values and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterSkNotIn(values ...string) *ScanBuilder {
+	sb.FilterMixin.FilterNotIn(ColumnSk, filterValues(values)...)
+	return sb
+}
+
+// FilterRequestsIn filters items whose requests is one of the values and returns ScanBuilder for method chaining.
+// Lists longer than 100 values are split into IN comparisons joined with OR,
+// lists longer than MaxInValues fail when the input is built.
+func (sb *ScanBuilder) FilterRequestsIn(values ...int) *ScanBuilder {
+	sb.FilterMixin.FilterIn(ColumnRequests, filterValues(values)...)
+	return sb
+}
+
+// FilterRequestsNotIn filters items whose requests is none of the values and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterRequestsNotIn(values ...int) *ScanBuilder {
+	sb.FilterMixin.FilterNotIn(ColumnRequests, filterValues(values)...)
+	return sb
+}
+
+// FilterExpiresAtIn filters items whose expires_at is one of the values and returns ScanBuilder for method chaining.
+// Lists longer than 100 values are split into IN comparisons joined with OR,
+// lists longer than MaxInValues fail when the input is built.
+func (sb *ScanBuilder) FilterExpiresAtIn(values ...int64) *ScanBuilder {
+	sb.FilterMixin.FilterIn(ColumnExpiresAt, filterValues(values)...)
+	return sb
+}
+
+// FilterExpiresAtNotIn filters items whose expires_at is none of the values and returns ScanBuilder for method chaining.
+func (sb *ScanBuilder) FilterExpiresAtNotIn(values ...int64) *ScanBuilder {
+	sb.FilterMixin.FilterNotIn(ColumnExpiresAt, filterValues(values)...)
+	return sb
+}
+
+// BuildScan constructs the final DynamoDB ScanInput with all configured options.
+// Combines filter conditions, projection attributes, pagination, and parallel scan settings.
+// Handles expression building and attribute mapping automatically.
+func (sb *ScanBuilder) BuildScan() (*dynamodb.ScanInput, error) {
+	if err := sb.checkCursor(sb.IndexName); err != nil {
+		return nil, err
+	}
+	if sb.filterErr != nil {
+		return nil, sb.filterErr
+	}
+	if err := sb.validateConsistentRead(); err != nil {
+		return nil, err
+	}
+	input := &dynamodb.ScanInput{
+		TableName: aws.String(TableName),
+	}
+	if sb.IndexName != "" {
+		input.IndexName = aws.String(sb.IndexName)
+	}
+	if sb.ConsistentRead {
+		input.ConsistentRead = aws.Bool(true)
+	}
+	var exprBuilder expression.Builder
+	hasExpression := false
+
+	if len(sb.FilterConditions) > 0 {
+		combinedFilter := sb.FilterConditions[0]
+		for _, condition := range sb.FilterConditions[1:] {
+			combinedFilter = combinedFilter.And(condition)
+		}
+		exprBuilder = exprBuilder.WithFilter(combinedFilter)
+		hasExpression = true
+	}
+	if len(sb.ProjectionAttributes) > 0 {
+		var projectionBuilder expression.ProjectionBuilder
+		for i, attr := range sb.ProjectionAttributes {
+			if i == 0 {
+				projectionBuilder = expression.NamesList(expression.Name(attr))
+			} else {
+				projectionBuilder = projectionBuilder.AddNames(expression.Name(attr))
+			}
+		}
+		exprBuilder = exprBuilder.WithProjection(projectionBuilder)
+		hasExpression = true
+	}
+	if hasExpression {
+		expr, err := exprBuilder.Build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build scan expression: %v", err)
+		}
+		if len(sb.FilterConditions) > 0 {
+			input.FilterExpression = expr.Filter()
+		}
+		if len(sb.ProjectionAttributes) > 0 {
+			input.ProjectionExpression = expr.Projection()
+		}
+		if expr.Names() != nil {
+			input.ExpressionAttributeNames = expr.Names()
+		}
+		if expr.Values() != nil {
+			input.ExpressionAttributeValues = expr.Values()
+		}
+		if sb.StablePlaceholders {
+			input.ExpressionAttributeNames, input.ExpressionAttributeValues = stablePlaceholders(input.ExpressionAttributeNames, input.ExpressionAttributeValues, input.FilterExpression, input.ProjectionExpression)
+		}
+	}
+	input.Limit = sb.requestLimit()
+	if sb.ExclusiveStartKey != nil {
+		input.ExclusiveStartKey = sb.ExclusiveStartKey
+	}
+	if sb.ParallelScanConfig != nil {
+		input.Segment = aws.Int32(int32(sb.ParallelScanConfig.Segment))
+		input.TotalSegments = aws.Int32(int32(sb.ParallelScanConfig.TotalSegments))
+	}
+	return input, nil
+}
+
+// validateConsistentRead rejects consistent reads of a global secondary index.
+func (sb *ScanBuilder) validateConsistentRead() error {
+	if !sb.ConsistentRead || sb.IndexName == "" {
+		return nil
+	}
+	if info := GetIndexInfo(sb.IndexName); info != nil && info.Type == "GSI" {
+		return fmt.Errorf("consistent reads are not supported on global secondary index %s", sb.IndexName)
+	}
+	return nil
+}
+
+// Execute runs the scan against DynamoDB and returns strongly-typed results.
+// Handles the complete scan lifecycle: build input, execute, unmarshal results.
+// Returns all items that match the filter conditions as SchemaItem structs.
+// With LimitResults, pages are fetched until enough items pass the filters (ExecuteWithCursor
+// also returns the cursor resuming after them); if ctx ends or MaxPages requests are sent before,
+// the items read so far are returned with a *PartialResultError.
+// With FilterHashKeyIn, one Query per hash key replaces the scan.
+func (sb *ScanBuilder) Execute(ctx context.Context, client DynamoDBAPI) ([]SchemaItem, error) {
+	if sb.HashKeyValues != nil {
+		if sb.ResultLimitValue != nil {
+			return sb.executeFanOut(ctx, client, *sb.ResultLimitValue, sb.maxPages())
+		}
+		return sb.executeFanOut(ctx, client, -1, 1)
+	}
+	if sb.ResultLimitValue != nil {
+		items, _, err := sb.executePages(ctx, client, *sb.ResultLimitValue, sb.maxPages())
+		return items, err
+	}
+	input, err := sb.BuildScan()
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.Scan(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute scan: %v", err)
+	}
+	var items []SchemaItem
+	err = attributevalue.UnmarshalListOfMaps(result.Items, &items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scan result: %v", err)
+	}
+	return items, nil
+}
+
+// ExecuteAll runs the scan following LastEvaluatedKey until every page is read
+// and returns at most MaxItems (or Limit) items, all matching items if neither is set.
+// PageSize controls the number of items evaluated per request.
+// ExecuteWithCursor also returns the cursor resuming after the returned items; if ctx ends before the last page,
+// the items read so far are returned with a *PartialResultError holding the cursor to resume from.
+// Neither applies with FilterHashKeyIn.
+func (sb *ScanBuilder) ExecuteAll(ctx context.Context, client DynamoDBAPI) ([]SchemaItem, error) {
+	if sb.HashKeyValues != nil {
+		return sb.executeFanOut(ctx, client, sb.totalLimit(), -1)
+	}
+	items, _, err := sb.executePages(ctx, client, sb.totalLimit(), -1)
+	return items, err
+}
+
+// ExecuteWithCursor reads like Execute with LimitResults, like ExecuteAll without it, and returns
+// the items with the cursor resuming after them, empty if all matching items were read.
+// The cursor is not kept by the builder, so it can be reused or shared between reads.
+// Example:
+//
+//	page, err := NewScanBuilder().LimitResults(100).StartFromCursor(cursor).ExecuteWithCursor(ctx, client)
+//	if page.HasMore {
+//	    cursor = page.NextCursor
+//	}
+func (sb *ScanBuilder) ExecuteWithCursor(ctx context.Context, client DynamoDBAPI) (Page, error) {
+	if sb.HashKeyValues != nil {
+		return Page{}, fmt.Errorf("FilterHashKeyIn is not supported by ExecuteWithCursor, use Execute or ExecuteAll")
+	}
+	limit, maxPages := sb.totalLimit(), -1
+	if sb.ResultLimitValue != nil {
+		limit, maxPages = *sb.ResultLimitValue, sb.maxPages()
+	}
+	items, cursor, err := sb.executePages(ctx, client, limit, maxPages)
+	return Page{Items: items, NextCursor: cursor, HasMore: cursor != ""}, err
+}
+
+// ExecutePaginated runs one request of the scan and returns its page with the cursor of the next one.
+// PageSize (or Limit) sets the number of items evaluated per page; with filters a page may hold
+// fewer items, even none, while HasMore is true.
+// Example:
+//
+//	page, err := NewScanBuilder().PageSize(50).StartFromCursor(cursor).ExecutePaginated(ctx, client)
+//	if page.HasMore {
+//	    cursor = page.NextCursor
+//	}
+func (sb *ScanBuilder) ExecutePaginated(ctx context.Context, client DynamoDBAPI) (Page, error) {
+	if sb.HashKeyValues != nil {
+		return Page{}, fmt.Errorf("FilterHashKeyIn is not supported by ExecutePaginated, use Execute or ExecuteAll")
+	}
+	input, err := sb.BuildScan()
+	if err != nil {
+		return Page{}, err
+	}
+	result, err := client.Scan(ctx, input)
+	if err != nil {
+		return Page{}, fmt.Errorf("failed to execute scan: %v", err)
+	}
+	var items []SchemaItem
+	if err := attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+		return Page{}, fmt.Errorf("failed to unmarshal scan result: %v", err)
+	}
+	cursor, err := EncodeCursor(result.LastEvaluatedKey)
+	if err != nil {
+		return Page{}, err
+	}
+	return Page{Items: items, NextCursor: cursor, HasMore: cursor != ""}, nil
+}
+
+// Iterate returns a PageIterator over the scan results which prefetches one page ahead.
+// PageSize controls the number of items evaluated per request; StartFrom sets the first page.
+func (sb *ScanBuilder) Iterate(ctx context.Context, client DynamoDBAPI) (*PageIterator, error) {
+	if sb.HashKeyValues != nil {
+		return nil, fmt.Errorf("FilterHashKeyIn is not supported by Iterate, use Execute or ExecuteAll")
+	}
+	input, err := sb.BuildScan()
+	if err != nil {
+		return nil, err
+	}
+	fetch := func(ctx context.Context, startKey map[string]types.AttributeValue) ([]SchemaItem, map[string]types.AttributeValue, error) {
+		pageInput := *input
+		pageInput.ExclusiveStartKey = startKey
+		result, err := client.Scan(ctx, &pageInput)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to execute scan: %v", err)
+		}
+		var items []SchemaItem
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal scan result: %v", err)
+		}
+		return items, result.LastEvaluatedKey, nil
+	}
+	return newPageIterator(ctx, input.ExclusiveStartKey, fetch), nil
+}
+
+// Items returns an iterator over the scan results which reads pages lazily, one page ahead,
+// so large results are processed without buffering them. Stopping the loop stops reading;
+// canceling ctx ends the loop with ctx.Err(), so a truncated read is never mistaken for a complete one.
+// Example:
+//
+//	for item, err := range NewScanBuilder().PageSize(100).Items(ctx, client) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(item)
+//	}
+func (sb *ScanBuilder) Items(ctx context.Context, client DynamoDBAPI) iter.Seq2[SchemaItem, error] {
+	return iterateItems(sb.Iterate(ctx, client))
+}
+
+// Count returns the number of items matching the filters without transferring them:
+// requests use Select COUNT and follow LastEvaluatedKey until the table (or segment) is exhausted.
+// Projection is ignored.
+// Example:
+//
+//	n, err := NewScanBuilder().Filter(ColumnPk, EQ, "pk-1").Count(ctx, client)
+func (sb *ScanBuilder) Count(ctx context.Context, client DynamoDBAPI) (int64, error) {
+	count, _, err := sb.CountScanned(ctx, client)
+	return count, err
+}
+
+// CountScanned is Count which also returns the number of items evaluated before filters,
+// the read cost of the scan.
+func (sb *ScanBuilder) CountScanned(ctx context.Context, client DynamoDBAPI) (count, scanned int64, err error) {
+	if sb.HashKeyValues != nil {
+		return 0, 0, fmt.Errorf("FilterHashKeyIn is not supported by Count, use Execute or ExecuteAll")
+	}
+	counter := *sb
+	counter.ProjectionAttributes = nil
+	input, err := counter.BuildScan()
+	if err != nil {
+		return 0, 0, err
+	}
+	input.Select = types.SelectCount
+	for {
+		result, err := client.Scan(ctx, input)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to execute scan: %v", err)
+		}
+		count += int64(result.Count)
+		scanned += int64(result.ScannedCount)
+		if len(result.LastEvaluatedKey) == 0 {
+			return count, scanned, nil
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
+
+// DefaultStreamBuffer is the number of items ExecuteStream buffers ahead of the consumer.
+const DefaultStreamBuffer = 1000
+
+// ExecuteStream pages through the scan in the background and sends the items to a channel
+// of DefaultStreamBuffer items: reading pauses while the channel is full, so memory stays
+// bounded however large the table is. Both channels are closed when the scan ends; at most
+// one error is sent, after which no more items follow. Cancel ctx to stop early: ctx.Err()
+// is then sent to errs, so a truncated scan is never mistaken for a complete one.
+// Example:
+//
+//	items, errs := NewScanBuilder().PageSize(500).ExecuteStream(ctx, client)
+//	for item := range items {
+//	    process(item)
+//	}
+//	if err := <-errs; err != nil {
+//	    return err
+//	}
+func (sb *ScanBuilder) ExecuteStream(ctx context.Context, client DynamoDBAPI) (<-chan SchemaItem, <-chan error) {
+	var (
+		items = make(chan SchemaItem, DefaultStreamBuffer)
+		errs  = make(chan error, 1)
+	)
+	go func() {
+		defer close(errs)
+		defer close(items)
+		for item, err := range sb.Items(ctx, client) {
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case items <- item:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return items, errs
+}
+
+// executePages paginates the scan until limit items pass the filters, the table (or segment)
+// is exhausted or maxPages requests are sent. Negative values disable the respective cap.
+// Items of a page past limit are left unread, the returned cursor resumes after the returned ones;
+// reaching maxPages first returns the items with a *PartialResultError wrapping ErrPageCapReached.
+func (sb *ScanBuilder) executePages(ctx context.Context, client DynamoDBAPI, limit, maxPages int) ([]SchemaItem, string, error) {
+	input, added, err := sb.buildPagedScan(limit)
+	if err != nil {
+		return nil, "", err
+	}
+	var items []SchemaItem
+	resume := input.ExclusiveStartKey
+	for page := 0; limit < 0 || len(items) < limit; page++ {
+		if maxPages >= 0 && page >= maxPages {
+			cursor, err := finishPages(len(items), resume, ErrPageCapReached)
+			return items, cursor, err
+		}
+		result, err := client.Scan(ctx, input)
+		if err != nil {
+			if ctx.Err() != nil {
+				cursor, err := finishPages(len(items), resume, ctx.Err())
+				return items, cursor, err
+			}
+			return nil, "", fmt.Errorf("failed to execute scan: %v", err)
+		}
+		for _, raw := range result.Items {
+			if limit >= 0 && len(items) >= limit {
+				cursor, err := finishPages(len(items), resume, nil)
+				return items, cursor, err
+			}
+			resume = itemKey(raw, aws.ToString(input.IndexName))
+			if len(added) > 0 {
+				raw = maps.Clone(raw)
+				for _, name := range added {
+					delete(raw, name)
+				}
+			}
+			var item SchemaItem
+			if err := attributevalue.UnmarshalMap(raw, &item); err != nil {
+				return nil, "", fmt.Errorf("failed to unmarshal scan result: %v", err)
+			}
+			items = append(items, item)
+		}
+		resume = result.LastEvaluatedKey
+		if len(resume) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = resume
+	}
+	cursor, err := finishPages(len(items), resume, nil)
+	return items, cursor, err
+}
+
+// buildPagedScan builds the input of executePages. A read capped at limit items (not negative) resumes
+// after its last item, so key attributes missing from the projection are added to it: they are returned
+// to be removed from the items again.
+func (sb *ScanBuilder) buildPagedScan(limit int) (*dynamodb.ScanInput, []string, error) {
+	if limit < 0 || len(sb.ProjectionAttributes) == 0 {
+		input, err := sb.BuildScan()
+		return input, nil, err
+	}
+	var added []string
+	for _, name := range keyAttributeNames(sb.IndexName) {
+		if !slices.Contains(sb.ProjectionAttributes, name) {
+			added = append(added, name)
+		}
+	}
+	paged := *sb
+	paged.ProjectionAttributes = append(slices.Clone(sb.ProjectionAttributes), added...)
+	input, err := paged.BuildScan()
+	return input, added, err
+}
+
+// hashKeyFanOutConcurrency bounds the number of concurrent per-key queries of FilterHashKeyIn.
+const hashKeyFanOutConcurrency = 8
+
+// FilterHashKeyIn restricts the scan to items whose hash key (of the table, or of the index set
+// by WithIndex) is one of values. Execute, ExecuteAll and LimitResults then send one Query per key
+// instead of scanning, applying the other filters, projection and PageSize to each query.
+// Results are merged in the order of values; duplicate values are queried once.
+// Iterate and ExecuteParallel don't support it and return an error.
+// Example:
+//
+//	items, err := NewScanBuilder().
+//	    FilterHashKeyIn("pk-1").
+//	    Execute(ctx, client)
+func (sb *ScanBuilder) FilterHashKeyIn(values ...any) *ScanBuilder {
+	if sb.HashKeyValues == nil {
+		sb.HashKeyValues = make([]any, 0, len(values))
+	}
+	sb.HashKeyValues = append(sb.HashKeyValues, values...)
+	return sb
+}
+
+// fanOutHashKey returns the hash key attribute queried by FilterHashKeyIn.
+func (sb *ScanBuilder) fanOutHashKey() (string, error) {
+	if sb.IndexName == "" {
+		return TableSchema.HashKey, nil
+	}
+	for _, idx := range TableSchema.SecondaryIndexes {
+		if idx.Name != sb.IndexName {
+			continue
+		}
+		if idx.HashKey == "" {
+			return TableSchema.HashKey, nil
+		}
+		return idx.HashKey, nil
+	}
+	return "", fmt.Errorf("unknown index %s", sb.IndexName)
+}
+
+// buildFanOutQueries creates one QueryInput per distinct hash key value.
+func (sb *ScanBuilder) buildFanOutQueries() ([]*dynamodb.QueryInput, error) {
+	hashKey, err := sb.fanOutHashKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := sb.validateConsistentRead(); err != nil {
+		return nil, err
+	}
+	var (
+		inputs []*dynamodb.QueryInput
+		seen   = make(map[string]bool, len(sb.HashKeyValues))
+	)
+	for _, value := range sb.HashKeyValues {
+		id := fmt.Sprintf("%T:%v", value, value)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		exprBuilder := expression.NewBuilder().WithKeyCondition(expression.Key(hashKey).Equal(expression.Value(value)))
+		if filter := combineConditions(sb.FilterConditions); filter != nil {
+			exprBuilder = exprBuilder.WithFilter(*filter)
+		}
+		if len(sb.ProjectionAttributes) > 0 {
+			projection := expression.NamesList(expression.Name(sb.ProjectionAttributes[0]))
+			for _, attr := range sb.ProjectionAttributes[1:] {
+				projection = projection.AddNames(expression.Name(attr))
+			}
+			exprBuilder = exprBuilder.WithProjection(projection)
+		}
+		expr, err := exprBuilder.Build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build query expression for hash key %v: %v", value, err)
+		}
+		input := &dynamodb.QueryInput{
+			TableName:                 aws.String(TableName),
+			KeyConditionExpression:    expr.KeyCondition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			Limit:                     sb.requestLimit(),
+		}
+		if len(sb.FilterConditions) > 0 {
+			input.FilterExpression = expr.Filter()
+		}
+		if len(sb.ProjectionAttributes) > 0 {
+			input.ProjectionExpression = expr.Projection()
+		}
+		if sb.IndexName != "" {
+			input.IndexName = aws.String(sb.IndexName)
+		}
+		if sb.ConsistentRead {
+			input.ConsistentRead = aws.Bool(true)
+		}
+		if sb.StablePlaceholders {
+			input.ExpressionAttributeNames, input.ExpressionAttributeValues = stablePlaceholders(input.ExpressionAttributeNames, input.ExpressionAttributeValues, input.KeyConditionExpression, input.FilterExpression, input.ProjectionExpression)
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// executeFanOut runs the per-key queries concurrently, reading at most maxPages pages
+// (negative for all) of each key, and returns at most limit items (negative for all).
+func (sb *ScanBuilder) executeFanOut(ctx context.Context, client DynamoDBAPI, limit, maxPages int) ([]SchemaItem, error) {
+	inputs, err := sb.buildFanOutQueries()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		results = make([][]SchemaItem, len(inputs))
+		errs    = make([]error, len(inputs))
+		sem     = make(chan struct{}, hashKeyFanOutConcurrency)
+		wg      sync.WaitGroup
+	)
+	for i, input := range inputs {
+		wg.Add(1)
+		go func(i int, input *dynamodb.QueryInput) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = queryPages(ctx, client, input, limit, maxPages)
+		}(i, input)
+	}
+	wg.Wait()
+
+	var items []SchemaItem
+	for i := range inputs {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		items = append(items, results[i]...)
+	}
+	if limit >= 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// queryPages paginates one query until limit items are read or maxPages requests are sent.
+func queryPages(ctx context.Context, client DynamoDBAPI, input *dynamodb.QueryInput, limit, maxPages int) ([]SchemaItem, error) {
+	var items []SchemaItem
+	for page := 0; (maxPages < 0 || page < maxPages) && (limit < 0 || len(items) < limit); page++ {
+		result, err := client.Query(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute query: %v", err)
+		}
+		var pageItems []SchemaItem
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &pageItems); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal query result: %v", err)
+		}
+		items = append(items, pageItems...)
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+	return items, nil
+}
+
+// SegmentCheckpoint is the progress of one parallel scan segment.
+// LastEvaluatedKey is the position after the last handled page; Done marks a finished segment.
+// TotalSegments is the split of the table the segment belongs to: a position is only valid
+// within the same split.
+type SegmentCheckpoint struct {
+	Segment          int
+	TotalSegments    int
+	LastEvaluatedKey map[string]types.AttributeValue
+	Done             bool
+}
+
+// CheckpointStore persists segment checkpoints of a parallel scan job,
+// so an interrupted job resumes where it stopped instead of rescanning.
+// Implementations must be safe for concurrent use by segment workers.
+type CheckpointStore interface {
+	// Load returns the saved checkpoints of the job by segment, empty for a new job.
+	Load(ctx context.Context, job string) (map[int]SegmentCheckpoint, error)
+	// Save records the checkpoint of one segment.
+	Save(ctx context.Context, job string, checkpoint SegmentCheckpoint) error
+	// Clear removes all checkpoints of the job once every segment is done.
+	Clear(ctx context.Context, job string) error
+}
+
+// ParallelScanOptions configures ExecuteParallel.
+type ParallelScanOptions struct {
+	TotalSegments int             // Number of segments the table is split into
+	Concurrency   int             // Number of segments scanned at once, TotalSegments if not positive
+	Checkpoints   CheckpointStore // Optional store making the job resumable
+	Job           string          // Job identifier in the checkpoint store, required with Checkpoints
+}
+
+// PageHandler processes one page of items read from a segment.
+type PageHandler func(ctx context.Context, segment int, items []SchemaItem) error
+
+// ExecuteParallel scans the table in opts.TotalSegments segments by a pool of opts.Concurrency workers
+// and calls handle for every page, concurrently from different segments.
+// With a checkpoint store the segment position is saved after each handled page, so a page may be
+// handled again after a crash (at-least-once) but finished pages are never rescanned.
+// Checkpoints are cleared when all segments complete. Checkpoints saved with another TotalSegments
+// are rejected, since segments of another split cover other keys: resume the job with its original
+// TotalSegments or clear it. The first error cancels the other segments; errors of segments which
+// failed before the cancellation are joined into the returned error.
+// Example:
+//
+//	err := NewScanBuilder().ExecuteParallel(ctx, client, ParallelScanOptions{
+//	    TotalSegments: 8,
+//	    Checkpoints:   NewFileCheckpointStore("/var/lib/scan"),
+//	    Job:           "reindex-2024-06",
+//	}, func(ctx context.Context, segment int, items []SchemaItem) error {
+//	    return process(items)
+//	})
+func (sb *ScanBuilder) ExecuteParallel(ctx context.Context, client DynamoDBAPI, opts ParallelScanOptions, handle PageHandler) error {
+	if opts.TotalSegments < 1 {
+		return fmt.Errorf("parallel scan needs at least one segment, got %d", opts.TotalSegments)
+	}
+	if sb.HashKeyValues != nil {
+		return fmt.Errorf("FilterHashKeyIn is not supported by ExecuteParallel, use Execute or ExecuteAll")
+	}
+	if opts.Checkpoints != nil && opts.Job == "" {
+		return fmt.Errorf("parallel scan with checkpoints needs a job identifier")
+	}
+	input, err := sb.BuildScan()
+	if err != nil {
+		return err
+	}
+	saved := map[int]SegmentCheckpoint{}
+	if opts.Checkpoints != nil {
+		if saved, err = opts.Checkpoints.Load(ctx, opts.Job); err != nil {
+			return fmt.Errorf("failed to load checkpoints of job %s: %v", opts.Job, err)
+		}
+		for _, checkpoint := range saved {
+			if checkpoint.TotalSegments != opts.TotalSegments {
+				return fmt.Errorf("checkpoints of job %s were saved with %d segments, got %d: resume with the same TotalSegments or clear the job",
+					opts.Job, checkpoint.TotalSegments, opts.TotalSegments)
+			}
+		}
+	}
+
+	workers := opts.Concurrency
+	if workers <= 0 || workers > opts.TotalSegments {
+		workers = opts.TotalSegments
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		pool = make(chan struct{}, workers)
+	)
+	for segment := 0; segment < opts.TotalSegments; segment++ {
+		checkpoint := saved[segment]
+		if checkpoint.Done {
+			continue
+		}
+		segmentInput := *input
+		segmentInput.Segment = aws.Int32(int32(segment))
+		segmentInput.TotalSegments = aws.Int32(int32(opts.TotalSegments))
+		if checkpoint.LastEvaluatedKey != nil {
+			segmentInput.ExclusiveStartKey = checkpoint.LastEvaluatedKey
+		}
+
+		wg.Add(1)
+		go func(segment int, input *dynamodb.ScanInput) {
+			defer wg.Done()
+			select {
+			case pool <- struct{}{}:
+				defer func() { <-pool }()
+			case <-ctx.Done():
+				return
+			}
+			if err := scanSegment(ctx, client, input, segment, opts, handle); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				if len(errs) == 0 || ctx.Err() == nil {
+					errs = append(errs, err)
+				}
+				cancel()
+			}
+		}(segment, &segmentInput)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if opts.Checkpoints != nil {
+		if err := opts.Checkpoints.Clear(ctx, opts.Job); err != nil {
+			return fmt.Errorf("failed to clear checkpoints of job %s: %v", opts.Job, err)
+		}
+	}
+	return nil
+}
+
+// ExecuteParallelAll scans the table in totalSegments concurrent segments and returns all items,
+// merged in segment order. Use ExecuteParallel with a handler or ExecuteParallelStream for tables
+// which don't fit in memory.
+// Example:
+//
+//	items, err := NewScanBuilder().Filter(ColumnPk, EQ, "pk-1").ExecuteParallelAll(ctx, client, 4)
+func (sb *ScanBuilder) ExecuteParallelAll(ctx context.Context, client DynamoDBAPI, totalSegments int) ([]SchemaItem, error) {
+	var (
+		mu       sync.Mutex
+		segments = make(map[int][]SchemaItem)
+	)
+	err := sb.ExecuteParallel(ctx, client, ParallelScanOptions{TotalSegments: totalSegments}, func(_ context.Context, segment int, items []SchemaItem) error {
+		mu.Lock()
+		defer mu.Unlock()
+		segments[segment] = append(segments[segment], items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var items []SchemaItem
+	for segment := 0; segment < totalSegments; segment++ {
+		items = append(items, segments[segment]...)
+	}
+	return items, nil
+}
+
+// ExecuteParallelStream scans the table in totalSegments concurrent segments and sends the items
+// to a channel of DefaultStreamBuffer items as they are read, in no particular order.
+// Segments pause while the channel is full. Both channels are closed when the scan ends;
+// at most one (joined) error is sent. Cancel ctx to stop early.
+// Example:
+//
+//	items, errs := NewScanBuilder().ExecuteParallelStream(ctx, client, 8)
+//	for item := range items {
+//	    process(item)
+//	}
+//	if err := <-errs; err != nil {
+//	    return err
+//	}
+func (sb *ScanBuilder) ExecuteParallelStream(ctx context.Context, client DynamoDBAPI, totalSegments int) (<-chan SchemaItem, <-chan error) {
+	var (
+		items = make(chan SchemaItem, DefaultStreamBuffer)
+		errs  = make(chan error, 1)
+	)
+	go func() {
+		defer close(errs)
+		defer close(items)
+		err := sb.ExecuteParallel(ctx, client, ParallelScanOptions{TotalSegments: totalSegments}, func(ctx context.Context, _ int, page []SchemaItem) error {
+			for _, item := range page {
+				select {
+				case items <- item:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			errs <- err
+		}
+	}()
+	return items, errs
+}
+
+// scanSegment reads one segment page by page, saving a checkpoint after every handled page.
+func scanSegment(ctx context.Context, client DynamoDBAPI, input *dynamodb.ScanInput, segment int, opts ParallelScanOptions, handle PageHandler) error {
+	for {
+		result, err := client.Scan(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to scan segment %d: %v", segment, err)
+		}
+		var items []SchemaItem
+		if err := attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+			return fmt.Errorf("failed to unmarshal segment %d: %v", segment, err)
+		}
+		if err := handle(ctx, segment, items); err != nil {
+			return fmt.Errorf("segment %d: %w", segment, err)
+		}
+
+		done := len(result.LastEvaluatedKey) == 0
+		if opts.Checkpoints != nil {
+			checkpoint := SegmentCheckpoint{
+				Segment:          segment,
+				TotalSegments:    opts.TotalSegments,
+				LastEvaluatedKey: result.LastEvaluatedKey,
+				Done:             done,
+			}
+			if err := opts.Checkpoints.Save(ctx, opts.Job, checkpoint); err != nil {
+				return fmt.Errorf("failed to save checkpoint of segment %d: %v", segment, err)
+			}
+		}
+		if done {
+			return nil
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
+
+// checkpointKeyValue is the JSON form of a key attribute (keys are always S, N or B).
+type checkpointKeyValue struct {
+	S *string `json:"S,omitempty"`
+	N *string `json:"N,omitempty"`
+	B []byte  `json:"B,omitempty"`
+}
+
+// checkpointRecord is the JSON form of a SegmentCheckpoint.
+type checkpointRecord struct {
+	Segment          int                           `json:"segment"`
+	TotalSegments    int                           `json:"total_segments"`
+	LastEvaluatedKey map[string]checkpointKeyValue `json:"last_evaluated_key,omitempty"`
+	Done             bool                          `json:"done"`
+}
+
+// encodeCheckpoint converts a checkpoint to its JSON form.
+func encodeCheckpoint(checkpoint SegmentCheckpoint) (checkpointRecord, error) {
+	record := checkpointRecord{Segment: checkpoint.Segment, TotalSegments: checkpoint.TotalSegments, Done: checkpoint.Done}
+	if len(checkpoint.LastEvaluatedKey) == 0 {
+		return record, nil
+	}
+	record.LastEvaluatedKey = make(map[string]checkpointKeyValue, len(checkpoint.LastEvaluatedKey))
+	for name, av := range checkpoint.LastEvaluatedKey {
+		switch v := av.(type) {
+		case *types.AttributeValueMemberS:
+			record.LastEvaluatedKey[name] = checkpointKeyValue{S: aws.String(v.Value)}
+		case *types.AttributeValueMemberN:
+			record.LastEvaluatedKey[name] = checkpointKeyValue{N: aws.String(v.Value)}
+		case *types.AttributeValueMemberB:
+			record.LastEvaluatedKey[name] = checkpointKeyValue{B: v.Value}
+		default:
+			return record, fmt.Errorf("unsupported key attribute type %T of %s", av, name)
+		}
+	}
+	return record, nil
+}
+
+// decodeCheckpoint converts a checkpoint from its JSON form.
+func decodeCheckpoint(record checkpointRecord) SegmentCheckpoint {
+	checkpoint := SegmentCheckpoint{Segment: record.Segment, TotalSegments: record.TotalSegments, Done: record.Done}
+	if len(record.LastEvaluatedKey) == 0 {
+		return checkpoint
+	}
+	checkpoint.LastEvaluatedKey = make(map[string]types.AttributeValue, len(record.LastEvaluatedKey))
+	for name, v := range record.LastEvaluatedKey {
+		switch {
+		case v.S != nil:
+			checkpoint.LastEvaluatedKey[name] = &types.AttributeValueMemberS{Value: *v.S}
+		case v.N != nil:
+			checkpoint.LastEvaluatedKey[name] = &types.AttributeValueMemberN{Value: *v.N}
+		default:
+			checkpoint.LastEvaluatedKey[name] = &types.AttributeValueMemberB{Value: v.B}
+		}
+	}
+	return checkpoint
+}
+
+// blobCheckpointStore keeps all checkpoints of a job in one JSON document.
+// Read-modify-write cycles are serialized by the mutex, so a job must run in a single process.
+type blobCheckpointStore struct {
+	mu     sync.Mutex
+	read   func(ctx context.Context, job string) ([]byte, error) // nil data if the job has no document
+	write  func(ctx context.Context, job string, data []byte) error
+	remove func(ctx context.Context, job string) error
+}
+
+// Load implements CheckpointStore.
+func (s *blobCheckpointStore) Load(ctx context.Context, job string) (map[int]SegmentCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, err := s.records(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	checkpoints := make(map[int]SegmentCheckpoint, len(records))
+	for _, record := range records {
+		checkpoints[record.Segment] = decodeCheckpoint(record)
+	}
+	return checkpoints, nil
+}
+
+// Save implements CheckpointStore.
+func (s *blobCheckpointStore) Save(ctx context.Context, job string, checkpoint SegmentCheckpoint) error {
+	record, err := encodeCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, err := s.records(ctx, job)
+	if err != nil {
+		return err
+	}
+	records[checkpoint.Segment] = record
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return s.write(ctx, job, data)
+}
+
+// Clear implements CheckpointStore.
+func (s *blobCheckpointStore) Clear(ctx context.Context, job string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.remove(ctx, job)
+}
+
+// records reads the job document, segments are keyed by their number.
+func (s *blobCheckpointStore) records(ctx context.Context, job string) (map[int]checkpointRecord, error) {
+	records := make(map[int]checkpointRecord)
+	data, err := s.read(ctx, job)
+	if err != nil || len(data) == 0 {
+		return records, err
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint document of job %s: %v", job, err)
+	}
+	return records, nil
+}
+
+// NewFileCheckpointStore returns a CheckpointStore writing one <job>.json file per job into dir.
+func NewFileCheckpointStore(dir string) CheckpointStore {
+	path := func(job string) string {
+		return filepath.Join(dir, job+".json")
+	}
+	return &blobCheckpointStore{
+		read: func(_ context.Context, job string) ([]byte, error) {
+			data, err := os.ReadFile(path(job))
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, nil
+			}
+			return data, err
+		},
+		write: func(_ context.Context, job string, data []byte) error {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return err
+			}
+			tmp := path(job) + ".tmp"
+			if err := os.WriteFile(tmp, data, 0o644); err != nil {
+				return err
+			}
+			return os.Rename(tmp, path(job))
+		},
+		remove: func(_ context.Context, job string) error {
+			if err := os.Remove(path(job)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+// ObjectStorage is the subset of an object store (e.g. S3) used by NewS3CheckpointStore.
+// Wrap *s3.Client with GetObject/PutObject/DeleteObject on a fixed bucket;
+// the generated code doesn't depend on the S3 SDK.
+type ObjectStorage interface {
+	// GetObject returns the object data, nil data without error if the object doesn't exist.
+	GetObject(ctx context.Context, key string) ([]byte, error)
+	PutObject(ctx context.Context, key string, data []byte) error
+	DeleteObject(ctx context.Context, key string) error
+}
+
+// NewS3CheckpointStore returns a CheckpointStore writing one <prefix><job>.json object per job.
+func NewS3CheckpointStore(objects ObjectStorage, prefix string) CheckpointStore {
+	key := func(job string) string {
+		return prefix + job + ".json"
+	}
+	return &blobCheckpointStore{
+		read: func(ctx context.Context, job string) ([]byte, error) {
+			return objects.GetObject(ctx, key(job))
+		},
+		write: func(ctx context.Context, job string, data []byte) error {
+			return objects.PutObject(ctx, key(job), data)
+		},
+		remove: func(ctx context.Context, job string) error {
+			return objects.DeleteObject(ctx, key(job))
+		},
+	}
+}
+
+// DynamoDBCheckpointStore keeps checkpoints in a DynamoDB table with a string hash key "job"
+// and a number range key "segment", one item per segment. Safe across processes.
+type DynamoDBCheckpointStore struct {
+	Client DynamoDBAPI
+	Table  string
+}
+
+// NewDynamoDBCheckpointStore returns a CheckpointStore backed by the given checkpoint table.
+func NewDynamoDBCheckpointStore(client DynamoDBAPI, table string) *DynamoDBCheckpointStore {
+	return &DynamoDBCheckpointStore{Client: client, Table: table}
+}
+
+// Load implements CheckpointStore.
+func (s *DynamoDBCheckpointStore) Load(ctx context.Context, job string) (map[int]SegmentCheckpoint, error) {
+	checkpoints := make(map[int]SegmentCheckpoint)
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(s.Table),
+		KeyConditionExpression:    aws.String("#job = :job"),
+		ExpressionAttributeNames:  map[string]string{"#job": "job"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":job": &types.AttributeValueMemberS{Value: job}},
+		ConsistentRead:            aws.Bool(true),
+	}
+	for {
+		result, err := s.Client.Query(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.Items {
+			data, ok := item["checkpoint"].(*types.AttributeValueMemberS)
+			if !ok {
+				continue
+			}
+			var record checkpointRecord
+			if err := json.Unmarshal([]byte(data.Value), &record); err != nil {
+				return nil, fmt.Errorf("invalid checkpoint of job %s: %v", job, err)
+			}
+			checkpoints[record.Segment] = decodeCheckpoint(record)
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			return checkpoints, nil
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
+
+// Save implements CheckpointStore.
+func (s *DynamoDBCheckpointStore) Save(ctx context.Context, job string, checkpoint SegmentCheckpoint) error {
+	record, err := encodeCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = s.Client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.Table),
+		Item: map[string]types.AttributeValue{
+			"job":        &types.AttributeValueMemberS{Value: job},
+			"segment":    &types.AttributeValueMemberN{Value: strconv.Itoa(checkpoint.Segment)},
+			"checkpoint": &types.AttributeValueMemberS{Value: string(data)},
+		},
+	})
+	return err
+}
+
+// Clear implements CheckpointStore.
+func (s *DynamoDBCheckpointStore) Clear(ctx context.Context, job string) error {
+	checkpoints, err := s.Load(ctx, job)
+	if err != nil {
+		return err
+	}
+	for segment := range checkpoints {
+		_, err := s.Client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(s.Table),
+			Key: map[string]types.AttributeValue{
+				"job":     &types.AttributeValueMemberS{Value: job},
+				"segment": &types.AttributeValueMemberN{Value: strconv.Itoa(segment)},
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ItemInput converts a SchemaItem to DynamoDB AttributeValue map format.
+// Uses AWS SDK's attributevalue package for safe and consistent marshaling.
+// The resulting map can be used in PutItem, UpdateItem, and other DynamoDB operations.
+// BeforePut hooks run first and may modify the item or reject it.
+// Empty values DynamoDB rejects are handled by EmptyValues.
+// Example:
+//
+//	av, err := ItemInput(item)
+//	_, err = client.PutItem(ctx, &dynamodb.PutItemInput{TableName: aws.String(TableName), Item: av})
+func ItemInput(item SchemaItem) (map[string]types.AttributeValue, error) {
+	_, av, err := prepareItem(item)
+	return av, err
+}
+
+// prepareItem runs the steps of writing an item as a whole, see ItemInput, and returns
+// the item as written with its marshaled attributes. ItemInput, PutItem and TxPut share it.
+func prepareItem(item SchemaItem) (SchemaItem, map[string]types.AttributeValue, error) {
+	if err := runBeforePut(&item); err != nil {
+		return SchemaItem{}, nil, err
+	}
+	av, err := ToAttributeValues(item)
+	if err != nil {
+		return SchemaItem{}, nil, err
+	}
+	if _, err := applyEmptyValues(av); err != nil {
+		return SchemaItem{}, nil, err
+	}
+	return item, av, nil
+}
+
+// hooks are the lifecycle hooks registered for this package.
+var hooks struct {
+	mu           sync.RWMutex
+	beforePut    []func(item *SchemaItem) error
+	afterPut     []func(ctx context.Context, item SchemaItem) error
+	beforeUpdate []func(hashKeyValue any, rangeKeyValue any, updates map[string]any) error
+}
+
+// BeforePut registers a hook called with every item written as a whole, before it is marshaled:
+// by ItemInput, PutItem, TxPut and TransactionBuilder.Put. The hook may modify the item
+// (derive computed attributes) or reject it with an error (enforce invariants).
+// Hooks run in registration order; register them at startup.
+// Example:
+//
+//	BeforePut(func(item *SchemaItem) error {
+//	    item.Requests = 1 // derive a computed attribute
+//	    return nil
+//	})
+func BeforePut(fn func(item *SchemaItem) error) {
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.beforePut = append(hooks.beforePut, fn)
+}
+
+// AfterPut registers a hook called after PutItem wrote an item, e.g. to emit a domain event.
+// An error of the hook is returned by PutItem, the item stays written.
+func AfterPut(fn func(ctx context.Context, item SchemaItem) error) {
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.afterPut = append(hooks.afterPut, fn)
+}
+
+// BeforeUpdate registers a hook called with the key and the attribute updates of every partial
+// update, before the update input is built: by UpdateItemInputFromRaw, UpdateItemInputWithCondition,
+// TxUpdate and TransactionBuilder.Update. The hook may add or change updates or reject them.
+func BeforeUpdate(fn func(hashKeyValue any, rangeKeyValue any, updates map[string]any) error) {
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.beforeUpdate = append(hooks.beforeUpdate, fn)
+}
+
+// ResetHooks removes all registered hooks, e.g. between tests.
+func ResetHooks() {
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.beforePut = nil
+	hooks.afterPut = nil
+	hooks.beforeUpdate = nil
+}
+
+// runBeforePut calls the BeforePut hooks in order, stopping at the first error.
+func runBeforePut(item *SchemaItem) error {
+	hooks.mu.RLock()
+	fns := hooks.beforePut
+	hooks.mu.RUnlock()
+	for _, fn := range fns {
+		if err := fn(item); err != nil {
+			return fmt.Errorf("before put hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// runAfterPut calls the AfterPut hooks in order, stopping at the first error.
+func runAfterPut(ctx context.Context, item SchemaItem) error {
+	hooks.mu.RLock()
+	fns := hooks.afterPut
+	hooks.mu.RUnlock()
+	for _, fn := range fns {
+		if err := fn(ctx, item); err != nil {
+			return fmt.Errorf("after put hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// runBeforeUpdate calls the BeforeUpdate hooks in order, stopping at the first error.
+func runBeforeUpdate(hashKeyValue any, rangeKeyValue any, updates map[string]any) error {
+	hooks.mu.RLock()
+	fns := hooks.beforeUpdate
+	hooks.mu.RUnlock()
+	for _, fn := range fns {
+		if err := fn(hashKeyValue, rangeKeyValue, updates); err != nil {
+			return fmt.Errorf("before update hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// PutItem writes the item like a put of ItemInput: BeforePut hooks run before the write,
+// AfterPut hooks after it succeeded, with the item as written.
+func PutItem(ctx context.Context, client DynamoDBAPI, item SchemaItem) error {
+	written, av, err := prepareItem(item)
+	if err != nil {
+		return err
+	}
+	if _, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(TableSchema.TableName),
+		Item:      av,
+	}); err != nil {
+		return fmt.Errorf("failed to put item: %w", err)
+	}
+	return runAfterPut(ctx, written)
+}
+
+// UpdateItemInput creates an UpdateItemInput from a complete SchemaItem.
+// Automatically extracts the key and updates all non-key attributes.
+// Use when you want to update an entire item with new values.
+func UpdateItemInput(item SchemaItem) (*dynamodb.UpdateItemInput, error) {
+	key, err := KeyInput(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key from item for update: %v", err)
+	}
+	allAttributes, err := marshalItemToMap(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal item for update: %v", err)
+	}
+	omitted, err := applyEmptyValues(allAttributes)
+	if err != nil {
+		return nil, err
+	}
+	updates := extractNonKeyAttributes(allAttributes)
+	if len(updates) == 0 && len(omitted) == 0 {
+		return nil, fmt.Errorf("no non-key attributes to update")
+	}
+	updateExpression, attrNames, attrValues := buildUpdateExpression(updates)
+
+	input := &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(TableSchema.TableName),
+		Key:                       key,
+		UpdateExpression:          aws.String(updateExpression),
+		ExpressionAttributeNames:  attrNames,
+		ExpressionAttributeValues: attrValues,
+	}
+	removeAttributes(input, omitted)
+	return input, nil
+}
+
+// UpdateItemInputFromRaw creates an UpdateItemInput from raw key values and update map.
+// More efficient for partial updates when you only want to modify specific attributes.
+// Use when you know exactly which fields to update without loading the full item.
+// BeforeUpdate hooks run first and may modify updates or reject them.
+// Empty sets are handled by EmptyValues, by default their attributes are removed.
+// Example:
+//
+//	input, err := UpdateItemInputFromRaw("pk-1", "sk-1", map[string]any{
+//	    ColumnRequests: 1,
+//	})
+func UpdateItemInputFromRaw(hashKeyValue any, rangeKeyValue any, updates map[string]any) (*dynamodb.UpdateItemInput, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return nil, err
+	}
+	if err := runBeforeUpdate(hashKeyValue, rangeKeyValue, updates); err != nil {
+		return nil, err
+	}
+	if err := validateUpdatesMap(updates); err != nil {
+		return nil, err
+	}
+	key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key for update: %v", err)
+	}
+	marshaledUpdates, err := marshalUpdatesWithSchema(updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal updates: %v", err)
+	}
+	omitted, err := applyEmptyValues(marshaledUpdates)
+	if err != nil {
+		return nil, err
+	}
+	updateExpression, attrNames, attrValues := buildUpdateExpression(marshaledUpdates)
+
+	input := &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(TableSchema.TableName),
+		Key:                       key,
+		UpdateExpression:          aws.String(updateExpression),
+		ExpressionAttributeNames:  attrNames,
+		ExpressionAttributeValues: attrValues,
+	}
+	removeAttributes(input, omitted)
+	return input, nil
+}
+
+// UpdateItemInputWithCondition creates a conditional UpdateItemInput.
+// Updates the item only if the condition expression evaluates to true.
+// Condition values are marshaled attribute values, see UpdateItemInputWithTypedCondition for Go values.
+func UpdateItemInputWithCondition(
+	hashKeyValue any,
+	rangeKeyValue any,
+	updates map[string]any,
+	conditionExpression string,
+	conditionAttributeNames map[string]string,
+	conditionAttributeValues map[string]types.AttributeValue,
+) (*dynamodb.UpdateItemInput, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return nil, err
+	}
+	if err := validateUpdatesMap(updates); err != nil {
+		return nil, err
+	}
+	if err := validateConditionExpression(conditionExpression); err != nil {
+		return nil, err
+	}
+	updateInput, err := UpdateItemInputFromRaw(hashKeyValue, rangeKeyValue, updates)
+	if err != nil {
+		return nil, err
+	}
+	if updateInput.ConditionExpression != nil {
+		conditionExpression = aws.ToString(updateInput.ConditionExpression) + " AND (" + conditionExpression + ")"
+	}
+	updateInput.ConditionExpression = aws.String(conditionExpression)
+
+	updateInput.ExpressionAttributeNames, updateInput.ExpressionAttributeValues = mergeExpressionAttributes(
+		updateInput.ExpressionAttributeNames,
+		updateInput.ExpressionAttributeValues,
+		conditionAttributeNames,
+		conditionAttributeValues,
+	)
+	return updateInput, nil
+}
+
+// ConditionValue is a Go value of a condition expression placeholder and the attribute it is compared with.
+// Value is marshaled like updates of Attribute (e.g. []string as a string set, a single string compared
+// with a set by contains as a string); an empty Attribute marshals it generically and a types.AttributeValue is kept.
+type ConditionValue struct {
+	Attribute string
+	Value     any
+}
+
+// UpdateItemInputWithTypedCondition is UpdateItemInputWithCondition with Go condition values,
+// marshaled by the schema type of the attribute each one names.
+// Example:
+//
+//	input, err := UpdateItemInputWithTypedCondition(
+//	    "pk-1", "sk-1", updates,
+//	    "#status = :expected", map[string]string{"#status": "status"},
+//	    map[string]ConditionValue{":expected": {Attribute: "status", Value: "active"}},
+//	)
+func UpdateItemInputWithTypedCondition(
+	hashKeyValue any,
+	rangeKeyValue any,
+	updates map[string]any,
+	conditionExpression string,
+	conditionAttributeNames map[string]string,
+	conditionAttributeValues map[string]ConditionValue,
+) (*dynamodb.UpdateItemInput, error) {
+	values, err := marshalConditionValues(conditionAttributeValues)
+	if err != nil {
+		return nil, err
+	}
+	return UpdateItemInputWithCondition(hashKeyValue, rangeKeyValue, updates, conditionExpression, conditionAttributeNames, values)
+}
+
+// UpdateItemInputWithExpression creates an UpdateItemInput using DynamoDB expression builders.
+// Provides maximum flexibility for complex update operations (SET, ADD, REMOVE, DELETE).
+// Use for advanced scenarios like atomic increments, list operations, or complex conditions.
+// Example:
+//
+//	updateExpr := expression.Set(expression.Name("counter"), expression.Name("counter").Plus(expression.Value(1)))
+//	condExpr := expression.Name("version").Equal(expression.Value(currentVersion))
+//	input, err := UpdateItemInputWithExpression("user123", nil, updateExpr, &condExpr)
+func UpdateItemInputWithExpression(hashKeyValue any, rangeKeyValue any, updateBuilder expression.UpdateBuilder, conditionBuilder *expression.ConditionBuilder) (*dynamodb.UpdateItemInput, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return nil, err
+	}
+	key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key for expression update: %v", err)
+	}
+	var expr expression.Expression
+	if conditionBuilder != nil {
+		expr, err = expression.NewBuilder().
+			WithUpdate(updateBuilder).
+			WithCondition(*conditionBuilder).
+			Build()
+	} else {
+		expr, err = expression.NewBuilder().
+			WithUpdate(updateBuilder).
+			Build()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build update expression: %v", err)
+	}
+	input := &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(TableSchema.TableName),
+		Key:                       key,
+		UpdateExpression:          expr.Update(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}
+	if conditionBuilder != nil {
+		input.ConditionExpression = expr.Condition()
+	}
+	return input, nil
+}
+
+// DeleteItemInput creates a DeleteItemInput from a complete SchemaItem.
+// Extracts the primary key from the item for the delete operation.
+// Use when you have the full item and want to delete it.
+func DeleteItemInput(item SchemaItem) (*dynamodb.DeleteItemInput, error) {
+	key, err := KeyInput(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key from item for delete: %v", err)
+	}
+	return &dynamodb.DeleteItemInput{
+		TableName: aws.String(TableSchema.TableName),
+		Key:       key,
+	}, nil
+}
+
+// DeleteItemInputFromRaw creates a DeleteItemInput from raw key values.
+// Use when you only have the key values and want to delete the item.
+// More efficient than DeleteItemInput when you don't have the full item.
+// Example:
+//
+//	input, err := DeleteItemInputFromRaw("pk-1", "sk-1")
+func DeleteItemInputFromRaw(hashKeyValue any, rangeKeyValue any) (*dynamodb.DeleteItemInput, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return nil, err
+	}
+	key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key for delete: %v", err)
+	}
+	return &dynamodb.DeleteItemInput{
+		TableName: aws.String(TableSchema.TableName),
+		Key:       key,
+	}, nil
+}
+
+// DeleteItemInputWithCondition creates a conditional DeleteItemInput.
+// Deletes the item only if the condition expression evaluates to true.
+// Prevents accidental deletion and enables optimistic locking patterns.
+func DeleteItemInputWithCondition(
+	hashKeyValue any,
+	rangeKeyValue any,
+	conditionExpression string,
+	expressionAttributeNames map[string]string,
+	expressionAttributeValues map[string]types.AttributeValue,
+) (*dynamodb.DeleteItemInput, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return nil, err
+	}
+	if err := validateConditionExpression(conditionExpression); err != nil {
+		return nil, err
+	}
+	key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key for conditional delete: %v", err)
+	}
+	input := &dynamodb.DeleteItemInput{
+		TableName:           aws.String(TableSchema.TableName),
+		Key:                 key,
+		ConditionExpression: aws.String(conditionExpression),
+	}
+	if expressionAttributeNames != nil {
+		input.ExpressionAttributeNames = expressionAttributeNames
+	}
+	if expressionAttributeValues != nil {
+		input.ExpressionAttributeValues = expressionAttributeValues
+	}
+	return input, nil
+}
+
+// BatchDeleteItemsInput creates a BatchWriteItemInput for deleting multiple items.
+// Takes pre-built key maps and creates delete requests for batch operation.
+// Limited to 25 items per batch due to DynamoDB constraints.
+func BatchDeleteItemsInput(keys []map[string]types.AttributeValue) (*dynamodb.BatchWriteItemInput, error) {
+	if err := validateBatchSize(len(keys), "delete"); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return &dynamodb.BatchWriteItemInput{}, nil
+	}
+	writeRequests := make([]types.WriteRequest, 0, len(keys))
+	for _, key := range keys {
+		writeRequests = append(writeRequests, types.WriteRequest{
+			DeleteRequest: &types.DeleteRequest{
+				Key: key,
+			},
+		})
+	}
+	return &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			TableSchema.TableName: writeRequests,
+		},
+	}, nil
+}
+
+// BatchDeleteItemsInputFromRaw creates a BatchWriteItemInput from SchemaItems.
+// Extracts keys from each item and creates batch delete requests.
+// More convenient than BatchDeleteItemsInput when you have full items.
+func BatchDeleteItemsInputFromRaw(items []SchemaItem) (*dynamodb.BatchWriteItemInput, error) {
+	if err := validateBatchSize(len(items), "delete"); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return &dynamodb.BatchWriteItemInput{}, nil
+	}
+	keys := make([]map[string]types.AttributeValue, 0, len(items))
+	for _, item := range items {
+		key, err := KeyInput(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create key from item: %v", err)
+		}
+		keys = append(keys, key)
+	}
+	return BatchDeleteItemsInput(keys)
+}
+
+// TYPED CONDITIONS
+// Condition<Attribute><Operator> helpers build conditions of conditional writes.
+// Number and binary sets only support Exists and NotExists (contains takes strings).
+// Combine them with And, Or and expression.Not and pass them to PutItemInputIf,
+// UpdateItemInputIf, DeleteItemInputIf or TxConditionCheck.
+
+// ConditionPkEQ checks that pk equals the value.
+func ConditionPkEQ(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnPk).Equal(expression.Value(value))
+}
+
+// ConditionPkNE checks that pk does not equal the value.
+func ConditionPkNE(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnPk).NotEqual(expression.Value(value))
+}
+
+// ConditionPkGT checks that pk is greater than the value.
+func ConditionPkGT(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnPk).GreaterThan(expression.Value(value))
+}
+
+// ConditionPkGTE checks that pk is greater than or equal to the value.
+func ConditionPkGTE(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnPk).GreaterThanEqual(expression.Value(value))
+}
+
+// ConditionPkLT checks that pk is less than the value.
+func ConditionPkLT(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnPk).LessThan(expression.Value(value))
+}
+
+// ConditionPkLTE checks that pk is less than or equal to the value.
+func ConditionPkLTE(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnPk).LessThanEqual(expression.Value(value))
+}
+
+// ConditionPkBetween checks that pk is between start and end, inclusive.
+func ConditionPkBetween(start, end string) expression.ConditionBuilder {
+	return expression.Name(ColumnPk).Between(expression.Value(start), expression.Value(end))
+}
+
+// ConditionPkBeginsWith checks that pk starts with the prefix.
+func ConditionPkBeginsWith(prefix string) expression.ConditionBuilder {
+	return expression.Name(ColumnPk).BeginsWith(prefix)
+}
+
+// ConditionPkContains checks that pk contains the substring.
+func ConditionPkContains(substr string) expression.ConditionBuilder {
+	return expression.Name(ColumnPk).Contains(substr)
+}
+
+// ConditionPkExists checks that the item has pk.
+func ConditionPkExists() expression.ConditionBuilder {
+	return expression.Name(ColumnPk).AttributeExists()
+}
+
+// ConditionPkNotExists checks that the item has no pk.
+// Used with PutItemInputIf it only creates new items.
+func ConditionPkNotExists() expression.ConditionBuilder {
+	return expression.Name(ColumnPk).AttributeNotExists()
+}
+
+// ConditionSkEQ checks that sk equals the value.
+func ConditionSkEQ(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnSk).Equal(expression.Value(value))
+}
+
+// ConditionSkNE checks that sk does not equal the value.
+func ConditionSkNE(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnSk).NotEqual(expression.Value(value))
+}
+
+// ConditionSkGT checks that sk is greater than the value.
+func ConditionSkGT(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnSk).GreaterThan(expression.Value(value))
+}
+
+// ConditionSkGTE checks that sk is greater than or equal to the value.
+func ConditionSkGTE(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnSk).GreaterThanEqual(expression.Value(value))
+}
+
+// ConditionSkLT checks that sk is less than the value.
+func ConditionSkLT(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnSk).LessThan(expression.Value(value))
+}
+
+// ConditionSkLTE checks that sk is less than or equal to the value.
+func ConditionSkLTE(value string) expression.ConditionBuilder {
+	return expression.Name(ColumnSk).LessThanEqual(expression.Value(value))
+}
+
+// ConditionSkBetween checks that sk is between start and end, inclusive.
+func ConditionSkBetween(start, end string) expression.ConditionBuilder {
+	return expression.Name(ColumnSk).Between(expression.Value(start), expression.Value(end))
+}
+
+// ConditionSkBeginsWith checks that sk starts with the prefix.
+func ConditionSkBeginsWith(prefix string) expression.ConditionBuilder {
+	return expression.Name(ColumnSk).BeginsWith(prefix)
+}
+
+// ConditionSkContains checks that sk contains the substring.
+func ConditionSkContains(substr string) expression.ConditionBuilder {
+	return expression.Name(ColumnSk).Contains(substr)
+}
+
+// ConditionSkExists checks that the item has sk.
+func ConditionSkExists() expression.ConditionBuilder {
+	return expression.Name(ColumnSk).AttributeExists()
+}
+
+// ConditionSkNotExists checks that the item has no sk.
+func ConditionSkNotExists() expression.ConditionBuilder {
+	return expression.Name(ColumnSk).AttributeNotExists()
+}
+
+// ConditionRequestsEQ checks that requests equals the value.
+func ConditionRequestsEQ(value int) expression.ConditionBuilder {
+	return expression.Name(ColumnRequests).Equal(expression.Value(value))
+}
+
+// ConditionRequestsNE checks that requests does not equal the value.
+func ConditionRequestsNE(value int) expression.ConditionBuilder {
+	return expression.Name(ColumnRequests).NotEqual(expression.Value(value))
+}
+
+// ConditionRequestsGT checks that requests is greater than the value.
+func ConditionRequestsGT(value int) expression.ConditionBuilder {
+	return expression.Name(ColumnRequests).GreaterThan(expression.Value(value))
+}
+
+// ConditionRequestsGTE checks that requests is greater than or equal to the value.
+func ConditionRequestsGTE(value int) expression.ConditionBuilder {
+	return expression.Name(ColumnRequests).GreaterThanEqual(expression.Value(value))
+}
+
+// ConditionRequestsLT checks that requests is less than the value.
+func ConditionRequestsLT(value int) expression.ConditionBuilder {
+	return expression.Name(ColumnRequests).LessThan(expression.Value(value))
+}
+
+// ConditionRequestsLTE checks that requests is less than or equal to the value.
+func ConditionRequestsLTE(value int) expression.ConditionBuilder {
+	return expression.Name(ColumnRequests).LessThanEqual(expression.Value(value))
+}
+
+// ConditionRequestsBetween checks that requests is between start and end, inclusive.
+func ConditionRequestsBetween(start, end int) expression.ConditionBuilder {
+	return expression.Name(ColumnRequests).Between(expression.Value(start), expression.Value(end))
+}
+
+// ConditionRequestsExists checks that the item has requests.
+func ConditionRequestsExists() expression.ConditionBuilder {
+	return expression.Name(ColumnRequests).AttributeExists()
+}
+
+// ConditionRequestsNotExists checks that the item has no requests.
+func ConditionRequestsNotExists() expression.ConditionBuilder {
+	return expression.Name(ColumnRequests).AttributeNotExists()
+}
+
+// ConditionExpiresAtEQ checks that expires_at equals the value.
+func ConditionExpiresAtEQ(value int64) expression.ConditionBuilder {
+	return expression.Name(ColumnExpiresAt).Equal(expression.Value(value))
+}
+
+// ConditionExpiresAtNE checks that expires_at does not equal the value.
+func ConditionExpiresAtNE(value int64) expression.ConditionBuilder {
+	return expression.Name(ColumnExpiresAt).NotEqual(expression.Value(value))
+}
+
+// ConditionExpiresAtGT checks that expires_at is greater than the value.
+func ConditionExpiresAtGT(value int64) expression.ConditionBuilder {
+	return expression.Name(ColumnExpiresAt).GreaterThan(expression.Value(value))
+}
+
+// ConditionExpiresAtGTE checks that expires_at is greater than or equal to the value.
+func ConditionExpiresAtGTE(value int64) expression.ConditionBuilder {
+	return expression.Name(ColumnExpiresAt).GreaterThanEqual(expression.Value(value))
+}
+
+// ConditionExpiresAtLT checks that expires_at is less than the value.
+func ConditionExpiresAtLT(value int64) expression.ConditionBuilder {
+	return expression.Name(ColumnExpiresAt).LessThan(expression.Value(value))
+}
+
+// ConditionExpiresAtLTE checks that expires_at is less than or equal to the value.
+func ConditionExpiresAtLTE(value int64) expression.ConditionBuilder {
+	return expression.Name(ColumnExpiresAt).LessThanEqual(expression.Value(value))
+}
+
+// ConditionExpiresAtBetween checks that expires_at is between start and end, inclusive.
+func ConditionExpiresAtBetween(start, end int64) expression.ConditionBuilder {
+	return expression.Name(ColumnExpiresAt).Between(expression.Value(start), expression.Value(end))
+}
+
+// ConditionExpiresAtExists checks that the item has expires_at.
+func ConditionExpiresAtExists() expression.ConditionBuilder {
+	return expression.Name(ColumnExpiresAt).AttributeExists()
+}
+
+// ConditionExpiresAtNotExists checks that the item has no expires_at.
+func ConditionExpiresAtNotExists() expression.ConditionBuilder {
+	return expression.Name(ColumnExpiresAt).AttributeNotExists()
+}
+
+// PutItemInputIf creates a PutItemInput writing the item only if the condition holds.
+// Example:
+//
+//	input, err := PutItemInputIf(item, ConditionPkNotExists())
+//	_, err = client.PutItem(ctx, input)
+func PutItemInputIf(item SchemaItem, condition expression.ConditionBuilder) (*dynamodb.PutItemInput, error) {
+	expr, err := buildCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+	av, err := ItemInput(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal item for conditional put: %v", err)
+	}
+	return &dynamodb.PutItemInput{
+		TableName:                 aws.String(TableSchema.TableName),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	}, nil
+}
+
+// UpdateItemInputIf creates an UpdateItemInput like UpdateItemInputFromRaw
+// that updates the item only if the condition holds.
+// Example:
+//
+//	input, err := UpdateItemInputIf("pk-1", "sk-1", updates, ConditionPkExists())
+func UpdateItemInputIf(hashKeyValue any, rangeKeyValue any, updates map[string]any, condition expression.ConditionBuilder) (*dynamodb.UpdateItemInput, error) {
+	expr, err := buildCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+	input, err := UpdateItemInputFromRaw(hashKeyValue, rangeKeyValue, updates)
+	if err != nil {
+		return nil, err
+	}
+	conditionExpression := aws.ToString(expr.Condition())
+	if input.ConditionExpression != nil {
+		conditionExpression = aws.ToString(input.ConditionExpression) + " AND (" + conditionExpression + ")"
+	}
+	input.ConditionExpression = aws.String(conditionExpression)
+	input.ExpressionAttributeNames, input.ExpressionAttributeValues = mergeExpressionAttributes(
+		input.ExpressionAttributeNames,
+		input.ExpressionAttributeValues,
+		expr.Names(),
+		expr.Values(),
+	)
+	return input, nil
+}
+
+// DeleteItemInputIf creates a DeleteItemInput deleting the item only if the condition holds.
+// Example:
+//
+//	input, err := DeleteItemInputIf("pk-1", "sk-1", ConditionPkExists())
+func DeleteItemInputIf(hashKeyValue any, rangeKeyValue any, condition expression.ConditionBuilder) (*dynamodb.DeleteItemInput, error) {
+	expr, err := buildCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+	input, err := DeleteItemInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return nil, err
+	}
+	input.ConditionExpression = expr.Condition()
+	input.ExpressionAttributeNames = expr.Names()
+	input.ExpressionAttributeValues = expr.Values()
+	return input, nil
+}
+
+// buildCondition builds the condition expression with its attribute names and values.
+func buildCondition(condition expression.ConditionBuilder) (expression.Expression, error) {
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return expression.Expression{}, fmt.Errorf("failed to build condition expression: %v", err)
+	}
+	if err := validateConditionExpression(aws.ToString(expr.Condition())); err != nil {
+		return expression.Expression{}, err
+	}
+	return expr, nil
+}
+
+// TxPut creates a transaction entry writing the item.
+// Entries of any generated package can be combined into one transaction with TransactionBuilder.Add
+// or the github.com/Mad-Pixels/go-dyno/txn coordinator:
+//
+//	err := txn.New().
+//	    Add(TxPut(item)).
+//	    Add(otherpkg.TxDelete(otherKey, nil)).
+//	    Execute(ctx, client)
+func TxPut(item SchemaItem) (types.TransactWriteItem, error) {
+	_, av, err := prepareItem(item)
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+	return types.TransactWriteItem{
+		Put: &types.Put{
+			TableName: aws.String(TableSchema.TableName),
+			Item:      av,
+		},
+	}, nil
+}
+
+// TxUpdate creates a transaction entry setting the given attributes of an item.
+// Example:
+//
+//	entry, err := TxUpdate("pk-1", "sk-1", map[string]any{
+//	    ColumnRequests: 1,
+//	})
+func TxUpdate(hashKeyValue any, rangeKeyValue any, updates map[string]any) (types.TransactWriteItem, error) {
+	input, err := UpdateItemInputFromRaw(hashKeyValue, rangeKeyValue, updates)
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			TableName:                           input.TableName,
+			Key:                                 input.Key,
+			UpdateExpression:                    input.UpdateExpression,
+			ExpressionAttributeNames:            input.ExpressionAttributeNames,
+			ExpressionAttributeValues:           input.ExpressionAttributeValues,
+			ConditionExpression:                 input.ConditionExpression,
+			ReturnValuesOnConditionCheckFailure: input.ReturnValuesOnConditionCheckFailure,
+		},
+	}, nil
+}
+
+// TxDelete creates a transaction entry deleting an item by key.
+func TxDelete(hashKeyValue any, rangeKeyValue any) (types.TransactWriteItem, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return types.TransactWriteItem{}, err
+	}
+	key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return types.TransactWriteItem{}, fmt.Errorf("failed to create key for delete: %v", err)
+	}
+	return types.TransactWriteItem{
+		Delete: &types.Delete{
+			TableName: aws.String(TableSchema.TableName),
+			Key:       key,
+		},
+	}, nil
+}
+
+// TxConditionCheck creates a transaction entry which fails the whole transaction
+// unless the condition holds for the item, without modifying it.
+func TxConditionCheck(hashKeyValue any, rangeKeyValue any, condition expression.ConditionBuilder) (types.TransactWriteItem, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return types.TransactWriteItem{}, err
+	}
+	key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return types.TransactWriteItem{}, fmt.Errorf("failed to create key for condition check: %v", err)
+	}
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return types.TransactWriteItem{}, fmt.Errorf("failed to build condition expression: %v", err)
+	}
+	return types.TransactWriteItem{
+		ConditionCheck: &types.ConditionCheck{
+			TableName:                 aws.String(TableSchema.TableName),
+			Key:                       key,
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		},
+	}, nil
+}
+
+// KeyInput creates a DynamoDB key map from a SchemaItem with full validation.
+// Extracts the primary key (hash + range) from the item and validates values.
+// Use when you have a complete item and need to create a key for operations.
+// Handles both simple (hash only) and composite (hash + range) keys automatically.
+func KeyInput(item SchemaItem) (map[string]types.AttributeValue, error) {
+	var hashKeyValue any
+
+	hashKeyValue = item.Pk
+
+	var rangeKeyValue any
+
+	rangeKeyValue = item.Sk
+
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return nil, err
+	}
+	key := make(map[string]types.AttributeValue)
+
+	hashKeyAV, err := attributevalue.Marshal(hashKeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hash key: %v", err)
+	}
+	key[TableSchema.HashKey] = hashKeyAV
+
+	if TableSchema.RangeKey != "" && rangeKeyValue != nil {
+		rangeKeyAV, err := attributevalue.Marshal(rangeKeyValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal range key: %v", err)
+		}
+		key[TableSchema.RangeKey] = rangeKeyAV
+	}
+
+	return key, nil
+}
+
+// KeyInputFromRaw creates a DynamoDB key map from raw key values without validation.
+// More efficient than KeyInput when you already have validated key values.
+// Assumes validation has been done by the caller - use with caution.
+// Handles both simple (hash only) and composite (hash + range) keys automatically.
+// Example:
+//
+//	key, err := KeyInputFromRaw("pk-1", "sk-1")
+//	out, err := client.GetItem(ctx, &dynamodb.GetItemInput{TableName: aws.String(TableName), Key: key})
+func KeyInputFromRaw(hashKeyValue any, rangeKeyValue any) (map[string]types.AttributeValue, error) {
+	key := make(map[string]types.AttributeValue)
+
+	hashKeyAV, err := attributevalue.Marshal(hashKeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hash key: %v", err)
+	}
+	key[TableSchema.HashKey] = hashKeyAV
+
+	if TableSchema.RangeKey != "" && rangeKeyValue != nil {
+		rangeKeyAV, err := attributevalue.Marshal(rangeKeyValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal range key: %v", err)
+		}
+		key[TableSchema.RangeKey] = rangeKeyAV
+	}
+
+	return key, nil
+}
+
+// ItemKey identifies an item by the raw values of its primary key.
+// RangeKey is nil for tables without a range key.
+type ItemKey struct {
+	HashKey  any
+	RangeKey any
+}
+
+// ItemKeyOf returns the primary key of the item.
+func ItemKeyOf(item SchemaItem) ItemKey {
+	key := ItemKey{}
+	key.HashKey = item.Pk
+	key.RangeKey = item.Sk
+	return key
+}
+
+// ErrItemNotFound is returned by GetItem and GetItemFromRaw when the table has no item with the key.
+// Check it with errors.Is.
+var ErrItemNotFound = errors.New("item not found")
+
+// GetItemOption customizes the reads of GetItem and GetItemFromRaw.
+type GetItemOption func(o *getItemOptions)
+
+// getItemOptions are the GetItemInput and the read fallback of a GetItem call.
+type getItemOptions struct {
+	input     *dynamodb.GetItemInput
+	fallback  ReadFallback
+	freshness FreshnessCheck
+}
+
+// WithConsistentRead makes GetItem and GetItemFromRaw use a strongly consistent read.
+// Example:
+//
+//	item, err := GetItem(ctx, client, key, WithConsistentRead())
+func WithConsistentRead() GetItemOption {
+	return func(o *getItemOptions) {
+		o.input.ConsistentRead = aws.Bool(true)
+	}
+}
+
+// WithStrongThenFallback reads strongly consistent and falls back to an eventually consistent
+// read if the consistent read fails, see StrongThenEventual.
+// Example:
+//
+//	item, err := GetItem(ctx, client, key, WithStrongThenFallback())
+func WithStrongThenFallback() GetItemOption {
+	return func(o *getItemOptions) {
+		o.fallback = StrongThenEventual
+	}
+}
+
+// WithStalenessCheck reads eventually consistent and re-reads strongly consistent
+// if the item is missing or fresh rejects it, see EventualThenStrong.
+func WithStalenessCheck(fresh FreshnessCheck) GetItemOption {
+	return func(o *getItemOptions) {
+		o.fallback = EventualThenStrong
+		o.freshness = fresh
+	}
+}
+
+// GetItemInput creates a GetItemInput from a SchemaItem holding the primary key.
+// Only the key attributes of the item are used.
+func GetItemInput(item SchemaItem) (*dynamodb.GetItemInput, error) {
+	key, err := KeyInput(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key from item for get: %v", err)
+	}
+	return &dynamodb.GetItemInput{
+		TableName: aws.String(TableSchema.TableName),
+		Key:       key,
+	}, nil
+}
+
+// GetItemInputFromRaw creates a GetItemInput from raw key values.
+// Example:
+//
+//	input, err := GetItemInputFromRaw("pk-1", "sk-1")
+func GetItemInputFromRaw(hashKeyValue any, rangeKeyValue any) (*dynamodb.GetItemInput, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return nil, err
+	}
+	key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key for get: %v", err)
+	}
+	return &dynamodb.GetItemInput{
+		TableName: aws.String(TableSchema.TableName),
+		Key:       key,
+	}, nil
+}
+
+// GetItem reads the item with the primary key of key, other attributes of key are ignored.
+// Reads are eventually consistent unless WithConsistentRead, WithStrongThenFallback
+// or WithStalenessCheck is passed.
+// Returns ErrItemNotFound if the table has no such item.
+func GetItem(ctx context.Context, client DynamoDBAPI, key SchemaItem, opts ...GetItemOption) (*SchemaItem, error) {
+	input, err := GetItemInput(key)
+	if err != nil {
+		return nil, err
+	}
+	return getItem(ctx, client, input, opts)
+}
+
+// GetItemFromRaw reads the item with the raw key values.
+// Returns ErrItemNotFound if the table has no such item.
+// Example:
+//
+//	item, err := GetItemFromRaw(ctx, client, "pk-1", "sk-1")
+//	if errors.Is(err, ErrItemNotFound) {
+//	    // create it
+//	}
+func GetItemFromRaw(ctx context.Context, client DynamoDBAPI, hashKeyValue any, rangeKeyValue any, opts ...GetItemOption) (*SchemaItem, error) {
+	input, err := GetItemInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return nil, err
+	}
+	return getItem(ctx, client, input, opts)
+}
+
+// getItem applies the options and reads the item.
+func getItem(ctx context.Context, client DynamoDBAPI, input *dynamodb.GetItemInput, opts []GetItemOption) (*SchemaItem, error) {
+	o := getItemOptions{input: input}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return readWithFallback(ctx, client, input, o)
+}
+
+// readWithFallback reads the item, retrying with the other consistency
+// as the read fallback of the options requires.
+func readWithFallback(ctx context.Context, client DynamoDBAPI, input *dynamodb.GetItemInput, o getItemOptions) (*SchemaItem, error) {
+	consistent, eventual := *input, *input
+	consistent.ConsistentRead = aws.Bool(true)
+	eventual.ConsistentRead = aws.Bool(false)
+
+	switch o.fallback {
+	case StrongThenEventual:
+		item, err := readItem(ctx, client, &consistent)
+		if err == nil || errors.Is(err, ErrItemNotFound) || ctx.Err() != nil {
+			return item, err
+		}
+		return readItem(ctx, client, &eventual)
+	case EventualThenStrong:
+		item, err := readItem(ctx, client, &eventual)
+		if err != nil && !errors.Is(err, ErrItemNotFound) {
+			return nil, err
+		}
+		if err == nil && (o.freshness == nil || o.freshness(*item)) {
+			return item, nil
+		}
+		return readItem(ctx, client, &consistent)
+	}
+	return readItem(ctx, client, input)
+}
+
+// readItem executes the GetItem request and unmarshals the item.
+func readItem(ctx context.Context, client DynamoDBAPI, input *dynamodb.GetItemInput) (*SchemaItem, error) {
+	out, err := client.GetItem(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+	if len(out.Item) == 0 {
+		return nil, ErrItemNotFound
+	}
+	return UnmarshalItem(out.Item)
+}
+
+// maxTransactItems is the DynamoDB limit of actions in one transaction.
+const maxTransactItems = 100
+
+// TransactionBuilder combines typed Put, Update, Delete and ConditionCheck actions
+// into one TransactWriteItemsInput. Actions of other generated packages join with Add,
+// so a single transaction can span several tables while every entry stays typed.
+// The first error of any action is kept and returned by Build and Execute.
+// Example:
+//
+//	err := NewTransactionBuilder().
+//	    Put(item).
+//	    ConditionCheck("pk-1", "sk-1", expression.AttributeExists(expression.Name(ColumnPk))).
+//	    Add(otherpkg.TxDelete(otherKey, nil)).
+//	    Execute(ctx, client)
+type TransactionBuilder struct {
+	items []types.TransactWriteItem
+	token string
+	err   error
+}
+
+// NewTransactionBuilder creates an empty TransactionBuilder.
+func NewTransactionBuilder() *TransactionBuilder {
+	return &TransactionBuilder{}
+}
+
+// Put adds an action writing the item.
+func (tb *TransactionBuilder) Put(item SchemaItem) *TransactionBuilder {
+	return tb.Add(TxPut(item))
+}
+
+// Update adds an action setting the given attributes of an item.
+func (tb *TransactionBuilder) Update(hashKeyValue any, rangeKeyValue any, updates map[string]any) *TransactionBuilder {
+	return tb.Add(TxUpdate(hashKeyValue, rangeKeyValue, updates))
+}
+
+// Delete adds an action deleting an item by key.
+func (tb *TransactionBuilder) Delete(hashKeyValue any, rangeKeyValue any) *TransactionBuilder {
+	return tb.Add(TxDelete(hashKeyValue, rangeKeyValue))
+}
+
+// ConditionCheck adds an action failing the transaction unless the condition holds for the item.
+func (tb *TransactionBuilder) ConditionCheck(hashKeyValue any, rangeKeyValue any, condition expression.ConditionBuilder) *TransactionBuilder {
+	return tb.Add(TxConditionCheck(hashKeyValue, rangeKeyValue, condition))
+}
+
+// Add appends an action built by a Tx* function of this or any other generated package.
+func (tb *TransactionBuilder) Add(item types.TransactWriteItem, err error) *TransactionBuilder {
+	if tb.err != nil {
+		return tb
+	}
+	if err != nil {
+		tb.err = fmt.Errorf("transaction action %d: %w", len(tb.items), err)
+		return tb
+	}
+	tb.items = append(tb.items, item)
+	return tb
+}
+
+// WithClientRequestToken makes the transaction idempotent: retries with the same token
+// within 10 minutes are not applied twice.
+func (tb *TransactionBuilder) WithClientRequestToken(token string) *TransactionBuilder {
+	tb.token = token
+	return tb
+}
+
+// Len returns the number of actions.
+func (tb *TransactionBuilder) Len() int {
+	return len(tb.items)
+}
+
+// Build returns the TransactWriteItemsInput of the actions.
+func (tb *TransactionBuilder) Build() (*dynamodb.TransactWriteItemsInput, error) {
+	if tb.err != nil {
+		return nil, tb.err
+	}
+	if len(tb.items) == 0 {
+		return nil, errors.New("transaction has no actions")
+	}
+	if len(tb.items) > maxTransactItems {
+		return nil, fmt.Errorf("transaction has %d actions, DynamoDB allows at most %d", len(tb.items), maxTransactItems)
+	}
+	input := &dynamodb.TransactWriteItemsInput{TransactItems: tb.items}
+	if tb.token != "" {
+		input.ClientRequestToken = aws.String(tb.token)
+	}
+	return input, nil
+}
+
+// Execute runs the transaction. If DynamoDB cancels it, the error names the failed
+// actions by index and table and wraps the TransactionCanceledException.
+func (tb *TransactionBuilder) Execute(ctx context.Context, client DynamoDBAPI) error {
+	input, err := tb.Build()
+	if err != nil {
+		return err
+	}
+	_, err = client.TransactWriteItems(ctx, input)
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) {
+		return err
+	}
+	var failed []string
+	for i, reason := range canceled.CancellationReasons {
+		code := aws.ToString(reason.Code)
+		if code == "" || code == "None" || i >= len(tb.items) {
+			continue
+		}
+		failed = append(failed, fmt.Sprintf("action %d (%s): %s", i, transactTableName(tb.items[i]), code))
+	}
+	return fmt.Errorf("transaction canceled: %s: %w", strings.Join(failed, ", "), err)
+}
+
+// transactTableName returns the table an action writes to.
+func transactTableName(item types.TransactWriteItem) string {
+	switch {
+	case item.Put != nil:
+		return aws.ToString(item.Put.TableName)
+	case item.Update != nil:
+		return aws.ToString(item.Update.TableName)
+	case item.Delete != nil:
+		return aws.ToString(item.Delete.TableName)
+	case item.ConditionCheck != nil:
+		return aws.ToString(item.ConditionCheck.TableName)
+	}
+	return ""
+}
+
+// TransactGetInput creates a TransactGetItemsInput reading the items by key.
+// At most 100 keys are allowed in one transaction.
+func TransactGetInput(keys ...ItemKey) (*dynamodb.TransactGetItemsInput, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("transaction has no keys")
+	}
+	if len(keys) > maxTransactItems {
+		return nil, fmt.Errorf("transaction has %d keys, DynamoDB allows at most %d", len(keys), maxTransactItems)
+	}
+	items := make([]types.TransactGetItem, 0, len(keys))
+	for i, k := range keys {
+		if err := validateKeyInputs(k.HashKey, k.RangeKey); err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		key, err := KeyInputFromRaw(k.HashKey, k.RangeKey)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		items = append(items, types.TransactGetItem{
+			Get: &types.Get{
+				TableName: aws.String(TableSchema.TableName),
+				Key:       key,
+			},
+		})
+	}
+	return &dynamodb.TransactGetItemsInput{TransactItems: items}, nil
+}
+
+// TransactGet reads the items by key in one serializable transaction and returns them
+// in the order of the keys. Items which don't exist are returned as nil.
+// Example:
+//
+//	items, err := TransactGet(ctx, client,
+//	    ItemKey{HashKey: "pk-1", RangeKey: "sk-1"},
+//	    ItemKeyOf(otherItem),
+//	)
+func TransactGet(ctx context.Context, client DynamoDBAPI, keys ...ItemKey) ([]*SchemaItem, error) {
+	input, err := TransactGetInput(keys...)
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.TransactGetItems(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute transactional get: %w", err)
+	}
+	items := make([]*SchemaItem, len(keys))
+	for i, response := range out.Responses {
+		if i >= len(items) || len(response.Item) == 0 {
+			continue
+		}
+		var item SchemaItem
+		if err := attributevalue.UnmarshalMap(response.Item, &item); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal item %d: %v", i, err)
+		}
+		items[i] = &item
+	}
+	return items, nil
+}
+
+// IncrementAttribute atomically increments a numeric attribute by a specified value.
+// Uses DynamoDB's ADD operation to ensure thread-safe increments without race conditions.
+// Creates the attribute with the increment value if it doesn't exist.
+func IncrementAttribute(hashKeyValue any, rangeKeyValue any, attributeName string, incrementValue int) (*dynamodb.UpdateItemInput, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return nil, err
+	}
+	if err := validateAttributeName(attributeName); err != nil {
+		return nil, err
+	}
+	if err := validateIncrementValue(incrementValue); err != nil {
+		return nil, err
+	}
+
+	key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key for increment: %v", err)
+	}
+	input := &dynamodb.UpdateItemInput{
+		TableName:        aws.String(TableSchema.TableName),
+		Key:              key,
+		UpdateExpression: aws.String("ADD #attr :val"),
+		ExpressionAttributeNames: map[string]string{
+			"#attr": attributeName,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":val": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", incrementValue)},
+		},
+	}
+	return input, nil
+}
+
+// AddToSet atomically adds values to a DynamoDB Set (SS, NS or BS).
+// Uses DynamoDB's ADD operation for sets - duplicate values are automatically ignored.
+// Creates the set with provided values if the attribute doesn't exist.
+// Supports string sets ([]string), binary sets ([][]byte) and numeric sets ([]int, []float64, etc.).
+func AddToSet(hashKeyValue any, rangeKeyValue any, attributeName string, values any) (*dynamodb.UpdateItemInput, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return nil, err
+	}
+	if err := validateAttributeName(attributeName); err != nil {
+		return nil, err
+	}
+	if err := validateSetValues(values); err != nil {
+		return nil, err
+	}
+
+	key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key for add to set: %v", err)
+	}
+
+	var attributeValue types.AttributeValue
+	switch v := values.(type) {
+	case []string:
+		attributeValue = &types.AttributeValueMemberSS{Value: v}
+	case [][]byte:
+		attributeValue = &types.AttributeValueMemberBS{Value: v}
+	default:
+		return nil, fmt.Errorf("unsupported type for set operation: %T, expected []string or [][]byte", values)
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		TableName:        aws.String(TableSchema.TableName),
+		Key:              key,
+		UpdateExpression: aws.String("ADD #attr :val"),
+		ExpressionAttributeNames: map[string]string{
+			"#attr": attributeName,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":val": attributeValue,
+		},
+	}
+	return input, nil
+}
+
+// RemoveFromSet atomically removes values from a DynamoDB Set (SS, NS or BS).
+// Uses DynamoDB's DELETE operation for sets - non-existent values are ignored.
+// If all values are removed, the attribute is deleted from the item.
+// Supports string sets ([]string), binary sets ([][]byte) and numeric sets ([]int, []float64, etc.).
+func RemoveFromSet(hashKeyValue any, rangeKeyValue any, attributeName string, values any) (*dynamodb.UpdateItemInput, error) {
+	if err := validateKeyInputs(hashKeyValue, rangeKeyValue); err != nil {
+		return nil, err
+	}
+	if err := validateAttributeName(attributeName); err != nil {
+		return nil, err
+	}
+	if err := validateSetValues(values); err != nil {
+		return nil, err
+	}
+
+	key, err := KeyInputFromRaw(hashKeyValue, rangeKeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key for remove from set: %v", err)
+	}
+
+	var attributeValue types.AttributeValue
+	switch v := values.(type) {
+	case []string:
+		attributeValue = &types.AttributeValueMemberSS{Value: v}
+	case [][]byte:
+		attributeValue = &types.AttributeValueMemberBS{Value: v}
+	default:
+		return nil, fmt.Errorf("unsupported type for set operation: %T, expected []string or [][]byte", values)
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		TableName:        aws.String(TableSchema.TableName),
+		Key:              key,
+		UpdateExpression: aws.String("DELETE #attr :val"),
+		ExpressionAttributeNames: map[string]string{
+			"#attr": attributeName,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":val": attributeValue,
+		},
+	}
+	return input, nil
+}
+
+const (
+	// maxBatchGetItems is the DynamoDB limit of keys in one BatchGetItem request.
+	maxBatchGetItems = 100
+
+	// maxBatchWriteItems is the DynamoDB limit of requests in one BatchWriteItem request.
+	maxBatchWriteItems = 25
+
+	// maxBatchRetries is the number of retries of unprocessed items before a batch fails.
+	maxBatchRetries = 8
+
+	// batchBackoffBase and batchBackoffMax bound the exponential backoff between retries.
+	batchBackoffBase = 50 * time.Millisecond
+	batchBackoffMax  = 5 * time.Second
+)
+
+// BatchGetItems reads the items by the keys of the given items (other attributes are ignored).
+// Keys are deduplicated and sent in chunks of 100; UnprocessedKeys are retried with exponential backoff.
+// Items are returned in the order of keys, items which don't exist are skipped.
+// Example:
+//
+//	items, err := BatchGetItems(ctx, client, []SchemaItem{
+//	    {
+//	        Pk: "pk-1",
+//	        Sk: "sk-1",
+//	    },
+//	})
+func BatchGetItems(ctx context.Context, client DynamoDBAPI, keys []SchemaItem) ([]SchemaItem, error) {
+	var (
+		requested = make([]string, 0, len(keys))
+		unique    = make([]map[string]types.AttributeValue, 0, len(keys))
+		seen      = make(map[string]bool, len(keys))
+	)
+	for i, item := range keys {
+		key, err := KeyInput(item)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		id := batchKeyID(key)
+		requested = append(requested, id)
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, key)
+		}
+	}
+
+	found := make(map[string]SchemaItem, len(unique))
+	for start := 0; start < len(unique); start += maxBatchGetItems {
+		end := start + maxBatchGetItems
+		if end > len(unique) {
+			end = len(unique)
+		}
+		pending := unique[start:end]
+		for attempt := 0; len(pending) > 0; attempt++ {
+			if attempt > maxBatchRetries {
+				return nil, fmt.Errorf("batch get: %d keys unprocessed after %d retries", len(pending), maxBatchRetries)
+			}
+			if attempt > 0 {
+				if err := batchBackoff(ctx, attempt); err != nil {
+					return nil, err
+				}
+			}
+			out, err := client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: map[string]types.KeysAndAttributes{
+					TableSchema.TableName: {Keys: pending},
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute batch get: %w", err)
+			}
+			for _, raw := range out.Responses[TableSchema.TableName] {
+				var item SchemaItem
+				if err := attributevalue.UnmarshalMap(raw, &item); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal item: %v", err)
+				}
+				found[batchKeyID(raw)] = item
+			}
+			pending = out.UnprocessedKeys[TableSchema.TableName].Keys
+		}
+	}
+
+	items := make([]SchemaItem, 0, len(found))
+	for _, id := range requested {
+		if item, ok := found[id]; ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// BatchWriteError reports a partially failed BatchWriteItems call.
+// Unprocessed holds the requests which were not written: requests still unprocessed after
+// all retries and, if a request failed with Err, the requests of it and of the chunks after it.
+type BatchWriteError struct {
+	Unprocessed []types.WriteRequest
+	Total       int
+	Err         error
+}
+
+// Error implements error.
+func (e *BatchWriteError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("batch write: %d of %d requests unprocessed: %v", len(e.Unprocessed), e.Total, e.Err)
+	}
+	return fmt.Sprintf("batch write: %d of %d requests unprocessed after %d retries", len(e.Unprocessed), e.Total, maxBatchRetries)
+}
+
+// Unwrap returns the error of the failed request.
+func (e *BatchWriteError) Unwrap() error {
+	return e.Err
+}
+
+// BatchWriteItems puts and deletes the items (deletes use only their keys) in chunks of 25 requests.
+// UnprocessedItems are retried with exponential backoff; items still unprocessed are reported
+// by a *BatchWriteError while the remaining chunks are written. Puts run the BeforePut hooks.
+// An item must not be put and deleted, or put twice, in the same call.
+// Example:
+//
+//	err := BatchWriteItems(ctx, client, newItems, expiredItems)
+//	var partial *BatchWriteError
+//	if errors.As(err, &partial) {
+//	    // partial.Unprocessed can be retried later
+//	}
+func BatchWriteItems(ctx context.Context, client DynamoDBAPI, puts []SchemaItem, deletes []SchemaItem) error {
+	requests := make([]types.WriteRequest, 0, len(puts)+len(deletes))
+	for i, item := range puts {
+		av, err := ItemInput(item)
+		if err != nil {
+			return fmt.Errorf("put %d: %w", i, err)
+		}
+		requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: av}})
+	}
+	for i, item := range deletes {
+		key, err := KeyInput(item)
+		if err != nil {
+			return fmt.Errorf("delete %d: %w", i, err)
+		}
+		requests = append(requests, types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: key}})
+	}
+
+	var unprocessed []types.WriteRequest
+	for start := 0; start < len(requests); start += maxBatchWriteItems {
+		end := start + maxBatchWriteItems
+		if end > len(requests) {
+			end = len(requests)
+		}
+		pending := requests[start:end]
+		for attempt := 0; len(pending) > 0; attempt++ {
+			if attempt > maxBatchRetries {
+				unprocessed = append(unprocessed, pending...)
+				break
+			}
+			if attempt > 0 {
+				if err := batchBackoff(ctx, attempt); err != nil {
+					unprocessed = append(append(unprocessed, pending...), requests[end:]...)
+					return &BatchWriteError{Unprocessed: unprocessed, Total: len(requests), Err: err}
+				}
+			}
+			out, err := client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: map[string][]types.WriteRequest{TableSchema.TableName: pending},
+			})
+			if err != nil {
+				unprocessed = append(append(unprocessed, pending...), requests[end:]...)
+				return &BatchWriteError{Unprocessed: unprocessed, Total: len(requests), Err: err}
+			}
+			pending = out.UnprocessedItems[TableSchema.TableName]
+		}
+	}
+	if len(unprocessed) > 0 {
+		return &BatchWriteError{Unprocessed: unprocessed, Total: len(requests)}
+	}
+	return nil
+}
+
+// batchKeyID returns a comparable identity of the primary key of an item or key map.
+func batchKeyID(item map[string]types.AttributeValue) string {
+	var b strings.Builder
+	for _, name := range []string{TableSchema.HashKey, TableSchema.RangeKey} {
+		switch v := item[name].(type) {
+		case *types.AttributeValueMemberS:
+			b.WriteString("S:" + v.Value)
+		case *types.AttributeValueMemberN:
+			b.WriteString("N:" + v.Value)
+		case *types.AttributeValueMemberB:
+			b.WriteString("B:" + string(v.Value))
+		}
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// batchBackoff waits before retry attempt (1-based) of unprocessed batch items:
+// exponential backoff from batchBackoffBase capped at batchBackoffMax, with full jitter.
+func batchBackoff(ctx context.Context, attempt int) error {
+	delay := batchBackoffMax
+	if attempt <= 16 && batchBackoffBase<<(attempt-1) < batchBackoffMax {
+		delay = batchBackoffBase << (attempt - 1)
+	}
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(delay)) + 1))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ReadFallback is the consistency fallback of reads by GetItem, GetItemFromRaw and QueryBuilder.
+// Reads with a fallback need strong consistency, so queries never select a GSI.
+type ReadFallback int
+
+const (
+	// NoReadFallback sends reads once with the requested consistency.
+	NoReadFallback ReadFallback = iota
+
+	// StrongThenEventual reads strongly consistent and retries eventually consistent if the read fails,
+	// e.g. when consistent reads are throttled or the region of a Global Tables replica is impaired.
+	StrongThenEventual
+
+	// EventualThenStrong reads eventually consistent and retries strongly consistent
+	// if a read item fails the FreshnessCheck (GetItem also retries missing items).
+	EventualThenStrong
+)
+
+// FreshnessCheck reports whether an eventually consistent read of the item is fresh enough,
+// e.g. its version or update time is not older than the last write of the caller.
+type FreshnessCheck func(item SchemaItem) bool
+
+// freshItems reports whether all raw items pass the freshness check.
+// Items that can't be unmarshaled are stale, so they are read again.
+func freshItems(raw []map[string]types.AttributeValue, fresh FreshnessCheck) bool {
+	if fresh == nil {
+		return true
+	}
+	for _, av := range raw {
+		var item SchemaItem
+		if err := attributevalue.UnmarshalMap(av, &item); err != nil || !fresh(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateAndGet runs the update and returns the item as stored after it.
+// The update is sent with ReturnValues ALL_NEW; if the response carries no attributes
+// (e.g. a client decorator or emulator drops them) the item is read back with a consistent read.
+// Works with every UpdateItemInput builder: UpdateItemInputFromRaw, IncrementAttribute, AddToSet, ...
+// Example:
+//
+//	input, err := UpdateItemInputFromRaw("pk-1", "sk-1", map[string]any{
+//	    ColumnRequests: 1,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	item, err := UpdateAndGet(ctx, client, input)
+func UpdateAndGet(ctx context.Context, client DynamoDBAPI, input *dynamodb.UpdateItemInput) (*SchemaItem, error) {
+	if input == nil {
+		return nil, fmt.Errorf("update input is required")
+	}
+	input.ReturnValues = types.ReturnValueAllNew
+	out, err := client.UpdateItem(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update item: %v", err)
+	}
+	if len(out.Attributes) == 0 {
+		return GetConsistent(ctx, client, input.Key)
+	}
+	var item SchemaItem
+	if err := attributevalue.UnmarshalMap(out.Attributes, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal updated item: %v", err)
+	}
+	return &item, nil
+}
+
+// TransactWriteAndGet runs the transaction and returns the items of this table it put or updated,
+// in transaction order. TransactWriteItems can't return item attributes, so every item is read back
+// with a consistent read after the transaction commits.
+func TransactWriteAndGet(ctx context.Context, client DynamoDBAPI, input *dynamodb.TransactWriteItemsInput) ([]SchemaItem, error) {
+	if input == nil {
+		return nil, fmt.Errorf("transaction input is required")
+	}
+	if _, err := client.TransactWriteItems(ctx, input); err != nil {
+		return nil, fmt.Errorf("failed to execute transaction: %v", err)
+	}
+	var items []SchemaItem
+	for _, write := range input.TransactItems {
+		var key map[string]types.AttributeValue
+		switch {
+		case write.Update != nil && aws.ToString(write.Update.TableName) == TableSchema.TableName:
+			key = write.Update.Key
+		case write.Put != nil && aws.ToString(write.Put.TableName) == TableSchema.TableName:
+			key = map[string]types.AttributeValue{TableSchema.HashKey: write.Put.Item[TableSchema.HashKey]}
+			if TableSchema.RangeKey != "" {
+				key[TableSchema.RangeKey] = write.Put.Item[TableSchema.RangeKey]
+			}
+		default:
+			continue
+		}
+		item, err := GetConsistent(ctx, client, key)
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			items = append(items, *item)
+		}
+	}
+	return items, nil
+}
+
+// GetConsistent reads the item by key with a strongly consistent read.
+// Returns nil without error if the item doesn't exist.
+func GetConsistent(ctx context.Context, client DynamoDBAPI, key map[string]types.AttributeValue) (*SchemaItem, error) {
+	out, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(TableSchema.TableName),
+		Key:            key,
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read item: %v", err)
+	}
+	if len(out.Item) == 0 {
+		return nil, nil
+	}
+	var item SchemaItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal item: %v", err)
+	}
+	return &item, nil
+}
+
+const (
+	// GeneratorVersion is the go-dyno version this code was generated with.
+	// Pass it to github.com/Mad-Pixels/go-dyno/registry to detect packages generated by different versions.
+	GeneratorVersion = "0.0.1"
+
+	// MinDynamoDBVersion is the oldest github.com/aws/aws-sdk-go-v2/service/dynamodb version this code supports.
+	MinDynamoDBVersion = "1.43.1"
+)
+
+// dynamoDBModule is the module MinDynamoDBVersion applies to.
+const dynamoDBModule = "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+// init fails loudly if the binary links a DynamoDB module older than MinDynamoDBVersion.
+func init() {
+	if err := checkGeneratorCompatibility(); err != nil {
+		panic(err)
+	}
+}
+
+// checkGeneratorCompatibility verifies the DynamoDB module version linked into the binary.
+// Binaries without build info or with a replaced local module are not checked.
+func checkGeneratorCompatibility() error {
+	linked := linkedModuleVersion(dynamoDBModule)
+	if linked != "" && compareVersions(linked, MinDynamoDBVersion) < 0 {
+		return fmt.Errorf("godyno: package for table %s requires %s >= %s, linked %s", TableName, dynamoDBModule, MinDynamoDBVersion, linked)
+	}
+	return nil
+}
+
+// linkedModuleVersion returns the version of the module path linked into the binary,
+// empty if it is unknown.
+func linkedModuleVersion(path string) string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != path {
+			continue
+		}
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		if strings.HasPrefix(dep.Version, "v") {
+			return dep.Version
+		}
+		return ""
+	}
+	return ""
+}
+
+// compareVersions compares dotted numeric versions ("1.36.3"), ignoring a "v" prefix and pre-release suffix.
+// Returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+	var (
+		pa = strings.Split(strings.TrimPrefix(a, "v"), ".")
+		pb = strings.Split(strings.TrimPrefix(b, "v"), ".")
+	)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(strings.SplitN(pa[i], "-", 2)[0])
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(strings.SplitN(pb[i], "-", 2)[0])
+		}
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		}
+	}
+	return 0
+}
+
+// QuotaKeyPrefix is prepended to subjects in the hash key of quota window items.
+// Window items are stored next to the items of the table: scans return them too,
+// skip hash keys starting with QuotaKeyPrefix.
+const QuotaKeyPrefix = "quota#"
+
+// quotaCountAttribute is the attribute of window items counting the consumed units.
+const quotaCountAttribute = "quota_count"
+
+// ErrQuotaExceeded matches every *QuotaExceededError:
+//
+//	if errors.Is(err, ErrQuotaExceeded) { /* reject the request */ }
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaExceededError reports a ConsumeQuota rejected because the subject used up its quota.
+type QuotaExceededError struct {
+	Subject string
+	Limit   int64
+
+	// RetryAfter is the time until the current window ends.
+	// Sliding windows may still be used up then.
+	RetryAfter time.Duration
+}
+
+// Error implements error.
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota of %s exceeded: limit %d, retry after %s", e.Subject, e.Limit, e.RetryAfter)
+}
+
+// Is reports whether target is ErrQuotaExceeded.
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// QuotaClock returns the time windows are computed from.
+// Replace it in tests for deterministic windows.
+var QuotaClock = time.Now
+
+// QuotaOption configures ConsumeQuota.
+type QuotaOption func(*quotaOptions)
+
+type quotaOptions struct {
+	cost    int64
+	sliding bool
+}
+
+// WithQuotaCost consumes n units instead of 1, e.g. for weighted requests.
+func WithQuotaCost(n int64) QuotaOption {
+	return func(o *quotaOptions) {
+		o.cost = n
+	}
+}
+
+// WithSlidingWindow counts the previous window weighted by its overlap with the window ending now,
+// smoothing bursts at window boundaries. Costs a read of the previous window.
+func WithSlidingWindow() QuotaOption {
+	return func(o *quotaOptions) {
+		o.sliding = true
+	}
+}
+
+// ConsumeQuota consumes a unit of the quota of subject, limit units per window, and returns the units
+// left in the window. Windows are fixed and aligned to the Unix epoch unless WithSlidingWindow;
+// counters of different window durations are separate.
+// Returns a *QuotaExceededError matching ErrQuotaExceeded if the quota is used up, consuming nothing.
+// Example:
+//
+//	remaining, err := ConsumeQuota(ctx, client, "user-42", 100, time.Minute)
+//	if errors.Is(err, ErrQuotaExceeded) {
+//	    // reject with 429
+//	}
+func ConsumeQuota(ctx context.Context, client DynamoDBAPI, subject string, limit int64, window time.Duration, opts ...QuotaOption) (int64, error) {
+	options := quotaOptions{cost: 1}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if window < time.Millisecond {
+		return 0, fmt.Errorf("quota window must be at least 1ms, got %s", window)
+	}
+	if options.cost <= 0 {
+		return 0, fmt.Errorf("quota cost must be positive, got %d", options.cost)
+	}
+
+	now := QuotaClock()
+	start := time.UnixMilli(now.UnixMilli() - now.UnixMilli()%window.Milliseconds())
+	exceeded := &QuotaExceededError{Subject: subject, Limit: limit, RetryAfter: start.Add(window).Sub(now)}
+	allowance := limit
+	if options.sliding {
+		previous, err := quotaCount(ctx, client, quotaKey(subject, window, start.Add(-window)))
+		if err != nil {
+			return 0, err
+		}
+		overlap := 1 - float64(now.Sub(start))/float64(window)
+		allowance -= int64(math.Ceil(float64(previous) * overlap))
+	}
+	if allowance < options.cost {
+		return 0, exceeded
+	}
+
+	key, err := KeyInputFromRaw(quotaKey(subject, window, start), "quota")
+	if err != nil {
+		return 0, err
+	}
+	update := expression.Add(expression.Name(quotaCountAttribute), expression.Value(options.cost))
+	// The next window reads this one when sliding.
+	update = update.Set(expression.Name(TTLAttribute), expression.Value(start.Add(2*window).Unix()))
+	condition := expression.Name(quotaCountAttribute).AttributeNotExists().
+		Or(expression.Name(quotaCountAttribute).LessThanEqual(expression.Value(allowance - options.cost)))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build quota expression: %v", err)
+	}
+	out, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(TableSchema.TableName),
+		Key:                       key,
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              types.ReturnValueUpdatedNew,
+	})
+	if err != nil {
+		var failed *types.ConditionalCheckFailedException
+		if errors.As(err, &failed) {
+			return 0, exceeded
+		}
+		return 0, fmt.Errorf("failed to consume quota of %s: %w", subject, err)
+	}
+	var count int64
+	if err := attributevalue.Unmarshal(out.Attributes[quotaCountAttribute], &count); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal quota count: %v", err)
+	}
+	return allowance - count, nil
+}
+
+// quotaKey returns the hash key of the window item of subject starting at start.
+func quotaKey(subject string, window time.Duration, start time.Time) string {
+	return fmt.Sprintf("%s%s#%d#%d", QuotaKeyPrefix, subject, window.Milliseconds(), start.UnixMilli())
+}
+
+// quotaCount returns the units consumed in the window item with the hash key, 0 if it doesn't exist.
+func quotaCount(ctx context.Context, client DynamoDBAPI, hashKey string) (int64, error) {
+	key, err := KeyInputFromRaw(hashKey, "quota")
+	if err != nil {
+		return 0, err
+	}
+	out, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(TableSchema.TableName),
+		Key:       key,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read quota window: %w", err)
+	}
+	av, ok := out.Item[quotaCountAttribute]
+	if !ok {
+		return 0, nil
+	}
+	var count int64
+	if err := attributevalue.Unmarshal(av, &count); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal quota count: %v", err)
+	}
+	return count, nil
+}
+
+const (
+	// SchemaHashTagKey is the table tag holding the schema hash of the deployed table definition.
+	SchemaHashTagKey = "godyno:schema-hash"
+
+	// SchemaVersionTagKey is the table tag holding the schema version of the deployed table definition.
+	SchemaVersionTagKey = "godyno:schema-version"
+)
+
+// SchemaTags returns table tags describing the schema this code was generated from.
+// Attach them on table creation (or with TagResource) to enable version checks in AssertSchemaCompatible.
+// Example:
+//
+//	input := &dynamodb.CreateTableInput{TableName: aws.String(TableName), Tags: SchemaTags()}
+func SchemaTags() []types.Tag {
+	return []types.Tag{
+		{Key: aws.String(SchemaHashTagKey), Value: aws.String(SchemaHash)},
+		{Key: aws.String(SchemaVersionTagKey), Value: aws.String(strconv.Itoa(SchemaVersion))},
+	}
+}
+
+// AssertSchemaCompatible verifies that the live table matches the schema this code was generated from.
+// Checks table and index key schemas and key attribute types via DescribeTable.
+// If the table carries SchemaTags, a newer table version or a different hash for the same version is an error.
+// The hash of a table tagged with an older version, or without a SchemaHash tag, is not checked.
+// Call it on startup to catch deploys of stale code against changed tables.
+func AssertSchemaCompatible(ctx context.Context, client DynamoDBAPI) error {
+	out, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(TableName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %v", TableName, err)
+	}
+	if out.Table == nil {
+		return fmt.Errorf("table %s: empty description", TableName)
+	}
+	table := out.Table
+
+	if err := compareKeySchema("table", table.KeySchema, TableSchema.HashKey, TableSchema.RangeKey); err != nil {
+		return err
+	}
+	if err := compareKeyTypes(table.AttributeDefinitions); err != nil {
+		return err
+	}
+
+	remote := make(map[string][]types.KeySchemaElement)
+	for _, gsi := range table.GlobalSecondaryIndexes {
+		remote[aws.ToString(gsi.IndexName)] = gsi.KeySchema
+	}
+	for _, lsi := range table.LocalSecondaryIndexes {
+		remote[aws.ToString(lsi.IndexName)] = lsi.KeySchema
+	}
+	for _, idx := range TableSchema.SecondaryIndexes {
+		keys, ok := remote[idx.Name]
+		if !ok {
+			return fmt.Errorf("index %s: not found on table %s", idx.Name, TableName)
+		}
+		if err := compareKeySchema("index "+idx.Name, keys, idx.HashKey, idx.RangeKey); err != nil {
+			return err
+		}
+	}
+
+	if table.TableArn == nil {
+		return nil
+	}
+	tags, err := client.ListTagsOfResource(ctx, &dynamodb.ListTagsOfResourceInput{
+		ResourceArn: table.TableArn,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list tags of table %s: %v", TableName, err)
+	}
+	return compareSchemaTags(tags.Tags)
+}
+
+// compareKeySchema checks that a key schema consists of the expected hash and range keys.
+func compareKeySchema(scope string, keys []types.KeySchemaElement, hashKey, rangeKey string) error {
+	var remoteHash, remoteRange string
+	for _, k := range keys {
+		switch k.KeyType {
+		case types.KeyTypeHash:
+			remoteHash = aws.ToString(k.AttributeName)
+		case types.KeyTypeRange:
+			remoteRange = aws.ToString(k.AttributeName)
+		}
+	}
+	if remoteHash != hashKey {
+		return fmt.Errorf("%s: hash key is %q, generated code expects %q", scope, remoteHash, hashKey)
+	}
+	if remoteRange != rangeKey {
+		return fmt.Errorf("%s: range key is %q, generated code expects %q", scope, remoteRange, rangeKey)
+	}
+	return nil
+}
+
+// compareKeyTypes checks that key attribute definitions match schema attribute types.
+// A key attribute the schema doesn't define is an error; composite index keys are only checked by name.
+func compareKeyTypes(defs []types.AttributeDefinition) error {
+	indexKeys := make(map[string]bool)
+	for _, idx := range TableSchema.SecondaryIndexes {
+		indexKeys[idx.HashKey] = true
+		indexKeys[idx.RangeKey] = true
+	}
+	for _, def := range defs {
+		name := aws.ToString(def.AttributeName)
+		field, ok := TableSchema.FieldsMap[name]
+		if !ok {
+			if indexKeys[name] {
+				continue
+			}
+			return fmt.Errorf("attribute %s: table key attribute is not defined in the generated schema", name)
+		}
+		if string(def.AttributeType) != field.DynamoType {
+			return fmt.Errorf("attribute %s: table type is %s, generated code expects %s", name, def.AttributeType, field.DynamoType)
+		}
+	}
+	return nil
+}
+
+// compareSchemaTags checks table schema tags against the generated SchemaVersion and SchemaHash.
+// Tables without schema tags are accepted. The hash is compared only for tables of the generated version
+// (or without a version tag): an older table with a missing or invalid SchemaHash tag passes unchecked.
+func compareSchemaTags(tags []types.Tag) error {
+	var hash, version string
+	for _, t := range tags {
+		switch aws.ToString(t.Key) {
+		case SchemaHashTagKey:
+			hash = aws.ToString(t.Value)
+		case SchemaVersionTagKey:
+			version = aws.ToString(t.Value)
+		}
+	}
+	if version != "" {
+		v, err := strconv.Atoi(version)
+		if err != nil {
+			return fmt.Errorf("table %s: invalid %s tag %q: %v", TableName, SchemaVersionTagKey, version, err)
+		}
+		if v > SchemaVersion {
+			return fmt.Errorf("table %s: schema version %d is newer than generated code version %d", TableName, v, SchemaVersion)
+		}
+		if v < SchemaVersion {
+			return nil
+		}
+	}
+	if hash != "" && hash != SchemaHash {
+		return fmt.Errorf("table %s: schema hash %s differs from generated code hash %s", TableName, hash, SchemaHash)
+	}
+	return nil
+}
+
+const (
+	// LocalStackEndpoint is the default LocalStack edge endpoint.
+	LocalStackEndpoint = "http://localhost:4566"
+
+	// DynamoDBLocalEndpoint is the default dynamodb-local endpoint.
+	DynamoDBLocalEndpoint = "http://localhost:8000"
+)
+
+// ClientOptions configures the DynamoDB client created by NewClient.
+// Empty fields fall back to the default AWS SDK configuration chain.
+type ClientOptions struct {
+	Region          string // AWS region, e.g. "us-east-1"
+	Endpoint        string // Custom endpoint URL (LocalStack, dynamodb-local)
+	AccessKeyID     string // Static access key, intended for local development
+	SecretAccessKey string // Static secret key, used together with AccessKeyID
+	SessionToken    string // Optional session token for static credentials
+}
+
+// LocalStackOptions returns ClientOptions for a LocalStack instance with dummy credentials.
+func LocalStackOptions(region string) ClientOptions {
+	return ClientOptions{
+		Region:          region,
+		Endpoint:        LocalStackEndpoint,
+		AccessKeyID:     "test",
+		SecretAccessKey: "test",
+	}
+}
+
+// DynamoDBLocalOptions returns ClientOptions for a dynamodb-local instance with dummy credentials.
+func DynamoDBLocalOptions(region string) ClientOptions {
+	return ClientOptions{
+		Region:          region,
+		Endpoint:        DynamoDBLocalEndpoint,
+		AccessKeyID:     "local",
+		SecretAccessKey: "local",
+	}
+}
+
+// RegionalEndpoint returns the public DynamoDB endpoint URL for the given region.
+// Example: RegionalEndpoint("eu-west-1") → "https://dynamodb.eu-west-1.amazonaws.com"
+func RegionalEndpoint(region string) string {
+	return fmt.Sprintf("https://dynamodb.%s.amazonaws.com", region)
+}
+
+// NewClient creates a DynamoDB client from the default AWS configuration chain.
+// Region, endpoint and static credentials from opts override the defaults when set.
+// Example:
+//
+//	client, err := NewClient(ctx, LocalStackOptions("us-east-1"))
+func NewClient(ctx context.Context, opts ClientOptions) (*dynamodb.Client, error) {
+	var loadOpts []func(*config.LoadOptions) error
+	if opts.Region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(opts.Region))
+	}
+	if opts.AccessKeyID != "" {
+		loadOpts = append(loadOpts, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(opts.AccessKeyID, opts.SecretAccessKey, opts.SessionToken),
+		))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %v", err)
+	}
+	return dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) {
+		if opts.Endpoint != "" {
+			o.BaseEndpoint = aws.String(opts.Endpoint)
+		}
+	}), nil
+}
+
+// ErrTableUnavailable is returned by HealthCheck when the table, one of its indexes or its TTL isn't in a serving state.
+var ErrTableUnavailable = errors.New("table unavailable")
+
+// HealthCheck reports whether the table is ready to serve the generated code:
+// the table and its global secondary indexes are ACTIVE or UPDATING, TTL is enabled on expires_at
+// and a query limited to 1 item succeeds, which also checks data access permissions.
+// Returns an error matching ErrTableUnavailable for a table which isn't ready,
+// other errors for failed requests. Costs one DescribeTable, one DescribeTimeToLive and half a read capacity unit.
+// Example:
+//
+//	http.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+//	    if err := HealthCheck(r.Context(), client); err != nil {
+//	        http.Error(w, err.Error(), http.StatusServiceUnavailable)
+//	    }
+//	})
+func HealthCheck(ctx context.Context, client DynamoDBAPI) error {
+	out, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(TableName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %v", TableName, err)
+	}
+	if out.Table == nil {
+		return fmt.Errorf("%w: table %s has an empty description", ErrTableUnavailable, TableName)
+	}
+	if status := out.Table.TableStatus; !isServingStatus(string(status)) {
+		return fmt.Errorf("%w: table %s is %s", ErrTableUnavailable, TableName, status)
+	}
+
+	indexes := make(map[string]types.IndexStatus, len(out.Table.GlobalSecondaryIndexes))
+	for _, gsi := range out.Table.GlobalSecondaryIndexes {
+		indexes[aws.ToString(gsi.IndexName)] = gsi.IndexStatus
+	}
+	for _, idx := range TableSchema.SecondaryIndexes {
+		if idx.Type != "GSI" {
+			continue
+		}
+		status, ok := indexes[idx.Name]
+		if !ok {
+			return fmt.Errorf("%w: index %s not found on table %s", ErrTableUnavailable, idx.Name, TableName)
+		}
+		if !isServingStatus(string(status)) {
+			return fmt.Errorf("%w: index %s is %s", ErrTableUnavailable, idx.Name, status)
+		}
+	}
+
+	ttl, err := client.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(TableName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe TTL of table %s: %v", TableName, err)
+	}
+	if ttl.TimeToLiveDescription == nil {
+		return fmt.Errorf("%w: table %s has no TTL description", ErrTableUnavailable, TableName)
+	}
+	switch status := ttl.TimeToLiveDescription.TimeToLiveStatus; status {
+	case types.TimeToLiveStatusEnabled, types.TimeToLiveStatusEnabling:
+		if name := aws.ToString(ttl.TimeToLiveDescription.AttributeName); name != TTLAttribute {
+			return fmt.Errorf("%w: TTL of table %s is on %q, generated code expects %q", ErrTableUnavailable, TableName, name, TTLAttribute)
+		}
+	default:
+		return fmt.Errorf("%w: TTL of table %s is %s", ErrTableUnavailable, TableName, status)
+	}
+
+	if _, err := client.Query(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(TableName),
+		KeyConditionExpression:    aws.String("#pk = :pk"),
+		ExpressionAttributeNames:  map[string]string{"#pk": TableSchema.HashKey},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":pk": healthProbeKey()},
+		Limit:                     aws.Int32(1),
+	}); err != nil {
+		return fmt.Errorf("failed to query table %s: %v", TableName, err)
+	}
+	return nil
+}
+
+// isServingStatus reports whether a table or index status serves reads and writes.
+func isServingStatus(status string) bool {
+	return status == string(types.TableStatusActive) || status == string(types.TableStatusUpdating)
+}
+
+// healthProbeKey returns the hash key value queried by HealthCheck, a value of the hash key type.
+func healthProbeKey() types.AttributeValue {
+	switch TableSchema.FieldsMap[TableSchema.HashKey].DynamoType {
+	case "N":
+		return &types.AttributeValueMemberN{Value: "0"}
+	case "B":
+		return &types.AttributeValueMemberB{Value: []byte{0}}
+	}
+	return &types.AttributeValueMemberS{Value: "godyno:health"}
+}
+
+// CreateTableInput returns the CreateTable request of the table declared in the schema:
+// key schema, key attribute definitions, secondary indexes, table class, warm throughput and SchemaTags.
+// The table is on-demand; set BillingMode and ProvisionedThroughput for provisioned capacity.
+// Tables with replicas get the NEW_AND_OLD_IMAGES stream Global Tables replicate from;
+// add the replicas with ReplicaUpdateInputs once the table is ACTIVE.
+// CreateTable can't enable TTL, send TimeToLiveInput once the table is ACTIVE.
+// Example:
+//
+//	_, err := client.CreateTable(ctx, CreateTableInput())
+func CreateTableInput() *dynamodb.CreateTableInput {
+	input := &dynamodb.CreateTableInput{
+		TableName:   aws.String(TableName),
+		BillingMode: types.BillingModePayPerRequest,
+		KeySchema:   createTableKeySchema("pk", "sk"),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("pk"), AttributeType: types.ScalarAttributeType("S")},
+			{AttributeName: aws.String("sk"), AttributeType: types.ScalarAttributeType("S")},
+		},
+		Tags: SchemaTags(),
+	}
+	return input
+}
+
+// TimeToLiveInput returns the UpdateTimeToLive request enabling TTL on expires_at.
+// Example:
+//
+//	_, err := client.UpdateTimeToLive(ctx, TimeToLiveInput())
+func TimeToLiveInput() *dynamodb.UpdateTimeToLiveInput {
+	return &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(TableName),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String(TTLAttribute),
+			Enabled:       aws.Bool(true),
+		},
+	}
+}
+
+// createTableKeySchema returns the key schema of a hash key and an optional range key.
+func createTableKeySchema(hashKey, rangeKey string) []types.KeySchemaElement {
+	keys := []types.KeySchemaElement{
+		{AttributeName: aws.String(hashKey), KeyType: types.KeyTypeHash},
+	}
+	if rangeKey != "" {
+		keys = append(keys, types.KeySchemaElement{AttributeName: aws.String(rangeKey), KeyType: types.KeyTypeRange})
+	}
+	return keys
+}
+
+// createTableProjection returns an index projection of the type with optional non-key attributes (INCLUDE).
+func createTableProjection(projectionType string, nonKeyAttributes ...string) *types.Projection {
+	p := &types.Projection{ProjectionType: types.ProjectionType(projectionType)}
+	if len(nonKeyAttributes) > 0 {
+		p.NonKeyAttributes = nonKeyAttributes
+	}
+	return p
+}
+
+// MergeStrategy resolves conflicts of MergePutItems between given and stored items.
+type MergeStrategy int
+
+const (
+	// MergeOurs writes the given items over the stored ones.
+	MergeOurs MergeStrategy = iota
+
+	// MergeTheirs keeps the stored items and only writes items the table doesn't have yet.
+	MergeTheirs
+)
+
+// maxMergeAttempts is the number of times MergePutItems reads and merges a chunk
+// whose transaction is canceled by a concurrent write.
+const maxMergeAttempts = 3
+
+// MergePutItems upserts the items, resolving conflicts with stored items by the strategy.
+// Items are processed in chunks of 100: the stored items are read in one transaction, merged
+// and written in another, every put conditioned on the stored item being unchanged
+// (only its existence is checked, declare a version attribute for full protection).
+// A chunk canceled by a concurrent write is read and merged again, up to maxMergeAttempts times.
+// Returns the items as written, in the order of items; chunks written before an error are kept.
+// Example:
+//
+//	merged, err := MergePutItems(ctx, client, items, MergeOurs)
+func MergePutItems(ctx context.Context, client DynamoDBAPI, items []SchemaItem, strategy MergeStrategy) ([]SchemaItem, error) {
+	seen := make(map[string]int, len(items))
+	for i, item := range items {
+		key := fmt.Sprintf("%v", ItemKeyOf(item))
+		if j, ok := seen[key]; ok {
+			return nil, fmt.Errorf("items %d and %d have the same key %s", j, i, key)
+		}
+		seen[key] = i
+	}
+
+	merged := make([]SchemaItem, 0, len(items))
+	for start := 0; start < len(items); start += maxTransactItems {
+		chunk := items[start:min(start+maxTransactItems, len(items))]
+		var (
+			written []SchemaItem
+			err     error
+		)
+		for attempt := 1; attempt <= maxMergeAttempts; attempt++ {
+			written, err = mergePutChunk(ctx, client, chunk, strategy)
+			var canceled *types.TransactionCanceledException
+			if !errors.As(err, &canceled) {
+				break
+			}
+		}
+		if err != nil {
+			return merged, fmt.Errorf("failed to merge items %d-%d: %w", start, start+len(chunk)-1, err)
+		}
+		merged = append(merged, written...)
+	}
+	return merged, nil
+}
+
+// mergePutChunk reads the stored items of at most maxTransactItems items,
+// merges them by the strategy and writes the result in one transaction.
+func mergePutChunk(ctx context.Context, client DynamoDBAPI, items []SchemaItem, strategy MergeStrategy) ([]SchemaItem, error) {
+	keys := make([]ItemKey, len(items))
+	for i, item := range items {
+		keys[i] = ItemKeyOf(item)
+	}
+	stored, err := TransactGet(ctx, client, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	tb := NewTransactionBuilder()
+	written := make([]SchemaItem, len(items))
+	for i, item := range items {
+		result, put, err := mergeItem(item, stored[i], strategy)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		written[i] = result
+		if put != nil {
+			tb.Add(*put, nil)
+		}
+	}
+	if tb.Len() == 0 {
+		return written, nil
+	}
+	if err := tb.Execute(ctx, client); err != nil {
+		return nil, err
+	}
+	return written, nil
+}
+
+// mergeItem merges the given item with the stored one (nil if the table has none) and returns
+// the resulting item with the conditional put writing it, nil if nothing has to be written.
+func mergeItem(item SchemaItem, stored *SchemaItem, strategy MergeStrategy) (SchemaItem, *types.TransactWriteItem, error) {
+	if stored != nil && strategy == MergeTheirs {
+		return *stored, nil, nil
+	}
+	av, err := ItemInput(item)
+	if err != nil {
+		return SchemaItem{}, nil, err
+	}
+
+	condition := expression.Name(TableSchema.HashKey).AttributeNotExists()
+	if stored != nil {
+		condition = mergeUnchanged(*stored)
+	}
+
+	result, err := UnmarshalItem(av)
+	if err != nil {
+		return SchemaItem{}, nil, err
+	}
+	expr, err := buildCondition(condition)
+	if err != nil {
+		return SchemaItem{}, nil, err
+	}
+	return *result, &types.TransactWriteItem{
+		Put: &types.Put{
+			TableName:                 aws.String(TableSchema.TableName),
+			Item:                      av,
+			ConditionExpression:       expr.Condition(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+		},
+	}, nil
+}
+
+// mergeUnchanged returns the condition of a put over the stored item:
+// it still exists.
+func mergeUnchanged(stored SchemaItem) expression.ConditionBuilder {
+	return expression.Name(TableSchema.HashKey).AttributeExists()
+}
+
+// TTLAttribute is the attribute holding the expiry of the item in Unix seconds.
+// DynamoDB deletes expired items in the background, typically within a few days.
+const TTLAttribute = ColumnExpiresAt
+
+// TTLClock returns the time expiries are computed from and checked against.
+// Replace it in tests for deterministic expiries.
+var TTLClock = time.Now
+
+// TTLValue returns the expires_at value of an item expiring after d, e.g. for raw updates:
+//
+//	updates[TTLAttribute] = TTLValue(24 * time.Hour)
+func TTLValue(d time.Duration) int64 {
+	return int64(TTLClock().Add(d).Unix())
+}
+
+// WithTTL returns a copy of the item expiring after d.
+// Example:
+//
+//	av, err := ItemInput(item.WithTTL(24 * time.Hour))
+func (item SchemaItem) WithTTL(d time.Duration) SchemaItem {
+	item.ExpiresAt = TTLValue(d)
+	return item
+}
+
+// IsExpired reports whether the item has an expiry which passed.
+// Expired items are returned by reads until DynamoDB deletes them.
+func (item SchemaItem) IsExpired() bool {
+	return item.ExpiresAt > 0 && int64(item.ExpiresAt) <= TTLClock().Unix()
+}
+
+// UpdateWithTTL adds setting the expiry to d from now to an update expression.
+// Example:
+//
+//	update := UpdateWithTTL(expression.UpdateBuilder{}, 30*24*time.Hour)
+//	input, err := UpdateItemInputWithExpression("pk-1", "sk-1", update, nil)
+func UpdateWithTTL(update expression.UpdateBuilder, d time.Duration) expression.UpdateBuilder {
+	return update.Set(expression.Name(TTLAttribute), expression.Value(TTLValue(d)))
+}
+
+// MarshalMap converts any Go value (map, struct, etc.) to DynamoDB AttributeValue map
+// Uses AWS SDK's built-in marshaler for consistent behavior
+func MarshalMap(input any) (map[string]types.AttributeValue, error) {
+	result, err := attributevalue.MarshalMap(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal to AttributeValue map: %v", err)
+	}
+	return result, nil
+}
+
+// UnmarshalItem converts a DynamoDB AttributeValue map to SchemaItem
+// Uses AWS SDK's built-in unmarshaler for consistent behavior
+func UnmarshalItem(av map[string]types.AttributeValue) (*SchemaItem, error) {
+	item, err := FromAttributeValues(av)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ToAttributeValues converts a SchemaItem to a DynamoDB AttributeValue map with the marshaling
+// rules of the generated code (dynamodbav struct tags, set types, omitted empty values).
+// Use it to pass items to SDK calls the package doesn't wrap. Unlike ItemInput
+// it doesn't run BeforePut hooks.
+func ToAttributeValues(item SchemaItem) (map[string]types.AttributeValue, error) {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal item: %v", err)
+	}
+	if err := checkNumbers(av); err != nil {
+		return nil, err
+	}
+	return av, nil
+}
+
+// FromAttributeValues converts a DynamoDB AttributeValue map, e.g. an item of another SDK call
+// or a stream record, to a SchemaItem. Attributes not declared in the schema are ignored,
+// numbers the fields can't hold return a *NumberRangeError.
+// Example:
+//
+//	out, err := client.GetItem(ctx, input)
+//	item, err := FromAttributeValues(out.Item)
+func FromAttributeValues(av map[string]types.AttributeValue) (SchemaItem, error) {
+	var item SchemaItem
+	if err := checkNumbers(av); err != nil {
+		return SchemaItem{}, err
+	}
+	if err := attributevalue.UnmarshalMap(av, &item); err != nil {
+		return SchemaItem{}, fmt.Errorf("failed to unmarshal AttributeValue map: %v", err)
+	}
+	return item, nil
+}
+
+// Marshal converts a single Go value to DynamoDB AttributeValue
+// Uses AWS SDK's built-in marshaler for consistent behavior
+func Marshal(input any) (types.AttributeValue, error) {
+	result, err := attributevalue.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal to AttributeValue: %v", err)
+	}
+	return result, nil
+}
+
+// Generic type constraints for numeric types used in DynamoDB sets.
+// Provides compile-time type safety for numeric conversions.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+type Float interface {
+	~float32 | ~float64
+}
+
+// toIntStrings converts any signed or unsigned integer slice to string slice.
+// DynamoDB requires numeric sets as string arrays for the wire protocol.
+// Non-negative values are formatted unsigned, so uint64 values above math.MaxInt64 keep their value.
+func toIntStrings[T Signed | Unsigned](nums []T) []string {
+	out := make([]string, len(nums))
+	for i, n := range nums {
+		if n < 0 {
+			out[i] = strconv.FormatInt(int64(n), 10)
+		} else {
+			out[i] = strconv.FormatUint(uint64(n), 10)
+		}
+	}
+	return out
+}
+
+// toFloatStrings converts any float slice to string slice.
+// Uses 'g' format for optimal precision and readability.
+func toFloatStrings[F Float](nums []F) []string {
+	out := make([]string, len(nums))
+	for i, f := range nums {
+		out[i] = strconv.FormatFloat(float64(f), 'g', -1, 64)
+	}
+	return out
+}
+
+// marshalItemToMap converts SchemaItem to AttributeValue map for DynamoDB operations.
+// Internal helper that uses AWS SDK's attributevalue package for safe marshaling.
+func marshalItemToMap(item SchemaItem) (map[string]types.AttributeValue, error) {
+	return attributevalue.MarshalMap(item)
+}
+
+// extractNonKeyAttributes filters out primary key attributes from the attribute map.
+// Used in update operations where key attributes cannot be modified.
+// Returns only non-key attributes for SET/ADD/REMOVE expressions.
+func extractNonKeyAttributes(allAttributes map[string]types.AttributeValue) map[string]types.AttributeValue {
+	updates := make(map[string]types.AttributeValue, len(allAttributes)-2)
+	for attrName, attrValue := range allAttributes {
+		if attrName != TableSchema.HashKey && attrName != TableSchema.RangeKey {
+			updates[attrName] = attrValue
+		}
+	}
+	return updates
+}
+
+// buildUpdateExpression creates SET expression from attribute map.
+// Generates safe attribute names and values to avoid DynamoDB reserved words.
+// Returns expression string, name mappings, and value mappings.
+// Example: "SET #attr0 = :val0, #attr1 = :val1"
+func buildUpdateExpression(updates map[string]types.AttributeValue) (string, map[string]string, map[string]types.AttributeValue) {
+	if len(updates) == 0 {
+		return "", nil, nil
+	}
+	updateParts := make([]string, 0, len(updates))
+	attrNames := make(map[string]string, len(updates))
+	attrValues := make(map[string]types.AttributeValue, len(updates))
+
+	i := 0
+	for attrName, attrValue := range updates {
+		nameKey := fmt.Sprintf("#attr%d", i)
+		valueKey := fmt.Sprintf(":val%d", i)
+
+		updateParts = append(updateParts, fmt.Sprintf("%s = %s", nameKey, valueKey))
+		attrNames[nameKey] = attrName
+		attrValues[valueKey] = attrValue
+		i++
+	}
+	return "SET " + strings.Join(updateParts, ", "), attrNames, attrValues
+}
+
+// mergeExpressionAttributes merges condition attributes into existing expression maps.
+// Safely combines update expression attributes with filter condition attributes.
+// Prevents conflicts between update and condition expression mappings.
+func mergeExpressionAttributes(
+	baseNames map[string]string,
+	baseValues map[string]types.AttributeValue,
+	conditionNames map[string]string,
+	conditionValues map[string]types.AttributeValue,
+) (map[string]string, map[string]types.AttributeValue) {
+	if conditionNames != nil {
+		for key, value := range conditionNames {
+			baseNames[key] = value
+		}
+	}
+	if conditionValues != nil {
+		for key, value := range conditionValues {
+			baseValues[key] = value
+		}
+	}
+	return baseNames, baseValues
+}
+
+// marshalUpdatesWithSchema marshals updates map using schema type information.
+// Provides type-safe marshaling by consulting the table schema for field types.
+// Handles special DynamoDB types (Sets) that require custom marshaling logic.
+// Numbers are validated by checkNumbers.
+func marshalUpdatesWithSchema(updates map[string]any) (map[string]types.AttributeValue, error) {
+	result := make(map[string]types.AttributeValue, len(updates))
+	for fieldName, value := range updates {
+		if fieldInfo, exists := TableSchema.FieldsMap[fieldName]; exists {
+			av, err := marshalValueByType(value, fieldInfo.DynamoType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal field %s: %v", fieldName, err)
+			}
+			result[fieldName] = av
+		} else {
+			// Fallback to generic marshaling for unknown fields
+			av, err := attributevalue.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal field %s: %v", fieldName, err)
+			}
+			result[fieldName] = av
+		}
+	}
+	if err := checkNumbers(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// marshalConditionValues marshals the values of UpdateItemInputWithTypedCondition by placeholder.
+func marshalConditionValues(values map[string]ConditionValue) (map[string]types.AttributeValue, error) {
+	if values == nil {
+		return nil, nil
+	}
+	result := make(map[string]types.AttributeValue, len(values))
+	for placeholder, value := range values {
+		if _, ok := TableSchema.FieldsMap[value.Attribute]; value.Attribute != "" && !ok {
+			return nil, fmt.Errorf("condition value %s: unknown attribute '%s'", placeholder, value.Attribute)
+		}
+		av, err := marshalConditionValue(value.Attribute, value.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal condition value %s: %v", placeholder, err)
+		}
+		result[placeholder] = av
+	}
+	return result, nil
+}
+
+// marshalConditionValue marshals a condition value compared with attr, empty if unknown.
+// A single element compared with a set (contains) is marshaled as an element, not a set.
+func marshalConditionValue(attr string, value any) (types.AttributeValue, error) {
+	if av, ok := value.(types.AttributeValue); ok {
+		return av, nil
+	}
+	fieldInfo, ok := TableSchema.FieldsMap[attr]
+	if !ok {
+		return attributevalue.Marshal(value)
+	}
+	switch fieldInfo.DynamoType {
+	case "SS", "NS", "BS":
+		if reflect.ValueOf(value).Kind() != reflect.Slice {
+			return attributevalue.Marshal(value)
+		}
+		if _, ok := value.([]byte); ok && fieldInfo.DynamoType == "BS" {
+			return attributevalue.Marshal(value)
+		}
+	}
+	return marshalValueByType(value, fieldInfo.DynamoType)
+}
+
+// marshalValueByType marshals value according to specific DynamoDB type.
+// Handles special cases like String Sets (SS) and Number Sets (NS) that require
+// custom marshaling logic not provided by the default AWS SDK marshaler.
+func marshalValueByType(value any, dynamoType string) (types.AttributeValue, error) {
+	switch dynamoType {
+	case "SS":
+		ss, ok := value.([]string)
+		if !ok {
+			return nil, fmt.Errorf("SS: expected []string, got %T", value)
+		}
+		return &types.AttributeValueMemberSS{Value: ss}, nil
+	case "NS":
+		return nil, fmt.Errorf("NS: no numeric set types defined in schema")
+	default:
+		return attributevalue.Marshal(value)
+	}
+}
+
+// NumberRangeError reports a number which DynamoDB or the Go field of its attribute can't hold:
+// more than 38 significant digits, a magnitude outside 1e-130 to 1e126, NaN or infinity,
+// or a value overflowing or losing precision in the field type (e.g. 300 or 1.5 in an int8 field).
+// Marshaling items and updates and unmarshaling items return it instead of failing later.
+type NumberRangeError struct {
+	Attribute string
+	Value     string
+	Type      string // the Go field type, "N" for the DynamoDB number range
+}
+
+// Error implements error.
+func (e *NumberRangeError) Error() string {
+	return fmt.Sprintf("number %s of attribute '%s' doesn't fit %s", e.Value, e.Attribute, e.Type)
+}
+
+// numberFieldTypes maps number and number set attributes to the Go types of their fields.
+var numberFieldTypes = map[string]string{
+	ColumnRequests:  "int",
+	ColumnExpiresAt: "int64",
+}
+
+// checkNumbers validates the numbers of top-level N and NS attributes; nested numbers aren't checked.
+func checkNumbers(av map[string]types.AttributeValue) error {
+	for name, value := range av {
+		switch v := value.(type) {
+		case *types.AttributeValueMemberN:
+			if err := checkNumber(name, v.Value); err != nil {
+				return err
+			}
+		case *types.AttributeValueMemberNS:
+			for _, n := range v.Value {
+				if err := checkNumber(name, n); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkNumber validates a number of the attribute against the DynamoDB range and its field type.
+func checkNumber(attr, n string) error {
+	if !dynamoNumberValid(n) {
+		return &NumberRangeError{Attribute: attr, Value: n, Type: "N"}
+	}
+	if goType, ok := numberFieldTypes[attr]; ok && !numberFits(n, goType) {
+		return &NumberRangeError{Attribute: attr, Value: n, Type: goType}
+	}
+	return nil
+}
+
+// dynamoNumberValid reports whether n is a DynamoDB number: at most 38 significant digits
+// with the first one at a decimal exponent from -130 to 125.
+func dynamoNumberValid(n string) bool {
+	mantissa, exp, hasExp := strings.Cut(strings.ToLower(strings.TrimLeft(n, "+-")), "e")
+	e := 0
+	if hasExp {
+		var err error
+		if e, err = strconv.Atoi(exp); err != nil {
+			return false
+		}
+	}
+	intPart, frac, _ := strings.Cut(mantissa, ".")
+	all := intPart + frac
+	if all == "" || strings.Trim(all, "0123456789") != "" {
+		return false
+	}
+	digits := strings.TrimLeft(all, "0")
+	if digits == "" {
+		return true
+	}
+	exponent := e + len(intPart) - (len(all) - len(digits)) - 1
+	return len(strings.TrimRight(digits, "0")) <= 38 && exponent >= -130 && exponent <= 125
+}
+
+// numberFits reports whether n converts to the Go type without overflow or loss of precision.
+func numberFits(n, goType string) bool {
+	bits, _ := strconv.Atoi(strings.TrimLeft(goType, "intufloa"))
+	var err error
+	switch {
+	case strings.HasPrefix(goType, "float"):
+		_, err = strconv.ParseFloat(n, bits)
+	case strings.HasPrefix(goType, "uint"):
+		_, err = strconv.ParseUint(n, 10, bits)
+	default:
+		_, err = strconv.ParseInt(n, 10, bits)
+	}
+	return err == nil
+}
+
+// EmptyValuePolicy decides how generated writes handle values DynamoDB rejects:
+// empty sets, and empty strings and binaries of index key attributes.
+// Empty primary key attributes are always an error.
+type EmptyValuePolicy int
+
+const (
+	// EmptyOmit leaves empty values out of puts and removes their attributes in updates.
+	EmptyOmit EmptyValuePolicy = iota
+
+	// EmptyNull stores empty sets as NULL. Empty index keys are omitted, key attributes can't be NULL.
+	EmptyNull
+
+	// EmptyError rejects writes with empty values with an error matching ErrEmptyValue.
+	EmptyError
+)
+
+// EmptyValues is the policy applied by ItemInput, UpdateItemInput and UpdateItemInputFromRaw.
+// Set it at startup.
+// Example:
+//
+//	EmptyValues = EmptyError
+var EmptyValues = EmptyOmit
+
+// ErrEmptyValue is returned for empty values of writes rejected by the policy and for empty primary keys.
+var ErrEmptyValue = errors.New("empty value")
+
+// applyEmptyValues applies EmptyValues to the marshaled attributes of a write
+// and returns the omitted attributes, which updates remove.
+func applyEmptyValues(av map[string]types.AttributeValue) ([]string, error) {
+	var omitted []string
+	for _, name := range slices.Sorted(maps.Keys(av)) {
+		primaryKey := name == TableSchema.HashKey || name == TableSchema.RangeKey
+		indexKey := isIndexKeyAttribute(name)
+		if !isEmptyValue(av[name], primaryKey || indexKey) {
+			continue
+		}
+		switch {
+		case primaryKey:
+			return nil, fmt.Errorf("%w: primary key attribute %s", ErrEmptyValue, name)
+		case EmptyValues == EmptyError:
+			return nil, fmt.Errorf("%w: attribute %s", ErrEmptyValue, name)
+		case EmptyValues == EmptyNull && !indexKey:
+			av[name] = &types.AttributeValueMemberNULL{Value: true}
+		default:
+			delete(av, name)
+			omitted = append(omitted, name)
+		}
+	}
+	return omitted, nil
+}
+
+// isEmptyValue reports whether DynamoDB rejects the value: an empty set,
+// or an empty string or binary of a key attribute.
+func isEmptyValue(value types.AttributeValue, key bool) bool {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberSS:
+		return len(v.Value) == 0
+	case *types.AttributeValueMemberNS:
+		return len(v.Value) == 0
+	case *types.AttributeValueMemberBS:
+		return len(v.Value) == 0
+	case *types.AttributeValueMemberS:
+		return key && v.Value == ""
+	case *types.AttributeValueMemberB:
+		return key && len(v.Value) == 0
+	}
+	return false
+}
+
+// removeAttributes adds the removal of the attributes to an update.
+func removeAttributes(input *dynamodb.UpdateItemInput, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	if input.ExpressionAttributeNames == nil {
+		input.ExpressionAttributeNames = map[string]string{}
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		nameKey := fmt.Sprintf("#rm%d", i)
+		input.ExpressionAttributeNames[nameKey] = name
+		parts[i] = nameKey
+	}
+	remove := strings.Join(parts, ", ")
+	updateExpression := aws.ToString(input.UpdateExpression)
+	if strings.Contains(updateExpression, "REMOVE ") {
+		updateExpression = strings.Replace(updateExpression, "REMOVE ", "REMOVE "+remove+", ", 1)
+	} else {
+		updateExpression = strings.TrimSpace(updateExpression + " REMOVE " + remove)
+	}
+	input.UpdateExpression = aws.String(updateExpression)
+}
+
+// validateKeyPart checks if key part (hash or range) value is valid for DynamoDB.
+// Hash keys are required and cannot be nil/empty, range keys are optional.
+// Supports string, numeric and binary types used as DynamoDB keys.
+func validateKeyPart(partName string, value any) error {
+	if value == nil {
+		if partName == "hash" {
+			return fmt.Errorf("hash key cannot be nil")
+		}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		if v == "" && partName == "hash" {
+			return fmt.Errorf("hash key string cannot be empty")
+		}
+	case []byte:
+		if len(v) == 0 && partName == "hash" {
+			return fmt.Errorf("hash key binary cannot be empty")
+		}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+	case float32, float64:
+	default:
+		return fmt.Errorf("unsupported %s key type: %T", partName, value)
+	}
+	return nil
+}
+
+// validateHashKey checks if hash key value is valid for DynamoDB operations.
+func validateHashKey(value any) error {
+	return validateKeyPart("hash", value)
+}
+
+// validateRangeKey checks if range key value is valid (nil is allowed).
+func validateRangeKey(value any) error {
+	return validateKeyPart("range", value)
+}
+
+// validateAttributeName checks if attribute name meets DynamoDB requirements.
+func validateAttributeName(name string) error {
+	if name == "" {
+		return fmt.Errorf("attribute name cannot be empty")
+	}
+	if len(name) > 255 {
+		return fmt.Errorf("attribute name too long: %d chars (max 255)", len(name))
+	}
+	return nil
+}
+
+// validateUpdatesMap checks if updates map is valid for UpdateItem operations.
+func validateUpdatesMap(updates map[string]any) error {
+	if len(updates) == 0 {
+		return fmt.Errorf("updates map cannot be empty")
+	}
+	for attrName, value := range updates {
+		if err := validateAttributeName(attrName); err != nil {
+			return fmt.Errorf("invalid attribute name '%s': %v", attrName, err)
+		}
+		if value == nil {
+			return fmt.Errorf("update value for '%s' cannot be nil", attrName)
+		}
+	}
+	return nil
+}
+
+// validateBatchSize checks if batch size is within DynamoDB limits.
+func validateBatchSize(size int, operation string) error {
+	if size == 0 {
+		return fmt.Errorf("%s batch cannot be empty", operation)
+	}
+	if size > 25 {
+		return fmt.Errorf("%s batch size %d exceeds DynamoDB limit of 25", operation, size)
+	}
+	return nil
+}
+
+// validateSetValues checks if set values are valid for AddToSet/RemoveFromSet operations.
+func validateSetValues(values any) error {
+	if values == nil {
+		return fmt.Errorf("set values cannot be nil")
+	}
+	switch v := values.(type) {
+	case []string:
+		if len(v) == 0 {
+			return fmt.Errorf("string set cannot be empty")
+		}
+		for i, str := range v {
+			if str == "" {
+				return fmt.Errorf("string set item %d cannot be empty", i)
+			}
+		}
+	case [][]byte:
+		if len(v) == 0 {
+			return fmt.Errorf("binary set cannot be empty")
+		}
+		for i, b := range v {
+			if len(b) == 0 {
+				return fmt.Errorf("binary set item %d cannot be empty", i)
+			}
+		}
+	case []int, []int8, []int16, []int32, []int64, []uint, []uint8, []uint16, []uint32, []uint64, []float32, []float64:
+		rv := reflect.ValueOf(v)
+		if rv.Len() == 0 {
+			return fmt.Errorf("number set cannot be empty")
+		}
+	default:
+		return fmt.Errorf("unsupported set type: %T, expected []string, [][]byte or numeric slice", values)
+	}
+	return nil
+}
+
+// validateConditionExpression checks if condition expression meets DynamoDB limits.
+func validateConditionExpression(expr string) error {
+	if expr == "" {
+		return fmt.Errorf("condition expression cannot be empty")
+	}
+	if len(expr) > 4096 {
+		return fmt.Errorf("condition expression too long: %d chars (max 4096)", len(expr))
+	}
+	return nil
+}
+
+// validateIncrementValue checks if increment value is valid for atomic operations.
+func validateIncrementValue(value int) error {
+	// DynamoDB supports any int value for ADD operation
+	// No specific validation needed, but we keep the function for consistency
+	return nil
+}
+
+// validateKeyInputs validates both hash and range key inputs for DynamoDB operations.
+func validateKeyInputs(hashKeyValue, rangeKeyValue any) error {
+	if err := validateHashKey(hashKeyValue); err != nil {
+		return fmt.Errorf("invalid hash key: %v", err)
+	}
+	if err := validateRangeKey(rangeKeyValue); err != nil {
+		return fmt.Errorf("invalid range key: %v", err)
+	}
+	return nil
+}